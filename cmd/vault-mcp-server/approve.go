@@ -0,0 +1,166 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	approveCmd = &cobra.Command{
+		Use:   "approve <approval-id>",
+		Short: "Approve a queued tool call",
+		Long: `Approve a tool call queued for approval by a running server, which then runs the call on behalf
+of the session that made it and notifies the session of the result.
+The approval token is read from MCP_APPROVAL_TOKEN.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return decideApproval(cmd, args[0], "approve")
+		},
+	}
+
+	denyCmd = &cobra.Command{
+		Use:   "deny <approval-id>",
+		Short: "Deny a queued tool call",
+		Long: `Deny a tool call queued for approval by a running server. The call does not run and the session
+that made it is notified. The approval token is read from MCP_APPROVAL_TOKEN.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return decideApproval(cmd, args[0], "deny")
+		},
+	}
+
+	approvalsCmd = &cobra.Command{
+		Use:   "approvals",
+		Short: "List the tool calls queued for approval",
+		Long: `List the tool calls queued for approval by a running server and the recent decisions.
+The approval token is read from MCP_APPROVAL_TOKEN.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			body, err := approvalRequest(cmd, http.MethodGet, "/approvals")
+			if err != nil {
+				return err
+			}
+			var approvals []client.Approval
+			if err := json.Unmarshal(body, &approvals); err != nil {
+				return fmt.Errorf("invalid response from the approval endpoint: %w", err)
+			}
+			if len(approvals) == 0 {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No tool calls queued for approval")
+				return nil
+			}
+			for _, approval := range approvals {
+				printApproval(cmd.OutOrStdout(), approval)
+			}
+			return nil
+		},
+	}
+)
+
+// approvalFlags adds the flags shared by the approval commands
+func approvalFlags(cmd *cobra.Command) {
+	cmd.Flags().String("server", defaultApprovalServer(), "URL of the approval endpoint of the server (env: MCP_APPROVAL_ADDR)")
+	cmd.Flags().String("approver", os.Getenv("USER"), "Name of the approver, recorded with the decision")
+	cmd.Flags().Duration("timeout", 5*time.Minute, "How long to wait for the approved tool call to complete")
+}
+
+// defaultApprovalServer returns the URL of the approval endpoint the server listens on by default
+func defaultApprovalServer() string {
+	address := os.Getenv(client.ApprovalAddressEnv)
+	if address == "" {
+		address = client.DefaultApprovalAddress
+	}
+	if strings.Contains(address, "://") {
+		return address
+	}
+	return "http://" + address
+}
+
+func decideApproval(cmd *cobra.Command, id string, decision string) error {
+	body, err := approvalRequest(cmd, http.MethodPost, "/approvals/"+url.PathEscape(id)+"/"+decision)
+	if err != nil {
+		return err
+	}
+	var approval client.Approval
+	if err := json.Unmarshal(body, &approval); err != nil {
+		return fmt.Errorf("invalid response from the approval endpoint: %w", err)
+	}
+	printApproval(cmd.OutOrStdout(), approval)
+	if approval.Status == client.ApprovalFailed {
+		return fmt.Errorf("tool call %s failed: %s", approval.ID, approval.Error)
+	}
+	return nil
+}
+
+// approvalRequest sends a request to the approval endpoint and returns the body of a successful response
+func approvalRequest(cmd *cobra.Command, method string, path string) ([]byte, error) {
+	token := os.Getenv(client.ApprovalTokenEnv)
+	if token == "" {
+		return nil, fmt.Errorf("%s must be set to the approval token of the server", client.ApprovalTokenEnv)
+	}
+	serverURL, err := cmd.Flags().GetString("server")
+	if err != nil {
+		return nil, err
+	}
+	approver, err := cmd.Flags().GetString("approver")
+	if err != nil {
+		return nil, err
+	}
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(cmd.Context(), method, strings.TrimSuffix(serverURL, "/")+path, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if approver != "" {
+		req.Header.Set("X-Approver", approver)
+	}
+
+	resp, err := (&http.Client{Timeout: timeout}).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach the approval endpoint: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Error != "" {
+			return nil, fmt.Errorf("approval endpoint returned %d: %s", resp.StatusCode, apiErr.Error)
+		}
+		return nil, fmt.Errorf("approval endpoint returned %d", resp.StatusCode)
+	}
+	return body, nil
+}
+
+func printApproval(out io.Writer, approval client.Approval) {
+	_, _ = fmt.Fprintf(out, "%s  %-8s  %s  session %s  created %s\n", approval.ID, approval.Status, approval.Tool,
+		approval.SessionID, approval.CreatedAt.Format(time.RFC3339))
+	if len(approval.Arguments) > 0 {
+		args, _ := json.Marshal(approval.Arguments)
+		_, _ = fmt.Fprintf(out, "    arguments: %s\n", args)
+	}
+	if approval.Error != "" {
+		_, _ = fmt.Fprintf(out, "    error: %s\n", approval.Error)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/devvault"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+)
+
+// startDevVault starts and seeds a Vault dev server when --dev-vault or MCP_DEV_VAULT is set, and points
+// the server at it. The returned function stops the dev server.
+func startDevVault(flags *pflag.FlagSet, logger *log.Logger) (func(), error) {
+	enabled, err := boolFlagOrEnv(flags, "dev-vault", "MCP_DEV_VAULT")
+	if err != nil || !enabled {
+		return func() {}, err
+	}
+
+	s, err := devvault.Start(context.Background(), logger)
+	if err != nil {
+		return nil, err
+	}
+	vault, err := s.Client()
+	if err != nil {
+		s.Stop()
+		return nil, err
+	}
+	done, err := devvault.Seed(context.Background(), vault)
+	if err != nil {
+		s.Stop()
+		return nil, err
+	}
+	for _, step := range done {
+		logger.WithField("step", step).Info("Seeded Vault dev server")
+	}
+
+	// Sessions reach the dev server through the same settings as a regular Vault
+	for name, value := range map[string]string{
+		client.VaultAddress:      s.Address,
+		client.VaultToken:        s.Token,
+		client.VaultNamespace:    "",
+		client.VaultProxyAddress: "",
+		client.VaultReadAddress:  "",
+	} {
+		if err := os.Setenv(name, value); err != nil {
+			s.Stop()
+			return nil, err
+		}
+	}
+
+	// The standard output belongs to the stdio transport
+	_, _ = fmt.Fprintf(os.Stderr, "Vault dev server running at %s with root token %s, its data is discarded on exit\n", s.Address, s.Token)
+	return s.Stop, nil
+}
@@ -0,0 +1,79 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/doctor"
+	"github.com/spf13/cobra"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the configuration and the connection to Vault",
+	Long: `Check the configuration of the server from its environment variables, its TLS, CORS and session
+settings, the connection to Vault, and the validity and capabilities of the Vault token, then print a
+readiness report. The command exits with a non-zero status when a check fails.`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true, // main prints the error
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		transport, err := cmd.Flags().GetString("transport")
+		if err != nil {
+			return err
+		}
+		host, err := cmd.Flags().GetString("transport-host")
+		if err != nil {
+			return err
+		}
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		timeout, err := cmd.Flags().GetDuration("timeout")
+		if err != nil {
+			return err
+		}
+
+		var opts doctor.Options
+		switch transport {
+		case "stdio":
+		case "streamable-http", "http":
+			opts.HTTP = true
+			opts.Host = host
+		default:
+			return fmt.Errorf("unknown transport '%s', use stdio or streamable-http", transport)
+		}
+		opts.Timeout = timeout
+
+		report := doctor.Run(cmd.Context(), opts)
+		switch format {
+		case "json":
+			encoder := json.NewEncoder(cmd.OutOrStdout())
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(report); err != nil {
+				return err
+			}
+		case "text":
+			report.WriteText(cmd.OutOrStdout())
+		default:
+			return fmt.Errorf("unknown format '%s', use text or json", format)
+		}
+
+		if !report.Ready {
+			return fmt.Errorf("one or more checks failed")
+		}
+		return nil
+	},
+}
+
+// defaultDoctorTransport returns the transport the server would start with in this environment
+func defaultDoctorTransport() string {
+	if shouldUseHTTPMode() {
+		return "streamable-http"
+	}
+	return "stdio"
+}
@@ -4,15 +4,16 @@
 package main
 
 import (
-	"context"
 	"fmt"
-	"io"
-	stdlog "log"
 	"os"
+	"strconv"
+	"time"
 
-	"github.com/mark3labs/mcp-go/server"
+	"github.com/hashicorp/vault-mcp-server/pkg/service"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
@@ -20,6 +21,11 @@ func init() {
 	cobra.OnInitialize(initConfig)
 	rootCmd.SetVersionTemplate("{{.Short}}\n{{.Version}}\n")
 	rootCmd.PersistentFlags().String("log-file", "", "Path to log file")
+	rootCmd.PersistentFlags().Int("log-max-size", 0, "Rotate the log file once it reaches this many megabytes, 0 to disable (env: MCP_LOG_MAX_SIZE)")
+	rootCmd.PersistentFlags().Duration("log-rotate-interval", 0, "Rotate the log file after this long, such as 24h, 0 to disable (env: MCP_LOG_ROTATE_INTERVAL)")
+	rootCmd.PersistentFlags().Int("log-max-backups", 0, "Number of rotated log files to keep, 0 to keep all (env: MCP_LOG_MAX_BACKUPS)")
+	rootCmd.PersistentFlags().Duration("log-max-age", 0, "Delete rotated log files older than this, such as 720h, 0 to keep them (env: MCP_LOG_MAX_AGE)")
+	rootCmd.PersistentFlags().Bool("dev-vault", false, "Run the server against a seeded in-memory Vault dev server, which requires the vault binary (env: MCP_DEV_VAULT)")
 
 	// Add StreamableHTTP command flags (avoid 'h' shorthand conflict with help)
 	streamableHTTPCmd.Flags().String("transport-host", DefaultBindAddress, "Host to bind to")
@@ -31,16 +37,54 @@ func init() {
 	httpCmdAlias.Flags().StringP("transport-port", "p", DefaultBindPort, "Port to listen on")
 	httpCmdAlias.Flags().String("mcp-endpoint", DefaultEndPointPath, "Path for streamable HTTP endpoint")
 
+	// Add gRPC command flags
+	grpcCmd.Flags().String("transport-host", DefaultBindAddress, "Host to bind to")
+	grpcCmd.Flags().StringP("transport-port", "p", DefaultGRPCPort, "Port to listen on")
+
+	// Add the flags of the approval commands
+	approvalFlags(approveCmd)
+	approvalFlags(denyCmd)
+	approvalFlags(approvalsCmd)
+
+	// Add doctor command flags, which default to the transport selected by the environment
+	doctorCmd.Flags().String("transport", defaultDoctorTransport(), "Transport to check the configuration for: stdio or streamable-http")
+	doctorCmd.Flags().String("transport-host", getHTTPHost(), "Host the StreamableHTTP server binds to")
+	doctorCmd.Flags().String("format", "text", "Output format: text or json")
+	doctorCmd.Flags().Duration("timeout", 10*time.Second, "Timeout of each request to Vault")
+
+	// Add tools list command flags
+	toolsListCmd.Flags().String("format", "json", "Output format: json or markdown")
+	toolsListCmd.Flags().String("tenant", "", "Only list the tools allowed for this tenant profile of MCP_TENANCY_CONFIG_FILE")
+	toolsListCmd.Flags().Bool("read-only", false, "Only list the tools annotated as read-only")
+	toolsCmd.AddCommand(toolsListCmd)
+
+	// Add service command flags, the name is shared by all subcommands
+	serviceCmd.PersistentFlags().String("name", service.DefaultName, "Name of the Windows service or launchd agent")
+	for _, cmd := range []*cobra.Command{serviceInstallCmd, serviceRunCmd} {
+		cmd.Flags().String("transport-host", DefaultBindAddress, "Host to bind to")
+		cmd.Flags().StringP("transport-port", "p", DefaultBindPort, "Port to listen on")
+		cmd.Flags().String("mcp-endpoint", DefaultEndPointPath, "Path for streamable HTTP endpoint")
+	}
+	serviceInstallCmd.Flags().StringArray("env", nil, "Environment variable of the server as KEY=VALUE, or KEY to copy it from the current environment, can be repeated")
+	serviceCmd.AddCommand(serviceInstallCmd, serviceUninstallCmd, serviceStartCmd, serviceStopCmd, serviceStatusCmd, serviceRunCmd)
+
 	rootCmd.AddCommand(stdioCmd)
 	rootCmd.AddCommand(streamableHTTPCmd)
 	rootCmd.AddCommand(httpCmdAlias) // Add the alias for backward compatibility
+	rootCmd.AddCommand(grpcCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(toolsCmd)
+	rootCmd.AddCommand(approveCmd)
+	rootCmd.AddCommand(denyCmd)
+	rootCmd.AddCommand(approvalsCmd)
+	rootCmd.AddCommand(serviceCmd)
 }
 
 func initConfig() {
 	viper.AutomaticEnv()
 }
 
-func initLogger(outPath string) (*log.Logger, error) {
+func initLogger(outPath string, rotation utils.LogRotation) (*log.Logger, error) {
 	logger := log.New()
 	logger.SetLevel(log.DebugLevel)
 
@@ -48,38 +92,72 @@ func initLogger(outPath string) (*log.Logger, error) {
 		return logger, nil
 	}
 
-	file, err := os.OpenFile(outPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	file, err := utils.OpenRotatingFile(outPath, rotation)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open log file: %w", err)
+		return nil, err
 	}
 
 	logger.SetOutput(file)
 	return logger, nil
 }
 
-func serverInit(ctx context.Context, hcServer *server.MCPServer, logger *log.Logger) error {
-	stdioServer := server.NewStdioServer(hcServer)
-	stdLogger := stdlog.New(logger.Writer(), "stdioserver", 0)
-	stdioServer.SetErrorLogger(stdLogger)
-
-	// Start listening for messages
-	errC := make(chan error, 1)
-	go func() {
-		in, out := io.Reader(os.Stdin), io.Writer(os.Stdout)
-		errC <- stdioServer.Listen(ctx, in, out)
-	}()
-
-	_, _ = fmt.Fprintf(os.Stderr, "Vault MCP Server running on stdio\n")
-
-	// Wait for shutdown signal
-	select {
-	case <-ctx.Done():
-		logger.Infof("shutting down server...")
-	case err := <-errC:
+// logRotationConfig reads the rotation of the log file from the flags, falling back to the environment
+// variables when a flag is not set, such as when the HTTP mode is selected through the environment
+func logRotationConfig(flags *pflag.FlagSet) (utils.LogRotation, error) {
+	maxSize, err := intFlagOrEnv(flags, "log-max-size", "MCP_LOG_MAX_SIZE")
+	if err != nil {
+		return utils.LogRotation{}, err
+	}
+	interval, err := durationFlagOrEnv(flags, "log-rotate-interval", "MCP_LOG_ROTATE_INTERVAL")
+	if err != nil {
+		return utils.LogRotation{}, err
+	}
+	maxBackups, err := intFlagOrEnv(flags, "log-max-backups", "MCP_LOG_MAX_BACKUPS")
+	if err != nil {
+		return utils.LogRotation{}, err
+	}
+	maxAge, err := durationFlagOrEnv(flags, "log-max-age", "MCP_LOG_MAX_AGE")
+	if err != nil {
+		return utils.LogRotation{}, err
+	}
+
+	return utils.LogRotation{
+		MaxSize:    int64(maxSize) * 1024 * 1024,
+		Interval:   interval,
+		MaxBackups: maxBackups,
+		MaxAge:     maxAge,
+	}, nil
+}
+
+func intFlagOrEnv(flags *pflag.FlagSet, name string, env string) (int, error) {
+	if value := os.Getenv(env); value != "" && !flags.Changed(name) {
+		parsed, err := strconv.Atoi(value)
 		if err != nil {
-			return fmt.Errorf("error running server: %w", err)
+			return 0, fmt.Errorf("invalid %s: %w", env, err)
 		}
+		return parsed, nil
 	}
+	return flags.GetInt(name)
+}
 
-	return nil
+func durationFlagOrEnv(flags *pflag.FlagSet, name string, env string) (time.Duration, error) {
+	if value := os.Getenv(env); value != "" && !flags.Changed(name) {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s: %w", env, err)
+		}
+		return parsed, nil
+	}
+	return flags.GetDuration(name)
+}
+
+func boolFlagOrEnv(flags *pflag.FlagSet, name string, env string) (bool, error) {
+	if value := os.Getenv(env); value != "" && !flags.Changed(name) {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return false, fmt.Errorf("invalid %s: %w", env, err)
+		}
+		return parsed, nil
+	}
+	return flags.GetBool(name)
 }
@@ -5,23 +5,19 @@ package main
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	stdlog "log"
-	"net/http"
+	"net"
 	"os"
 	"os/signal"
-	"path"
-	"strings"
 	"syscall"
-	"time"
 
 	"github.com/hashicorp/vault-mcp-server/pkg/client"
-	"github.com/hashicorp/vault-mcp-server/pkg/tools"
+	"github.com/hashicorp/vault-mcp-server/pkg/mcpserver"
+	"github.com/hashicorp/vault-mcp-server/pkg/service"
 
 	"github.com/hashicorp/vault-mcp-server/version"
 
-	"github.com/mark3labs/mcp-go/server"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -30,6 +26,7 @@ const (
 	DefaultBindAddress  = "127.0.0.1"
 	DefaultBindPort     = "8080"
 	DefaultEndPointPath = "/mcp"
+	DefaultGRPCPort     = "8091"
 )
 
 var (
@@ -50,12 +47,22 @@ var (
 			if err != nil {
 				stdlog.Fatal("Failed to get log file:", err)
 			}
-			logger, err := initLogger(logFile)
+			rotation, err := logRotationConfig(rootCmd.PersistentFlags())
+			if err != nil {
+				stdlog.Fatal("Failed to get log rotation:", err)
+			}
+			logger, err := initLogger(logFile, rotation)
 			if err != nil {
 				stdlog.Fatal("Failed to initialize logger:", err)
 			}
 
-			if err := runStdioServer(logger); err != nil {
+			stopDevVault, err := startDevVault(rootCmd.PersistentFlags(), logger)
+			if err != nil {
+				stdlog.Fatal("Failed to start Vault dev server:", err)
+			}
+			err = runStdioServer(logger)
+			stopDevVault()
+			if err != nil {
 				stdlog.Fatal("failed to run stdio server:", err)
 			}
 		},
@@ -72,7 +79,11 @@ You can specify the host, port, and endpoint path to customize where the server
 			if err != nil {
 				stdlog.Fatal("Failed to get log file:", err)
 			}
-			logger, err := initLogger(logFile)
+			rotation, err := logRotationConfig(rootCmd.PersistentFlags())
+			if err != nil {
+				stdlog.Fatal("Failed to get log rotation:", err)
+			}
+			logger, err := initLogger(logFile, rotation)
 			if err != nil {
 				stdlog.Fatal("Failed to initialize logger:", err)
 			}
@@ -91,7 +102,13 @@ You can specify the host, port, and endpoint path to customize where the server
 				stdlog.Fatal("Failed to get endpoint path:", err)
 			}
 
-			if err := runHTTPServer(logger, host, port, endpointPath); err != nil {
+			stopDevVault, err := startDevVault(rootCmd.PersistentFlags(), logger)
+			if err != nil {
+				stdlog.Fatal("Failed to start Vault dev server:", err)
+			}
+			err = runHTTPServer(context.Background(), logger, host, port, endpointPath)
+			stopDevVault()
+			if err != nil {
 				stdlog.Fatal("failed to run streamableHTTP server:", err)
 			}
 		},
@@ -108,167 +125,140 @@ You can specify the host, port, and endpoint path to customize where the server
 			streamableHTTPCmd.Run(cmd, args)
 		},
 	}
-)
-
-func runHTTPServer(logger *log.Logger, host string, port string, endpointPath string) error {
-	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
-	defer stop()
-
-	hcServer := NewServer(version.Version, logger)
-	tools.InitTools(hcServer, logger)
 
-	return httpServerInit(ctx, hcServer, logger, host, port, endpointPath)
-}
+	grpcCmd = &cobra.Command{
+		Use:   "grpc",
+		Short: "Start gRPC server (experimental)",
+		Long: `Start a server that communicates using the experimental gRPC transport.
+Each bidirectional stream of the Session method is an MCP session, carrying one JSON-RPC message per
+google.protobuf.BytesValue. The Vault settings of a session are taken from the stream metadata.`,
+		Run: func(cmd *cobra.Command, _ []string) {
+			logFile, err := rootCmd.PersistentFlags().GetString("log-file")
+			if err != nil {
+				stdlog.Fatal("Failed to get log file:", err)
+			}
+			rotation, err := logRotationConfig(rootCmd.PersistentFlags())
+			if err != nil {
+				stdlog.Fatal("Failed to get log rotation:", err)
+			}
+			logger, err := initLogger(logFile, rotation)
+			if err != nil {
+				stdlog.Fatal("Failed to initialize logger:", err)
+			}
 
-func httpServerInit(ctx context.Context, hcServer *server.MCPServer, logger *log.Logger, host string, port string, endpointPath string) error {
-	// Ensure endpoint path starts with /
-	endpointPath = path.Join("/", endpointPath)
-	// Create StreamableHTTP server which implements the new streamable-http transport
-	// This is the modern MCP transport that supports both direct HTTP responses and SSE streams
-	opts := []server.StreamableHTTPOption{
-		server.WithEndpointPath(endpointPath),
-		server.WithLogger(logger),
-	}
+			port, err := cmd.Flags().GetString("transport-port")
+			if err != nil {
+				stdlog.Fatal("Failed to get gRPC port:", err)
+			}
+			host, err := cmd.Flags().GetString("transport-host")
+			if err != nil {
+				stdlog.Fatal("Failed to get gRPC host:", err)
+			}
 
-	// Load TLS configuration
-	tlsConfig, err := client.GetTLSConfigFromEnv()
-	if err != nil {
-		return fmt.Errorf("TLS configuration error: %w", err)
-	}
-	if tlsConfig != nil {
-		opts = append(opts, server.WithTLSCert(tlsConfig.CertFile, tlsConfig.KeyFile))
+			stopDevVault, err := startDevVault(rootCmd.PersistentFlags(), logger)
+			if err != nil {
+				stdlog.Fatal("Failed to start Vault dev server:", err)
+			}
+			err = runGRPCServer(context.Background(), logger, host, port)
+			stopDevVault()
+			if err != nil {
+				stdlog.Fatal("failed to run gRPC server:", err)
+			}
+		},
 	}
+)
 
-	// Log the endpoint path being used
-	logger.Infof("Using endpoint path: %s", endpointPath)
-
-	// Create StreamableHTTP server which implements the new streamable-http transport
-	// This is the modern MCP transport that supports both direct HTTP responses and SSE streams
-	baseStreamableServer := server.NewStreamableHTTPServer(hcServer, opts...)
-
-	// Load CORS configuration
-	corsConfig := client.LoadCORSConfigFromEnv()
-
-	// Log CORS configuration
-	logger.Infof("CORS Mode: %s", corsConfig.Mode)
-	if len(corsConfig.AllowedOrigins) > 0 {
-		logger.Infof("Allowed Origins: %s", strings.Join(corsConfig.AllowedOrigins, ", "))
-	} else if corsConfig.Mode == "strict" {
-		logger.Warnf("No allowed origins configured in strict mode. All cross-origin requests will be rejected.")
-	} else if corsConfig.Mode == "development" {
-		logger.Infof("Development mode: localhost origins are automatically allowed")
-	} else if corsConfig.Mode == "disabled" {
-		logger.Warnf("CORS validation is disabled. This is not recommended for production.")
-	}
+// runHTTPServer runs the StreamableHTTP server until ctx is cancelled or the process is interrupted
+func runHTTPServer(ctx context.Context, logger *log.Logger, host string, port string, endpointPath string) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// Create a security wrapper around the streamable server
-	streamableServer := client.NewSecurityHandler(baseStreamableServer, corsConfig.AllowedOrigins, corsConfig.Mode, logger)
+	s := mcpserver.New(mcpserver.Config{Version: version.Version, Logger: logger})
+	serveApprovals(ctx, s, logger)
+	serveAdmin(ctx, s, logger)
+	runBackground(ctx, s, logger)
+	return s.ListenAndServe(ctx, host, port, endpointPath)
+}
 
-	mux := http.NewServeMux()
+// runGRPCServer runs the gRPC server until ctx is cancelled or the process is interrupted
+func runGRPCServer(ctx context.Context, logger *log.Logger, host string, port string) error {
+	if err := checkApprovalAddress(host, port); err != nil {
+		return err
+	}
 
-	// Apply middleware
-	streamableServer = client.VaultContextMiddleware(logger)(streamableServer)
-	streamableServer = client.LoggingMiddleware(logger)(streamableServer)
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// Handle the /mcp endpoint with the streamable server (with security wrapper)
-	mux.Handle(endpointPath, streamableServer)
-	mux.Handle(endpointPath+"/", streamableServer)
+	s := mcpserver.New(mcpserver.Config{Version: version.Version, Logger: logger})
+	serveApprovals(ctx, s, logger)
+	serveAdmin(ctx, s, logger)
+	runBackground(ctx, s, logger)
+	return s.ListenAndServeGRPC(ctx, host, port)
+}
 
-	// Add health check endpoint
-	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		response := fmt.Sprintf(`{"status":"ok","service":"vault-mcp-server","transport":"streamable-http","endpoint":"%s"}`, endpointPath)
-		if _, err := w.Write([]byte(response)); err != nil {
-			logger.WithError(err).Error("Failed to write health check response")
-		}
-	})
-
-	addr := fmt.Sprintf("%s:%s", host, port)
-	httpServer := &http.Server{
-		Addr:              addr,
-		Handler:           mux,
-		ReadTimeout:       30 * time.Second,
-		ReadHeaderTimeout: 30 * time.Second,
-		WriteTimeout:      30 * time.Second,
-		IdleTimeout:       60 * time.Minute, // Set to 60 minutes to support long-lived connections
+// checkApprovalAddress fails when the approval endpoint would listen on the same port as the gRPC server, on
+// the same host or on all interfaces
+func checkApprovalAddress(host string, port string) error {
+	address := client.LoadApprovalConfigFromEnv().Address
+	approvalHost, approvalPort, err := net.SplitHostPort(address)
+	if err != nil || approvalPort != port {
+		return nil
 	}
-
-	if tlsConfig != nil {
-		httpServer.TLSConfig = tlsConfig.Config
-		logger.Infof("TLS enabled with certificate: %s", tlsConfig.CertFile)
-	} else {
-		if !client.IsLocalHost(host) {
-			return fmt.Errorf("TLS is required for non-localhost binding (%s). Set MCP_TLS_CERT_FILE and MCP_TLS_KEY_FILE environment variables", host)
-		}
-		logger.Warnf("TLS is disabled on StreamableHTTP server; this is not recommended for production")
+	if approvalHost == host || isUnspecifiedHost(approvalHost) || isUnspecifiedHost(host) {
+		return fmt.Errorf("the gRPC server and the approval endpoint (%s %s) cannot both listen on port %s", client.ApprovalAddressEnv, address, port)
 	}
+	return nil
+}
 
-	// Start server in goroutine
-	errC := make(chan error, 1)
-	go func() {
-		logger.Infof("Starting StreamableHTTP server on %s%s", addr, endpointPath)
-		errC <- httpServer.ListenAndServe()
-	}()
-
-	// Wait for shutdown signal
-	select {
-	case <-ctx.Done():
-		logger.Infof("Shutting down StreamableHTTP server...")
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		return httpServer.Shutdown(shutdownCtx)
-	case err := <-errC:
-		if err != nil && !errors.Is(err, http.ErrServerClosed) {
-			return fmt.Errorf("StreamableHTTP server error: %w", err)
-		}
+// isUnspecifiedHost reports whether a listener on host binds all interfaces
+func isUnspecifiedHost(host string) bool {
+	if host == "" {
+		return true
 	}
-
-	return nil
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsUnspecified()
 }
 
 func runStdioServer(logger *log.Logger) error {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	hcServer := NewServer(version.Version, logger)
-	tools.InitTools(hcServer, logger)
+	s := mcpserver.New(mcpserver.Config{Version: version.Version, Logger: logger})
+	serveApprovals(ctx, s, logger)
+	serveAdmin(ctx, s, logger)
+	runBackground(ctx, s, logger)
+	_, _ = fmt.Fprintf(os.Stderr, "Vault MCP Server running on stdio\n")
+	return s.ServeStdio(ctx)
+}
 
-	return serverInit(ctx, hcServer, logger)
+// serveApprovals serves the approval endpoint next to the MCP transport. Tool calls that require approval
+// stay queued until they expire if it fails.
+func serveApprovals(ctx context.Context, s *mcpserver.Server, logger *log.Logger) {
+	go func() {
+		if err := s.ServeApprovals(ctx); err != nil {
+			logger.WithError(err).Error("Failed to serve the approval endpoint")
+		}
+	}()
 }
 
-func NewServer(version string, logger *log.Logger, opts ...server.ServerOption) *server.MCPServer {
-	// Create rate limiting middleware with environment-based configuration
-	rateLimitConfig := client.LoadRateLimitConfigFromEnv()
-	rateLimitMiddleware := client.NewRateLimitMiddleware(rateLimitConfig, logger)
+// serveAdmin serves the operational endpoints on the admin listener next to the MCP transport, when one is
+// configured
+func serveAdmin(ctx context.Context, s *mcpserver.Server, logger *log.Logger) {
+	go func() {
+		if err := s.ServeAdmin(ctx); err != nil {
+			logger.WithError(err).Error("Failed to serve the admin listener")
+		}
+	}()
+}
 
-	// Add default options
-	defaultOpts := []server.ServerOption{
-		server.WithToolCapabilities(true),
-		server.WithResourceCapabilities(true, true),
-		server.WithToolHandlerMiddleware(rateLimitMiddleware.Middleware()),
-	}
-	opts = append(defaultOpts, opts...)
-
-	// Create hooks for session management
-	hooks := &server.Hooks{}
-	hooks.AddOnRegisterSession(func(ctx context.Context, session server.ClientSession) {
-		client.NewSessionHandler(ctx, session, logger)
-	})
-	hooks.AddOnUnregisterSession(func(ctx context.Context, session server.ClientSession) {
-		client.EndSessionHandler(ctx, session, logger)
-	})
-
-	// Add hooks to options
-	opts = append(opts, server.WithHooks(hooks))
-
-	// Create a new MCP server
-	s := server.NewMCPServer(
-		"vault-mcp-server",
-		version,
-		opts...,
-	)
-	return s
+// runBackground runs the background subsystems next to the MCP transport, on the elected leader when
+// leader election is enabled
+func runBackground(ctx context.Context, s *mcpserver.Server, logger *log.Logger) {
+	go func() {
+		if err := s.RunBackground(ctx); err != nil {
+			logger.WithError(err).Error("Failed to run the background subsystems")
+		}
+	}()
 }
 
 // runDefaultCommand handles the default behavior when no subcommand is provided
@@ -278,30 +268,51 @@ func runDefaultCommand(cmd *cobra.Command, _ []string) {
 	if err != nil {
 		stdlog.Fatal("Failed to get log file:", err)
 	}
-	logger, err := initLogger(logFile)
+	rotation, err := logRotationConfig(cmd.PersistentFlags())
+	if err != nil {
+		stdlog.Fatal("Failed to get log rotation:", err)
+	}
+	logger, err := initLogger(logFile, rotation)
 	if err != nil {
 		stdlog.Fatal("Failed to initialize logger:", err)
 	}
 
-	if err := runStdioServer(logger); err != nil {
+	stopDevVault, err := startDevVault(cmd.PersistentFlags(), logger)
+	if err != nil {
+		stdlog.Fatal("Failed to start Vault dev server:", err)
+	}
+	err = runStdioServer(logger)
+	stopDevVault()
+	if err != nil {
 		stdlog.Fatal("failed to run stdio server:", err)
 	}
 }
 
 func main() {
-	// Check environment variables first - they override command line args
-	if shouldUseHTTPMode() {
+	// Check environment variables first - they override command line args, except for Windows services
+	// which must answer the service manager
+	if shouldUseHTTPMode() && !service.IsService() {
 		port := getHTTPPort()
 		host := getHTTPHost()
 		endpointPath := getEndpointPath(nil)
 
 		logFile, _ := rootCmd.PersistentFlags().GetString("log-file")
-		logger, err := initLogger(logFile)
+		rotation, err := logRotationConfig(rootCmd.PersistentFlags())
+		if err != nil {
+			stdlog.Fatal("Failed to get log rotation:", err)
+		}
+		logger, err := initLogger(logFile, rotation)
 		if err != nil {
 			stdlog.Fatal("Failed to initialize logger:", err)
 		}
 
-		if err := runHTTPServer(logger, host, port, endpointPath); err != nil {
+		stopDevVault, err := startDevVault(rootCmd.PersistentFlags(), logger)
+		if err != nil {
+			stdlog.Fatal("Failed to start Vault dev server:", err)
+		}
+		err = runHTTPServer(context.Background(), logger, host, port, endpointPath)
+		stopDevVault()
+		if err != nil {
 			stdlog.Fatal("failed to run HTTP server:", err)
 		}
 		return
@@ -0,0 +1,206 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/service"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serviceCmd = &cobra.Command{
+		Use:   "service",
+		Short: "Run the server as a Windows service or a macOS launchd agent",
+		Long: `Install, start, stop and remove the StreamableHTTP server as a Windows service or a launchd agent
+of the current user on macOS, so that it keeps running in the background. Windows services log to the
+event log, launchd agents to ~/Library/Logs/<name>.log unless --log-file is set.`,
+	}
+
+	serviceInstallCmd = &cobra.Command{
+		Use:   "install",
+		Short: "Install and start the service",
+		Long: `Install the StreamableHTTP server as a service and start it. Services do not inherit the
+environment of the user, pass the variables of the server such as VAULT_ADDR and VAULT_TOKEN with --env,
+either as KEY=VALUE or as KEY to copy the variable from the current environment.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			name, err := cmd.Flags().GetString("name")
+			if err != nil {
+				return err
+			}
+			host, err := cmd.Flags().GetString("transport-host")
+			if err != nil {
+				return err
+			}
+			port, err := cmd.Flags().GetString("transport-port")
+			if err != nil {
+				return err
+			}
+			endpointPath, err := cmd.Flags().GetString("mcp-endpoint")
+			if err != nil {
+				return err
+			}
+			entries, err := cmd.Flags().GetStringArray("env")
+			if err != nil {
+				return err
+			}
+			logFile, err := rootCmd.PersistentFlags().GetString("log-file")
+			if err != nil {
+				return err
+			}
+
+			env, err := service.ParseEnv(entries)
+			if err != nil {
+				return err
+			}
+			executable, err := os.Executable()
+			if err != nil {
+				return fmt.Errorf("failed to find the server binary: %w", err)
+			}
+			if executable, err = filepath.EvalSymlinks(executable); err != nil {
+				return fmt.Errorf("failed to find the server binary: %w", err)
+			}
+
+			// Windows services must answer the service manager, launchd agents run the server directly
+			args := []string{"streamable-http"}
+			if runtime.GOOS == "windows" {
+				args = []string{"service", "run", "--name", name}
+			}
+			args = append(args, "--transport-host", host, "--transport-port", port, "--mcp-endpoint", endpointPath)
+			if logFile != "" {
+				args = append(args, "--log-file", logFile)
+			}
+
+			err = service.Install(service.Config{
+				Name:       name,
+				Executable: executable,
+				Args:       args,
+				Env:        env,
+				LogFile:    logFile,
+			})
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Installed service %s, listening on http://%s:%s%s\n", name, host, port, endpointPath)
+			return nil
+		},
+	}
+
+	serviceUninstallCmd = &cobra.Command{
+		Use:          "uninstall",
+		Short:        "Stop and remove the service",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return serviceAction(cmd, "Removed", service.Uninstall)
+		},
+	}
+
+	serviceStartCmd = &cobra.Command{
+		Use:          "start",
+		Short:        "Start the service",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return serviceAction(cmd, "Started", service.Start)
+		},
+	}
+
+	serviceStopCmd = &cobra.Command{
+		Use:          "stop",
+		Short:        "Stop the service",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return serviceAction(cmd, "Stopped", service.Stop)
+		},
+	}
+
+	serviceStatusCmd = &cobra.Command{
+		Use:          "status",
+		Short:        "Print the state of the service",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			name, err := cmd.Flags().GetString("name")
+			if err != nil {
+				return err
+			}
+			status, err := service.QueryStatus(name)
+			if errors.Is(err, service.ErrNotInstalled) {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Service %s is not installed\n", name)
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Service %s is %s\n", name, status)
+			return nil
+		},
+	}
+
+	// serviceRunCmd is the command the Windows service manager starts
+	serviceRunCmd = &cobra.Command{
+		Use:          "run",
+		Short:        "Run the server under the Windows service manager",
+		Args:         cobra.NoArgs,
+		Hidden:       true,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			name, err := cmd.Flags().GetString("name")
+			if err != nil {
+				return err
+			}
+			host, err := cmd.Flags().GetString("transport-host")
+			if err != nil {
+				return err
+			}
+			port, err := cmd.Flags().GetString("transport-port")
+			if err != nil {
+				return err
+			}
+			endpointPath, err := cmd.Flags().GetString("mcp-endpoint")
+			if err != nil {
+				return err
+			}
+			logFile, err := rootCmd.PersistentFlags().GetString("log-file")
+			if err != nil {
+				return err
+			}
+			rotation, err := logRotationConfig(rootCmd.PersistentFlags())
+			if err != nil {
+				return err
+			}
+			logger, err := initLogger(logFile, rotation)
+			if err != nil {
+				return err
+			}
+
+			return service.Run(name, logger, func(ctx context.Context) error {
+				return runHTTPServer(ctx, logger, host, port, endpointPath)
+			})
+		},
+	}
+)
+
+// serviceAction runs an action on the service named by the --name flag
+func serviceAction(cmd *cobra.Command, done string, action func(name string) error) error {
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return err
+	}
+	if err := action(name); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s service %s\n", done, name)
+	return nil
+}
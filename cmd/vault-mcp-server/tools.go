@@ -0,0 +1,106 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/mcpserver"
+	"github.com/hashicorp/vault-mcp-server/version"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	toolsCmd = &cobra.Command{
+		Use:   "tools",
+		Short: "Inspect the tools of the server",
+	}
+
+	toolsListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "Print the tools the server exposes",
+		Long: `Print the name, description, input schema and annotations of every tool the server exposes with
+the current environment, without starting a transport. Tools enabled by MCP_ENABLE_ADMIN_TOOLS and
+MCP_TOOL_PLUGINS are included, and --tenant and --read-only restrict the list to what a client of
+a tenant profile or a read-only client can call, so the exposed surface can be reviewed and diffed.`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true, // main prints the error
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := cmd.Flags().GetString("format")
+			if err != nil {
+				return err
+			}
+			tenant, err := cmd.Flags().GetString("tenant")
+			if err != nil {
+				return err
+			}
+			readOnly, err := cmd.Flags().GetBool("read-only")
+			if err != nil {
+				return err
+			}
+			if format != "json" && format != "markdown" {
+				return fmt.Errorf("unknown format '%s', use json or markdown", format)
+			}
+
+			profile, err := manifestProfile(tenant, readOnly)
+			if err != nil {
+				return err
+			}
+
+			// Logs go to stderr so that the manifest can be redirected
+			logger := log.New()
+			logger.SetOutput(os.Stderr)
+			logger.SetLevel(log.WarnLevel)
+
+			s := mcpserver.New(mcpserver.Config{Version: version.Version, Logger: logger})
+			tools := s.ToolManifest(profile)
+
+			if format == "markdown" {
+				return mcpserver.WriteToolManifestMarkdown(cmd.OutOrStdout(), tools)
+			}
+			encoder := json.NewEncoder(cmd.OutOrStdout())
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(tools)
+		},
+	}
+)
+
+// manifestProfile returns the profile restricting the manifest: the named tenant profile of
+// MCP_TENANCY_CONFIG_FILE, made read-only when readOnly is set, or nil when neither is selected
+func manifestProfile(tenant string, readOnly bool) (*client.TenantProfile, error) {
+	var profile *client.TenantProfile
+	if tenant != "" {
+		config, err := client.LoadTenancyConfigFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		if config == nil {
+			return nil, fmt.Errorf("--tenant requires %s", client.TenancyConfigFileEnv)
+		}
+		for _, candidate := range config.Profiles {
+			if candidate.Name == tenant {
+				profile = candidate
+				break
+			}
+		}
+		if profile == nil {
+			return nil, fmt.Errorf("no tenant profile named '%s' in %s", tenant, os.Getenv(client.TenancyConfigFileEnv))
+		}
+	}
+
+	if readOnly {
+		restricted := client.TenantProfile{}
+		if profile != nil {
+			restricted = *profile
+		}
+		restricted.ReadOnly = true
+		profile = &restricted
+	}
+	return profile, nil
+}
@@ -0,0 +1,278 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/mcpserver"
+	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMockVault serves a KV v2 mount 'secret' holding a single secret at 'app/config'
+func newMockVault(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "e2e-token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch strings.TrimSuffix(r.URL.Path, "/") {
+		case "/v1/sys/mounts":
+			_, _ = w.Write([]byte(`{"data":{"secret/":{"type":"kv","description":"key/value secret storage","options":{"version":"2"}}}}`))
+		case "/v1/secret/data/app/config":
+			_, _ = w.Write([]byte(`{"data":{"data":{"username":"app"},"metadata":{"version":1}}}`))
+		case "/v1/secret/metadata":
+			_, _ = w.Write([]byte(`{"data":{"keys":["app/"]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[]}`))
+		}
+	}))
+	t.Cleanup(mockVault.Close)
+	return mockVault
+}
+
+// startHTTPServer starts the assembled StreamableHTTP server on an ephemeral port and returns its base URL
+func startHTTPServer(t *testing.T) string {
+	t.Helper()
+
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errC := make(chan error, 1)
+	go func() {
+		errC <- mcpserver.New(mcpserver.Config{Version: "e2e", Logger: logger}).ServeListener(ctx, listener, "/mcp")
+	}()
+	t.Cleanup(func() {
+		// Connections that were dialed but never used would delay the shutdown
+		http.DefaultClient.CloseIdleConnections()
+		cancel()
+		require.NoError(t, <-errC)
+	})
+
+	baseURL := "http://" + listener.Addr().String()
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(baseURL + "/health")
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 5*time.Second, 20*time.Millisecond)
+
+	return baseURL
+}
+
+// newMCPClient starts an MCP session against the server with the given Vault address and token headers
+func newMCPClient(t *testing.T, baseURL string, headers map[string]string) *mcpclient.Client {
+	t.Helper()
+
+	httpClient := &http.Client{}
+	c, err := mcpclient.NewStreamableHttpClient(baseURL+"/mcp",
+		transport.WithHTTPHeaders(headers),
+		transport.WithHTTPBasicClient(httpClient),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = c.Close()
+		httpClient.CloseIdleConnections()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, c.Start(ctx))
+
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{Name: "e2e", Version: "1.0.0"}
+	result, err := c.Initialize(ctx, initRequest)
+	require.NoError(t, err)
+	require.Equal(t, "vault-mcp-server", result.ServerInfo.Name)
+	require.Equal(t, "e2e", result.ServerInfo.Version)
+
+	return c
+}
+
+func callTool(t *testing.T, c *mcpclient.Client, name string, args map[string]any) (*mcp.CallToolResult, error) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req := mcp.CallToolRequest{}
+	req.Params.Name = name
+	req.Params.Arguments = args
+	return c.CallTool(ctx, req)
+}
+
+func resultText(t *testing.T, result *mcp.CallToolResult) string {
+	t.Helper()
+	require.NotEmpty(t, result.Content)
+	text, ok := mcp.AsTextContent(result.Content[0])
+	require.True(t, ok)
+	return text.Text
+}
+
+func TestStreamableHTTPSession(t *testing.T) {
+	t.Setenv("MCP_CORS_MODE", "strict")
+	t.Setenv("MCP_ALLOWED_ORIGINS", "https://portal.example.com")
+
+	mockVault := newMockVault(t)
+	baseURL := startHTTPServer(t)
+	c := newMCPClient(t, baseURL, map[string]string{
+		"VAULT_ADDR":    mockVault.URL,
+		"X-Vault-Token": "e2e-token",
+	})
+
+	t.Run("tools/list", func(t *testing.T) {
+		result, err := c.ListTools(context.Background(), mcp.ListToolsRequest{})
+		require.NoError(t, err)
+
+		names := map[string]bool{}
+		for _, tool := range result.Tools {
+			names[tool.Name] = true
+		}
+		for _, name := range []string{"list_mounts", "read_secret", "write_secret", "list_secrets", "server_info"} {
+			assert.True(t, names[name], "expected tool %s to be listed", name)
+		}
+	})
+
+	t.Run("list_mounts", func(t *testing.T) {
+		result, err := callTool(t, c, "list_mounts", nil)
+		require.NoError(t, err)
+		require.False(t, result.IsError, resultText(t, result))
+		assert.Contains(t, resultText(t, result), "secret")
+	})
+
+	t.Run("read_secret", func(t *testing.T) {
+		result, err := callTool(t, c, "read_secret", map[string]any{"mount": "secret", "path": "app/config"})
+		require.NoError(t, err)
+		require.False(t, result.IsError, resultText(t, result))
+
+		var data map[string]any
+		require.NoError(t, json.Unmarshal([]byte(resultText(t, result)), &data))
+		assert.Equal(t, "app", data["username"])
+	})
+
+	t.Run("list_secrets", func(t *testing.T) {
+		result, err := callTool(t, c, "list_secrets", map[string]any{"mount": "secret"})
+		require.NoError(t, err)
+		require.False(t, result.IsError, resultText(t, result))
+		assert.JSONEq(t, `["app/"]`, resultText(t, result))
+	})
+
+	t.Run("missing secret", func(t *testing.T) {
+		result, err := callTool(t, c, "read_secret", map[string]any{"mount": "secret", "path": "missing"})
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, resultText(t, result), "Secret not found")
+	})
+
+	t.Run("missing mount", func(t *testing.T) {
+		result, err := callTool(t, c, "read_secret", map[string]any{"mount": "nope", "path": "app/config"})
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, resultText(t, result), "does not exist")
+	})
+
+	t.Run("missing argument", func(t *testing.T) {
+		result, err := callTool(t, c, "read_secret", map[string]any{"mount": "secret"})
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+	})
+
+	t.Run("unknown tool", func(t *testing.T) {
+		_, err := callTool(t, c, "does_not_exist", nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestStreamableHTTPVaultPermissionDenied(t *testing.T) {
+	mockVault := newMockVault(t)
+	baseURL := startHTTPServer(t)
+	c := newMCPClient(t, baseURL, map[string]string{
+		"VAULT_ADDR":    mockVault.URL,
+		"X-Vault-Token": "wrong-token",
+	})
+
+	result, err := callTool(t, c, "list_mounts", nil)
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, resultText(t, result), "permission denied")
+}
+
+func TestStreamableHTTPSecurity(t *testing.T) {
+	t.Setenv("MCP_CORS_MODE", "strict")
+	t.Setenv("MCP_ALLOWED_ORIGINS", "https://portal.example.com")
+
+	baseURL := startHTTPServer(t)
+	initialize := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"e2e","version":"1.0.0"}}}`
+
+	post := func(url string, headers map[string]string) *http.Response {
+		req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(initialize))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json, text/event-stream")
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	t.Run("allowed origin", func(t *testing.T) {
+		resp := post(baseURL+"/mcp", map[string]string{"Origin": "https://portal.example.com"})
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "https://portal.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+		assert.NotEmpty(t, resp.Header.Get("RateLimit-Limit"))
+	})
+
+	t.Run("unknown origin", func(t *testing.T) {
+		resp := post(baseURL+"/mcp", map[string]string{"Origin": "https://evil.example.com"})
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("preflight", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodOptions, baseURL+"/mcp", nil)
+		require.NoError(t, err)
+		req.Header.Set("Origin", "https://portal.example.com")
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		req.Header.Set("Access-Control-Request-Headers", "content-type, authorization, x-vault-token, x-unknown")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Equal(t, "Content-Type, Authorization, X-Vault-Token", resp.Header.Get("Access-Control-Allow-Headers"))
+		assert.Contains(t, resp.Header.Get("Access-Control-Allow-Methods"), http.MethodPost)
+	})
+
+	t.Run("token in query parameters", func(t *testing.T) {
+		resp := post(fmt.Sprintf("%s/mcp?VAULT_TOKEN=secret", baseURL), nil)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
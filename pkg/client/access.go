@@ -0,0 +1,170 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/vault/api"
+)
+
+// Policy capabilities of Vault requests
+const (
+	CapabilityCreate = "create"
+	CapabilityRead   = "read"
+	CapabilityUpdate = "update"
+	CapabilityPatch  = "patch"
+	CapabilityDelete = "delete"
+	CapabilityList   = "list"
+	CapabilitySudo   = "sudo"
+)
+
+// capabilityOrder orders capabilities as the Vault documentation lists them
+var capabilityOrder = []string{CapabilityCreate, CapabilityRead, CapabilityUpdate, CapabilityPatch, CapabilityDelete, CapabilityList, CapabilitySudo}
+
+// maxSessionAccessPaths bounds the paths recorded per session, requests to further paths are not recorded
+var maxSessionAccessPaths = 1000
+
+var (
+	sessionAccess sync.Map
+)
+
+// AccessRecord holds the capabilities the requests of a session used on a Vault path
+type AccessRecord struct {
+	Namespace    string   `json:"namespace,omitempty"` // Vault namespace the path is relative to
+	Path         string   `json:"path"`                // Vault API path, without the /v1/ prefix
+	Capabilities []string `json:"capabilities"`        // Capabilities the requests needed
+	Requests     int      `json:"requests"`            // Number of requests to the path
+}
+
+// accessLog holds the Vault paths a session accessed
+type accessLog struct {
+	mu        sync.Mutex
+	records   map[string]*AccessRecord // By namespace and path
+	truncated bool
+}
+
+func getAccessLog(sessionID string) *accessLog {
+	value, _ := sessionAccess.LoadOrStore(sessionID, &accessLog{records: map[string]*AccessRecord{}})
+	return value.(*accessLog)
+}
+
+// recordAccess records the path and capabilities of every request of the session that Vault authorized.
+// Requests refused by Vault and requests that failed on the server are not recorded.
+func recordAccess(sessionID string, next func(context.Context, *http.Response, error) (bool, error)) func(context.Context, *http.Response, error) (bool, error) {
+	if next == nil {
+		next = api.DefaultRetryPolicy
+	}
+	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		if err == nil && resp != nil && resp.Request != nil {
+			getAccessLog(sessionID).record(resp)
+		}
+		return next(ctx, resp, err)
+	}
+}
+
+// record adds the path and capabilities of the request of a response to the log
+func (l *accessLog) record(resp *http.Response) {
+	switch status := resp.StatusCode; {
+	case status == http.StatusUnauthorized, status == http.StatusForbidden, status >= http.StatusInternalServerError:
+		return
+	}
+	path, ok := strings.CutPrefix(resp.Request.URL.Path, "/v1/")
+	if !ok {
+		return
+	}
+	namespace := strings.Trim(resp.Request.Header.Get(api.NamespaceHeaderName), "/")
+	capabilities := requestCapabilities(resp.Request)
+	if len(capabilities) == 0 {
+		return
+	}
+
+	// Vault appends a slash to the path of LIST requests before it checks policies
+	path = strings.TrimSuffix(path, "/")
+	if slices.Contains(capabilities, CapabilityList) {
+		path += "/"
+	}
+	if api.IsSudoPath("/" + strings.TrimSuffix(path, "/")) {
+		capabilities = append(capabilities, CapabilitySudo)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	key := namespace + "\x00" + path
+	record, ok := l.records[key]
+	if !ok {
+		if len(l.records) >= maxSessionAccessPaths {
+			l.truncated = true
+			return
+		}
+		record = &AccessRecord{Namespace: namespace, Path: path}
+		l.records[key] = record
+	}
+	record.Requests++
+	for _, capability := range capabilities {
+		if !slices.Contains(record.Capabilities, capability) {
+			record.Capabilities = append(record.Capabilities, capability)
+		}
+	}
+	slices.SortFunc(record.Capabilities, func(a, b string) int {
+		return slices.Index(capabilityOrder, a) - slices.Index(capabilityOrder, b)
+	})
+}
+
+// requestCapabilities returns the capabilities a request needs, as the -output-policy flag of the Vault CLI
+// derives them. Writes need create or update depending on whether the resource exists, so both are returned.
+func requestCapabilities(req *http.Request) []string {
+	switch req.Method {
+	case "LIST":
+		return []string{CapabilityList}
+	case http.MethodGet, http.MethodHead:
+		if req.URL.Query().Get("list") == "true" {
+			return []string{CapabilityList}
+		}
+		return []string{CapabilityRead}
+	case http.MethodPost, http.MethodPut:
+		return []string{CapabilityCreate, CapabilityUpdate}
+	case http.MethodPatch:
+		return []string{CapabilityPatch}
+	case http.MethodDelete:
+		return []string{CapabilityDelete}
+	}
+	return nil
+}
+
+// SessionAccess returns the Vault paths the requests of a session accessed, sorted by namespace and path,
+// and whether paths were left out because the session accessed too many
+func SessionAccess(sessionID string) ([]AccessRecord, bool) {
+	value, ok := sessionAccess.Load(sessionID)
+	if !ok {
+		return nil, false
+	}
+	access := value.(*accessLog)
+	access.mu.Lock()
+	defer access.mu.Unlock()
+
+	records := make([]AccessRecord, 0, len(access.records))
+	for _, record := range access.records {
+		copied := *record
+		copied.Capabilities = slices.Clone(record.Capabilities)
+		records = append(records, copied)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Namespace != records[j].Namespace {
+			return records[i].Namespace < records[j].Namespace
+		}
+		return records[i].Path < records[j].Path
+	})
+	return records, access.truncated
+}
+
+// DeleteSessionAccess removes the access log of a session
+func DeleteSessionAccess(sessionID string) {
+	sessionAccess.Delete(sessionID)
+}
@@ -0,0 +1,88 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionAccess(t *testing.T) {
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/secret/data/denied":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+		case "/v1/secret/data/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[]}`))
+		default:
+			_, _ = w.Write([]byte(`{"data":{"keys":["a"]}}`))
+		}
+	}))
+	defer mockVault.Close()
+
+	sessionID := "test-" + t.Name()
+	vault, err := NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	defer DeleteVaultClient(sessionID)
+	defer DeleteSessionAccess(sessionID)
+
+	_, err = vault.Logical().Read("secret/data/app")
+	require.NoError(t, err)
+	_, err = vault.Logical().Write("secret/data/app", map[string]interface{}{"data": map[string]interface{}{"k": "v"}})
+	require.NoError(t, err)
+	_, err = vault.Logical().List("secret/metadata/app")
+	require.NoError(t, err)
+	_, _ = vault.Logical().Read("secret/data/denied")
+	_, _ = vault.Logical().Read("secret/data/missing")
+	_, err = vault.Logical().Read("sys/audit")
+	require.NoError(t, err)
+
+	vault.SetNamespace("team-a")
+	_, err = vault.Logical().Delete("kv/old")
+	require.NoError(t, err)
+
+	records, truncated := SessionAccess(sessionID)
+	assert.False(t, truncated)
+	assert.Equal(t, []AccessRecord{
+		{Path: "secret/data/app", Capabilities: []string{CapabilityCreate, CapabilityRead, CapabilityUpdate}, Requests: 2},
+		{Path: "secret/data/missing", Capabilities: []string{CapabilityRead}, Requests: 1},
+		{Path: "secret/metadata/app/", Capabilities: []string{CapabilityList}, Requests: 1},
+		{Path: "sys/audit", Capabilities: []string{CapabilityRead, CapabilitySudo}, Requests: 1},
+		{Namespace: "team-a", Path: "kv/old", Capabilities: []string{CapabilityDelete}, Requests: 1},
+	}, records, "requests refused by Vault are not recorded")
+
+	DeleteSessionAccess(sessionID)
+	records, _ = SessionAccess(sessionID)
+	assert.Empty(t, records)
+}
+
+func TestSessionAccess_Bounded(t *testing.T) {
+	previous := maxSessionAccessPaths
+	maxSessionAccessPaths = 1
+	defer func() { maxSessionAccessPaths = previous }()
+
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer mockVault.Close()
+
+	sessionID := "test-" + t.Name()
+	vault, err := NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	defer DeleteVaultClient(sessionID)
+	defer DeleteSessionAccess(sessionID)
+
+	_, _ = vault.Logical().Read("secret/data/a")
+	_, _ = vault.Logical().Read("secret/data/b")
+	records, truncated := SessionAccess(sessionID)
+	assert.Len(t, records, 1)
+	assert.True(t, truncated)
+}
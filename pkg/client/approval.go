@@ -0,0 +1,535 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// ApprovalRequiredEnv selects the tool calls that wait for a human approver: 'destructive' for every tool
+	// that is not annotated as read-only or non-destructive, or a comma-separated list of tool names
+	ApprovalRequiredEnv = "MCP_REQUIRE_APPROVAL"
+	// ApprovalTokenEnv is the bearer token approvers present to the approval endpoint
+	ApprovalTokenEnv = "MCP_APPROVAL_TOKEN"
+	// ApprovalAddressEnv is the address the approval endpoint listens on
+	ApprovalAddressEnv = "MCP_APPROVAL_ADDR"
+	// ApprovalTTLEnv sets how long a queued tool call waits for a decision before it expires
+	ApprovalTTLEnv = "MCP_APPROVAL_TTL"
+
+	// ApprovalDestructive requires approval for every destructive tool
+	ApprovalDestructive = "destructive"
+	// DefaultApprovalAddress is the address of the approval endpoint when MCP_APPROVAL_ADDR is not set
+	DefaultApprovalAddress = "127.0.0.1:8090"
+	// DefaultApprovalTTL is how long a queued tool call waits when MCP_APPROVAL_TTL is not set
+	DefaultApprovalTTL = time.Hour
+
+	// approvalRetention is how long decided approvals are kept for status queries
+	approvalRetention = 24 * time.Hour
+)
+
+// Statuses of approvals
+const (
+	ApprovalPending  = "pending"
+	ApprovalApproved = "approved" // Approved and running
+	ApprovalExecuted = "executed"
+	ApprovalFailed   = "failed"
+	ApprovalDenied   = "denied"
+	ApprovalExpired  = "expired"
+)
+
+var (
+	// ErrApprovalNotFound is returned for an unknown or pruned approval ID
+	ErrApprovalNotFound = errors.New("approval not found")
+	// ErrApprovalDecided is returned when an approval is no longer pending
+	ErrApprovalDecided = errors.New("approval already decided")
+)
+
+// sensitiveArguments are the tool arguments whose values are hidden from approvers
+var sensitiveArguments = []string{"api_token", "integration_key", "jwt", "password", "pem_bundle", "private_key", "secret_id", "secret_key", "token", "value"}
+
+// ApprovalConfig holds the configuration of the approval flow
+type ApprovalConfig struct {
+	Destructive bool            // Whether every destructive tool requires approval
+	Tools       map[string]bool // Tools that require approval, by name
+	TTL         time.Duration   // How long a queued tool call waits for a decision
+	Address     string          // Address of the approval endpoint
+	Token       string          // Bearer token of the approval endpoint
+}
+
+// Enabled reports whether any tool call requires approval
+func (c ApprovalConfig) Enabled() bool {
+	return c.Destructive || len(c.Tools) > 0
+}
+
+// Requires reports whether calls of the tool wait for an approver. Following the MCP specification, tools
+// are destructive unless annotated as read-only or as not destructive.
+func (c ApprovalConfig) Requires(tool mcp.Tool) bool {
+	if c.Tools[tool.Name] {
+		return true
+	}
+	if !c.Destructive {
+		return false
+	}
+	annotations := tool.Annotations
+	if annotations.ReadOnlyHint != nil && *annotations.ReadOnlyHint {
+		return false
+	}
+	return annotations.DestructiveHint == nil || *annotations.DestructiveHint
+}
+
+// LoadApprovalConfigFromEnv loads the approval flow configuration from environment variables
+func LoadApprovalConfigFromEnv() ApprovalConfig {
+	config := ApprovalConfig{
+		Tools:   map[string]bool{},
+		TTL:     DefaultApprovalTTL,
+		Address: strings.TrimSpace(os.Getenv(ApprovalAddressEnv)),
+		Token:   os.Getenv(ApprovalTokenEnv),
+	}
+	if config.Address == "" {
+		config.Address = DefaultApprovalAddress
+	}
+
+	for _, name := range strings.Split(os.Getenv(ApprovalRequiredEnv), ",") {
+		name = strings.TrimSpace(name)
+		switch name {
+		case "":
+		case ApprovalDestructive:
+			config.Destructive = true
+		default:
+			config.Tools[name] = true
+		}
+	}
+
+	if value := os.Getenv(ApprovalTTLEnv); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			config.TTL = parsed
+		} else {
+			log.Warnf("Invalid %s value '%s', using default %s", ApprovalTTLEnv, value, DefaultApprovalTTL)
+		}
+	}
+
+	return config
+}
+
+// Approval is a tool call queued for a human approver
+type Approval struct {
+	ID        string         `json:"id"`
+	Tool      string         `json:"tool"`
+	Arguments map[string]any `json:"arguments,omitempty"` // Arguments of the call, with sensitive values hidden
+	SessionID string         `json:"session_id"`
+	RequestID string         `json:"request_id,omitempty"`
+	Status    string         `json:"status"`
+	CreatedAt time.Time      `json:"created_at"`
+	ExpiresAt time.Time      `json:"expires_at"`
+	DecidedAt *time.Time     `json:"decided_at,omitempty"`
+	Approver  string         `json:"approver,omitempty"`
+	Error     string         `json:"error,omitempty"` // Why the approved call failed, if it did
+
+	// The result is only returned to the session, it can hold data the approver must not see
+	Result string `json:"-"`
+
+	ctx     context.Context
+	request mcp.CallToolRequest
+	handler server.ToolHandlerFunc
+}
+
+// approvalQueue holds the approvals of this server instance
+type approvalQueue struct {
+	mu    sync.Mutex
+	now   func() time.Time
+	items map[string]*Approval
+}
+
+var approvals = &approvalQueue{
+	now:   time.Now,
+	items: map[string]*Approval{},
+}
+
+// prune expires pending approvals past their deadline and drops decided approvals after the retention.
+// Must be called with the lock held.
+func (q *approvalQueue) prune() {
+	now := q.now()
+	for id, approval := range q.items {
+		if approval.Status == ApprovalPending && now.After(approval.ExpiresAt) {
+			approval.Status = ApprovalExpired
+			approval.DecidedAt = &approval.ExpiresAt
+		}
+		if approval.DecidedAt != nil && now.After(approval.DecidedAt.Add(approvalRetention)) {
+			delete(q.items, id)
+		}
+	}
+}
+
+func (q *approvalQueue) add(approval *Approval) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.prune()
+	q.items[approval.ID] = approval
+}
+
+// decide moves a pending approval to the status, returning a copy of it
+func (q *approvalQueue) decide(id string, status string, approver string) (*Approval, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.prune()
+
+	approval, ok := q.items[id]
+	if !ok {
+		return nil, ErrApprovalNotFound
+	}
+	if approval.Status != ApprovalPending {
+		return nil, fmt.Errorf("%w: %s is %s", ErrApprovalDecided, id, approval.Status)
+	}
+	now := q.now()
+	approval.Status = status
+	approval.DecidedAt = &now
+	approval.Approver = approver
+	return approval, nil
+}
+
+// finish records the outcome of an approved tool call
+func (q *approvalQueue) finish(approval *Approval, status string, result string, errMessage string) Approval {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	approval.Status = status
+	approval.Result = result
+	approval.Error = errMessage
+	return *approval
+}
+
+// GetApproval returns a copy of the approval with the ID
+func GetApproval(id string) (Approval, bool) {
+	approvals.mu.Lock()
+	defer approvals.mu.Unlock()
+	approvals.prune()
+	approval, ok := approvals.items[id]
+	if !ok {
+		return Approval{}, false
+	}
+	return *approval, true
+}
+
+// ListApprovals returns copies of the approvals of this server instance, oldest first
+func ListApprovals() []Approval {
+	approvals.mu.Lock()
+	defer approvals.mu.Unlock()
+	approvals.prune()
+
+	list := make([]Approval, 0, len(approvals.items))
+	for _, approval := range approvals.items {
+		list = append(list, *approval)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+	return list
+}
+
+// ApproveToolCall runs a queued tool call on behalf of its session and notifies the session of the result
+func ApproveToolCall(id string, approver string, logger *log.Logger) (Approval, error) {
+	approval, err := approvals.decide(id, ApprovalApproved, approver)
+	if err != nil {
+		return Approval{}, err
+	}
+
+	entry := logger.WithFields(log.Fields{
+		"approval_id":  approval.ID,
+		"approver":     approver,
+		"tool":         approval.Tool,
+		"session_id":   approval.SessionID,
+		RequestIDField: approval.RequestID,
+	})
+
+	// Without the client of the session the call would run with the credentials of the server
+	if GetVaultClient(approval.SessionID) == nil {
+		entry.Warn("Approved tool call not run, its session has ended")
+		result := approvals.finish(approval, ApprovalFailed, "", "the session of the tool call has ended")
+		recordApproval(result, TranscriptApprovalDecision)
+		return result, nil
+	}
+
+	entry.Info("Running approved tool call")
+	result, err := approval.handler(approval.ctx, approval.request)
+	var finished Approval
+	switch {
+	case err != nil:
+		finished = approvals.finish(approval, ApprovalFailed, "", err.Error())
+	case result != nil && result.IsError:
+		finished = approvals.finish(approval, ApprovalFailed, toolResultText(result), "the tool returned an error")
+	default:
+		finished = approvals.finish(approval, ApprovalExecuted, toolResultText(result), "")
+	}
+	entry.WithField("status", finished.Status).Info("Approved tool call completed")
+	recordApproval(finished, TranscriptApprovalDecision)
+
+	notifyApprovalDecision(approval.ctx, finished, logger)
+	return finished, nil
+}
+
+// DenyToolCall rejects a queued tool call and notifies its session
+func DenyToolCall(id string, approver string, logger *log.Logger) (Approval, error) {
+	approval, err := approvals.decide(id, ApprovalDenied, approver)
+	if err != nil {
+		return Approval{}, err
+	}
+
+	logger.WithFields(log.Fields{
+		"approval_id": approval.ID,
+		"approver":    approver,
+		"tool":        approval.Tool,
+		"session_id":  approval.SessionID,
+	}).Info("Tool call denied")
+
+	denied := approvals.finish(approval, ApprovalDenied, "", "")
+	recordApproval(denied, TranscriptApprovalDecision)
+	notifyApprovalDecision(approval.ctx, denied, logger)
+	return denied, nil
+}
+
+// notifyApprovalDecision sends the decision and the result of a queued tool call to its session as a log message
+func notifyApprovalDecision(ctx context.Context, approval Approval, logger *log.Logger) {
+	srv := server.ServerFromContext(ctx)
+	if srv == nil {
+		return
+	}
+
+	level := mcp.LoggingLevelInfo
+	if approval.Status != ApprovalExecuted {
+		level = mcp.LoggingLevelWarning
+	}
+	err := srv.SendNotificationToSpecificClient(approval.SessionID, "notifications/message", map[string]any{
+		"level":  level,
+		"logger": "vault-mcp-server",
+		"data": map[string]any{
+			"approval_id": approval.ID,
+			"tool":        approval.Tool,
+			"status":      approval.Status,
+			"result":      approval.Result,
+			"error":       approval.Error,
+		},
+	})
+	if err != nil {
+		logger.WithError(err).WithField("approval_id", approval.ID).Debug("Failed to notify the session of the approval decision")
+	}
+}
+
+// toolResultText joins the text contents of a tool result
+func toolResultText(result *mcp.CallToolResult) string {
+	if result == nil {
+		return ""
+	}
+	var texts []string
+	for _, content := range result.Content {
+		if text, ok := mcp.AsTextContent(content); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// redactArguments returns a copy of the arguments with the values of sensitive arguments hidden
+func redactArguments(arguments any) map[string]any {
+	args, ok := arguments.(map[string]any)
+	if !ok {
+		return nil
+	}
+	redacted := make(map[string]any, len(args))
+	for name, value := range args {
+		if slices.Contains(sensitiveArguments, name) {
+			value = "<redacted>"
+		}
+		redacted[name] = value
+	}
+	return redacted
+}
+
+// ApprovalMiddleware queues the tool calls that require a human approver instead of running them
+type ApprovalMiddleware struct {
+	config ApprovalConfig
+	logger *log.Logger
+}
+
+// NewApprovalMiddleware creates a new approval middleware
+func NewApprovalMiddleware(config ApprovalConfig, logger *log.Logger) *ApprovalMiddleware {
+	return &ApprovalMiddleware{
+		config: config,
+		logger: logger,
+	}
+}
+
+// Middleware returns the tool handler middleware function. A tool call that requires approval is queued
+// with the rest of the middleware chain and answered with its approval ID. It runs in the context of its
+// session once an approver approves it through the approval endpoint.
+func (m *ApprovalMiddleware) Middleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if !m.requiresApproval(ctx, request.Params.Name) {
+				return next(ctx, request)
+			}
+
+			sessionID := getSessionIDFromContext(ctx)
+			if sessionID == "" {
+				return mcp.NewToolResultError(fmt.Sprintf("'%s' requires approval, which is only available in a session", request.Params.Name)), nil
+			}
+
+			now := approvals.now()
+			approval := &Approval{
+				ID:        NewRequestID(),
+				Tool:      request.Params.Name,
+				Arguments: redactArguments(request.Params.Arguments),
+				SessionID: sessionID,
+				RequestID: RequestIDFromContext(ctx),
+				Status:    ApprovalPending,
+				CreatedAt: now,
+				ExpiresAt: now.Add(m.config.TTL),
+				// The call runs after the request that queued it has completed
+				ctx:     context.WithoutCancel(ctx),
+				request: request,
+				handler: next,
+			}
+			approvals.add(approval)
+			recordApproval(*approval, TranscriptApprovalQueued)
+
+			RequestLogger(ctx, m.logger).WithFields(log.Fields{
+				"approval_id": approval.ID,
+				"tool":        approval.Tool,
+				"session_id":  sessionID,
+			}).Info("Tool call queued for approval")
+
+			jsonData, err := json.Marshal(map[string]any{
+				"status":      ApprovalPending,
+				"approval_id": approval.ID,
+				"tool":        approval.Tool,
+				"expires_at":  approval.ExpiresAt,
+				"message": fmt.Sprintf("'%s' requires approval by a human operator and has not run yet. An approver can run it with 'vault-mcp-server approve %s' before %s. "+
+					"The session is notified of the decision, and get_approval_status reports it.", approval.Tool, approval.ID, approval.ExpiresAt.Format(time.RFC3339)),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return mcp.NewToolResultText(string(jsonData)), nil
+		}
+	}
+}
+
+// requiresApproval reports whether calls of the named tool wait for an approver
+func (m *ApprovalMiddleware) requiresApproval(ctx context.Context, toolName string) bool {
+	if m.config.Tools[toolName] {
+		return true
+	}
+	if !m.config.Destructive {
+		return false
+	}
+
+	srv := server.ServerFromContext(ctx)
+	if srv == nil {
+		return false
+	}
+	tool := srv.GetTool(toolName)
+	if tool == nil {
+		return false
+	}
+	return m.config.Requires(tool.Tool)
+}
+
+// ApprovalHandler serves the approval endpoint to approvers presenting the bearer token:
+//
+//	GET  /approvals              lists the approvals
+//	GET  /approvals/{id}         returns an approval
+//	POST /approvals/{id}/approve runs the queued tool call
+//	POST /approvals/{id}/deny    rejects the queued tool call
+//	GET  /transcripts            lists the session transcripts
+//	GET  /transcripts/{session}  returns the transcript of a session
+//
+// The approver is identified by the X-Approver header of the request.
+func ApprovalHandler(token string, logger *log.Logger) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /approvals", func(w http.ResponseWriter, r *http.Request) {
+		writeApprovalJSON(w, http.StatusOK, ListApprovals())
+	})
+	mux.HandleFunc("GET /approvals/{id}", func(w http.ResponseWriter, r *http.Request) {
+		approval, ok := GetApproval(r.PathValue("id"))
+		if !ok {
+			writeApprovalError(w, ErrApprovalNotFound)
+			return
+		}
+		writeApprovalJSON(w, http.StatusOK, approval)
+	})
+	mux.HandleFunc("POST /approvals/{id}/approve", func(w http.ResponseWriter, r *http.Request) {
+		approval, err := ApproveToolCall(r.PathValue("id"), approverFromRequest(r), logger)
+		if err != nil {
+			writeApprovalError(w, err)
+			return
+		}
+		writeApprovalJSON(w, http.StatusOK, approval)
+	})
+	mux.HandleFunc("POST /approvals/{id}/deny", func(w http.ResponseWriter, r *http.Request) {
+		approval, err := DenyToolCall(r.PathValue("id"), approverFromRequest(r), logger)
+		if err != nil {
+			writeApprovalError(w, err)
+			return
+		}
+		writeApprovalJSON(w, http.StatusOK, approval)
+	})
+	mux.HandleFunc("GET /transcripts", func(w http.ResponseWriter, r *http.Request) {
+		writeApprovalJSON(w, http.StatusOK, ListTranscripts())
+	})
+	mux.HandleFunc("GET /transcripts/{session}", func(w http.ResponseWriter, r *http.Request) {
+		transcript, ok := SessionTranscript(r.PathValue("session"))
+		if !ok {
+			writeApprovalJSON(w, http.StatusNotFound, map[string]string{"error": "transcript not found"})
+			return
+		}
+		writeApprovalJSON(w, http.StatusOK, transcript)
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		presented, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if token == "" || !ok || subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+			logger.WithField("remote_addr", r.RemoteAddr).Warn("Rejected approval request with an invalid token")
+			writeApprovalJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid approval token"})
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// approverFromRequest returns the approver named by the request, for the logs and the approval record
+func approverFromRequest(r *http.Request) string {
+	if approver := sanitizeAuditValue(r.Header.Get("X-Approver")); approver != "" {
+		return approver
+	}
+	return "unknown"
+}
+
+func writeApprovalError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrApprovalNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, ErrApprovalDecided):
+		status = http.StatusConflict
+	}
+	writeApprovalJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func writeApprovalJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
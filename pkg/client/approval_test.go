@@ -0,0 +1,222 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// notifiedClientSession is a client session that keeps the notifications sent to it
+type notifiedClientSession struct {
+	mockClientSession
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *notifiedClientSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+func TestLoadApprovalConfigFromEnv(t *testing.T) {
+	t.Setenv(ApprovalRequiredEnv, "destructive, write_secret")
+	t.Setenv(ApprovalTTLEnv, "10m")
+	t.Setenv(ApprovalAddressEnv, "")
+	t.Setenv(ApprovalTokenEnv, "approver-token")
+
+	config := LoadApprovalConfigFromEnv()
+	assert.True(t, config.Enabled())
+	assert.True(t, config.Destructive)
+	assert.Equal(t, map[string]bool{"write_secret": true}, config.Tools)
+	assert.Equal(t, 10*time.Minute, config.TTL)
+	assert.Equal(t, DefaultApprovalAddress, config.Address)
+	assert.Equal(t, "approver-token", config.Token)
+
+	t.Setenv(ApprovalRequiredEnv, "")
+	t.Setenv(ApprovalTTLEnv, "soon")
+	config = LoadApprovalConfigFromEnv()
+	assert.False(t, config.Enabled())
+	assert.Equal(t, DefaultApprovalTTL, config.TTL)
+}
+
+func TestApprovalFlow(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	sessionID := "test-approval"
+	_, err := NewVaultClient(sessionID, "http://127.0.0.1:8200", false, "test-token", "")
+	require.NoError(t, err)
+	defer DeleteVaultClient(sessionID)
+
+	var calls atomic.Int32
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls.Add(1)
+		// Approved calls run in the session of the call that queued them
+		if getSessionIDFromContext(ctx) != sessionID {
+			return mcp.NewToolResultError("wrong session"), nil
+		}
+		return mcp.NewToolResultText("written"), nil
+	}
+
+	middleware := NewApprovalMiddleware(ApprovalConfig{Destructive: true, TTL: time.Minute}, logger)
+	srv := server.NewMCPServer("test", "1.0.0", server.WithToolHandlerMiddleware(middleware.Middleware()))
+	srv.AddTool(mcp.NewTool("read_tool", mcp.WithReadOnlyHintAnnotation(true)), handler)
+	srv.AddTool(mcp.NewTool("create_tool", mcp.WithDestructiveHintAnnotation(false)), handler)
+	srv.AddTool(mcp.NewTool("write_tool"), handler)
+
+	session := &notifiedClientSession{mockClientSession{id: sessionID}, make(chan mcp.JSONRPCNotification, 10)}
+	require.NoError(t, srv.RegisterSession(context.Background(), session))
+	defer srv.UnregisterSession(context.Background(), sessionID)
+	ctx := srv.WithContext(context.Background(), session)
+
+	call := func(tool string, arguments map[string]any) string {
+		message, err := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"method":  "tools/call",
+			"params":  map[string]any{"name": tool, "arguments": arguments},
+		})
+		require.NoError(t, err)
+		response, ok := srv.HandleMessage(ctx, message).(mcp.JSONRPCResponse)
+		require.True(t, ok, "tool call failed")
+		result := response.Result.(*mcp.CallToolResult)
+		return result.Content[0].(mcp.TextContent).Text
+	}
+
+	queue := func(t *testing.T) string {
+		var pending map[string]any
+		require.NoError(t, json.Unmarshal([]byte(call("write_tool", map[string]any{"path": "secret/app", "value": "hunter2"})), &pending))
+		assert.Equal(t, ApprovalPending, pending["status"])
+		return pending["approval_id"].(string)
+	}
+
+	approvalServer := httptest.NewServer(ApprovalHandler("approver-token", logger))
+	defer approvalServer.Close()
+
+	request := func(t *testing.T, method string, path string, token string) (int, []byte) {
+		req, err := http.NewRequest(method, approvalServer.URL+path, http.NoBody)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("X-Approver", "alice")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		var body json.RawMessage
+		_ = json.NewDecoder(resp.Body).Decode(&body)
+		return resp.StatusCode, body
+	}
+
+	t.Run("read-only and non-destructive tools run immediately", func(t *testing.T) {
+		assert.Equal(t, "written", call("read_tool", nil))
+		assert.Equal(t, "written", call("create_tool", nil))
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("destructive tools are queued with sensitive arguments hidden", func(t *testing.T) {
+		id := queue(t)
+		assert.Equal(t, int32(2), calls.Load())
+
+		approval, ok := GetApproval(id)
+		require.True(t, ok)
+		assert.Equal(t, "write_tool", approval.Tool)
+		assert.Equal(t, sessionID, approval.SessionID)
+		assert.Equal(t, map[string]any{"path": "secret/app", "value": "<redacted>"}, approval.Arguments)
+	})
+
+	t.Run("the approval endpoint requires the token", func(t *testing.T) {
+		status, _ := request(t, http.MethodGet, "/approvals", "wrong-token")
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+
+	t.Run("approved calls run and notify the session", func(t *testing.T) {
+		id := queue(t)
+		calls.Store(0)
+
+		status, body := request(t, http.MethodPost, "/approvals/"+id+"/approve", "approver-token")
+		require.Equal(t, http.StatusOK, status, string(body))
+		var approval Approval
+		require.NoError(t, json.Unmarshal(body, &approval))
+		assert.Equal(t, ApprovalExecuted, approval.Status)
+		assert.Equal(t, "alice", approval.Approver)
+		assert.Equal(t, int32(1), calls.Load())
+		assert.NotContains(t, string(body), "written", "results are only returned to the session")
+
+		stored, _ := GetApproval(id)
+		assert.Equal(t, "written", stored.Result)
+
+		select {
+		case notification := <-session.notifications:
+			assert.Equal(t, "notifications/message", notification.Method)
+			data := notification.Params.AdditionalFields["data"].(map[string]any)
+			assert.Equal(t, id, data["approval_id"])
+			assert.Equal(t, ApprovalExecuted, data["status"])
+		case <-time.After(time.Second):
+			t.Fatal("session was not notified")
+		}
+
+		status, _ = request(t, http.MethodPost, "/approvals/"+id+"/approve", "approver-token")
+		assert.Equal(t, http.StatusConflict, status, "approvals run once")
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("denied calls do not run", func(t *testing.T) {
+		id := queue(t)
+		calls.Store(0)
+
+		status, body := request(t, http.MethodPost, "/approvals/"+id+"/deny", "approver-token")
+		require.Equal(t, http.StatusOK, status, string(body))
+		approval, _ := GetApproval(id)
+		assert.Equal(t, ApprovalDenied, approval.Status)
+		assert.Equal(t, int32(0), calls.Load())
+	})
+
+	t.Run("unknown approvals are not found", func(t *testing.T) {
+		status, _ := request(t, http.MethodPost, "/approvals/unknown/approve", "approver-token")
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+
+	t.Run("pending calls expire", func(t *testing.T) {
+		id := queue(t)
+		approvals.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+		defer func() { approvals.now = time.Now }()
+
+		_, err := ApproveToolCall(id, "alice", logger)
+		assert.ErrorIs(t, err, ErrApprovalDecided)
+		approval, _ := GetApproval(id)
+		assert.Equal(t, ApprovalExpired, approval.Status)
+	})
+
+	t.Run("calls of ended sessions do not run", func(t *testing.T) {
+		id := queue(t)
+		calls.Store(0)
+		DeleteVaultClient(sessionID)
+
+		approval, err := ApproveToolCall(id, "alice", logger)
+		require.NoError(t, err)
+		assert.Equal(t, ApprovalFailed, approval.Status)
+		assert.Equal(t, int32(0), calls.Load())
+	})
+
+	t.Run("list returns the approvals oldest first", func(t *testing.T) {
+		status, body := request(t, http.MethodGet, "/approvals", "approver-token")
+		require.Equal(t, http.StatusOK, status)
+		var list []Approval
+		require.NoError(t, json.Unmarshal(body, &list))
+		require.NotEmpty(t, list)
+		for i := 1; i < len(list); i++ {
+			assert.False(t, list[i].CreatedAt.Before(list[i-1].CreatedAt), fmt.Sprintf("approval %d out of order", i))
+		}
+	})
+}
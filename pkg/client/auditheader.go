@@ -0,0 +1,105 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// AuditHeaderEnv names the header that identifies the MCP session in the requests sent to Vault.
+	// Setting it to an empty value stops the header from being sent.
+	AuditHeaderEnv = "MCP_VAULT_AUDIT_HEADER"
+	// DefaultAuditHeader is the header sent when MCP_VAULT_AUDIT_HEADER is not set
+	DefaultAuditHeader = "X-MCP-Session"
+
+	// maxAuditClientName bounds the client name copied from the initialize request of the client
+	maxAuditClientName = 64
+)
+
+var (
+	auditHeader   = DefaultAuditHeader
+	auditHeaderMu sync.RWMutex
+)
+
+// SetAuditHeader replaces the header that identifies the MCP session in Vault requests, an empty name disables it
+func SetAuditHeader(name string) {
+	auditHeaderMu.Lock()
+	defer auditHeaderMu.Unlock()
+	auditHeader = name
+}
+
+// GetAuditHeader returns the header that identifies the MCP session in Vault requests
+func GetAuditHeader() string {
+	auditHeaderMu.RLock()
+	defer auditHeaderMu.RUnlock()
+	return auditHeader
+}
+
+// LoadAuditHeaderFromEnv returns the header set by MCP_VAULT_AUDIT_HEADER, or false when it is not set or
+// is not a valid header name
+func LoadAuditHeaderFromEnv() (string, bool) {
+	name, ok := os.LookupEnv(AuditHeaderEnv)
+	if !ok {
+		return "", false
+	}
+	name = strings.TrimSpace(name)
+	if name != "" && !ValidHeaderName(name) {
+		return "", false
+	}
+	return name, true
+}
+
+// ValidHeaderName reports whether name only has the characters allowed in HTTP header names
+func ValidHeaderName(name string) bool {
+	for _, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.ContainsRune("!#$%&'*+-.^_`|~", c):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// auditHeaderValue describes the session and the client that opened it, such as
+// 'session=3f2a9c01b7e4; client=claude-ai/0.1.0'. The session is identified by its hash, the same as in
+// the User-Agent, since audit devices may record the header in plain text. Vault audit devices record it
+// once the header is added to sys/config/auditing/request-headers.
+func auditHeaderValue(session server.ClientSession) string {
+	value := "session=" + SessionHash(session.SessionID())
+
+	withInfo, ok := session.(server.SessionWithClientInfo)
+	if !ok {
+		return value
+	}
+	info := withInfo.GetClientInfo()
+	if info.Name == "" {
+		return value
+	}
+	client := info.Name
+	if info.Version != "" {
+		client += "/" + info.Version
+	}
+	return value + "; client=" + sanitizeAuditValue(client)
+}
+
+// sanitizeAuditValue makes a value chosen by the client safe to send in a header, replacing
+// separators and characters that are not printable ASCII
+func sanitizeAuditValue(value string) string {
+	if len(value) > maxAuditClientName {
+		value = value[:maxAuditClientName]
+	}
+	return strings.Map(func(c rune) rune {
+		if c < ' ' || c > '~' || c == ';' {
+			return '_'
+		}
+		return c
+	}, value)
+}
@@ -0,0 +1,131 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockClientInfoSession is a session that knows the client that opened it
+type mockClientInfoSession struct {
+	mockClientSession
+	info mcp.Implementation
+}
+
+func (m *mockClientInfoSession) GetClientInfo() mcp.Implementation     { return m.info }
+func (m *mockClientInfoSession) SetClientInfo(info mcp.Implementation) { m.info = info }
+func (m *mockClientInfoSession) GetClientCapabilities() mcp.ClientCapabilities {
+	return mcp.ClientCapabilities{}
+}
+func (m *mockClientInfoSession) SetClientCapabilities(_ mcp.ClientCapabilities) {}
+
+func TestLoadAuditHeaderFromEnv(t *testing.T) {
+	t.Run("not set", func(t *testing.T) {
+		_, ok := LoadAuditHeaderFromEnv()
+		assert.False(t, ok)
+	})
+
+	t.Run("custom header", func(t *testing.T) {
+		t.Setenv(AuditHeaderEnv, " X-Agent-Session ")
+		header, ok := LoadAuditHeaderFromEnv()
+		assert.True(t, ok)
+		assert.Equal(t, "X-Agent-Session", header)
+	})
+
+	t.Run("empty disables the header", func(t *testing.T) {
+		t.Setenv(AuditHeaderEnv, "")
+		header, ok := LoadAuditHeaderFromEnv()
+		assert.True(t, ok)
+		assert.Empty(t, header)
+	})
+
+	t.Run("invalid header name", func(t *testing.T) {
+		t.Setenv(AuditHeaderEnv, "X Agent: Session")
+		_, ok := LoadAuditHeaderFromEnv()
+		assert.False(t, ok)
+	})
+}
+
+func TestAuditHeaderValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		session server.ClientSession
+		want    string
+	}{
+		{
+			name:    "session without client info",
+			session: &mockClientSession{id: "session-1"},
+			want:    "session=" + SessionHash("session-1"),
+		},
+		{
+			name:    "client name and version",
+			session: &mockClientInfoSession{mockClientSession{id: "session-2"}, mcp.Implementation{Name: "claude-ai", Version: "0.1.0"}},
+			want:    "session=" + SessionHash("session-2") + "; client=claude-ai/0.1.0",
+		},
+		{
+			name:    "unsafe client name",
+			session: &mockClientInfoSession{mockClientSession{id: "session-3"}, mcp.Implementation{Name: "agent;\r\nX-Vault-Token: x"}},
+			want:    "session=" + SessionHash("session-3") + "; client=agent___X-Vault-Token: x",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, auditHeaderValue(tt.session))
+		})
+	}
+}
+
+func TestAuditHeaderForwardedToVault(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	received := make(chan http.Header, 1)
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	defer mockVault.Close()
+
+	sessionID := "test-audit-header"
+	_, err := NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	defer DeleteVaultClient(sessionID)
+
+	session := &mockClientInfoSession{mockClientSession{id: sessionID}, mcp.Implementation{Name: "e2e", Version: "1.0.0"}}
+	ctx := server.NewMCPServer("test", "1.0.0").WithContext(WithRequestID(context.Background(), "audit-id"), session)
+
+	read := func() http.Header {
+		vault, err := GetVaultClientFromContext(ctx, logger)
+		require.NoError(t, err)
+		_, err = vault.Logical().Read("sys/mounts")
+		require.NoError(t, err)
+		return <-received
+	}
+
+	headers := read()
+	assert.Equal(t, "session="+SessionHash(sessionID)+"; client=e2e/1.0.0", headers.Get(DefaultAuditHeader))
+	assert.Equal(t, "audit-id", headers.Get(RequestIDHeader))
+
+	SetAuditHeader("X-Agent-Session")
+	defer SetAuditHeader(DefaultAuditHeader)
+	headers = read()
+	assert.Empty(t, headers.Get(DefaultAuditHeader))
+	assert.Equal(t, "session="+SessionHash(sessionID)+"; client=e2e/1.0.0", headers.Get("X-Agent-Session"))
+
+	SetAuditHeader("")
+	headers = read()
+	assert.Empty(t, headers.Get("X-Agent-Session"))
+	assert.Equal(t, "audit-id", headers.Get(RequestIDHeader))
+}
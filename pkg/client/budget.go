@@ -0,0 +1,167 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	sessionUsages sync.Map
+)
+
+// BudgetConfig holds per-session budget configuration. A limit of zero disables that budget.
+type BudgetConfig struct {
+	MaxToolCalls     int64 // Maximum number of tool calls per session
+	MaxMutatingCalls int64 // Maximum number of tool calls that are not read-only per session
+	MaxVaultRequests int64 // Maximum number of requests sent to Vault per session
+}
+
+// LoadBudgetConfigFromEnv loads session budget configuration from environment variables
+func LoadBudgetConfigFromEnv() BudgetConfig {
+	return BudgetConfig{
+		MaxToolCalls:     parseBudget("MCP_SESSION_MAX_TOOL_CALLS"),
+		MaxMutatingCalls: parseBudget("MCP_SESSION_MAX_MUTATING_CALLS"),
+		MaxVaultRequests: parseBudget("MCP_SESSION_MAX_VAULT_REQUESTS"),
+	}
+}
+
+// parseBudget parses a non-negative budget from the given environment variable
+func parseBudget(env string) int64 {
+	value := os.Getenv(env)
+	if value == "" {
+		return 0
+	}
+
+	budget, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || budget < 0 {
+		log.Warnf("Invalid %s value '%s', the budget is disabled", env, value)
+		return 0
+	}
+
+	log.Infof("%s set to %d", env, budget)
+	return budget
+}
+
+// SessionUsage tracks how much of its budget a session has used
+type SessionUsage struct {
+	ToolCalls     atomic.Int64
+	MutatingCalls atomic.Int64
+	VaultRequests atomic.Int64
+}
+
+// GetSessionUsage returns the usage counters for the given session, creating them if needed
+func GetSessionUsage(sessionID string) *SessionUsage {
+	value, _ := sessionUsages.LoadOrStore(sessionID, &SessionUsage{})
+	return value.(*SessionUsage)
+}
+
+// DeleteSessionUsage removes the usage counters for the given session
+func DeleteSessionUsage(sessionID string) {
+	sessionUsages.Delete(sessionID)
+}
+
+// BudgetMiddleware enforces the per-session budgets on tool calls
+type BudgetMiddleware struct {
+	config BudgetConfig
+	logger *log.Logger
+}
+
+// NewBudgetMiddleware creates a new session budget middleware
+func NewBudgetMiddleware(config BudgetConfig, logger *log.Logger) *BudgetMiddleware {
+	return &BudgetMiddleware{
+		config: config,
+		logger: logger,
+	}
+}
+
+// Middleware returns the tool handler middleware function
+func (m *BudgetMiddleware) Middleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			sessionID := getSessionIDFromContext(ctx)
+			if sessionID == "" {
+				return next(ctx, request)
+			}
+
+			toolName := request.Params.Name
+			usage := GetSessionUsage(sessionID)
+
+			if m.config.MaxVaultRequests > 0 && usage.VaultRequests.Load() >= m.config.MaxVaultRequests {
+				RequestLogger(ctx, m.logger).Warnf("Vault request budget exceeded for session: %s, tool: %s", sessionID, toolName)
+				return nil, fmt.Errorf("budget exceeded: this session has used its budget of %d Vault requests", m.config.MaxVaultRequests)
+			}
+
+			if m.config.MaxToolCalls > 0 && usage.ToolCalls.Add(1) > m.config.MaxToolCalls {
+				RequestLogger(ctx, m.logger).Warnf("Tool call budget exceeded for session: %s, tool: %s", sessionID, toolName)
+				return nil, fmt.Errorf("budget exceeded: this session has used its budget of %d tool calls", m.config.MaxToolCalls)
+			}
+
+			if m.config.MaxMutatingCalls > 0 && !IsReadOnlyTool(ctx, toolName) && usage.MutatingCalls.Add(1) > m.config.MaxMutatingCalls {
+				RequestLogger(ctx, m.logger).Warnf("Mutating call budget exceeded for session: %s, tool: %s", sessionID, toolName)
+				return nil, fmt.Errorf("budget exceeded: this session has used its budget of %d mutating tool calls", m.config.MaxMutatingCalls)
+			}
+
+			return next(ctx, request)
+		}
+	}
+}
+
+// ResourceMiddleware returns the resource handler middleware function. Resource reads do not count as tool calls,
+// but they send requests to Vault, so they are refused once the session has used its Vault request budget.
+func (m *BudgetMiddleware) ResourceMiddleware() server.ResourceHandlerMiddleware {
+	return func(next server.ResourceHandlerFunc) server.ResourceHandlerFunc {
+		return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			sessionID := getSessionIDFromContext(ctx)
+			if sessionID == "" || m.config.MaxVaultRequests <= 0 {
+				return next(ctx, request)
+			}
+
+			if GetSessionUsage(sessionID).VaultRequests.Load() >= m.config.MaxVaultRequests {
+				RequestLogger(ctx, m.logger).Warnf("Vault request budget exceeded for session: %s, resource: %s", sessionID, request.Params.URI)
+				return nil, fmt.Errorf("budget exceeded: this session has used its budget of %d Vault requests", m.config.MaxVaultRequests)
+			}
+
+			return next(ctx, request)
+		}
+	}
+}
+
+// IsReadOnlyTool reports whether the named tool is annotated as read-only on the server in the context
+func IsReadOnlyTool(ctx context.Context, toolName string) bool {
+	srv := server.ServerFromContext(ctx)
+	if srv == nil {
+		return false
+	}
+
+	tool := srv.GetTool(toolName)
+	if tool == nil || tool.Tool.Annotations.ReadOnlyHint == nil {
+		return false
+	}
+
+	return *tool.Tool.Annotations.ReadOnlyHint
+}
+
+// countRequests wraps the retry policy of a Vault client so that every attempt sent to Vault,
+// including retries, is counted against the session budget
+func countRequests(sessionID string, next func(context.Context, *http.Response, error) (bool, error)) func(context.Context, *http.Response, error) (bool, error) {
+	if next == nil {
+		next = api.DefaultRetryPolicy
+	}
+	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		GetSessionUsage(sessionID).VaultRequests.Add(1)
+		return next(ctx, resp, err)
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+func newBudgetTestServer(opts ...server.ServerOption) *server.MCPServer {
+	srv := server.NewMCPServer("test", "1.0.0", opts...)
+	mockHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("success"), nil
+	}
+	srv.AddTool(mcp.NewTool("read_tool", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: boolPtr(true)})), mockHandler)
+	srv.AddTool(mcp.NewTool("write_tool"), mockHandler)
+	return srv
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestBudgetMiddlewareToolCalls(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	sessionID := "budget-tool-calls"
+	defer DeleteSessionUsage(sessionID)
+
+	srv := newBudgetTestServer()
+	ctx := srv.WithContext(context.Background(), &mockClientSession{id: sessionID})
+
+	middleware := NewBudgetMiddleware(BudgetConfig{MaxToolCalls: 2}, logger)
+	handler := middleware.Middleware()(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("success"), nil
+	})
+
+	request := mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "read_tool"}}
+
+	for i := 0; i < 2; i++ {
+		if _, err := handler(ctx, request); err != nil {
+			t.Fatalf("Call %d should succeed, got error: %v", i+1, err)
+		}
+	}
+
+	_, err := handler(ctx, request)
+	if err == nil {
+		t.Fatal("Third call should exceed the budget")
+	}
+	if err.Error() != "budget exceeded: this session has used its budget of 2 tool calls" {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Other sessions have their own budget
+	otherCtx := srv.WithContext(context.Background(), &mockClientSession{id: "budget-other"})
+	defer DeleteSessionUsage("budget-other")
+	if _, err := handler(otherCtx, request); err != nil {
+		t.Fatalf("Call from another session should succeed, got error: %v", err)
+	}
+}
+
+func TestBudgetMiddlewareMutatingCalls(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	sessionID := "budget-mutating-calls"
+	defer DeleteSessionUsage(sessionID)
+
+	// The tool annotations are only available when the call goes through the server
+	middleware := NewBudgetMiddleware(BudgetConfig{MaxMutatingCalls: 1}, logger)
+	srv := newBudgetTestServer(server.WithToolHandlerMiddleware(middleware.Middleware()))
+	ctx := srv.WithContext(context.Background(), &mockClientSession{id: sessionID})
+
+	callTool := func(name string) *mcp.JSONRPCError {
+		message := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":%q}}`, name)
+		response := srv.HandleMessage(ctx, []byte(message))
+		if rpcErr, ok := response.(mcp.JSONRPCError); ok {
+			return &rpcErr
+		}
+		return nil
+	}
+
+	if err := callTool("write_tool"); err != nil {
+		t.Fatalf("First mutating call should succeed, got error: %v", err.Error.Message)
+	}
+	if err := callTool("write_tool"); err == nil {
+		t.Fatal("Second mutating call should exceed the budget")
+	}
+	if err := callTool("read_tool"); err != nil {
+		t.Fatalf("Read-only calls should not be limited, got error: %v", err.Error.Message)
+	}
+}
+
+func TestBudgetMiddlewareVaultRequests(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	sessionID := "budget-vault-requests"
+	defer DeleteSessionUsage(sessionID)
+	defer DeleteVaultClient(sessionID)
+
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	defer mockVault.Close()
+
+	vault, err := NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	if err != nil {
+		t.Fatalf("Failed to create Vault client: %v", err)
+	}
+
+	srv := newBudgetTestServer()
+	ctx := srv.WithContext(context.Background(), &mockClientSession{id: sessionID})
+
+	middleware := NewBudgetMiddleware(BudgetConfig{MaxVaultRequests: 2}, logger)
+	handler := middleware.Middleware()(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Each call sends two requests to Vault
+		for i := 0; i < 2; i++ {
+			if _, err := vault.Logical().Read("secret/data/test"); err != nil {
+				return nil, err
+			}
+		}
+		return mcp.NewToolResultText("success"), nil
+	})
+
+	request := mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "read_tool"}}
+
+	if _, err := handler(ctx, request); err != nil {
+		t.Fatalf("First call should succeed, got error: %v", err)
+	}
+	if got := GetSessionUsage(sessionID).VaultRequests.Load(); got != 2 {
+		t.Fatalf("Expected 2 Vault requests to be counted, got %d", got)
+	}
+	if _, err := handler(ctx, request); err == nil {
+		t.Fatal("Second call should exceed the Vault request budget")
+	}
+}
+
+func TestBudgetResourceMiddleware(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	sessionID := "budget-resource-reads"
+	defer DeleteSessionUsage(sessionID)
+
+	srv := newBudgetTestServer()
+	ctx := srv.WithContext(context.Background(), &mockClientSession{id: sessionID})
+
+	middleware := NewBudgetMiddleware(BudgetConfig{MaxToolCalls: 1, MaxVaultRequests: 2}, logger)
+	handler := middleware.ResourceMiddleware()(func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		return nil, nil
+	})
+
+	request := mcp.ReadResourceRequest{Params: mcp.ReadResourceParams{URI: "vault://topology/mermaid"}}
+
+	// Resource reads do not use the tool call budget
+	for i := 0; i < 2; i++ {
+		if _, err := handler(ctx, request); err != nil {
+			t.Fatalf("Read %d should succeed, got error: %v", i+1, err)
+		}
+	}
+
+	GetSessionUsage(sessionID).VaultRequests.Store(2)
+	if _, err := handler(ctx, request); err == nil {
+		t.Fatal("Read should be refused once the Vault request budget is used")
+	}
+}
+
+func TestLoadBudgetConfigFromEnv(t *testing.T) {
+	t.Setenv("MCP_SESSION_MAX_TOOL_CALLS", "100")
+	t.Setenv("MCP_SESSION_MAX_MUTATING_CALLS", "invalid")
+	t.Setenv("MCP_SESSION_MAX_VAULT_REQUESTS", "-1")
+
+	config := LoadBudgetConfigFromEnv()
+
+	if config.MaxToolCalls != 100 {
+		t.Errorf("Expected tool call budget of 100, got %d", config.MaxToolCalls)
+	}
+	if config.MaxMutatingCalls != 0 {
+		t.Errorf("Expected invalid mutating call budget to be disabled, got %d", config.MaxMutatingCalls)
+	}
+	if config.MaxVaultRequests != 0 {
+		t.Errorf("Expected negative Vault request budget to be disabled, got %d", config.MaxVaultRequests)
+	}
+}
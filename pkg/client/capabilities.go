@@ -0,0 +1,229 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// CapabilityCacheTTLEnv sets how long the capabilities of the token of a session are cached, 0 disables the cache
+	CapabilityCacheTTLEnv = "MCP_CAPABILITY_CACHE_TTL"
+	// DefaultCapabilityCacheTTL is how long capabilities are cached when MCP_CAPABILITY_CACHE_TTL is not set
+	DefaultCapabilityCacheTTL = 30 * time.Second
+)
+
+// capabilityChangingPaths are the paths whose writes can change the capabilities of existing tokens
+var capabilityChangingPaths = []string{
+	"sys/policy/",
+	"sys/policies/",
+	"auth/token/",
+	"identity/",
+}
+
+// capabilityKey identifies the capabilities of a token on a path in a namespace
+type capabilityKey struct {
+	scope string
+	path  string
+}
+
+type capabilityEntry struct {
+	capabilities []string
+	expires      time.Time
+}
+
+// capabilityCache holds the results of sys/capabilities-self per session
+type capabilityCache struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	now      func() time.Time
+	sessions map[string]map[capabilityKey]capabilityEntry
+}
+
+var capabilities = &capabilityCache{
+	ttl:      DefaultCapabilityCacheTTL,
+	now:      time.Now,
+	sessions: map[string]map[capabilityKey]capabilityEntry{},
+}
+
+// SetCapabilityCacheTTL replaces how long capabilities are cached, 0 disables the cache
+func SetCapabilityCacheTTL(ttl time.Duration) {
+	capabilities.mu.Lock()
+	defer capabilities.mu.Unlock()
+	capabilities.ttl = ttl
+	capabilities.sessions = map[string]map[capabilityKey]capabilityEntry{}
+}
+
+// LoadCapabilityCacheTTLFromEnv returns the TTL set by MCP_CAPABILITY_CACHE_TTL, or false when it is not set or invalid
+func LoadCapabilityCacheTTLFromEnv() (time.Duration, bool) {
+	value := os.Getenv(CapabilityCacheTTLEnv)
+	if value == "" {
+		return 0, false
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl < 0 {
+		log.Warnf("Invalid %s value '%s', using default %s", CapabilityCacheTTLEnv, value, DefaultCapabilityCacheTTL)
+		return 0, false
+	}
+	return ttl, true
+}
+
+// InvalidateCapabilities drops the cached capabilities of a session
+func InvalidateCapabilities(sessionID string) {
+	capabilities.mu.Lock()
+	defer capabilities.mu.Unlock()
+	delete(capabilities.sessions, sessionID)
+}
+
+// get returns the cached capabilities on the paths and the paths that are not cached
+func (c *capabilityCache) get(sessionID, scope string, paths []string) (map[string][]string, []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached := make(map[string][]string, len(paths))
+	var missing []string
+	now := c.now()
+	for _, path := range paths {
+		entry, ok := c.sessions[sessionID][capabilityKey{scope: scope, path: path}]
+		if !ok || now.After(entry.expires) {
+			missing = append(missing, path)
+			continue
+		}
+		cached[path] = entry.capabilities
+	}
+	return cached, missing
+}
+
+// put caches capabilities and drops the expired entries of the session
+func (c *capabilityCache) put(sessionID, scope string, caps map[string][]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ttl <= 0 {
+		return
+	}
+	entries, ok := c.sessions[sessionID]
+	if !ok {
+		entries = map[capabilityKey]capabilityEntry{}
+		c.sessions[sessionID] = entries
+	}
+
+	now := c.now()
+	for key, entry := range entries {
+		if now.After(entry.expires) {
+			delete(entries, key)
+		}
+	}
+	for path, pathCaps := range caps {
+		entries[capabilityKey{scope: scope, path: path}] = capabilityEntry{capabilities: pathCaps, expires: now.Add(c.ttl)}
+	}
+}
+
+// capabilityScope identifies the token and namespace of a client without keeping the token itself
+func capabilityScope(vault *api.Client) string {
+	sum := sha256.Sum256([]byte(vault.Token()))
+	return hex.EncodeToString(sum[:8]) + "/" + vault.Namespace()
+}
+
+// SelfCapabilities returns the capabilities of the token of the client on the paths. Results are cached per
+// session for MCP_CAPABILITY_CACHE_TTL and invalidated when the session writes policies, tokens or identities,
+// so that tools can check capabilities without a Vault round trip on every call.
+func SelfCapabilities(ctx context.Context, vault *api.Client, paths []string) (map[string][]string, error) {
+	sessionID := getSessionIDFromContext(ctx)
+	if sessionID == "" {
+		return querySelfCapabilities(ctx, vault, paths)
+	}
+
+	scope := capabilityScope(vault)
+	result, missing := capabilities.get(sessionID, scope, paths)
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	queried, err := querySelfCapabilities(ctx, vault, missing)
+	if err != nil {
+		return nil, err
+	}
+	capabilities.put(sessionID, scope, queried)
+	for path, pathCaps := range queried {
+		result[path] = pathCaps
+	}
+	return result, nil
+}
+
+// querySelfCapabilities checks the capabilities on the paths in one request to sys/capabilities-self
+func querySelfCapabilities(ctx context.Context, vault *api.Client, paths []string) (map[string][]string, error) {
+	secret, err := vault.Logical().WriteWithContext(ctx, "sys/capabilities-self", map[string]interface{}{
+		"paths": paths,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]string, len(paths))
+	if secret == nil {
+		return result, nil
+	}
+	for _, path := range paths {
+		caps, ok := secret.Data[path].([]interface{})
+		if !ok {
+			continue
+		}
+		// An empty list is cached too, so that missing capabilities are not checked again
+		result[path] = []string{}
+		for _, c := range caps {
+			if s, ok := c.(string); ok {
+				result[path] = append(result[path], s)
+			}
+		}
+	}
+	return result, nil
+}
+
+// invalidateCapabilities wraps the retry policy of a Vault client so that the cached capabilities of the
+// session are dropped after it successfully writes to a path that can change them
+func invalidateCapabilities(sessionID string, next func(context.Context, *http.Response, error) (bool, error)) func(context.Context, *http.Response, error) (bool, error) {
+	if next == nil {
+		next = api.DefaultRetryPolicy
+	}
+	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		if err == nil && resp != nil && resp.StatusCode < http.StatusBadRequest && changesCapabilities(resp.Request) {
+			InvalidateCapabilities(sessionID)
+		}
+		return next(ctx, resp, err)
+	}
+}
+
+// changesCapabilities reports whether the request can change the capabilities of existing tokens
+func changesCapabilities(r *http.Request) bool {
+	if r == nil || r.URL == nil {
+		return false
+	}
+	switch r.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return false
+	}
+
+	_, path, ok := strings.Cut(r.URL.Path, "/v1/")
+	if !ok || strings.HasPrefix(path, "auth/token/lookup") {
+		return false
+	}
+	for _, prefix := range capabilityChangingPaths {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
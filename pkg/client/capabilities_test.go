@@ -0,0 +1,171 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelfCapabilities(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	var queries atomic.Int32
+	var queried []interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/sys/capabilities-self", func(w http.ResponseWriter, r *http.Request) {
+		queries.Add(1)
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		queried, _ = body["paths"].([]interface{})
+
+		data := map[string]interface{}{}
+		for _, path := range queried {
+			data[path.(string)] = []string{"read"}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	})
+	mux.HandleFunc("/v1/sys/policies/acl/app", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/v1/secret/data/app", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mockVault := httptest.NewServer(mux)
+	defer mockVault.Close()
+
+	sessionID := "test-self-capabilities"
+	_, err := NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	defer DeleteVaultClient(sessionID)
+	defer InvalidateCapabilities(sessionID)
+
+	ctx := server.NewMCPServer("test", "1.0.0").WithContext(context.Background(), &mockClientSession{id: sessionID})
+	vault, err := GetVaultClientFromContext(ctx, logger)
+	require.NoError(t, err)
+
+	t.Run("queries Vault on a miss", func(t *testing.T) {
+		caps, err := SelfCapabilities(ctx, vault, []string{"secret/data/app"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]string{"secret/data/app": {"read"}}, caps)
+		assert.Equal(t, int32(1), queries.Load())
+	})
+
+	t.Run("serves cached paths and queries only the missing ones", func(t *testing.T) {
+		caps, err := SelfCapabilities(ctx, vault, []string{"secret/data/app", "sys/mounts"})
+		require.NoError(t, err)
+		assert.Len(t, caps, 2)
+		assert.Equal(t, int32(2), queries.Load())
+		assert.Equal(t, []interface{}{"sys/mounts"}, queried)
+
+		_, err = SelfCapabilities(ctx, vault, []string{"secret/data/app", "sys/mounts"})
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), queries.Load())
+	})
+
+	t.Run("other writes keep the cache", func(t *testing.T) {
+		_, err := vault.Logical().Write("secret/data/app", map[string]interface{}{"data": map[string]interface{}{}})
+		require.NoError(t, err)
+
+		_, err = SelfCapabilities(ctx, vault, []string{"secret/data/app"})
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), queries.Load())
+	})
+
+	t.Run("policy writes invalidate the cache", func(t *testing.T) {
+		_, err := vault.Logical().Write("sys/policies/acl/app", map[string]interface{}{"policy": ""})
+		require.NoError(t, err)
+
+		_, err = SelfCapabilities(ctx, vault, []string{"secret/data/app"})
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), queries.Load())
+	})
+
+	t.Run("another namespace is cached separately", func(t *testing.T) {
+		_, err := SelfCapabilities(ctx, vault.WithNamespace("team-a"), []string{"secret/data/app"})
+		require.NoError(t, err)
+		assert.Equal(t, int32(4), queries.Load())
+	})
+
+	t.Run("entries expire after the TTL", func(t *testing.T) {
+		now := time.Now()
+		capabilities.now = func() time.Time { return now }
+		defer func() { capabilities.now = time.Now }()
+
+		_, err := SelfCapabilities(ctx, vault, []string{"sys/auth"})
+		require.NoError(t, err)
+		assert.Equal(t, int32(5), queries.Load())
+
+		now = now.Add(DefaultCapabilityCacheTTL + time.Second)
+		_, err = SelfCapabilities(ctx, vault, []string{"sys/auth"})
+		require.NoError(t, err)
+		assert.Equal(t, int32(6), queries.Load())
+	})
+
+	t.Run("a TTL of zero disables the cache", func(t *testing.T) {
+		SetCapabilityCacheTTL(0)
+		defer SetCapabilityCacheTTL(DefaultCapabilityCacheTTL)
+
+		_, err := SelfCapabilities(ctx, vault, []string{"sys/auth"})
+		require.NoError(t, err)
+		_, err = SelfCapabilities(ctx, vault, []string{"sys/auth"})
+		require.NoError(t, err)
+		assert.Equal(t, int32(8), queries.Load())
+	})
+}
+
+func TestChangesCapabilities(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		want   bool
+	}{
+		{http.MethodPut, "/v1/sys/policies/acl/app", true},
+		{http.MethodDelete, "/v1/sys/policy/app", true},
+		{http.MethodPost, "/v1/auth/token/roles/agent", true},
+		{http.MethodPut, "/v1/identity/group/name/admins", true},
+		{http.MethodPost, "/v1/auth/token/lookup-self", false},
+		{http.MethodGet, "/v1/sys/policies/acl/app", false},
+		{http.MethodPut, "/v1/secret/data/sys/policies/acl", false},
+		{http.MethodPut, "/v1/sys/capabilities-self", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "http://vault.example.com:8200"+tt.path, nil)
+			assert.Equal(t, tt.want, changesCapabilities(req))
+		})
+	}
+}
+
+func TestLoadCapabilityCacheTTLFromEnv(t *testing.T) {
+	_, ok := LoadCapabilityCacheTTLFromEnv()
+	assert.False(t, ok)
+
+	t.Setenv(CapabilityCacheTTLEnv, "5s")
+	ttl, ok := LoadCapabilityCacheTTLFromEnv()
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, ttl)
+
+	t.Setenv(CapabilityCacheTTLEnv, "0")
+	ttl, ok = LoadCapabilityCacheTTLFromEnv()
+	assert.True(t, ok)
+	assert.Zero(t, ttl)
+
+	t.Setenv(CapabilityCacheTTLEnv, "soon")
+	_, ok = LoadCapabilityCacheTTLFromEnv()
+	assert.False(t, ok)
+}
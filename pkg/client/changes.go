@@ -0,0 +1,180 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/i18n"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Operations of change records
+const (
+	OperationCreate = "create"
+	OperationUpdate = "update"
+	OperationDelete = "delete"
+)
+
+// Resource types of change records
+const (
+	ResourceMount               = "mount"
+	ResourceAuthMethod          = "auth_method"
+	ResourceKVSecret            = "kv_secret"
+	ResourcePKIIssuer           = "pki_issuer"
+	ResourcePKIKey              = "pki_key"
+	ResourcePKIRole             = "pki_role"
+	ResourceTransformTemplate   = "transform_template"
+	ResourceTransformation      = "transformation"
+	ResourceTransformRole       = "transform_role"
+	ResourceCertAuthRole        = "cert_auth_role"
+	ResourceGitHubConfig        = "github_auth_config"
+	ResourceGitHubMapping       = "github_mapping"
+	ResourceMFAMethod           = "mfa_method"
+	ResourceMFALoginEnforcement = "mfa_login_enforcement"
+	ResourceUIHeader            = "ui_header"
+	ResourceManagedKeyMount     = "managed_key_mount"
+	ResourceDatabaseConnection  = "database_connection"
+	ResourceDatabaseRole        = "database_role"
+	ResourceMongoDBAtlasConfig  = "mongodb_atlas_config"
+	ResourceMongoDBAtlasRole    = "mongodb_atlas_role"
+	ResourceRawEndpoint         = "raw_endpoint"
+	ResourceAuditDevice         = "audit_device"
+	ResourcePolicy              = "policy"
+	ResourceUserpassUser        = "userpass_user"
+	ResourceTokenRole           = "token_role"
+	ResourceNamespace           = "namespace"
+	ResourceAuthRole            = "auth_role"
+	ResourceQuota               = "quota"
+	ResourceTransitKey          = "transit_key"
+)
+
+// maxSessionChanges bounds the change records kept per session, the oldest records are dropped first
+var maxSessionChanges = 1000
+
+var (
+	sessionChanges sync.Map
+)
+
+// ChangeRecord describes a change that a tool made in Vault. The previous and new states are summaries,
+// such as versions and key names, and never hold secret values.
+type ChangeRecord struct {
+	ID             int            `json:"id"`                         // Sequence number of the change in the session
+	Time           time.Time      `json:"time"`                       // When the change was made
+	Tool           string         `json:"tool"`                       // Tool that made the change
+	ResourceType   string         `json:"resource_type"`              // Type of the changed resource, such as 'kv_secret' or 'mount'
+	Mount          string         `json:"mount,omitempty"`            // Mount of the changed resource, if any
+	Path           string         `json:"path"`                       // Vault path of the changed resource
+	Namespace      string         `json:"namespace,omitempty"`        // Vault namespace of the changed resource
+	Operation      string         `json:"operation"`                  // create, update or delete
+	Previous       map[string]any `json:"previous,omitempty"`         // Summary of the state before the change
+	New            map[string]any `json:"new,omitempty"`              // Summary of the state after the change
+	RequestID      string         `json:"request_id,omitempty"`       // Request ID of the tool call
+	VaultRequestID string         `json:"vault_request_id,omitempty"` // ID Vault assigned to the request that made the change, if returned
+	Message        string         `json:"message"`                    // Description of the change
+	UndoOf         int            `json:"undo_of,omitempty"`          // ID of the change this change reverted
+	UndoneBy       int            `json:"undone_by,omitempty"`        // ID of the change that reverted this change
+}
+
+// changeJournal holds the change records of a session in the order they were made
+type changeJournal struct {
+	mu      sync.Mutex
+	nextID  int
+	records []ChangeRecord
+}
+
+func getChangeJournal(sessionID string) *changeJournal {
+	value, _ := sessionChanges.LoadOrStore(sessionID, &changeJournal{nextID: 1})
+	return value.(*changeJournal)
+}
+
+// RecordChange adds a change to the journal of the session in the context and returns it with its ID,
+// time, namespace and request ID filled in. Changes made outside of a session are returned but not kept.
+func RecordChange(ctx context.Context, change ChangeRecord) ChangeRecord {
+	change.Time = time.Now().UTC()
+	change.RequestID = RequestIDFromContext(ctx)
+	if change.Namespace == "" {
+		change.Namespace = changeNamespace(ctx)
+	}
+
+	sessionID := getSessionIDFromContext(ctx)
+	if sessionID == "" {
+		return change
+	}
+
+	journal := getChangeJournal(sessionID)
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+
+	change.ID = journal.nextID
+	journal.nextID++
+	journal.records = append(journal.records, change)
+	if len(journal.records) > maxSessionChanges {
+		journal.records = journal.records[len(journal.records)-maxSessionChanges:]
+	}
+	return change
+}
+
+// SessionChanges returns the change records of a session, oldest first
+func SessionChanges(sessionID string) []ChangeRecord {
+	value, ok := sessionChanges.Load(sessionID)
+	if !ok {
+		return nil
+	}
+	journal := value.(*changeJournal)
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+	return append([]ChangeRecord(nil), journal.records...)
+}
+
+// MarkChangeUndone records that a change of the session was reverted by the change undoID
+func MarkChangeUndone(sessionID string, id int, undoID int) {
+	value, ok := sessionChanges.Load(sessionID)
+	if !ok {
+		return
+	}
+	journal := value.(*changeJournal)
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+	for i := range journal.records {
+		if journal.records[i].ID == id {
+			journal.records[i].UndoneBy = undoID
+			return
+		}
+	}
+}
+
+// DeleteSessionChanges removes the change records of a session
+func DeleteSessionChanges(sessionID string) {
+	sessionChanges.Delete(sessionID)
+}
+
+// changeNamespace returns the namespace the tool call runs in
+func changeNamespace(ctx context.Context) string {
+	if namespace := namespaceFromContext(ctx); namespace != "" {
+		return namespace
+	}
+	if client := GetVaultClient(getSessionIDFromContext(ctx)); client != nil {
+		return client.Namespace()
+	}
+	return ""
+}
+
+// NewChangeResult records a change made by the tool call and returns the record as the result of the call
+func NewChangeResult(ctx context.Context, req mcp.CallToolRequest, change ChangeRecord) *mcp.CallToolResult {
+	change.Tool = req.Params.Name
+	change = RecordChange(ctx, change)
+	// The record keeps the English message, the result carries the message in the locale of the client
+	change.Message = i18n.Translate(ctx, change.Message)
+
+	jsonData, err := json.Marshal(change)
+	if err != nil {
+		// The change was made, so report it even if the record cannot be encoded
+		return mcp.NewToolResultText(change.Message)
+	}
+	return mcp.NewToolResultText(string(jsonData))
+}
@@ -0,0 +1,103 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/i18n"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordChange(t *testing.T) {
+	sessionID := "test-record-change"
+	defer DeleteSessionChanges(sessionID)
+
+	ctx := server.NewMCPServer("test", "1.0.0").WithContext(WithRequestID(context.Background(), "change-id"), &mockClientSession{id: sessionID})
+
+	first := RecordChange(ctx, ChangeRecord{Tool: "create_mount", ResourceType: ResourceMount, Path: "sys/mounts/app", Operation: OperationCreate})
+	second := RecordChange(context.WithValue(ctx, namespaceOverrideKey{}, "team-a"), ChangeRecord{Tool: "write_secret", ResourceType: ResourceKVSecret, Path: "app/data/config", Operation: OperationCreate})
+
+	assert.Equal(t, 1, first.ID)
+	assert.Equal(t, 2, second.ID)
+	assert.Equal(t, "change-id", first.RequestID)
+	assert.False(t, first.Time.IsZero())
+	assert.Empty(t, first.Namespace)
+	assert.Equal(t, "team-a", second.Namespace)
+
+	changes := SessionChanges(sessionID)
+	require.Len(t, changes, 2)
+	assert.Equal(t, "create_mount", changes[0].Tool)
+	assert.Equal(t, "write_secret", changes[1].Tool)
+
+	DeleteSessionChanges(sessionID)
+	assert.Empty(t, SessionChanges(sessionID))
+}
+
+func TestRecordChange_KeepsTheMostRecentChanges(t *testing.T) {
+	sessionID := "test-record-change-cap"
+	defer DeleteSessionChanges(sessionID)
+
+	previous := maxSessionChanges
+	maxSessionChanges = 3
+	defer func() { maxSessionChanges = previous }()
+
+	ctx := server.NewMCPServer("test", "1.0.0").WithContext(context.Background(), &mockClientSession{id: sessionID})
+	for i := 0; i < 5; i++ {
+		RecordChange(ctx, ChangeRecord{Operation: OperationUpdate})
+	}
+
+	changes := SessionChanges(sessionID)
+	require.Len(t, changes, 3)
+	assert.Equal(t, 3, changes[0].ID)
+	assert.Equal(t, 5, changes[2].ID)
+}
+
+func TestRecordChange_WithoutSession(t *testing.T) {
+	change := RecordChange(context.Background(), ChangeRecord{Operation: OperationDelete})
+	assert.Zero(t, change.ID)
+	assert.False(t, change.Time.IsZero())
+}
+
+func TestNewChangeResult(t *testing.T) {
+	sessionID := "test-change-result"
+	defer DeleteSessionChanges(sessionID)
+
+	ctx := server.NewMCPServer("test", "1.0.0").WithContext(context.Background(), &mockClientSession{id: sessionID})
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "delete_mount"}}
+
+	result := NewChangeResult(ctx, req, ChangeRecord{
+		ResourceType: ResourceMount,
+		Path:         "sys/mounts/app",
+		Operation:    OperationDelete,
+		Previous:     map[string]any{"type": "kv"},
+		Message:      "Deleted mount 'app'.",
+	})
+	require.False(t, result.IsError)
+
+	var change ChangeRecord
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &change))
+	assert.Equal(t, 1, change.ID)
+	assert.Equal(t, "delete_mount", change.Tool)
+	assert.Equal(t, "kv", change.Previous["type"])
+	assert.Equal(t, "Deleted mount 'app'.", change.Message)
+	assert.Len(t, SessionChanges(sessionID), 1)
+
+	t.Run("messages are in the locale of the client", func(t *testing.T) {
+		result := NewChangeResult(i18n.WithLocale(ctx, "fr"), req, ChangeRecord{
+			ResourceType: ResourceMount,
+			Path:         "sys/mounts/app",
+			Operation:    OperationDelete,
+			Message:      "Successfully deleted mount at path 'app'",
+		})
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &change))
+		assert.Equal(t, "Montage supprimé au chemin 'app'", change.Message)
+		assert.Equal(t, "Successfully deleted mount at path 'app'", SessionChanges(sessionID)[1].Message, "the record stays in English")
+	})
+}
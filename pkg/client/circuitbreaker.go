@@ -0,0 +1,279 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half-open"
+)
+
+var (
+	circuitBreakers sync.Map
+
+	// ErrCircuitOpen is returned when a tool call is rejected by an open circuit breaker
+	ErrCircuitOpen = errors.New("circuit breaker open")
+)
+
+// CircuitBreakerConfig holds circuit breaker configuration
+type CircuitBreakerConfig struct {
+	FailureThreshold int           // Consecutive failures before the circuit opens, zero disables the circuit breaker
+	Cooldown         time.Duration // Time the circuit stays open before a trial request is allowed
+}
+
+// DefaultCircuitBreakerConfig returns a sensible default configuration
+func DefaultCircuitBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		FailureThreshold: 5,
+		Cooldown:         30 * time.Second,
+	}
+}
+
+// LoadCircuitBreakerConfigFromEnv loads circuit breaker configuration from environment variables
+func LoadCircuitBreakerConfigFromEnv() CircuitBreakerConfig {
+	config := DefaultCircuitBreakerConfig()
+
+	if threshold := os.Getenv("MCP_CIRCUIT_BREAKER_THRESHOLD"); threshold != "" {
+		if parsed, err := strconv.Atoi(threshold); err == nil && parsed >= 0 {
+			config.FailureThreshold = parsed
+			log.Infof("Circuit breaker failure threshold set to %d", parsed)
+		} else {
+			log.Warnf("Invalid MCP_CIRCUIT_BREAKER_THRESHOLD value, using default %d", config.FailureThreshold)
+		}
+	}
+
+	if cooldown := os.Getenv("MCP_CIRCUIT_BREAKER_COOLDOWN"); cooldown != "" {
+		if parsed, err := time.ParseDuration(cooldown); err == nil && parsed > 0 {
+			config.Cooldown = parsed
+			log.Infof("Circuit breaker cooldown set to %s", parsed)
+		} else {
+			log.Warnf("Invalid MCP_CIRCUIT_BREAKER_COOLDOWN value, using default %s", config.Cooldown)
+		}
+	}
+
+	return config
+}
+
+// CircuitBreaker tracks consecutive failures of a single Vault server for a session
+type CircuitBreaker struct {
+	config   CircuitBreakerConfig
+	state    string
+	failures int
+	failed   map[context.Context]bool // Requests already counted in failures, whatever their retries do
+	trial    bool                     // Whether the trial request of the half-open circuit is in flight
+	openedAt time.Time
+	mu       sync.Mutex
+	now      func() time.Time
+}
+
+// circuitKey identifies the circuit breaker of a session for a Vault address
+type circuitKey struct {
+	sessionID string
+	address   string
+}
+
+// NewCircuitBreaker creates a new circuit breaker in the closed state
+func NewCircuitBreaker(config CircuitBreakerConfig) *CircuitBreaker {
+	return &CircuitBreaker{
+		config: config,
+		state:  CircuitClosed,
+		now:    time.Now,
+	}
+}
+
+// GetCircuitBreaker gets or creates the circuit breaker of a session for a Vault address, so that a
+// session failing on its own, such as with an expired token, does not fail the other sessions fast
+func GetCircuitBreaker(sessionID string, address string, config CircuitBreakerConfig) *CircuitBreaker {
+	breaker, _ := circuitBreakers.LoadOrStore(circuitKey{sessionID, address}, NewCircuitBreaker(config))
+	return breaker.(*CircuitBreaker)
+}
+
+// DeleteSessionCircuitBreakers removes the circuit breakers of a session
+func DeleteSessionCircuitBreakers(sessionID string) {
+	circuitBreakers.Range(func(key, _ any) bool {
+		if key.(circuitKey).sessionID == sessionID {
+			circuitBreakers.Delete(key)
+		}
+		return true
+	})
+}
+
+// Allow reports whether a request may be sent. When the circuit is open it also returns
+// how long the caller should wait before retrying.
+func (b *CircuitBreaker) Allow() (bool, time.Duration) {
+	allowed, _, retryAfter := b.allow()
+	return allowed, retryAfter
+}
+
+// allow reports whether a request may be sent, and whether it is the trial request of the half-open
+// circuit. Only one trial request is let through until its result is recorded or endTrial is called.
+func (b *CircuitBreaker) allow() (bool, bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitClosed:
+		return true, false, 0
+	case CircuitHalfOpen:
+		if b.trial {
+			return false, false, b.config.Cooldown
+		}
+		b.trial = true
+		return true, true, 0
+	}
+
+	elapsed := b.now().Sub(b.openedAt)
+	if elapsed >= b.config.Cooldown {
+		// Let a trial request through; its result closes or re-opens the circuit
+		b.state = CircuitHalfOpen
+		b.trial = true
+		return true, true, 0
+	}
+
+	return false, false, b.config.Cooldown - elapsed
+}
+
+// endTrial lets the next caller try when the trial request finished without recording a result, such
+// as a tool call that failed before sending anything to Vault
+func (b *CircuitBreaker) endTrial() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+}
+
+// State returns the current state of the circuit
+func (b *CircuitBreaker) State() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// RecordSuccess closes the circuit and resets the failure count
+func (b *CircuitBreaker) RecordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = CircuitClosed
+	b.failures = 0
+	b.trial = false
+}
+
+// RecordFailure counts a failure and opens the circuit once the threshold is reached
+func (b *CircuitBreaker) RecordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.recordFailure()
+}
+
+func (b *CircuitBreaker) recordFailure() {
+	b.failures++
+	b.trial = false
+	if b.state == CircuitHalfOpen || b.failures >= b.config.FailureThreshold {
+		b.state = CircuitOpen
+		b.openedAt = b.now()
+	}
+}
+
+// recordRequestFailure counts the failure of a request once, however many times it is retried. The
+// attempts of a request share its context, which the Vault client cancels when the request returns.
+func (b *CircuitBreaker) recordRequestFailure(request context.Context) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failed[request] {
+		return
+	}
+	if b.failed == nil {
+		b.failed = map[context.Context]bool{}
+	}
+	b.failed[request] = true
+	context.AfterFunc(request, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.failed, request)
+	})
+	b.recordFailure()
+}
+
+// recordCircuitResult wraps the retry policy of a Vault client so that the result of every request is
+// recorded on the circuit breaker of the session for the Vault address
+func recordCircuitResult(sessionID string, address string, next func(context.Context, *http.Response, error) (bool, error)) func(context.Context, *http.Response, error) (bool, error) {
+	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		if value, ok := circuitBreakers.Load(circuitKey{sessionID, address}); ok {
+			breaker := value.(*CircuitBreaker)
+			switch {
+			case ctx.Err() != nil:
+				// The caller gave up, this says nothing about the health of Vault
+			case err != nil, resp != nil && resp.StatusCode >= http.StatusInternalServerError:
+				breaker.recordRequestFailure(ctx)
+			default:
+				breaker.RecordSuccess()
+			}
+		}
+		return next(ctx, resp, err)
+	}
+}
+
+// CircuitBreakerMiddleware fails tool calls fast while the Vault server of the session is failing
+type CircuitBreakerMiddleware struct {
+	config CircuitBreakerConfig
+	logger *log.Logger
+}
+
+// NewCircuitBreakerMiddleware creates a new circuit breaker middleware
+func NewCircuitBreakerMiddleware(config CircuitBreakerConfig, logger *log.Logger) *CircuitBreakerMiddleware {
+	return &CircuitBreakerMiddleware{
+		config: config,
+		logger: logger,
+	}
+}
+
+// Middleware returns the tool handler middleware function
+func (m *CircuitBreakerMiddleware) Middleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if m.config.FailureThreshold == 0 {
+				return next(ctx, request)
+			}
+
+			sessionID := getSessionIDFromContext(ctx)
+			if sessionID == "" {
+				return next(ctx, request)
+			}
+
+			vault := GetVaultClient(sessionID)
+			if vault == nil {
+				return next(ctx, request)
+			}
+
+			breaker := GetCircuitBreaker(sessionID, vault.Address(), m.config)
+			allowed, trial, retryAfter := breaker.allow()
+			if !allowed {
+				RequestLogger(ctx, m.logger).Warnf("Circuit breaker open for Vault at %s, tool: %s", vault.Address(), request.Params.Name)
+				return nil, fmt.Errorf("%w: Vault at %s is failing, retry after %d seconds", ErrCircuitOpen, vault.Address(), int(math.Ceil(retryAfter.Seconds())))
+			}
+			if trial {
+				defer breaker.endTrial()
+			}
+
+			return next(ctx, request)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+func TestCircuitBreakerStates(t *testing.T) {
+	now := time.Now()
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 2, Cooldown: 10 * time.Second})
+	breaker.now = func() time.Time { return now }
+
+	breaker.RecordFailure()
+	if allowed, _ := breaker.Allow(); !allowed {
+		t.Fatal("Circuit should stay closed below the failure threshold")
+	}
+
+	breaker.RecordFailure()
+	allowed, retryAfter := breaker.Allow()
+	if allowed {
+		t.Fatal("Circuit should open at the failure threshold")
+	}
+	if retryAfter != 10*time.Second {
+		t.Errorf("Expected retry after 10s, got %s", retryAfter)
+	}
+
+	// After the cooldown a trial request is allowed
+	now = now.Add(10 * time.Second)
+	if allowed, _ := breaker.Allow(); !allowed {
+		t.Fatal("Circuit should half-open after the cooldown")
+	}
+	if breaker.State() != CircuitHalfOpen {
+		t.Fatalf("Expected half-open state, got %s", breaker.State())
+	}
+
+	// A failed trial request re-opens the circuit immediately
+	breaker.RecordFailure()
+	if breaker.State() != CircuitOpen {
+		t.Fatalf("Expected open state after failed trial, got %s", breaker.State())
+	}
+
+	// A successful trial request closes the circuit
+	now = now.Add(10 * time.Second)
+	breaker.Allow()
+	breaker.RecordSuccess()
+	if breaker.State() != CircuitClosed {
+		t.Fatalf("Expected closed state after successful trial, got %s", breaker.State())
+	}
+}
+
+func TestCircuitBreakerSingleTrial(t *testing.T) {
+	now := time.Now()
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, Cooldown: 10 * time.Second})
+	breaker.now = func() time.Time { return now }
+	breaker.RecordFailure()
+	now = now.Add(10 * time.Second)
+
+	if allowed, trial, _ := breaker.allow(); !allowed || !trial {
+		t.Fatal("The first caller after the cooldown should be the trial")
+	}
+	if allowed, _ := breaker.Allow(); allowed {
+		t.Fatal("Other callers should wait for the result of the trial")
+	}
+
+	// A trial that sent nothing to Vault lets the next caller try
+	breaker.endTrial()
+	if allowed, trial, _ := breaker.allow(); !allowed || !trial {
+		t.Fatal("The next caller should be the trial once the previous one ended")
+	}
+	breaker.RecordSuccess()
+	if allowed, _ := breaker.Allow(); !allowed {
+		t.Fatal("Every caller should be allowed once the circuit closes")
+	}
+}
+
+func TestCircuitBreakerCountsRequests(t *testing.T) {
+	var attempts atomic.Int32
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"errors":["Vault is sealed"]}`))
+	}))
+	defer mockVault.Close()
+
+	sessionID := "circuit-breaker-retries"
+	defer DeleteSessionCircuitBreakers(sessionID)
+	defer DeleteSessionUsage(sessionID)
+	defer DeleteVaultClient(sessionID)
+
+	vault, err := NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	if err != nil {
+		t.Fatalf("Failed to create Vault client: %v", err)
+	}
+	vault.SetMaxRetries(2)
+	vault.SetMinRetryWait(time.Millisecond)
+	vault.SetMaxRetryWait(time.Millisecond)
+
+	config := CircuitBreakerConfig{FailureThreshold: 2, Cooldown: time.Minute}
+	breaker := GetCircuitBreaker(sessionID, mockVault.URL, config)
+
+	// The retries of a failed request count as a single failure
+	if _, err := vault.Logical().Read("secret/data/test"); err == nil {
+		t.Fatal("Expected the read to fail")
+	}
+	if attempts.Load() != 3 {
+		t.Fatalf("Expected 3 attempts, got %d", attempts.Load())
+	}
+	if breaker.State() != CircuitClosed {
+		t.Fatalf("Expected the circuit to stay closed after one failed request, got %s", breaker.State())
+	}
+
+	if _, err := vault.Logical().Read("secret/data/test"); err == nil {
+		t.Fatal("Expected the read to fail")
+	}
+	if breaker.State() != CircuitOpen {
+		t.Fatalf("Expected the circuit to open after two failed requests, got %s", breaker.State())
+	}
+
+	// The circuit of another session for the same Vault stays closed
+	other := GetCircuitBreaker("circuit-breaker-other", mockVault.URL, config)
+	defer DeleteSessionCircuitBreakers("circuit-breaker-other")
+	if other.State() != CircuitClosed {
+		t.Fatalf("Expected the circuit of another session to stay closed, got %s", other.State())
+	}
+}
+
+func TestCircuitBreakerMiddleware(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	// Avoid retries so that each tool call sends exactly one request
+	t.Setenv("VAULT_MAX_RETRIES", "0")
+
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"errors":["Vault is sealed"]}`))
+	}))
+	defer mockVault.Close()
+	sessionID := "circuit-breaker"
+	defer DeleteSessionCircuitBreakers(sessionID)
+	defer DeleteVaultClient(sessionID)
+	defer DeleteSessionUsage(sessionID)
+
+	vault, err := NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	if err != nil {
+		t.Fatalf("Failed to create Vault client: %v", err)
+	}
+
+	srv := server.NewMCPServer("test", "1.0.0")
+	ctx := srv.WithContext(context.Background(), &mockClientSession{id: sessionID})
+
+	calls := 0
+	middleware := NewCircuitBreakerMiddleware(CircuitBreakerConfig{FailureThreshold: 2, Cooldown: time.Minute}, logger)
+	handler := middleware.Middleware()(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls++
+		if _, err := vault.Logical().Read("secret/data/test"); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText("success"), nil
+	})
+
+	request := mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "read_secret"}}
+
+	for i := 0; i < 2; i++ {
+		if _, err := handler(ctx, request); err != nil {
+			t.Fatalf("Call %d should reach Vault, got error: %v", i+1, err)
+		}
+	}
+
+	_, err = handler(ctx, request)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected circuit breaker error, got: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected the handler to be skipped while the circuit is open, got %d calls", calls)
+	}
+}
+
+func TestLoadCircuitBreakerConfigFromEnv(t *testing.T) {
+	config := LoadCircuitBreakerConfigFromEnv()
+	if config != DefaultCircuitBreakerConfig() {
+		t.Errorf("Expected default configuration, got %+v", config)
+	}
+
+	t.Setenv("MCP_CIRCUIT_BREAKER_THRESHOLD", "3")
+	t.Setenv("MCP_CIRCUIT_BREAKER_COOLDOWN", "1m")
+
+	config = LoadCircuitBreakerConfigFromEnv()
+	if config.FailureThreshold != 3 {
+		t.Errorf("Expected failure threshold of 3, got %d", config.FailureThreshold)
+	}
+	if config.Cooldown != time.Minute {
+		t.Errorf("Expected cooldown of 1m, got %s", config.Cooldown)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/hashicorp/vault/api"
@@ -26,6 +27,7 @@ const (
 	VaultToken           = "VAULT_TOKEN"
 	VaultNamespace       = "VAULT_NAMESPACE"
 	VaultSkipTLSVerify   = "VAULT_SKIP_VERIFY"
+	VaultProxyAddress    = "VAULT_PROXY_ADDR"
 	VaultHeaderToken     = "X-Vault-Token"
 	VaultHeaderNamespace = "X-Vault-Namespace"
 )
@@ -52,7 +54,8 @@ func NewVaultClient(sessionId string, vaultAddress string, vaultSkipTLSVerify bo
 	tr := &http.Transport{
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: vaultSkipTLSVerify},
 	}
-	config.HttpClient = &http.Client{Transport: tr}
+	config.HttpClient = &http.Client{Transport: injectFaults(tr)}
+	config.CheckRetry = recordCircuitResult(sessionId, vaultAddress, countRequests(sessionId, recordAccess(sessionId, invalidateCapabilities(sessionId, config.CheckRetry))))
 
 	client, err := api.NewClient(config)
 	if err != nil {
@@ -60,6 +63,7 @@ func NewVaultClient(sessionId string, vaultAddress string, vaultSkipTLSVerify bo
 	}
 
 	client.SetToken(vaultToken)
+	setClientHeaders(sessionId, client)
 
 	if vaultNamespace != "" {
 		client.SetNamespace(vaultNamespace)
@@ -91,31 +95,167 @@ func GetVaultClientFromContext(ctx context.Context, logger *log.Logger) (*api.Cl
 	}
 
 	// Log the session ID for debugging
-	logger.WithField("session_id", session.SessionID()).Debug("Retrieving Vault client for session")
+	logger.WithFields(log.Fields{
+		"session_id":   session.SessionID(),
+		RequestIDField: RequestIDFromContext(ctx),
+	}).Debug("Retrieving Vault client for session")
 
-	// Try to get existing client
+	// Try to get existing client. The Vault settings of the request always win over the cached client,
+	// so that any server instance builds the same client for the same request.
 	client := GetVaultClient(session.SessionID())
-	if client != nil {
-		return client, nil
+	if client != nil && !requestMatchesClient(ctx, client) {
+		logger.WithField("session_id", session.SessionID()).Info("Vault settings of the request differ from the cached client, rebuilding it")
+		client = nil
+	} else if client == nil {
+		logger.WithField("session_id", session.SessionID()).Warn("Vault client not found, creating a new one")
+	}
+	if client == nil {
+
+		var err error
+		client, err = CreateVaultClientForSession(ctx, session, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The tools of a token scope call Vault with a child token limited to the policies of the scope
+	client, err := scopedClient(ctx, session.SessionID(), client, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// A namespace passed to the tool call overrides the namespace of the session
+	if namespace := namespaceFromContext(ctx); namespace != "" {
+		client = client.WithNamespace(namespace)
+	}
+
+	// Read-only tool calls are served by the read replica when one is configured
+	if address := readAddress(ctx); address != "" && readOnlyCallFromContext(ctx) {
+		logger.WithFields(log.Fields{
+			"session_id": session.SessionID(),
+			"read_addr":  address,
+		}).Debug("Routing read-only tool call to the read replica")
+		client = readReplicaClient(session.SessionID(), address, client, logger)
+	}
+
+	return withRequestHeaders(ctx, session, client), nil
+}
+
+// withRequestHeaders returns a copy of the client that identifies the session and the request ID of the
+// context in every request sent to Vault, so that they show up in the audit log of Vault and in the logs
+// of the proxies in front of it. Unless disabled, the copy also records the consistency tokens of the
+// writes of the session and sends them with its reads.
+func withRequestHeaders(ctx context.Context, session server.ClientSession, client *api.Client) *api.Client {
+	headers := http.Header{}
+	if name := GetAuditHeader(); name != "" {
+		headers.Set(name, auditHeaderValue(session))
+	}
+	if id := RequestIDFromContext(ctx); id != "" {
+		headers.Set(RequestIDHeader, id)
+		headers.Set(CorrelationIDHeader, id)
+	}
+	consistent := ReadYourWrites()
+	if len(headers) == 0 && !consistent {
+		return client
+	}
+
+	// WithRequestCallbacks replaces the callbacks of the client, so every header is set by this callback
+	sessionID := session.SessionID()
+	client = client.WithRequestCallbacks(func(r *api.Request) {
+		if r.Headers == nil {
+			r.Headers = http.Header{}
+		}
+		for name, values := range headers {
+			r.Headers[name] = values
+		}
+		if consistent {
+			requireIndex(sessionID, r)
+		}
+	})
+	if consistent {
+		client = client.WithResponseCallbacks(recordIndex(sessionID))
+	}
+	return client
+}
+
+// requestMatchesClient reports whether every Vault setting carried by the request matches the client.
+// Settings missing from the request do not prevent the client from being reused.
+func requestMatchesClient(ctx context.Context, client *api.Client) bool {
+	address, _ := ctx.Value(contextKey(VaultAddress)).(string)
+	if proxyAddress, _ := ctx.Value(contextKey(VaultProxyAddress)).(string); proxyAddress != "" {
+		address = proxyAddress
+	}
+	if address != "" && strings.TrimSuffix(address, "/") != strings.TrimSuffix(client.Address(), "/") {
+		return false
 	}
 
-	logger.WithField("session_id", session.SessionID()).Warn("Vault client not found, creating a new one")
+	if token, _ := ctx.Value(contextKey(VaultToken)).(string); token != "" && token != client.Token() {
+		return false
+	}
+
+	if namespace, _ := ctx.Value(contextKey(VaultNamespace)).(string); namespace != "" && namespace != client.Namespace() {
+		return false
+	}
+
+	if skipTLSStr, _ := ctx.Value(contextKey(VaultSkipTLSVerify)).(string); skipTLSStr != "" {
+		skipTLS, err := strconv.ParseBool(skipTLSStr)
+		if err == nil && skipTLS != clientSkipsTLSVerify(client) {
+			return false
+		}
+	}
+
+	return true
+}
 
-	return CreateVaultClientForSession(ctx, session, logger)
+// clientSkipsTLSVerify reports whether the client was created with TLS verification disabled
+func clientSkipsTLSVerify(client *api.Client) bool {
+	config := client.CloneConfig()
+	if config.HttpClient == nil {
+		return false
+	}
+	roundTripper := config.HttpClient.Transport
+	if faulty, ok := roundTripper.(*faultTransport); ok {
+		roundTripper = faulty.next
+	}
+	transport, ok := roundTripper.(*http.Transport)
+	return ok && transport.TLSClientConfig != nil && transport.TLSClientConfig.InsecureSkipVerify
 }
 
 func CreateVaultClientForSession(ctx context.Context, session server.ClientSession, logger *log.Logger) (*api.Client, error) {
 
+	// Metadata stored by another server instance is used when the request does not carry the setting,
+	// so that a session can move between instances
+	stored, err := GetSessionStore().Load(ctx, session.SessionID())
+	if err != nil {
+		logger.WithError(err).WithField("session_id", session.SessionID()).Warn("Failed to load stored session metadata")
+	}
+	if stored == nil {
+		stored = &SessionMetadata{}
+	}
+
 	// Initialize a new Vault client for this session
 	vaultAddress, ok := ctx.Value(contextKey(VaultAddress)).(string)
 	if !ok || vaultAddress == "" {
+		vaultAddress = stored.Address
+	}
+	if vaultAddress == "" {
 		vaultAddress = getEnv(VaultAddress, DefaultVaultAddress)
 	}
 
+	// A local Vault Agent or Vault Proxy can authenticate requests with its auto-auth token,
+	// so the token is optional when requests are routed through one
+	vaultProxyAddress, ok := ctx.Value(contextKey(VaultProxyAddress)).(string)
+	if !ok || vaultProxyAddress == "" {
+		vaultProxyAddress = stored.ProxyAddress
+	}
+	if vaultProxyAddress == "" {
+		vaultProxyAddress = getEnv(VaultProxyAddress, "")
+	}
+
 	vaultToken, ok := ctx.Value(contextKey(VaultToken)).(string)
 	if !ok || vaultToken == "" {
 		vaultToken = getEnv(VaultToken, "")
-		if vaultToken == "" {
+		if vaultToken == "" && vaultProxyAddress == "" {
 			//logger.Warn("Vault token not provided for session")
 			return nil, fmt.Errorf("vault token not provided for session")
 		}
@@ -123,6 +263,9 @@ func CreateVaultClientForSession(ctx context.Context, session server.ClientSessi
 
 	vaultNamespace, ok := ctx.Value(contextKey(VaultNamespace)).(string)
 	if !ok || vaultNamespace == "" {
+		vaultNamespace = stored.Namespace
+	}
+	if vaultNamespace == "" {
 		vaultNamespace = getEnv(VaultNamespace, "")
 	}
 
@@ -144,6 +287,10 @@ func CreateVaultClientForSession(ctx context.Context, session server.ClientSessi
 			}
 		}
 	}
+	if !skipProvidedInContext && stored.SkipTLSVerify {
+		vaultSkipTLSVerify = true
+		skipProvidedInContext = true
+	}
 	if !skipProvidedInContext {
 		envVal := getEnv(VaultSkipTLSVerify, "false")
 		parsed, err := strconv.ParseBool(envVal)
@@ -157,14 +304,34 @@ func CreateVaultClientForSession(ctx context.Context, session server.ClientSessi
 		}
 	}
 
+	metadata := &SessionMetadata{
+		Address:       vaultAddress,
+		Namespace:     vaultNamespace,
+		ProxyAddress:  vaultProxyAddress,
+		SkipTLSVerify: vaultSkipTLSVerify,
+	}
+
+	if vaultProxyAddress != "" {
+		logger.WithFields(log.Fields{
+			"session_id": session.SessionID(),
+			"proxy_addr": vaultProxyAddress,
+		}).Debug("Routing Vault requests through Vault Proxy")
+		vaultAddress = vaultProxyAddress
+	}
+
 	newClient, err := NewVaultClient(session.SessionID(), vaultAddress, vaultSkipTLSVerify, vaultToken, vaultNamespace)
 	if err != nil {
 		return nil, fmt.Errorf("NewVaultClient failed to create Vault client: %v", err)
 	}
 
+	if err := GetSessionStore().Save(ctx, session.SessionID(), metadata); err != nil {
+		logger.WithError(err).WithField("session_id", session.SessionID()).Warn("Failed to store session metadata")
+	}
+
 	logger.WithFields(log.Fields{
-		"session_id": session.SessionID(),
-		"vault_addr": vaultAddress,
+		"session_id":   session.SessionID(),
+		"session_hash": SessionHash(session.SessionID()),
+		"vault_addr":   vaultAddress,
 	}).Info("Created Vault client for session")
 
 	return newClient, nil
@@ -181,7 +348,18 @@ func NewSessionHandler(ctx context.Context, session server.ClientSession, logger
 }
 
 // EndSessionHandler cleans up the Vault client when the session ends
-func EndSessionHandler(_ context.Context, session server.ClientSession, logger *log.Logger) {
+func EndSessionHandler(ctx context.Context, session server.ClientSession, logger *log.Logger) {
+	RevokeScopedTokens(session.SessionID(), logger)
 	DeleteVaultClient(session.SessionID())
+	DeleteSessionUsage(session.SessionID())
+	DeleteSessionCircuitBreakers(session.SessionID())
+	InvalidateCapabilities(session.SessionID())
+	DeleteSessionChanges(session.SessionID())
+	DeleteSessionAccess(session.SessionID())
+	DeleteSessionIndexes(session.SessionID())
+	EndTranscript(session.SessionID())
+	if err := GetSessionStore().Delete(ctx, session.SessionID()); err != nil {
+		logger.WithError(err).WithField("session_id", session.SessionID()).Warn("Failed to delete stored session metadata")
+	}
 	logger.WithField("session_id", session.SessionID()).Info("Cleaned up Vault client for session")
 }
@@ -12,8 +12,10 @@ import (
 
 	"github.com/hashicorp/vault/api"
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetEnv(t *testing.T) {
@@ -238,3 +240,119 @@ func TestVaultNamespaceSupport(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rr.Code)
 	})
 }
+
+func TestCreateVaultClientForSession_Proxy(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	t.Run("requests are routed through the proxy without a token", func(t *testing.T) {
+		t.Setenv(VaultToken, "")
+
+		ctx := context.WithValue(context.Background(), contextKey(VaultAddress), "https://vault.example.com:8200")
+		ctx = context.WithValue(ctx, contextKey(VaultProxyAddress), "http://127.0.0.1:8100")
+
+		session := &mockClientSession{id: "test-proxy"}
+		client, err := CreateVaultClientForSession(ctx, session, logger)
+		assert.NoError(t, err)
+		assert.Equal(t, "http://127.0.0.1:8100", client.Address())
+		assert.Empty(t, client.Token())
+		DeleteVaultClient(session.id)
+	})
+
+	t.Run("token is required without a proxy", func(t *testing.T) {
+		t.Setenv(VaultToken, "")
+		t.Setenv(VaultProxyAddress, "")
+
+		ctx := context.WithValue(context.Background(), contextKey(VaultAddress), "https://vault.example.com:8200")
+
+		session := &mockClientSession{id: "test-no-proxy"}
+		_, err := CreateVaultClientForSession(ctx, session, logger)
+		assert.Error(t, err)
+	})
+}
+
+func TestCreateVaultClientForSession_SessionStore(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	store := NewMemorySessionStore()
+	SetSessionStore(store)
+	defer SetSessionStore(NewMemorySessionStore())
+
+	t.Setenv(VaultToken, "test-token")
+	t.Setenv(VaultAddress, "")
+	t.Setenv(VaultNamespace, "")
+
+	// Metadata stored by another instance is used when the request does not carry the settings
+	session := &mockClientSession{id: "test-store"}
+	require.NoError(t, store.Save(context.Background(), session.id, &SessionMetadata{
+		Address:   "https://vault.example.com:8200",
+		Namespace: "team-a",
+	}))
+
+	client, err := CreateVaultClientForSession(context.Background(), session, logger)
+	require.NoError(t, err)
+	assert.Equal(t, "https://vault.example.com:8200", client.Address())
+	assert.Equal(t, "team-a", client.Namespace())
+
+	// Settings in the request take precedence and are stored for the session
+	ctx := context.WithValue(context.Background(), contextKey(VaultNamespace), "team-b")
+	client, err = CreateVaultClientForSession(ctx, session, logger)
+	require.NoError(t, err)
+	assert.Equal(t, "team-b", client.Namespace())
+
+	metadata, err := store.Load(context.Background(), session.id)
+	require.NoError(t, err)
+	assert.Equal(t, "team-b", metadata.Namespace)
+
+	EndSessionHandler(context.Background(), session, logger)
+	metadata, err = store.Load(context.Background(), session.id)
+	require.NoError(t, err)
+	assert.Nil(t, metadata)
+}
+
+func TestGetVaultClientFromContext_RequestSettings(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	session := &mockClientSession{id: "test-request-settings"}
+	srv := server.NewMCPServer("test", "1.0.0")
+	defer DeleteVaultClient(session.id)
+
+	// The audit header and the consistency tokens make GetVaultClientFromContext return a copy of the cached client
+	SetAuditHeader("")
+	defer SetAuditHeader(DefaultAuditHeader)
+	SetReadYourWrites(false)
+	defer SetReadYourWrites(true)
+
+	cached, err := NewVaultClient(session.id, "https://vault-a.example.com:8200", false, "token-a", "")
+	require.NoError(t, err)
+
+	t.Run("client is reused when the request carries no settings", func(t *testing.T) {
+		client, err := GetVaultClientFromContext(srv.WithContext(context.Background(), session), logger)
+		require.NoError(t, err)
+		assert.Same(t, cached, client)
+	})
+
+	t.Run("client is reused when the request settings match", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), contextKey(VaultAddress), "https://vault-a.example.com:8200/")
+		ctx = context.WithValue(ctx, contextKey(VaultToken), "token-a")
+
+		client, err := GetVaultClientFromContext(srv.WithContext(ctx, session), logger)
+		require.NoError(t, err)
+		assert.Same(t, cached, client)
+	})
+
+	t.Run("client is rebuilt from the request settings", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), contextKey(VaultAddress), "https://vault-b.example.com:8200")
+		ctx = context.WithValue(ctx, contextKey(VaultToken), "token-b")
+		ctx = context.WithValue(ctx, contextKey(VaultSkipTLSVerify), "true")
+
+		client, err := GetVaultClientFromContext(srv.WithContext(ctx, session), logger)
+		require.NoError(t, err)
+		assert.Equal(t, "https://vault-b.example.com:8200", client.Address())
+		assert.Equal(t, "token-b", client.Token())
+		assert.True(t, clientSkipsTLSVerify(client))
+		assert.Same(t, client, GetVaultClient(session.id))
+	})
+}
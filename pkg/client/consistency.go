@@ -0,0 +1,120 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/hashicorp/vault/api"
+	log "github.com/sirupsen/logrus"
+)
+
+// ReadYourWritesEnv enables or disables sending the consistency tokens of the writes of a session with
+// its reads
+const ReadYourWritesEnv = "MCP_VAULT_READ_YOUR_WRITES"
+
+var (
+	readYourWrites   = true
+	readYourWritesMu sync.RWMutex
+)
+
+// sessionIndexes holds the consistency tokens of the writes of each session
+var sessionIndexes sync.Map // map[string]*indexStore
+
+// indexStore holds the X-Vault-Index consistency tokens returned by the writes of a session, merged so
+// that only the latest token of each cluster is kept
+type indexStore struct {
+	mu     sync.RWMutex
+	states []string
+}
+
+// SetReadYourWrites sets whether reads carry the consistency tokens of the writes of their session
+func SetReadYourWrites(enabled bool) {
+	readYourWritesMu.Lock()
+	defer readYourWritesMu.Unlock()
+	readYourWrites = enabled
+}
+
+// ReadYourWrites reports whether reads carry the consistency tokens of the writes of their session
+func ReadYourWrites() bool {
+	readYourWritesMu.RLock()
+	defer readYourWritesMu.RUnlock()
+	return readYourWrites
+}
+
+// LoadReadYourWritesFromEnv returns the setting of MCP_VAULT_READ_YOUR_WRITES, and false when it is not set
+// or is not a valid boolean
+func LoadReadYourWritesFromEnv() (bool, bool) {
+	value := os.Getenv(ReadYourWritesEnv)
+	if value == "" {
+		return false, false
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Warnf("Invalid %s value '%s', reads wait for the writes of their session", ReadYourWritesEnv, value)
+		return false, false
+	}
+	return enabled, true
+}
+
+func getIndexStore(sessionID string) *indexStore {
+	store, _ := sessionIndexes.LoadOrStore(sessionID, &indexStore{})
+	return store.(*indexStore)
+}
+
+// SessionIndexes returns the consistency tokens recorded for the writes of a session
+func SessionIndexes(sessionID string) []string {
+	store, ok := sessionIndexes.Load(sessionID)
+	if !ok {
+		return nil
+	}
+	s := store.(*indexStore)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]string(nil), s.states...)
+}
+
+// DeleteSessionIndexes forgets the consistency tokens of a session
+func DeleteSessionIndexes(sessionID string) {
+	sessionIndexes.Delete(sessionID)
+}
+
+// recordIndex returns a response callback keeping the consistency token of the writes of the session.
+// Vault Enterprise returns the token on every response, but only writes move the state a later read must
+// wait for.
+func recordIndex(sessionID string) api.ResponseCallback {
+	return func(resp *api.Response) {
+		if resp == nil || resp.Response == nil || resp.Request == nil || isReadMethod(resp.Request.Method) {
+			return
+		}
+		index := resp.Header.Get(api.HeaderIndex)
+		if index == "" {
+			return
+		}
+		store := getIndexStore(sessionID)
+		store.mu.Lock()
+		store.states = api.MergeReplicationStates(store.states, index)
+		store.mu.Unlock()
+	}
+}
+
+// requireIndex adds the consistency tokens of the writes of the session to a read, so that a performance
+// standby or replica that has not applied them yet makes the request wait or retry instead of returning
+// stale data
+func requireIndex(sessionID string, r *api.Request) {
+	if !isReadMethod(r.Method) {
+		return
+	}
+	for _, index := range SessionIndexes(sessionID) {
+		r.Headers.Add(api.HeaderIndex, index)
+	}
+}
+
+// isReadMethod reports whether a request reads, LIST requests being sent as GET
+func isReadMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == "LIST"
+}
@@ -0,0 +1,124 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func walState(localIndex string) string {
+	return base64.StdEncoding.EncodeToString([]byte("v1:cluster-a:" + localIndex + ":0:abcd"))
+}
+
+func TestReadYourWrites(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	var mu sync.Mutex
+	var received []string
+	index := walState("5")
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received = r.Header.Values(api.HeaderIndex)
+		mu.Unlock()
+		// Vault Enterprise returns its state on every response
+		w.Header().Set(api.HeaderIndex, index)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	defer mockVault.Close()
+
+	sessionID := "test-read-your-writes"
+	_, err := NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	defer DeleteVaultClient(sessionID)
+	defer DeleteSessionIndexes(sessionID)
+
+	ctx := server.NewMCPServer("test", "1.0.0").WithContext(context.Background(), &mockClientSession{id: sessionID})
+	vault, err := GetVaultClientFromContext(ctx, logger)
+	require.NoError(t, err)
+	lastIndex := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return received
+	}
+
+	t.Run("reads do not record the state", func(t *testing.T) {
+		_, err := vault.Logical().Read("secret/data/app")
+		require.NoError(t, err)
+		assert.Empty(t, SessionIndexes(sessionID))
+		assert.Empty(t, lastIndex())
+	})
+
+	t.Run("reads carry the state of the last write", func(t *testing.T) {
+		_, err := vault.Logical().Write("secret/data/app", map[string]interface{}{"data": map[string]interface{}{"a": "b"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{index}, SessionIndexes(sessionID))
+		assert.Empty(t, lastIndex(), "writes are not held back")
+
+		index = walState("9")
+		_, err = vault.Logical().Write("secret/data/app", map[string]interface{}{"data": map[string]interface{}{"a": "c"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{walState("9")}, SessionIndexes(sessionID), "only the latest state of the cluster is kept")
+
+		// A client built for a later tool call of the session carries the state as well
+		vault, err := GetVaultClientFromContext(ctx, logger)
+		require.NoError(t, err)
+		_, err = vault.Logical().Read("secret/data/app")
+		require.NoError(t, err)
+		assert.Equal(t, []string{walState("9")}, lastIndex())
+		_, err = vault.Logical().List("secret/metadata")
+		require.NoError(t, err)
+		assert.Equal(t, []string{walState("9")}, lastIndex())
+	})
+
+	t.Run("other sessions do not wait for the writes", func(t *testing.T) {
+		otherSession := "test-read-your-writes-other"
+		_, err := NewVaultClient(otherSession, mockVault.URL, false, "test-token", "")
+		require.NoError(t, err)
+		defer DeleteVaultClient(otherSession)
+
+		other, err := GetVaultClientFromContext(server.NewMCPServer("test", "1.0.0").WithContext(context.Background(), &mockClientSession{id: otherSession}), logger)
+		require.NoError(t, err)
+		_, err = other.Logical().Read("secret/data/app")
+		require.NoError(t, err)
+		assert.Empty(t, lastIndex())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		SetReadYourWrites(false)
+		defer SetReadYourWrites(true)
+
+		vault, err := GetVaultClientFromContext(ctx, logger)
+		require.NoError(t, err)
+		_, err = vault.Logical().Read("secret/data/app")
+		require.NoError(t, err)
+		assert.Empty(t, lastIndex())
+	})
+}
+
+func TestLoadReadYourWritesFromEnv(t *testing.T) {
+	_, ok := LoadReadYourWritesFromEnv()
+	assert.False(t, ok)
+
+	t.Setenv(ReadYourWritesEnv, "false")
+	enabled, ok := LoadReadYourWritesFromEnv()
+	assert.True(t, ok)
+	assert.False(t, enabled)
+
+	t.Setenv(ReadYourWritesEnv, "maybe")
+	_, ok = LoadReadYourWritesFromEnv()
+	assert.False(t, ok)
+}
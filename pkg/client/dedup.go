@@ -0,0 +1,140 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// ReadDedupEnv enables or disables the deduplication of identical concurrent read-only tool calls
+const ReadDedupEnv = "MCP_READ_DEDUP"
+
+// errSharedCallFailed is returned to the callers sharing a tool call that ended without a result
+var errSharedCallFailed = errors.New("shared tool call ended without a result")
+
+// LoadReadDedupFromEnv reports whether identical concurrent read-only tool calls are deduplicated, which is
+// the default. Invalid values are ignored with a warning.
+func LoadReadDedupFromEnv() bool {
+	value := os.Getenv(ReadDedupEnv)
+	if value == "" {
+		return true
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Warnf("Invalid %s value '%s', read deduplication is enabled", ReadDedupEnv, value)
+		return true
+	}
+	return enabled
+}
+
+// inflightCall is a tool call whose result is shared with the identical calls received while it runs
+type inflightCall struct {
+	done   chan struct{}
+	result *mcp.CallToolResult
+	err    error
+}
+
+// ReadDedupMiddleware runs identical concurrent calls of a read-only tool once. Calls are identical when
+// they come from the same session with the same namespace, tool and arguments. The calls received while
+// the first one runs wait for it and get a copy of its result.
+type ReadDedupMiddleware struct {
+	enabled bool
+	logger  *log.Logger
+
+	mu    sync.Mutex
+	calls map[string]*inflightCall
+}
+
+// NewReadDedupMiddleware creates a new read deduplication middleware
+func NewReadDedupMiddleware(enabled bool, logger *log.Logger) *ReadDedupMiddleware {
+	return &ReadDedupMiddleware{
+		enabled: enabled,
+		logger:  logger,
+		calls:   map[string]*inflightCall{},
+	}
+}
+
+// Middleware returns the tool handler middleware function
+func (m *ReadDedupMiddleware) Middleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		if !m.enabled {
+			return next
+		}
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if !IsReadOnlyTool(ctx, request.Params.Name) {
+				return next(ctx, request)
+			}
+			key, ok := dedupKey(ctx, request)
+			if !ok {
+				return next(ctx, request)
+			}
+
+			m.mu.Lock()
+			if call, exists := m.calls[key]; exists {
+				m.mu.Unlock()
+				RequestLogger(ctx, m.logger).Debugf("Sharing the result of an identical call of tool: %s", request.Params.Name)
+				select {
+				case <-call.done:
+					return sharedResult(call.result), call.err
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+			call := &inflightCall{done: make(chan struct{}), err: errSharedCallFailed}
+			m.calls[key] = call
+			m.mu.Unlock()
+
+			defer func() {
+				m.mu.Lock()
+				delete(m.calls, key)
+				m.mu.Unlock()
+				close(call.done)
+			}()
+
+			call.result, call.err = next(ctx, request)
+			return call.result, call.err
+		}
+	}
+}
+
+// dedupKey identifies a tool call by its session, namespace, tool and arguments. Arguments are marshaled
+// with sorted keys, so the same arguments in another order give the same key. Calls outside a session
+// are not deduplicated.
+func dedupKey(ctx context.Context, request mcp.CallToolRequest) (string, bool) {
+	sessionID := getSessionIDFromContext(ctx)
+	if sessionID == "" {
+		return "", false
+	}
+	arguments, err := json.Marshal(request.GetArguments())
+	if err != nil {
+		return "", false
+	}
+	namespace, _ := ctx.Value(contextKey(VaultNamespace)).(string)
+	key, err := json.Marshal([]string{sessionID, namespace, namespaceFromContext(ctx), request.Params.Name, string(arguments)})
+	if err != nil {
+		return "", false
+	}
+	return string(key), true
+}
+
+// sharedResult copies a result for another caller, so that the middleware of one caller changing its
+// result does not change the result of the other
+func sharedResult(result *mcp.CallToolResult) *mcp.CallToolResult {
+	if result == nil {
+		return nil
+	}
+	shared := *result
+	shared.Content = slices.Clone(result.Content)
+	return &shared
+}
@@ -0,0 +1,108 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadDedupMiddleware(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	var executions atomic.Int32
+	started := make(chan struct{}, 4)
+	release := make(chan struct{})
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		executions.Add(1)
+		started <- struct{}{}
+		<-release
+		return mcp.NewToolResultText("success"), nil
+	}
+
+	srv := server.NewMCPServer("test", "1.0.0", server.WithToolHandlerMiddleware(NewReadDedupMiddleware(true, logger).Middleware()))
+	srv.AddTool(mcp.NewTool("read_tool", mcp.WithReadOnlyHintAnnotation(true)), handler)
+	srv.AddTool(mcp.NewTool("write_tool"), handler)
+	ctx := srv.WithContext(context.Background(), &mockClientSession{id: "dedup-session"})
+
+	call := func(ctx context.Context, tool string, arguments string) <-chan mcp.JSONRPCMessage {
+		response := make(chan mcp.JSONRPCMessage, 1)
+		go func() {
+			message := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":%q,"arguments":%s}}`, tool, arguments)
+			response <- srv.HandleMessage(ctx, []byte(message))
+		}()
+		return response
+	}
+	// concurrently starts a call, then the others while it is running, and returns the number of executions
+	concurrently := func(t *testing.T, first func() <-chan mcp.JSONRPCMessage, others ...func() <-chan mcp.JSONRPCMessage) int32 {
+		executions.Store(0)
+		release = make(chan struct{})
+		responses := []<-chan mcp.JSONRPCMessage{first()}
+		<-started
+		for _, other := range others {
+			responses = append(responses, other())
+		}
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+
+		for _, response := range responses {
+			result, ok := (<-response).(mcp.JSONRPCResponse)
+			require.True(t, ok)
+			text := result.Result.(*mcp.CallToolResult).Content[0].(mcp.TextContent).Text
+			assert.Equal(t, "success", text)
+		}
+		for len(started) > 0 {
+			<-started
+		}
+		return executions.Load()
+	}
+
+	t.Run("identical reads run once", func(t *testing.T) {
+		count := concurrently(t,
+			func() <-chan mcp.JSONRPCMessage { return call(ctx, "read_tool", `{"mount":"secret","path":"app"}`) },
+			func() <-chan mcp.JSONRPCMessage { return call(ctx, "read_tool", `{"path":"app","mount":"secret"}`) },
+		)
+		assert.Equal(t, int32(1), count)
+	})
+
+	t.Run("different arguments, namespaces and sessions run separately", func(t *testing.T) {
+		otherSession := srv.WithContext(context.Background(), &mockClientSession{id: "other-session"})
+		otherNamespace := context.WithValue(ctx, contextKey(VaultNamespace), "team-a")
+		count := concurrently(t,
+			func() <-chan mcp.JSONRPCMessage { return call(ctx, "read_tool", `{"path":"app"}`) },
+			func() <-chan mcp.JSONRPCMessage { return call(ctx, "read_tool", `{"path":"db"}`) },
+			func() <-chan mcp.JSONRPCMessage { return call(otherSession, "read_tool", `{"path":"app"}`) },
+			func() <-chan mcp.JSONRPCMessage { return call(otherNamespace, "read_tool", `{"path":"app"}`) },
+		)
+		assert.Equal(t, int32(4), count)
+	})
+
+	t.Run("mutating tools are not deduplicated", func(t *testing.T) {
+		count := concurrently(t,
+			func() <-chan mcp.JSONRPCMessage { return call(ctx, "write_tool", `{"path":"app"}`) },
+			func() <-chan mcp.JSONRPCMessage { return call(ctx, "write_tool", `{"path":"app"}`) },
+		)
+		assert.Equal(t, int32(2), count)
+	})
+}
+
+func TestLoadReadDedupFromEnv(t *testing.T) {
+	assert.True(t, LoadReadDedupFromEnv())
+
+	t.Setenv(ReadDedupEnv, "false")
+	assert.False(t, LoadReadDedupFromEnv())
+
+	t.Setenv(ReadDedupEnv, "sometimes")
+	assert.True(t, LoadReadDedupFromEnv())
+}
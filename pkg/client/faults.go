@@ -0,0 +1,228 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// FaultInjectionEnv enables fault injection as a comma-separated list of fault=probability pairs, e.g.
+	// "error=0.2,timeout=0.1". Only meant for resilience tests, never set it in production.
+	FaultInjectionEnv = "MCP_FAULT_INJECTION"
+	// FaultInjectionSeedEnv seeds the choice of faults, so that a test run injects the same faults every time
+	FaultInjectionSeedEnv = "MCP_FAULT_INJECTION_SEED"
+	// FaultInjectionDelayEnv sets how long an injected timeout hangs before failing, 0 fails at once
+	FaultInjectionDelayEnv = "MCP_FAULT_INJECTION_DELAY"
+)
+
+const (
+	FaultServerError = "error"     // Vault answers 500 Internal Server Error
+	FaultTimeout     = "timeout"   // The request times out without an answer
+	FaultSealed      = "sealed"    // Vault answers 503 Service Unavailable because it is sealed
+	FaultMalformed   = "malformed" // Vault answers 200 OK with a truncated JSON body
+)
+
+// faultKinds are the faults in the order they are drawn
+var faultKinds = []string{FaultServerError, FaultTimeout, FaultSealed, FaultMalformed}
+
+// FaultConfig holds the probability of each fault per Vault request
+type FaultConfig struct {
+	Probabilities map[string]float64 // Probability of each fault, by fault kind. The sum must not exceed 1.
+	Seed          int64              // Seed of the random choice of faults
+	Delay         time.Duration      // How long an injected timeout hangs before failing
+}
+
+// Enabled reports whether any fault is injected
+func (c FaultConfig) Enabled() bool {
+	for _, probability := range c.Probabilities {
+		if probability > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadFaultConfigFromEnv loads the fault injection settings from MCP_FAULT_INJECTION, MCP_FAULT_INJECTION_SEED
+// and MCP_FAULT_INJECTION_DELAY. It returns false when fault injection is not enabled. Invalid entries are
+// ignored with a warning.
+func LoadFaultConfigFromEnv() (FaultConfig, bool) {
+	config := FaultConfig{Probabilities: map[string]float64{}, Seed: time.Now().UnixNano()}
+
+	total := 0.0
+	for _, entry := range strings.Split(os.Getenv(FaultInjectionEnv), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kind, value, ok := strings.Cut(entry, "=")
+		kind = strings.ToLower(strings.TrimSpace(kind))
+		probability, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if !ok || err != nil || probability < 0 || total+probability > 1 || !isFaultKind(kind) {
+			log.Warnf("Invalid %s entry '%s', expected fault=probability with fault one of %s and probabilities adding up to at most 1",
+				FaultInjectionEnv, entry, strings.Join(faultKinds, ", "))
+			continue
+		}
+		config.Probabilities[kind] = probability
+		total += probability
+	}
+	if !config.Enabled() {
+		return config, false
+	}
+
+	if value := os.Getenv(FaultInjectionSeedEnv); value != "" {
+		if seed, err := strconv.ParseInt(value, 10, 64); err == nil {
+			config.Seed = seed
+		} else {
+			log.Warnf("Invalid %s value '%s', using a random seed", FaultInjectionSeedEnv, value)
+		}
+	}
+	if value := os.Getenv(FaultInjectionDelayEnv); value != "" {
+		if delay, err := time.ParseDuration(value); err == nil && delay >= 0 {
+			config.Delay = delay
+		} else {
+			log.Warnf("Invalid %s value '%s', injected timeouts fail at once", FaultInjectionDelayEnv, value)
+		}
+	}
+
+	log.Warnf("Fault injection is enabled, requests to Vault fail on purpose: %s", os.Getenv(FaultInjectionEnv))
+	return config, true
+}
+
+func isFaultKind(kind string) bool {
+	for _, known := range faultKinds {
+		if kind == known {
+			return true
+		}
+	}
+	return false
+}
+
+// faultInjector decides which requests fail and counts the injected faults
+type faultInjector struct {
+	config   FaultConfig
+	mu       sync.Mutex
+	rand     *rand.Rand
+	injected map[string]int
+}
+
+var faults atomic.Pointer[faultInjector]
+
+// SetFaultInjection replaces the faults injected into the requests of the Vault clients, a config without
+// faults disables injection. Faults are only injected into clients created while injection is enabled.
+func SetFaultInjection(config FaultConfig) {
+	if !config.Enabled() {
+		faults.Store(nil)
+		return
+	}
+	faults.Store(&faultInjector{
+		config:   config,
+		rand:     rand.New(rand.NewSource(config.Seed)),
+		injected: map[string]int{},
+	})
+}
+
+// InjectedFaults returns the number of faults injected since fault injection was last set, by fault kind
+func InjectedFaults() map[string]int {
+	injector := faults.Load()
+	counts := map[string]int{}
+	if injector == nil {
+		return counts
+	}
+	injector.mu.Lock()
+	defer injector.mu.Unlock()
+	for kind, count := range injector.injected {
+		counts[kind] = count
+	}
+	return counts
+}
+
+// draw picks the fault to inject into a request, or "" to send it
+func (f *faultInjector) draw() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	roll := f.rand.Float64()
+	for _, kind := range faultKinds {
+		probability := f.config.Probabilities[kind]
+		if roll < probability {
+			f.injected[kind]++
+			return kind
+		}
+		roll -= probability
+	}
+	return ""
+}
+
+// faultTimeoutError is returned for an injected timeout, like the error of a request that timed out
+type faultTimeoutError struct{}
+
+func (faultTimeoutError) Error() string   { return "injected fault: request timed out" }
+func (faultTimeoutError) Timeout() bool   { return true }
+func (faultTimeoutError) Temporary() bool { return true }
+
+// injectFaults wraps the transport of a Vault client when fault injection is enabled
+func injectFaults(transport *http.Transport) http.RoundTripper {
+	if faults.Load() == nil {
+		return transport
+	}
+	return &faultTransport{next: transport}
+}
+
+// faultTransport injects faults into the requests of a Vault client while fault injection is enabled
+type faultTransport struct {
+	next http.RoundTripper
+}
+
+func (t *faultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	injector := faults.Load()
+	if injector == nil {
+		return t.next.RoundTrip(req)
+	}
+
+	switch injector.draw() {
+	case FaultServerError:
+		return faultResponse(req, http.StatusInternalServerError, `{"errors":["injected fault: internal error"]}`), nil
+	case FaultSealed:
+		return faultResponse(req, http.StatusServiceUnavailable, `{"errors":["Vault is sealed"]}`), nil
+	case FaultMalformed:
+		return faultResponse(req, http.StatusOK, `{"request_id":"injected","data":{"keys":[`), nil
+	case FaultTimeout:
+		timer := time.NewTimer(injector.config.Delay)
+		defer timer.Stop()
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-timer.C:
+			return nil, faultTimeoutError{}
+		}
+	default:
+		return t.next.RoundTrip(req)
+	}
+}
+
+func faultResponse(req *http.Request, status int, body string) *http.Response {
+	return &http.Response{
+		Status:        strconv.Itoa(status) + " " + http.StatusText(status),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewBufferString(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
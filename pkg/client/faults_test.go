@@ -0,0 +1,118 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFaultConfigFromEnv(t *testing.T) {
+	t.Setenv(FaultInjectionEnv, "error=0.2, sealed=0.1, unknown=0.1, timeout=0.9, malformed=x")
+	t.Setenv(FaultInjectionSeedEnv, "42")
+	t.Setenv(FaultInjectionDelayEnv, "2s")
+
+	config, ok := LoadFaultConfigFromEnv()
+	require.True(t, ok)
+	assert.Equal(t, map[string]float64{FaultServerError: 0.2, FaultSealed: 0.1}, config.Probabilities)
+	assert.Equal(t, int64(42), config.Seed)
+	assert.Equal(t, 2*time.Second, config.Delay)
+
+	t.Setenv(FaultInjectionEnv, "")
+	_, ok = LoadFaultConfigFromEnv()
+	assert.False(t, ok)
+}
+
+func TestFaultInjection(t *testing.T) {
+	var requests atomic.Int32
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"value":"ok"}}`))
+	}))
+	defer mockVault.Close()
+
+	// Faults are injected into the clients created while injection is enabled
+	SetFaultInjection(FaultConfig{Probabilities: map[string]float64{FaultServerError: 1}})
+	sessionID := "test-faults"
+	vault, err := NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	defer DeleteVaultClient(sessionID)
+	vault.SetMaxRetries(0)
+	defer SetFaultInjection(FaultConfig{})
+
+	inject := func(kind string) {
+		SetFaultInjection(FaultConfig{Probabilities: map[string]float64{kind: 1}})
+	}
+
+	t.Run("server errors and sealed responses fail requests and open the circuit", func(t *testing.T) {
+		breaker := GetCircuitBreaker(sessionID, mockVault.URL, CircuitBreakerConfig{FailureThreshold: 2, Cooldown: time.Minute})
+		defer DeleteSessionCircuitBreakers(sessionID)
+
+		inject(FaultServerError)
+		_, err := vault.Logical().Read("secret/app")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Code: 500")
+
+		inject(FaultSealed)
+		_, err = vault.Logical().Read("secret/app")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Vault is sealed")
+
+		assert.Equal(t, CircuitOpen, breaker.State())
+		assert.Equal(t, int32(0), requests.Load(), "faulty requests never reach Vault")
+		assert.Equal(t, map[string]int{FaultSealed: 1}, InjectedFaults())
+	})
+
+	t.Run("malformed responses fail to parse", func(t *testing.T) {
+		inject(FaultMalformed)
+		_, err := vault.Logical().Read("secret/app")
+		require.Error(t, err)
+	})
+
+	t.Run("timeouts hang until the request gives up", func(t *testing.T) {
+		SetFaultInjection(FaultConfig{Probabilities: map[string]float64{FaultTimeout: 1}, Delay: time.Minute})
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err := vault.Logical().ReadWithContext(ctx, "secret/app")
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("the same seed injects the same faults", func(t *testing.T) {
+		run := func() []bool {
+			SetFaultInjection(FaultConfig{Probabilities: map[string]float64{FaultServerError: 0.5}, Seed: 7})
+			var failed []bool
+			for i := 0; i < 20; i++ {
+				_, err := vault.Logical().Read("secret/app")
+				failed = append(failed, err != nil)
+			}
+			return failed
+		}
+		first := run()
+		assert.Equal(t, first, run())
+		assert.Contains(t, first, true)
+		assert.Contains(t, first, false)
+	})
+
+	t.Run("requests reach Vault when injection is disabled", func(t *testing.T) {
+		SetFaultInjection(FaultConfig{})
+		requests.Store(0)
+		secret, err := vault.Logical().Read("secret/app")
+		require.NoError(t, err)
+		assert.Equal(t, "ok", secret.Data["value"])
+		assert.Equal(t, int32(1), requests.Load())
+		assert.False(t, clientSkipsTLSVerify(vault))
+	})
+}
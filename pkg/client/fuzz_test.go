@@ -0,0 +1,127 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func FuzzVaultContextMiddleware(f *testing.F) {
+	f.Add("http://vault:8200", "token", "admin/team", "")
+	f.Add("", "", "", "VAULT_TOKEN=secret")
+	f.Add("::not a url::", "\x00\xff", "/", "VAULT_ADDR=%zz&VAULT_SKIP_VERIFY=maybe")
+	f.Add("", "", "", ";;&&==")
+
+	logger := log.New()
+	logger.SetLevel(log.FatalLevel)
+
+	handler := VaultContextMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	f.Fuzz(func(t *testing.T, address string, token string, namespace string, query string) {
+		req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+		req.URL.RawQuery = query
+		req.Header.Set(VaultAddress, address)
+		req.Header.Set(VaultHeaderToken, token)
+		req.Header.Set(VaultHeaderNamespace, namespace)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		require.Contains(t, []int{http.StatusOK, http.StatusBadRequest}, rr.Code)
+	})
+}
+
+func FuzzClientIP(f *testing.F) {
+	f.Add("10.0.0.1:1234", "203.0.113.7, 10.0.0.2", "")
+	f.Add("10.0.0.1:1234", "", `for="[2001:db8::1]:4711";proto=https, for=10.0.0.2`)
+	f.Add("[::1]:80", ",,,", "for=_hidden;for=unknown")
+	f.Add("not an address", "\x00", `for="`)
+
+	proxies, err := ParseTrustedProxies("10.0.0.0/8,::1")
+	require.NoError(f, err)
+
+	f.Fuzz(func(t *testing.T, remoteAddr string, xForwardedFor string, forwarded string) {
+		req := httptest.NewRequest(http.MethodGet, "/mcp", nil)
+		req.RemoteAddr = remoteAddr
+		if xForwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", xForwardedFor)
+		}
+		if forwarded != "" {
+			req.Header.Set("Forwarded", forwarded)
+		}
+
+		ip := proxies.ClientIP(req)
+		require.True(t, ip == "" || net.ParseIP(ip) != nil, "client IP %q is not an IP address", ip)
+	})
+}
+
+func FuzzParseTrustedProxies(f *testing.F) {
+	f.Add("10.0.0.0/8, 192.168.1.10,::1")
+	f.Add("10.0.0.0/33")
+	f.Add(",,, ,")
+	f.Add("fe80::1%eth0")
+
+	f.Fuzz(func(t *testing.T, value string) {
+		proxies, err := ParseTrustedProxies(value)
+		if err != nil {
+			return
+		}
+		for _, network := range proxies {
+			require.NotNil(t, network)
+			require.True(t, network.Contains(network.IP))
+		}
+	})
+}
+
+func FuzzParseRateLimit(f *testing.F) {
+	f.Add("10:20")
+	f.Add(" 0.5 : 1 ")
+	f.Add("NaN:-1")
+	f.Add("1e400:99999999999999999999")
+	f.Add("::")
+
+	f.Fuzz(func(t *testing.T, limit string) {
+		rps, burst := parseRateLimit(limit)
+		if rps == 0 && burst == 0 {
+			return
+		}
+
+		// A valid limit builds a limiter without panicking
+		config := DefaultRateLimitConfig()
+		config.GlobalLimit = rate.Limit(rps)
+		config.GlobalBurst = burst
+		_ = NewRateLimitMiddleware(config, log.New())
+	})
+}
+
+func FuzzParseTenancyConfig(f *testing.F) {
+	f.Add([]byte(`{"profiles":[{"name":"team-a","api_keys":["key-a"],"allowed_tools":["list_*"],"allowed_namespaces":["/team-a/"],"rate_limit":"2:5"}]}`))
+	f.Add([]byte(`{"profiles":[{"name":"team-a","client_ids":["a"],"allowed_tools":["["]}]}`))
+	f.Add([]byte(`{"profiles":[{"name":"x","api_keys":["k"],"rate_limit":"NaN:1"}]}`))
+	f.Add([]byte(`{"profiles":[null]}`))
+	f.Add([]byte(`null`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		config, err := ParseTenancyConfig(data)
+		if err != nil {
+			return
+		}
+		require.NotEmpty(t, config.Profiles)
+
+		req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+		req.Header.Set(HeaderAPIKey, "key-a")
+		if profile := config.Resolve(req, nil); profile != nil {
+			profile.AllowsTool("list_secrets", true)
+			profile.AllowsNamespace("team-a")
+		}
+	})
+}
@@ -9,8 +9,11 @@ import (
 	"net/http"
 	"net/textproto"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 
+	"github.com/mark3labs/mcp-go/server"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -18,6 +21,42 @@ import (
 type CORSConfig struct {
 	AllowedOrigins []string
 	Mode           string // "strict", "development", "disabled"
+	// AllowCredentials lets browsers send cookies and HTTP authentication with cross-origin requests. It is
+	// ignored in disabled mode, where any origin is accepted.
+	AllowCredentials bool
+}
+
+// MCPEndpointMethods are the methods of the StreamableHTTP endpoint: POST sends messages, GET opens the
+// event stream and DELETE terminates the session
+var MCPEndpointMethods = []string{http.MethodGet, http.MethodPost, http.MethodDelete}
+
+// CORSAllowedHeaders are the request headers read by the server, the only ones allowed in cross-origin requests
+var CORSAllowedHeaders = []string{
+	"Content-Type",
+	"Accept",
+	"Accept-Language",
+	"Authorization", // Bearer credentials of MCP clients, checked by an authenticating proxy in front of the server
+	server.HeaderKeySessionID,
+	server.HeaderKeyProtocolVersion,
+	VaultAddress,
+	VaultSkipTLSVerify,
+	VaultProxyAddress,
+	VaultHeaderToken,
+	VaultHeaderNamespace,
+	RequestIDHeader,
+	CorrelationIDHeader,
+	HeaderAPIKey,
+	HeaderClientID,
+}
+
+// CORSExposedHeaders are the response headers browsers let cross-origin clients read
+var CORSExposedHeaders = []string{
+	server.HeaderKeySessionID,
+	RequestIDHeader,
+	"RateLimit-Limit",
+	"RateLimit-Remaining",
+	"RateLimit-Reset",
+	"Retry-After",
 }
 
 // LoadCORSConfigFromEnv loads CORS configuration from environment variables
@@ -39,9 +78,13 @@ func LoadCORSConfigFromEnv() CORSConfig {
 		}
 	}
 
+	// Credentials stay disabled unless explicitly enabled
+	allowCredentials, _ := strconv.ParseBool(os.Getenv("MCP_CORS_ALLOW_CREDENTIALS"))
+
 	return CORSConfig{
-		AllowedOrigins: origins,
-		Mode:           mode,
+		AllowedOrigins:   origins,
+		Mode:             mode,
+		AllowCredentials: allowCredentials,
 	}
 }
 
@@ -76,29 +119,39 @@ func isOriginAllowed(origin string, allowedOrigins []string, mode string) bool {
 
 // securityHandler wraps the StreamableHTTP handler with origin validation
 type securityHandler struct {
-	handler        http.Handler
-	allowedOrigins []string
-	corsMode       string
-	logger         *log.Logger
+	handler http.Handler
+	cors    CORSConfig
+	methods []string
+	logger  *log.Logger
 }
 
-// NewSecurityHandler creates a new security handler
-func NewSecurityHandler(handler http.Handler, allowedOrigins []string, corsMode string, logger *log.Logger) http.Handler {
+// NewSecurityHandler creates a new security handler for a route accepting the given methods. Preflight
+// requests are answered for these methods and the supported headers only.
+func NewSecurityHandler(handler http.Handler, cors CORSConfig, methods []string, logger *log.Logger) http.Handler {
 	return &securityHandler{
-		handler:        handler,
-		allowedOrigins: allowedOrigins,
-		corsMode:       corsMode,
-		logger:         logger,
+		handler: handler,
+		cors:    cors,
+		methods: methods,
+		logger:  logger,
 	}
 }
 
 // ServeHTTP implements the http.Handler interface
 func (h *securityHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// The CORS headers depend on the origin, so caches must not share responses between origins
+	w.Header().Add("Vary", "Origin")
+
 	// Validate Origin header
 	origin := r.Header.Get("Origin")
+	preflight := r.Method == http.MethodOptions && origin != "" && r.Header.Get("Access-Control-Request-Method") != ""
+	if preflight {
+		w.Header().Add("Vary", "Access-Control-Request-Method")
+		w.Header().Add("Vary", "Access-Control-Request-Headers")
+	}
+
 	if origin != "" {
-		if !isOriginAllowed(origin, h.allowedOrigins, h.corsMode) {
-			h.logger.Warnf("Rejected request from unauthorized origin: %s (CORS mode: %s)", origin, h.corsMode)
+		if !isOriginAllowed(origin, h.cors.AllowedOrigins, h.cors.Mode) {
+			h.logger.Warnf("Rejected request from unauthorized origin: %s (CORS mode: %s)", origin, h.cors.Mode)
 			http.Error(w, "Origin not allowed", http.StatusForbidden)
 			return
 		}
@@ -107,16 +160,39 @@ func (h *securityHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		h.logger.Debugf("Allowed request from origin: %s", origin)
 
 		// If we have a valid origin, add CORS headers
-		w.Header().Set("Access-Control-Max-Age", "3600")
 		w.Header().Set("Access-Control-Allow-Origin", origin)
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, VAULT_ADDR, X-Vault-Token, X-Vault-Namespace")
+		if h.cors.AllowCredentials && h.cors.Mode != "disabled" {
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+		if !preflight {
+			w.Header().Set("Access-Control-Expose-Headers", strings.Join(CORSExposedHeaders, ", "))
+		}
 	}
 
 	// Handle OPTIONS requests for CORS preflight
 	if r.Method == http.MethodOptions {
+		allow := strings.Join(append(slices.Clone(h.methods), http.MethodOptions), ", ")
+		if !preflight {
+			w.Header().Set("Allow", allow)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		method := r.Header.Get("Access-Control-Request-Method")
+		if !slices.Contains(h.methods, method) {
+			h.logger.Debugf("Rejected preflight request for method %s from origin: %s", method, origin)
+			w.Header().Set("Allow", allow)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
 		h.logger.Debugf("Handling OPTIONS preflight request from origin: %s", origin)
-		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Access-Control-Max-Age", "3600")
+		w.Header().Set("Access-Control-Allow-Methods", allow)
+		if headers := supportedHeaders(r.Header.Values("Access-Control-Request-Headers")); len(headers) > 0 {
+			w.Header().Set("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+		}
+		w.WriteHeader(http.StatusNoContent)
 		return
 	}
 
@@ -124,13 +200,30 @@ func (h *securityHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	h.handler.ServeHTTP(w, r)
 }
 
+// supportedHeaders returns the headers of a preflight request that the server supports, leaving out the
+// others so the browser refuses to send them
+func supportedHeaders(requested []string) []string {
+	var headers []string
+	for _, value := range requested {
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			for _, supported := range CORSAllowedHeaders {
+				if strings.EqualFold(name, supported) && !slices.Contains(headers, supported) {
+					headers = append(headers, supported)
+				}
+			}
+		}
+	}
+	return headers
+}
+
 // VaultContextMiddleware adds Vault-related header values to the request context
 // This middleware extracts Vault configuration from HTTP headers, query parameters,
 // or environment variables and adds them to the request context for use by MCP tools
 func VaultContextMiddleware(logger *log.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			requiredHeaders := []string{VaultAddress, VaultToken, VaultHeaderToken, VaultSkipTLSVerify}
+			requiredHeaders := []string{VaultAddress, VaultToken, VaultHeaderToken, VaultSkipTLSVerify, VaultProxyAddress}
 			ctx := r.Context()
 
 			for _, header := range requiredHeaders {
@@ -148,7 +241,7 @@ func VaultContextMiddleware(logger *log.Logger) func(http.Handler) http.Handler
 
 					// Explicitly disallow VaultToken in query parameters for security reasons
 					if (header == VaultToken || header == VaultHeaderToken) && headerValue != "" {
-						logger.Info(fmt.Sprintf("Vault token was provided in query parameters by client %v, terminating request", r.RemoteAddr))
+						logger.Info(fmt.Sprintf("Vault token was provided in query parameters by client %v, terminating request", requestClientIP(r)))
 						http.Error(w, "Vault token should not be provided in query parameters for security reasons, use the X-Vault-Token header", http.StatusBadRequest)
 						return
 					}
@@ -190,15 +283,51 @@ func VaultContextMiddleware(logger *log.Logger) func(http.Handler) http.Handler
 	}
 }
 
+// VaultContextFromHeaders adds the Vault settings of a request of a transport other than HTTP, such as gRPC
+// metadata, to the context. header returns the value of a header by name; settings without a header are
+// taken from the environment, as for HTTP requests.
+func VaultContextFromHeaders(ctx context.Context, header func(name string) string) context.Context {
+	for _, name := range []string{VaultAddress, VaultSkipTLSVerify, VaultProxyAddress} {
+		value := header(name)
+		if value == "" {
+			value = getEnv(name, "")
+		}
+		if value != "" {
+			ctx = context.WithValue(ctx, contextKey(name), value)
+		}
+	}
+
+	token := header(VaultHeaderToken)
+	if token == "" {
+		token = header(VaultToken)
+	}
+	if token == "" {
+		token = getEnv(VaultToken, "")
+	}
+	if token != "" {
+		ctx = context.WithValue(ctx, contextKey(VaultToken), token)
+	}
+
+	namespace := header(VaultHeaderNamespace)
+	if namespace == "" {
+		namespace = getEnv(VaultNamespace, "")
+	}
+	if namespace != "" {
+		ctx = context.WithValue(ctx, contextKey(VaultNamespace), namespace)
+	}
+	return ctx
+}
+
 // LoggingMiddleware logs HTTP requests with structured logging
 func LoggingMiddleware(logger *log.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			logger.WithFields(log.Fields{
-				"method":     r.Method,
-				"path":       r.URL.Path,
-				"remote_ip":  r.RemoteAddr,
-				"user_agent": r.UserAgent(),
+				"method":       r.Method,
+				"path":         r.URL.Path,
+				"remote_ip":    requestClientIP(r),
+				"user_agent":   r.UserAgent(),
+				RequestIDField: RequestIDFromContext(r.Context()),
 			}).Info("HTTP request received")
 
 			next.ServeHTTP(w, r)
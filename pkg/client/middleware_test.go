@@ -166,6 +166,73 @@ func TestLoadCORSConfigFromEnv(t *testing.T) {
 	config = LoadCORSConfigFromEnv()
 	assert.Equal(t, "development", config.Mode)
 	assert.Equal(t, []string{"https://example.com", "https://test.com"}, config.AllowedOrigins)
+	assert.False(t, config.AllowCredentials)
+
+	t.Setenv("MCP_CORS_ALLOW_CREDENTIALS", "true")
+	assert.True(t, LoadCORSConfigFromEnv().AllowCredentials)
+	t.Setenv("MCP_CORS_ALLOW_CREDENTIALS", "sometimes")
+	assert.False(t, LoadCORSConfigFromEnv().AllowCredentials)
+}
+
+// TestSecurityHandlerPreflight tests the answers to CORS preflight requests, which list the methods of the
+// route and only the requested headers that the server supports
+func TestSecurityHandlerPreflight(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	called := false
+	mockHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	})
+	cors := CORSConfig{AllowedOrigins: []string{"https://example.com"}, Mode: "strict", AllowCredentials: true}
+
+	preflight := func(handler http.Handler, method string, headers string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodOptions, "/mcp", nil)
+		req.Header.Set("Origin", "https://example.com")
+		req.Header.Set("Access-Control-Request-Method", method)
+		if headers != "" {
+			req.Header.Set("Access-Control-Request-Headers", headers)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("supported method and headers", func(t *testing.T) {
+		rr := preflight(NewSecurityHandler(mockHandler, cors, MCPEndpointMethods, logger), http.MethodPost, "content-type, mcp-session-id, x-vault-token, authorization, x-unknown")
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Equal(t, "https://example.com", rr.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "true", rr.Header().Get("Access-Control-Allow-Credentials"))
+		assert.Equal(t, "GET, POST, DELETE, OPTIONS", rr.Header().Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "Content-Type, Mcp-Session-Id, X-Vault-Token, Authorization", rr.Header().Get("Access-Control-Allow-Headers"))
+		assert.Equal(t, "3600", rr.Header().Get("Access-Control-Max-Age"))
+		assert.Equal(t, []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"}, rr.Header().Values("Vary"))
+		assert.Empty(t, rr.Header().Get("Access-Control-Expose-Headers"))
+		assert.False(t, called, "preflight requests are not passed to the MCP handler")
+	})
+
+	t.Run("method not served by the route", func(t *testing.T) {
+		rr := preflight(NewSecurityHandler(mockHandler, cors, []string{http.MethodGet}, logger), http.MethodPost, "")
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+		assert.Equal(t, "GET, OPTIONS", rr.Header().Get("Allow"))
+		assert.Empty(t, rr.Header().Get("Access-Control-Allow-Methods"))
+	})
+
+	t.Run("credentials are not allowed in disabled mode", func(t *testing.T) {
+		rr := preflight(NewSecurityHandler(mockHandler, CORSConfig{Mode: "disabled", AllowCredentials: true}, MCPEndpointMethods, logger), http.MethodGet, "")
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Empty(t, rr.Header().Get("Access-Control-Allow-Credentials"))
+	})
+
+	t.Run("options without preflight headers", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodOptions, "/mcp", nil)
+		rr := httptest.NewRecorder()
+		NewSecurityHandler(mockHandler, cors, MCPEndpointMethods, logger).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Equal(t, "GET, POST, DELETE, OPTIONS", rr.Header().Get("Allow"))
+		assert.Empty(t, rr.Header().Get("Access-Control-Allow-Origin"))
+	})
 }
 
 // TestSecurityHandler tests the HTTP handler that applies CORS validation logic
@@ -294,7 +361,7 @@ func TestSecurityHandler(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			handler := NewSecurityHandler(mockHandler, tt.allowedOrigins, tt.mode, logger)
+			handler := NewSecurityHandler(mockHandler, CORSConfig{AllowedOrigins: tt.allowedOrigins, Mode: tt.mode}, MCPEndpointMethods, logger)
 
 			req := httptest.NewRequest("GET", "/mcp", nil)
 			if tt.origin != "" {
@@ -305,10 +372,12 @@ func TestSecurityHandler(t *testing.T) {
 			handler.ServeHTTP(rr, req)
 
 			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, []string{"Origin"}, rr.Header().Values("Vary"))
 
 			if tt.expectedHeader {
 				assert.Equal(t, tt.origin, rr.Header().Get("Access-Control-Allow-Origin"))
-				assert.NotEmpty(t, rr.Header().Get("Access-Control-Allow-Methods"))
+				assert.Contains(t, rr.Header().Get("Access-Control-Expose-Headers"), "Mcp-Session-Id")
+				assert.Empty(t, rr.Header().Get("Access-Control-Allow-Methods"), "methods are only listed in preflight responses")
 			} else if tt.expectedStatus == http.StatusOK {
 				assert.Empty(t, rr.Header().Get("Access-Control-Allow-Origin"))
 			}
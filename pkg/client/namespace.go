@@ -0,0 +1,63 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// NamespaceArgument is the name of the optional tool argument that overrides the session namespace
+const NamespaceArgument = "namespace"
+
+// namespaceOverrideKey is the context key for the namespace requested by a tool call
+type namespaceOverrideKey struct{}
+
+// WithNamespaceArgument returns a copy of the tool with an optional namespace argument added to its input schema
+func WithNamespaceArgument(tool mcp.Tool) mcp.Tool {
+	if tool.RawInputSchema != nil {
+		return tool
+	}
+	if _, exists := tool.InputSchema.Properties[NamespaceArgument]; exists {
+		return tool
+	}
+
+	properties := make(map[string]any, len(tool.InputSchema.Properties)+1)
+	for name, property := range tool.InputSchema.Properties {
+		properties[name] = property
+	}
+	properties[NamespaceArgument] = map[string]any{
+		"type":        "string",
+		"description": "Optional Vault Enterprise namespace to run this tool in, such as 'admin/team-a'. Overrides the namespace of the session.",
+	}
+	tool.InputSchema.Properties = properties
+
+	return tool
+}
+
+// NamespaceMiddleware makes the namespace argument of a tool call available to GetVaultClientFromContext
+func NamespaceMiddleware(logger *log.Logger) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if namespace := strings.Trim(strings.TrimSpace(request.GetString(NamespaceArgument, "")), "/"); namespace != "" {
+				logger.WithFields(log.Fields{
+					"tool":      request.Params.Name,
+					"namespace": namespace,
+				}).Debug("Using namespace from tool arguments")
+				ctx = context.WithValue(ctx, namespaceOverrideKey{}, namespace)
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// namespaceFromContext returns the namespace requested by the current tool call, if any
+func namespaceFromContext(ctx context.Context) string {
+	namespace, _ := ctx.Value(namespaceOverrideKey{}).(string)
+	return namespace
+}
@@ -0,0 +1,66 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithNamespaceArgument(t *testing.T) {
+	tool := mcp.NewTool("read_secret",
+		mcp.WithString("mount", mcp.Required()),
+	)
+
+	withNamespace := WithNamespaceArgument(tool)
+
+	assert.Contains(t, withNamespace.InputSchema.Properties, NamespaceArgument)
+	assert.Contains(t, withNamespace.InputSchema.Properties, "mount")
+	assert.NotContains(t, withNamespace.InputSchema.Required, NamespaceArgument)
+	assert.NotContains(t, tool.InputSchema.Properties, NamespaceArgument, "original tool should not be modified")
+}
+
+func TestNamespaceMiddleware(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	sessionID := "test-namespace-override"
+	_, err := NewVaultClient(sessionID, "http://127.0.0.1:8200", false, "test-token", "session-namespace")
+	require.NoError(t, err)
+	defer DeleteVaultClient(sessionID)
+
+	srv := server.NewMCPServer("test", "1.0.0")
+	ctx := srv.WithContext(context.Background(), &mockClientSession{id: sessionID})
+
+	var namespace string
+	handler := NamespaceMiddleware(logger)(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		vault, err := GetVaultClientFromContext(ctx, logger)
+		require.NoError(t, err)
+		namespace = vault.Namespace()
+		return mcp.NewToolResultText("success"), nil
+	})
+
+	t.Run("session namespace is used without an argument", func(t *testing.T) {
+		_, err := handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "list_mounts"}})
+		require.NoError(t, err)
+		assert.Equal(t, "session-namespace", namespace)
+	})
+
+	t.Run("namespace argument overrides the session namespace", func(t *testing.T) {
+		request := mcp.CallToolRequest{Params: mcp.CallToolParams{
+			Name:      "list_mounts",
+			Arguments: map[string]interface{}{NamespaceArgument: "/admin/team-a/"},
+		}}
+		_, err := handler(ctx, request)
+		require.NoError(t, err)
+		assert.Equal(t, "admin/team-a", namespace)
+		assert.Equal(t, "session-namespace", GetVaultClient(sessionID).Namespace(), "session client should not be modified")
+	})
+}
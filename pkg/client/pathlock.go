@@ -0,0 +1,386 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/redis/go-redis/v9"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// PathLockEnv selects where the locks of mutating tool calls are kept: 'memory', 'redis' or 'off'
+	PathLockEnv = "MCP_PATH_LOCK"
+	// PathLockWaitEnv is how long a mutating tool call waits for the lock of its path before it fails
+	PathLockWaitEnv = "MCP_PATH_LOCK_WAIT"
+	// PathLockTTLEnv is how long a Redis lock is held at most, so that the locks of a crashed instance expire
+	PathLockTTLEnv = "MCP_PATH_LOCK_TTL"
+
+	DefaultPathLockWait = 2 * time.Second
+	DefaultPathLockTTL  = time.Minute
+)
+
+// PathLocker holds exclusive locks on keys. Keys are paths: the lock of a key conflicts with the locks of
+// the same key, of its ancestors and of its descendants, so that locking a mount such as 'secret' waits for
+// the calls changing 'secret/app' and the other way round.
+type PathLocker interface {
+	// Lock waits until the lock of the key is obtained or the context is done. The returned function
+	// releases the lock.
+	Lock(ctx context.Context, key string, ttl time.Duration) (func(), error)
+}
+
+// PathLockConfig configures the locks serializing mutating tool calls on the same path. Locking is
+// disabled when Locker is nil.
+type PathLockConfig struct {
+	Locker PathLocker
+	Wait   time.Duration // How long a tool call waits for the lock
+	TTL    time.Duration // How long a lock is held at most by lockers that expire locks
+}
+
+// ResourceBusyError is returned when a mutating tool call cannot lock its path because another tool call
+// is changing it. It carries a hint of when to retry.
+type ResourceBusyError struct {
+	Path       string
+	RetryAfter time.Duration
+}
+
+func (e *ResourceBusyError) Error() string {
+	return fmt.Sprintf("resource busy: '%s' is being changed by another tool call, retry after %s", e.Path, e.RetryAfter.Round(time.Millisecond))
+}
+
+// LoadPathLockConfigFromEnv loads the path lock configuration from MCP_PATH_LOCK, MCP_PATH_LOCK_WAIT and
+// MCP_PATH_LOCK_TTL. Locks are kept in memory by default. Invalid values are ignored with a warning, and
+// Redis locks fall back to memory when the Redis server of the session store is not set or invalid.
+func LoadPathLockConfigFromEnv() PathLockConfig {
+	config := PathLockConfig{Wait: DefaultPathLockWait, TTL: DefaultPathLockTTL}
+
+	if value := os.Getenv(PathLockWaitEnv); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed >= 0 {
+			config.Wait = parsed
+		} else {
+			log.Warnf("Invalid %s value '%s', using default %s", PathLockWaitEnv, value, DefaultPathLockWait)
+		}
+	}
+	if value := os.Getenv(PathLockTTLEnv); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			config.TTL = parsed
+		} else {
+			log.Warnf("Invalid %s value '%s', using default %s", PathLockTTLEnv, value, DefaultPathLockTTL)
+		}
+	}
+
+	switch backend := os.Getenv(PathLockEnv); backend {
+	case "", "memory":
+		config.Locker = NewMemoryPathLocker()
+	case "redis":
+		redisConfig, err := LoadRedisConfigFromEnv()
+		if err != nil {
+			log.WithError(err).Warnf("Path locks are kept in memory")
+			config.Locker = NewMemoryPathLocker()
+			break
+		}
+		if redisConfig.Addr == "" {
+			log.Warnf("%s is required when %s is 'redis', path locks are kept in memory", SessionStoreRedisAddrEnv, PathLockEnv)
+			config.Locker = NewMemoryPathLocker()
+			break
+		}
+		log.Infof("Using Redis path locks at %s", redisConfig.Addr)
+		config.Locker = NewRedisPathLocker(redisConfig)
+	case "off":
+		log.Infof("Path locks are disabled")
+	default:
+		log.Warnf("Unsupported %s value '%s', path locks are kept in memory", PathLockEnv, backend)
+		config.Locker = NewMemoryPathLocker()
+	}
+
+	return config
+}
+
+// PathLockMiddleware serializes the mutating tool calls on the same path, so that the read-modify-write
+// cycles of two sessions do not interleave
+type PathLockMiddleware struct {
+	config PathLockConfig
+	logger *log.Logger
+}
+
+// NewPathLockMiddleware creates a new path lock middleware
+func NewPathLockMiddleware(config PathLockConfig, logger *log.Logger) *PathLockMiddleware {
+	return &PathLockMiddleware{config: config, logger: logger}
+}
+
+// Middleware returns the tool handler middleware function
+func (m *PathLockMiddleware) Middleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		if m.config.Locker == nil {
+			return next
+		}
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			toolName := request.Params.Name
+			if IsReadOnlyTool(ctx, toolName) {
+				return next(ctx, request)
+			}
+			path := lockedPath(ctx, request)
+			if path == "" {
+				return next(ctx, request)
+			}
+			key := path
+			if namespace := namespaceFromContext(ctx); namespace != "" {
+				key = strings.Trim(namespace, "/") + "/" + path
+			}
+
+			waitCtx, cancel := context.WithTimeout(ctx, m.config.Wait)
+			unlock, err := m.config.Locker.Lock(waitCtx, key, m.config.TTL)
+			cancel()
+			if err != nil {
+				// The tool call itself was cancelled, not the wait for the lock
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				if errors.Is(err, context.DeadlineExceeded) {
+					RequestLogger(ctx, m.logger).Warnf("Path %s is locked by another tool call, tool: %s", key, toolName)
+					return nil, &ResourceBusyError{Path: key, RetryAfter: m.config.Wait}
+				}
+				RequestLogger(ctx, m.logger).WithError(err).Errorf("Failed to lock path %s, tool: %s", key, toolName)
+				return nil, fmt.Errorf("failed to lock '%s': %w", key, err)
+			}
+			defer unlock()
+
+			return next(ctx, request)
+		}
+	}
+}
+
+// mountPathTools take the mount they create, delete or enable in their path argument
+var mountPathTools = map[string]bool{
+	"create_mount":     true,
+	"delete_mount":     true,
+	"enable_database":  true,
+	"enable_pki":       true,
+	"enable_transform": true,
+	"enable_transit":   true,
+}
+
+// authMountTools take the path of an auth method, which is mounted under auth/, in their mount argument
+var authMountTools = map[string]bool{
+	"configure_github_auth": true,
+	"create_cert_auth_role": true,
+	"list_cert_auth_roles":  true,
+	"list_github_mappings":  true,
+	"map_github_team":       true,
+	"test_auth_login":       true,
+}
+
+// lockedPath returns the path changed by a tool call, built from its mount and path arguments, or from its
+// path argument for the tools changing a whole mount. Arguments left out take the default of the tool.
+// Calls without a mount are not locked.
+func lockedPath(ctx context.Context, request mcp.CallToolRequest) string {
+	name := request.Params.Name
+	if mountPathTools[name] {
+		return strings.Trim(toolArgument(ctx, request, "path"), "/")
+	}
+
+	mount := strings.Trim(toolArgument(ctx, request, "mount"), "/")
+	if mount == "" {
+		return ""
+	}
+	if authMountTools[name] {
+		return "auth/" + mount
+	}
+	if path := strings.Trim(request.GetString("path", ""), "/"); path != "" {
+		return mount + "/" + path
+	}
+	return mount
+}
+
+// toolArgument returns a string argument of a tool call, or its default in the input schema of the tool on
+// the server in the context when the call leaves it out
+func toolArgument(ctx context.Context, request mcp.CallToolRequest, name string) string {
+	if _, ok := request.GetArguments()[name]; ok {
+		return request.GetString(name, "")
+	}
+
+	srv := server.ServerFromContext(ctx)
+	if srv == nil {
+		return ""
+	}
+	tool := srv.GetTool(request.Params.Name)
+	if tool == nil {
+		return ""
+	}
+	property, _ := tool.Tool.InputSchema.Properties[name].(map[string]any)
+	value, _ := property["default"].(string)
+	return value
+}
+
+// MemoryPathLocker keeps the locks in the memory of a single server instance
+type MemoryPathLocker struct {
+	mu   sync.Mutex
+	held map[string]chan struct{} // Closed when the lock is released
+}
+
+// NewMemoryPathLocker creates an in-memory path locker
+func NewMemoryPathLocker() *MemoryPathLocker {
+	return &MemoryPathLocker{held: map[string]chan struct{}{}}
+}
+
+func (l *MemoryPathLocker) Lock(ctx context.Context, key string, _ time.Duration) (func(), error) {
+	for {
+		l.mu.Lock()
+		released, busy := l.conflicting(key)
+		if !busy {
+			done := make(chan struct{})
+			l.held[key] = done
+			l.mu.Unlock()
+			return func() {
+				l.mu.Lock()
+				delete(l.held, key)
+				l.mu.Unlock()
+				close(done)
+			}, nil
+		}
+		l.mu.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// conflicting returns the release channel of a held lock that conflicts with the key
+func (l *MemoryPathLocker) conflicting(key string) (chan struct{}, bool) {
+	for held, released := range l.held {
+		if pathsOverlap(held, key) {
+			return released, true
+		}
+	}
+	return nil, false
+}
+
+// pathsOverlap reports whether two paths are the same or one is an ancestor of the other
+func pathsOverlap(a, b string) bool {
+	return a == b || strings.HasPrefix(a, b+"/") || strings.HasPrefix(b, a+"/")
+}
+
+// pathAncestors returns the ancestors of a path, 'secret' and 'secret/team' for 'secret/team/app'
+func pathAncestors(path string) []string {
+	var ancestors []string
+	for i, c := range path {
+		if c == '/' {
+			ancestors = append(ancestors, path[:i])
+		}
+	}
+	return ancestors
+}
+
+// redisLockScript takes a lock with the token ARGV[1] for ARGV[2] milliseconds, it returns 1 when the lock
+// was taken and 0 when a conflicting lock is held
+const redisLockScript = `
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local ttl = tonumber(ARGV[2])
+for i = 1, #KEYS, 2 do
+	if redis.call("EXISTS", KEYS[i]) == 1 then return 0 end
+end
+redis.call("ZREMRANGEBYSCORE", KEYS[2], "-inf", now)
+if redis.call("ZCARD", KEYS[2]) > 0 then return 0 end
+redis.call("SET", KEYS[1], ARGV[1], "PX", ttl)
+for i = 4, #KEYS, 2 do
+	redis.call("ZADD", KEYS[i], now + ttl, ARGV[1])
+	if redis.call("PTTL", KEYS[i]) < ttl then redis.call("PEXPIRE", KEYS[i], ttl) end
+end
+return 1`
+
+// redisUnlockScript deletes a lock only when it is still held with the token of the caller, so that a lock
+// that expired and was taken by another instance is not released
+const redisUnlockScript = `
+for i = 4, #KEYS, 2 do
+	redis.call("ZREM", KEYS[i], ARGV[1])
+end
+if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("DEL", KEYS[1]) end
+return 0`
+
+// The scripts run by their SHA1 digest once Redis has cached them
+var (
+	redisLock   = redis.NewScript(redisLockScript)
+	redisUnlock = redis.NewScript(redisUnlockScript)
+)
+
+// RedisPathLocker keeps the locks in Redis so that they are shared by all server instances. Locks expire
+// after their TTL in case the instance holding them stops.
+//
+// A path has two keys: its lock, and the set of the tokens of the locks held on its descendants, scored by
+// their expiry. A lock is taken when neither the path nor an ancestor is locked and no descendant holds an
+// unexpired lock, and its token is then added to the set of every ancestor. The scripts get the keys of the
+// path followed by the keys of each ancestor.
+type RedisPathLocker struct {
+	client *redis.Client
+}
+
+// NewRedisPathLocker creates a path locker backed by Redis
+func NewRedisPathLocker(config RedisConfig) *RedisPathLocker {
+	return &RedisPathLocker{client: newRedisClient(config)}
+}
+
+func (l *RedisPathLocker) key(path string) string {
+	return "vault-mcp-server:lock:" + path
+}
+
+// keys returns the lock and the set of locked descendants of the path and of each of its ancestors
+func (l *RedisPathLocker) keys(path string) []string {
+	keys := []string{l.key(path), "vault-mcp-server:lock-children:" + path}
+	for _, ancestor := range pathAncestors(path) {
+		keys = append(keys, l.key(ancestor), "vault-mcp-server:lock-children:"+ancestor)
+	}
+	return keys
+}
+
+func (l *RedisPathLocker) Lock(ctx context.Context, path string, ttl time.Duration) (func(), error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return nil, err
+	}
+	token := hex.EncodeToString(random)
+	keys := l.keys(path)
+
+	backoff := 25 * time.Millisecond
+	for {
+		locked, err := redisLock.Run(ctx, l.client, keys, token, ttl.Milliseconds()).Int()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, err
+		}
+		if locked == 1 {
+			return func() {
+				// The lock is released even when the tool call was cancelled
+				ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+				defer cancel()
+				if err := redisUnlock.Run(ctx, l.client, keys, token).Err(); err != nil {
+					log.WithError(err).Warnf("Failed to release Redis path lock %s, it expires after %s", path, ttl)
+				}
+			}, nil
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		backoff = min(backoff*2, 250*time.Millisecond)
+	}
+}
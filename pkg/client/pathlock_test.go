@@ -0,0 +1,263 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPathLocker(t *testing.T, locker PathLocker) {
+	ctx := context.Background()
+
+	unlock, err := locker.Lock(ctx, "secret/app", time.Minute)
+	require.NoError(t, err)
+
+	t.Run("held locks time out", func(t *testing.T) {
+		waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		_, err := locker.Lock(waitCtx, "secret/app", time.Minute)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("other paths are not locked", func(t *testing.T) {
+		for _, path := range []string{"secret/other", "secret/application", "secrets"} {
+			unlockOther, err := locker.Lock(ctx, path, time.Minute)
+			require.NoError(t, err, path)
+			unlockOther()
+		}
+	})
+
+	t.Run("ancestors and descendants are locked", func(t *testing.T) {
+		for _, path := range []string{"secret", "secret/app/config"} {
+			waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+			_, err := locker.Lock(waitCtx, path, time.Minute)
+			cancel()
+			assert.ErrorIs(t, err, context.DeadlineExceeded, path)
+		}
+	})
+
+	t.Run("waiting calls get the released lock", func(t *testing.T) {
+		locked := make(chan error, 1)
+		go func() {
+			waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			defer cancel()
+			unlockNext, err := locker.Lock(waitCtx, "secret/app", time.Minute)
+			if err == nil {
+				unlockNext()
+			}
+			locked <- err
+		}()
+
+		time.Sleep(20 * time.Millisecond)
+		unlock()
+		assert.NoError(t, <-locked)
+	})
+}
+
+func TestMemoryPathLocker(t *testing.T) {
+	locker := NewMemoryPathLocker()
+	testPathLocker(t, locker)
+	assert.Empty(t, locker.held, "released locks are removed")
+}
+
+func TestRedisPathLocker(t *testing.T) {
+	redisServer := miniredis.RunT(t)
+	testPathLocker(t, NewRedisPathLocker(RedisConfig{Addr: redisServer.Addr()}))
+	assert.Empty(t, redisServer.Keys(), "released locks are removed")
+
+	t.Run("expired locks taken by another instance are not released", func(t *testing.T) {
+		redisServer := miniredis.RunT(t)
+		locker := NewRedisPathLocker(RedisConfig{Addr: redisServer.Addr()})
+		unlock, err := locker.Lock(context.Background(), "secret/app", time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute, redisServer.TTL(locker.key("secret/app")))
+
+		require.NoError(t, redisServer.Set(locker.key("secret/app"), "token-of-another-instance"))
+		unlock()
+		value, err := redisServer.Get(locker.key("secret/app"))
+		require.NoError(t, err)
+		assert.Equal(t, "token-of-another-instance", value)
+	})
+}
+
+func TestPathLockMiddleware(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	config := PathLockConfig{Locker: NewMemoryPathLocker(), Wait: 50 * time.Millisecond, TTL: time.Minute}
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	handler := NewPathLockMiddleware(config, logger).Middleware()(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if request.GetBool("block", false) {
+			started <- struct{}{}
+			<-release
+		}
+		return mcp.NewToolResultText("success"), nil
+	})
+	call := func(args map[string]any) error {
+		_, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "write_secret", Arguments: args}})
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- call(map[string]any{"mount": "secret/", "path": "/app", "block": true})
+	}()
+	<-started
+
+	err := call(map[string]any{"mount": "secret", "path": "app"})
+	var busy *ResourceBusyError
+	require.ErrorAs(t, err, &busy)
+	assert.Equal(t, "secret/app", busy.Path)
+	assert.Equal(t, 50*time.Millisecond, busy.RetryAfter)
+	assert.Contains(t, err.Error(), "retry after 50ms")
+
+	assert.NoError(t, call(map[string]any{"mount": "secret", "path": "other"}))
+	assert.NoError(t, call(map[string]any{"name": "no-mount"}))
+
+	close(release)
+	require.NoError(t, <-done)
+	assert.NoError(t, call(map[string]any{"mount": "secret", "path": "app"}))
+}
+
+func TestPathLockMiddleware_Mounts(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	config := PathLockConfig{Locker: NewMemoryPathLocker(), Wait: 50 * time.Millisecond, TTL: time.Minute}
+	srv := server.NewMCPServer("test", "1.0.0", server.WithToolHandlerMiddleware(NewPathLockMiddleware(config, logger).Middleware()))
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if request.GetBool("block", false) {
+			started <- struct{}{}
+			<-release
+		}
+		return mcp.NewToolResultText("success"), nil
+	}
+	srv.AddTool(mcp.NewTool("create_mount", mcp.WithString("path", mcp.Required())), handler)
+	srv.AddTool(mcp.NewTool("delete_mount", mcp.WithString("path", mcp.Required())), handler)
+	srv.AddTool(mcp.NewTool("enable_pki", mcp.WithString("path", mcp.DefaultString("pki"))), handler)
+	srv.AddTool(mcp.NewTool("configure_github_auth", mcp.WithString("mount", mcp.DefaultString("github"))), handler)
+	srv.AddTool(mcp.NewTool("write_secret", mcp.WithString("mount", mcp.Required()), mcp.WithString("path", mcp.Required())), handler)
+
+	call := func(name string, args map[string]any) *mcp.JSONRPCError {
+		message, err := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"method":  "tools/call",
+			"params":  map[string]any{"name": name, "arguments": args},
+		})
+		require.NoError(t, err)
+		if rpcErr, ok := srv.HandleMessage(context.Background(), message).(mcp.JSONRPCError); ok {
+			return &rpcErr
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name    string
+		tool    string
+		args    map[string]any
+		busy    map[string]map[string]any // Calls that wait for the lock, by tool
+		allowed map[string]map[string]any // Calls that run at the same time, by tool
+		path    string
+	}{
+		{
+			name:    "concurrent deletes of the same mount",
+			tool:    "delete_mount",
+			args:    map[string]any{"path": "secret/"},
+			busy:    map[string]map[string]any{"delete_mount": {"path": "secret"}, "create_mount": {"path": "/secret"}, "write_secret": {"mount": "secret", "path": "app"}},
+			allowed: map[string]map[string]any{"create_mount": {"path": "secrets"}, "write_secret": {"mount": "other", "path": "app"}},
+			path:    "secret",
+		},
+		{
+			name:    "mount created while a secret of the mount is written",
+			tool:    "write_secret",
+			args:    map[string]any{"mount": "secret", "path": "app"},
+			busy:    map[string]map[string]any{"create_mount": {"path": "secret"}},
+			allowed: map[string]map[string]any{"write_secret": {"mount": "secret", "path": "other"}},
+			path:    "secret",
+		},
+		{
+			name:    "mount path left to its default",
+			tool:    "enable_pki",
+			args:    map[string]any{},
+			busy:    map[string]map[string]any{"enable_pki": {}, "delete_mount": {"path": "pki"}},
+			allowed: map[string]map[string]any{"enable_pki": {"path": "pki_int"}},
+			path:    "pki",
+		},
+		{
+			name:    "auth mounts",
+			tool:    "configure_github_auth",
+			args:    map[string]any{},
+			busy:    map[string]map[string]any{"configure_github_auth": {"mount": "github"}},
+			allowed: map[string]map[string]any{"write_secret": {"mount": "github", "path": "app"}},
+			path:    "auth/github",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release = make(chan struct{})
+			args := map[string]any{"block": true}
+			for key, value := range tt.args {
+				args[key] = value
+			}
+			done := make(chan *mcp.JSONRPCError, 1)
+			go func() {
+				done <- call(tt.tool, args)
+			}()
+			<-started
+
+			for tool, args := range tt.busy {
+				rpcErr := call(tool, args)
+				require.NotNil(t, rpcErr, tool)
+				assert.Contains(t, rpcErr.Error.Message, "resource busy", tool)
+			}
+			for tool, args := range tt.allowed {
+				assert.Nil(t, call(tool, args), tool)
+			}
+
+			close(release)
+			assert.Nil(t, <-done)
+			for tool, args := range tt.busy {
+				assert.Nil(t, call(tool, args), tool)
+			}
+		})
+	}
+
+	assert.Equal(t, "auth/github", lockedPath(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "configure_github_auth", Arguments: map[string]any{"mount": "/github/"}}}))
+}
+
+func TestLoadPathLockConfigFromEnv(t *testing.T) {
+	config := LoadPathLockConfigFromEnv()
+	assert.IsType(t, &MemoryPathLocker{}, config.Locker)
+	assert.Equal(t, DefaultPathLockWait, config.Wait)
+
+	t.Setenv(PathLockEnv, "off")
+	assert.Nil(t, LoadPathLockConfigFromEnv().Locker)
+
+	t.Setenv(PathLockEnv, "redis")
+	assert.IsType(t, &MemoryPathLocker{}, LoadPathLockConfigFromEnv().Locker, "falls back to memory without a Redis server")
+
+	t.Setenv(SessionStoreRedisAddrEnv, "redis:6379")
+	t.Setenv(PathLockWaitEnv, "500ms")
+	t.Setenv(PathLockTTLEnv, "forever")
+	config = LoadPathLockConfigFromEnv()
+	assert.IsType(t, &RedisPathLocker{}, config.Locker)
+	assert.Equal(t, 500*time.Millisecond, config.Wait)
+	assert.Equal(t, DefaultPathLockTTL, config.TTL)
+}
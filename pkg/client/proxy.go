@@ -0,0 +1,172 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const TrustedProxiesEnv = "MCP_TRUSTED_PROXIES"
+
+// TrustedProxies are the reverse proxies whose X-Forwarded-For and Forwarded headers are believed
+type TrustedProxies []*net.IPNet
+
+type clientIPKey struct{}
+
+// LoadTrustedProxiesFromEnv loads the trusted proxies from MCP_TRUSTED_PROXIES, a comma separated list of
+// IP addresses and CIDR ranges. No proxy is trusted by default.
+func LoadTrustedProxiesFromEnv() (TrustedProxies, error) {
+	return ParseTrustedProxies(os.Getenv(TrustedProxiesEnv))
+}
+
+// ParseTrustedProxies parses a comma separated list of IP addresses and CIDR ranges
+func ParseTrustedProxies(value string) (TrustedProxies, error) {
+	var proxies TrustedProxies
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy address '%s'", entry)
+			}
+			bits := 8 * net.IPv4len
+			if ip.To4() == nil {
+				bits = 8 * net.IPv6len
+			}
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy range '%s': %w", entry, err)
+		}
+		proxies = append(proxies, network)
+	}
+	return proxies, nil
+}
+
+// trusts reports whether the address belongs to a trusted proxy
+func (p TrustedProxies) trusts(address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for _, network := range p {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP returns the address of the client that sent the request. When the request comes from a trusted
+// proxy, the forwarding headers are walked from the nearest hop and the first untrusted address is returned.
+func (p TrustedProxies) ClientIP(r *http.Request) string {
+	ip := hostOnly(r.RemoteAddr)
+	if !p.trusts(ip) {
+		return ip
+	}
+
+	hops := forwardedFor(r)
+	for i := len(hops) - 1; i >= 0; i-- {
+		if hops[i] == "" {
+			// An unparsable hop cannot be trusted any further
+			break
+		}
+		ip = hops[i]
+		if !p.trusts(ip) {
+			break
+		}
+	}
+	return ip
+}
+
+// forwardedFor returns the client addresses of the X-Forwarded-For header, or of the Forwarded header
+// when X-Forwarded-For is missing, from the original client to the nearest proxy
+func forwardedFor(r *http.Request) []string {
+	var hops []string
+
+	if values := r.Header.Values("X-Forwarded-For"); len(values) > 0 {
+		for _, value := range values {
+			for _, hop := range strings.Split(value, ",") {
+				hops = append(hops, hostOnly(strings.TrimSpace(hop)))
+			}
+		}
+		return hops
+	}
+
+	for _, value := range r.Header.Values("Forwarded") {
+		for _, element := range strings.Split(value, ",") {
+			hop := ""
+			for _, pair := range strings.Split(element, ";") {
+				key, val, found := strings.Cut(strings.TrimSpace(pair), "=")
+				if found && strings.EqualFold(key, "for") {
+					hop = hostOnly(strings.Trim(val, `"`))
+				}
+			}
+			hops = append(hops, hop)
+		}
+	}
+	return hops
+}
+
+// hostOnly strips the port and IPv6 brackets from an address, returning an empty string for
+// anything that is not an IP address, such as the obfuscated identifiers allowed by Forwarded
+func hostOnly(address string) string {
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		address = host
+	}
+	address = strings.TrimSuffix(strings.TrimPrefix(address, "["), "]")
+	if net.ParseIP(address) == nil {
+		return ""
+	}
+	return address
+}
+
+// ClientIPFromContext returns the client address resolved by ClientIPMiddleware, or an empty string
+func ClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
+}
+
+// WithClientIP adds the client address of a request of a transport other than HTTP to the context
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	if ip == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// requestClientIP returns the resolved client address of the request, falling back to its remote address
+func requestClientIP(r *http.Request) string {
+	if ip := ClientIPFromContext(r.Context()); ip != "" {
+		return ip
+	}
+	return r.RemoteAddr
+}
+
+// ClientIPMiddleware resolves the real client address of every HTTP request, honouring the forwarding
+// headers of trusted proxies, and adds it to the request context for logging and rate limiting
+func ClientIPMiddleware(proxies TrustedProxies) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := proxies.ClientIP(r)
+			if ip == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), clientIPKey{}, ip)))
+		})
+	}
+}
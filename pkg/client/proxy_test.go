@@ -0,0 +1,148 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestParseTrustedProxies(t *testing.T) {
+	proxies, err := ParseTrustedProxies("10.0.0.0/8, 192.168.1.10,::1")
+	require.NoError(t, err)
+	require.Len(t, proxies, 3)
+	assert.True(t, proxies.trusts("10.1.2.3"))
+	assert.True(t, proxies.trusts("192.168.1.10"))
+	assert.False(t, proxies.trusts("192.168.1.11"))
+	assert.True(t, proxies.trusts("::1"))
+
+	proxies, err = ParseTrustedProxies("")
+	require.NoError(t, err)
+	assert.Empty(t, proxies)
+
+	_, err = ParseTrustedProxies("nginx")
+	assert.Error(t, err)
+	_, err = ParseTrustedProxies("10.0.0.0/33")
+	assert.Error(t, err)
+}
+
+func TestTrustedProxiesClientIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies("10.0.0.0/8")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string]string
+		expected   string
+	}{
+		{
+			name:       "direct client",
+			remoteAddr: "203.0.113.5:51234",
+			expected:   "203.0.113.5",
+		},
+		{
+			name:       "untrusted peer cannot spoof its address",
+			remoteAddr: "203.0.113.5:51234",
+			headers:    map[string]string{"X-Forwarded-For": "198.51.100.7"},
+			expected:   "203.0.113.5",
+		},
+		{
+			name:       "trusted proxy",
+			remoteAddr: "10.0.0.2:443",
+			headers:    map[string]string{"X-Forwarded-For": "198.51.100.7"},
+			expected:   "198.51.100.7",
+		},
+		{
+			name:       "chain of trusted proxies",
+			remoteAddr: "10.0.0.2:443",
+			headers:    map[string]string{"X-Forwarded-For": "198.51.100.7, 10.0.0.9"},
+			expected:   "198.51.100.7",
+		},
+		{
+			name:       "spoofed hops before the first untrusted address are ignored",
+			remoteAddr: "10.0.0.2:443",
+			headers:    map[string]string{"X-Forwarded-For": "1.2.3.4, 198.51.100.7"},
+			expected:   "198.51.100.7",
+		},
+		{
+			name:       "forwarded header",
+			remoteAddr: "10.0.0.2:443",
+			headers:    map[string]string{"Forwarded": `for=198.51.100.7;proto=https, for="[2001:db8::1]:4711"`},
+			expected:   "2001:db8::1",
+		},
+		{
+			name:       "obfuscated forwarded identifier",
+			remoteAddr: "10.0.0.2:443",
+			headers:    map[string]string{"Forwarded": "for=_hidden"},
+			expected:   "10.0.0.2",
+		},
+		{
+			name:       "trusted proxy without forwarding headers",
+			remoteAddr: "10.0.0.2:443",
+			expected:   "10.0.0.2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for header, value := range tt.headers {
+				req.Header.Set(header, value)
+			}
+			assert.Equal(t, tt.expected, proxies.ClientIP(req))
+		})
+	}
+}
+
+func TestClientRateLimitBehindProxy(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	proxies, err := ParseTrustedProxies("10.0.0.2")
+	require.NoError(t, err)
+
+	rateLimiter := NewRateLimitMiddleware(RateLimitConfig{
+		GlobalLimit:     rate.Every(time.Second),
+		GlobalBurst:     10,
+		PerSessionLimit: rate.Every(time.Second),
+		PerSessionBurst: 10,
+		PerClientLimit:  rate.Every(time.Second),
+		PerClientBurst:  1,
+	}, logger)
+	toolHandler := rateLimiter.Middleware()(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("success"), nil
+	})
+
+	// Call the tool the way the HTTP transport does, with the context of the request
+	callTool := func(forwardedFor string) error {
+		var callErr error
+		handler := ClientIPMiddleware(proxies)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, callErr = toolHandler(r.Context(), mcp.CallToolRequest{})
+		}))
+		req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+		req.RemoteAddr = "10.0.0.2:443"
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+		return callErr
+	}
+
+	require.NoError(t, callTool("198.51.100.7"))
+	err = callTool("198.51.100.7")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "too many requests from this client")
+
+	// Another client behind the same proxy has its own limit
+	assert.NoError(t, callTool("198.51.100.8"))
+}
@@ -5,7 +5,9 @@ package client
 
 import (
 	"context"
-	"errors"
+	"fmt"
+	"math"
+	"net/http"
 	"os"
 	"strconv"
 	"strings"
@@ -24,6 +26,8 @@ type RateLimitConfig struct {
 	GlobalBurst     int        // Global burst capacity
 	PerSessionLimit rate.Limit // Per-session requests per second
 	PerSessionBurst int        // Per-session burst capacity
+	PerClientLimit  rate.Limit // Per-client IP requests per second, disabled when zero
+	PerClientBurst  int        // Per-client IP burst capacity
 }
 
 // DefaultRateLimitConfig returns a sensible default configuration
@@ -62,6 +66,17 @@ func LoadRateLimitConfigFromEnv() RateLimitConfig {
 		}
 	}
 
+	// Per-client IP rate limiting (format: "rps:burst"), only available on the HTTP transport
+	if clientLimit := os.Getenv("MCP_RATE_LIMIT_CLIENT"); clientLimit != "" {
+		if rps, burst := parseRateLimit(clientLimit); rps > 0 && burst > 0 {
+			config.PerClientLimit = rate.Limit(rps)
+			config.PerClientBurst = burst
+			log.Infof("Per-client rate limit set to %f rps with burst %d", rps, burst)
+		} else {
+			log.Warnf("Invalid MCP_RATE_LIMIT_CLIENT format, per-client rate limiting is disabled")
+		}
+	}
+
 	return config
 }
 
@@ -87,6 +102,7 @@ type RateLimitMiddleware struct {
 	config          RateLimitConfig
 	globalLimiter   *rate.Limiter
 	sessionLimiters map[string]*rate.Limiter
+	clientLimiters  map[string]*rate.Limiter
 	mu              sync.RWMutex
 	logger          *log.Logger
 }
@@ -97,6 +113,7 @@ func NewRateLimitMiddleware(config RateLimitConfig, logger *log.Logger) *RateLim
 		config:          config,
 		globalLimiter:   rate.NewLimiter(config.GlobalLimit, config.GlobalBurst),
 		sessionLimiters: make(map[string]*rate.Limiter),
+		clientLimiters:  make(map[string]*rate.Limiter),
 		logger:          logger,
 	}
 }
@@ -124,6 +141,19 @@ func (m *RateLimitMiddleware) getSessionLimiter(sessionID string) *rate.Limiter
 	return limiter
 }
 
+// getClientLimiter gets or creates a rate limiter for a client IP
+func (m *RateLimitMiddleware) getClientLimiter(clientIP string) *rate.Limiter {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	limiter, exists := m.clientLimiters[clientIP]
+	if !exists {
+		limiter = rate.NewLimiter(m.config.PerClientLimit, m.config.PerClientBurst)
+		m.clientLimiters[clientIP] = limiter
+	}
+	return limiter
+}
+
 // Middleware returns the tool handler middleware function
 func (m *RateLimitMiddleware) Middleware() server.ToolHandlerMiddleware {
 	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
@@ -132,16 +162,34 @@ func (m *RateLimitMiddleware) Middleware() server.ToolHandlerMiddleware {
 
 			// Check global rate limit
 			if !m.globalLimiter.Allow() {
-				m.logger.Warnf("Global rate limit exceeded for tool: %s", toolName)
-				return nil, errors.New("rate limit exceeded: too many requests globally")
+				RequestLogger(ctx, m.logger).Warnf("Global rate limit exceeded for tool: %s", toolName)
+				return nil, &RateLimitError{
+					RateLimitStatus: limiterStatus("global", m.globalLimiter),
+					message:         "rate limit exceeded: too many requests globally",
+				}
+			}
+
+			// Check per-client rate limit if the HTTP transport resolved the client IP
+			if clientIP := ClientIPFromContext(ctx); clientIP != "" && m.config.PerClientBurst > 0 {
+				clientLimiter := m.getClientLimiter(clientIP)
+				if !clientLimiter.Allow() {
+					RequestLogger(ctx, m.logger).Warnf("Client rate limit exceeded for client: %s, tool: %s", clientIP, toolName)
+					return nil, &RateLimitError{
+						RateLimitStatus: limiterStatus("client", clientLimiter),
+						message:         "rate limit exceeded: too many requests from this client",
+					}
+				}
 			}
 
 			// Check per-session rate limit if we can get session ID from context
 			if sessionID := getSessionIDFromContext(ctx); sessionID != "" {
 				sessionLimiter := m.getSessionLimiter(sessionID)
 				if !sessionLimiter.Allow() {
-					m.logger.Warnf("Session rate limit exceeded for session: %s, tool: %s", sessionID, toolName)
-					return nil, errors.New("rate limit exceeded: too many requests from this session")
+					RequestLogger(ctx, m.logger).Warnf("Session rate limit exceeded for session: %s, tool: %s", sessionID, toolName)
+					return nil, &RateLimitError{
+						RateLimitStatus: limiterStatus("session", sessionLimiter),
+						message:         "rate limit exceeded: too many requests from this session",
+					}
 				}
 			}
 
@@ -177,4 +225,106 @@ func (m *RateLimitMiddleware) CleanupSessions(activeSessions []string) {
 			m.logger.Debugf("Cleaned up rate limiter for inactive session: %s", sessionID)
 		}
 	}
+
+	// Client limiters that have fully recovered behave like new ones
+	for clientIP, limiter := range m.clientLimiters {
+		if limiter.Tokens() >= float64(limiter.Burst()) {
+			delete(m.clientLimiters, clientIP)
+		}
+	}
+}
+
+// RateLimitStatus describes how many requests a rate limiter allows right now
+type RateLimitStatus struct {
+	Scope      string        // Either 'global', 'client' or 'session'
+	Limit      float64       // Requests per second
+	Burst      int           // Burst capacity
+	Remaining  int           // Requests that are allowed right now
+	RetryAfter time.Duration // Time until the next request is allowed, zero when requests remain
+	Reset      time.Duration // Time until the full burst capacity is available again
+}
+
+// RateLimitError is returned when a tool call exceeds a rate limit. It carries the state of the
+// limiter so that clients can back off until RetryAfter instead of retrying blindly.
+type RateLimitError struct {
+	RateLimitStatus
+	message string
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%s (limit %g requests per second, burst %d, remaining %d, retry after %s)",
+		e.message, e.Limit, e.Burst, e.Remaining, e.RetryAfter.Round(time.Millisecond))
+}
+
+// limiterStatus returns the current status of a rate limiter
+func limiterStatus(scope string, limiter *rate.Limiter) RateLimitStatus {
+	tokens := limiter.Tokens()
+	status := RateLimitStatus{
+		Scope: scope,
+		Limit: float64(limiter.Limit()),
+		Burst: limiter.Burst(),
+	}
+	if tokens >= 1 {
+		status.Remaining = int(tokens)
+	}
+	if status.Limit > 0 {
+		if tokens < 1 {
+			status.RetryAfter = time.Duration((1 - tokens) / status.Limit * float64(time.Second))
+		}
+		status.Reset = time.Duration((float64(status.Burst) - tokens) / status.Limit * float64(time.Second))
+	}
+	return status
+}
+
+// Status returns the status of the most restrictive of the global, client and session rate limiters
+func (m *RateLimitMiddleware) Status(sessionID, clientIP string) RateLimitStatus {
+	status := limiterStatus("global", m.globalLimiter)
+
+	m.mu.RLock()
+	clientLimiter, clientExists := m.clientLimiters[clientIP]
+	sessionLimiter, sessionExists := m.sessionLimiters[sessionID]
+	m.mu.RUnlock()
+
+	// Limiters that do not exist yet are full
+	if clientIP != "" && m.config.PerClientBurst > 0 {
+		if !clientExists {
+			clientLimiter = rate.NewLimiter(m.config.PerClientLimit, m.config.PerClientBurst)
+		}
+		status = moreRestrictive(status, limiterStatus("client", clientLimiter))
+	}
+	if sessionID != "" {
+		if !sessionExists {
+			sessionLimiter = rate.NewLimiter(m.config.PerSessionLimit, m.config.PerSessionBurst)
+		}
+		status = moreRestrictive(status, limiterStatus("session", sessionLimiter))
+	}
+
+	return status
+}
+
+// moreRestrictive returns the status that allows fewer requests, or the one that recovers later
+func moreRestrictive(a, b RateLimitStatus) RateLimitStatus {
+	if b.Remaining < a.Remaining || (b.Remaining == a.Remaining && b.RetryAfter > a.RetryAfter) {
+		return b
+	}
+	return a
+}
+
+// RateLimitHeadersMiddleware adds the RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers to
+// HTTP responses, and Retry-After when no requests remain, as seen when the request arrived
+func RateLimitHeadersMiddleware(rateLimiter *RateLimitMiddleware) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			status := rateLimiter.Status(r.Header.Get(server.HeaderKeySessionID), ClientIPFromContext(r.Context()))
+
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(status.Burst))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(status.Remaining))
+			w.Header().Set("RateLimit-Reset", strconv.Itoa(int(math.Ceil(status.Reset.Seconds()))))
+			if status.Remaining == 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(status.RetryAfter.Seconds()))))
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
 }
@@ -5,10 +5,15 @@ package client
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/time/rate"
 )
@@ -62,9 +67,85 @@ func TestRateLimitMiddleware(t *testing.T) {
 	if err == nil {
 		t.Fatal("Second request should be rate limited")
 	}
-	if err.Error() != "rate limit exceeded: too many requests globally" {
+	if !strings.HasPrefix(err.Error(), "rate limit exceeded: too many requests globally") {
 		t.Fatalf("Expected global rate limit error, got: %v", err)
 	}
+
+	// The error carries the state of the limiter
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("Expected a RateLimitError, got: %T", err)
+	}
+	if rateLimitErr.Scope != "global" || rateLimitErr.Burst != 1 || rateLimitErr.Remaining != 0 {
+		t.Fatalf("Unexpected rate limit status: %+v", rateLimitErr.RateLimitStatus)
+	}
+	if rateLimitErr.RetryAfter <= 0 || rateLimitErr.RetryAfter > time.Second {
+		t.Fatalf("Expected retry after up to one second, got: %s", rateLimitErr.RetryAfter)
+	}
+}
+
+func TestRateLimitHeadersMiddleware(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	config := RateLimitConfig{
+		GlobalLimit:     rate.Every(time.Second),
+		GlobalBurst:     5,
+		PerSessionLimit: rate.Every(time.Second),
+		PerSessionBurst: 2,
+	}
+	middleware := NewRateLimitMiddleware(config, logger)
+	handler := RateLimitHeadersMiddleware(middleware)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Without a session only the global limit applies
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/mcp", nil))
+	if got := recorder.Header().Get("RateLimit-Limit"); got != "5" {
+		t.Fatalf("Expected RateLimit-Limit 5, got %q", got)
+	}
+	if got := recorder.Header().Get("RateLimit-Remaining"); got != "5" {
+		t.Fatalf("Expected RateLimit-Remaining 5, got %q", got)
+	}
+	if got := recorder.Header().Get("RateLimit-Reset"); got != "0" {
+		t.Fatalf("Expected RateLimit-Reset 0, got %q", got)
+	}
+	if got := recorder.Header().Get("Retry-After"); got != "" {
+		t.Fatalf("Expected no Retry-After header, got %q", got)
+	}
+
+	// Exhaust the session limit
+	sessionHandler := middleware.Middleware()(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("success"), nil
+	})
+	ctx := server.NewMCPServer("test", "1.0").WithContext(context.Background(), &mockClientSession{id: "limited-session"})
+	for i := 0; i < 2; i++ {
+		if _, err := sessionHandler(ctx, mcp.CallToolRequest{}); err != nil {
+			t.Fatalf("Request %d should succeed, got error: %v", i, err)
+		}
+	}
+
+	// The session limit is the most restrictive one for requests of that session
+	request := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	request.Header.Set(server.HeaderKeySessionID, "limited-session")
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	if got := recorder.Header().Get("RateLimit-Limit"); got != "2" {
+		t.Fatalf("Expected RateLimit-Limit 2, got %q", got)
+	}
+	if got := recorder.Header().Get("RateLimit-Remaining"); got != "0" {
+		t.Fatalf("Expected RateLimit-Remaining 0, got %q", got)
+	}
+	if got := recorder.Header().Get("RateLimit-Reset"); got != "2" {
+		t.Fatalf("Expected RateLimit-Reset 2, got %q", got)
+	}
+	if got := recorder.Header().Get("Retry-After"); got != "1" {
+		t.Fatalf("Expected Retry-After 1, got %q", got)
+	}
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected the request to be passed on, got status %d", recorder.Code)
+	}
 }
 
 func TestLoadRateLimitConfigFromEnv(t *testing.T) {
@@ -0,0 +1,71 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// VaultReadAddress is the address of a performance standby or performance replica serving read-only tools
+const VaultReadAddress = "VAULT_READ_ADDR"
+
+// readOnlyCallKey is the context key marking the calls of read-only tools
+type readOnlyCallKey struct{}
+
+// ReadRoutingMiddleware marks the calls of tools annotated as read-only, so that GetVaultClientFromContext
+// sends their requests to the read replica set by VAULT_READ_ADDR. Every other tool goes to the active node.
+func ReadRoutingMiddleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if IsReadOnlyTool(ctx, request.Params.Name) {
+				ctx = context.WithValue(ctx, readOnlyCallKey{}, true)
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// readOnlyCallFromContext reports whether the current tool call is read-only
+func readOnlyCallFromContext(ctx context.Context) bool {
+	readOnly, _ := ctx.Value(readOnlyCallKey{}).(bool)
+	return readOnly
+}
+
+// readAddress returns the read replica address, or an empty string when reads go to the active node
+func readAddress(ctx context.Context) string {
+	// Vault Proxy and Vault Agent pick the node themselves and may authenticate requests with their own token
+	if proxyAddress, _ := ctx.Value(contextKey(VaultProxyAddress)).(string); proxyAddress != "" {
+		return ""
+	}
+	if getEnv(VaultProxyAddress, "") != "" {
+		return ""
+	}
+	return strings.TrimSpace(getEnv(VaultReadAddress, ""))
+}
+
+// readReplicaClient returns a copy of the client sending its requests to the read replica. Its reads carry
+// the consistency tokens of the writes of the session like those of the active node, so that they wait for
+// the writes to reach the replica. The client itself is returned when the copy cannot be created.
+func readReplicaClient(sessionID string, address string, client *api.Client, logger *log.Logger) *api.Client {
+	replica, err := client.CloneWithHeaders()
+	if err == nil {
+		err = replica.SetAddress(address)
+	}
+	if err != nil {
+		logger.WithError(err).WithField("read_addr", address).Warn("Failed to create Vault client for the read replica, using the active node")
+		return client
+	}
+
+	replica.SetToken(client.Token())
+	// Failures of the replica must not open the circuit breaker of the active node, while its requests
+	// count against the budget and the access log of the session like any other
+	replica.SetCheckRetry(recordCircuitResult(sessionID, address, countRequests(sessionID, recordAccess(sessionID, nil))))
+	return replica
+}
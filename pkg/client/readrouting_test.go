@@ -0,0 +1,101 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadRoutingMiddleware(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	newVault := func(requests *atomic.Int32, tokens chan<- string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			tokens <- r.Header.Get(VaultHeaderToken)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"data":{}}`))
+		}))
+	}
+
+	var activeRequests, replicaRequests atomic.Int32
+	tokens := make(chan string, 10)
+	active := newVault(&activeRequests, tokens)
+	defer active.Close()
+	replica := newVault(&replicaRequests, tokens)
+	defer replica.Close()
+
+	t.Setenv(VaultReadAddress, replica.URL)
+
+	sessionID := "test-read-routing"
+	_, err := NewVaultClient(sessionID, active.URL, false, "test-token", "")
+	require.NoError(t, err)
+	defer DeleteVaultClient(sessionID)
+	defer DeleteSessionUsage(sessionID)
+
+	// The tool annotations are only available when the call goes through the server
+	readVault := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		vault, err := GetVaultClientFromContext(ctx, logger)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := vault.Logical().Read("sys/mounts"); err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultText("success"), nil
+	}
+	srv := server.NewMCPServer("test", "1.0.0", server.WithToolHandlerMiddleware(ReadRoutingMiddleware()))
+	srv.AddTool(mcp.NewTool("read_tool", mcp.WithReadOnlyHintAnnotation(true)), readVault)
+	srv.AddTool(mcp.NewTool("write_tool"), readVault)
+	ctx := srv.WithContext(context.Background(), &mockClientSession{id: sessionID})
+
+	call := func(ctx context.Context, tool string) {
+		message := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":%q}}`, tool)
+		response := srv.HandleMessage(ctx, []byte(message))
+		_, failed := response.(mcp.JSONRPCError)
+		require.False(t, failed, "tool call failed: %v", response)
+		assert.Equal(t, "test-token", <-tokens)
+	}
+
+	t.Run("read-only tools go to the replica", func(t *testing.T) {
+		call(ctx, "read_tool")
+		assert.Equal(t, int32(1), replicaRequests.Load())
+		assert.Equal(t, int32(0), activeRequests.Load())
+	})
+
+	t.Run("mutating tools go to the active node", func(t *testing.T) {
+		call(ctx, "write_tool")
+		assert.Equal(t, int32(1), replicaRequests.Load())
+		assert.Equal(t, int32(1), activeRequests.Load())
+	})
+
+	t.Run("replica requests count against the session budget", func(t *testing.T) {
+		assert.Equal(t, int64(2), GetSessionUsage(sessionID).VaultRequests.Load())
+	})
+
+	t.Run("sessions routed through a proxy do not use the replica", func(t *testing.T) {
+		call(context.WithValue(ctx, contextKey(VaultProxyAddress), active.URL), "read_tool")
+		assert.Equal(t, int32(1), replicaRequests.Load())
+		assert.Equal(t, int32(2), activeRequests.Load())
+	})
+
+	t.Run("without a read address everything goes to the active node", func(t *testing.T) {
+		t.Setenv(VaultReadAddress, "")
+		call(ctx, "read_tool")
+		assert.Equal(t, int32(1), replicaRequests.Load())
+		assert.Equal(t, int32(3), activeRequests.Load())
+	})
+}
@@ -0,0 +1,91 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisTimeout bounds connecting to Redis and every command sent to it
+const redisTimeout = 5 * time.Second
+
+// RedisConfig is the Redis server shared by the session store and the path locks
+type RedisConfig struct {
+	Addr     string      // Address of the server (host:port)
+	Password string      // Password of the server, optional
+	DB       int         // Database number
+	TLS      *tls.Config // TLS configuration, plain TCP when nil
+}
+
+// LoadRedisConfigFromEnv loads the Redis server from MCP_SESSION_STORE_REDIS_ADDR, which is either an address
+// such as 'redis:6379' or a redis:// or rediss:// URL, and the password, TLS and CA file settings. The address
+// is empty when no Redis server is configured.
+func LoadRedisConfigFromEnv() (RedisConfig, error) {
+	config := RedisConfig{Addr: os.Getenv(SessionStoreRedisAddrEnv)}
+	if strings.Contains(config.Addr, "://") {
+		options, err := redis.ParseURL(config.Addr)
+		if err != nil {
+			return RedisConfig{}, fmt.Errorf("invalid %s: %w", SessionStoreRedisAddrEnv, err)
+		}
+		config = RedisConfig{Addr: options.Addr, Password: options.Password, DB: options.DB, TLS: options.TLSConfig}
+	}
+	if password := os.Getenv(SessionStoreRedisPasswordEnv); password != "" {
+		config.Password = password
+	}
+
+	if value := os.Getenv(SessionStoreRedisTLSEnv); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return RedisConfig{}, fmt.Errorf("invalid %s value '%s'", SessionStoreRedisTLSEnv, value)
+		}
+		if !enabled {
+			config.TLS = nil
+		} else if config.TLS == nil {
+			host, _, _ := net.SplitHostPort(config.Addr)
+			config.TLS = &tls.Config{MinVersion: tls.VersionTLS12, ServerName: host}
+		}
+	}
+
+	if file := os.Getenv(SessionStoreRedisCAFileEnv); file != "" {
+		if config.TLS == nil {
+			return RedisConfig{}, fmt.Errorf("%s requires TLS, set %s or use a rediss:// address", SessionStoreRedisCAFileEnv, SessionStoreRedisTLSEnv)
+		}
+		pem, err := os.ReadFile(file)
+		if err != nil {
+			return RedisConfig{}, fmt.Errorf("cannot read %s: %w", SessionStoreRedisCAFileEnv, err)
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			return RedisConfig{}, fmt.Errorf("%s holds no PEM certificate", SessionStoreRedisCAFileEnv)
+		}
+		config.TLS.RootCAs = roots
+	}
+
+	return config, nil
+}
+
+// newRedisClient creates a client keeping a pool of connections to the Redis server. Failed commands are not
+// retried: a command whose reply was lost may have been applied, such as a lock that was taken.
+func newRedisClient(config RedisConfig) *redis.Client {
+	return redis.NewClient(&redis.Options{
+		Addr:                  config.Addr,
+		Password:              config.Password,
+		DB:                    config.DB,
+		TLSConfig:             config.TLS,
+		DialTimeout:           redisTimeout,
+		ReadTimeout:           redisTimeout,
+		WriteTimeout:          redisTimeout,
+		ContextTimeoutEnabled: true,
+		MaxRetries:            -1,
+	})
+}
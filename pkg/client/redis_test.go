@@ -0,0 +1,221 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/pem"
+	"io"
+	"net"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startBrokenRedis serves a Redis server that rejects the connection handshake and answers every other
+// command with the first bytes of a bulk string before it drops the connection
+func startBrokenRedis(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					count, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+					args := make([]string, count)
+					for i := range args {
+						header, _ := reader.ReadString('\n')
+						size, _ := strconv.Atoi(strings.TrimSpace(header[1:]))
+						arg := make([]byte, size+2)
+						if _, err := io.ReadFull(reader, arg); err != nil {
+							return
+						}
+						args[i] = string(arg[:size])
+					}
+
+					switch strings.ToUpper(args[0]) {
+					case "HELLO", "CLIENT":
+						_, _ = conn.Write([]byte("-ERR unknown command\r\n"))
+					default:
+						_, _ = conn.Write([]byte("$64\r\nvault-mcp"))
+						return
+					}
+				}
+			}(conn)
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestRedisClientErrors(t *testing.T) {
+	ctx := context.Background()
+	aead := newTestSessionCipher(t)
+
+	t.Run("connection dropped in the middle of a reply", func(t *testing.T) {
+		store := NewRedisSessionStore(RedisConfig{Addr: startBrokenRedis(t)}, aead, time.Hour)
+
+		start := time.Now()
+		metadata, err := store.Load(ctx, "s1")
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		assert.Nil(t, metadata)
+		assert.Less(t, time.Since(start), redisTimeout, "a dropped connection fails at once")
+
+		locker := NewRedisPathLocker(RedisConfig{Addr: startBrokenRedis(t)})
+		_, err = locker.Lock(ctx, "secret/app", time.Minute)
+		assert.Error(t, err)
+	})
+
+	t.Run("server restarted between commands", func(t *testing.T) {
+		redisServer := miniredis.RunT(t)
+		store := NewRedisSessionStore(RedisConfig{Addr: redisServer.Addr()}, aead, time.Hour)
+		require.NoError(t, store.Save(ctx, "s1", &SessionMetadata{Address: "https://vault:8200"}))
+
+		redisServer.Close()
+		_, err := store.Load(ctx, "s1")
+		assert.Error(t, err)
+
+		require.NoError(t, redisServer.Restart())
+		metadata, err := store.Load(ctx, "s1")
+		require.NoError(t, err)
+		require.NotNil(t, metadata)
+		assert.Equal(t, "https://vault:8200", metadata.Address)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		redisServer := miniredis.RunT(t)
+		redisServer.RequireAuth("secret")
+
+		err := NewRedisSessionStore(RedisConfig{Addr: redisServer.Addr(), Password: "wrong"}, aead, time.Hour).Delete(ctx, "s1")
+		assert.Error(t, err)
+		assert.NoError(t, NewRedisSessionStore(RedisConfig{Addr: redisServer.Addr(), Password: "secret"}, aead, time.Hour).Delete(ctx, "s1"))
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		redisServer := miniredis.RunT(t)
+		store := NewRedisSessionStore(RedisConfig{Addr: redisServer.Addr()}, aead, time.Hour)
+
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := store.Load(cancelled, "s1")
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestRedisClientReusesConnections(t *testing.T) {
+	redisServer := miniredis.RunT(t)
+	store := NewRedisSessionStore(RedisConfig{Addr: redisServer.Addr()}, newTestSessionCipher(t), time.Hour)
+	ctx := context.Background()
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, store.Save(ctx, "s1", &SessionMetadata{Address: "https://vault:8200"}))
+		_, err := store.Load(ctx, "s1")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, redisServer.TotalConnectionCount())
+}
+
+func TestRedisClientTLS(t *testing.T) {
+	// The test server of net/http/httptest has a certificate for 127.0.0.1
+	certificates := httptest.NewTLSServer(nil)
+	certificates.Close()
+
+	redisServer, err := miniredis.RunTLS(&tls.Config{Certificates: certificates.TLS.Certificates})
+	require.NoError(t, err)
+	t.Cleanup(redisServer.Close)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificates.Certificate().Raw}), 0o600))
+
+	t.Setenv(SessionStoreRedisAddrEnv, "rediss://"+redisServer.Addr())
+	t.Setenv(SessionStoreRedisCAFileEnv, caFile)
+	config, err := LoadRedisConfigFromEnv()
+	require.NoError(t, err)
+	require.NotNil(t, config.TLS)
+
+	locker := NewRedisPathLocker(config)
+	unlock, err := locker.Lock(context.Background(), "secret/app", time.Minute)
+	require.NoError(t, err)
+	unlock()
+
+	// Without the CA, the certificate of the server is not trusted
+	config.TLS = &tls.Config{MinVersion: tls.VersionTLS12}
+	_, err = NewRedisPathLocker(config).Lock(context.Background(), "secret/app", time.Minute)
+	assert.Error(t, err)
+
+	// A plain connection is refused by the TLS server
+	config.TLS = nil
+	_, err = NewRedisPathLocker(config).Lock(context.Background(), "secret/app", time.Minute)
+	assert.Error(t, err)
+}
+
+func TestLoadRedisConfigFromEnv(t *testing.T) {
+	config, err := LoadRedisConfigFromEnv()
+	require.NoError(t, err)
+	assert.Empty(t, config.Addr)
+
+	t.Setenv(SessionStoreRedisAddrEnv, "redis:6379")
+	t.Setenv(SessionStoreRedisPasswordEnv, "secret")
+	config, err = LoadRedisConfigFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, RedisConfig{Addr: "redis:6379", Password: "secret"}, config)
+
+	t.Setenv(SessionStoreRedisTLSEnv, "true")
+	config, err = LoadRedisConfigFromEnv()
+	require.NoError(t, err)
+	require.NotNil(t, config.TLS)
+	assert.Equal(t, "redis", config.TLS.ServerName)
+
+	t.Setenv(SessionStoreRedisTLSEnv, "")
+	t.Setenv(SessionStoreRedisPasswordEnv, "")
+	t.Setenv(SessionStoreRedisAddrEnv, "rediss://:from-url@redis.example.com:6380/2")
+	config, err = LoadRedisConfigFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, "redis.example.com:6380", config.Addr)
+	assert.Equal(t, "from-url", config.Password)
+	assert.Equal(t, 2, config.DB)
+	require.NotNil(t, config.TLS)
+
+	t.Setenv(SessionStoreRedisTLSEnv, "sometimes")
+	_, err = LoadRedisConfigFromEnv()
+	assert.Error(t, err)
+
+	t.Setenv(SessionStoreRedisTLSEnv, "false")
+	t.Setenv(SessionStoreRedisCAFileEnv, filepath.Join(t.TempDir(), "ca.pem"))
+	_, err = LoadRedisConfigFromEnv()
+	assert.ErrorContains(t, err, "requires TLS")
+
+	t.Setenv(SessionStoreRedisAddrEnv, "redis://redis:6379")
+	t.Setenv(SessionStoreRedisTLSEnv, "true")
+	_, err = LoadRedisConfigFromEnv()
+	assert.ErrorContains(t, err, "cannot read")
+
+	t.Setenv(SessionStoreRedisAddrEnv, "http://redis:6379")
+	_, err = LoadRedisConfigFromEnv()
+	assert.Error(t, err)
+}
@@ -0,0 +1,158 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// RequestIDHeader carries the request ID in HTTP requests and responses, and in the requests sent to Vault
+	RequestIDHeader = "X-Request-Id"
+	// CorrelationIDHeader is an alias of RequestIDHeader understood by many proxies and log pipelines
+	CorrelationIDHeader = "X-Correlation-Id"
+	// RequestIDField is the name of the log field and of the result metadata holding the request ID
+	RequestIDField = "request_id"
+
+	// maxRequestIDLength bounds the request IDs accepted from clients
+	maxRequestIDLength = 128
+)
+
+// requestIDKey is the context key for the request ID
+type requestIDKey struct{}
+
+// NewRequestID generates a random request ID
+func NewRequestID() string {
+	return uuid.NewString()
+}
+
+// WithRequestID returns a copy of the context carrying the request ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID of the current request, if any
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// RequestLogger returns a log entry carrying the request ID of the context, if any
+func RequestLogger(ctx context.Context, logger *log.Logger) *log.Entry {
+	entry := log.NewEntry(logger)
+	if id := RequestIDFromContext(ctx); id != "" {
+		entry = entry.WithField(RequestIDField, id)
+	}
+	return entry
+}
+
+// validRequestID reports whether a request ID sent by a client is safe to log and forward to Vault
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// RequestIDHTTPMiddleware assigns a request ID to every HTTP request. The X-Request-Id or X-Correlation-Id
+// header of the client is reused when it is a valid ID, so that the logs of the server can be joined with
+// the logs of the client. The ID is returned in the X-Request-Id header of the response.
+func RequestIDHTTPMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(RequestIDHeader)
+			if id == "" {
+				id = r.Header.Get(CorrelationIDHeader)
+			}
+			if !validRequestID(id) {
+				id = NewRequestID()
+			}
+
+			w.Header().Set(RequestIDHeader, id)
+			next.ServeHTTP(w, r.WithContext(WithRequestID(r.Context(), id)))
+		})
+	}
+}
+
+// RequestIDMiddleware assigns a request ID to every tool call that does not have one yet, such as calls
+// received over stdio. The tool call is logged with the ID when it starts and ends, the ID is added to the
+// metadata of the result and to error messages, and GetVaultClientFromContext forwards it to Vault.
+func RequestIDMiddleware(logger *log.Logger) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			id := RequestIDFromContext(ctx)
+			if id == "" {
+				id = NewRequestID()
+				ctx = WithRequestID(ctx, id)
+			}
+
+			entry := logger.WithFields(log.Fields{
+				RequestIDField: id,
+				"session_id":   getSessionIDFromContext(ctx),
+				"tool":         request.Params.Name,
+			})
+			entry.Info("Tool call started")
+
+			start := time.Now()
+			result, err := next(ctx, request)
+			entry = entry.WithField("duration", time.Since(start).String())
+
+			if err != nil {
+				entry.WithError(err).Warn("Tool call failed")
+				return result, fmt.Errorf("%w (request ID: %s)", err, id)
+			}
+			if result == nil {
+				entry.Info("Tool call completed")
+				return nil, nil
+			}
+
+			if result.IsError {
+				entry.Warn("Tool call returned an error")
+				addRequestIDToError(result, id)
+			} else {
+				entry.Info("Tool call completed")
+			}
+			addRequestIDToMeta(result, id)
+			return result, nil
+		}
+	}
+}
+
+// addRequestIDToError appends the request ID to the last text of an error result, so that users can
+// quote it when reporting the error
+func addRequestIDToError(result *mcp.CallToolResult, id string) {
+	for i := len(result.Content) - 1; i >= 0; i-- {
+		if text, ok := mcp.AsTextContent(result.Content[i]); ok {
+			result.Content[i] = mcp.NewTextContent(fmt.Sprintf("%s (request ID: %s)", text.Text, id))
+			return
+		}
+	}
+}
+
+// addRequestIDToMeta adds the request ID to the metadata of the result
+func addRequestIDToMeta(result *mcp.CallToolResult, id string) {
+	if result.Meta == nil {
+		result.Meta = &mcp.Meta{}
+	}
+	if result.Meta.AdditionalFields == nil {
+		result.Meta.AdditionalFields = map[string]any{}
+	}
+	result.Meta.AdditionalFields[RequestIDField] = id
+}
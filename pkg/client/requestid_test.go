@@ -0,0 +1,141 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestIDHTTPMiddleware(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    string
+	}{
+		{name: "generated without a header"},
+		{name: "request ID header", headers: map[string]string{RequestIDHeader: "client-id-1"}, want: "client-id-1"},
+		{name: "correlation ID header", headers: map[string]string{CorrelationIDHeader: "trace:42"}, want: "trace:42"},
+		{name: "invalid characters", headers: map[string]string{RequestIDHeader: "bad id\r\n"}},
+		{name: "too long", headers: map[string]string{RequestIDHeader: strings.Repeat("a", maxRequestIDLength+1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var id string
+			handler := RequestIDHTTPMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				id = RequestIDFromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.NotEmpty(t, id)
+			assert.True(t, validRequestID(id))
+			if tt.want != "" {
+				assert.Equal(t, tt.want, id)
+			}
+			assert.Equal(t, id, rr.Header().Get(RequestIDHeader))
+		})
+	}
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	t.Run("generates an ID and adds it to the result metadata", func(t *testing.T) {
+		var id string
+		handler := RequestIDMiddleware(logger)(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			id = RequestIDFromContext(ctx)
+			return mcp.NewToolResultText("success"), nil
+		})
+
+		result, err := handler(context.Background(), mcp.CallToolRequest{})
+		require.NoError(t, err)
+		require.NotEmpty(t, id)
+		require.NotNil(t, result.Meta)
+		assert.Equal(t, id, result.Meta.AdditionalFields[RequestIDField])
+		assert.Equal(t, "success", result.Content[0].(mcp.TextContent).Text)
+	})
+
+	t.Run("reuses the ID of the HTTP request", func(t *testing.T) {
+		var id string
+		handler := RequestIDMiddleware(logger)(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			id = RequestIDFromContext(ctx)
+			return mcp.NewToolResultText("success"), nil
+		})
+
+		_, err := handler(WithRequestID(context.Background(), "http-id"), mcp.CallToolRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, "http-id", id)
+	})
+
+	t.Run("adds the ID to error messages", func(t *testing.T) {
+		handler := RequestIDMiddleware(logger)(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultError("Secret not found"), nil
+		})
+
+		result, err := handler(WithRequestID(context.Background(), "error-id"), mcp.CallToolRequest{})
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Equal(t, "Secret not found (request ID: error-id)", result.Content[0].(mcp.TextContent).Text)
+	})
+
+	t.Run("wraps returned errors", func(t *testing.T) {
+		rateLimitErr := &RateLimitError{message: "rate limit exceeded"}
+		handler := RequestIDMiddleware(logger)(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return nil, rateLimitErr
+		})
+
+		_, err := handler(WithRequestID(context.Background(), "wrapped-id"), mcp.CallToolRequest{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "request ID: wrapped-id")
+		assert.True(t, errors.Is(err, rateLimitErr))
+	})
+}
+
+func TestRequestIDForwardedToVault(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	received := make(chan http.Header, 1)
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	defer mockVault.Close()
+
+	sessionID := "test-request-id"
+	_, err := NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	defer DeleteVaultClient(sessionID)
+
+	srv := server.NewMCPServer("test", "1.0.0")
+	ctx := srv.WithContext(WithRequestID(context.Background(), "vault-id"), &mockClientSession{id: sessionID})
+
+	vault, err := GetVaultClientFromContext(ctx, logger)
+	require.NoError(t, err)
+	_, err = vault.Logical().Read("sys/mounts")
+	require.NoError(t, err)
+
+	headers := <-received
+	assert.Equal(t, "vault-id", headers.Get(RequestIDHeader))
+	assert.Equal(t, "vault-id", headers.Get(CorrelationIDHeader))
+}
@@ -0,0 +1,133 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// SecretArgumentCheckEnv selects what happens when a secret is passed in an argument that is not meant for
+// secrets: 'warn', 'block' or 'off'
+const SecretArgumentCheckEnv = "MCP_SECRET_ARGUMENT_CHECK"
+
+// SecretArgumentCheck is the action taken when a tool argument that is not meant for secrets holds one
+type SecretArgumentCheck string
+
+const (
+	SecretArgumentCheckOff   SecretArgumentCheck = "off"
+	SecretArgumentCheckWarn  SecretArgumentCheck = "warn"
+	SecretArgumentCheckBlock SecretArgumentCheck = "block"
+)
+
+// secretArgumentFields are the arguments allowed to hold secrets besides the sensitive arguments, such as
+// the raw request bodies of the escape hatch tools
+var secretArgumentFields = []string{"body"}
+
+// literalSecret is a kind of secret recognized in tool arguments
+type literalSecret struct {
+	kind    string
+	pattern *regexp.Regexp
+}
+
+var literalSecrets = []literalSecret{
+	{kind: "Vault token", pattern: regexp.MustCompile(`\bhv[sbr]\.[A-Za-z0-9_-]{24,}`)},
+	{kind: "Vault token", pattern: regexp.MustCompile(`\b[sbr]\.[A-Za-z0-9]{24}\b`)},
+	{kind: "private key", pattern: regexp.MustCompile(`-----BEGIN ([A-Z0-9]+ )*PRIVATE KEY-----`)},
+}
+
+// LoadSecretArgumentCheckFromEnv loads the action taken on secrets in the wrong arguments from
+// MCP_SECRET_ARGUMENT_CHECK, 'warn' by default. Invalid values are ignored with a warning.
+func LoadSecretArgumentCheckFromEnv() SecretArgumentCheck {
+	switch value := SecretArgumentCheck(strings.ToLower(strings.TrimSpace(os.Getenv(SecretArgumentCheckEnv)))); value {
+	case "":
+		return SecretArgumentCheckWarn
+	case SecretArgumentCheckOff, SecretArgumentCheckWarn, SecretArgumentCheckBlock:
+		log.Infof("%s set to %s", SecretArgumentCheckEnv, value)
+		return value
+	default:
+		log.Warnf("Invalid %s value '%s', using '%s'", SecretArgumentCheckEnv, value, SecretArgumentCheckWarn)
+		return SecretArgumentCheckWarn
+	}
+}
+
+// SecretArgumentMiddleware looks for values that look like Vault tokens or private keys in the arguments of
+// tool calls that are not meant for secrets, such as paths and descriptions, which end up in mount
+// configurations and in the audit log of Vault in clear text. Depending on the check the call is refused,
+// or runs with a warning added to its result.
+func SecretArgumentMiddleware(check SecretArgumentCheck, logger *log.Logger) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		if check == SecretArgumentCheckOff {
+			return next
+		}
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			findings := findLiteralSecrets(request.Params.Arguments)
+			if len(findings) == 0 {
+				return next(ctx, request)
+			}
+
+			// The values are never logged, only the arguments holding them
+			message := fmt.Sprintf("%s. Pass secrets only in the arguments meant for them, such as 'value' or 'password'", strings.Join(findings, ", "))
+			if check == SecretArgumentCheckBlock {
+				RequestLogger(ctx, logger).Warnf("Refused tool call with secrets in its arguments, tool: %s: %s", request.Params.Name, strings.Join(findings, ", "))
+				return nil, fmt.Errorf("tool call refused: %s", message)
+			}
+
+			RequestLogger(ctx, logger).Warnf("Tool call with secrets in its arguments, tool: %s: %s", request.Params.Name, strings.Join(findings, ", "))
+			result, err := next(ctx, request)
+			if result != nil {
+				result.Content = append(result.Content, mcp.NewTextContent("Warning: "+message+"."))
+			}
+			return result, err
+		}
+	}
+}
+
+// findLiteralSecrets describes the arguments that are not meant for secrets but hold one, in a stable order
+func findLiteralSecrets(arguments any) []string {
+	args, ok := arguments.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	var findings []string
+	for name, value := range args {
+		if slices.Contains(sensitiveArguments, name) || slices.Contains(secretArgumentFields, name) {
+			continue
+		}
+		findings = appendLiteralSecrets(findings, name, value)
+	}
+	sort.Strings(findings)
+	return findings
+}
+
+// appendLiteralSecrets inspects a value and the values nested in it
+func appendLiteralSecrets(findings []string, name string, value any) []string {
+	switch v := value.(type) {
+	case string:
+		for _, secret := range literalSecrets {
+			if secret.pattern.MatchString(v) {
+				return append(findings, fmt.Sprintf("argument '%s' looks like a %s", name, secret.kind))
+			}
+		}
+	case []any:
+		for i, item := range v {
+			findings = appendLiteralSecrets(findings, fmt.Sprintf("%s[%d]", name, i), item)
+		}
+	case map[string]any:
+		for key, item := range v {
+			findings = appendLiteralSecrets(findings, name+"."+key, item)
+		}
+	}
+	return findings
+}
@@ -0,0 +1,217 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	SessionStoreEnv              = "MCP_SESSION_STORE"
+	SessionStoreRedisAddrEnv     = "MCP_SESSION_STORE_REDIS_ADDR"
+	SessionStoreRedisPasswordEnv = "MCP_SESSION_STORE_REDIS_PASSWORD"
+	SessionStoreRedisTLSEnv      = "MCP_SESSION_STORE_REDIS_TLS"
+	SessionStoreRedisCAFileEnv   = "MCP_SESSION_STORE_REDIS_CA_FILE"
+	SessionStoreKeyEnv           = "MCP_SESSION_STORE_KEY"
+	SessionStoreTTLEnv           = "MCP_SESSION_STORE_TTL"
+)
+
+// DefaultSessionStoreTTL is how long the metadata of an idle session is kept in a shared store
+const DefaultSessionStoreTTL = 24 * time.Hour
+
+// SessionMetadata is the connection information needed to rebuild the Vault client of a session.
+// It never holds the Vault token, which is provided again by every request or by the environment.
+type SessionMetadata struct {
+	Address       string `json:"address"`
+	Namespace     string `json:"namespace,omitempty"`
+	ProxyAddress  string `json:"proxy_address,omitempty"`
+	SkipTLSVerify bool   `json:"skip_tls_verify,omitempty"`
+}
+
+// SessionStore keeps the metadata of sessions so that any server instance can rebuild their Vault clients
+type SessionStore interface {
+	Save(ctx context.Context, sessionID string, metadata *SessionMetadata) error
+	Load(ctx context.Context, sessionID string) (*SessionMetadata, error) // Returns nil when the session is unknown
+	Delete(ctx context.Context, sessionID string) error
+}
+
+var (
+	sessionStore   SessionStore = NewMemorySessionStore()
+	sessionStoreMu sync.RWMutex
+)
+
+// SetSessionStore replaces the store used for session metadata
+func SetSessionStore(store SessionStore) {
+	sessionStoreMu.Lock()
+	defer sessionStoreMu.Unlock()
+	sessionStore = store
+}
+
+// GetSessionStore returns the store used for session metadata
+func GetSessionStore() SessionStore {
+	sessionStoreMu.RLock()
+	defer sessionStoreMu.RUnlock()
+	return sessionStore
+}
+
+// LoadSessionStoreFromEnv creates the session store selected by MCP_SESSION_STORE, which is 'memory' by default
+func LoadSessionStoreFromEnv() (SessionStore, error) {
+	switch backend := os.Getenv(SessionStoreEnv); backend {
+	case "", "memory":
+		return NewMemorySessionStore(), nil
+	case "redis":
+		redisConfig, err := LoadRedisConfigFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		if redisConfig.Addr == "" {
+			return nil, fmt.Errorf("%s is required when %s is 'redis'", SessionStoreRedisAddrEnv, SessionStoreEnv)
+		}
+
+		key := os.Getenv(SessionStoreKeyEnv)
+		if key == "" {
+			return nil, fmt.Errorf("%s is required when %s is 'redis'", SessionStoreKeyEnv, SessionStoreEnv)
+		}
+		aead, err := newSessionCipher(key)
+		if err != nil {
+			return nil, err
+		}
+
+		ttl := DefaultSessionStoreTTL
+		if value := os.Getenv(SessionStoreTTLEnv); value != "" {
+			ttl, err = time.ParseDuration(value)
+			if err != nil || ttl <= 0 {
+				return nil, fmt.Errorf("invalid %s value '%s'", SessionStoreTTLEnv, value)
+			}
+		}
+
+		log.Infof("Using Redis session store at %s", redisConfig.Addr)
+		return NewRedisSessionStore(redisConfig, aead, ttl), nil
+	default:
+		return nil, fmt.Errorf("unsupported %s value '%s', use 'memory' or 'redis'", SessionStoreEnv, backend)
+	}
+}
+
+// MemorySessionStore keeps session metadata in the memory of a single server instance
+type MemorySessionStore struct {
+	sessions sync.Map
+}
+
+// NewMemorySessionStore creates an in-memory session store
+func NewMemorySessionStore() *MemorySessionStore {
+	return &MemorySessionStore{}
+}
+
+func (s *MemorySessionStore) Save(_ context.Context, sessionID string, metadata *SessionMetadata) error {
+	stored := *metadata
+	s.sessions.Store(sessionID, &stored)
+	return nil
+}
+
+func (s *MemorySessionStore) Load(_ context.Context, sessionID string) (*SessionMetadata, error) {
+	if value, ok := s.sessions.Load(sessionID); ok {
+		stored := *value.(*SessionMetadata)
+		return &stored, nil
+	}
+	return nil, nil
+}
+
+func (s *MemorySessionStore) Delete(_ context.Context, sessionID string) error {
+	s.sessions.Delete(sessionID)
+	return nil
+}
+
+// RedisSessionStore keeps encrypted session metadata in Redis so that it is shared by all server instances
+type RedisSessionStore struct {
+	client *redis.Client
+	aead   cipher.AEAD
+	ttl    time.Duration
+}
+
+// NewRedisSessionStore creates a session store backed by Redis. Metadata is encrypted with the given cipher
+// and expires after ttl without activity.
+func NewRedisSessionStore(config RedisConfig, aead cipher.AEAD, ttl time.Duration) *RedisSessionStore {
+	return &RedisSessionStore{
+		client: newRedisClient(config),
+		aead:   aead,
+		ttl:    ttl,
+	}
+}
+
+func (s *RedisSessionStore) key(sessionID string) string {
+	return "vault-mcp-server:session:" + sessionID
+}
+
+func (s *RedisSessionStore) Save(ctx context.Context, sessionID string, metadata *SessionMetadata) error {
+	plaintext, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	// The session ID is bound to the ciphertext so that entries cannot be swapped between sessions
+	ciphertext := s.aead.Seal(nonce, nonce, plaintext, []byte(sessionID))
+
+	return s.client.Set(ctx, s.key(sessionID), ciphertext, s.ttl).Err()
+}
+
+func (s *RedisSessionStore) Load(ctx context.Context, sessionID string) (*SessionMetadata, error) {
+	ciphertext, err := s.client.Get(ctx, s.key(sessionID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	nonceSize := s.aead.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, errors.New("stored session metadata is corrupt")
+	}
+	plaintext, err := s.aead.Open(nil, ciphertext[:nonceSize], ciphertext[nonceSize:], []byte(sessionID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt stored session metadata: %w", err)
+	}
+
+	metadata := &SessionMetadata{}
+	if err := json.Unmarshal(plaintext, metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+func (s *RedisSessionStore) Delete(ctx context.Context, sessionID string) error {
+	return s.client.Del(ctx, s.key(sessionID)).Err()
+}
+
+// newSessionCipher creates an AES-GCM cipher from a base64 encoded 16, 24 or 32 byte key
+func newSessionCipher(encodedKey string) (cipher.AEAD, error) {
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be base64 encoded: %w", SessionStoreKeyEnv, err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", SessionStoreKeyEnv, err)
+	}
+
+	return cipher.NewGCM(block)
+}
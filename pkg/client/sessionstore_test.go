@@ -0,0 +1,96 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemorySessionStore(t *testing.T) {
+	store := NewMemorySessionStore()
+	ctx := context.Background()
+
+	metadata, err := store.Load(ctx, "missing")
+	require.NoError(t, err)
+	assert.Nil(t, metadata)
+
+	require.NoError(t, store.Save(ctx, "s1", &SessionMetadata{Address: "https://vault:8200", Namespace: "team-a"}))
+	metadata, err = store.Load(ctx, "s1")
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", metadata.Namespace)
+
+	require.NoError(t, store.Delete(ctx, "s1"))
+	metadata, err = store.Load(ctx, "s1")
+	require.NoError(t, err)
+	assert.Nil(t, metadata)
+}
+
+// newTestSessionCipher creates a session cipher with a random key
+func newTestSessionCipher(t *testing.T) cipher.AEAD {
+	t.Helper()
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	aead, err := newSessionCipher(base64.StdEncoding.EncodeToString(key))
+	require.NoError(t, err)
+	return aead
+}
+
+func TestRedisSessionStore(t *testing.T) {
+	redisServer := miniredis.RunT(t)
+	store := NewRedisSessionStore(RedisConfig{Addr: redisServer.Addr()}, newTestSessionCipher(t), time.Hour)
+	ctx := context.Background()
+
+	require.NoError(t, store.Save(ctx, "s1", &SessionMetadata{Address: "https://vault:8200", SkipTLSVerify: true}))
+	require.Len(t, redisServer.Keys(), 1)
+	value, err := redisServer.Get(store.key("s1"))
+	require.NoError(t, err)
+	assert.NotContains(t, value, "vault:8200", "metadata must be encrypted at rest")
+	assert.Equal(t, time.Hour, redisServer.TTL(store.key("s1")))
+
+	metadata, err := store.Load(ctx, "s1")
+	require.NoError(t, err)
+	require.NotNil(t, metadata)
+	assert.Equal(t, "https://vault:8200", metadata.Address)
+	assert.True(t, metadata.SkipTLSVerify)
+
+	// An entry copied to another session cannot be decrypted
+	require.NoError(t, redisServer.Set(store.key("s2"), value))
+	_, err = store.Load(ctx, "s2")
+	assert.Error(t, err)
+
+	require.NoError(t, store.Delete(ctx, "s1"))
+	metadata, err = store.Load(ctx, "s1")
+	require.NoError(t, err)
+	assert.Nil(t, metadata)
+}
+
+func TestLoadSessionStoreFromEnv(t *testing.T) {
+	store, err := LoadSessionStoreFromEnv()
+	require.NoError(t, err)
+	assert.IsType(t, &MemorySessionStore{}, store)
+
+	t.Setenv(SessionStoreEnv, "redis")
+	_, err = LoadSessionStoreFromEnv()
+	assert.Error(t, err)
+
+	t.Setenv(SessionStoreRedisAddrEnv, "127.0.0.1:6379")
+	t.Setenv(SessionStoreKeyEnv, "not a key")
+	_, err = LoadSessionStoreFromEnv()
+	assert.Error(t, err)
+
+	t.Setenv(SessionStoreKeyEnv, base64.StdEncoding.EncodeToString(make([]byte, 32)))
+	store, err = LoadSessionStoreFromEnv()
+	require.NoError(t, err)
+	assert.IsType(t, &RedisSessionStore{}, store)
+}
@@ -0,0 +1,387 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+)
+
+const (
+	TenancyConfigFileEnv = "MCP_TENANCY_CONFIG_FILE"
+	HeaderAPIKey         = "X-MCP-API-Key"
+	HeaderClientID       = "X-MCP-Client-ID"
+
+	// DocsURIPrefix starts the URIs of the reference documents, which hold no Vault data
+	DocsURIPrefix = "vault-docs://"
+)
+
+// TenantProfile restricts what the clients of one tenant can do through the HTTP transport
+type TenantProfile struct {
+	Name              string   `json:"name"`
+	APIKeys           []string `json:"api_keys"`           // API keys sent in the X-MCP-API-Key header
+	ClientIDs         []string `json:"client_ids"`         // Client IDs sent in the X-MCP-Client-ID header by an authenticating proxy
+	AllowedTools      []string `json:"allowed_tools"`      // Glob patterns of allowed tool names, all tools are allowed when empty
+	AllowedResources  []string `json:"allowed_resources"`  // Glob patterns of allowed resource URIs, see AllowsResource when empty
+	AllowedNamespaces []string `json:"allowed_namespaces"` // Allowed Vault namespaces including their children, all namespaces are allowed when empty
+	ReadOnly          bool     `json:"read_only"`          // Only allow tools annotated as read-only
+	RateLimit         string   `json:"rate_limit"`         // Rate limit shared by all sessions of the tenant (format: "rps:burst")
+
+	limiter *rate.Limiter
+}
+
+// TenancyConfig maps API keys and client IDs to tenant profiles
+type TenancyConfig struct {
+	Profiles []*TenantProfile `json:"profiles"`
+}
+
+type tenantProfileKey struct{}
+
+// LoadTenancyConfigFromEnv loads the tenant profiles from the file in MCP_TENANCY_CONFIG_FILE.
+// It returns nil when multi-tenancy is not configured.
+func LoadTenancyConfigFromEnv() (*TenancyConfig, error) {
+	file := os.Getenv(TenancyConfigFileEnv)
+	if file == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read tenancy configuration file %s: %w", file, err)
+	}
+
+	return ParseTenancyConfig(data)
+}
+
+// ParseTenancyConfig parses and validates a JSON tenancy configuration
+func ParseTenancyConfig(data []byte) (*TenancyConfig, error) {
+	config := &TenancyConfig{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("invalid tenancy configuration: %w", err)
+	}
+	if len(config.Profiles) == 0 {
+		return nil, errors.New("tenancy configuration has no profiles")
+	}
+
+	names := map[string]bool{}
+	for _, profile := range config.Profiles {
+		if profile == nil || profile.Name == "" {
+			return nil, errors.New("every tenant profile needs a name")
+		}
+		if names[profile.Name] {
+			return nil, fmt.Errorf("duplicate tenant profile '%s'", profile.Name)
+		}
+		names[profile.Name] = true
+
+		if len(profile.APIKeys) == 0 && len(profile.ClientIDs) == 0 {
+			return nil, fmt.Errorf("tenant profile '%s' needs at least one API key or client ID", profile.Name)
+		}
+		for _, pattern := range profile.AllowedTools {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("tenant profile '%s' has an invalid tool pattern '%s'", profile.Name, pattern)
+			}
+		}
+		for _, pattern := range profile.AllowedResources {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("tenant profile '%s' has an invalid resource pattern '%s'", profile.Name, pattern)
+			}
+		}
+		for i, namespace := range profile.AllowedNamespaces {
+			profile.AllowedNamespaces[i] = strings.Trim(namespace, "/")
+		}
+
+		if profile.RateLimit != "" {
+			rps, burst := parseRateLimit(profile.RateLimit)
+			if rps <= 0 || burst <= 0 {
+				return nil, fmt.Errorf("tenant profile '%s' has an invalid rate limit '%s'", profile.Name, profile.RateLimit)
+			}
+			profile.limiter = rate.NewLimiter(rate.Limit(rps), burst)
+		}
+	}
+
+	return config, nil
+}
+
+// ErrUnknownTenant is returned for requests without the API key or client ID of a tenant profile
+var ErrUnknownTenant = errors.New("a valid X-MCP-API-Key or X-MCP-Client-ID is required")
+
+// Resolve returns the profile matching the API key or client ID of the request, or nil when none matches.
+// The client ID is only honoured when the request comes from a trusted proxy, which is expected to set it
+// after authenticating the client.
+func (c *TenancyConfig) Resolve(r *http.Request, proxies TrustedProxies) *TenantProfile {
+	return c.resolve(r.Header.Get(HeaderAPIKey), proxies.clientID(r.RemoteAddr, r.Header.Get(HeaderClientID)))
+}
+
+// resolve returns the profile matching an API key or client ID, or nil when none matches
+func (c *TenancyConfig) resolve(apiKey, clientID string) *TenantProfile {
+	for _, profile := range c.Profiles {
+		if apiKey != "" {
+			for _, key := range profile.APIKeys {
+				if subtle.ConstantTimeCompare([]byte(key), []byte(apiKey)) == 1 {
+					return profile
+				}
+			}
+		}
+		if clientID != "" {
+			for _, id := range profile.ClientIDs {
+				if id == clientID {
+					return profile
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// clientID returns the client ID sent by the peer when the peer is a trusted proxy, and an empty string
+// otherwise, since any other client could claim the ID of another tenant
+func (p TrustedProxies) clientID(peer string, clientID string) string {
+	if !p.trusts(hostOnly(peer)) {
+		return ""
+	}
+	return clientID
+}
+
+// authorize returns the profile matching the credentials of a request. It fails with ErrUnknownTenant when
+// no profile matches, and when the profile may not use the Vault namespace of the request.
+func (c *TenancyConfig) authorize(apiKey, clientID, namespace string) (*TenantProfile, error) {
+	profile := c.resolve(apiKey, clientID)
+	if profile == nil {
+		return nil, ErrUnknownTenant
+	}
+	if namespace == "" {
+		namespace = getEnv(VaultNamespace, "")
+	}
+	if !profile.AllowsNamespace(namespace) {
+		return profile, fmt.Errorf("namespace '%s' is not allowed for this client", namespace)
+	}
+	return profile, nil
+}
+
+// AllowsTool reports whether the profile may call the named tool
+func (p *TenantProfile) AllowsTool(name string, readOnly bool) bool {
+	if p.ReadOnly && !readOnly {
+		return false
+	}
+	if len(p.AllowedTools) == 0 {
+		return true
+	}
+	for _, pattern := range p.AllowedTools {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceGuards holds the checks that a profile must also pass to list and read a resource, by URI
+var resourceGuards sync.Map
+
+// GuardResource adds a check that a tenant profile must pass, in addition to its allowed_resources, to list
+// and read the resource with the URI, such as a resource serving the result of a tool
+func GuardResource(uri string, allows func(profile *TenantProfile) bool) {
+	resourceGuards.Store(uri, allows)
+}
+
+// AllowsResource reports whether the profile may list and read the resource with the URI, or the resource
+// template with the URI template. Without allowed_resources, a profile that restricts its tools may only read
+// the reference documents, since the other resources serve the same Vault data as tools; a profile that
+// allows every tool may read every resource.
+func (p *TenantProfile) AllowsResource(uri string) bool {
+	if guard, ok := resourceGuards.Load(uri); ok && !guard.(func(*TenantProfile) bool)(p) {
+		return false
+	}
+	if len(p.AllowedResources) == 0 {
+		return len(p.AllowedTools) == 0 || strings.HasPrefix(uri, DocsURIPrefix)
+	}
+	for _, pattern := range p.AllowedResources {
+		if matched, _ := path.Match(pattern, uri); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsNamespace reports whether the profile may use the namespace or one of its children
+func (p *TenantProfile) AllowsNamespace(namespace string) bool {
+	if len(p.AllowedNamespaces) == 0 {
+		return true
+	}
+	namespace = strings.Trim(namespace, "/")
+	for _, allowed := range p.AllowedNamespaces {
+		if namespace == allowed || strings.HasPrefix(namespace, allowed+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// TenantProfileFromContext returns the tenant profile of the request, or nil when multi-tenancy is disabled
+func TenantProfileFromContext(ctx context.Context) *TenantProfile {
+	profile, _ := ctx.Value(tenantProfileKey{}).(*TenantProfile)
+	return profile
+}
+
+// TenancyHTTPMiddleware resolves the tenant profile of every HTTP request and rejects requests
+// without a known API key or client ID, or for a namespace the tenant may not use. Client IDs are only
+// accepted from trusted proxies.
+func TenancyHTTPMiddleware(config *TenancyConfig, proxies TrustedProxies, logger *log.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if config == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			clientID := proxies.clientID(r.RemoteAddr, r.Header.Get(HeaderClientID))
+			profile, err := config.authorize(r.Header.Get(HeaderAPIKey), clientID, r.Header.Get(VaultHeaderNamespace))
+			if errors.Is(err, ErrUnknownTenant) {
+				logger.Warnf("Rejected request from %s without a valid API key or client ID", requestClientIP(r))
+				http.Error(w, "Unauthorized: a valid X-MCP-API-Key or X-MCP-Client-ID header is required", http.StatusUnauthorized)
+				return
+			}
+			if err != nil {
+				logger.WithFields(log.Fields{
+					"tenant": profile.Name,
+				}).WithError(err).Warn("Rejected request for a namespace the tenant may not use")
+				http.Error(w, "Forbidden: "+err.Error(), http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), tenantProfileKey{}, profile)))
+		})
+	}
+}
+
+// TenantContextFromHeaders resolves the tenant profile of a request of a transport other than HTTP, such as
+// the metadata of a gRPC stream, and adds it to the context. The header function returns the values of the
+// HTTP header names, and the client ID is only accepted when the peer address is a trusted proxy. It fails
+// when no profile matches or the profile may not use the namespace of the request.
+func TenantContextFromHeaders(ctx context.Context, config *TenancyConfig, proxies TrustedProxies, peer string, header func(name string) string) (context.Context, *TenantProfile, error) {
+	profile, err := config.authorize(header(HeaderAPIKey), proxies.clientID(peer, header(HeaderClientID)), header(VaultHeaderNamespace))
+	if err != nil {
+		return ctx, profile, err
+	}
+	return context.WithValue(ctx, tenantProfileKey{}, profile), profile, nil
+}
+
+// TenancyMiddleware enforces the tenant profile of the request on every tool call
+func TenancyMiddleware(logger *log.Logger) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			profile := TenantProfileFromContext(ctx)
+			if profile == nil {
+				return next(ctx, request)
+			}
+
+			toolName := request.Params.Name
+			if !profile.AllowsTool(toolName, IsReadOnlyTool(ctx, toolName)) {
+				RequestLogger(ctx, logger).Warnf("Tool %s is not allowed for tenant: %s", toolName, profile.Name)
+				return nil, fmt.Errorf("tool '%s' is not allowed for this client", toolName)
+			}
+
+			if namespace := namespaceFromContext(ctx); namespace != "" && !profile.AllowsNamespace(namespace) {
+				RequestLogger(ctx, logger).Warnf("Namespace %s is not allowed for tenant: %s, tool: %s", namespace, profile.Name, toolName)
+				return nil, fmt.Errorf("namespace '%s' is not allowed for this client", namespace)
+			}
+
+			if profile.limiter != nil && !profile.limiter.Allow() {
+				RequestLogger(ctx, logger).Warnf("Tenant rate limit exceeded for tenant: %s, tool: %s", profile.Name, toolName)
+				return nil, errors.New("rate limit exceeded: too many requests from this tenant")
+			}
+
+			return next(ctx, request)
+		}
+	}
+}
+
+// TenancyToolFilter hides the tools that the tenant profile of the request may not call
+func TenancyToolFilter(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+	profile := TenantProfileFromContext(ctx)
+	if profile == nil {
+		return tools
+	}
+
+	allowed := make([]mcp.Tool, 0, len(tools))
+	for _, tool := range tools {
+		readOnly := tool.Annotations.ReadOnlyHint != nil && *tool.Annotations.ReadOnlyHint
+		if profile.AllowsTool(tool.Name, readOnly) {
+			allowed = append(allowed, tool)
+		}
+	}
+	return allowed
+}
+
+// TenancyResourceMiddleware enforces the tenant profile of the request on every resource read, including the
+// rate limit shared by the sessions of the tenant
+func TenancyResourceMiddleware(logger *log.Logger) server.ResourceHandlerMiddleware {
+	return func(next server.ResourceHandlerFunc) server.ResourceHandlerFunc {
+		return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			profile := TenantProfileFromContext(ctx)
+			if profile == nil {
+				return next(ctx, request)
+			}
+
+			uri := request.Params.URI
+			if !profile.AllowsResource(uri) {
+				RequestLogger(ctx, logger).Warnf("Resource %s is not allowed for tenant: %s", uri, profile.Name)
+				return nil, fmt.Errorf("resource '%s' is not allowed for this client", uri)
+			}
+
+			if profile.limiter != nil && !profile.limiter.Allow() {
+				RequestLogger(ctx, logger).Warnf("Tenant rate limit exceeded for tenant: %s, resource: %s", profile.Name, uri)
+				return nil, errors.New("rate limit exceeded: too many requests from this tenant")
+			}
+
+			return next(ctx, request)
+		}
+	}
+}
+
+// TenancyResourceFilter hides the resources that the tenant profile of the request may not read. It is
+// registered as a hook after resources are listed.
+func TenancyResourceFilter(ctx context.Context, _ any, _ *mcp.ListResourcesRequest, result *mcp.ListResourcesResult) {
+	profile := TenantProfileFromContext(ctx)
+	if profile == nil || result == nil {
+		return
+	}
+
+	allowed := make([]mcp.Resource, 0, len(result.Resources))
+	for _, resource := range result.Resources {
+		if profile.AllowsResource(resource.URI) {
+			allowed = append(allowed, resource)
+		}
+	}
+	result.Resources = allowed
+}
+
+// TenancyResourceTemplateFilter hides the resource templates that the tenant profile of the request may not read.
+// It is registered as a hook after resource templates are listed.
+func TenancyResourceTemplateFilter(ctx context.Context, _ any, _ *mcp.ListResourceTemplatesRequest, result *mcp.ListResourceTemplatesResult) {
+	profile := TenantProfileFromContext(ctx)
+	if profile == nil || result == nil {
+		return
+	}
+
+	allowed := make([]mcp.ResourceTemplate, 0, len(result.ResourceTemplates))
+	for _, template := range result.ResourceTemplates {
+		if template.URITemplate != nil && profile.AllowsResource(template.URITemplate.Raw()) {
+			allowed = append(allowed, template)
+		}
+	}
+	result.ResourceTemplates = allowed
+}
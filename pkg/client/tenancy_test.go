@@ -0,0 +1,242 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTenancyConfig = `{
+  "profiles": [
+    {
+      "name": "team-a",
+      "api_keys": ["key-a"],
+      "allowed_tools": ["list_*", "read_secret"],
+      "allowed_namespaces": ["team-a/"],
+      "rate_limit": "1:1"
+    },
+    {
+      "name": "auditors",
+      "client_ids": ["auditor"],
+      "read_only": true
+    }
+  ]
+}`
+
+func TestParseTenancyConfig(t *testing.T) {
+	config, err := ParseTenancyConfig([]byte(testTenancyConfig))
+	require.NoError(t, err)
+	require.Len(t, config.Profiles, 2)
+	assert.Equal(t, []string{"team-a"}, config.Profiles[0].AllowedNamespaces)
+
+	_, err = ParseTenancyConfig([]byte(`{"profiles": [{"name": "no-credentials"}]}`))
+	assert.Error(t, err)
+
+	_, err = ParseTenancyConfig([]byte(`{"profiles": [{"name": "a", "api_keys": ["k"], "rate_limit": "fast"}]}`))
+	assert.Error(t, err)
+}
+
+func TestTenantProfile(t *testing.T) {
+	config, err := ParseTenancyConfig([]byte(testTenancyConfig))
+	require.NoError(t, err)
+	teamA, auditors := config.Profiles[0], config.Profiles[1]
+
+	assert.True(t, teamA.AllowsTool("list_mounts", false))
+	assert.True(t, teamA.AllowsTool("read_secret", true))
+	assert.False(t, teamA.AllowsTool("delete_mount", false))
+	assert.True(t, auditors.AllowsTool("list_mounts", true))
+	assert.False(t, auditors.AllowsTool("write_secret", false))
+
+	assert.True(t, teamA.AllowsNamespace("team-a"))
+	assert.True(t, teamA.AllowsNamespace("team-a/dev/"))
+	assert.False(t, teamA.AllowsNamespace("team-ab"))
+	assert.False(t, teamA.AllowsNamespace(""))
+	assert.True(t, auditors.AllowsNamespace(""))
+}
+
+func TestTenancyHTTPMiddleware(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	config, err := ParseTenancyConfig([]byte(testTenancyConfig))
+	require.NoError(t, err)
+
+	proxies, err := ParseTrustedProxies("10.0.0.0/8")
+	require.NoError(t, err)
+
+	var profile *TenantProfile
+	handler := TenancyHTTPMiddleware(config, proxies, logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		profile = TenantProfileFromContext(r.Context())
+	}))
+
+	tests := []struct {
+		name       string
+		headers    map[string]string
+		remoteAddr string
+		status     int
+		tenant     string
+	}{
+		{"missing credentials", map[string]string{}, "", http.StatusUnauthorized, ""},
+		{"unknown API key", map[string]string{HeaderAPIKey: "other"}, "", http.StatusUnauthorized, ""},
+		{"API key with allowed namespace", map[string]string{HeaderAPIKey: "key-a", VaultHeaderNamespace: "team-a"}, "", http.StatusOK, "team-a"},
+		{"API key with other namespace", map[string]string{HeaderAPIKey: "key-a", VaultHeaderNamespace: "team-b"}, "", http.StatusForbidden, ""},
+		{"client ID from trusted proxy", map[string]string{HeaderClientID: "auditor"}, "10.0.0.5:4321", http.StatusOK, "auditors"},
+		{"client ID from untrusted peer", map[string]string{HeaderClientID: "auditor"}, "192.0.2.1:1234", http.StatusUnauthorized, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile = nil
+			req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+			if tt.remoteAddr != "" {
+				req.RemoteAddr = tt.remoteAddr
+			}
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+			if tt.tenant != "" {
+				require.NotNil(t, profile)
+				assert.Equal(t, tt.tenant, profile.Name)
+			}
+		})
+	}
+}
+
+func TestTenancyMiddleware(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	config, err := ParseTenancyConfig([]byte(testTenancyConfig))
+	require.NoError(t, err)
+
+	handler := TenancyMiddleware(logger)(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("success"), nil
+	})
+	call := func(ctx context.Context, name string) error {
+		_, err := handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: name}})
+		return err
+	}
+
+	// Without a profile every tool is allowed
+	assert.NoError(t, call(context.Background(), "delete_mount"))
+
+	ctx := context.WithValue(context.Background(), tenantProfileKey{}, config.Profiles[0])
+	assert.Error(t, call(ctx, "delete_mount"))
+	assert.Error(t, call(context.WithValue(ctx, namespaceOverrideKey{}, "team-b"), "list_mounts"))
+	assert.NoError(t, call(ctx, "list_mounts"))
+	assert.ErrorContains(t, call(ctx, "list_mounts"), "rate limit exceeded")
+}
+
+func TestTenancyToolFilter(t *testing.T) {
+	config, err := ParseTenancyConfig([]byte(testTenancyConfig))
+	require.NoError(t, err)
+
+	tools := []mcp.Tool{
+		mcp.NewTool("list_mounts", mcp.WithReadOnlyHintAnnotation(true)),
+		mcp.NewTool("delete_mount"),
+	}
+
+	assert.Len(t, TenancyToolFilter(context.Background(), tools), 2)
+
+	ctx := context.WithValue(context.Background(), tenantProfileKey{}, config.Profiles[1])
+	filtered := TenancyToolFilter(ctx, tools)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "list_mounts", filtered[0].Name)
+}
+
+func TestTenantProfileAllowsResource(t *testing.T) {
+	config, err := ParseTenancyConfig([]byte(`{"profiles": [
+		{"name": "team-a", "api_keys": ["a"], "allowed_tools": ["list_*"]},
+		{"name": "team-b", "api_keys": ["b"], "allowed_tools": ["list_*"], "allowed_resources": ["vault://mounts/*/stats"]},
+		{"name": "admins", "api_keys": ["c"]}
+	]}`))
+	require.NoError(t, err)
+	teamA, teamB, admins := config.Profiles[0], config.Profiles[1], config.Profiles[2]
+
+	assert.True(t, teamA.AllowsResource("vault-docs://pki/setup"))
+	assert.False(t, teamA.AllowsResource("vault://calendar/expirations"))
+	assert.True(t, teamB.AllowsResource("vault://mounts/secret/stats"))
+	assert.True(t, teamB.AllowsResource("vault://mounts/{mount}/stats"))
+	assert.False(t, teamB.AllowsResource("vault://session/transcript"))
+	assert.False(t, teamB.AllowsResource("vault-docs://pki/setup"))
+	assert.True(t, admins.AllowsResource("vault://jobs"))
+
+	_, err = ParseTenancyConfig([]byte(`{"profiles": [{"name": "a", "api_keys": ["k"], "allowed_resources": ["vault://["]}]}`))
+	assert.Error(t, err)
+}
+
+func TestTenancyResourceMiddleware(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	config, err := ParseTenancyConfig([]byte(testTenancyConfig))
+	require.NoError(t, err)
+
+	handler := TenancyResourceMiddleware(logger)(func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		return []mcp.ResourceContents{mcp.TextResourceContents{URI: request.Params.URI, Text: "success"}}, nil
+	})
+	read := func(ctx context.Context, uri string) error {
+		_, err := handler(ctx, mcp.ReadResourceRequest{Params: mcp.ReadResourceParams{URI: uri}})
+		return err
+	}
+
+	// Without a profile every resource is allowed
+	assert.NoError(t, read(context.Background(), "vault://topology/mermaid"))
+
+	ctx := context.WithValue(context.Background(), tenantProfileKey{}, config.Profiles[0])
+	assert.ErrorContains(t, read(ctx, "vault://topology/mermaid"), "not allowed")
+	assert.ErrorContains(t, read(ctx, "vault://mounts/secret/stats"), "not allowed")
+	assert.NoError(t, read(ctx, "vault-docs://kv/versions"))
+	assert.ErrorContains(t, read(ctx, "vault-docs://kv/versions"), "rate limit exceeded")
+}
+
+func TestTenancyResourceFilters(t *testing.T) {
+	config, err := ParseTenancyConfig([]byte(testTenancyConfig))
+	require.NoError(t, err)
+
+	newResult := func() *mcp.ListResourcesResult {
+		return &mcp.ListResourcesResult{Resources: []mcp.Resource{
+			mcp.NewResource("vault-docs://pki/setup", "PKI setup guide"),
+			mcp.NewResource("vault://calendar/expirations", "Expiration calendar"),
+		}}
+	}
+	newTemplates := func() *mcp.ListResourceTemplatesResult {
+		return &mcp.ListResourceTemplatesResult{ResourceTemplates: []mcp.ResourceTemplate{
+			mcp.NewResourceTemplate("vault://mounts/{mount}/stats", "KV mount statistics"),
+		}}
+	}
+
+	result, templates := newResult(), newTemplates()
+	TenancyResourceFilter(context.Background(), 1, nil, result)
+	TenancyResourceTemplateFilter(context.Background(), 1, nil, templates)
+	assert.Len(t, result.Resources, 2)
+	assert.Len(t, templates.ResourceTemplates, 1)
+
+	// The auditors may call every read-only tool, so they may read every resource
+	ctx := context.WithValue(context.Background(), tenantProfileKey{}, config.Profiles[1])
+	result = newResult()
+	TenancyResourceFilter(ctx, 1, nil, result)
+	assert.Len(t, result.Resources, 2)
+
+	ctx = context.WithValue(context.Background(), tenantProfileKey{}, config.Profiles[0])
+	result, templates = newResult(), newTemplates()
+	TenancyResourceFilter(ctx, 1, nil, result)
+	TenancyResourceTemplateFilter(ctx, 1, nil, templates)
+	require.Len(t, result.Resources, 1)
+	assert.Equal(t, "vault-docs://pki/setup", result.Resources[0].URI)
+	assert.Empty(t, templates.ResourceTemplates)
+}
@@ -0,0 +1,137 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// ToolTimeoutEnv sets the timeout of tool calls without an override, 0 disables it
+	ToolTimeoutEnv = "MCP_TOOL_TIMEOUT"
+	// ToolTimeoutsEnv sets the timeouts of individual tools as a comma-separated list of tool=duration pairs
+	ToolTimeoutsEnv = "MCP_TOOL_TIMEOUTS"
+)
+
+// ErrToolTimeout is returned when a tool call does not complete within its timeout
+var ErrToolTimeout = errors.New("tool call timed out")
+
+// ToolTimeoutConfig holds the timeouts of tool calls. A timeout of zero disables it.
+type ToolTimeoutConfig struct {
+	Default time.Duration            // Timeout of the tools without an override
+	Tools   map[string]time.Duration // Timeouts of individual tools, by tool name
+}
+
+// LoadToolTimeoutConfigFromEnv loads the tool timeouts from MCP_TOOL_TIMEOUT and MCP_TOOL_TIMEOUTS.
+// Invalid entries are ignored with a warning.
+func LoadToolTimeoutConfigFromEnv() ToolTimeoutConfig {
+	config := ToolTimeoutConfig{Tools: map[string]time.Duration{}}
+
+	if value := os.Getenv(ToolTimeoutEnv); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed >= 0 {
+			config.Default = parsed
+			log.Infof("%s set to %s", ToolTimeoutEnv, parsed)
+		} else {
+			log.Warnf("Invalid %s value '%s', tool calls have no default timeout", ToolTimeoutEnv, value)
+		}
+	}
+
+	for _, entry := range strings.Split(os.Getenv(ToolTimeoutsEnv), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		parsed, err := time.ParseDuration(strings.TrimSpace(value))
+		if !ok || name == "" || err != nil || parsed < 0 {
+			log.Warnf("Invalid %s entry '%s', expected tool=duration", ToolTimeoutsEnv, entry)
+			continue
+		}
+		config.Tools[name] = parsed
+	}
+	if len(config.Tools) > 0 {
+		log.Infof("Timeouts set for %d tools", len(config.Tools))
+	}
+
+	return config
+}
+
+// Timeout returns the timeout of the named tool, 0 when it has none
+func (c ToolTimeoutConfig) Timeout(tool string) time.Duration {
+	if timeout, ok := c.Tools[tool]; ok {
+		return timeout
+	}
+	return c.Default
+}
+
+// Longest returns the longest timeout of the configuration
+func (c ToolTimeoutConfig) Longest() time.Duration {
+	longest := c.Default
+	for _, timeout := range c.Tools {
+		longest = max(longest, timeout)
+	}
+	return longest
+}
+
+// ToolTimeoutMiddleware bounds the duration of tool calls by their timeouts
+type ToolTimeoutMiddleware struct {
+	config ToolTimeoutConfig
+	logger *log.Logger
+}
+
+// NewToolTimeoutMiddleware creates a new tool timeout middleware
+func NewToolTimeoutMiddleware(config ToolTimeoutConfig, logger *log.Logger) *ToolTimeoutMiddleware {
+	return &ToolTimeoutMiddleware{
+		config: config,
+		logger: logger,
+	}
+}
+
+// Middleware returns the tool handler middleware function. The handler runs with a context that expires
+// after the timeout of the tool, and the call fails with ErrToolTimeout once it expires, even if the handler
+// is still waiting on a request that does not use the context.
+func (m *ToolTimeoutMiddleware) Middleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			timeout := m.config.Timeout(request.Params.Name)
+			if timeout <= 0 {
+				return next(ctx, request)
+			}
+
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			type callResult struct {
+				result *mcp.CallToolResult
+				err    error
+			}
+			done := make(chan callResult, 1)
+			go func() {
+				result, err := next(ctx, request)
+				done <- callResult{result, err}
+			}()
+
+			select {
+			case call := <-done:
+				return call.result, call.err
+			case <-ctx.Done():
+				if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					return nil, ctx.Err()
+				}
+				RequestLogger(ctx, m.logger).Warnf("Tool call timed out after %s, tool: %s", timeout, request.Params.Name)
+				return nil, fmt.Errorf("%w: '%s' did not complete within %s", ErrToolTimeout, request.Params.Name, timeout)
+			}
+		}
+	}
+}
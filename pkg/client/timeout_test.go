@@ -0,0 +1,70 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadToolTimeoutConfigFromEnv(t *testing.T) {
+	t.Setenv(ToolTimeoutEnv, "20s")
+	t.Setenv(ToolTimeoutsEnv, "analyze_security_health=60s, read_secret=5s,broken,write_secret=soon,=1s")
+
+	config := LoadToolTimeoutConfigFromEnv()
+	assert.Equal(t, 20*time.Second, config.Default)
+	assert.Equal(t, map[string]time.Duration{
+		"analyze_security_health": 60 * time.Second,
+		"read_secret":             5 * time.Second,
+	}, config.Tools)
+
+	assert.Equal(t, 5*time.Second, config.Timeout("read_secret"))
+	assert.Equal(t, 20*time.Second, config.Timeout("list_mounts"))
+	assert.Equal(t, 60*time.Second, config.Longest())
+}
+
+func TestToolTimeoutMiddleware(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	middleware := NewToolTimeoutMiddleware(ToolTimeoutConfig{
+		Tools: map[string]time.Duration{"slow_tool": 20 * time.Millisecond},
+	}, logger)
+
+	release := make(chan struct{})
+	defer close(release)
+	handler := middleware.Middleware()(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if request.Params.Name == "slow_tool" {
+			// Ignores the context, like a handler waiting on a request that does not use it
+			<-release
+		}
+		_, hasDeadline := ctx.Deadline()
+		if hasDeadline {
+			return mcp.NewToolResultText("deadline"), nil
+		}
+		return mcp.NewToolResultText("no deadline"), nil
+	})
+
+	t.Run("tools over their timeout fail", func(t *testing.T) {
+		start := time.Now()
+		_, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "slow_tool"}})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrToolTimeout))
+		assert.Contains(t, err.Error(), "'slow_tool' did not complete within 20ms")
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("tools without a timeout run unbounded", func(t *testing.T) {
+		result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "fast_tool"}})
+		require.NoError(t, err)
+		assert.Equal(t, "no deadline", result.Content[0].(mcp.TextContent).Text)
+	})
+}
@@ -16,21 +16,38 @@ type TLSConfig struct {
 	Config   *tls.Config
 }
 
+const (
+	// AdminTLSCertFileEnv is the TLS certificate of the admin listener, configured independently of the MCP listener
+	AdminTLSCertFileEnv = "MCP_ADMIN_TLS_CERT_FILE"
+	// AdminTLSKeyFileEnv is the TLS key of the admin listener
+	AdminTLSKeyFileEnv = "MCP_ADMIN_TLS_KEY_FILE"
+)
+
 // GetTLSConfigFromEnv loads TLS cert/key file paths from environment variables
 func GetTLSConfigFromEnv() (*TLSConfig, error) {
-	certFile := os.Getenv("MCP_TLS_CERT_FILE")
-	keyFile := os.Getenv("MCP_TLS_KEY_FILE")
+	return getTLSConfig("MCP_TLS_CERT_FILE", "MCP_TLS_KEY_FILE")
+}
+
+// GetAdminTLSConfigFromEnv loads the TLS cert/key file paths of the admin listener from MCP_ADMIN_TLS_CERT_FILE
+// and MCP_ADMIN_TLS_KEY_FILE
+func GetAdminTLSConfigFromEnv() (*TLSConfig, error) {
+	return getTLSConfig(AdminTLSCertFileEnv, AdminTLSKeyFileEnv)
+}
+
+func getTLSConfig(certEnv string, keyEnv string) (*TLSConfig, error) {
+	certFile := os.Getenv(certEnv)
+	keyFile := os.Getenv(keyEnv)
 
 	if certFile == "" && keyFile == "" {
 		return nil, nil
 	}
 
 	if certFile == "" {
-		return nil, fmt.Errorf("MCP_TLS_CERT_FILE is required when MCP_TLS_KEY_FILE is set")
+		return nil, fmt.Errorf("%s is required when %s is set", certEnv, keyEnv)
 	}
 
 	if keyFile == "" {
-		return nil, fmt.Errorf("MCP_TLS_KEY_FILE is required when MCP_TLS_CERT_FILE is set")
+		return nil, fmt.Errorf("%s is required when %s is set", keyEnv, certEnv)
 	}
 
 	// Validate certificate files exist and are readable
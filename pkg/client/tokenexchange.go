@@ -0,0 +1,313 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// TokenExchangeConfigFileEnv is the JSON file mapping tool categories to the policies of the child
+	// tokens used for their Vault calls
+	TokenExchangeConfigFileEnv = "MCP_TOKEN_EXCHANGE_CONFIG_FILE"
+
+	// DefaultTokenExchangeTTL is the TTL of the child tokens of the scopes without one
+	DefaultTokenExchangeTTL = 15 * time.Minute
+)
+
+// TokenScope is a tool category whose Vault calls use a child token limited to the policies of the scope
+type TokenScope struct {
+	Name     string   `json:"name"`     // Name of the scope, such as kv-read or pki-issue
+	Tools    []string `json:"tools"`    // Glob patterns of the tool names in the scope
+	Policies []string `json:"policies"` // Policies of the child tokens
+	Role     string   `json:"role"`     // Token role the child tokens are created against, optional
+	TTL      string   `json:"ttl"`      // TTL of the child tokens, the TTL of the configuration by default
+
+	ttl time.Duration
+}
+
+// TokenExchangeConfig maps tool categories to least-privilege child tokens of the token of the session
+type TokenExchangeConfig struct {
+	TTL    string        `json:"ttl"` // Default TTL of the child tokens, 15m by default
+	Scopes []*TokenScope `json:"scopes"`
+}
+
+// tokenExchangeCallKey is the context key holding the scope of the current tool call
+type tokenExchangeCallKey struct{}
+
+// tokenExchangeCall is the scope of a tool call, nil when the tool belongs to no scope
+type tokenExchangeCall struct {
+	tool  string
+	scope *TokenScope
+}
+
+// scopedToken is a child token minted for the scope of a session
+type scopedToken struct {
+	client  *api.Client // Client of the session authenticated with the child token
+	parent  string      // Token the child token was created from
+	expires time.Time
+	renewAt time.Time
+}
+
+// scopedTokenSlot holds the child token of a scope of a session. Its lock is held while the token is
+// minted, so that the concurrent calls of the scope wait for one child token instead of minting several,
+// without holding back the calls of other scopes and sessions.
+type scopedTokenSlot struct {
+	mu    sync.Mutex
+	token *scopedToken
+}
+
+var (
+	scopedTokensMu sync.Mutex
+	scopedTokens   = map[string]map[string]*scopedTokenSlot{} // By session ID and scope name
+)
+
+// LoadTokenExchangeConfigFromEnv loads the token scopes from the file in MCP_TOKEN_EXCHANGE_CONFIG_FILE.
+// It returns nil when token exchange is not configured.
+func LoadTokenExchangeConfigFromEnv() (*TokenExchangeConfig, error) {
+	file := os.Getenv(TokenExchangeConfigFileEnv)
+	if file == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read token exchange configuration file %s: %w", file, err)
+	}
+
+	return ParseTokenExchangeConfig(data)
+}
+
+// ParseTokenExchangeConfig parses and validates a JSON token exchange configuration
+func ParseTokenExchangeConfig(data []byte) (*TokenExchangeConfig, error) {
+	config := &TokenExchangeConfig{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("invalid token exchange configuration: %w", err)
+	}
+	if len(config.Scopes) == 0 {
+		return nil, errors.New("token exchange configuration has no scopes")
+	}
+
+	ttl := DefaultTokenExchangeTTL
+	if config.TTL != "" {
+		parsed, err := time.ParseDuration(config.TTL)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("invalid token exchange TTL '%s'", config.TTL)
+		}
+		ttl = parsed
+	}
+
+	names := map[string]bool{}
+	for _, scope := range config.Scopes {
+		if scope == nil || scope.Name == "" {
+			return nil, errors.New("every token scope needs a name")
+		}
+		if names[scope.Name] {
+			return nil, fmt.Errorf("duplicate token scope '%s'", scope.Name)
+		}
+		names[scope.Name] = true
+
+		if len(scope.Tools) == 0 {
+			return nil, fmt.Errorf("token scope '%s' has no tools", scope.Name)
+		}
+		for _, pattern := range scope.Tools {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("token scope '%s' has an invalid tool pattern '%s'", scope.Name, pattern)
+			}
+		}
+		// Without policies or a role, Vault would give the child token every policy of its parent
+		if len(scope.Policies) == 0 && scope.Role == "" {
+			return nil, fmt.Errorf("token scope '%s' needs policies or a token role", scope.Name)
+		}
+
+		scope.ttl = ttl
+		if scope.TTL != "" {
+			parsed, err := time.ParseDuration(scope.TTL)
+			if err != nil || parsed <= 0 {
+				return nil, fmt.Errorf("token scope '%s' has an invalid TTL '%s'", scope.Name, scope.TTL)
+			}
+			scope.ttl = parsed
+		}
+	}
+
+	return config, nil
+}
+
+// Scope returns the first scope containing the named tool, or nil when the tool belongs to no scope
+func (c *TokenExchangeConfig) Scope(tool string) *TokenScope {
+	for _, scope := range c.Scopes {
+		for _, pattern := range scope.Tools {
+			if matched, _ := path.Match(pattern, tool); matched {
+				return scope
+			}
+		}
+	}
+	return nil
+}
+
+// TokenExchangeMiddleware attaches the token scope of the tool to every call, so that GetVaultClientFromContext
+// sends its Vault requests with a child token of the scope. Tools outside every scope fail closed: they
+// still run, but cannot get a Vault client. The middleware does nothing when config is nil.
+func TokenExchangeMiddleware(config *TokenExchangeConfig, logger *log.Logger) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		if config == nil {
+			return next
+		}
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			call := &tokenExchangeCall{tool: request.Params.Name, scope: config.Scope(request.Params.Name)}
+			if call.scope == nil {
+				RequestLogger(ctx, logger).Debugf("Tool %s belongs to no token scope", call.tool)
+			}
+			return next(context.WithValue(ctx, tokenExchangeCallKey{}, call), request)
+		}
+	}
+}
+
+// scopedClient returns the client of the session authenticated with the child token of the scope of the
+// tool call, or the client itself when token exchange is not enabled
+func scopedClient(ctx context.Context, sessionID string, client *api.Client, logger *log.Logger) (*api.Client, error) {
+	call, _ := ctx.Value(tokenExchangeCallKey{}).(*tokenExchangeCall)
+	if call == nil {
+		return client, nil
+	}
+	if call.scope == nil {
+		return nil, fmt.Errorf("tool '%s' belongs to no token scope, add it to a scope of %s", call.tool, TokenExchangeConfigFileEnv)
+	}
+
+	slot := getScopedTokenSlot(sessionID, call.scope.Name)
+	slot.mu.Lock()
+	parent := client.Token()
+	now := time.Now()
+	if token := slot.token; token != nil && token.parent == parent && now.Before(token.renewAt) {
+		slot.mu.Unlock()
+		return token.client, nil
+	}
+
+	token, err := mintScopedToken(sessionID, call.scope, client, now)
+	if err != nil {
+		slot.mu.Unlock()
+		RequestLogger(ctx, logger).WithError(err).Errorf("Failed to create a child token for token scope: %s", call.scope.Name)
+		return nil, fmt.Errorf("failed to create a child token for token scope '%s': %w", call.scope.Name, err)
+	}
+	previous := slot.token
+	slot.token = token
+	slot.mu.Unlock()
+
+	// The previous child token would only expire on its own, so it is revoked right away
+	if previous != nil {
+		revokeScopedToken(previous, logger)
+	}
+
+	logger.WithFields(log.Fields{
+		"session_id":  sessionID,
+		"token_scope": call.scope.Name,
+		"expires":     token.expires.Format(time.RFC3339),
+	}).Debug("Created child token for token scope")
+	return token.client, nil
+}
+
+// getScopedTokenSlot gets or creates the slot of the child token of a scope of a session
+func getScopedTokenSlot(sessionID string, scope string) *scopedTokenSlot {
+	scopedTokensMu.Lock()
+	defer scopedTokensMu.Unlock()
+
+	if scopedTokens[sessionID] == nil {
+		scopedTokens[sessionID] = map[string]*scopedTokenSlot{}
+	}
+	slot := scopedTokens[sessionID][scope]
+	if slot == nil {
+		slot = &scopedTokenSlot{}
+		scopedTokens[sessionID][scope] = slot
+	}
+	return slot
+}
+
+// mintScopedToken creates a non-renewable child token of the scope with the token of the client
+func mintScopedToken(sessionID string, scope *TokenScope, client *api.Client, now time.Time) (*scopedToken, error) {
+	renewable := false
+	request := &api.TokenCreateRequest{
+		Policies:    scope.Policies,
+		TTL:         scope.ttl.String(),
+		DisplayName: "mcp-" + scope.Name,
+		Renewable:   &renewable,
+		Metadata: map[string]string{
+			"mcp_session_id":  sessionID,
+			"mcp_token_scope": scope.Name,
+		},
+	}
+
+	var secret *api.Secret
+	var err error
+	if scope.Role != "" {
+		secret, err = client.Auth().Token().CreateWithRole(request, scope.Role)
+	} else {
+		secret, err = client.Auth().Token().Create(request)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil || secret.Auth == nil || secret.Auth.ClientToken == "" {
+		return nil, errors.New("Vault returned no token")
+	}
+
+	child, err := client.CloneWithHeaders()
+	if err != nil {
+		return nil, err
+	}
+	child.SetToken(secret.Auth.ClientToken)
+
+	// Vault caps the TTL to the TTL of the parent, the token is replaced once three quarters have passed
+	ttl := time.Duration(secret.Auth.LeaseDuration) * time.Second
+	if ttl <= 0 {
+		ttl = scope.ttl
+	}
+	return &scopedToken{
+		client:  child,
+		parent:  client.Token(),
+		expires: now.Add(ttl),
+		renewAt: now.Add(ttl * 3 / 4),
+	}, nil
+}
+
+// revokeScopedToken revokes a child token, failures are only logged since the token expires on its own
+func revokeScopedToken(token *scopedToken, logger *log.Logger) {
+	if err := token.client.Auth().Token().RevokeSelf(""); err != nil {
+		logger.WithError(err).Warn("Failed to revoke child token, it expires at its TTL")
+	}
+}
+
+// RevokeScopedTokens revokes the child tokens minted for the session
+func RevokeScopedTokens(sessionID string, logger *log.Logger) {
+	scopedTokensMu.Lock()
+	slots := scopedTokens[sessionID]
+	delete(scopedTokens, sessionID)
+	scopedTokensMu.Unlock()
+
+	revoked := 0
+	for _, slot := range slots {
+		slot.mu.Lock()
+		token := slot.token
+		slot.token = nil
+		slot.mu.Unlock()
+		if token != nil {
+			revokeScopedToken(token, logger)
+			revoked++
+		}
+	}
+	if revoked > 0 {
+		logger.WithField("session_id", sessionID).Infof("Revoked %d child tokens", revoked)
+	}
+}
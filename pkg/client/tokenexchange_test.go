@@ -0,0 +1,231 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTokenExchangeConfig = `{
+  "ttl": "10m",
+  "scopes": [
+    {"name": "kv-read", "tools": ["read_secret", "list_secrets"], "policies": ["mcp-kv-read"]},
+    {"name": "pki-issue", "tools": ["issue_pki_*"], "role": "mcp-pki", "ttl": "1m"}
+  ]
+}`
+
+func TestParseTokenExchangeConfig(t *testing.T) {
+	config, err := ParseTokenExchangeConfig([]byte(testTokenExchangeConfig))
+	require.NoError(t, err)
+	require.Len(t, config.Scopes, 2)
+	assert.Equal(t, 10*time.Minute, config.Scopes[0].ttl)
+	assert.Equal(t, time.Minute, config.Scopes[1].ttl)
+
+	assert.Equal(t, "kv-read", config.Scope("read_secret").Name)
+	assert.Equal(t, "pki-issue", config.Scope("issue_pki_certificate").Name)
+	assert.Nil(t, config.Scope("delete_mount"))
+
+	for name, data := range map[string]string{
+		"no scopes":       `{"scopes": []}`,
+		"no policies":     `{"scopes": [{"name": "a", "tools": ["*"]}]}`,
+		"no tools":        `{"scopes": [{"name": "a", "policies": ["p"]}]}`,
+		"duplicate scope": `{"scopes": [{"name": "a", "tools": ["*"], "policies": ["p"]}, {"name": "a", "tools": ["*"], "policies": ["p"]}]}`,
+		"invalid pattern": `{"scopes": [{"name": "a", "tools": ["["], "policies": ["p"]}]}`,
+		"invalid ttl":     `{"ttl": "forever", "scopes": [{"name": "a", "tools": ["*"], "policies": ["p"]}]}`,
+	} {
+		_, err := ParseTokenExchangeConfig([]byte(data))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestTokenExchangeMiddleware(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	var mu sync.Mutex
+	var created []map[string]any
+	var revoked []string
+	readTokens := map[string]string{}
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		token := r.Header.Get(VaultHeaderToken)
+
+		switch r.URL.Path {
+		case "/v1/auth/token/create", "/v1/auth/token/create/mcp-pki":
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			body["parent"] = token
+			body["path"] = r.URL.Path
+			created = append(created, body)
+			_, _ = fmt.Fprintf(w, `{"auth":{"client_token":"child-%d","lease_duration":600}}`, len(created))
+		case "/v1/auth/token/revoke-self":
+			revoked = append(revoked, token)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			readTokens[r.URL.Path] = token
+			_, _ = w.Write([]byte(`{"data":{}}`))
+		}
+	}))
+	defer vault.Close()
+
+	config, err := ParseTokenExchangeConfig([]byte(testTokenExchangeConfig))
+	require.NoError(t, err)
+
+	sessionID := "test-token-exchange"
+	_, err = NewVaultClient(sessionID, vault.URL, false, "operator-token", "")
+	require.NoError(t, err)
+	defer DeleteVaultClient(sessionID)
+	defer DeleteSessionUsage(sessionID)
+
+	readVault := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		vault, err := GetVaultClientFromContext(ctx, logger)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if _, err := vault.Logical().Read(request.GetString("path", "")); err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultText("success"), nil
+	}
+	srv := server.NewMCPServer("test", "1.0.0", server.WithToolHandlerMiddleware(TokenExchangeMiddleware(config, logger)))
+	srv.AddTool(mcp.NewTool("read_secret"), readVault)
+	srv.AddTool(mcp.NewTool("issue_pki_certificate"), readVault)
+	srv.AddTool(mcp.NewTool("delete_mount"), readVault)
+	ctx := srv.WithContext(context.Background(), &mockClientSession{id: sessionID})
+
+	call := func(tool string, path string) *mcp.CallToolResult {
+		message := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":%q,"arguments":{"path":%q}}}`, tool, path)
+		response, ok := srv.HandleMessage(ctx, []byte(message)).(mcp.JSONRPCResponse)
+		require.True(t, ok, "tool call failed")
+		result, ok := response.Result.(*mcp.CallToolResult)
+		require.True(t, ok)
+		return result
+	}
+
+	t.Run("tools call Vault with the child token of their scope", func(t *testing.T) {
+		require.False(t, call("read_secret", "secret/data/a").IsError)
+		require.False(t, call("read_secret", "secret/data/b").IsError)
+		require.False(t, call("issue_pki_certificate", "pki/issue/web").IsError)
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Len(t, created, 2, "child tokens are reused within the session")
+		assert.Equal(t, "operator-token", created[0]["parent"])
+		assert.Equal(t, []any{"mcp-kv-read"}, created[0]["policies"])
+		assert.Equal(t, "10m0s", created[0]["ttl"])
+		assert.Equal(t, false, created[0]["renewable"])
+		assert.Equal(t, "/v1/auth/token/create/mcp-pki", created[1]["path"])
+		assert.Equal(t, "child-1", readTokens["/v1/secret/data/b"])
+		assert.Equal(t, "child-2", readTokens["/v1/pki/issue/web"])
+	})
+
+	t.Run("tools outside every scope cannot call Vault", func(t *testing.T) {
+		result := call("delete_mount", "sys/mounts/secret")
+		assert.True(t, result.IsError)
+		assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "belongs to no token scope")
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.NotContains(t, readTokens, "/v1/sys/mounts/secret")
+	})
+
+	t.Run("child tokens are revoked with the session", func(t *testing.T) {
+		RevokeScopedTokens(sessionID, logger)
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.ElementsMatch(t, []string{"child-1", "child-2"}, revoked)
+	})
+}
+
+func TestScopedClientConcurrency(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	release := make(chan struct{})
+	var mu sync.Mutex
+	created := map[string]int{}
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parent := r.Header.Get(VaultHeaderToken)
+		if parent == "slow-token" {
+			<-release
+		}
+		mu.Lock()
+		created[parent]++
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"auth":{"client_token":"child-of-%s","lease_duration":600}}`, parent)
+	}))
+	defer vault.Close()
+
+	config, err := ParseTokenExchangeConfig([]byte(testTokenExchangeConfig))
+	require.NoError(t, err)
+	ctx := context.WithValue(context.Background(), tokenExchangeCallKey{}, &tokenExchangeCall{tool: "read_secret", scope: config.Scope("read_secret")})
+
+	newClient := func(sessionID string, token string) {
+		_, err := NewVaultClient(sessionID, vault.URL, false, token, "")
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			DeleteVaultClient(sessionID)
+			DeleteSessionUsage(sessionID)
+			scopedTokensMu.Lock()
+			delete(scopedTokens, sessionID)
+			scopedTokensMu.Unlock()
+		})
+	}
+	newClient("test-scoped-slow", "slow-token")
+	newClient("test-scoped-fast", "fast-token")
+
+	// Concurrent calls of the same scope wait for a single child token
+	var wg sync.WaitGroup
+	tokens := make(chan string, 3)
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			scoped, err := scopedClient(ctx, "test-scoped-slow", GetVaultClient("test-scoped-slow"), logger)
+			assert.NoError(t, err)
+			tokens <- scoped.Token()
+		}()
+	}
+
+	// Minting the child token of another session is not held back by the slow one
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scoped, err := scopedClient(ctx, "test-scoped-fast", GetVaultClient("test-scoped-fast"), logger)
+		assert.NoError(t, err)
+		assert.Equal(t, "child-of-fast-token", scoped.Token())
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the child token of another session waited for the slow session")
+	}
+
+	close(release)
+	wg.Wait()
+	close(tokens)
+	for token := range tokens {
+		assert.Equal(t, "child-of-slow-token", token)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, map[string]int{"slow-token": 1, "fast-token": 1}, created)
+}
@@ -0,0 +1,250 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// TranscriptsEnv enables the recording of session transcripts
+const TranscriptsEnv = "MCP_SESSION_TRANSCRIPTS"
+
+// Types of transcript entries
+const (
+	TranscriptToolCall         = "tool_call"
+	TranscriptApprovalQueued   = "approval_queued"
+	TranscriptApprovalDecision = "approval_decision"
+)
+
+// Statuses of tool call entries
+const (
+	TranscriptStatusOK     = "ok"
+	TranscriptStatusError  = "error"  // The tool returned an error result
+	TranscriptStatusFailed = "failed" // The call failed before the tool returned a result
+)
+
+var (
+	transcriptsEnabled bool
+	transcriptsMu      sync.RWMutex
+
+	// maxTranscriptEntries bounds the entries kept per session, the oldest entries are dropped first
+	maxTranscriptEntries = 1000
+	// transcriptRetention is how long the transcript of an ended session is kept for review
+	transcriptRetention = 24 * time.Hour
+
+	sessionTranscripts = &transcriptStore{now: time.Now, items: map[string]*transcript{}}
+)
+
+// TranscriptEntry is a tool call, or an approval of a tool call, of a session. Sensitive arguments are
+// hidden and results are summarized, transcripts never hold secret values.
+type TranscriptEntry struct {
+	ID          int            `json:"id"`
+	Time        time.Time      `json:"time"`
+	Type        string         `json:"type"`
+	Tool        string         `json:"tool"`
+	Arguments   map[string]any `json:"arguments,omitempty"`
+	RequestID   string         `json:"request_id,omitempty"`
+	Status      string         `json:"status"`
+	DurationMs  int64          `json:"duration_ms,omitempty"`
+	ResultBytes int            `json:"result_bytes,omitempty"` // Size of the text returned to the client
+	Error       string         `json:"error,omitempty"`        // Why the call failed, for calls failed before the tool returned
+	ApprovalID  string         `json:"approval_id,omitempty"`
+	Approver    string         `json:"approver,omitempty"`
+}
+
+// Transcript is the record of what a session did
+type Transcript struct {
+	SessionID string            `json:"session_id"`
+	StartedAt time.Time         `json:"started_at"`
+	EndedAt   *time.Time        `json:"ended_at,omitempty"`
+	Dropped   int               `json:"dropped,omitempty"` // Oldest entries dropped to bound the transcript
+	Entries   []TranscriptEntry `json:"entries"`
+}
+
+// TranscriptSummary describes a transcript without its entries
+type TranscriptSummary struct {
+	SessionID string     `json:"session_id"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+	Entries   int        `json:"entries"`
+}
+
+type transcript struct {
+	Transcript
+	nextID int
+}
+
+// transcriptStore holds the transcripts of the sessions of this server instance
+type transcriptStore struct {
+	mu    sync.Mutex
+	now   func() time.Time
+	items map[string]*transcript
+}
+
+// SetTranscripts sets whether the transcripts of sessions are recorded
+func SetTranscripts(enabled bool) {
+	transcriptsMu.Lock()
+	defer transcriptsMu.Unlock()
+	transcriptsEnabled = enabled
+}
+
+// TranscriptsEnabled reports whether the transcripts of sessions are recorded
+func TranscriptsEnabled() bool {
+	transcriptsMu.RLock()
+	defer transcriptsMu.RUnlock()
+	return transcriptsEnabled
+}
+
+// LoadTranscriptsFromEnv returns the setting of MCP_SESSION_TRANSCRIPTS, and false when it is not set or is
+// not a valid boolean
+func LoadTranscriptsFromEnv() (bool, bool) {
+	value := os.Getenv(TranscriptsEnv)
+	if value == "" {
+		return false, false
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Warnf("Invalid %s value '%s', session transcripts are not recorded", TranscriptsEnv, value)
+		return false, false
+	}
+	return enabled, true
+}
+
+// prune drops the transcripts of sessions that ended before the retention. Must be called with the lock held.
+func (s *transcriptStore) prune() {
+	now := s.now()
+	for id, t := range s.items {
+		if t.EndedAt != nil && now.After(t.EndedAt.Add(transcriptRetention)) {
+			delete(s.items, id)
+		}
+	}
+}
+
+func (s *transcriptStore) record(sessionID string, entry TranscriptEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prune()
+
+	now := s.now().UTC()
+	t, ok := s.items[sessionID]
+	if !ok {
+		t = &transcript{Transcript: Transcript{SessionID: sessionID, StartedAt: now}, nextID: 1}
+		s.items[sessionID] = t
+	}
+	entry.ID = t.nextID
+	entry.Time = now
+	t.nextID++
+	t.Entries = append(t.Entries, entry)
+	if len(t.Entries) > maxTranscriptEntries {
+		t.Dropped += len(t.Entries) - maxTranscriptEntries
+		t.Entries = t.Entries[len(t.Entries)-maxTranscriptEntries:]
+	}
+}
+
+// RecordTranscriptEntry adds an entry to the transcript of a session when transcripts are enabled
+func RecordTranscriptEntry(sessionID string, entry TranscriptEntry) {
+	if sessionID == "" || !TranscriptsEnabled() {
+		return
+	}
+	sessionTranscripts.record(sessionID, entry)
+}
+
+// SessionTranscript returns a copy of the transcript of a session
+func SessionTranscript(sessionID string) (Transcript, bool) {
+	sessionTranscripts.mu.Lock()
+	defer sessionTranscripts.mu.Unlock()
+	sessionTranscripts.prune()
+
+	t, ok := sessionTranscripts.items[sessionID]
+	if !ok {
+		return Transcript{}, false
+	}
+	copied := t.Transcript
+	copied.Entries = append([]TranscriptEntry(nil), t.Entries...)
+	return copied, true
+}
+
+// ListTranscripts returns the summaries of the transcripts of this server instance, oldest first
+func ListTranscripts() []TranscriptSummary {
+	sessionTranscripts.mu.Lock()
+	defer sessionTranscripts.mu.Unlock()
+	sessionTranscripts.prune()
+
+	list := make([]TranscriptSummary, 0, len(sessionTranscripts.items))
+	for _, t := range sessionTranscripts.items {
+		list = append(list, TranscriptSummary{SessionID: t.SessionID, StartedAt: t.StartedAt, EndedAt: t.EndedAt, Entries: len(t.Entries)})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].StartedAt.Before(list[j].StartedAt) })
+	return list
+}
+
+// EndTranscript marks the transcript of a session as ended. It is kept for review until the retention passes.
+func EndTranscript(sessionID string) {
+	sessionTranscripts.mu.Lock()
+	defer sessionTranscripts.mu.Unlock()
+	if t, ok := sessionTranscripts.items[sessionID]; ok && t.EndedAt == nil {
+		now := sessionTranscripts.now().UTC()
+		t.EndedAt = &now
+	}
+}
+
+// TranscriptMiddleware records every tool call of a session in its transcript when transcripts are enabled:
+// the tool, the arguments with sensitive values hidden, the status, the duration and the size of the result.
+func TranscriptMiddleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			sessionID := getSessionIDFromContext(ctx)
+			if sessionID == "" || !TranscriptsEnabled() {
+				return next(ctx, request)
+			}
+
+			start := time.Now()
+			result, err := next(ctx, request)
+
+			entry := TranscriptEntry{
+				Type:       TranscriptToolCall,
+				Tool:       request.Params.Name,
+				Arguments:  redactArguments(request.Params.Arguments),
+				RequestID:  RequestIDFromContext(ctx),
+				Status:     TranscriptStatusOK,
+				DurationMs: time.Since(start).Milliseconds(),
+			}
+			switch {
+			case err != nil:
+				entry.Status = TranscriptStatusFailed
+				entry.Error = err.Error()
+			case result != nil && result.IsError:
+				entry.Status = TranscriptStatusError
+			}
+			entry.ResultBytes = len(toolResultText(result))
+			RecordTranscriptEntry(sessionID, entry)
+
+			return result, err
+		}
+	}
+}
+
+// recordApproval adds a queued or decided approval to the transcript of its session
+func recordApproval(approval Approval, entryType string) {
+	RecordTranscriptEntry(approval.SessionID, TranscriptEntry{
+		Type:        entryType,
+		Tool:        approval.Tool,
+		Arguments:   approval.Arguments,
+		RequestID:   approval.RequestID,
+		Status:      approval.Status,
+		ResultBytes: len(approval.Result),
+		Error:       approval.Error,
+		ApprovalID:  approval.ID,
+		Approver:    approval.Approver,
+	})
+}
@@ -0,0 +1,190 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionTranscript(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+	SetTranscripts(true)
+	defer SetTranscripts(false)
+
+	sessionID := "test-transcript"
+	_, err := NewVaultClient(sessionID, "http://127.0.0.1:8200", false, "test-token", "")
+	require.NoError(t, err)
+	defer DeleteVaultClient(sessionID)
+
+	approval := NewApprovalMiddleware(ApprovalConfig{Tools: map[string]bool{"write_tool": true}, TTL: time.Minute}, logger)
+	srv := server.NewMCPServer("test", "1.0.0",
+		server.WithToolHandlerMiddleware(RequestIDMiddleware(logger)),
+		server.WithToolHandlerMiddleware(TranscriptMiddleware()),
+		server.WithToolHandlerMiddleware(approval.Middleware()),
+	)
+	srv.AddTool(mcp.NewTool("read_tool"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("s3cr3t-data"), nil
+	})
+	srv.AddTool(mcp.NewTool("failing_tool"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return nil, errors.New("vault unreachable")
+	})
+	srv.AddTool(mcp.NewTool("write_tool"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("written"), nil
+	})
+	ctx := srv.WithContext(context.Background(), &mockClientSession{id: sessionID})
+
+	call := func(tool string, arguments map[string]any) mcp.JSONRPCMessage {
+		message, err := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"method":  "tools/call",
+			"params":  map[string]any{"name": tool, "arguments": arguments},
+		})
+		require.NoError(t, err)
+		return srv.HandleMessage(ctx, message)
+	}
+
+	call("read_tool", map[string]any{"path": "secret/app", "token": "hvs.secret"})
+	call("failing_tool", nil)
+	response := call("write_tool", map[string]any{"path": "secret/app", "value": "hunter2"})
+	var pending map[string]any
+	text := response.(mcp.JSONRPCResponse).Result.(*mcp.CallToolResult).Content[0].(mcp.TextContent).Text
+	require.NoError(t, json.Unmarshal([]byte(text), &pending))
+	approvalID := pending["approval_id"].(string)
+	_, err = ApproveToolCall(approvalID, "alice", logger)
+	require.NoError(t, err)
+
+	transcript, ok := SessionTranscript(sessionID)
+	require.True(t, ok)
+	assert.Equal(t, sessionID, transcript.SessionID)
+	assert.Nil(t, transcript.EndedAt)
+	require.Len(t, transcript.Entries, 5)
+
+	read := transcript.Entries[0]
+	assert.Equal(t, 1, read.ID)
+	assert.Equal(t, TranscriptToolCall, read.Type)
+	assert.Equal(t, "read_tool", read.Tool)
+	assert.Equal(t, map[string]any{"path": "secret/app", "token": "<redacted>"}, read.Arguments)
+	assert.Equal(t, TranscriptStatusOK, read.Status)
+	assert.Equal(t, len("s3cr3t-data"), read.ResultBytes)
+	assert.NotEmpty(t, read.RequestID)
+
+	failed := transcript.Entries[1]
+	assert.Equal(t, TranscriptStatusFailed, failed.Status)
+	assert.Equal(t, "vault unreachable", failed.Error)
+
+	queued := transcript.Entries[2]
+	assert.Equal(t, TranscriptApprovalQueued, queued.Type)
+	assert.Equal(t, approvalID, queued.ApprovalID)
+	assert.Equal(t, ApprovalPending, queued.Status)
+	assert.Equal(t, "<redacted>", queued.Arguments["value"])
+	assert.Equal(t, TranscriptToolCall, transcript.Entries[3].Type, "the call that queued the approval")
+
+	decided := transcript.Entries[4]
+	assert.Equal(t, TranscriptApprovalDecision, decided.Type)
+	assert.Equal(t, ApprovalExecuted, decided.Status)
+	assert.Equal(t, "alice", decided.Approver)
+	assert.Equal(t, len("written"), decided.ResultBytes)
+
+	raw, err := json.Marshal(transcript)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "hunter2")
+	assert.NotContains(t, string(raw), "hvs.secret")
+	assert.NotContains(t, string(raw), "s3cr3t-data")
+
+	t.Run("reviewers read transcripts through the approval endpoint", func(t *testing.T) {
+		approvalServer := httptest.NewServer(ApprovalHandler("approver-token", logger))
+		defer approvalServer.Close()
+
+		get := func(path string) (int, []byte) {
+			req, err := http.NewRequest(http.MethodGet, approvalServer.URL+path, http.NoBody)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer approver-token")
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+			var body json.RawMessage
+			_ = json.NewDecoder(resp.Body).Decode(&body)
+			return resp.StatusCode, body
+		}
+
+		status, body := get("/transcripts")
+		assert.Equal(t, http.StatusOK, status)
+		var list []TranscriptSummary
+		require.NoError(t, json.Unmarshal(body, &list))
+		assert.Contains(t, list, TranscriptSummary{SessionID: sessionID, StartedAt: transcript.StartedAt, Entries: 5})
+
+		status, body = get("/transcripts/" + sessionID)
+		assert.Equal(t, http.StatusOK, status)
+		var served Transcript
+		require.NoError(t, json.Unmarshal(body, &served))
+		assert.Len(t, served.Entries, 5)
+
+		status, _ = get("/transcripts/unknown")
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+
+	t.Run("transcripts of ended sessions are kept for the retention", func(t *testing.T) {
+		EndSessionHandler(context.Background(), &mockClientSession{id: sessionID}, logger)
+		transcript, ok := SessionTranscript(sessionID)
+		require.True(t, ok)
+		assert.NotNil(t, transcript.EndedAt)
+
+		sessionTranscripts.now = func() time.Time { return time.Now().Add(transcriptRetention + time.Minute) }
+		defer func() { sessionTranscripts.now = time.Now }()
+		_, ok = SessionTranscript(sessionID)
+		assert.False(t, ok)
+	})
+}
+
+func TestTranscriptLimits(t *testing.T) {
+	sessionID := "test-transcript-limits"
+	RecordTranscriptEntry(sessionID, TranscriptEntry{Type: TranscriptToolCall, Tool: "read_tool"})
+	_, ok := SessionTranscript(sessionID)
+	assert.False(t, ok, "nothing is recorded while transcripts are disabled")
+
+	SetTranscripts(true)
+	defer SetTranscripts(false)
+	defer EndTranscript(sessionID)
+
+	original := maxTranscriptEntries
+	maxTranscriptEntries = 3
+	defer func() { maxTranscriptEntries = original }()
+
+	for range 5 {
+		RecordTranscriptEntry(sessionID, TranscriptEntry{Type: TranscriptToolCall, Tool: "read_tool"})
+	}
+	transcript, ok := SessionTranscript(sessionID)
+	require.True(t, ok)
+	assert.Equal(t, 2, transcript.Dropped)
+	require.Len(t, transcript.Entries, 3)
+	assert.Equal(t, 3, transcript.Entries[0].ID, "the oldest entries are dropped")
+}
+
+func TestLoadTranscriptsFromEnv(t *testing.T) {
+	_, ok := LoadTranscriptsFromEnv()
+	assert.False(t, ok)
+
+	t.Setenv(TranscriptsEnv, "true")
+	enabled, ok := LoadTranscriptsFromEnv()
+	assert.True(t, ok)
+	assert.True(t, enabled)
+
+	t.Setenv(TranscriptsEnv, "always")
+	_, ok = LoadTranscriptsFromEnv()
+	assert.False(t, ok)
+}
@@ -0,0 +1,122 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/textproto"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/vault-mcp-server/version"
+	"github.com/hashicorp/vault/api"
+	log "github.com/sirupsen/logrus"
+)
+
+// RequestHeadersEnv sets custom headers sent with every Vault request, as a comma-separated list of
+// name=value pairs
+const RequestHeadersEnv = "MCP_VAULT_REQUEST_HEADERS"
+
+// reservedRequestHeaders are set by the server itself and cannot be replaced by custom headers
+var reservedRequestHeaders = []string{
+	"User-Agent",
+	"Authorization",
+	api.AuthHeaderName,
+	api.NamespaceHeaderName,
+	api.HeaderIndex,
+	"X-Vault-Request",
+	"X-Vault-Wrap-TTL",
+	RequestIDHeader,
+	CorrelationIDHeader,
+}
+
+var (
+	requestHeaders   http.Header
+	requestHeadersMu sync.RWMutex
+)
+
+// SetRequestHeaders replaces the custom headers sent with every Vault request
+func SetRequestHeaders(headers http.Header) {
+	requestHeadersMu.Lock()
+	defer requestHeadersMu.Unlock()
+	requestHeaders = headers.Clone()
+}
+
+// GetRequestHeaders returns the custom headers sent with every Vault request
+func GetRequestHeaders() http.Header {
+	requestHeadersMu.RLock()
+	defer requestHeadersMu.RUnlock()
+	return requestHeaders.Clone()
+}
+
+// LoadRequestHeadersFromEnv returns the custom headers set by MCP_VAULT_REQUEST_HEADERS, and false when it
+// is not set. Invalid entries and headers set by the server itself are ignored with a warning.
+func LoadRequestHeadersFromEnv() (http.Header, bool) {
+	value := os.Getenv(RequestHeadersEnv)
+	if strings.TrimSpace(value) == "" {
+		return nil, false
+	}
+
+	headers := http.Header{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, headerValue, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		headerValue = strings.TrimSpace(headerValue)
+		if !ok || name == "" || !ValidHeaderName(name) || headerValue == "" || strings.ContainsFunc(headerValue, notPrintableASCII) {
+			log.Warnf("Invalid %s entry '%s', expected name=value", RequestHeadersEnv, entry)
+			continue
+		}
+		if isReservedRequestHeader(name) {
+			log.Warnf("Ignoring %s entry '%s', the header is set by the server", RequestHeadersEnv, name)
+			continue
+		}
+		headers.Add(name, headerValue)
+	}
+	return headers, true
+}
+
+// UserAgent returns the User-Agent of the Vault requests of a session. The session is identified by a hash,
+// so that the logs of Vault telemetry and web application firewalls tell sessions apart without the ID that
+// clients present to the server.
+func UserAgent(sessionID string) string {
+	return "vault-mcp-server/" + version.GetHumanVersion() + " session=" + SessionHash(sessionID)
+}
+
+// SessionHash identifies a session in the requests sent to Vault. The session ID lets clients resume a
+// session, so it never leaves the server.
+func SessionHash(sessionID string) string {
+	sum := sha256.Sum256([]byte(sessionID))
+	return hex.EncodeToString(sum[:6])
+}
+
+// setClientHeaders sets the User-Agent of the session and the custom headers on a new client
+func setClientHeaders(sessionID string, client *api.Client) {
+	headers := client.Headers()
+	for name, values := range GetRequestHeaders() {
+		headers[name] = values
+	}
+	headers.Set("User-Agent", UserAgent(sessionID))
+	client.SetHeaders(headers)
+}
+
+func isReservedRequestHeader(name string) bool {
+	canonical := textproto.CanonicalMIMEHeaderKey(name)
+	for _, reserved := range reservedRequestHeaders {
+		if canonical == textproto.CanonicalMIMEHeaderKey(reserved) {
+			return true
+		}
+	}
+	return strings.EqualFold(name, GetAuditHeader())
+}
+
+func notPrintableASCII(r rune) bool {
+	return r < ' ' || r > '~'
+}
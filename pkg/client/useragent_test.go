@@ -0,0 +1,66 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserAgent(t *testing.T) {
+	agent := UserAgent("mcp-session-1")
+	assert.Regexp(t, regexp.MustCompile(`^vault-mcp-server/`+regexp.QuoteMeta(version.GetHumanVersion())+` session=[0-9a-f]{12}$`), agent)
+	assert.NotContains(t, agent, "mcp-session-1")
+	assert.Equal(t, agent, UserAgent("mcp-session-1"))
+	assert.NotEqual(t, agent, UserAgent("mcp-session-2"))
+}
+
+func TestVaultRequestHeaders(t *testing.T) {
+	SetRequestHeaders(http.Header{"X-Team": {"platform"}})
+	defer SetRequestHeaders(nil)
+
+	received := make(chan http.Header, 1)
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	defer mockVault.Close()
+
+	sessionID := "test-request-headers"
+	vault, err := NewVaultClient(sessionID, mockVault.URL, false, "test-token", "team-a")
+	require.NoError(t, err)
+	defer DeleteVaultClient(sessionID)
+
+	_, err = vault.Logical().Read("sys/mounts")
+	require.NoError(t, err)
+	headers := <-received
+	assert.Equal(t, UserAgent(sessionID), headers.Get("User-Agent"))
+	assert.Equal(t, "platform", headers.Get("X-Team"))
+	assert.Equal(t, "test-token", headers.Get("X-Vault-Token"))
+	assert.Equal(t, "team-a", headers.Get("X-Vault-Namespace"))
+
+	// Copies of the client keep the headers
+	_, err = vault.WithNamespace("team-b").Logical().Read("sys/mounts")
+	require.NoError(t, err)
+	headers = <-received
+	assert.Equal(t, UserAgent(sessionID), headers.Get("User-Agent"))
+	assert.Equal(t, "platform", headers.Get("X-Team"))
+}
+
+func TestLoadRequestHeadersFromEnv(t *testing.T) {
+	_, ok := LoadRequestHeadersFromEnv()
+	assert.False(t, ok)
+
+	t.Setenv(RequestHeadersEnv, "X-Team=platform, X-Cost-Center = 42; eu ,broken,=value,X-Bad Name=v,X-Vault-Token=secret,user-agent=curl,X-MCP-Session=spoofed")
+	headers, ok := LoadRequestHeadersFromEnv()
+	assert.True(t, ok)
+	assert.Equal(t, http.Header{"X-Team": {"platform"}, "X-Cost-Center": {"42; eu"}}, headers)
+}
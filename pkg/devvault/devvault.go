@@ -0,0 +1,155 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Package devvault runs an in-memory Vault dev server next to the MCP server and seeds it with example
+// mounts and secrets, so that the tools can be demonstrated or tested without setting up Vault.
+package devvault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/vault/api"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// BinaryEnv is the environment variable selecting the Vault binary, found in the PATH by default
+	BinaryEnv = "MCP_DEV_VAULT_BINARY"
+
+	// DefaultStartTimeout is how long Start waits for the dev server to be unsealed
+	DefaultStartTimeout = 30 * time.Second
+)
+
+// ErrBinaryNotFound is returned when no Vault binary is available to run the dev server
+var ErrBinaryNotFound = errors.New("the vault binary was not found in the PATH, install Vault or set " + BinaryEnv)
+
+// Server is a running Vault dev server
+type Server struct {
+	Address string // Address of the listener of the dev server
+	Token   string // Root token of the dev server
+
+	cmd  *exec.Cmd
+	done chan error
+}
+
+// Start runs a Vault dev server listening on a free local port with a random root token, and waits until
+// it is unsealed. The output of the server is logged at debug level, since the stdio transport owns the
+// standard output.
+func Start(ctx context.Context, logger *log.Logger) (*Server, error) {
+	binary := os.Getenv(BinaryEnv)
+	if binary == "" {
+		path, err := exec.LookPath("vault")
+		if err != nil {
+			return nil, ErrBinaryNotFound
+		}
+		binary = path
+	}
+
+	address, err := freeAddress()
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{
+		Address: "http://" + address,
+		Token:   "dev-" + uuid.NewString(),
+		done:    make(chan error, 1),
+	}
+
+	output := logger.WriterLevel(log.DebugLevel)
+	s.cmd = exec.Command(binary, "server", "-dev",
+		"-dev-listen-address="+address,
+		"-dev-root-token-id="+s.Token,
+		"-dev-no-store-token",
+	)
+	// The dev server must not pick up the settings of the Vault the user normally talks to
+	s.cmd.Env = append(os.Environ(), "VAULT_ADDR=", "VAULT_TOKEN=", "VAULT_NAMESPACE=")
+	s.cmd.Stdout = output
+	s.cmd.Stderr = output
+	if err := s.cmd.Start(); err != nil {
+		_ = output.Close()
+		return nil, fmt.Errorf("failed to start the Vault dev server: %w", err)
+	}
+	go func() {
+		s.done <- s.cmd.Wait()
+		_ = output.Close()
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultStartTimeout)
+	defer cancel()
+	if err := s.waitReady(ctx); err != nil {
+		s.Stop()
+		return nil, err
+	}
+
+	logger.WithField("address", s.Address).Info("Started Vault dev server")
+	return s, nil
+}
+
+// Client returns a Vault client authenticated with the root token of the dev server
+func (s *Server) Client() (*api.Client, error) {
+	config := api.DefaultConfig()
+	config.Address = s.Address
+	client, err := api.NewClient(config)
+	if err != nil {
+		return nil, err
+	}
+	client.SetToken(s.Token)
+	return client, nil
+}
+
+// Stop stops the dev server, discarding everything it stored
+func (s *Server) Stop() {
+	if s.cmd.Process == nil {
+		return
+	}
+	_ = s.cmd.Process.Signal(os.Interrupt)
+	select {
+	case <-s.done:
+	case <-time.After(5 * time.Second):
+		_ = s.cmd.Process.Kill()
+		<-s.done
+	}
+}
+
+// waitReady polls the health endpoint until the dev server is unsealed
+func (s *Server) waitReady(ctx context.Context) error {
+	client, err := s.Client()
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		health, err := client.Sys().HealthWithContext(ctx)
+		if err == nil && health.Initialized && !health.Sealed {
+			return nil
+		}
+
+		select {
+		case err := <-s.done:
+			s.done <- err
+			return fmt.Errorf("the Vault dev server exited: %v", err)
+		case <-ctx.Done():
+			return fmt.Errorf("the Vault dev server did not start within %s", DefaultStartTimeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// freeAddress returns a local address with a free port
+func freeAddress() (string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", fmt.Errorf("failed to find a free port for the Vault dev server: %w", err)
+	}
+	defer listener.Close()
+	return listener.Addr().String(), nil
+}
@@ -0,0 +1,89 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package devvault
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStart(t *testing.T) {
+	t.Run("missing binary", func(t *testing.T) {
+		t.Setenv(BinaryEnv, "")
+		t.Setenv("PATH", t.TempDir())
+		_, err := Start(context.Background(), log.New())
+		assert.ErrorIs(t, err, ErrBinaryNotFound)
+	})
+
+	t.Run("binary that cannot run", func(t *testing.T) {
+		t.Setenv(BinaryEnv, filepath.Join(t.TempDir(), "vault"))
+		_, err := Start(context.Background(), log.New())
+		assert.ErrorContains(t, err, "failed to start the Vault dev server")
+	})
+}
+
+func TestSeed(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		_, _ = io.Copy(io.Discard, r.Body)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/secret/data/demo/app", "/v1/secret/data/demo/database":
+			_, _ = w.Write([]byte(`{"data":{"version":1}}`))
+		case "/v1/pki/root/generate/internal":
+			_, _ = w.Write([]byte(`{"data":{"issuer_id":"1"}}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer vault.Close()
+
+	config := api.DefaultConfig()
+	config.Address = vault.URL
+	client, err := api.NewClient(config)
+	require.NoError(t, err)
+	client.SetToken("root")
+
+	done, err := Seed(context.Background(), client)
+	require.NoError(t, err)
+	assert.Len(t, done, len(seedSteps))
+	assert.Equal(t, []string{
+		"PUT /v1/secret/data/demo/app",
+		"PUT /v1/secret/data/demo/app",
+		"PUT /v1/secret/data/demo/database",
+		"POST /v1/sys/mounts/kv-v1",
+		"PUT /v1/kv-v1/demo/legacy",
+		"POST /v1/sys/mounts/pki",
+		"PUT /v1/pki/root/generate/internal",
+		"PUT /v1/pki/roles/demo",
+	}, requests)
+
+	t.Run("stops at the first failure", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+		}))
+		defer failing.Close()
+		require.NoError(t, client.SetAddress(failing.URL))
+
+		done, err := Seed(context.Background(), client)
+		assert.Empty(t, done)
+		assert.ErrorContains(t, err, "failed to write secret/demo/app in two versions")
+	})
+}
@@ -0,0 +1,85 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package devvault
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/vault/api"
+)
+
+// seedStep is one change made by Seed
+type seedStep struct {
+	description string
+	run         func(ctx context.Context, client *api.Client) error
+}
+
+// seedSteps create the example layout. The dev server already mounts a KV v2 engine at secret/.
+var seedSteps = []seedStep{
+	{"write secret/demo/app in two versions", func(ctx context.Context, client *api.Client) error {
+		if _, err := client.KVv2("secret").Put(ctx, "demo/app", map[string]interface{}{
+			"api_url": "https://api.example.com",
+			"api_key": "demo-key-v1",
+		}); err != nil {
+			return err
+		}
+		_, err := client.KVv2("secret").Put(ctx, "demo/app", map[string]interface{}{
+			"api_url": "https://api.example.com",
+			"api_key": "demo-key-v2",
+		})
+		return err
+	}},
+	{"write secret/demo/database", func(ctx context.Context, client *api.Client) error {
+		_, err := client.KVv2("secret").Put(ctx, "demo/database", map[string]interface{}{
+			"username": "app",
+			"password": "demo-password",
+		})
+		return err
+	}},
+	{"mount a KV v1 engine at kv-v1/ with kv-v1/demo/legacy", func(ctx context.Context, client *api.Client) error {
+		if err := client.Sys().MountWithContext(ctx, "kv-v1", &api.MountInput{
+			Type:        "kv",
+			Description: "Example KV v1 mount",
+			Options:     map[string]string{"version": "1"},
+		}); err != nil {
+			return err
+		}
+		return client.KVv1("kv-v1").Put(ctx, "demo/legacy", map[string]interface{}{"token": "demo-legacy-token"})
+	}},
+	{"mount a PKI engine at pki/ with a root CA and the role demo", func(ctx context.Context, client *api.Client) error {
+		if err := client.Sys().MountWithContext(ctx, "pki", &api.MountInput{
+			Type:        "pki",
+			Description: "Example PKI mount",
+			Config:      api.MountConfigInput{MaxLeaseTTL: "87600h"},
+		}); err != nil {
+			return err
+		}
+		if _, err := client.Logical().WriteWithContext(ctx, "pki/root/generate/internal", map[string]interface{}{
+			"common_name": "Demo Root CA",
+			"issuer_name": "demo-root",
+			"ttl":         "87600h",
+		}); err != nil {
+			return err
+		}
+		_, err := client.Logical().WriteWithContext(ctx, "pki/roles/demo", map[string]interface{}{
+			"allowed_domains":  "example.com",
+			"allow_subdomains": true,
+			"max_ttl":          "72h",
+		})
+		return err
+	}},
+}
+
+// Seed creates example mounts and secrets in the dev server, returning a description of each change
+func Seed(ctx context.Context, client *api.Client) ([]string, error) {
+	done := make([]string, 0, len(seedSteps))
+	for _, step := range seedSteps {
+		if err := step.run(ctx, client); err != nil {
+			return done, fmt.Errorf("failed to %s: %w", step.description, err)
+		}
+		done = append(done, step.description)
+	}
+	return done, nil
+}
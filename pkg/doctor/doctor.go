@@ -0,0 +1,453 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Package doctor checks the configuration of the server and its access to Vault before it is started, so
+// that setup problems are reported up front instead of as failed tool calls
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/scheduler"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault/api"
+	log "github.com/sirupsen/logrus"
+)
+
+// Statuses of checks
+const (
+	StatusOK   = "ok"
+	StatusWarn = "warn"
+	StatusFail = "fail"
+	StatusSkip = "skip"
+)
+
+// doctorSessionID is the session of the Vault client used by the checks
+const doctorSessionID = "doctor"
+
+// Check is the result of one check
+type Check struct {
+	Name    string   `json:"name"`
+	Status  string   `json:"status"`
+	Message string   `json:"message"`
+	Details []string `json:"details,omitempty"`
+}
+
+// Report is the result of all checks
+type Report struct {
+	Ready  bool    `json:"ready"` // Whether no check failed
+	Checks []Check `json:"checks"`
+}
+
+// Options select the transport the server is checked for
+type Options struct {
+	HTTP    bool          // Whether the server runs the StreamableHTTP transport
+	Host    string        // Host the HTTP transport binds to
+	Timeout time.Duration // Timeout of each request to Vault, 10s by default
+}
+
+// capabilityCheck is a capability the token needs for a group of tools
+type capabilityCheck struct {
+	path         string
+	capabilities []string // Any of these grants access
+	purpose      string
+}
+
+var capabilityChecks = []capabilityCheck{
+	{"sys/mounts", []string{"read"}, "list secrets engines (list_mounts)"},
+	{"sys/mounts/example", []string{"create", "update"}, "enable secrets engines (create_mount)"},
+	{"sys/mounts/example", []string{"delete"}, "disable secrets engines (delete_mount)"},
+	{"sys/auth", []string{"read"}, "list auth methods (auth method tools)"},
+}
+
+// Run runs the checks and returns the report
+func Run(ctx context.Context, opts Options) Report {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+
+	var report Report
+	add := func(check Check) {
+		report.Checks = append(report.Checks, check)
+	}
+
+	add(checkEnvironment())
+	add(checkTLS(opts))
+	if opts.HTTP {
+		add(checkCORS())
+		add(checkTrustedProxies())
+		add(checkTenancy())
+		add(checkSessionStore(ctx, opts))
+	}
+	add(checkApproval())
+	add(checkPlugins())
+	add(checkSchedule())
+
+	vault, connection := checkVaultConnection(ctx, opts)
+	add(connection)
+	if vault != nil {
+		defer client.DeleteVaultClient(doctorSessionID)
+		token := checkVaultToken(ctx, vault, opts)
+		add(token)
+		if token.Status == StatusFail {
+			add(Check{Name: "vault_capabilities", Status: StatusSkip, Message: "The token is not valid"})
+		} else {
+			add(checkCapabilities(ctx, vault, opts))
+		}
+	} else {
+		add(Check{Name: "vault_token", Status: StatusSkip, Message: "Vault is not reachable"})
+		add(Check{Name: "vault_capabilities", Status: StatusSkip, Message: "Vault is not reachable"})
+	}
+
+	report.Ready = !slices.ContainsFunc(report.Checks, func(check Check) bool { return check.Status == StatusFail })
+	return report
+}
+
+// WriteText writes the report for a terminal
+func (r Report) WriteText(w io.Writer) {
+	for _, check := range r.Checks {
+		_, _ = fmt.Fprintf(w, "[%-4s] %-20s %s\n", strings.ToUpper(check.Status), check.Name, check.Message)
+		for _, detail := range check.Details {
+			_, _ = fmt.Fprintf(w, "       %-20s - %s\n", "", detail)
+		}
+	}
+	if r.Ready {
+		_, _ = fmt.Fprintln(w, "\nThe server is ready to start")
+	} else {
+		_, _ = fmt.Fprintln(w, "\nThe server is not ready, fix the failed checks above")
+	}
+}
+
+// warningHook collects the warnings logged while the configuration is loaded
+type warningHook struct {
+	warnings []string
+}
+
+func (h *warningHook) Levels() []log.Level {
+	return []log.Level{log.PanicLevel, log.FatalLevel, log.ErrorLevel, log.WarnLevel}
+}
+
+func (h *warningHook) Fire(entry *log.Entry) error {
+	h.warnings = append(h.warnings, entry.Message)
+	return nil
+}
+
+// checkEnvironment loads the configuration of the middleware the way the server does and reports the
+// values it rejects, which the server only logs before it falls back to the defaults
+func checkEnvironment() Check {
+	logger := log.StandardLogger()
+	hook := &warningHook{}
+	hooks := logger.ReplaceHooks(log.LevelHooks{})
+	output := logger.Out
+	logger.AddHook(hook)
+	logger.SetOutput(io.Discard)
+
+	client.LoadRateLimitConfigFromEnv()
+	client.LoadBudgetConfigFromEnv()
+	client.LoadCircuitBreakerConfigFromEnv()
+	client.LoadToolTimeoutConfigFromEnv()
+	client.LoadApprovalConfigFromEnv()
+	client.LoadCapabilityCacheTTLFromEnv()
+
+	logger.ReplaceHooks(hooks)
+	logger.SetOutput(output)
+
+	problems := hook.warnings
+	if value, set := os.LookupEnv(client.AuditHeaderEnv); set {
+		if _, ok := client.LoadAuditHeaderFromEnv(); !ok {
+			problems = append(problems, fmt.Sprintf("Invalid %s value '%s', the default header is used", client.AuditHeaderEnv, value))
+		}
+	}
+	if value := os.Getenv(utils.MaxResultBytesEnv); value != "" {
+		if _, ok := utils.LoadMaxResultBytesFromEnv(); !ok {
+			problems = append(problems, fmt.Sprintf("Invalid %s value '%s', the default size cap is used", utils.MaxResultBytesEnv, value))
+		}
+	}
+	if value := os.Getenv(tools.EnableAdminToolsEnv); value != "" {
+		if _, err := strconv.ParseBool(value); err != nil {
+			problems = append(problems, fmt.Sprintf("Invalid %s value '%s', admin tools are disabled", tools.EnableAdminToolsEnv, value))
+		}
+	}
+	if value := os.Getenv(client.VaultSkipTLSVerify); value != "" {
+		if _, err := strconv.ParseBool(value); err != nil {
+			problems = append(problems, fmt.Sprintf("Invalid %s value '%s', TLS certificates are verified", client.VaultSkipTLSVerify, value))
+		}
+	}
+
+	if len(problems) > 0 {
+		return Check{Name: "environment", Status: StatusWarn, Message: "Invalid values are replaced by defaults", Details: problems}
+	}
+	return Check{Name: "environment", Status: StatusOK, Message: "All settings are valid"}
+}
+
+func checkTLS(opts Options) Check {
+	check := Check{Name: "tls"}
+	tlsConfig, err := client.GetTLSConfigFromEnv()
+	switch {
+	case err != nil:
+		check.Status, check.Message = StatusFail, err.Error()
+	case tlsConfig != nil:
+		check.Status, check.Message = StatusOK, fmt.Sprintf("Certificate %s and key %s are valid", tlsConfig.CertFile, tlsConfig.KeyFile)
+	case !opts.HTTP:
+		check.Status, check.Message = StatusSkip, "TLS is not used by the stdio transport"
+	case !client.IsLocalHost(opts.Host):
+		check.Status, check.Message = StatusFail, fmt.Sprintf("TLS is required for non-localhost binding (%s). Set MCP_TLS_CERT_FILE and MCP_TLS_KEY_FILE", opts.Host)
+	default:
+		check.Status, check.Message = StatusWarn, "TLS is disabled, which is only allowed on localhost"
+	}
+	return check
+}
+
+func checkCORS() Check {
+	cors := client.LoadCORSConfigFromEnv()
+	check := Check{Name: "cors", Status: StatusOK, Message: fmt.Sprintf("Mode %s with %d allowed origins", cors.Mode, len(cors.AllowedOrigins))}
+	switch cors.Mode {
+	case "strict":
+		if len(cors.AllowedOrigins) == 0 {
+			check.Status, check.Message = StatusWarn, "Mode strict without MCP_ALLOWED_ORIGINS, requests from browsers are rejected"
+		}
+	case "development":
+		check.Status, check.Message = StatusWarn, "Mode development also allows localhost origins, do not use it in production"
+	case "disabled":
+		check.Status, check.Message = StatusWarn, "CORS is disabled, requests from any origin are accepted"
+		if cors.AllowCredentials {
+			check.Message += ", MCP_CORS_ALLOW_CREDENTIALS is ignored"
+		}
+	default:
+		check.Status, check.Message = StatusFail, fmt.Sprintf("Unknown MCP_CORS_MODE '%s', use strict, development or disabled", cors.Mode)
+	}
+	return check
+}
+
+func checkTrustedProxies() Check {
+	proxies, err := client.LoadTrustedProxiesFromEnv()
+	if err != nil {
+		return Check{Name: "trusted_proxies", Status: StatusFail, Message: err.Error()}
+	}
+	if len(proxies) == 0 {
+		return Check{Name: "trusted_proxies", Status: StatusSkip, Message: "No trusted proxies, forwarding headers are ignored"}
+	}
+	return Check{Name: "trusted_proxies", Status: StatusOK, Message: fmt.Sprintf("%d trusted proxy addresses and ranges", len(proxies))}
+}
+
+func checkTenancy() Check {
+	tenancy, err := client.LoadTenancyConfigFromEnv()
+	if err != nil {
+		return Check{Name: "tenancy", Status: StatusFail, Message: err.Error()}
+	}
+	if tenancy == nil {
+		return Check{Name: "tenancy", Status: StatusSkip, Message: "Multi-tenancy is disabled"}
+	}
+	return Check{Name: "tenancy", Status: StatusOK, Message: fmt.Sprintf("%d tenant profiles", len(tenancy.Profiles))}
+}
+
+func checkSessionStore(ctx context.Context, opts Options) Check {
+	store, err := client.LoadSessionStoreFromEnv()
+	if err != nil {
+		return Check{Name: "session_store", Status: StatusFail, Message: err.Error()}
+	}
+	if _, ok := store.(*client.MemorySessionStore); ok {
+		return Check{Name: "session_store", Status: StatusOK, Message: "Sessions are kept in memory"}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+	if _, err := store.Load(ctx, doctorSessionID); err != nil {
+		return Check{Name: "session_store", Status: StatusFail, Message: fmt.Sprintf("Session store is not reachable: %v", err)}
+	}
+	return Check{Name: "session_store", Status: StatusOK, Message: "Session store is reachable"}
+}
+
+func checkApproval() Check {
+	config := client.LoadApprovalConfigFromEnv()
+	if !config.Enabled() {
+		return Check{Name: "approval", Status: StatusSkip, Message: "No tool calls require approval"}
+	}
+	if config.Token == "" {
+		return Check{Name: "approval", Status: StatusFail, Message: fmt.Sprintf("%s is required when %s is set", client.ApprovalTokenEnv, client.ApprovalRequiredEnv)}
+	}
+	host, _, err := net.SplitHostPort(config.Address)
+	if err != nil {
+		return Check{Name: "approval", Status: StatusFail, Message: fmt.Sprintf("Invalid %s '%s': %v", client.ApprovalAddressEnv, config.Address, err)}
+	}
+	if tlsConfig, _ := client.GetTLSConfigFromEnv(); tlsConfig == nil && !client.IsLocalHost(host) {
+		return Check{Name: "approval", Status: StatusFail, Message: fmt.Sprintf("TLS is required for non-localhost binding of the approval endpoint (%s)", host)}
+	}
+	return Check{Name: "approval", Status: StatusOK, Message: fmt.Sprintf("Approval endpoint on %s", config.Address)}
+}
+
+func checkSchedule() Check {
+	config, err := scheduler.LoadConfigFromEnv()
+	switch {
+	case err != nil:
+		return Check{Name: "schedule", Status: StatusFail, Message: err.Error()}
+	case config == nil:
+		return Check{Name: "schedule", Status: StatusSkip, Message: "No jobs are scheduled"}
+	}
+	jobs := make([]string, 0, len(config.Jobs))
+	for _, job := range config.Jobs {
+		jobs = append(jobs, fmt.Sprintf("%s: %s on '%s'", job.Name, job.Tool, job.Schedule))
+	}
+	for _, sink := range config.Sinks {
+		jobs = append(jobs, fmt.Sprintf("sink %s: %s findings of %s or higher", sink.Name, sink.Type, sink.MinSeverity))
+	}
+	return Check{Name: "schedule", Status: StatusOK, Message: fmt.Sprintf("%d jobs scheduled", len(config.Jobs)), Details: jobs}
+}
+
+func checkPlugins() Check {
+	var missing []string
+	count := 0
+	for _, command := range filepath.SplitList(os.Getenv(tools.ToolPluginsEnv)) {
+		command = strings.TrimSpace(command)
+		if command == "" {
+			continue
+		}
+		count++
+		if _, err := exec.LookPath(command); err != nil {
+			missing = append(missing, fmt.Sprintf("%s: %v", command, err))
+		}
+	}
+
+	switch {
+	case count == 0:
+		return Check{Name: "plugins", Status: StatusSkip, Message: "No tool plugins"}
+	case len(missing) > 0:
+		// The server skips plugins that fail to start
+		return Check{Name: "plugins", Status: StatusWarn, Message: fmt.Sprintf("%d of %d tool plugins cannot be started", len(missing), count), Details: missing}
+	default:
+		return Check{Name: "plugins", Status: StatusOK, Message: fmt.Sprintf("%d tool plugins found", count)}
+	}
+}
+
+// checkVaultConnection creates the Vault client the server would create from the environment and checks the
+// health of the Vault server. The client is nil when Vault cannot be reached.
+func checkVaultConnection(ctx context.Context, opts Options) (*api.Client, Check) {
+	check := Check{Name: "vault_connection"}
+
+	address := os.Getenv(client.VaultAddress)
+	if address == "" {
+		address = client.DefaultVaultAddress
+	}
+	if proxyAddress := os.Getenv(client.VaultProxyAddress); proxyAddress != "" {
+		check.Details = append(check.Details, fmt.Sprintf("Requests are routed through Vault Proxy at %s", proxyAddress))
+		address = proxyAddress
+	}
+	skipVerify, _ := strconv.ParseBool(os.Getenv(client.VaultSkipTLSVerify))
+	if skipVerify {
+		check.Details = append(check.Details, "TLS certificates of Vault are not verified")
+	}
+
+	vault, err := client.NewVaultClient(doctorSessionID, address, skipVerify, os.Getenv(client.VaultToken), os.Getenv(client.VaultNamespace))
+	if err != nil {
+		check.Status, check.Message = StatusFail, err.Error()
+		return nil, check
+	}
+	vault.SetMaxRetries(0)
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+	health, err := vault.Sys().HealthWithContext(ctx)
+	if err != nil {
+		client.DeleteVaultClient(doctorSessionID)
+		check.Status, check.Message = StatusFail, fmt.Sprintf("Vault at %s is not reachable: %v", address, err)
+		return nil, check
+	}
+
+	switch {
+	case !health.Initialized:
+		check.Status, check.Message = StatusFail, fmt.Sprintf("Vault at %s is not initialized", address)
+	case health.Sealed:
+		check.Status, check.Message = StatusFail, fmt.Sprintf("Vault at %s is sealed", address)
+	default:
+		check.Status, check.Message = StatusOK, fmt.Sprintf("Vault %s at %s is unsealed", health.Version, address)
+		if health.Standby {
+			check.Details = append(check.Details, "The node is a standby, requests are forwarded to the active node")
+		}
+	}
+	return vault, check
+}
+
+func checkVaultToken(ctx context.Context, vault *api.Client, opts Options) Check {
+	check := Check{Name: "vault_token"}
+	if vault.Token() == "" {
+		if os.Getenv(client.VaultProxyAddress) != "" {
+			check.Status, check.Message = StatusOK, "No VAULT_TOKEN, Vault Proxy authenticates the requests"
+			return check
+		}
+		check.Status, check.Message = StatusWarn, "No VAULT_TOKEN, HTTP clients must send X-Vault-Token"
+		if !opts.HTTP {
+			check.Status, check.Message = StatusFail, "VAULT_TOKEN is required by the stdio transport"
+		}
+		return check
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+	secret, err := vault.Auth().Token().LookupSelfWithContext(ctx)
+	if err != nil {
+		check.Status, check.Message = StatusFail, fmt.Sprintf("Token lookup failed: %v", err)
+		return check
+	}
+
+	policies, _ := secret.TokenPolicies()
+	ttl, _ := secret.TokenTTL()
+	renewable, _ := secret.TokenIsRenewable()
+	check.Status = StatusOK
+	check.Message = fmt.Sprintf("Token is valid with policies %s", strings.Join(policies, ", "))
+	switch {
+	case ttl == 0:
+		check.Details = append(check.Details, "The token does not expire")
+	case ttl < 24*time.Hour && !renewable:
+		check.Status = StatusWarn
+		check.Details = append(check.Details, fmt.Sprintf("The token expires in %s and is not renewable", ttl))
+	default:
+		check.Details = append(check.Details, fmt.Sprintf("The token expires in %s", ttl))
+	}
+	return check
+}
+
+func checkCapabilities(ctx context.Context, vault *api.Client, opts Options) Check {
+	check := Check{Name: "vault_capabilities"}
+
+	paths := make([]string, 0, len(capabilityChecks))
+	for _, capability := range capabilityChecks {
+		if !slices.Contains(paths, capability.path) {
+			paths = append(paths, capability.path)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+	granted, err := client.SelfCapabilities(ctx, vault, paths)
+	if err != nil {
+		check.Status, check.Message = StatusWarn, fmt.Sprintf("Capabilities cannot be checked: %v", err)
+		return check
+	}
+
+	for _, capability := range capabilityChecks {
+		caps := granted[capability.path]
+		if slices.Contains(caps, "root") || slices.ContainsFunc(capability.capabilities, func(c string) bool { return slices.Contains(caps, c) }) {
+			continue
+		}
+		check.Details = append(check.Details, fmt.Sprintf("Cannot %s: needs %s on %s", capability.purpose, strings.Join(capability.capabilities, " or "), capability.path))
+	}
+
+	if len(check.Details) > 0 {
+		check.Status, check.Message = StatusWarn, "Some tools will fail with permission denied"
+		return check
+	}
+	check.Status, check.Message = StatusOK, "The token can manage mounts and auth methods"
+	return check
+}
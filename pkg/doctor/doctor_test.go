@@ -0,0 +1,141 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMockVault serves the endpoints used by the checks, granting the capabilities in caps
+func newMockVault(t *testing.T, caps string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/sys/health":
+			_, _ = w.Write([]byte(`{"initialized":true,"sealed":false,"standby":false,"version":"1.19.0"}`))
+		case "/v1/auth/token/lookup-self":
+			if r.Header.Get("X-Vault-Token") != "valid-token" {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":{"policies":["default","mcp"],"ttl":0,"renewable":false}}`))
+		case "/v1/sys/capabilities-self":
+			_, _ = w.Write([]byte(`{"data":{"sys/mounts":` + caps + `,"sys/mounts/example":` + caps + `,"sys/auth":` + caps + `}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func findCheck(t *testing.T, report Report, name string) Check {
+	for _, check := range report.Checks {
+		if check.Name == name {
+			return check
+		}
+	}
+	require.Failf(t, "check not found", "no check named %s", name)
+	return Check{}
+}
+
+func TestRun(t *testing.T) {
+	t.Setenv(client.VaultToken, "valid-token")
+	t.Setenv(client.VaultProxyAddress, "")
+	t.Setenv("MCP_TLS_CERT_FILE", "")
+	t.Setenv("MCP_TLS_KEY_FILE", "")
+
+	t.Run("ready with a valid token", func(t *testing.T) {
+		vault := newMockVault(t, `["root"]`)
+		t.Setenv(client.VaultAddress, vault.URL)
+
+		report := Run(context.Background(), Options{})
+		assert.True(t, report.Ready)
+		assert.Equal(t, StatusOK, findCheck(t, report, "vault_connection").Status)
+		assert.Equal(t, StatusOK, findCheck(t, report, "vault_token").Status)
+		assert.Equal(t, StatusOK, findCheck(t, report, "vault_capabilities").Status)
+		assert.Nil(t, client.GetVaultClient(doctorSessionID), "the client of the checks is removed")
+
+		var out bytes.Buffer
+		report.WriteText(&out)
+		assert.Contains(t, out.String(), "The server is ready to start")
+	})
+
+	t.Run("missing capabilities are warnings", func(t *testing.T) {
+		vault := newMockVault(t, `["read"]`)
+		t.Setenv(client.VaultAddress, vault.URL)
+
+		report := Run(context.Background(), Options{})
+		assert.True(t, report.Ready)
+		check := findCheck(t, report, "vault_capabilities")
+		assert.Equal(t, StatusWarn, check.Status)
+		assert.Len(t, check.Details, 2)
+	})
+
+	t.Run("invalid token fails", func(t *testing.T) {
+		vault := newMockVault(t, `["root"]`)
+		t.Setenv(client.VaultAddress, vault.URL)
+		t.Setenv(client.VaultToken, "expired-token")
+
+		report := Run(context.Background(), Options{})
+		assert.False(t, report.Ready)
+		assert.Equal(t, StatusFail, findCheck(t, report, "vault_token").Status)
+		assert.Equal(t, StatusSkip, findCheck(t, report, "vault_capabilities").Status)
+	})
+
+	t.Run("unreachable Vault fails", func(t *testing.T) {
+		vault := newMockVault(t, `["root"]`)
+		vault.Close()
+		t.Setenv(client.VaultAddress, vault.URL)
+
+		report := Run(context.Background(), Options{})
+		assert.False(t, report.Ready)
+		assert.Equal(t, StatusFail, findCheck(t, report, "vault_connection").Status)
+		assert.Equal(t, StatusSkip, findCheck(t, report, "vault_token").Status)
+	})
+}
+
+func TestCheckEnvironment(t *testing.T) {
+	t.Setenv("MCP_RATE_LIMIT_GLOBAL", "fast")
+	t.Setenv(client.ToolTimeoutEnv, "soon")
+	t.Setenv(client.AuditHeaderEnv, "bad header")
+
+	check := checkEnvironment()
+	assert.Equal(t, StatusWarn, check.Status)
+	assert.Len(t, check.Details, 3)
+}
+
+func TestCheckTLS(t *testing.T) {
+	t.Setenv("MCP_TLS_CERT_FILE", "")
+	t.Setenv("MCP_TLS_KEY_FILE", "")
+
+	assert.Equal(t, StatusSkip, checkTLS(Options{}).Status)
+	assert.Equal(t, StatusWarn, checkTLS(Options{HTTP: true, Host: "127.0.0.1"}).Status)
+	assert.Equal(t, StatusFail, checkTLS(Options{HTTP: true, Host: "10.0.0.5"}).Status)
+
+	t.Setenv("MCP_TLS_CERT_FILE", "/does/not/exist.pem")
+	t.Setenv("MCP_TLS_KEY_FILE", "/does/not/exist.key")
+	assert.Equal(t, StatusFail, checkTLS(Options{HTTP: true, Host: "127.0.0.1"}).Status)
+}
+
+func TestCheckCORS(t *testing.T) {
+	t.Setenv("MCP_CORS_MODE", "strict")
+	t.Setenv("MCP_ALLOWED_ORIGINS", "https://example.com")
+	assert.Equal(t, StatusOK, checkCORS().Status)
+
+	t.Setenv("MCP_ALLOWED_ORIGINS", "")
+	assert.Equal(t, StatusWarn, checkCORS().Status)
+
+	t.Setenv("MCP_CORS_MODE", "open")
+	assert.Equal(t, StatusFail, checkCORS().Status)
+}
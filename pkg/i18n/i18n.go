@@ -0,0 +1,217 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Package i18n translates the human-readable messages of tool results. The catalog of each locale maps the
+// English format strings used by the tool handlers, such as "Failed to read secret: %v", to their
+// translation, so handlers keep returning English messages and the messages are translated on their way to
+// the client. Messages missing from the catalog are returned in English.
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// LocaleEnv sets the locale of the messages of clients that do not ask for one
+const LocaleEnv = "MCP_LOCALE"
+
+// DefaultLocale is the locale of the messages written by the tool handlers
+const DefaultLocale = "en"
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// verbPattern matches the formatting verbs of the catalog keys
+var verbPattern = regexp.MustCompile(`%[vsdq]`)
+
+// message is a catalog entry with formatting verbs, matched against formatted messages
+type message struct {
+	pattern     *regexp.Regexp
+	translation []string // Literal parts of the translation, between which the formatted values are inserted
+	literal     int      // Length of the literal text of the key, more specific entries are matched first
+}
+
+// catalog holds the translated messages of a locale
+type catalog struct {
+	exact    map[string]string
+	patterns []message
+}
+
+var (
+	catalogs      map[string]*catalog
+	catalogsOnce  sync.Once
+	defaultLocale = DefaultLocale
+	defaultMu     sync.RWMutex
+)
+
+type localeKey struct{}
+
+// loadCatalogs parses the embedded catalogs once
+func loadCatalogs() map[string]*catalog {
+	catalogsOnce.Do(func() {
+		catalogs = map[string]*catalog{}
+		names, _ := localeFiles.ReadDir("locales")
+		for _, name := range names {
+			data, err := localeFiles.ReadFile(path.Join("locales", name.Name()))
+			if err != nil {
+				log.WithError(err).Errorf("Failed to read message catalog %s", name.Name())
+				continue
+			}
+			c, err := parseCatalog(data)
+			if err != nil {
+				log.WithError(err).Errorf("Invalid message catalog %s", name.Name())
+				continue
+			}
+			catalogs[strings.TrimSuffix(name.Name(), ".json")] = c
+		}
+	})
+	return catalogs
+}
+
+// parseCatalog parses a catalog mapping English messages to their translation. Messages with formatting
+// verbs must keep the same number of verbs, in the same order, in their translation.
+func parseCatalog(data []byte) (*catalog, error) {
+	var messages map[string]string
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return nil, err
+	}
+
+	c := &catalog{exact: map[string]string{}}
+	for key, translation := range messages {
+		verbs := verbPattern.FindAllString(key, -1)
+		if len(verbs) == 0 {
+			c.exact[key] = translation
+			continue
+		}
+		if translated := verbPattern.FindAllString(translation, -1); len(translated) != len(verbs) {
+			return nil, fmt.Errorf("the translation of '%s' has %d formatting verbs instead of %d", key, len(translated), len(verbs))
+		}
+
+		literals := verbPattern.Split(key, -1)
+		var expr strings.Builder
+		expr.WriteString("^")
+		length := 0
+		for i, literal := range literals {
+			if i > 0 {
+				expr.WriteString("(.*?)")
+			}
+			expr.WriteString(regexp.QuoteMeta(literal))
+			length += len(literal)
+		}
+		expr.WriteString("$")
+
+		c.patterns = append(c.patterns, message{
+			pattern:     regexp.MustCompile(expr.String()),
+			translation: verbPattern.Split(translation, -1),
+			literal:     length,
+		})
+	}
+	sort.SliceStable(c.patterns, func(i, j int) bool { return c.patterns[i].literal > c.patterns[j].literal })
+	return c, nil
+}
+
+// translate returns the translation of a message, or the message itself when the catalog has none
+func (c *catalog) translate(text string) string {
+	if translation, ok := c.exact[text]; ok {
+		return translation
+	}
+	for _, m := range c.patterns {
+		values := m.pattern.FindStringSubmatch(text)
+		if values == nil {
+			continue
+		}
+		var b strings.Builder
+		for i, literal := range m.translation {
+			if i > 0 {
+				b.WriteString(values[i])
+			}
+			b.WriteString(literal)
+		}
+		return b.String()
+	}
+	return text
+}
+
+// Locales returns the supported locales, including the default locale
+func Locales() []string {
+	locales := []string{DefaultLocale}
+	for locale := range loadCatalogs() {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales[1:])
+	return locales
+}
+
+// Supported returns the supported locale matching the language tag, such as "de" for "de-CH", or an
+// empty string when it is not supported
+func Supported(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	language, _, _ := strings.Cut(strings.ReplaceAll(tag, "_", "-"), "-")
+	if language == DefaultLocale {
+		return DefaultLocale
+	}
+	if _, ok := loadCatalogs()[language]; ok {
+		return language
+	}
+	return ""
+}
+
+// LoadLocaleFromEnv loads the default locale from MCP_LOCALE. An unsupported locale is ignored with a warning.
+func LoadLocaleFromEnv() (string, bool) {
+	value := os.Getenv(LocaleEnv)
+	if value == "" {
+		return "", false
+	}
+	locale := Supported(value)
+	if locale == "" {
+		log.Warnf("Unsupported %s value '%s', messages are in English. Supported locales: %s", LocaleEnv, value, strings.Join(Locales(), ", "))
+		return "", false
+	}
+	log.Infof("%s set to %s", LocaleEnv, locale)
+	return locale, true
+}
+
+// SetDefaultLocale sets the locale of the messages of clients that do not ask for one
+func SetDefaultLocale(locale string) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultLocale = locale
+}
+
+// WithLocale returns a copy of the context carrying the locale asked for by the client
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// LocaleFromContext returns the locale asked for by the client, or the default locale
+func LocaleFromContext(ctx context.Context) string {
+	if locale, ok := ctx.Value(localeKey{}).(string); ok && locale != "" {
+		return locale
+	}
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultLocale
+}
+
+// Translate returns the message in the locale of the context
+func Translate(ctx context.Context, text string) string {
+	locale := LocaleFromContext(ctx)
+	if locale == DefaultLocale {
+		return text
+	}
+	c, ok := loadCatalogs()[locale]
+	if !ok {
+		return text
+	}
+	return c.translate(text)
+}
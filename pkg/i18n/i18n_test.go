@@ -0,0 +1,142 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package i18n
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogs(t *testing.T) {
+	assert.Equal(t, []string{"en", "de", "es", "fr"}, Locales())
+
+	// Every catalog translates the same messages
+	var english []string
+	for _, locale := range Locales()[1:] {
+		data, err := localeFiles.ReadFile("locales/" + locale + ".json")
+		require.NoError(t, err)
+		_, err = parseCatalog(data)
+		require.NoError(t, err, locale)
+
+		var messages map[string]string
+		require.NoError(t, json.Unmarshal(data, &messages))
+		keys := make([]string, 0, len(messages))
+		for key := range messages {
+			keys = append(keys, key)
+		}
+		if english == nil {
+			english = keys
+		}
+		assert.ElementsMatch(t, english, keys, "messages of %s", locale)
+	}
+
+	t.Run("messages are used by the handlers", func(t *testing.T) {
+		var sources strings.Builder
+		err := filepath.WalkDir("..", func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+				return err
+			}
+			data, err := os.ReadFile(path)
+			sources.Write(data)
+			return err
+		})
+		require.NoError(t, err)
+
+		for _, message := range english {
+			// Messages with verbs are quoted in calls of fmt.Sprintf, the generic parameter message is an exception
+			if message == "Missing or invalid '%s' parameter" {
+				continue
+			}
+			assert.Contains(t, sources.String(), strconv.Quote(message), "the catalogs translate a message that no handler returns")
+		}
+	})
+
+	t.Run("translations keep the formatting verbs", func(t *testing.T) {
+		_, err := parseCatalog([]byte(`{"Failed to read path '%s': %v": "Lesen fehlgeschlagen: %v"}`))
+		assert.Error(t, err)
+	})
+}
+
+func TestTranslate(t *testing.T) {
+	de := WithLocale(context.Background(), "de")
+
+	assert.Equal(t, "Keine aktive Sitzung", Translate(de, "No active session"))
+	assert.Equal(t, "Fehlender oder ungültiger Parameter 'mount'", Translate(de, "Missing or invalid 'mount' parameter"))
+	assert.Equal(t,
+		"Version 3 des Secrets unter Pfad 'app/db' im Mount 'secret' mit dem Schlüssel 'password' geschrieben",
+		Translate(de, fmt.Sprintf("Successfully wrote version %v of the secret to path '%s' in mount '%s' with key '%s'", 3, "app/db", "secret", "password")))
+
+	// The most specific message wins
+	assert.Equal(t,
+		"Mount-Pfad 'kv' existiert nicht. Verwenden Sie 'create_mount' mit dem Typ kv2, um den Mount zu erstellen.",
+		Translate(de, "mount path 'kv' does not exist. Use 'create_mount' with the type kv2 to create the mount."))
+	assert.Equal(t, "Mount-Pfad 'kv' existiert nicht", Translate(de, "mount path 'kv' does not exist"))
+
+	// Unknown messages and JSON results stay as they are
+	assert.Equal(t, "Something else", Translate(de, "Something else"))
+	assert.Equal(t, `{"keys":["a"]}`, Translate(de, `{"keys":["a"]}`))
+
+	assert.Equal(t, "No active session", Translate(context.Background(), "No active session"))
+	assert.Equal(t, "No active session", Translate(WithLocale(context.Background(), "xx"), "No active session"))
+
+	t.Run("default locale", func(t *testing.T) {
+		SetDefaultLocale("fr")
+		defer SetDefaultLocale(DefaultLocale)
+		assert.Equal(t, "Aucune session active", Translate(context.Background(), "No active session"))
+		assert.Equal(t, "Keine aktive Sitzung", Translate(de, "No active session"), "the locale of the client wins")
+	})
+}
+
+func TestNegotiate(t *testing.T) {
+	assert.Equal(t, "de", Negotiate("de-CH, en;q=0.8"))
+	assert.Equal(t, "fr", Negotiate("ja, fr;q=0.9, en;q=0.8"))
+	assert.Equal(t, "en", Negotiate("en-US,en;q=0.9,de;q=0.5"))
+	assert.Equal(t, "es", Negotiate("de;q=0.2, es_MX;q=0.7"))
+	assert.Equal(t, "", Negotiate("ja, zh-CN"))
+	assert.Equal(t, "", Negotiate("de;q=0"))
+	assert.Equal(t, "", Negotiate(""))
+}
+
+func TestLoadLocaleFromEnv(t *testing.T) {
+	_, ok := LoadLocaleFromEnv()
+	assert.False(t, ok)
+
+	t.Setenv(LocaleEnv, "de_DE")
+	locale, ok := LoadLocaleFromEnv()
+	assert.True(t, ok)
+	assert.Equal(t, "de", locale)
+
+	t.Setenv(LocaleEnv, "tlh")
+	_, ok = LoadLocaleFromEnv()
+	assert.False(t, ok)
+}
+
+func TestMiddleware(t *testing.T) {
+	handler := Middleware()(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{mcp.NewTextContent("Missing or invalid arguments format"), mcp.NewTextContent(`{"data":{}}`)},
+			IsError: true,
+		}, nil
+	})
+
+	result, err := handler(WithLocale(context.Background(), "es"), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "Formato de argumentos ausente o no válido", result.Content[0].(mcp.TextContent).Text)
+	assert.Equal(t, `{"data":{}}`, result.Content[1].(mcp.TextContent).Text)
+
+	result, err = handler(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "Missing or invalid arguments format", result.Content[0].(mcp.TextContent).Text)
+}
@@ -0,0 +1,78 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package i18n
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Middleware translates the text contents of tool results to the locale of the client
+func Middleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			result, err := next(ctx, request)
+			if result == nil || LocaleFromContext(ctx) == DefaultLocale {
+				return result, err
+			}
+
+			for i, content := range result.Content {
+				if text, ok := mcp.AsTextContent(content); ok {
+					if translated := Translate(ctx, text.Text); translated != text.Text {
+						result.Content[i] = mcp.NewTextContent(translated)
+					}
+				}
+			}
+			return result, err
+		}
+	}
+}
+
+// HTTPMiddleware selects the locale of the messages of a request from its Accept-Language header, falling
+// back to the default locale when no language of the header is supported
+func HTTPMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if locale := Negotiate(r.Header.Get("Accept-Language")); locale != "" {
+				r = r.WithContext(WithLocale(r.Context(), locale))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Negotiate returns the supported locale preferred by an Accept-Language header, or an empty string when
+// the header names no supported language
+func Negotiate(acceptLanguage string) string {
+	type preference struct {
+		locale  string
+		quality float64
+	}
+	var preferences []preference
+	for _, entry := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(entry), ";")
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if locale := Supported(tag); locale != "" && quality > 0 {
+			preferences = append(preferences, preference{locale: locale, quality: quality})
+		}
+	}
+	if len(preferences) == 0 {
+		return ""
+	}
+	sort.SliceStable(preferences, func(i, j int) bool { return preferences[i].quality > preferences[j].quality })
+	return preferences[0].locale
+}
@@ -0,0 +1,428 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Package leader elects one replica of a Kubernetes deployment to run the background subsystems, using a
+// Lease object of the coordination.k8s.io API. Every replica keeps serving requests.
+package leader
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// ElectionEnv enables leader election
+	ElectionEnv = "MCP_LEADER_ELECTION"
+	// LeaseNameEnv is the name of the Lease object
+	LeaseNameEnv = "MCP_LEADER_ELECTION_LEASE"
+	// LeaseNamespaceEnv is the Kubernetes namespace of the Lease object, the namespace of the pod by default
+	LeaseNamespaceEnv = "MCP_LEADER_ELECTION_NAMESPACE"
+	// IdentityEnv identifies the replica in the Lease object, POD_NAME or the host name by default
+	IdentityEnv = "MCP_LEADER_ELECTION_IDENTITY"
+	// LeaseDurationEnv is how long the other replicas wait after the last renewal before they take over
+	LeaseDurationEnv = "MCP_LEADER_ELECTION_LEASE_DURATION"
+
+	// DefaultLeaseName is the name of the Lease object when MCP_LEADER_ELECTION_LEASE is not set
+	DefaultLeaseName = "vault-mcp-server"
+	// DefaultLeaseDuration is the lease duration when MCP_LEADER_ELECTION_LEASE_DURATION is not set
+	DefaultLeaseDuration = 15 * time.Second
+
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+	// microTimeFormat is the format of the times of Lease objects
+	microTimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+// ErrConflict is returned when the Lease object was changed by another replica since it was read
+var ErrConflict = errors.New("lease was modified by another replica")
+
+// Config holds the leader election configuration
+type Config struct {
+	Enabled       bool
+	LeaseName     string
+	Namespace     string
+	Identity      string
+	LeaseDuration time.Duration // How long a lease is held without renewal
+	RenewDeadline time.Duration // How long the leader tries to renew before it gives up leadership
+	RetryPeriod   time.Duration // How often the lease is renewed, or tried to be acquired
+}
+
+// LoadConfigFromEnv loads the leader election configuration from environment variables
+func LoadConfigFromEnv() Config {
+	config := Config{
+		LeaseName:     DefaultLeaseName,
+		Namespace:     os.Getenv(LeaseNamespaceEnv),
+		Identity:      os.Getenv(IdentityEnv),
+		LeaseDuration: DefaultLeaseDuration,
+	}
+
+	if value := os.Getenv(ElectionEnv); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			log.Warnf("Invalid %s value '%s', leader election is disabled", ElectionEnv, value)
+		}
+		config.Enabled = enabled
+	}
+	if name := os.Getenv(LeaseNameEnv); name != "" {
+		config.LeaseName = name
+	}
+	if config.Namespace == "" {
+		if namespace, err := os.ReadFile(serviceAccountDir + "/namespace"); err == nil {
+			config.Namespace = strings.TrimSpace(string(namespace))
+		}
+	}
+	if config.Identity == "" {
+		config.Identity = os.Getenv("POD_NAME")
+	}
+	if config.Identity == "" {
+		config.Identity, _ = os.Hostname()
+	}
+	if value := os.Getenv(LeaseDurationEnv); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed >= 3*time.Second {
+			config.LeaseDuration = parsed
+		} else {
+			log.Warnf("Invalid %s value '%s', using default %s", LeaseDurationEnv, value, DefaultLeaseDuration)
+		}
+	}
+
+	// The ratios of the defaults of Kubernetes controllers: 15s lease, 10s renew deadline, 2s retry
+	config.RenewDeadline = config.LeaseDuration * 2 / 3
+	config.RetryPeriod = config.LeaseDuration * 2 / 15
+	return config
+}
+
+// leaseSpec is the spec of a coordination.k8s.io/v1 Lease
+type leaseSpec struct {
+	HolderIdentity       string `json:"holderIdentity,omitempty"`
+	LeaseDurationSeconds int    `json:"leaseDurationSeconds,omitempty"`
+	AcquireTime          string `json:"acquireTime,omitempty"`
+	RenewTime            string `json:"renewTime,omitempty"`
+	LeaseTransitions     int    `json:"leaseTransitions,omitempty"`
+}
+
+// lease is a coordination.k8s.io/v1 Lease
+type lease struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name            string `json:"name"`
+		Namespace       string `json:"namespace"`
+		ResourceVersion string `json:"resourceVersion,omitempty"`
+	} `json:"metadata"`
+	Spec leaseSpec `json:"spec"`
+}
+
+// LeaseClient reads and writes Lease objects through the Kubernetes API
+type LeaseClient struct {
+	baseURL   string
+	tokenFile string
+	client    *http.Client
+}
+
+// NewInClusterLeaseClient creates a Lease client with the service account of the pod
+func NewInClusterLeaseClient() (*LeaseClient, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, errors.New("not running in a Kubernetes pod, KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT are not set")
+	}
+
+	ca, err := os.ReadFile(serviceAccountDir + "/ca.crt")
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the CA of the Kubernetes API: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, errors.New("invalid CA of the Kubernetes API")
+	}
+
+	httpClient := &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}},
+	}
+	return NewLeaseClient("https://"+net.JoinHostPort(host, port), serviceAccountDir+"/token", httpClient), nil
+}
+
+// NewLeaseClient creates a Lease client for the Kubernetes API at baseURL. The bearer token is read from
+// tokenFile on every request, as projected service account tokens are rotated.
+func NewLeaseClient(baseURL string, tokenFile string, httpClient *http.Client) *LeaseClient {
+	return &LeaseClient{baseURL: strings.TrimSuffix(baseURL, "/"), tokenFile: tokenFile, client: httpClient}
+}
+
+func (c *LeaseClient) leaseURL(namespace string, name string) string {
+	path := fmt.Sprintf("%s/apis/coordination.k8s.io/v1/namespaces/%s/leases", c.baseURL, url.PathEscape(namespace))
+	if name != "" {
+		path += "/" + url.PathEscape(name)
+	}
+	return path
+}
+
+// do sends a request and decodes the Lease of the response. A missing Lease is returned as nil.
+func (c *LeaseClient) do(ctx context.Context, method string, url string, body *lease) (*lease, error) {
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			return nil, err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, &payload)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if c.tokenFile != "" {
+		token, err := os.ReadFile(c.tokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read the service account token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound && method == http.MethodGet:
+		return nil, nil
+	case resp.StatusCode == http.StatusConflict:
+		return nil, ErrConflict
+	case resp.StatusCode >= 300:
+		var status struct {
+			Message string `json:"message"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&status)
+		return nil, fmt.Errorf("kubernetes API returned %d: %s", resp.StatusCode, status.Message)
+	}
+
+	var result lease
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("invalid Lease in the response of the Kubernetes API: %w", err)
+	}
+	return &result, nil
+}
+
+// Elector holds or waits for the lease of a group of replicas
+type Elector struct {
+	config Config
+	client *LeaseClient
+	logger *log.Logger
+	now    func() time.Time
+
+	mu             sync.RWMutex
+	leader         bool
+	holder         string    // Holder of the lease when it was last read
+	observedRecord leaseSpec // Spec of the lease when it was last read
+	observedTime   time.Time // When the spec last changed, by the clock of this replica
+}
+
+// NewElector creates an elector for the Lease of the configuration
+func NewElector(config Config, client *LeaseClient, logger *log.Logger) *Elector {
+	return &Elector{
+		config: config,
+		client: client,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// IsLeader reports whether this replica holds the lease
+func (e *Elector) IsLeader() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.leader
+}
+
+// Leader returns the identity of the replica holding the lease, when known
+func (e *Elector) Leader() string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.holder
+}
+
+// Run takes part in the election until the context is cancelled. lead is called with a context that is
+// cancelled when the lease is lost, and Run waits for it to return before it tries to acquire the lease
+// again. The lease is released when the context is cancelled.
+func (e *Elector) Run(ctx context.Context, lead func(ctx context.Context)) {
+	for ctx.Err() == nil {
+		if !e.acquire(ctx) {
+			return
+		}
+
+		e.logger.WithField("lease", e.config.LeaseName).Infof("Acquired leadership as %s", e.config.Identity)
+		leaderCtx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			lead(leaderCtx)
+		}()
+
+		e.renew(leaderCtx)
+		cancel()
+		<-done
+		e.setLeader(false)
+
+		if ctx.Err() != nil {
+			e.release()
+			return
+		}
+		e.logger.WithField("lease", e.config.LeaseName).Warn("Lost leadership, background subsystems stopped")
+	}
+}
+
+// acquire tries to acquire the lease every retry period until it succeeds or the context is cancelled
+func (e *Elector) acquire(ctx context.Context) bool {
+	ticker := time.NewTicker(e.config.RetryPeriod)
+	defer ticker.Stop()
+	for {
+		if e.tryAcquireOrRenew(ctx) {
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+	}
+}
+
+// renew renews the lease every retry period until a renewal does not succeed within the renew deadline
+func (e *Elector) renew(ctx context.Context) {
+	ticker := time.NewTicker(e.config.RetryPeriod)
+	defer ticker.Stop()
+	lastRenewal := e.now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if e.tryAcquireOrRenew(ctx) {
+			lastRenewal = e.now()
+			continue
+		}
+		if e.now().Sub(lastRenewal) >= e.config.RenewDeadline {
+			return
+		}
+	}
+}
+
+// tryAcquireOrRenew creates, takes over or renews the lease, and reports whether this replica holds it
+func (e *Elector) tryAcquireOrRenew(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, e.config.RenewDeadline)
+	defer cancel()
+
+	now := e.now()
+	spec := leaseSpec{
+		HolderIdentity:       e.config.Identity,
+		LeaseDurationSeconds: int(e.config.LeaseDuration.Seconds()),
+		AcquireTime:          now.UTC().Format(microTimeFormat),
+		RenewTime:            now.UTC().Format(microTimeFormat),
+	}
+
+	current, err := e.client.do(ctx, http.MethodGet, e.client.leaseURL(e.config.Namespace, e.config.LeaseName), nil)
+	if err != nil {
+		e.logger.WithError(err).Debug("Failed to read the leader election lease")
+		return false
+	}
+
+	if current == nil {
+		created := &lease{APIVersion: "coordination.k8s.io/v1", Kind: "Lease", Spec: spec}
+		created.Metadata.Name = e.config.LeaseName
+		created.Metadata.Namespace = e.config.Namespace
+		if _, err := e.client.do(ctx, http.MethodPost, e.client.leaseURL(e.config.Namespace, ""), created); err != nil {
+			e.logger.WithError(err).Debug("Failed to create the leader election lease")
+			return false
+		}
+		e.observe(spec, now)
+		e.setLeader(true)
+		return true
+	}
+
+	e.observe(current.Spec, now)
+	held := current.Spec.HolderIdentity == e.config.Identity
+	if !held && current.Spec.HolderIdentity != "" && !e.expired(current.Spec, now) {
+		e.setLeader(false)
+		return false
+	}
+
+	if held {
+		spec.AcquireTime = current.Spec.AcquireTime
+		spec.LeaseTransitions = current.Spec.LeaseTransitions
+	} else {
+		spec.LeaseTransitions = current.Spec.LeaseTransitions + 1
+	}
+	current.Spec = spec
+	if _, err := e.client.do(ctx, http.MethodPut, e.client.leaseURL(e.config.Namespace, e.config.LeaseName), current); err != nil {
+		e.logger.WithError(err).Debug("Failed to update the leader election lease")
+		return false
+	}
+	e.observe(spec, now)
+	e.setLeader(true)
+	return true
+}
+
+// observe records the spec of the lease, and when it changed by the clock of this replica, so that expiry
+// does not depend on the clocks of the other replicas
+func (e *Elector) observe(spec leaseSpec, now time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if spec != e.observedRecord {
+		e.observedRecord = spec
+		e.observedTime = now
+	}
+	e.holder = spec.HolderIdentity
+}
+
+// expired reports whether the holder of the lease has not renewed it within its duration
+func (e *Elector) expired(spec leaseSpec, now time.Time) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	duration := time.Duration(spec.LeaseDurationSeconds) * time.Second
+	return e.observedTime.Add(duration).Before(now)
+}
+
+func (e *Elector) setLeader(leader bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.leader = leader
+}
+
+// release gives up the lease so that another replica takes over without waiting for it to expire
+func (e *Elector) release() {
+	ctx, cancel := context.WithTimeout(context.Background(), e.config.RetryPeriod)
+	defer cancel()
+
+	current, err := e.client.do(ctx, http.MethodGet, e.client.leaseURL(e.config.Namespace, e.config.LeaseName), nil)
+	if err != nil || current == nil || current.Spec.HolderIdentity != e.config.Identity {
+		return
+	}
+	now := e.now().UTC().Format(microTimeFormat)
+	current.Spec = leaseSpec{
+		LeaseDurationSeconds: 1,
+		AcquireTime:          now,
+		RenewTime:            now,
+		LeaseTransitions:     current.Spec.LeaseTransitions,
+	}
+	if _, err := e.client.do(ctx, http.MethodPut, e.client.leaseURL(e.config.Namespace, e.config.LeaseName), current); err != nil {
+		e.logger.WithError(err).Warn("Failed to release the leader election lease")
+		return
+	}
+	e.logger.WithField("lease", e.config.LeaseName).Info("Released leadership")
+}
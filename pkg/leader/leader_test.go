@@ -0,0 +1,206 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package leader
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLeaseAPI serves one Lease object with the optimistic concurrency of the Kubernetes API
+type fakeLeaseAPI struct {
+	mu              sync.Mutex
+	lease           *lease
+	resourceVersion int
+}
+
+func (f *fakeLeaseAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var body lease
+	if r.Method != http.MethodGet {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if f.lease == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+	case http.MethodPost:
+		if f.lease != nil {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		f.resourceVersion++
+		body.Metadata.ResourceVersion = strconv.Itoa(f.resourceVersion)
+		f.lease = &body
+	case http.MethodPut:
+		if f.lease == nil || body.Metadata.ResourceVersion != f.lease.Metadata.ResourceVersion {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		f.resourceVersion++
+		body.Metadata.ResourceVersion = strconv.Itoa(f.resourceVersion)
+		f.lease = &body
+	}
+	_ = json.NewEncoder(w).Encode(f.lease)
+}
+
+func (f *fakeLeaseAPI) holder() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.lease == nil {
+		return ""
+	}
+	return f.lease.Spec.HolderIdentity
+}
+
+func newTestElector(t *testing.T, api *httptest.Server, identity string, leaseDuration time.Duration) *Elector {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+	config := Config{
+		Enabled:       true,
+		LeaseName:     "vault-mcp-server",
+		Namespace:     "vault",
+		Identity:      identity,
+		LeaseDuration: leaseDuration,
+		RenewDeadline: leaseDuration * 2 / 3,
+		RetryPeriod:   leaseDuration * 2 / 15,
+	}
+	return NewElector(config, NewLeaseClient(api.URL, "", api.Client()), logger)
+}
+
+func TestLoadConfigFromEnv(t *testing.T) {
+	t.Setenv(ElectionEnv, "true")
+	t.Setenv(LeaseNameEnv, "mcp")
+	t.Setenv(LeaseNamespaceEnv, "vault")
+	t.Setenv(IdentityEnv, "")
+	t.Setenv("POD_NAME", "vault-mcp-server-0")
+	t.Setenv(LeaseDurationEnv, "30s")
+
+	config := LoadConfigFromEnv()
+	assert.True(t, config.Enabled)
+	assert.Equal(t, "mcp", config.LeaseName)
+	assert.Equal(t, "vault", config.Namespace)
+	assert.Equal(t, "vault-mcp-server-0", config.Identity)
+	assert.Equal(t, 30*time.Second, config.LeaseDuration)
+	assert.Equal(t, 20*time.Second, config.RenewDeadline)
+	assert.Equal(t, 4*time.Second, config.RetryPeriod)
+
+	t.Setenv(ElectionEnv, "maybe")
+	t.Setenv(LeaseDurationEnv, "1s")
+	config = LoadConfigFromEnv()
+	assert.False(t, config.Enabled)
+	assert.Equal(t, DefaultLeaseDuration, config.LeaseDuration)
+}
+
+func TestTryAcquireOrRenew(t *testing.T) {
+	fake := &fakeLeaseAPI{}
+	api := httptest.NewServer(fake)
+	defer api.Close()
+
+	now := time.Now()
+	clock := func() time.Time { return now }
+	a := newTestElector(t, api, "replica-a", 15*time.Second)
+	a.now = clock
+	b := newTestElector(t, api, "replica-b", 15*time.Second)
+	b.now = clock
+	ctx := context.Background()
+
+	t.Run("the first replica creates the lease", func(t *testing.T) {
+		require.True(t, a.tryAcquireOrRenew(ctx))
+		assert.True(t, a.IsLeader())
+		assert.Equal(t, "replica-a", fake.holder())
+	})
+
+	t.Run("other replicas wait while the lease is renewed", func(t *testing.T) {
+		assert.False(t, b.tryAcquireOrRenew(ctx))
+		assert.False(t, b.IsLeader())
+		assert.Equal(t, "replica-a", b.Leader())
+
+		now = now.Add(10 * time.Second)
+		require.True(t, a.tryAcquireOrRenew(ctx))
+		now = now.Add(10 * time.Second)
+		assert.False(t, b.tryAcquireOrRenew(ctx), "the renewal restarts the lease duration")
+	})
+
+	t.Run("an expired lease is taken over", func(t *testing.T) {
+		now = now.Add(16 * time.Second)
+		require.True(t, b.tryAcquireOrRenew(ctx))
+		assert.Equal(t, "replica-b", fake.holder())
+		assert.Equal(t, 1, fake.lease.Spec.LeaseTransitions)
+
+		assert.False(t, a.tryAcquireOrRenew(ctx))
+		assert.False(t, a.IsLeader())
+	})
+}
+
+func TestRun(t *testing.T) {
+	fake := &fakeLeaseAPI{}
+	api := httptest.NewServer(fake)
+	defer api.Close()
+
+	a := newTestElector(t, api, "replica-a", 3*time.Second)
+	b := newTestElector(t, api, "replica-b", 3*time.Second)
+
+	leading := make(chan string, 2)
+	lead := func(identity string) func(ctx context.Context) {
+		return func(ctx context.Context) {
+			leading <- identity
+			<-ctx.Done()
+		}
+	}
+
+	ctxA, cancelA := context.WithCancel(context.Background())
+	doneA := make(chan struct{})
+	go func() {
+		defer close(doneA)
+		a.Run(ctxA, lead("replica-a"))
+	}()
+	require.Equal(t, "replica-a", <-leading)
+
+	ctxB, cancelB := context.WithCancel(context.Background())
+	defer cancelB()
+	doneB := make(chan struct{})
+	go func() {
+		defer close(doneB)
+		b.Run(ctxB, lead("replica-b"))
+	}()
+
+	select {
+	case identity := <-leading:
+		t.Fatalf("%s leads while replica-a holds the lease", identity)
+	case <-time.After(time.Second):
+	}
+
+	// The lease is released on shutdown, so the other replica takes over before it expires
+	cancelA()
+	<-doneA
+	assert.False(t, a.IsLeader())
+	select {
+	case identity := <-leading:
+		assert.Equal(t, "replica-b", identity)
+	case <-time.After(3 * time.Second):
+		t.Fatal("replica-b did not take over the released lease")
+	}
+
+	cancelB()
+	<-doneB
+}
@@ -0,0 +1,119 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+)
+
+// AdminAddressEnv is the address of the admin listener, such as "10.0.0.5:9090". When set, the operational
+// endpoints are served there instead of next to the MCP endpoint.
+const AdminAddressEnv = "MCP_ADMIN_ADDR"
+
+// adminAddress returns the address of the admin listener, empty when the operational endpoints are served
+// next to the MCP endpoint
+func adminAddress() string {
+	return strings.TrimSpace(os.Getenv(AdminAddressEnv))
+}
+
+// healthResponse is the response of the /health endpoint
+type healthResponse struct {
+	Status    string `json:"status"`
+	Service   string `json:"service"`
+	Transport string `json:"transport,omitempty"`
+	Endpoint  string `json:"endpoint,omitempty"`
+	Leader    *bool  `json:"leader,omitempty"` // Set while leader election runs
+}
+
+// healthHandler serves the /health endpoint. The transport and endpoint are omitted on the admin listener,
+// which serves every transport.
+func (s *Server) healthHandler(transport string, endpointPath string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response := healthResponse{Status: "ok", Service: "vault-mcp-server", Transport: transport, Endpoint: endpointPath}
+		// Every replica serves requests, leadership only tells which one runs the background subsystems
+		if elector := s.elector.Load(); elector != nil {
+			isLeader := elector.IsLeader()
+			response.Leader = &isLeader
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			s.logger.WithError(err).Error("Failed to write health check response")
+		}
+	}
+}
+
+// AdminHandler returns the handler of the operational endpoints served on the admin listener
+func (s *Server) AdminHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /health", s.healthHandler("", ""))
+	return mux
+}
+
+// ServeAdmin serves the operational endpoints on MCP_ADMIN_ADDR until the context is cancelled, so that the
+// MCP endpoint can be exposed to clients while they stay on an internal interface. It returns immediately
+// when MCP_ADMIN_ADDR is not set. TLS is configured from MCP_ADMIN_TLS_CERT_FILE and MCP_ADMIN_TLS_KEY_FILE,
+// independently of the MCP listener, and is required unless the listener binds to localhost.
+func (s *Server) ServeAdmin(ctx context.Context) error {
+	logger := s.logger
+
+	address := adminAddress()
+	if address == "" {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid %s '%s': %w", AdminAddressEnv, address, err)
+	}
+	tlsConfig, err := client.GetAdminTLSConfigFromEnv()
+	if err != nil {
+		return fmt.Errorf("admin TLS configuration error: %w", err)
+	}
+	if tlsConfig == nil && !client.IsLocalHost(host) {
+		return fmt.Errorf("TLS is required for non-localhost binding of the admin listener (%s). Set %s and %s environment variables", host, client.AdminTLSCertFileEnv, client.AdminTLSKeyFileEnv)
+	}
+
+	httpServer := &http.Server{
+		Addr:              address,
+		Handler:           s.AdminHandler(),
+		ReadTimeout:       30 * time.Second,
+		ReadHeaderTimeout: 30 * time.Second,
+		WriteTimeout:      defaultWriteTimeout,
+	}
+
+	errC := make(chan error, 1)
+	go func() {
+		logger.Infof("Starting admin listener on %s", address)
+		if tlsConfig != nil {
+			httpServer.TLSConfig = tlsConfig.Config
+			errC <- httpServer.ListenAndServeTLS(tlsConfig.CertFile, tlsConfig.KeyFile)
+			return
+		}
+		errC <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case <-ctx.Done():
+		logger.Infof("Shutting down admin listener...")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return httpServer.Shutdown(shutdownCtx)
+	case err := <-errC:
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("admin listener error: %w", err)
+		}
+	}
+
+	return nil
+}
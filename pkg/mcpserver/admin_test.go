@@ -0,0 +1,78 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminListener(t *testing.T) {
+	t.Setenv(AdminAddressEnv, "")
+	s := New(Config{Version: "1.2.3", Logger: newLogger()})
+
+	// Without an admin listener, /health is served next to the MCP endpoint
+	httpServer, err := s.newHTTPServer("localhost", "/mcp")
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	httpServer.Handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var health healthResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &health))
+	assert.Equal(t, healthResponse{Status: "ok", Service: "vault-mcp-server", Transport: "streamable-http", Endpoint: "/mcp"}, health)
+
+	// With an admin listener, /health moves there
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+	t.Setenv(AdminAddressEnv, address)
+
+	httpServer, err = s.newHTTPServer("localhost", "/mcp")
+	require.NoError(t, err)
+	recorder = httptest.NewRecorder()
+	httpServer.Handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.ServeAdmin(ctx) }()
+	require.Eventually(t, func() bool {
+		resp, err := http.Get("http://" + address + "/health")
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+		health = healthResponse{}
+		return resp.StatusCode == http.StatusOK && json.NewDecoder(resp.Body).Decode(&health) == nil
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.Equal(t, healthResponse{Status: "ok", Service: "vault-mcp-server"}, health)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestServeAdminRequiresTLS(t *testing.T) {
+	t.Setenv(client.AdminTLSCertFileEnv, "")
+	t.Setenv(client.AdminTLSKeyFileEnv, "")
+	s := New(Config{Version: "1.2.3", Logger: newLogger()})
+
+	t.Setenv(AdminAddressEnv, "")
+	assert.NoError(t, s.ServeAdmin(context.Background()), "the admin listener is optional")
+
+	t.Setenv(AdminAddressEnv, "10.0.0.1:9090")
+	assert.ErrorContains(t, s.ServeAdmin(context.Background()), "TLS is required")
+
+	t.Setenv(AdminAddressEnv, "9090")
+	assert.ErrorContains(t, s.ServeAdmin(context.Background()), "invalid "+AdminAddressEnv)
+}
@@ -0,0 +1,81 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package mcpserver
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/leader"
+)
+
+// backgroundTask is a subsystem that runs next to the transport, such as a cache warmer
+type backgroundTask struct {
+	name string
+	run  func(ctx context.Context)
+}
+
+// AddBackgroundTask registers a subsystem that runs in the background until its context is cancelled.
+// Tasks must be added before RunBackground is called.
+func (s *Server) AddBackgroundTask(name string, run func(ctx context.Context)) {
+	s.backgroundTasks = append(s.backgroundTasks, backgroundTask{name: name, run: run})
+}
+
+// RunBackground runs the background tasks until the context is cancelled. When MCP_LEADER_ELECTION is set,
+// the replicas of a deployment elect a leader through a Kubernetes Lease and only the leader runs the
+// tasks, which are stopped when it loses the lease. All replicas keep serving requests, and report the
+// telemetry of their own tool calls when it is opted in.
+func (s *Server) RunBackground(ctx context.Context) error {
+	if s.telemetry != nil {
+		go s.telemetry.Run(ctx)
+	}
+
+	config := leader.LoadConfigFromEnv()
+	if !config.Enabled {
+		s.runBackgroundTasks(ctx)
+		return nil
+	}
+
+	if config.Namespace == "" {
+		return fmt.Errorf("leader election requires %s outside of a Kubernetes pod", leader.LeaseNamespaceEnv)
+	}
+	if config.Identity == "" {
+		return fmt.Errorf("leader election requires %s or POD_NAME", leader.IdentityEnv)
+	}
+	leaseClient, err := leader.NewInClusterLeaseClient()
+	if err != nil {
+		return fmt.Errorf("leader election: %w", err)
+	}
+
+	elector := leader.NewElector(config, leaseClient, s.logger)
+	s.elector.Store(elector)
+	defer s.elector.Store(nil)
+
+	s.logger.WithField("lease", config.Namespace+"/"+config.LeaseName).Infof("Leader election started as %s", config.Identity)
+	elector.Run(ctx, s.runBackgroundTasks)
+	return nil
+}
+
+// IsLeader reports whether this replica runs the background tasks: it holds the lease, or leader election
+// is not running
+func (s *Server) IsLeader() bool {
+	elector := s.elector.Load()
+	return elector == nil || elector.IsLeader()
+}
+
+// runBackgroundTasks runs every background task until the context is cancelled and they return
+func (s *Server) runBackgroundTasks(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, task := range s.backgroundTasks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.logger.Infof("Starting background task %s", task.name)
+			task.run(ctx)
+			s.logger.Infof("Background task %s stopped", task.name)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,354 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package mcpserver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	// GRPCServiceName is the name of the gRPC service of the MCP server
+	GRPCServiceName = "hashicorp.vault.mcp.v1.MCP"
+	// GRPCClientCAEnv is the CA bundle that verifies the certificates of gRPC clients. When set, clients must
+	// present a certificate (mTLS).
+	GRPCClientCAEnv = "MCP_GRPC_CLIENT_CA_FILE"
+
+	// grpcConcurrentMessages bounds the messages of a session handled at the same time
+	grpcConcurrentMessages = 16
+)
+
+// grpcService is implemented by the handler of the MCP gRPC service
+type grpcService interface {
+	session(stream grpc.ServerStream) error
+}
+
+// grpcServiceDesc describes the MCP gRPC service without generated code. Its only method is a bidirectional
+// stream of JSON-RPC messages, each carried in a google.protobuf.BytesValue:
+//
+//	service MCP {
+//	  rpc Session(stream google.protobuf.BytesValue) returns (stream google.protobuf.BytesValue);
+//	}
+var grpcServiceDesc = grpc.ServiceDesc{
+	ServiceName: GRPCServiceName,
+	HandlerType: (*grpcService)(nil),
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName: "Session",
+			Handler: func(srv any, stream grpc.ServerStream) error {
+				return srv.(grpcService).session(stream)
+			},
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "vault_mcp.proto",
+}
+
+// grpcTransport serves the MCP server over gRPC, one session per stream
+type grpcTransport struct {
+	mcpServer *server.MCPServer
+	tenancy   *client.TenancyConfig
+	proxies   client.TrustedProxies
+	logger    *log.Logger
+}
+
+// grpcSession is the MCP session of a gRPC stream
+type grpcSession struct {
+	id            string
+	notifications chan mcp.JSONRPCNotification
+	initialized   atomic.Bool
+
+	mu                 sync.RWMutex
+	clientInfo         mcp.Implementation
+	clientCapabilities mcp.ClientCapabilities
+}
+
+func (s *grpcSession) SessionID() string { return s.id }
+func (s *grpcSession) Initialize()       { s.initialized.Store(true) }
+func (s *grpcSession) Initialized() bool { return s.initialized.Load() }
+
+func (s *grpcSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+func (s *grpcSession) GetClientInfo() mcp.Implementation {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.clientInfo
+}
+
+func (s *grpcSession) SetClientInfo(clientInfo mcp.Implementation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clientInfo = clientInfo
+}
+
+func (s *grpcSession) GetClientCapabilities() mcp.ClientCapabilities {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.clientCapabilities
+}
+
+func (s *grpcSession) SetClientCapabilities(clientCapabilities mcp.ClientCapabilities) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clientCapabilities = clientCapabilities
+}
+
+// session serves a stream as an MCP session. The Vault settings of the session are taken from the stream
+// metadata, with the same names as the HTTP headers (x-vault-token, x-vault-namespace, vault_addr), or from
+// the environment. With multi-tenancy, the stream is rejected unless its x-mcp-api-key or x-mcp-client-id
+// metadata matches a tenant profile allowed to use its namespace. Client IDs are only accepted from trusted
+// proxies. Messages are handled concurrently, so a
+// long tool call does not hold back the others.
+func (t *grpcTransport) session(stream grpc.ServerStream) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	header := func(name string) string {
+		if values := md.Get(name); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+	ctx = client.VaultContextFromHeaders(ctx, header)
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			ctx = client.WithClientIP(ctx, host)
+		}
+	}
+
+	if t.tenancy != nil {
+		var profile *client.TenantProfile
+		var err error
+		ctx, profile, err = client.TenantContextFromHeaders(ctx, t.tenancy, t.proxies, client.ClientIPFromContext(ctx), header)
+		if errors.Is(err, client.ErrUnknownTenant) {
+			t.logger.Warnf("Rejected gRPC stream from %s without a valid API key or client ID", client.ClientIPFromContext(ctx))
+			return status.Error(codes.Unauthenticated, "a valid x-mcp-api-key or x-mcp-client-id is required")
+		}
+		if err != nil {
+			t.logger.WithField("tenant", profile.Name).WithError(err).Warn("Rejected gRPC stream for a namespace the tenant may not use")
+			return status.Error(codes.PermissionDenied, err.Error())
+		}
+	}
+
+	session := &grpcSession{
+		id:            uuid.NewString(),
+		notifications: make(chan mcp.JSONRPCNotification, 100),
+	}
+	entry := t.logger.WithFields(log.Fields{"session_id": session.id, "client_ip": client.ClientIPFromContext(ctx)})
+	if subject := peerCertificateSubject(ctx); subject != "" {
+		entry = entry.WithField("client_certificate", subject)
+	}
+
+	if err := t.mcpServer.RegisterSession(ctx, session); err != nil {
+		return fmt.Errorf("failed to register session: %w", err)
+	}
+	defer t.mcpServer.UnregisterSession(context.WithoutCancel(ctx), session.id)
+	ctx = t.mcpServer.WithContext(ctx, session)
+	entry.Info("gRPC session started")
+
+	var sendMu sync.Mutex
+	send := func(message any) error {
+		data, err := json.Marshal(message)
+		if err != nil {
+			return err
+		}
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		return stream.SendMsg(wrapperspb.Bytes(data))
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	defer cancel()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case notification := <-session.notifications:
+				if err := send(notification); err != nil {
+					entry.WithError(err).Debug("Failed to send notification")
+				}
+			}
+		}
+	}()
+
+	slots := make(chan struct{}, grpcConcurrentMessages)
+	for {
+		in := &wrapperspb.BytesValue{}
+		if err := stream.RecvMsg(in); err != nil {
+			if !errors.Is(err, io.EOF) {
+				entry = entry.WithError(err)
+			}
+			entry.Info("gRPC session ended")
+			return nil
+		}
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil
+		}
+		wg.Add(1)
+		go func(message []byte) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			response := t.mcpServer.HandleMessage(ctx, message)
+			if response == nil {
+				return
+			}
+			if err := send(response); err != nil {
+				entry.WithError(err).Debug("Failed to send response")
+			}
+		}(in.GetValue())
+	}
+}
+
+// peerCertificateSubject returns the subject of the certificate of an mTLS client
+func peerCertificateSubject(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.PeerCertificates) == 0 {
+		return ""
+	}
+	return info.State.PeerCertificates[0].Subject.String()
+}
+
+// GRPCServer returns a gRPC server with the MCP service and the standard health service, for serving on
+// listeners of another program. TLS is configured from MCP_TLS_CERT_FILE and MCP_TLS_KEY_FILE, and client
+// certificates are required when MCP_GRPC_CLIENT_CA_FILE is set. TLS is required unless the server binds
+// to localhost. Streams are matched to the tenant profiles of MCP_TENANCY_CONFIG_FILE.
+func (s *Server) GRPCServer(host string) (*grpc.Server, error) {
+	tlsConfig, err := client.GetTLSConfigFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("TLS configuration error: %w", err)
+	}
+
+	var opts []grpc.ServerOption
+	switch {
+	case tlsConfig != nil:
+		config, err := grpcTLSConfig(tlsConfig)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(config)))
+		s.logger.Infof("TLS enabled with certificate: %s", tlsConfig.CertFile)
+	case os.Getenv(GRPCClientCAEnv) != "":
+		return nil, fmt.Errorf("%s requires TLS. Set MCP_TLS_CERT_FILE and MCP_TLS_KEY_FILE environment variables", GRPCClientCAEnv)
+	case !client.IsLocalHost(host):
+		return nil, fmt.Errorf("TLS is required for non-localhost binding (%s). Set MCP_TLS_CERT_FILE and MCP_TLS_KEY_FILE environment variables", host)
+	default:
+		s.logger.Warnf("TLS is disabled on gRPC server; this is not recommended for production")
+	}
+
+	tenancyConfig, err := client.LoadTenancyConfigFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("tenancy configuration error: %w", err)
+	}
+	if tenancyConfig != nil {
+		s.logger.Infof("Multi-tenancy enabled with %d profiles", len(tenancyConfig.Profiles))
+	}
+	trustedProxies, err := client.LoadTrustedProxiesFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("trusted proxies configuration error: %w", err)
+	}
+
+	grpcServer := grpc.NewServer(opts...)
+	grpcServer.RegisterService(&grpcServiceDesc, &grpcTransport{mcpServer: s.MCPServer, tenancy: tenancyConfig, proxies: trustedProxies, logger: s.logger})
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(GRPCServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	return grpcServer, nil
+}
+
+// grpcTLSConfig loads the certificate of the server and, when MCP_GRPC_CLIENT_CA_FILE is set, the CAs that
+// verify the certificates of clients
+func grpcTLSConfig(tlsConfig *client.TLSConfig) (*tls.Config, error) {
+	config := tlsConfig.Config.Clone()
+	certificate, err := tls.LoadX509KeyPair(tlsConfig.CertFile, tlsConfig.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TLS certificate/key pair: %w", err)
+	}
+	config.Certificates = []tls.Certificate{certificate}
+
+	if caFile := os.Getenv(GRPCClientCAEnv); caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read %s %s: %w", GRPCClientCAEnv, caFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%s %s holds no PEM certificates", GRPCClientCAEnv, caFile)
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
+
+// ListenAndServeGRPC serves the MCP server with the experimental gRPC transport until the context is cancelled
+func (s *Server) ListenAndServeGRPC(ctx context.Context, host string, port string) error {
+	grpcServer, err := s.GRPCServer(host)
+	if err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(host, port)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("gRPC server error: %w", err)
+	}
+
+	errC := make(chan error, 1)
+	go func() {
+		s.logger.Infof("Starting gRPC server on %s", listener.Addr())
+		errC <- grpcServer.Serve(listener)
+	}()
+
+	select {
+	case <-ctx.Done():
+		s.logger.Infof("Shutting down gRPC server...")
+		grpcServer.GracefulStop()
+	case err := <-errC:
+		if err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			return fmt.Errorf("gRPC server error: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// dialGRPCServer serves a new MCP server with the gRPC transport on an in-memory listener and connects to it
+func dialGRPCServer(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+	s := New(Config{Version: "1.2.3", Logger: newLogger()})
+	grpcServer, err := s.GRPCServer("localhost")
+	require.NoError(t, err)
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func TestGRPCTransport(t *testing.T) {
+	t.Setenv("VAULT_ADDR", "")
+	t.Setenv("VAULT_TOKEN", "")
+
+	tokens := make(chan string, 1)
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/sys/mounts" {
+			select {
+			case tokens <- r.Header.Get("X-Vault-Token"):
+			default:
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"secret/": map[string]interface{}{"type": "kv", "options": map[string]interface{}{"version": "2"}},
+			}})
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer mockVault.Close()
+
+	conn := dialGRPCServer(t)
+
+	health, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{Service: GRPCServiceName})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, health.Status)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "vault_addr", mockVault.URL, "x-vault-token", "grpc-token")
+	stream, err := conn.NewStream(ctx, &grpcServiceDesc.Streams[0], "/"+GRPCServiceName+"/Session")
+	require.NoError(t, err)
+
+	call := func(message string) map[string]interface{} {
+		require.NoError(t, stream.SendMsg(wrapperspb.Bytes([]byte(message))))
+		out := &wrapperspb.BytesValue{}
+		require.NoError(t, stream.RecvMsg(out))
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(out.GetValue(), &response))
+		return response
+	}
+
+	response := call(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test","version":"1.0"}}}`)
+	require.Contains(t, response, "result", response)
+	assert.Equal(t, "vault-mcp-server", response["result"].(map[string]interface{})["serverInfo"].(map[string]interface{})["name"])
+	require.NoError(t, stream.SendMsg(wrapperspb.Bytes([]byte(`{"jsonrpc":"2.0","method":"notifications/initialized"}`))))
+
+	response = call(`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`)
+	require.Contains(t, response, "result", response)
+	assert.NotEmpty(t, response["result"].(map[string]interface{})["tools"])
+
+	// The Vault client of the session uses the address and token of the stream metadata
+	response = call(`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"list_mounts","arguments":{}}}`)
+	require.Contains(t, response, "result", response)
+	assert.NotEqual(t, true, response["result"].(map[string]interface{})["isError"], response)
+	assert.Equal(t, "grpc-token", <-tokens)
+
+	require.NoError(t, stream.CloseSend())
+}
+
+func TestGRPCServerRequiresTLS(t *testing.T) {
+	t.Setenv("MCP_TLS_CERT_FILE", "")
+	t.Setenv("MCP_TLS_KEY_FILE", "")
+
+	s := New(Config{Version: "1.2.3", Logger: newLogger()})
+	_, err := s.GRPCServer("10.0.0.1")
+	assert.ErrorContains(t, err, "TLS is required")
+
+	t.Setenv(GRPCClientCAEnv, "/tmp/ca.pem")
+	_, err = s.GRPCServer("localhost")
+	assert.ErrorContains(t, err, "requires TLS")
+}
+
+func TestGRPCTransportTenancy(t *testing.T) {
+	t.Setenv("VAULT_NAMESPACE", "")
+	configFile := filepath.Join(t.TempDir(), "tenancy.json")
+	require.NoError(t, os.WriteFile(configFile, []byte(`{"profiles": [
+		{"name": "team-a", "api_keys": ["key-a"], "client_ids": ["team-a"], "allowed_tools": ["list_*"], "allowed_namespaces": ["team-a/"]}
+	]}`), 0o600))
+	t.Setenv("MCP_TENANCY_CONFIG_FILE", configFile)
+	conn := dialGRPCServer(t)
+
+	open := func(kv ...string) grpc.ClientStream {
+		stream, err := conn.NewStream(metadata.AppendToOutgoingContext(context.Background(), kv...), &grpcServiceDesc.Streams[0], "/"+GRPCServiceName+"/Session")
+		require.NoError(t, err)
+		return stream
+	}
+
+	for name, tc := range map[string]struct {
+		metadata []string
+		code     codes.Code
+	}{
+		"no credentials":      {nil, codes.Unauthenticated},
+		"unknown API key":     {[]string{"x-mcp-api-key", "key-b"}, codes.Unauthenticated},
+		"untrusted client ID": {[]string{"x-mcp-client-id", "team-a"}, codes.Unauthenticated},
+		"forbidden namespace": {[]string{"x-mcp-api-key", "key-a", "x-vault-namespace", "team-b/"}, codes.PermissionDenied},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := open(tc.metadata...).RecvMsg(&wrapperspb.BytesValue{})
+			assert.Equal(t, tc.code, status.Code(err), err)
+		})
+	}
+
+	// The tools of a stream are limited to the tools of its tenant profile
+	stream := open("x-mcp-api-key", "key-a", "x-vault-namespace", "team-a/")
+	require.NoError(t, stream.SendMsg(wrapperspb.Bytes([]byte(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test","version":"1.0"}}}`))))
+	require.NoError(t, stream.RecvMsg(&wrapperspb.BytesValue{}))
+	require.NoError(t, stream.SendMsg(wrapperspb.Bytes([]byte(`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`))))
+	out := &wrapperspb.BytesValue{}
+	require.NoError(t, stream.RecvMsg(out))
+
+	var response struct {
+		Result struct {
+			Tools []struct {
+				Name string `json:"name"`
+			} `json:"tools"`
+		} `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(out.GetValue(), &response))
+	require.NotEmpty(t, response.Result.Tools)
+	for _, tool := range response.Result.Tools {
+		assert.True(t, strings.HasPrefix(tool.Name, "list_"), tool.Name)
+	}
+	require.NoError(t, stream.CloseSend())
+}
@@ -0,0 +1,102 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package mcpserver
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ToolManifest returns the tools the server lists to a client of the tenant profile, sorted by name. All
+// registered tools are returned when the profile is nil.
+func (s *Server) ToolManifest(profile *client.TenantProfile) []mcp.Tool {
+	registered := s.MCPServer.ListTools()
+	tools := make([]mcp.Tool, 0, len(registered))
+	for _, tool := range registered {
+		if profile != nil && !profile.AllowsTool(tool.Tool.Name, isReadOnly(tool.Tool)) {
+			continue
+		}
+		tools = append(tools, tool.Tool)
+	}
+	sort.Slice(tools, func(i, j int) bool { return tools[i].Name < tools[j].Name })
+	return tools
+}
+
+// WriteToolManifestMarkdown writes the tools as a Markdown document with their annotations and parameters
+func WriteToolManifestMarkdown(w io.Writer, tools []mcp.Tool) error {
+	var b strings.Builder
+	b.WriteString("# Vault MCP Server Tools\n\n")
+	b.WriteString("| Tool | Read-only | Destructive | Idempotent | Open world |\n")
+	b.WriteString("|------|-----------|-------------|------------|------------|\n")
+	for _, tool := range tools {
+		annotations := tool.Annotations
+		// The destructive hint only applies to tools that are not read-only
+		destructive := "no"
+		if !isReadOnly(tool) {
+			destructive = hint(annotations.DestructiveHint, true)
+		}
+		fmt.Fprintf(&b, "| [%s](#%s) | %s | %s | %s | %s |\n", tool.Name, tool.Name,
+			hint(annotations.ReadOnlyHint, false), destructive,
+			hint(annotations.IdempotentHint, false), hint(annotations.OpenWorldHint, true))
+	}
+
+	for _, tool := range tools {
+		fmt.Fprintf(&b, "\n## %s\n\n%s\n", tool.Name, strings.TrimSpace(tool.Description))
+
+		properties := tool.InputSchema.Properties
+		if len(properties) == 0 {
+			b.WriteString("\nNo parameters.\n")
+			continue
+		}
+		names := make([]string, 0, len(properties))
+		for name := range properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		b.WriteString("\n| Parameter | Type | Required | Description |\n")
+		b.WriteString("|-----------|------|----------|-------------|\n")
+		for _, name := range names {
+			property, _ := properties[name].(map[string]any)
+			propertyType, _ := property["type"].(string)
+			description, _ := property["description"].(string)
+			required := "no"
+			if slices.Contains(tool.InputSchema.Required, name) {
+				required = "yes"
+			}
+			fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n", name, propertyType, required, markdownCell(description))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// isReadOnly reports whether the tool is annotated as read-only
+func isReadOnly(tool mcp.Tool) bool {
+	return tool.Annotations.ReadOnlyHint != nil && *tool.Annotations.ReadOnlyHint
+}
+
+// hint formats an annotation, which takes its default from the MCP specification when it is not set
+func hint(value *bool, defaultValue bool) string {
+	if value != nil {
+		defaultValue = *value
+	}
+	if defaultValue {
+		return "yes"
+	}
+	return "no"
+}
+
+// markdownCell escapes text for a Markdown table cell
+func markdownCell(text string) string {
+	text = strings.ReplaceAll(text, "|", "\\|")
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,66 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package mcpserver
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolManifest(t *testing.T) {
+	s := New(Config{Version: "1.2.3", Logger: newLogger()})
+
+	t.Run("all tools sorted by name", func(t *testing.T) {
+		tools := s.ToolManifest(nil)
+		assert.Len(t, tools, len(s.MCPServer.ListTools()))
+		assert.True(t, sort.SliceIsSorted(tools, func(i, j int) bool { return tools[i].Name < tools[j].Name }))
+	})
+
+	t.Run("tenant profiles restrict the tools", func(t *testing.T) {
+		tools := s.ToolManifest(&client.TenantProfile{AllowedTools: []string{"read_*", "write_secret"}})
+		names := make([]string, 0, len(tools))
+		for _, tool := range tools {
+			names = append(names, tool.Name)
+		}
+		assert.Contains(t, names, "read_secret")
+		assert.Contains(t, names, "write_secret")
+		assert.NotContains(t, names, "list_mounts")
+	})
+
+	t.Run("read-only profiles only list read-only tools", func(t *testing.T) {
+		tools := s.ToolManifest(&client.TenantProfile{ReadOnly: true})
+		require.NotEmpty(t, tools)
+		for _, tool := range tools {
+			assert.True(t, isReadOnly(tool), tool.Name)
+		}
+	})
+}
+
+func TestWriteToolManifestMarkdown(t *testing.T) {
+	tools := []mcp.Tool{
+		mcp.NewTool("read_thing",
+			mcp.WithDescription("Reads a thing"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithString("path", mcp.Required(), mcp.Description("Path of the | thing")),
+			mcp.WithNumber("limit", mcp.Description("Maximum number of things")),
+		),
+		mcp.NewTool("delete_thing", mcp.WithDescription("Deletes a thing")),
+	}
+
+	var out strings.Builder
+	require.NoError(t, WriteToolManifestMarkdown(&out, tools))
+	markdown := out.String()
+
+	assert.Contains(t, markdown, "| [read_thing](#read_thing) | yes | no | no | yes |")
+	assert.Contains(t, markdown, "| [delete_thing](#delete_thing) | no | yes | no | yes |")
+	assert.Contains(t, markdown, "| `path` | string | yes | Path of the \\| thing |")
+	assert.Contains(t, markdown, "| `limit` | number | no | Maximum number of things |")
+	assert.Contains(t, markdown, "## delete_thing\n\nDeletes a thing\n\nNo parameters.")
+}
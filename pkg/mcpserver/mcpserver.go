@@ -0,0 +1,526 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Package mcpserver assembles the Vault MCP server so that other Go programs can embed it
+// instead of running the vault-mcp-server binary.
+package mcpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	stdlog "log"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/i18n"
+	"github.com/hashicorp/vault-mcp-server/pkg/leader"
+	"github.com/hashicorp/vault-mcp-server/pkg/resources"
+	"github.com/hashicorp/vault-mcp-server/pkg/scheduler"
+	"github.com/hashicorp/vault-mcp-server/pkg/telemetry"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault-mcp-server/version"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// defaultWriteTimeout bounds the time to write an HTTP response
+	defaultWriteTimeout = 30 * time.Second
+	// writeTimeoutMargin leaves time to write the error of a tool call that timed out
+	writeTimeoutMargin = 5 * time.Second
+)
+
+// Config configures a Vault MCP server. Everything that is not set here is read from the
+// same environment variables as the vault-mcp-server binary.
+type Config struct {
+	Version       string                  // Version reported to clients, the version of this module by default
+	Logger        *log.Logger             // Logger for the server, the standard logrus logger by default
+	RateLimit     *client.RateLimitConfig // Rate limits of tool calls, loaded from MCP_RATE_LIMIT_* by default
+	ServerOptions []server.ServerOption   // Additional options for the MCP server, applied after the defaults
+}
+
+// Server is a Vault MCP server with its tools, resources and middleware
+type Server struct {
+	MCPServer   *server.MCPServer
+	RateLimiter *client.RateLimitMiddleware
+
+	logger          *log.Logger
+	backgroundTasks []backgroundTask
+	elector         atomic.Pointer[leader.Elector] // Set while leader election runs
+	telemetry       *telemetry.Collector           // Set when telemetry is opted in
+}
+
+// New creates a Vault MCP server with every tool and resource registered
+func New(cfg Config) *Server {
+	if cfg.Version == "" {
+		cfg.Version = version.Version
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = log.StandardLogger()
+	}
+	rateLimitConfig := client.LoadRateLimitConfigFromEnv()
+	if cfg.RateLimit != nil {
+		rateLimitConfig = *cfg.RateLimit
+	}
+
+	if limit, ok := utils.LoadMaxResultBytesFromEnv(); ok {
+		utils.MaxResultBytes = limit
+	}
+	if header, ok := client.LoadAuditHeaderFromEnv(); ok {
+		client.SetAuditHeader(header)
+	}
+	if headers, ok := client.LoadRequestHeadersFromEnv(); ok {
+		client.SetRequestHeaders(headers)
+	}
+	if enabled, ok := client.LoadReadYourWritesFromEnv(); ok {
+		client.SetReadYourWrites(enabled)
+	}
+	if enabled, ok := client.LoadTranscriptsFromEnv(); ok {
+		client.SetTranscripts(enabled)
+	}
+	if ttl, ok := client.LoadCapabilityCacheTTLFromEnv(); ok {
+		client.SetCapabilityCacheTTL(ttl)
+	}
+	if faults, ok := client.LoadFaultConfigFromEnv(); ok {
+		client.SetFaultInjection(faults)
+	}
+	if locale, ok := i18n.LoadLocaleFromEnv(); ok {
+		i18n.SetDefaultLocale(locale)
+	}
+
+	rateLimiter := client.NewRateLimitMiddleware(rateLimitConfig, cfg.Logger)
+	hcServer := NewMCPServer(cfg.Version, cfg.Logger, rateLimiter, cfg.ServerOptions...)
+	tools.InitTools(hcServer, cfg.Logger)
+	resources.InitResources(hcServer, cfg.Logger)
+
+	s := &Server{
+		MCPServer:   hcServer,
+		RateLimiter: rateLimiter,
+		logger:      cfg.Logger,
+	}
+	telemetryConfig := telemetry.LoadConfigFromEnv()
+	if collector := telemetry.NewCollector(telemetryConfig, cfg.Version, cfg.Logger); collector != nil {
+		telemetry.SetCollector(collector)
+		s.telemetry = collector
+		cfg.Logger.Infof("Telemetry enabled, anonymous tool usage is reported every %s", telemetryConfig.Interval)
+	}
+	s.initScheduler()
+	return s
+}
+
+// initScheduler publishes the results of the jobs scheduled in MCP_SCHEDULE_FILE and runs them in the background.
+// An invalid schedule is logged and no job runs, the server keeps serving requests.
+func (s *Server) initScheduler() {
+	config, err := scheduler.LoadConfigFromEnv()
+	if err == nil && config != nil {
+		var jobs *scheduler.Scheduler
+		if jobs, err = scheduler.New(config, s.MCPServer, s.logger); err == nil {
+			s.MCPServer.AddResources(jobs.Resources()...)
+			s.AddBackgroundTask("scheduler", jobs.Run)
+			s.logger.Infof("Scheduled %d jobs", len(config.Jobs))
+		}
+	}
+	if err != nil {
+		s.logger.WithError(err).Errorf("Scheduled jobs are disabled, fix %s", scheduler.ScheduleFileEnv)
+	}
+}
+
+// NewMCPServer creates the MCP server with the tool middleware and session hooks, without any tools
+func NewMCPServer(version string, logger *log.Logger, rateLimitMiddleware *client.RateLimitMiddleware, opts ...server.ServerOption) *server.MCPServer {
+	// Create session budget middleware with environment-based configuration
+	budgetConfig := client.LoadBudgetConfigFromEnv()
+	budgetMiddleware := client.NewBudgetMiddleware(budgetConfig, logger)
+
+	// Create circuit breaker middleware with environment-based configuration
+	circuitBreakerConfig := client.LoadCircuitBreakerConfigFromEnv()
+	circuitBreakerMiddleware := client.NewCircuitBreakerMiddleware(circuitBreakerConfig, logger)
+
+	// Create tool timeout middleware with environment-based configuration
+	toolTimeoutMiddleware := client.NewToolTimeoutMiddleware(client.LoadToolTimeoutConfigFromEnv(), logger)
+
+	// Create approval middleware with environment-based configuration
+	approvalMiddleware := client.NewApprovalMiddleware(client.LoadApprovalConfigFromEnv(), logger)
+
+	// Create path lock middleware with environment-based configuration
+	pathLockMiddleware := client.NewPathLockMiddleware(client.LoadPathLockConfigFromEnv(), logger)
+
+	// Create read deduplication middleware with environment-based configuration
+	readDedupMiddleware := client.NewReadDedupMiddleware(client.LoadReadDedupFromEnv(), logger)
+
+	// Load the token scopes of the tools. An invalid configuration fails closed, every Vault call is refused
+	// rather than sent with the token of the session.
+	tokenExchangeConfig, err := client.LoadTokenExchangeConfigFromEnv()
+	if err != nil {
+		logger.WithError(err).Errorf("Vault calls are refused until %s is fixed", client.TokenExchangeConfigFileEnv)
+		tokenExchangeConfig = &client.TokenExchangeConfig{}
+	} else if tokenExchangeConfig != nil {
+		logger.Infof("Token exchange enabled with %d scopes", len(tokenExchangeConfig.Scopes))
+	}
+
+	// Add default options
+	defaultOpts := []server.ServerOption{
+		server.WithToolCapabilities(true),
+		server.WithResourceCapabilities(true, true),
+		server.WithElicitation(),
+		server.WithToolHandlerMiddleware(client.RequestIDMiddleware(logger)),
+		server.WithToolHandlerMiddleware(client.TranscriptMiddleware()),
+		server.WithToolHandlerMiddleware(telemetry.Middleware()),
+		server.WithToolHandlerMiddleware(i18n.Middleware()),
+		server.WithToolHandlerMiddleware(client.SecretArgumentMiddleware(client.LoadSecretArgumentCheckFromEnv(), logger)),
+		server.WithToolHandlerMiddleware(rateLimitMiddleware.Middleware()),
+		server.WithToolHandlerMiddleware(budgetMiddleware.Middleware()),
+		server.WithToolHandlerMiddleware(circuitBreakerMiddleware.Middleware()),
+		server.WithToolHandlerMiddleware(approvalMiddleware.Middleware()),
+		server.WithToolHandlerMiddleware(toolTimeoutMiddleware.Middleware()),
+		server.WithToolHandlerMiddleware(client.NamespaceMiddleware(logger)),
+		server.WithToolHandlerMiddleware(readDedupMiddleware.Middleware()),
+		server.WithToolHandlerMiddleware(client.ReadRoutingMiddleware()),
+		server.WithToolHandlerMiddleware(client.TokenExchangeMiddleware(tokenExchangeConfig, logger)),
+		server.WithToolHandlerMiddleware(client.TenancyMiddleware(logger)),
+		server.WithToolHandlerMiddleware(pathLockMiddleware.Middleware()),
+		server.WithToolFilter(client.TenancyToolFilter),
+		server.WithResourceHandlerMiddleware(budgetMiddleware.ResourceMiddleware()),
+		server.WithResourceHandlerMiddleware(client.TenancyResourceMiddleware(logger)),
+	}
+	opts = append(defaultOpts, opts...)
+
+	// Create hooks for session management
+	hooks := &server.Hooks{}
+	hooks.AddOnRegisterSession(func(ctx context.Context, session server.ClientSession) {
+		client.NewSessionHandler(ctx, session, logger)
+	})
+	hooks.AddOnUnregisterSession(func(ctx context.Context, session server.ClientSession) {
+		client.EndSessionHandler(ctx, session, logger)
+	})
+	// Calls of legacy tool names are forwarded to the snake_case tools
+	hooks.AddBeforeCallTool(tools.ToolAliasHook(logger))
+	// Resources that the tenant profile may not read are hidden like its tools
+	hooks.AddAfterListResources(client.TenancyResourceFilter)
+	hooks.AddAfterListResourceTemplates(client.TenancyResourceTemplateFilter)
+
+	// Add hooks to options
+	opts = append(opts, server.WithHooks(hooks))
+
+	// Create a new MCP server
+	s := server.NewMCPServer(
+		"vault-mcp-server",
+		version,
+		opts...,
+	)
+
+	// Analysis tools can ask the model of the client to draft remediation plans
+	s.EnableSampling()
+	return s
+}
+
+// ServeStdio serves the MCP server on standard input and output until the context is cancelled
+func (s *Server) ServeStdio(ctx context.Context) error {
+	return s.Serve(ctx, os.Stdin, os.Stdout)
+}
+
+// Serve serves the MCP server over the given streams using JSON-RPC messages until the context is cancelled
+func (s *Server) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	stdioServer := server.NewStdioServer(s.MCPServer)
+	stdLogger := stdlog.New(s.logger.Writer(), "stdioserver", 0)
+	stdioServer.SetErrorLogger(stdLogger)
+
+	// Start listening for messages
+	errC := make(chan error, 1)
+	go func() {
+		errC <- stdioServer.Listen(ctx, in, out)
+	}()
+
+	// Wait for shutdown signal
+	select {
+	case <-ctx.Done():
+		s.logger.Infof("shutting down server...")
+	case err := <-errC:
+		if err != nil {
+			return fmt.Errorf("error running server: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// HTTPHandler returns the StreamableHTTP transport of the MCP server wrapped in the HTTP middleware stack,
+// for mounting at endpointPath in the mux of another program. The session store, multi-tenancy, trusted
+// proxies and CORS are configured from the environment.
+func (s *Server) HTTPHandler(endpointPath string, opts ...server.StreamableHTTPOption) (http.Handler, error) {
+	logger := s.logger
+
+	// Ensure endpoint path starts with /
+	endpointPath = path.Join("/", endpointPath)
+	// Create StreamableHTTP server which implements the new streamable-http transport
+	// This is the modern MCP transport that supports both direct HTTP responses and SSE streams
+	opts = append([]server.StreamableHTTPOption{
+		server.WithEndpointPath(endpointPath),
+		server.WithLogger(logger),
+	}, opts...)
+
+	// Load the session store, which can be shared by several instances behind a load balancer
+	sessionStore, err := client.LoadSessionStoreFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("session store configuration error: %w", err)
+	}
+	client.SetSessionStore(sessionStore)
+
+	// Load multi-tenancy configuration
+	tenancyConfig, err := client.LoadTenancyConfigFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("tenancy configuration error: %w", err)
+	}
+	if tenancyConfig != nil {
+		logger.Infof("Multi-tenancy enabled with %d profiles", len(tenancyConfig.Profiles))
+	}
+
+	// Load the reverse proxies whose forwarding headers identify the real client
+	trustedProxies, err := client.LoadTrustedProxiesFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("trusted proxies configuration error: %w", err)
+	}
+	if len(trustedProxies) > 0 {
+		logger.Infof("Trusting forwarding headers from %d proxy addresses", len(trustedProxies))
+	}
+
+	// Log the endpoint path being used
+	logger.Infof("Using endpoint path: %s", endpointPath)
+
+	baseStreamableServer := server.NewStreamableHTTPServer(s.MCPServer, opts...)
+
+	// Load CORS configuration
+	corsConfig := client.LoadCORSConfigFromEnv()
+
+	// Log CORS configuration
+	logger.Infof("CORS Mode: %s", corsConfig.Mode)
+	if len(corsConfig.AllowedOrigins) > 0 {
+		logger.Infof("Allowed Origins: %s", strings.Join(corsConfig.AllowedOrigins, ", "))
+	} else if corsConfig.Mode == "strict" {
+		logger.Warnf("No allowed origins configured in strict mode. All cross-origin requests will be rejected.")
+	} else if corsConfig.Mode == "development" {
+		logger.Infof("Development mode: localhost origins are automatically allowed")
+	} else if corsConfig.Mode == "disabled" {
+		logger.Warnf("CORS validation is disabled. This is not recommended for production.")
+	}
+	if corsConfig.AllowCredentials {
+		if corsConfig.Mode == "disabled" {
+			logger.Warnf("MCP_CORS_ALLOW_CREDENTIALS is ignored while CORS validation is disabled")
+		} else {
+			logger.Infof("Credentialed cross-origin requests are allowed")
+		}
+	}
+
+	// Create a security wrapper around the streamable server
+	streamableServer := client.NewSecurityHandler(baseStreamableServer, corsConfig, client.MCPEndpointMethods, logger)
+
+	// Apply middleware
+	streamableServer = client.VaultContextMiddleware(logger)(streamableServer)
+	streamableServer = i18n.HTTPMiddleware()(streamableServer)
+	streamableServer = client.TenancyHTTPMiddleware(tenancyConfig, trustedProxies, logger)(streamableServer)
+	streamableServer = client.RateLimitHeadersMiddleware(s.RateLimiter)(streamableServer)
+	streamableServer = client.LoggingMiddleware(logger)(streamableServer)
+	streamableServer = client.RequestIDHTTPMiddleware()(streamableServer)
+	streamableServer = client.ClientIPMiddleware(trustedProxies)(streamableServer)
+
+	return streamableServer, nil
+}
+
+// ListenAndServe serves the MCP server with the StreamableHTTP transport and a /health endpoint until the
+// context is cancelled. The /health endpoint is served by ServeAdmin instead when MCP_ADMIN_ADDR is set. TLS is configured from MCP_TLS_CERT_FILE and MCP_TLS_KEY_FILE and is required
+// unless the server binds to localhost.
+func (s *Server) ListenAndServe(ctx context.Context, host string, port string, endpointPath string) error {
+	httpServer, err := s.newHTTPServer(host, endpointPath)
+	if err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(host, port)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("StreamableHTTP server error: %w", err)
+	}
+
+	return s.serveHTTP(ctx, httpServer, listener, endpointPath)
+}
+
+// ServeListener is like ListenAndServe, but accepts connections on an existing listener
+func (s *Server) ServeListener(ctx context.Context, listener net.Listener, endpointPath string) error {
+	host, _, err := net.SplitHostPort(listener.Addr().String())
+	if err != nil {
+		return err
+	}
+
+	httpServer, err := s.newHTTPServer(host, endpointPath)
+	if err != nil {
+		_ = listener.Close()
+		return err
+	}
+
+	return s.serveHTTP(ctx, httpServer, listener, endpointPath)
+}
+
+// newHTTPServer creates the HTTP server for the StreamableHTTP transport and, without an admin listener, the
+// /health endpoint
+func (s *Server) newHTTPServer(host string, endpointPath string) (*http.Server, error) {
+	logger := s.logger
+
+	// Ensure endpoint path starts with /
+	endpointPath = path.Join("/", endpointPath)
+
+	// Load TLS configuration
+	var opts []server.StreamableHTTPOption
+	tlsConfig, err := client.GetTLSConfigFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("TLS configuration error: %w", err)
+	}
+	if tlsConfig != nil {
+		opts = append(opts, server.WithTLSCert(tlsConfig.CertFile, tlsConfig.KeyFile))
+	}
+
+	streamableServer, err := s.HTTPHandler(endpointPath, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+
+	// Handle the /mcp endpoint with the streamable server (with security wrapper)
+	mux.Handle(endpointPath, streamableServer)
+	mux.Handle(endpointPath+"/", streamableServer)
+
+	// Add health check endpoint, unless the operational endpoints are served on the admin listener
+	if adminAddress() == "" {
+		mux.HandleFunc("/health", s.healthHandler("streamable-http", endpointPath))
+	}
+
+	httpServer := &http.Server{
+		Handler:           mux,
+		ReadTimeout:       30 * time.Second,
+		ReadHeaderTimeout: 30 * time.Second,
+		WriteTimeout:      writeTimeout(),
+		IdleTimeout:       60 * time.Minute, // Set to 60 minutes to support long-lived connections
+	}
+
+	if tlsConfig != nil {
+		httpServer.TLSConfig = tlsConfig.Config
+		logger.Infof("TLS enabled with certificate: %s", tlsConfig.CertFile)
+	} else {
+		if !client.IsLocalHost(host) {
+			return nil, fmt.Errorf("TLS is required for non-localhost binding (%s). Set MCP_TLS_CERT_FILE and MCP_TLS_KEY_FILE environment variables", host)
+		}
+		logger.Warnf("TLS is disabled on StreamableHTTP server; this is not recommended for production")
+	}
+
+	return httpServer, nil
+}
+
+// ServeApprovals serves the approval endpoint on MCP_APPROVAL_ADDR until the context is cancelled. The
+// endpoint also serves the session transcripts for compliance review. It returns immediately when no tool
+// requires approval and transcripts are not recorded. The endpoint requires MCP_APPROVAL_TOKEN, and TLS
+// unless it binds to localhost.
+func (s *Server) ServeApprovals(ctx context.Context) error {
+	logger := s.logger
+
+	config := client.LoadApprovalConfigFromEnv()
+	if !config.Enabled() && !client.TranscriptsEnabled() {
+		return nil
+	}
+	if config.Token == "" {
+		if !config.Enabled() {
+			logger.Warnf("Session transcripts are only readable by their sessions, set %s to serve them to reviewers", client.ApprovalTokenEnv)
+			return nil
+		}
+		return fmt.Errorf("%s is required when %s is set", client.ApprovalTokenEnv, client.ApprovalRequiredEnv)
+	}
+
+	host, _, err := net.SplitHostPort(config.Address)
+	if err != nil {
+		return fmt.Errorf("invalid %s '%s': %w", client.ApprovalAddressEnv, config.Address, err)
+	}
+	tlsConfig, err := client.GetTLSConfigFromEnv()
+	if err != nil {
+		return fmt.Errorf("TLS configuration error: %w", err)
+	}
+	if tlsConfig == nil && !client.IsLocalHost(host) {
+		return fmt.Errorf("TLS is required for non-localhost binding of the approval endpoint (%s). Set MCP_TLS_CERT_FILE and MCP_TLS_KEY_FILE environment variables", host)
+	}
+
+	httpServer := &http.Server{
+		Addr:              config.Address,
+		Handler:           client.ApprovalHandler(config.Token, logger),
+		ReadTimeout:       30 * time.Second,
+		ReadHeaderTimeout: 30 * time.Second,
+		WriteTimeout:      writeTimeout(), // Approved tool calls run before the response
+	}
+
+	errC := make(chan error, 1)
+	go func() {
+		logger.Infof("Starting approval endpoint on %s", config.Address)
+		if tlsConfig != nil {
+			httpServer.TLSConfig = tlsConfig.Config
+			errC <- httpServer.ListenAndServeTLS(tlsConfig.CertFile, tlsConfig.KeyFile)
+			return
+		}
+		errC <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case <-ctx.Done():
+		logger.Infof("Shutting down approval endpoint...")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return httpServer.Shutdown(shutdownCtx)
+	case err := <-errC:
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("approval endpoint error: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// writeTimeout returns the write timeout of HTTP servers running tool calls. Tool calls are bounded by their
+// own timeouts, so the response must not be cut off before the longest of them expires.
+func writeTimeout() time.Duration {
+	writeTimeout := defaultWriteTimeout
+	if longest := client.LoadToolTimeoutConfigFromEnv().Longest() + writeTimeoutMargin; longest > writeTimeout {
+		writeTimeout = longest
+	}
+	return writeTimeout
+}
+
+// serveHTTP serves HTTP requests on the listener until the context is cancelled
+func (s *Server) serveHTTP(ctx context.Context, httpServer *http.Server, listener net.Listener, endpointPath string) error {
+	logger := s.logger
+
+	// Start server in goroutine
+	errC := make(chan error, 1)
+	go func() {
+		logger.Infof("Starting StreamableHTTP server on %s%s", listener.Addr(), path.Join("/", endpointPath))
+		errC <- httpServer.Serve(listener)
+	}()
+
+	// Wait for shutdown signal
+	select {
+	case <-ctx.Done():
+		logger.Infof("Shutting down StreamableHTTP server...")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return httpServer.Shutdown(shutdownCtx)
+	case err := <-errC:
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("StreamableHTTP server error: %w", err)
+		}
+	}
+
+	return nil
+}
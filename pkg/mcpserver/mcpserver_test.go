@@ -0,0 +1,142 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package mcpserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/sys"
+	"github.com/mark3labs/mcp-go/mcp"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func newLogger() *log.Logger {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+	return logger
+}
+
+func TestNew(t *testing.T) {
+	s := New(Config{Version: "1.2.3", Logger: newLogger()})
+	require.NotNil(t, s.MCPServer)
+	require.NotNil(t, s.RateLimiter)
+
+	tools := s.MCPServer.ListTools()
+	assert.Contains(t, tools, "list_secrets")
+	assert.Contains(t, tools, "server_info")
+}
+
+func TestHTTPHandler(t *testing.T) {
+	t.Setenv("MCP_CORS_MODE", "strict")
+
+	s := New(Config{
+		Logger: newLogger(),
+		RateLimit: &client.RateLimitConfig{
+			GlobalLimit:     rate.Every(time.Second),
+			GlobalBurst:     7,
+			PerSessionLimit: rate.Every(time.Second),
+			PerSessionBurst: 7,
+		},
+	})
+	handler, err := s.HTTPHandler("mcp")
+	require.NoError(t, err)
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test","version":"1.0"}}}`
+	req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req.WithContext(context.Background()))
+
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	assert.Contains(t, recorder.Body.String(), `"name":"vault-mcp-server"`)
+	assert.Equal(t, "7", recorder.Header().Get("RateLimit-Limit"))
+
+	// Cross-origin requests are rejected in strict mode
+	req = httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body))
+	req.Header.Set("Origin", "https://evil.example.com")
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+}
+
+func TestTenantResources(t *testing.T) {
+	s := New(Config{Version: "1.2.3", Logger: newLogger()})
+
+	config, err := client.ParseTenancyConfig([]byte(`{"profiles": [{"name": "team-a", "api_keys": ["key-a"], "allowed_tools": ["list_*"]}]}`))
+	require.NoError(t, err)
+	ctx, _, err := client.TenantContextFromHeaders(context.Background(), config, nil, "", func(name string) string {
+		if name == client.HeaderAPIKey {
+			return "key-a"
+		}
+		return ""
+	})
+	require.NoError(t, err)
+
+	response := s.MCPServer.HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","id":1,"method":"resources/list"}`))
+	result, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "unexpected response %v", response)
+	list := result.Result.(mcp.ListResourcesResult)
+	require.NotEmpty(t, list.Resources)
+	for _, resource := range list.Resources {
+		assert.True(t, strings.HasPrefix(resource.URI, "vault-docs://"), resource.URI)
+	}
+
+	response = s.MCPServer.HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","id":2,"method":"resources/read","params":{"uri":"vault://topology/mermaid"}}`))
+	rpcErr, ok := response.(mcp.JSONRPCError)
+	require.True(t, ok, "unexpected response %v", response)
+	assert.Contains(t, rpcErr.Error.Message, "not allowed")
+}
+
+func TestWriteTimeoutOutlastsToolWaits(t *testing.T) {
+	assert.Less(t, time.Duration(sys.DefaultTidyTimeoutSeconds)*time.Second, writeTimeout(), "run_tidy must return before the response is cut off")
+}
+
+func TestRunBackground(t *testing.T) {
+	t.Setenv("MCP_LEADER_ELECTION", "")
+
+	s := New(Config{Version: "1.2.3", Logger: newLogger()})
+	started := make(chan string, 2)
+	for _, name := range []string{"first", "second"} {
+		s.AddBackgroundTask(name, func(ctx context.Context) {
+			started <- name
+			<-ctx.Done()
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.RunBackground(ctx) }()
+
+	// Without leader election every replica runs the tasks
+	assert.ElementsMatch(t, []string{"first", "second"}, []string{<-started, <-started})
+	assert.True(t, s.IsLeader())
+
+	cancel()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("background tasks did not stop")
+	}
+}
+
+func TestRunBackgroundRequiresKubernetes(t *testing.T) {
+	t.Setenv("MCP_LEADER_ELECTION", "true")
+	t.Setenv("MCP_LEADER_ELECTION_NAMESPACE", "vault")
+	t.Setenv("MCP_LEADER_ELECTION_IDENTITY", "replica-a")
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+
+	s := New(Config{Version: "1.2.3", Logger: newLogger()})
+	assert.ErrorContains(t, s.RunBackground(context.Background()), "not running in a Kubernetes pod")
+}
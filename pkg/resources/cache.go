@@ -0,0 +1,65 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+)
+
+type cacheEntry[T any] struct {
+	value   T
+	expires time.Time
+}
+
+// resultCache holds results computed for a Vault server, token and namespace until they expire
+type resultCache[T any] struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	ttl     time.Duration
+	entries map[string]cacheEntry[T]
+}
+
+func newResultCache[T any](ttl time.Duration) *resultCache[T] {
+	return &resultCache[T]{
+		now:     time.Now,
+		ttl:     ttl,
+		entries: map[string]cacheEntry[T]{},
+	}
+}
+
+func (c *resultCache[T]) get(key string) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || c.now().After(entry.expires) {
+		var zero T
+		return zero, false
+	}
+	return entry.value, true
+}
+
+// put caches a result and drops the expired entries
+func (c *resultCache[T]) put(key string, value T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for k, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = cacheEntry[T]{value: value, expires: now.Add(c.ttl)}
+}
+
+// cacheKey identifies a result as seen by a client without keeping the token itself
+func cacheKey(vault *api.Client, name string) string {
+	sum := sha256.Sum256([]byte(vault.Token()))
+	return strings.Join([]string{vault.Address(), hex.EncodeToString(sum[:8]), vault.Namespace(), name}, "|")
+}
@@ -0,0 +1,428 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/token"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// ExpirationCalendarURI is the URI of the expiration calendar resource in JSON
+	ExpirationCalendarURI = "vault://calendar/expirations"
+	// ExpirationCalendarICalURI is the URI of the expiration calendar resource in iCalendar format
+	ExpirationCalendarICalURI = "vault://calendar/expirations.ics"
+	// ExpirationCalendarTTL is how long the expiration calendar is cached before it is built again
+	ExpirationCalendarTTL = 5 * time.Minute
+	// ExpirationHorizon is how far ahead the expiration calendar looks
+	ExpirationHorizon = 90 * 24 * time.Hour
+	// MaxCalendarCertificates is the number of certificates read per PKI mount when building the calendar
+	MaxCalendarCertificates = 1000
+)
+
+// Kinds of expiration events
+const (
+	ExpirationCertificate   = "certificate"
+	ExpirationCACertificate = "ca_certificate"
+	ExpirationStaticRole    = "static_role_rotation"
+	ExpirationToken         = "token"
+	ExpirationLicense       = "license"
+)
+
+// ExpirationEvent is something in Vault expiring or rotating at a known time
+type ExpirationEvent struct {
+	Time    time.Time `json:"time"`
+	Kind    string    `json:"kind"`
+	Mount   string    `json:"mount,omitempty"`
+	Name    string    `json:"name"`
+	Path    string    `json:"path"`
+	Summary string    `json:"summary"`
+}
+
+// ExpirationCalendar is the date ordered feed of the upcoming expirations
+type ExpirationCalendar struct {
+	GeneratedAt    time.Time         `json:"generated_at"`
+	HorizonDays    int               `json:"horizon_days"`
+	Events         []ExpirationEvent `json:"events"`
+	Truncated      bool              `json:"truncated"`
+	SkippedSources []string          `json:"skipped_sources,omitempty"`
+}
+
+var expirationCalendars = newResultCache[*ExpirationCalendar](ExpirationCalendarTTL)
+
+// ExpirationCalendarResources creates the resources serving the upcoming expirations of PKI certificates,
+// database static role rotations, tokens and the license, as JSON and as an iCalendar feed. The calendar is
+// built on the first read of either format and cached for ExpirationCalendarTTL.
+func ExpirationCalendarResources(logger *log.Logger) []server.ServerResource {
+	description := fmt.Sprintf("Upcoming expirations over the next %d days: PKI certificates, database static role rotations, token TTLs of known accessors and the license expiry, ordered by date. Sources the token cannot read are listed as skipped. Cached for %s.", int(ExpirationHorizon.Hours()/24), ExpirationCalendarTTL)
+
+	return []server.ServerResource{
+		{
+			Resource: mcp.NewResource(ExpirationCalendarURI, "Expiration calendar",
+				mcp.WithResourceDescription(description),
+				mcp.WithMIMEType("application/json"),
+			),
+			Handler: func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+				calendar, err := expirationCalendar(ctx, logger)
+				if err != nil {
+					return nil, err
+				}
+
+				jsonData, err := json.Marshal(calendar)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal expiration calendar: %v", err)
+				}
+
+				return []mcp.ResourceContents{
+					mcp.TextResourceContents{
+						URI:      ExpirationCalendarURI,
+						MIMEType: "application/json",
+						Text:     string(jsonData),
+					},
+				}, nil
+			},
+		},
+		{
+			Resource: mcp.NewResource(ExpirationCalendarICalURI, "Expiration calendar (iCalendar)",
+				mcp.WithResourceDescription(description+" In iCalendar format, for import into calendar applications."),
+				mcp.WithMIMEType("text/calendar"),
+			),
+			Handler: func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+				calendar, err := expirationCalendar(ctx, logger)
+				if err != nil {
+					return nil, err
+				}
+
+				return []mcp.ResourceContents{
+					mcp.TextResourceContents{
+						URI:      ExpirationCalendarICalURI,
+						MIMEType: "text/calendar",
+						Text:     calendar.ICal(),
+					},
+				}, nil
+			},
+		},
+	}
+}
+
+func expirationCalendar(ctx context.Context, logger *log.Logger) (*ExpirationCalendar, error) {
+	logger.Debug("Handling expiration calendar read request")
+
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Vault client: %v", err)
+	}
+
+	key := cacheKey(vault, ExpirationCalendarURI)
+	calendar, ok := expirationCalendars.get(key)
+	if !ok {
+		calendar = BuildExpirationCalendar(vault, time.Now().UTC(), ExpirationHorizon)
+		expirationCalendars.put(key, calendar)
+		logger.WithFields(log.Fields{"events": len(calendar.Events), "skipped": len(calendar.SkippedSources)}).Debug("Built expiration calendar")
+	}
+	return calendar, nil
+}
+
+// BuildExpirationCalendar collects the events between now and the horizon from every source the client can
+// read. A source that fails is listed in SkippedSources instead of failing the calendar.
+func BuildExpirationCalendar(vault *api.Client, now time.Time, horizon time.Duration) *ExpirationCalendar {
+	calendar := &ExpirationCalendar{
+		GeneratedAt: now,
+		HorizonDays: int(horizon.Hours() / 24),
+		Events:      []ExpirationEvent{},
+	}
+	end := now.Add(horizon)
+	add := func(event ExpirationEvent) {
+		if event.Time.After(now) && !event.Time.After(end) {
+			calendar.Events = append(calendar.Events, event)
+		}
+	}
+	skip := func(source string, err error) {
+		calendar.SkippedSources = append(calendar.SkippedSources, fmt.Sprintf("%s: %v", source, err))
+	}
+
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		skip("sys/mounts", err)
+	}
+	paths := make([]string, 0, len(mounts))
+	for path := range mounts {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		mount := strings.TrimSuffix(path, "/")
+		switch mounts[path].Type {
+		case "pki":
+			truncated, err := certificateExpirations(vault, mount, add)
+			if err != nil {
+				skip(mount+"/certs", err)
+			}
+			calendar.Truncated = calendar.Truncated || truncated
+		case "database":
+			if err := staticRoleRotations(vault, mount, now, add); err != nil {
+				skip(mount+"/static-roles", err)
+			}
+		}
+	}
+
+	truncated, err := tokenExpirations(vault, add)
+	if err != nil {
+		skip("auth/token/accessors", err)
+	}
+	calendar.Truncated = calendar.Truncated || truncated
+
+	if err := licenseExpiration(vault, add); err != nil {
+		skip("sys/license/status", err)
+	}
+
+	sort.SliceStable(calendar.Events, func(i, j int) bool {
+		if !calendar.Events[i].Time.Equal(calendar.Events[j].Time) {
+			return calendar.Events[i].Time.Before(calendar.Events[j].Time)
+		}
+		return calendar.Events[i].Path < calendar.Events[j].Path
+	})
+	return calendar
+}
+
+// certificateExpirations adds the expiry of the certificates of a PKI mount that are not revoked
+func certificateExpirations(vault *api.Client, mount string, add func(ExpirationEvent)) (bool, error) {
+	serials, err := listCalendarKeys(vault, mount+"/certs")
+	if err != nil {
+		return false, err
+	}
+	truncated := len(serials) > MaxCalendarCertificates
+	if truncated {
+		serials = serials[:MaxCalendarCertificates]
+	}
+
+	for _, serial := range serials {
+		path := mount + "/cert/" + serial
+		secret, err := vault.Logical().Read(path)
+		if err != nil || secret == nil {
+			continue
+		}
+		if revoked, ok := secret.Data["revocation_time"].(json.Number); ok && revoked.String() != "0" {
+			continue
+		}
+		pemData, _ := secret.Data["certificate"].(string)
+		block, _ := pem.Decode([]byte(pemData))
+		if block == nil {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			continue
+		}
+
+		kind, summary := ExpirationCertificate, "Certificate"
+		if cert.IsCA {
+			kind, summary = ExpirationCACertificate, "CA certificate"
+		}
+		name := cert.Subject.CommonName
+		if name == "" {
+			name = serial
+		}
+		add(ExpirationEvent{
+			Time:    cert.NotAfter.UTC(),
+			Kind:    kind,
+			Mount:   mount,
+			Name:    name,
+			Path:    path,
+			Summary: fmt.Sprintf("%s '%s' on %s expires", summary, name, mount),
+		})
+	}
+	return truncated, nil
+}
+
+// staticRoleRotations adds the next rotation of the static roles of a database mount
+func staticRoleRotations(vault *api.Client, mount string, now time.Time, add func(ExpirationEvent)) error {
+	roles, err := listCalendarKeys(vault, mount+"/static-roles")
+	if err != nil {
+		return err
+	}
+
+	for _, role := range roles {
+		path := mount + "/static-roles/" + role
+		secret, err := vault.Logical().Read(path)
+		if err != nil || secret == nil {
+			continue
+		}
+		ttl, ok := secret.Data["ttl"].(json.Number)
+		if !ok {
+			continue
+		}
+		seconds, err := ttl.Int64()
+		if err != nil || seconds <= 0 {
+			continue
+		}
+		username, _ := secret.Data["username"].(string)
+		summary := fmt.Sprintf("Static role '%s' on %s rotates its password", role, mount)
+		if username != "" {
+			summary = fmt.Sprintf("Static role '%s' on %s rotates the password of '%s'", role, mount, username)
+		}
+		add(ExpirationEvent{
+			Time:    now.Add(time.Duration(seconds) * time.Second),
+			Kind:    ExpirationStaticRole,
+			Mount:   mount,
+			Name:    role,
+			Path:    path,
+			Summary: summary,
+		})
+	}
+	return nil
+}
+
+// tokenExpirations adds the expiry of the tokens of the known accessors, looking up at most
+// token.DefaultMaxAccessorLookups of them
+func tokenExpirations(vault *api.Client, add func(ExpirationEvent)) (bool, error) {
+	accessors, err := listCalendarKeys(vault, "auth/token/accessors")
+	if err != nil {
+		return false, err
+	}
+	truncated := len(accessors) > token.DefaultMaxAccessorLookups
+	if truncated {
+		accessors = accessors[:token.DefaultMaxAccessorLookups]
+	}
+
+	for _, accessor := range accessors {
+		lookup, err := vault.Auth().Token().LookupAccessor(accessor)
+		if err != nil || lookup == nil {
+			continue
+		}
+		expireTime, _ := lookup.Data["expire_time"].(string)
+		expires, err := time.Parse(time.RFC3339Nano, expireTime)
+		if err != nil {
+			continue
+		}
+		name, _ := lookup.Data["display_name"].(string)
+		if name == "" {
+			name = accessor
+		}
+		add(ExpirationEvent{
+			Time:    expires.UTC(),
+			Kind:    ExpirationToken,
+			Name:    name,
+			Path:    "auth/token/accessors/" + accessor,
+			Summary: fmt.Sprintf("Token '%s' (accessor %s) expires", name, accessor),
+		})
+	}
+	return truncated, nil
+}
+
+// licenseExpiration adds the expiry of the autoloaded license. Vault Community Edition has no license and adds
+// nothing.
+func licenseExpiration(vault *api.Client, add func(ExpirationEvent)) error {
+	secret, err := vault.Logical().Read("sys/license/status")
+	if err != nil {
+		return err
+	}
+	if secret == nil {
+		return nil
+	}
+	autoloaded, _ := secret.Data["autoloaded"].(map[string]interface{})
+	expirationTime, _ := autoloaded["expiration_time"].(string)
+	expires, err := time.Parse(time.RFC3339Nano, expirationTime)
+	if err != nil {
+		return nil
+	}
+	licenseID, _ := autoloaded["license_id"].(string)
+	add(ExpirationEvent{
+		Time:    expires.UTC(),
+		Kind:    ExpirationLicense,
+		Name:    licenseID,
+		Path:    "sys/license/status",
+		Summary: "Vault license expires",
+	})
+	return nil
+}
+
+// listCalendarKeys lists the keys under a path, treating a path with nothing under it as empty
+func listCalendarKeys(vault *api.Client, path string) ([]string, error) {
+	secret, err := vault.Logical().List(path)
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil {
+		return nil, nil
+	}
+	raw, _ := secret.Data["keys"].([]interface{})
+	keys := make([]string, 0, len(raw))
+	for _, key := range raw {
+		if s, ok := key.(string); ok {
+			keys = append(keys, s)
+		}
+	}
+	return keys, nil
+}
+
+// ICal renders the calendar in iCalendar format (RFC 5545) with one event per expiration
+func (c *ExpirationCalendar) ICal() string {
+	const stamp = "20060102T150405Z"
+
+	var b strings.Builder
+	line := func(format string, args ...interface{}) {
+		b.WriteString(foldICalLine(fmt.Sprintf(format, args...)))
+		b.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//HashiCorp//Vault MCP Server//EN")
+	line("CALSCALE:GREGORIAN")
+	line("X-WR-CALNAME:Vault expirations")
+	for _, event := range c.Events {
+		line("BEGIN:VEVENT")
+		line("UID:%s@vault-mcp-server", url.PathEscape(event.Kind+"/"+event.Path))
+		line("DTSTAMP:%s", c.GeneratedAt.UTC().Format(stamp))
+		line("DTSTART:%s", event.Time.UTC().Format(stamp))
+		line("SUMMARY:%s", escapeICalText(event.Summary))
+		line("DESCRIPTION:%s", escapeICalText(event.Path))
+		line("CATEGORIES:%s", escapeICalText(event.Kind))
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return b.String()
+}
+
+func escapeICalText(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(text)
+}
+
+// foldICalLine splits a content line longer than 75 octets into continuation lines starting with a space,
+// without splitting a UTF-8 character
+func foldICalLine(line string) string {
+	const limit = 75
+	if len(line) <= limit {
+		return line
+	}
+
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
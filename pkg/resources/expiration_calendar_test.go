@@ -0,0 +1,182 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCertificate(t *testing.T, commonName string, notAfter time.Time, isCA bool) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestExpirationCalendar(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	in := func(days int) time.Time { return now.Add(time.Duration(days) * 24 * time.Hour) }
+
+	certs := map[string]map[string]interface{}{
+		"01": {"certificate": testCertificate(t, "web.example.com", in(10), false), "revocation_time": 0},
+		"02": {"certificate": testCertificate(t, "Example Root", in(60), true), "revocation_time": 0},
+		"03": {"certificate": testCertificate(t, "revoked.example.com", in(5), false), "revocation_time": now.Unix()},
+		"04": {"certificate": testCertificate(t, "expired.example.com", in(-1), false), "revocation_time": 0},
+		"05": {"certificate": testCertificate(t, "later.example.com", in(200), false), "revocation_time": 0},
+	}
+
+	var mountsRead atomic.Int32
+	var denyTokens atomic.Bool
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		reply := func(data interface{}) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+		}
+		list := func(keys ...string) { reply(map[string]interface{}{"keys": keys}) }
+
+		path := strings.TrimSuffix(r.URL.Path, "/")
+		switch {
+		case path == "/v1/sys/mounts":
+			mountsRead.Add(1)
+			reply(map[string]interface{}{
+				"pki/":      map[string]interface{}{"type": "pki"},
+				"database/": map[string]interface{}{"type": "database"},
+				"secret/":   map[string]interface{}{"type": "kv"},
+			})
+		case path == "/v1/pki/certs":
+			list("01", "02", "03", "04", "05")
+		case strings.HasPrefix(path, "/v1/pki/cert/"):
+			reply(certs[strings.TrimPrefix(path, "/v1/pki/cert/")])
+		case path == "/v1/database/static-roles":
+			list("app")
+		case path == "/v1/database/static-roles/app":
+			reply(map[string]interface{}{"username": "app-user", "ttl": 3600})
+		case path == "/v1/auth/token/accessors":
+			if denyTokens.Load() {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+				return
+			}
+			list("acc1", "acc2")
+		case path == "/v1/auth/token/lookup-accessor":
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body["accessor"] == "acc1" {
+				reply(map[string]interface{}{"display_name": "token-ci", "expire_time": in(3).Format(time.RFC3339Nano)})
+			} else {
+				reply(map[string]interface{}{"display_name": "root", "expire_time": nil})
+			}
+		case path == "/v1/sys/license/status":
+			reply(map[string]interface{}{"autoloaded": map[string]interface{}{"license_id": "lic-1", "expiration_time": in(30).Format(time.RFC3339)}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockVault.Close()
+
+	sessionID := "test-" + t.Name()
+	vault, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	defer client.DeleteVaultClient(sessionID)
+
+	t.Run("events are ordered within the horizon", func(t *testing.T) {
+		calendar := BuildExpirationCalendar(vault, now, ExpirationHorizon)
+		assert.Equal(t, 90, calendar.HorizonDays)
+		assert.False(t, calendar.Truncated)
+		assert.Empty(t, calendar.SkippedSources)
+
+		var kinds, names []string
+		for _, event := range calendar.Events {
+			kinds = append(kinds, event.Kind)
+			names = append(names, event.Name)
+		}
+		assert.Equal(t, []string{ExpirationStaticRole, ExpirationToken, ExpirationCertificate, ExpirationLicense, ExpirationCACertificate}, kinds)
+		assert.Equal(t, []string{"app", "token-ci", "web.example.com", "lic-1", "Example Root"}, names)
+		assert.Equal(t, now.Add(time.Hour), calendar.Events[0].Time)
+		assert.Contains(t, calendar.Events[0].Summary, "app-user")
+		assert.Equal(t, "pki/cert/01", calendar.Events[2].Path)
+		assert.Equal(t, "pki", calendar.Events[2].Mount)
+	})
+
+	t.Run("unreadable sources are skipped", func(t *testing.T) {
+		denyTokens.Store(true)
+		defer denyTokens.Store(false)
+
+		calendar := BuildExpirationCalendar(vault, now, ExpirationHorizon)
+		require.Len(t, calendar.SkippedSources, 1)
+		assert.Contains(t, calendar.SkippedSources[0], "auth/token/accessors")
+		assert.Len(t, calendar.Events, 4)
+	})
+
+	t.Run("resources serve JSON and iCalendar from the cache", func(t *testing.T) {
+		logger := log.New()
+		logger.SetLevel(log.ErrorLevel)
+		ctx := server.NewMCPServer("test", "1.0").WithContext(context.Background(), testSession{id: sessionID})
+
+		resources := ExpirationCalendarResources(logger)
+		require.Len(t, resources, 2)
+		reads := mountsRead.Load()
+
+		contents, err := resources[0].Handler(ctx, mcp.ReadResourceRequest{Params: mcp.ReadResourceParams{URI: ExpirationCalendarURI}})
+		require.NoError(t, err)
+		text := contents[0].(mcp.TextResourceContents)
+		assert.Equal(t, "application/json", text.MIMEType)
+		var calendar ExpirationCalendar
+		require.NoError(t, json.Unmarshal([]byte(text.Text), &calendar))
+		assert.Len(t, calendar.Events, 5)
+
+		contents, err = resources[1].Handler(ctx, mcp.ReadResourceRequest{Params: mcp.ReadResourceParams{URI: ExpirationCalendarICalURI}})
+		require.NoError(t, err)
+		text = contents[0].(mcp.TextResourceContents)
+		assert.Equal(t, "text/calendar", text.MIMEType)
+		assert.True(t, strings.HasPrefix(text.Text, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+		assert.True(t, strings.HasSuffix(text.Text, "END:VCALENDAR\r\n"))
+		assert.Equal(t, 5, strings.Count(text.Text, "BEGIN:VEVENT"))
+		assert.Contains(t, text.Text, "DTSTART:"+calendar.Events[0].Time.Format("20060102T150405Z"))
+		assert.Contains(t, text.Text, `SUMMARY:Certificate 'web.example.com' on pki expires`)
+
+		assert.Equal(t, reads+1, mountsRead.Load(), "both formats use the same cached calendar")
+	})
+}
+
+func TestICalText(t *testing.T) {
+	assert.Equal(t, `a\, b\; c\\d\ne`, escapeICalText("a, b; c\\d\ne"))
+
+	folded := foldICalLine("SUMMARY:" + strings.Repeat("é", 60))
+	for _, line := range strings.Split(folded, "\r\n") {
+		assert.LessOrEqual(t, len(line), 75)
+	}
+	assert.Equal(t, "SUMMARY:"+strings.Repeat("é", 60), strings.ReplaceAll(folded, "\r\n ", ""))
+}
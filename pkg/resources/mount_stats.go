@@ -0,0 +1,103 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/sys"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// MountStatsURITemplate is the URI template of the KV mount statistics resource
+	MountStatsURITemplate = "vault://mounts/{mount}/stats"
+	// MountStatsTTL is how long the statistics of a mount are cached before they are computed again
+	MountStatsTTL = 5 * time.Minute
+)
+
+var mountStats = newResultCache[*sys.KVMountStats](MountStatsTTL)
+
+// MountStatsResource creates a resource template serving the statistics of a KV mount: the number of secrets,
+// the number of versions kept per secret and the deepest path. Statistics are computed on the first read and
+// cached for MountStatsTTL, so clients can show them without walking the mount on every read.
+func MountStatsResource(logger *log.Logger) server.ServerResourceTemplate {
+	return server.ServerResourceTemplate{
+		Template: mcp.NewResourceTemplate(MountStatsURITemplate, "KV mount statistics",
+			mcp.WithTemplateDescription(fmt.Sprintf("Statistics of a KV mount: the number of secrets, the average number of versions kept per secret with a histogram, and the deepest secret path. Computed on first read and cached for %s, at most %d secrets are counted.", MountStatsTTL, sys.MaxSummarizedSecrets)),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		Handler: func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			return mountStatsHandler(ctx, req, logger)
+		},
+	}
+}
+
+func mountStatsHandler(ctx context.Context, req mcp.ReadResourceRequest, logger *log.Logger) ([]mcp.ResourceContents, error) {
+	logger.WithField("uri", req.Params.URI).Debug("Handling resource read request")
+
+	mount := mountArgument(req)
+	if mount == "" {
+		return nil, fmt.Errorf("missing mount in '%s'", req.Params.URI)
+	}
+
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Vault client: %v", err)
+	}
+
+	key := cacheKey(vault, mount)
+	stats, ok := mountStats.get(key)
+	if !ok {
+		mounts, err := vault.Sys().ListMounts()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list mounts: %v", err)
+		}
+		mountOutput, exists := mounts[mount+"/"]
+		if !exists {
+			return nil, fmt.Errorf("mount '%s' does not exist", mount)
+		}
+		stats, err = sys.ComputeKVMountStats(vault, mount, mountOutput, sys.MaxSummarizedSecrets)
+		if err != nil {
+			return nil, err
+		}
+		mountStats.put(key, stats)
+		logger.WithFields(log.Fields{"mount": mount, "secrets": stats.Secrets}).Debug("Computed mount statistics")
+	}
+
+	jsonData, err := json.Marshal(stats)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal mount statistics: %v", err)
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      req.Params.URI,
+			MIMEType: "application/json",
+			Text:     string(jsonData),
+		},
+	}, nil
+}
+
+// mountArgument returns the mount matched by the URI template, which decodes nested mounts passed URL
+// encoded, e.g. vault://mounts/team%2Fkv/stats.
+func mountArgument(req mcp.ReadResourceRequest) string {
+	var mount string
+	switch value := req.Params.Arguments["mount"].(type) {
+	case string:
+		mount = value
+	case []string:
+		if len(value) > 0 {
+			mount = value[0]
+		}
+	}
+	return strings.Trim(mount, "/")
+}
@@ -0,0 +1,145 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/sys"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSession struct {
+	id string
+}
+
+func (s testSession) SessionID() string                                   { return s.id }
+func (s testSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s testSession) Initialize()                                         {}
+func (s testSession) Initialized() bool                                   { return true }
+
+func TestMountStatsResource(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	list := func(w http.ResponseWriter, keys ...string) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"keys": keys}})
+	}
+	versions := func(w http.ResponseWriter, count int) {
+		v := map[string]interface{}{}
+		for i := 1; i <= count; i++ {
+			v[strconv.Itoa(i)] = map[string]interface{}{}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"versions": v}})
+	}
+
+	var listed atomic.Int32
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch strings.TrimSuffix(r.URL.Path, "/") {
+		case "/v1/sys/mounts":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"secret/":  map[string]interface{}{"type": "kv", "options": map[string]interface{}{"version": "2"}},
+				"legacy/":  map[string]interface{}{"type": "kv", "options": map[string]interface{}{"version": "1"}},
+				"transit/": map[string]interface{}{"type": "transit"},
+			}})
+		case "/v1/secret/metadata":
+			listed.Add(1)
+			list(w, "app", "team/")
+		case "/v1/secret/metadata/team":
+			list(w, "db", "web/")
+		case "/v1/secret/metadata/team/web":
+			list(w, "config")
+		case "/v1/secret/metadata/app":
+			versions(w, 1)
+		case "/v1/secret/metadata/team/db", "/v1/secret/metadata/team/web/config":
+			versions(w, 3)
+		case "/v1/legacy":
+			list(w, "a", "b")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockVault.Close()
+
+	sessionID := "test-" + t.Name()
+	_, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	defer client.DeleteVaultClient(sessionID)
+
+	srv := server.NewMCPServer("test", "1.0", server.WithResourceCapabilities(false, false))
+	InitResources(srv, logger)
+	ctx := srv.WithContext(context.Background(), testSession{id: sessionID})
+
+	read := func(t *testing.T, uri string) (*sys.KVMountStats, *mcp.JSONRPCError) {
+		message, err := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"method":  "resources/read",
+			"params":  map[string]any{"uri": uri},
+		})
+		require.NoError(t, err)
+		switch response := srv.HandleMessage(ctx, message).(type) {
+		case mcp.JSONRPCResponse:
+			result := response.Result.(mcp.ReadResourceResult)
+			require.Len(t, result.Contents, 1)
+			text := result.Contents[0].(mcp.TextResourceContents)
+			assert.Equal(t, uri, text.URI)
+			var stats sys.KVMountStats
+			require.NoError(t, json.Unmarshal([]byte(text.Text), &stats))
+			return &stats, nil
+		case mcp.JSONRPCError:
+			return nil, &response
+		default:
+			t.Fatalf("unexpected response %T", response)
+			return nil, nil
+		}
+	}
+
+	t.Run("KV v2 statistics are computed once and cached", func(t *testing.T) {
+		stats, rpcErr := read(t, "vault://mounts/secret/stats")
+		require.Nil(t, rpcErr)
+		assert.Equal(t, 2, stats.Version)
+		assert.Equal(t, 3, stats.Secrets)
+		assert.Equal(t, 2.33, stats.AverageVersions)
+		assert.Equal(t, map[int]int{1: 1, 3: 2}, stats.VersionHistogram)
+		assert.Equal(t, "team/web/config", stats.DeepestPath)
+		assert.Equal(t, 3, stats.MaxDepth)
+
+		_, rpcErr = read(t, "vault://mounts/secret/stats")
+		require.Nil(t, rpcErr)
+		assert.Equal(t, int32(1), listed.Load())
+	})
+
+	t.Run("KV v1 statistics have no versions", func(t *testing.T) {
+		stats, rpcErr := read(t, "vault://mounts/legacy/stats")
+		require.Nil(t, rpcErr)
+		assert.Equal(t, 1, stats.Version)
+		assert.Equal(t, 2, stats.Secrets)
+		assert.Empty(t, stats.VersionHistogram)
+		assert.Equal(t, 1, stats.MaxDepth)
+	})
+
+	t.Run("other mounts are rejected", func(t *testing.T) {
+		_, rpcErr := read(t, "vault://mounts/transit/stats")
+		require.NotNil(t, rpcErr)
+		assert.Contains(t, rpcErr.Error.Message, "only available for KV mounts")
+
+		_, rpcErr = read(t, "vault://mounts/missing/stats")
+		require.NotNil(t, rpcErr)
+		assert.Contains(t, rpcErr.Error.Message, "does not exist")
+	})
+}
@@ -0,0 +1,99 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"embed"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+//go:embed docs/*.md
+var docs embed.FS
+
+// Doc is a reference document served as a vault-docs:// resource
+type Doc struct {
+	URI         string
+	Name        string
+	Description string
+	File        string
+}
+
+// Docs are the reference documents that tool descriptions point to instead of embedding long prose
+var Docs = []Doc{
+	{
+		URI:         "vault-docs://pki/setup",
+		Name:        "PKI setup guide",
+		Description: "Steps for setting up a root CA, an intermediate CA and issuing certificates with the PKI tools.",
+		File:        "docs/pki-setup.md",
+	},
+	{
+		URI:         "vault-docs://kv/versions",
+		Name:        "KV version 1 and version 2",
+		Description: "Differences between KV version 1 and version 2 mounts and when to use each.",
+		File:        "docs/kv-versions.md",
+	},
+	{
+		URI:         "vault-docs://auth/methods",
+		Name:        "Auth method comparison",
+		Description: "Comparison of the Vault auth methods and recommendations for choosing one.",
+		File:        "docs/auth-methods.md",
+	},
+}
+
+// InitResources registers the reference documents, the mount statistics, the expiration calendar, the topology
+// graphs and, when transcripts are recorded, the session transcript as resources of the MCP server
+func InitResources(hcServer *server.MCPServer, logger *log.Logger) {
+	for _, doc := range Docs {
+		r := DocResource(doc, logger)
+		hcServer.AddResource(r.Resource, r.Handler)
+	}
+
+	stats := MountStatsResource(logger)
+	hcServer.AddResourceTemplate(stats.Template, stats.Handler)
+
+	for _, r := range ExpirationCalendarResources(logger) {
+		hcServer.AddResource(r.Resource, r.Handler)
+	}
+
+	for _, r := range TopologyResources(logger) {
+		hcServer.AddResource(r.Resource, r.Handler)
+	}
+
+	if client.TranscriptsEnabled() {
+		transcript := TranscriptResource(logger)
+		hcServer.AddResource(transcript.Resource, transcript.Handler)
+	}
+}
+
+// DocResource creates a resource serving a reference document
+func DocResource(doc Doc, logger *log.Logger) server.ServerResource {
+	return server.ServerResource{
+		Resource: mcp.NewResource(doc.URI, doc.Name,
+			mcp.WithResourceDescription(doc.Description),
+			mcp.WithMIMEType("text/markdown"),
+		),
+		Handler: func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			logger.WithField("uri", doc.URI).Debug("Handling resource read request")
+
+			content, err := docs.ReadFile(doc.File)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read document '%s': %v", doc.URI, err)
+			}
+
+			return []mcp.ResourceContents{
+				mcp.TextResourceContents{
+					URI:      doc.URI,
+					MIMEType: "text/markdown",
+					Text:     string(content),
+				},
+			}, nil
+		},
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocResources(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	for _, doc := range Docs {
+		t.Run(doc.URI, func(t *testing.T) {
+			r := DocResource(doc, logger)
+			assert.Equal(t, doc.URI, r.Resource.URI)
+
+			contents, err := r.Handler(context.Background(), mcp.ReadResourceRequest{})
+			require.NoError(t, err)
+			require.Len(t, contents, 1)
+
+			text, ok := contents[0].(mcp.TextResourceContents)
+			require.True(t, ok)
+			assert.NotEmpty(t, text.Text)
+			assert.Equal(t, "text/markdown", text.MIMEType)
+		})
+	}
+}
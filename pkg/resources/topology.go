@@ -0,0 +1,108 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/sys"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// TopologyMermaidURI is the URI of the topology resource as a Mermaid flowchart
+	TopologyMermaidURI = "vault://topology/mermaid"
+	// TopologyDOTURI is the URI of the topology resource in Graphviz DOT format
+	TopologyDOTURI = "vault://topology/dot"
+	// TopologyTTL is how long the topology is cached before it is read again
+	TopologyTTL = 5 * time.Minute
+)
+
+var topologies = newResultCache[*sys.DependencyGraph](TopologyTTL)
+
+// TopologyResources creates the resources rendering the topology of Vault, its secrets engines, auth methods,
+// policies and replication, as a Mermaid flowchart and in Graphviz DOT format. The topology is read on the first
+// read of either format and cached for TopologyTTL.
+func TopologyResources(logger *log.Logger) []server.ServerResource {
+	description := fmt.Sprintf("Topology of Vault: the secrets engines and auth methods, the PKI issuers and roles, KV paths and auth roles on them, the policies linking them and the clusters of disaster recovery and performance replication. Edges point from the dependent node to the node it depends on. At most %d entries are read per listing, parts the token cannot read are listed as comments. Cached for %s.", sys.DefaultDependencyLimit, TopologyTTL)
+
+	return []server.ServerResource{
+		{
+			Resource: mcp.NewResource(TopologyMermaidURI, "Vault topology (Mermaid)",
+				mcp.WithResourceDescription(description+" As a Mermaid flowchart."),
+				mcp.WithMIMEType("text/vnd.mermaid"),
+			),
+			Handler: func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+				graph, err := topology(ctx, logger)
+				if err != nil {
+					return nil, err
+				}
+
+				return []mcp.ResourceContents{
+					mcp.TextResourceContents{
+						URI:      TopologyMermaidURI,
+						MIMEType: "text/vnd.mermaid",
+						Text:     topologyComments(graph, "%% ") + graph.RenderMermaid(),
+					},
+				}, nil
+			},
+		},
+		{
+			Resource: mcp.NewResource(TopologyDOTURI, "Vault topology (DOT)",
+				mcp.WithResourceDescription(description+" In Graphviz DOT format."),
+				mcp.WithMIMEType("text/vnd.graphviz"),
+			),
+			Handler: func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+				graph, err := topology(ctx, logger)
+				if err != nil {
+					return nil, err
+				}
+
+				return []mcp.ResourceContents{
+					mcp.TextResourceContents{
+						URI:      TopologyDOTURI,
+						MIMEType: "text/vnd.graphviz",
+						Text:     topologyComments(graph, "// ") + graph.RenderDOT(),
+					},
+				}, nil
+			},
+		},
+	}
+}
+
+func topology(ctx context.Context, logger *log.Logger) (*sys.DependencyGraph, error) {
+	logger.Debug("Handling topology read request")
+
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Vault client: %v", err)
+	}
+
+	key := cacheKey(vault, TopologyMermaidURI)
+	graph, ok := topologies.get(key)
+	if !ok {
+		graph = sys.BuildTopologyGraph(vault, sys.DefaultDependencyLimit)
+		topologies.put(key, graph)
+		logger.WithFields(log.Fields{"nodes": len(graph.Nodes), "edges": len(graph.Edges)}).Debug("Built topology")
+	}
+	return graph, nil
+}
+
+// topologyComments lists the truncation and the warnings of a graph as comment lines of the rendered format
+func topologyComments(graph *sys.DependencyGraph, prefix string) string {
+	var sb strings.Builder
+	if graph.Truncated {
+		sb.WriteString(prefix + "truncated: listings stopped at the limit, nodes are missing\n")
+	}
+	for _, warning := range graph.Warnings {
+		sb.WriteString(prefix + strings.ReplaceAll(warning, "\n", " ") + "\n")
+	}
+	return sb.String()
+}
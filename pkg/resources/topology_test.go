@@ -0,0 +1,76 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopologyResources(t *testing.T) {
+	var mountsRead atomic.Int32
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		reply := func(data interface{}) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+		}
+
+		switch strings.TrimSuffix(r.URL.Path, "/") {
+		case "/v1/sys/mounts":
+			mountsRead.Add(1)
+			reply(map[string]interface{}{"secret/": map[string]interface{}{"type": "kv"}})
+		case "/v1/sys/auth":
+			reply(map[string]interface{}{"token/": map[string]interface{}{"type": "token"}})
+		case "/v1/sys/policies/acl":
+			reply(map[string]interface{}{"keys": []string{}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockVault.Close()
+
+	sessionID := "test-" + t.Name()
+	_, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	defer client.DeleteVaultClient(sessionID)
+
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+	ctx := server.NewMCPServer("test", "1.0").WithContext(context.Background(), testSession{id: sessionID})
+
+	resources := TopologyResources(logger)
+	require.Len(t, resources, 2)
+	reads := mountsRead.Load()
+
+	contents, err := resources[0].Handler(ctx, mcp.ReadResourceRequest{Params: mcp.ReadResourceParams{URI: TopologyMermaidURI}})
+	require.NoError(t, err)
+	text := contents[0].(mcp.TextResourceContents)
+	assert.Equal(t, "text/vnd.mermaid", text.MIMEType)
+	assert.True(t, strings.HasPrefix(text.Text, "%% unable to read replication status"), "the replication status of the mock Vault is missing")
+	assert.Contains(t, text.Text, "flowchart LR\n")
+	assert.Contains(t, text.Text, `[("secret (kv)")]`)
+	assert.Contains(t, text.Text, `[\"auth/token (token)"/]`)
+
+	contents, err = resources[1].Handler(ctx, mcp.ReadResourceRequest{Params: mcp.ReadResourceParams{URI: TopologyDOTURI}})
+	require.NoError(t, err)
+	text = contents[0].(mcp.TextResourceContents)
+	assert.Equal(t, "text/vnd.graphviz", text.MIMEType)
+	assert.True(t, strings.HasPrefix(text.Text, "// unable to read replication status"))
+	assert.Contains(t, text.Text, "digraph vault {")
+	assert.Contains(t, text.Text, `"secrets_engine:secret" [label="secret (kv)", shape=cylinder];`)
+
+	assert.Equal(t, reads+2, mountsRead.Load(), "both formats use the same cached topology")
+}
@@ -0,0 +1,54 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// TranscriptURI is the URI of the transcript of the calling session
+const TranscriptURI = "vault://session/transcript"
+
+// TranscriptResource creates a resource serving the transcript of the calling session: its tool calls with
+// sensitive arguments hidden, the status, duration and size of their results, and their approvals. A session
+// can only read its own transcript, reviewers read the others through the approval endpoint.
+func TranscriptResource(logger *log.Logger) server.ServerResource {
+	return server.ServerResource{
+		Resource: mcp.NewResource(TranscriptURI, "Session transcript",
+			mcp.WithResourceDescription("Transcript of the tool calls of this session for compliance review: tools, arguments with sensitive values hidden, result status and size, and approvals. Results themselves are never recorded."),
+			mcp.WithMIMEType("application/json"),
+		),
+		Handler: func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			logger.WithField("uri", req.Params.URI).Debug("Handling resource read request")
+
+			session := server.ClientSessionFromContext(ctx)
+			if session == nil {
+				return nil, fmt.Errorf("the session transcript is only available in a session")
+			}
+			transcript, ok := client.SessionTranscript(session.SessionID())
+			if !ok {
+				transcript = client.Transcript{SessionID: session.SessionID(), Entries: []client.TranscriptEntry{}}
+			}
+
+			jsonData, err := json.Marshal(transcript)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal session transcript: %v", err)
+			}
+			return []mcp.ResourceContents{
+				mcp.TextResourceContents{
+					URI:      req.Params.URI,
+					MIMEType: "application/json",
+					Text:     string(jsonData),
+				},
+			}, nil
+		},
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranscriptResource(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	message, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "resources/read",
+		"params":  map[string]any{"uri": TranscriptURI},
+	})
+	require.NoError(t, err)
+
+	srv := server.NewMCPServer("test", "1.0", server.WithResourceCapabilities(false, false))
+	InitResources(srv, logger)
+	_, isError := srv.HandleMessage(srv.WithContext(context.Background(), testSession{id: "disabled"}), message).(mcp.JSONRPCError)
+	assert.True(t, isError, "not registered while transcripts are disabled")
+
+	client.SetTranscripts(true)
+	defer client.SetTranscripts(false)
+	srv = server.NewMCPServer("test", "1.0", server.WithResourceCapabilities(false, false))
+	InitResources(srv, logger)
+
+	sessionID := "test-" + t.Name()
+	client.RecordTranscriptEntry(sessionID, client.TranscriptEntry{Type: client.TranscriptToolCall, Tool: "read_secret", Status: client.TranscriptStatusOK})
+	client.RecordTranscriptEntry("other-session", client.TranscriptEntry{Type: client.TranscriptToolCall, Tool: "delete_secret", Status: client.TranscriptStatusOK})
+	defer client.EndTranscript(sessionID)
+	defer client.EndTranscript("other-session")
+
+	read := func(t *testing.T, ctx context.Context) client.Transcript {
+		response, ok := srv.HandleMessage(ctx, message).(mcp.JSONRPCResponse)
+		require.True(t, ok)
+		result := response.Result.(mcp.ReadResourceResult)
+		require.Len(t, result.Contents, 1)
+		var transcript client.Transcript
+		require.NoError(t, json.Unmarshal([]byte(result.Contents[0].(mcp.TextResourceContents).Text), &transcript))
+		return transcript
+	}
+
+	t.Run("a session reads only its own transcript", func(t *testing.T) {
+		transcript := read(t, srv.WithContext(context.Background(), testSession{id: sessionID}))
+		assert.Equal(t, sessionID, transcript.SessionID)
+		require.Len(t, transcript.Entries, 1)
+		assert.Equal(t, "read_secret", transcript.Entries[0].Tool)
+	})
+
+	t.Run("sessions without calls read an empty transcript", func(t *testing.T) {
+		transcript := read(t, srv.WithContext(context.Background(), testSession{id: "new-session"}))
+		assert.Equal(t, "new-session", transcript.SessionID)
+		assert.Empty(t, transcript.Entries)
+	})
+}
@@ -0,0 +1,167 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule decides when a job runs
+type Schedule interface {
+	// Next returns the first time after t that the job runs
+	Next(t time.Time) time.Time
+}
+
+// everySchedule runs a job at a fixed interval
+type everySchedule struct {
+	interval time.Duration
+}
+
+func (s everySchedule) Next(t time.Time) time.Time {
+	return t.Add(s.interval)
+}
+
+// cronSchedule runs a job at the minutes matching a five field cron expression
+type cronSchedule struct {
+	minutes, hours, days, months, weekdays uint64 // Bit sets of the matching values
+	anyDay, anyWeekday                     bool   // The day of month or day of week field is '*'
+}
+
+// maxCronSearch bounds the search of the next run, expressions like '0 0 30 2 *' never match
+const maxCronSearch = 5 * 366 * 24 * time.Hour
+
+func (s cronSchedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxCronSearch)
+	for t.Before(limit) {
+		switch {
+		case s.months&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case s.hours&(1<<uint(t.Hour())) == 0:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case s.minutes&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// matchesDay applies the rule of cron that a day matches either field when both are restricted
+func (s cronSchedule) matchesDay(t time.Time) bool {
+	day := s.days&(1<<uint(t.Day())) != 0
+	weekday := s.weekdays&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.anyDay && s.anyWeekday:
+		return true
+	case s.anyDay:
+		return weekday
+	case s.anyWeekday:
+		return day
+	default:
+		return day || weekday
+	}
+}
+
+// ParseSchedule parses a five field cron expression (minute, hour, day of month, month, day of week) such
+// as '0 6 * * 1-5', one of the macros @hourly, @daily, @weekly and @monthly, or a fixed interval such as
+// '@every 30m'. Times are in UTC.
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	switch spec {
+	case "@hourly":
+		spec = "0 * * * *"
+	case "@daily", "@midnight":
+		spec = "0 0 * * *"
+	case "@weekly":
+		spec = "0 0 * * 0"
+	case "@monthly":
+		spec = "0 0 1 * *"
+	}
+
+	if interval, ok := strings.CutPrefix(spec, "@every "); ok {
+		duration, err := time.ParseDuration(strings.TrimSpace(interval))
+		if err != nil || duration < time.Minute {
+			return nil, fmt.Errorf("invalid interval '%s', expected a duration of at least 1m", interval)
+		}
+		return everySchedule{interval: duration}, nil
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid schedule '%s', expected five cron fields or @every <duration>", spec)
+	}
+
+	var schedule cronSchedule
+	var err error
+	if schedule.minutes, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("invalid minute field: %w", err)
+	}
+	if schedule.hours, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("invalid hour field: %w", err)
+	}
+	if schedule.days, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("invalid day of month field: %w", err)
+	}
+	if schedule.months, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("invalid month field: %w", err)
+	}
+	if schedule.weekdays, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("invalid day of week field: %w", err)
+	}
+	// Sunday is both 0 and 7
+	if schedule.weekdays&(1<<7) != 0 {
+		schedule.weekdays |= 1
+	}
+	schedule.anyDay = fields[2] == "*"
+	schedule.anyWeekday = fields[4] == "*"
+	return schedule, nil
+}
+
+// parseCronField parses a comma-separated list of '*', values and ranges with an optional step, such as
+// '*/15' or '1-5,10'
+func parseCronField(field string, minValue int, maxValue int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step '%s'", stepPart)
+			}
+		}
+
+		start, end := minValue, maxValue
+		if rangePart != "*" {
+			low, high, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if start, err = strconv.Atoi(low); err != nil {
+				return 0, fmt.Errorf("invalid value '%s'", low)
+			}
+			end = start
+			if isRange {
+				if end, err = strconv.Atoi(high); err != nil {
+					return 0, fmt.Errorf("invalid value '%s'", high)
+				}
+			} else if hasStep {
+				end = maxValue
+			}
+		}
+		if start < minValue || end > maxValue || start > end {
+			return 0, fmt.Errorf("'%s' is out of range %d-%d", part, minValue, maxValue)
+		}
+
+		for value := start; value <= end; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
@@ -0,0 +1,45 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	// A Wednesday
+	start := time.Date(2025, 1, 15, 10, 30, 45, 0, time.UTC)
+
+	for spec, expected := range map[string]time.Time{
+		"*/15 * * * *":   time.Date(2025, 1, 15, 10, 45, 0, 0, time.UTC),
+		"0 6 * * *":      time.Date(2025, 1, 16, 6, 0, 0, 0, time.UTC),
+		"0 6 * * 1-5":    time.Date(2025, 1, 16, 6, 0, 0, 0, time.UTC),
+		"0 6 * * 7":      time.Date(2025, 1, 19, 6, 0, 0, 0, time.UTC),
+		"0 0 1 */3 *":    time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+		"0 0 20 * 1":     time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC),
+		"30,45 10 * * *": time.Date(2025, 1, 15, 10, 45, 0, 0, time.UTC),
+		"@hourly":        time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC),
+		"@daily":         time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC),
+		"@weekly":        time.Date(2025, 1, 19, 0, 0, 0, 0, time.UTC),
+		"@monthly":       time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		"@every 90m":     start.Add(90 * time.Minute),
+	} {
+		schedule, err := ParseSchedule(spec)
+		require.NoError(t, err, spec)
+		assert.Equal(t, expected, schedule.Next(start), spec)
+	}
+
+	never, err := ParseSchedule("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, never.Next(start).IsZero())
+
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "@every 10s", "@every soon", "@yearly"} {
+		_, err := ParseSchedule(spec)
+		assert.Error(t, err, spec)
+	}
+}
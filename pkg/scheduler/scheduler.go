@@ -0,0 +1,436 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Package scheduler runs read-only tools periodically on the server and publishes their latest results as
+// MCP resources, so that clients read fresh analyses without running expensive tools themselves.
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// ScheduleFileEnv is the path of the JSON file that declares the scheduled jobs
+	ScheduleFileEnv = "MCP_SCHEDULE_FILE"
+	// SessionID is the session of the Vault client used by the jobs, created from VAULT_ADDR and VAULT_TOKEN
+	SessionID = "scheduler"
+
+	// DefaultJobTimeout is how long a job may run when its timeout is not set
+	DefaultJobTimeout = 5 * time.Minute
+	// JobsURI is the URI of the resource listing the jobs and their last run
+	JobsURI = "vault://jobs"
+
+	StatusPending = "pending" // The job has not run yet
+	StatusOK      = "ok"      // The last run succeeded
+	StatusError   = "error"   // The last run failed or the tool returned an error
+)
+
+// SchedulableTools are the tools that jobs may call. Jobs run with the token of the server and their results
+// can be read by every client, so only analyses that report findings rather than Vault data can be scheduled.
+var SchedulableTools = []string{
+	"analyze_deprecations",
+	"analyze_security_health",
+	"analyze_server_config",
+	"check_mount_protection",
+	"check_rotation_sla",
+	"check_secret_compliance",
+	"check_upgrade_readiness",
+	"estimate_mount_usage",
+}
+
+// jobNamePattern restricts job names to what can be used in a resource URI without escaping
+var jobNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// JobConfig declares a tool that runs on a schedule
+type JobConfig struct {
+	Name      string         `json:"name"`      // Name of the job, its result is served as vault://jobs/<name>
+	Tool      string         `json:"tool"`      // Name of a read-only tool
+	Schedule  string         `json:"schedule"`  // Cron expression, macro such as @daily, or @every <duration>
+	Arguments map[string]any `json:"arguments"` // Arguments of the tool call
+	Timeout   string         `json:"timeout"`   // How long a run may take, 5m by default
+
+	Notify          []string `json:"notify"`           // Sinks notified of the findings of the job, every sink by default
+	DefaultSeverity string   `json:"default_severity"` // Severity of findings without one, medium by default
+
+	schedule Schedule
+	timeout  time.Duration
+}
+
+// Config holds the scheduled jobs and the sinks notified of their findings
+type Config struct {
+	Jobs  []*JobConfig  `json:"jobs"`
+	Sinks []*SinkConfig `json:"sinks"`
+}
+
+// LoadConfigFromEnv loads the scheduled jobs from the file in MCP_SCHEDULE_FILE. It returns nil when no jobs
+// are scheduled.
+func LoadConfigFromEnv() (*Config, error) {
+	file := os.Getenv(ScheduleFileEnv)
+	if file == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read schedule file %s: %w", file, err)
+	}
+
+	return ParseConfig(data)
+}
+
+// ParseConfig parses and validates a JSON schedule
+func ParseConfig(data []byte) (*Config, error) {
+	config := &Config{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("invalid schedule: %w", err)
+	}
+	if len(config.Jobs) == 0 {
+		return nil, errors.New("schedule has no jobs")
+	}
+
+	sinks := map[string]bool{}
+	for _, sink := range config.Sinks {
+		if sink == nil || !jobNamePattern.MatchString(sink.Name) {
+			return nil, errors.New("every sink needs a name of lower case letters, digits, '-' and '_'")
+		}
+		if sinks[sink.Name] {
+			return nil, fmt.Errorf("duplicate sink name '%s'", sink.Name)
+		}
+		sinks[sink.Name] = true
+		if err := sink.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	names := map[string]bool{}
+	for _, job := range config.Jobs {
+		if job == nil || !jobNamePattern.MatchString(job.Name) {
+			return nil, errors.New("every job needs a name of lower case letters, digits, '-' and '_'")
+		}
+		if names[job.Name] {
+			return nil, fmt.Errorf("duplicate job name '%s'", job.Name)
+		}
+		names[job.Name] = true
+
+		if job.Tool == "" {
+			return nil, fmt.Errorf("job '%s' has no tool", job.Name)
+		}
+		if !slices.Contains(SchedulableTools, job.Tool) {
+			return nil, fmt.Errorf("job '%s': tool '%s' cannot be scheduled, use one of %s", job.Name, job.Tool, strings.Join(SchedulableTools, ", "))
+		}
+		schedule, err := ParseSchedule(job.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("job '%s': %w", job.Name, err)
+		}
+		job.schedule = schedule
+
+		job.timeout = DefaultJobTimeout
+		if job.Timeout != "" {
+			if job.timeout, err = time.ParseDuration(job.Timeout); err != nil || job.timeout <= 0 {
+				return nil, fmt.Errorf("job '%s': invalid timeout '%s'", job.Name, job.Timeout)
+			}
+		}
+
+		for _, sink := range job.Notify {
+			if !sinks[sink] {
+				return nil, fmt.Errorf("job '%s': unknown sink '%s'", job.Name, sink)
+			}
+		}
+		if job.DefaultSeverity == "" {
+			job.DefaultSeverity = DefaultFindingSeverity
+		}
+		if _, ok := severityRanks[job.DefaultSeverity]; !ok {
+			return nil, fmt.Errorf("job '%s': unknown severity '%s'", job.Name, job.DefaultSeverity)
+		}
+	}
+
+	return config, nil
+}
+
+// JobResult is the latest run of a job, served as the resource of the job
+type JobResult struct {
+	Job        string          `json:"job"`
+	Tool       string          `json:"tool"`
+	Schedule   string          `json:"schedule"`
+	Status     string          `json:"status"`
+	LastRun    *time.Time      `json:"last_run,omitempty"`
+	DurationMs int64           `json:"duration_ms,omitempty"`
+	NextRun    *time.Time      `json:"next_run,omitempty"`
+	Result     json.RawMessage `json:"result,omitempty"` // Result of the tool, as JSON
+	Error      string          `json:"error,omitempty"`
+}
+
+// Scheduler runs the jobs of a schedule against the tools of an MCP server
+type Scheduler struct {
+	server     *server.MCPServer
+	jobs       []*JobConfig
+	sinks      []*SinkConfig
+	logger     *log.Logger
+	now        func() time.Time
+	httpClient *http.Client
+	mu         sync.Mutex
+	results    map[string]*JobResult
+	notified   map[string]notifiedFindings // Findings last sent, by job and sink
+}
+
+// New creates a scheduler for the jobs of the config. Every job must call a read-only tool of the server.
+func New(config *Config, hcServer *server.MCPServer, logger *log.Logger) (*Scheduler, error) {
+	s := &Scheduler{
+		server:     hcServer,
+		jobs:       config.Jobs,
+		sinks:      config.Sinks,
+		logger:     logger,
+		now:        time.Now,
+		httpClient: &http.Client{Timeout: sinkTimeout},
+		results:    map[string]*JobResult{},
+		notified:   map[string]notifiedFindings{},
+	}
+
+	for _, job := range config.Jobs {
+		tool := hcServer.GetTool(job.Tool)
+		if tool == nil {
+			return nil, fmt.Errorf("job '%s': unknown tool '%s'", job.Name, job.Tool)
+		}
+		if readOnly := tool.Tool.Annotations.ReadOnlyHint; readOnly == nil || !*readOnly {
+			return nil, fmt.Errorf("job '%s': tool '%s' is not read-only, only read-only tools can be scheduled", job.Name, job.Tool)
+		}
+		s.results[job.Name] = &JobResult{Job: job.Name, Tool: job.Tool, Schedule: job.Schedule, Status: StatusPending}
+	}
+
+	return s, nil
+}
+
+// JobURI returns the URI of the resource serving the latest result of a job
+func JobURI(name string) string {
+	return JobsURI + "/" + name
+}
+
+// Resources returns the resources serving the list of jobs and the latest result of every job. With
+// multi-tenancy, a tenant only sees the jobs whose tool it may call in the namespace of the job.
+func (s *Scheduler) Resources() []server.ServerResource {
+	resources := []server.ServerResource{{
+		Resource: mcp.NewResource(JobsURI, "Scheduled jobs",
+			mcp.WithResourceDescription("The jobs that run read-only tools on a schedule, with the status and time of their last and next run."),
+			mcp.WithMIMEType("application/json"),
+		),
+		Handler: func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			profile := client.TenantProfileFromContext(ctx)
+			jobs := make([]JobResult, 0, len(s.jobs))
+			for _, job := range s.jobs {
+				if profile != nil && !allowsJob(profile, job) {
+					continue
+				}
+				result := s.Result(job.Name)
+				result.Result = nil
+				jobs = append(jobs, result)
+			}
+			sort.Slice(jobs, func(i, j int) bool { return jobs[i].Job < jobs[j].Job })
+			return jsonContents(JobsURI, jobs)
+		},
+	}}
+
+	for _, job := range s.jobs {
+		uri := JobURI(job.Name)
+		client.GuardResource(uri, func(profile *client.TenantProfile) bool {
+			return allowsJob(profile, job)
+		})
+		resources = append(resources, server.ServerResource{
+			Resource: mcp.NewResource(uri, "Scheduled job "+job.Name,
+				mcp.WithResourceDescription(fmt.Sprintf("Latest result of %s, run on the schedule '%s'.", job.Tool, job.Schedule)),
+				mcp.WithMIMEType("application/json"),
+			),
+			Handler: func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+				return jsonContents(uri, s.Result(job.Name))
+			},
+		})
+	}
+	return resources
+}
+
+// allowsJob reports whether a tenant profile may read the result of a job: it must be allowed to call the tool
+// of the job in the namespace the job runs in
+func allowsJob(profile *client.TenantProfile, job *JobConfig) bool {
+	namespace, _ := job.Arguments["namespace"].(string)
+	if namespace == "" {
+		namespace = os.Getenv(client.VaultNamespace)
+	}
+	return profile.AllowsTool(job.Tool, true) && profile.AllowsNamespace(namespace)
+}
+
+// Result returns a copy of the latest result of a job
+func (s *Scheduler) Result(name string) JobResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if result, ok := s.results[name]; ok {
+		return *result
+	}
+	return JobResult{Job: name, Status: StatusPending}
+}
+
+// Run runs every job on its schedule until the context is cancelled
+func (s *Scheduler) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, job := range s.jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.runJob(ctx, job)
+		}()
+	}
+	wg.Wait()
+}
+
+// runJob runs a job each time its schedule is due. Runs of a job never overlap.
+func (s *Scheduler) runJob(ctx context.Context, job *JobConfig) {
+	for {
+		next := job.schedule.Next(s.now().UTC())
+		if next.IsZero() {
+			s.logger.WithField("job", job.Name).Warn("Schedule of the job never matches, the job does not run")
+			return
+		}
+		s.setNextRun(job.Name, next)
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		s.RunJob(ctx, job.Name)
+	}
+}
+
+func (s *Scheduler) setNextRun(name string, next time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results[name].NextRun = &next
+}
+
+// RunJob runs a job once, stores its result, notifies the clients that the result changed and sends its
+// findings to the sinks of the job
+func (s *Scheduler) RunJob(ctx context.Context, name string) {
+	var job *JobConfig
+	for _, candidate := range s.jobs {
+		if candidate.Name == name {
+			job = candidate
+		}
+	}
+	if job == nil {
+		return
+	}
+
+	logger := s.logger.WithFields(log.Fields{"job": job.Name, "tool": job.Tool})
+	logger.Debug("Running scheduled job")
+
+	ctx, cancel := context.WithTimeout(ctx, job.timeout)
+	defer cancel()
+	ctx = s.server.WithContext(ctx, jobSession{})
+
+	started := s.now()
+	status, result, errMessage := s.callTool(ctx, job)
+	finished := s.now()
+
+	s.mu.Lock()
+	stored := s.results[job.Name]
+	stored.Status = status
+	stored.LastRun = &finished
+	stored.DurationMs = finished.Sub(started).Milliseconds()
+	stored.Result = result
+	stored.Error = errMessage
+	snapshot := *stored
+	s.mu.Unlock()
+
+	if status == StatusOK {
+		logger.WithField("duration_ms", finished.Sub(started).Milliseconds()).Info("Scheduled job completed")
+	} else {
+		logger.WithField("error", errMessage).Warn("Scheduled job failed")
+	}
+
+	s.server.SendNotificationToAllClients(mcp.MethodNotificationResourceUpdated, map[string]any{"uri": JobURI(job.Name)})
+	s.server.SendNotificationToAllClients(mcp.MethodNotificationResourcesListChanged, nil)
+
+	// Notifications get their own deadline, a run may have used up the timeout of the job
+	s.notifySinks(context.WithoutCancel(ctx), job, snapshot)
+}
+
+// callTool calls the tool of a job and returns the status, the result as JSON and the error of the run
+func (s *Scheduler) callTool(ctx context.Context, job *JobConfig) (string, json.RawMessage, string) {
+	tool := s.server.GetTool(job.Tool)
+	if tool == nil {
+		return StatusError, nil, fmt.Sprintf("tool '%s' is not registered", job.Tool)
+	}
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = job.Tool
+	request.Params.Arguments = job.Arguments
+	if request.Params.Arguments == nil {
+		request.Params.Arguments = map[string]any{}
+	}
+
+	// Jobs run outside of the limits of client sessions, but honour the namespace argument and read routing
+	handler := client.NamespaceMiddleware(s.logger)(client.ReadRoutingMiddleware()(tool.Handler))
+	result, err := handler(ctx, request)
+	if err != nil {
+		return StatusError, nil, err.Error()
+	}
+	if result == nil {
+		return StatusError, nil, "tool returned no result"
+	}
+
+	text := ""
+	for _, content := range result.Content {
+		if textContent, ok := content.(mcp.TextContent); ok {
+			text += textContent.Text
+		}
+	}
+	if result.IsError {
+		return StatusError, nil, text
+	}
+
+	// Results that are not JSON, such as plain text summaries, are stored as a JSON string
+	if json.Valid([]byte(text)) {
+		return StatusOK, json.RawMessage(text), ""
+	}
+	quoted, _ := json.Marshal(text)
+	return StatusOK, quoted, ""
+}
+
+func jsonContents(uri string, value any) ([]mcp.ResourceContents, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal '%s': %v", uri, err)
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: "application/json",
+			Text:     string(data),
+		},
+	}, nil
+}
+
+// jobSession is the client session of the tool calls of jobs, which use the Vault client created for
+// SessionID from the environment of the server
+type jobSession struct{}
+
+func (jobSession) SessionID() string                                   { return SessionID }
+func (jobSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (jobSession) Initialize()                                         {}
+func (jobSession) Initialized() bool                                   { return true }
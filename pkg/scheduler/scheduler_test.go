@@ -0,0 +1,230 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSession is a client session that keeps the notifications sent to it
+type testSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s testSession) SessionID() string                                   { return "test-scheduler-client" }
+func (s testSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return s.notifications }
+func (s testSession) Initialize()                                         {}
+func (s testSession) Initialized() bool                                   { return true }
+
+func TestParseConfig(t *testing.T) {
+	config, err := ParseConfig([]byte(`{"jobs": [
+		{"name": "security", "tool": "analyze_security_health", "schedule": "@daily"},
+		{"name": "rotation", "tool": "check_rotation_sla", "schedule": "0 6 * * 1", "arguments": {"slas": {"prod/**": "90d"}}, "timeout": "10m"}
+	]}`))
+	require.NoError(t, err)
+	require.Len(t, config.Jobs, 2)
+	assert.Equal(t, DefaultJobTimeout, config.Jobs[0].timeout)
+	assert.Equal(t, 10*time.Minute, config.Jobs[1].timeout)
+	assert.Equal(t, map[string]any{"prod/**": "90d"}, config.Jobs[1].Arguments["slas"])
+
+	for name, data := range map[string]string{
+		"no jobs":          `{"jobs": []}`,
+		"invalid name":     `{"jobs": [{"name": "Security Health", "tool": "check_rotation_sla", "schedule": "@daily"}]}`,
+		"duplicate name":   `{"jobs": [{"name": "a", "tool": "check_rotation_sla", "schedule": "@daily"}, {"name": "a", "tool": "check_rotation_sla", "schedule": "@daily"}]}`,
+		"missing tool":     `{"jobs": [{"name": "a", "schedule": "@daily"}]}`,
+		"tool not allowed": `{"jobs": [{"name": "a", "tool": "read_secret", "schedule": "@daily"}]}`,
+		"invalid schedule": `{"jobs": [{"name": "a", "tool": "check_rotation_sla", "schedule": "daily"}]}`,
+		"invalid timeout":  `{"jobs": [{"name": "a", "tool": "check_rotation_sla", "schedule": "@daily", "timeout": "-1m"}]}`,
+		"invalid json":     `{"jobs": `,
+	} {
+		_, err := ParseConfig([]byte(data))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestScheduler(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	hcServer := server.NewMCPServer("test", "1.0.0", server.WithResourceCapabilities(true, true))
+	readOnly := mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: utils.ToBoolPtr(true)})
+	fail := false
+	hcServer.AddTool(mcp.NewTool("analyze_security_health", readOnly), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if server.ClientSessionFromContext(ctx).SessionID() != SessionID {
+			return nil, errors.New("jobs run in the scheduler session")
+		}
+		if fail {
+			return mcp.NewToolResultError("Vault is sealed"), nil
+		}
+		data, _ := json.Marshal(map[string]any{"findings": 2, "threshold": request.GetString("threshold", "")})
+		return mcp.NewToolResultText(string(data)), nil
+	})
+	hcServer.AddTool(mcp.NewTool("check_mount_protection"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("written"), nil
+	})
+
+	t.Run("only read-only tools of the server can be scheduled", func(t *testing.T) {
+		for _, tool := range []string{"check_mount_protection", "check_upgrade_readiness"} {
+			config, err := ParseConfig([]byte(`{"jobs": [{"name": "a", "tool": "` + tool + `", "schedule": "@daily"}]}`))
+			require.NoError(t, err)
+			_, err = New(config, hcServer, logger)
+			assert.Error(t, err, tool)
+		}
+	})
+
+	config, err := ParseConfig([]byte(`{"jobs": [{"name": "health", "tool": "analyze_security_health", "schedule": "@hourly", "arguments": {"threshold": "high"}}]}`))
+	require.NoError(t, err)
+	jobs, err := New(config, hcServer, logger)
+	require.NoError(t, err)
+	hcServer.AddResources(jobs.Resources()...)
+
+	session := testSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+	require.NoError(t, hcServer.RegisterSession(context.Background(), session))
+	defer hcServer.UnregisterSession(context.Background(), session.SessionID())
+	ctx := hcServer.WithContext(context.Background(), session)
+
+	read := func(t *testing.T, uri string) string {
+		message, err := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"method":  "resources/read",
+			"params":  map[string]any{"uri": uri},
+		})
+		require.NoError(t, err)
+		response, ok := hcServer.HandleMessage(ctx, message).(mcp.JSONRPCResponse)
+		require.True(t, ok, "failed to read %s", uri)
+		return response.Result.(mcp.ReadResourceResult).Contents[0].(mcp.TextResourceContents).Text
+	}
+
+	t.Run("results are pending until the first run", func(t *testing.T) {
+		var result JobResult
+		require.NoError(t, json.Unmarshal([]byte(read(t, JobURI("health"))), &result))
+		assert.Equal(t, StatusPending, result.Status)
+		assert.Nil(t, result.LastRun)
+	})
+
+	t.Run("runs publish their result and notify clients", func(t *testing.T) {
+		jobs.RunJob(context.Background(), "health")
+
+		var result JobResult
+		require.NoError(t, json.Unmarshal([]byte(read(t, JobURI("health"))), &result))
+		assert.Equal(t, StatusOK, result.Status, result.Error)
+		assert.NotNil(t, result.LastRun)
+		assert.JSONEq(t, `{"findings": 2, "threshold": "high"}`, string(result.Result))
+
+		methods := map[string]any{}
+		for len(methods) < 2 {
+			select {
+			case notification := <-session.notifications:
+				methods[notification.Method] = notification.Params.AdditionalFields["uri"]
+			case <-time.After(time.Second):
+				t.Fatalf("clients were not notified: %v", methods)
+			}
+		}
+		assert.Equal(t, JobURI("health"), methods[mcp.MethodNotificationResourceUpdated])
+		assert.Contains(t, methods, mcp.MethodNotificationResourcesListChanged)
+	})
+
+	t.Run("failed runs keep the error", func(t *testing.T) {
+		fail = true
+		defer func() { fail = false }()
+		jobs.RunJob(context.Background(), "health")
+
+		var list []JobResult
+		require.NoError(t, json.Unmarshal([]byte(read(t, JobsURI)), &list))
+		require.Len(t, list, 1)
+		assert.Equal(t, StatusError, list[0].Status)
+		assert.Equal(t, "Vault is sealed", list[0].Error)
+		assert.Nil(t, list[0].Result)
+	})
+
+	t.Run("jobs run on their schedule until cancelled", func(t *testing.T) {
+		runCtx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			jobs.Run(runCtx)
+			close(done)
+		}()
+
+		require.Eventually(t, func() bool { return jobs.Result("health").NextRun != nil }, time.Second, 10*time.Millisecond)
+		assert.Equal(t, 0, jobs.Result("health").NextRun.Minute())
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("scheduler did not stop")
+		}
+	})
+}
+
+func TestSchedulerTenancy(t *testing.T) {
+	t.Setenv(client.VaultNamespace, "team-a")
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	hcServer := server.NewMCPServer("test", "1.0.0", server.WithResourceCapabilities(true, true))
+	readOnly := mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: utils.ToBoolPtr(true)})
+	for _, name := range []string{"analyze_security_health", "check_rotation_sla"} {
+		hcServer.AddTool(mcp.NewTool(name, readOnly), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(`{"findings": 0}`), nil
+		})
+	}
+
+	config, err := ParseConfig([]byte(`{"jobs": [
+		{"name": "security", "tool": "analyze_security_health", "schedule": "@daily"},
+		{"name": "rotation", "tool": "check_rotation_sla", "schedule": "@daily"},
+		{"name": "rotation-b", "tool": "check_rotation_sla", "schedule": "@daily", "arguments": {"namespace": "team-b"}}
+	]}`))
+	require.NoError(t, err)
+	jobs, err := New(config, hcServer, logger)
+	require.NoError(t, err)
+	hcServer.AddResources(jobs.Resources()...)
+
+	tenancy, err := client.ParseTenancyConfig([]byte(`{"profiles": [
+		{"name": "team-a", "api_keys": ["key-a"], "allowed_tools": ["check_*"], "allowed_namespaces": ["team-a"], "allowed_resources": ["vault://jobs", "vault://jobs/*"]},
+		{"name": "admins", "api_keys": ["key-admin"]}
+	]}`))
+	require.NoError(t, err)
+	teamA, admins := tenancy.Profiles[0], tenancy.Profiles[1]
+
+	assert.True(t, teamA.AllowsResource(JobURI("rotation")))
+	assert.False(t, teamA.AllowsResource(JobURI("security")), "the tenant may not call the tool of the job")
+	assert.False(t, teamA.AllowsResource(JobURI("rotation-b")), "the job runs in a namespace of another tenant")
+	assert.True(t, admins.AllowsResource(JobURI("rotation-b")))
+
+	listJobs := func(t *testing.T, apiKey string) []string {
+		ctx, _, err := client.TenantContextFromHeaders(context.Background(), tenancy, nil, "", func(name string) string {
+			if name == client.HeaderAPIKey {
+				return apiKey
+			}
+			return ""
+		})
+		require.NoError(t, err)
+		response, ok := hcServer.HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":"vault://jobs"}}`)).(mcp.JSONRPCResponse)
+		require.True(t, ok)
+
+		var list []JobResult
+		require.NoError(t, json.Unmarshal([]byte(response.Result.(mcp.ReadResourceResult).Contents[0].(mcp.TextResourceContents).Text), &list))
+		names := []string{}
+		for _, job := range list {
+			names = append(names, job.Job)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"rotation"}, listJobs(t, "key-a"))
+	assert.Equal(t, []string{"rotation", "rotation-b", "security"}, listJobs(t, "key-admin"))
+}
@@ -0,0 +1,290 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	SinkWebhook = "webhook" // Posts the findings as JSON
+	SinkSlack   = "slack"   // Posts a message to a Slack incoming webhook
+
+	// DefaultMinSeverity is the lowest severity a sink is notified of when its min_severity is not set
+	DefaultMinSeverity = "high"
+	// DefaultFindingSeverity is the severity of findings that have none, such as rotation SLA violations
+	DefaultFindingSeverity = "medium"
+	// DefaultRepeatInterval is how long a sink is not notified again of the same findings
+	DefaultRepeatInterval = 24 * time.Hour
+
+	// sinkTimeout bounds the time to deliver a notification
+	sinkTimeout = 10 * time.Second
+	// maxSlackFindings is the number of findings listed in a Slack message
+	maxSlackFindings = 20
+)
+
+// severityRanks orders the severities of findings, from the severities of the analysis tools
+var severityRanks = map[string]int{
+	"info":     0,
+	"low":      1,
+	"medium":   2,
+	"high":     3,
+	"critical": 4,
+}
+
+// SinkConfig declares where the findings of scheduled jobs are sent
+type SinkConfig struct {
+	Name           string            `json:"name"`
+	Type           string            `json:"type"`            // webhook or slack
+	URL            string            `json:"url"`             // URL to post to
+	URLEnv         string            `json:"url_env"`         // Environment variable holding the URL, for URLs that embed a secret
+	Headers        map[string]string `json:"headers"`         // Additional headers of webhook requests
+	MinSeverity    string            `json:"min_severity"`    // Lowest severity notified, high by default
+	RepeatInterval string            `json:"repeat_interval"` // How long the same findings are not notified again, 24h by default
+
+	url            string
+	repeatInterval time.Duration
+}
+
+// validate checks the sink and resolves its URL
+func (c *SinkConfig) validate() error {
+	if c.Type != SinkWebhook && c.Type != SinkSlack {
+		return fmt.Errorf("sink '%s': unknown type '%s', expected %s or %s", c.Name, c.Type, SinkWebhook, SinkSlack)
+	}
+
+	c.url = c.URL
+	if c.URLEnv != "" {
+		c.url = os.Getenv(c.URLEnv)
+	}
+	parsed, err := url.Parse(c.url)
+	if c.url == "" || err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return fmt.Errorf("sink '%s': missing or invalid URL, set url or url_env to an http(s) URL", c.Name)
+	}
+
+	if c.MinSeverity == "" {
+		c.MinSeverity = DefaultMinSeverity
+	}
+	if _, ok := severityRanks[c.MinSeverity]; !ok {
+		return fmt.Errorf("sink '%s': unknown severity '%s'", c.Name, c.MinSeverity)
+	}
+
+	c.repeatInterval = DefaultRepeatInterval
+	if c.RepeatInterval != "" {
+		if c.repeatInterval, err = time.ParseDuration(c.RepeatInterval); err != nil || c.repeatInterval < 0 {
+			return fmt.Errorf("sink '%s': invalid repeat interval '%s'", c.Name, c.RepeatInterval)
+		}
+	}
+	return nil
+}
+
+// JobFinding is a finding of a job run, taken from the 'findings' or 'violations' of the result of the tool
+type JobFinding struct {
+	Severity  string `json:"severity"`
+	Message   string `json:"message"`
+	Component string `json:"component,omitempty"`
+	Path      string `json:"path,omitempty"`
+}
+
+// JobNotification is the body posted to webhook sinks
+type JobNotification struct {
+	Job         string       `json:"job"`
+	Tool        string       `json:"tool"`
+	RunAt       time.Time    `json:"run_at"`
+	MinSeverity string       `json:"min_severity"`
+	Findings    []JobFinding `json:"findings"` // Findings of at least MinSeverity, most severe first
+	Resource    string       `json:"resource"` // URI of the resource serving the full result
+}
+
+// extractFindings returns the findings of a run. A failed run is a single high severity finding.
+func extractFindings(job *JobConfig, result JobResult) []JobFinding {
+	if result.Status == StatusError {
+		return []JobFinding{{Severity: "high", Message: fmt.Sprintf("Scheduled job %s failed: %s", job.Name, result.Error)}}
+	}
+
+	var report map[string]json.RawMessage
+	if json.Unmarshal(result.Result, &report) != nil {
+		return nil
+	}
+
+	var findings []JobFinding
+	for _, field := range []string{"findings", "violations"} {
+		var items []map[string]any
+		if json.Unmarshal(report[field], &items) != nil {
+			continue
+		}
+		for _, item := range items {
+			finding := JobFinding{Severity: job.DefaultSeverity}
+			if severity, _ := item["severity"].(string); severity != "" {
+				finding.Severity = strings.ToLower(severity)
+			}
+			finding.Message, _ = item["message"].(string)
+			finding.Component, _ = item["component"].(string)
+			finding.Path, _ = item["path"].(string)
+			if finding.Message == "" {
+				// Violations describe themselves with their fields, such as the age of an overdue secret
+				details, _ := json.Marshal(item)
+				finding.Message = string(details)
+			}
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// notifySinks sends the findings of a run that reach the severity of each sink of the job. The same findings
+// are sent to a sink again only after its repeat interval.
+func (s *Scheduler) notifySinks(ctx context.Context, job *JobConfig, result JobResult) {
+	sinks := s.jobSinks(job)
+	if len(sinks) == 0 {
+		return
+	}
+	findings := extractFindings(job, result)
+
+	for _, sink := range sinks {
+		selected := []JobFinding{}
+		for _, finding := range findings {
+			if severityRanks[finding.Severity] >= severityRanks[sink.MinSeverity] {
+				selected = append(selected, finding)
+			}
+		}
+
+		key := job.Name + "/" + sink.Name
+		fingerprint := findingsFingerprint(selected)
+		s.mu.Lock()
+		last, notified := s.notified[key]
+		if len(selected) == 0 {
+			// Findings that come back after they were resolved are notified at once
+			delete(s.notified, key)
+		}
+		s.mu.Unlock()
+		if len(selected) == 0 || (notified && last.fingerprint == fingerprint && s.now().Sub(last.at) < sink.repeatInterval) {
+			continue
+		}
+
+		sort.SliceStable(selected, func(i, j int) bool {
+			return severityRanks[selected[i].Severity] > severityRanks[selected[j].Severity]
+		})
+		notification := JobNotification{
+			Job:         job.Name,
+			Tool:        job.Tool,
+			RunAt:       *result.LastRun,
+			MinSeverity: sink.MinSeverity,
+			Findings:    selected,
+			Resource:    JobURI(job.Name),
+		}
+
+		logger := s.logger.WithFields(log.Fields{"job": job.Name, "sink": sink.Name, "findings": len(selected)})
+		if err := s.send(ctx, sink, notification); err != nil {
+			logger.WithError(err).Warn("Failed to notify sink")
+			continue
+		}
+		logger.Info("Notified sink of findings")
+
+		s.mu.Lock()
+		s.notified[key] = notifiedFindings{fingerprint: fingerprint, at: s.now()}
+		s.mu.Unlock()
+	}
+}
+
+// jobSinks returns the sinks notified of the findings of a job, every sink when the job names none
+func (s *Scheduler) jobSinks(job *JobConfig) []*SinkConfig {
+	if len(job.Notify) == 0 {
+		return s.sinks
+	}
+	var sinks []*SinkConfig
+	for _, sink := range s.sinks {
+		for _, name := range job.Notify {
+			if sink.Name == name {
+				sinks = append(sinks, sink)
+			}
+		}
+	}
+	return sinks
+}
+
+// notifiedFindings remembers the findings last sent to a sink for a job
+type notifiedFindings struct {
+	fingerprint string
+	at          time.Time
+}
+
+func findingsFingerprint(findings []JobFinding) string {
+	lines := make([]string, 0, len(findings))
+	for _, finding := range findings {
+		lines = append(lines, strings.Join([]string{finding.Severity, finding.Component, finding.Path, finding.Message}, "|"))
+	}
+	sort.Strings(lines)
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// send posts a notification to a sink
+func (s *Scheduler) send(ctx context.Context, sink *SinkConfig, notification JobNotification) error {
+	var body any = notification
+	if sink.Type == SinkSlack {
+		body = slackMessage(notification)
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sinkTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sink.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range sink.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("sink returned %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// slackMessage formats a notification for a Slack incoming webhook
+func slackMessage(notification JobNotification) map[string]string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*Vault MCP server: %d finding(s) of %s or higher from scheduled job `%s`* (%s)\n",
+		len(notification.Findings), notification.MinSeverity, notification.Job, notification.Tool)
+	for i, finding := range notification.Findings {
+		if i == maxSlackFindings {
+			fmt.Fprintf(&b, "…and %d more, read `%s` for the full result\n", len(notification.Findings)-i, notification.Resource)
+			break
+		}
+		subject := finding.Path
+		if subject == "" {
+			subject = finding.Component
+		}
+		if subject != "" {
+			subject = " `" + subject + "`"
+		}
+		fmt.Fprintf(&b, "• [%s]%s %s\n", strings.ToUpper(finding.Severity), subject, finding.Message)
+	}
+	return map[string]string{"text": b.String()}
+}
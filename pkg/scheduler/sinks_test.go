@@ -0,0 +1,159 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfigSinks(t *testing.T) {
+	t.Setenv("TEST_SLACK_URL", "https://hooks.slack.com/services/T000/B000/XXXX")
+	config, err := ParseConfig([]byte(`{
+		"sinks": [
+			{"name": "ops", "type": "webhook", "url": "https://alerts.example.com/vault", "headers": {"Authorization": "Bearer x"}},
+			{"name": "slack", "type": "slack", "url_env": "TEST_SLACK_URL", "min_severity": "critical", "repeat_interval": "1h"}
+		],
+		"jobs": [
+			{"name": "security", "tool": "analyze_security_health", "schedule": "@daily", "notify": ["slack"]},
+			{"name": "rotation", "tool": "check_rotation_sla", "schedule": "@daily", "default_severity": "high"}
+		]}`))
+	require.NoError(t, err)
+	require.Len(t, config.Sinks, 2)
+	assert.Equal(t, DefaultMinSeverity, config.Sinks[0].MinSeverity)
+	assert.Equal(t, DefaultRepeatInterval, config.Sinks[0].repeatInterval)
+	assert.Equal(t, "https://hooks.slack.com/services/T000/B000/XXXX", config.Sinks[1].url)
+	assert.Equal(t, time.Hour, config.Sinks[1].repeatInterval)
+	assert.Equal(t, DefaultFindingSeverity, config.Jobs[0].DefaultSeverity)
+	assert.Equal(t, "high", config.Jobs[1].DefaultSeverity)
+
+	job := `"jobs": [{"name": "a", "tool": "check_rotation_sla", "schedule": "@daily"}]`
+	for name, data := range map[string]string{
+		"unknown type":      `{"sinks": [{"name": "s", "type": "email", "url": "https://example.com"}], ` + job + `}`,
+		"missing url":       `{"sinks": [{"name": "s", "type": "webhook"}], ` + job + `}`,
+		"unset url env":     `{"sinks": [{"name": "s", "type": "webhook", "url_env": "TEST_UNSET_SINK_URL"}], ` + job + `}`,
+		"invalid url":       `{"sinks": [{"name": "s", "type": "webhook", "url": "ftp://example.com"}], ` + job + `}`,
+		"invalid severity":  `{"sinks": [{"name": "s", "type": "webhook", "url": "https://example.com", "min_severity": "urgent"}], ` + job + `}`,
+		"invalid repeat":    `{"sinks": [{"name": "s", "type": "webhook", "url": "https://example.com", "repeat_interval": "daily"}], ` + job + `}`,
+		"duplicate sink":    `{"sinks": [{"name": "s", "type": "webhook", "url": "https://example.com"}, {"name": "s", "type": "slack", "url": "https://example.com"}], ` + job + `}`,
+		"unknown sink":      `{"jobs": [{"name": "a", "tool": "check_rotation_sla", "schedule": "@daily", "notify": ["missing"]}]}`,
+		"job with severity": `{"jobs": [{"name": "a", "tool": "check_rotation_sla", "schedule": "@daily", "default_severity": "urgent"}]}`,
+	} {
+		_, err := ParseConfig([]byte(data))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestExtractFindings(t *testing.T) {
+	job := &JobConfig{Name: "health", DefaultSeverity: "medium"}
+
+	findings := extractFindings(job, JobResult{Status: StatusOK, Result: json.RawMessage(`{
+		"findings": [{"severity": "CRITICAL", "component": "audit", "message": "No audit device"}],
+		"violations": [{"path": "secret/db", "age_days": 120}]
+	}`)})
+	require.Len(t, findings, 2)
+	assert.Equal(t, JobFinding{Severity: "critical", Component: "audit", Message: "No audit device"}, findings[0])
+	assert.Equal(t, "medium", findings[1].Severity)
+	assert.Equal(t, "secret/db", findings[1].Path)
+	assert.Contains(t, findings[1].Message, `"age_days":120`)
+
+	assert.Empty(t, extractFindings(job, JobResult{Status: StatusOK, Result: json.RawMessage(`"all good"`)}))
+
+	failed := extractFindings(job, JobResult{Status: StatusError, Error: "Vault is sealed"})
+	require.Len(t, failed, 1)
+	assert.Equal(t, "high", failed[0].Severity)
+	assert.Contains(t, failed[0].Message, "Vault is sealed")
+}
+
+func TestNotifySinks(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	var mu sync.Mutex
+	received := map[string][]map[string]any{}
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		received[r.URL.Path] = append(received[r.URL.Path], body)
+		mu.Unlock()
+		if r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer receiver.Close()
+	count := func(path string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received[path])
+	}
+
+	hcServer := server.NewMCPServer("test", "1.0.0", server.WithResourceCapabilities(true, true))
+	severity := "critical"
+	hcServer.AddTool(mcp.NewTool("analyze_security_health", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: utils.ToBoolPtr(true)})),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			data, _ := json.Marshal(map[string]any{"findings": []map[string]any{
+				{"severity": severity, "component": "seal", "message": "Shamir seal with a single key share"},
+				{"severity": "low", "component": "ui", "message": "UI is enabled"},
+			}})
+			return mcp.NewToolResultText(string(data)), nil
+		})
+
+	config, err := ParseConfig([]byte(`{
+		"sinks": [
+			{"name": "hook", "type": "webhook", "url": "` + receiver.URL + `/hook", "min_severity": "high"},
+			{"name": "slack", "type": "slack", "url": "` + receiver.URL + `/slack", "min_severity": "critical"},
+			{"name": "down", "type": "webhook", "url": "` + receiver.URL + `/down"}
+		],
+		"jobs": [{"name": "health", "tool": "analyze_security_health", "schedule": "@daily"}]}`))
+	require.NoError(t, err)
+	jobs, err := New(config, hcServer, logger)
+	require.NoError(t, err)
+	now := time.Date(2025, 6, 1, 6, 0, 0, 0, time.UTC)
+	jobs.now = func() time.Time { return now }
+
+	jobs.RunJob(context.Background(), "health")
+	require.Equal(t, 1, count("/hook"))
+	require.Equal(t, 1, count("/slack"))
+
+	mu.Lock()
+	hook := received["/hook"][0]
+	slack := received["/slack"][0]
+	mu.Unlock()
+	assert.Equal(t, "health", hook["job"])
+	assert.Equal(t, JobURI("health"), hook["resource"])
+	require.Len(t, hook["findings"], 1, "the low finding is below the threshold")
+	assert.Equal(t, "critical", hook["findings"].([]any)[0].(map[string]any)["severity"])
+	assert.True(t, strings.Contains(slack["text"].(string), "[CRITICAL] `seal` Shamir seal"), slack["text"])
+
+	t.Run("same findings are not sent again before the repeat interval", func(t *testing.T) {
+		jobs.RunJob(context.Background(), "health")
+		assert.Equal(t, 1, count("/hook"))
+		assert.Equal(t, 2, count("/down"), "failed notifications are retried on the next run")
+
+		now = now.Add(DefaultRepeatInterval)
+		jobs.RunJob(context.Background(), "health")
+		assert.Equal(t, 2, count("/hook"))
+	})
+
+	t.Run("findings below the threshold of a sink are not sent", func(t *testing.T) {
+		severity = "high"
+		jobs.RunJob(context.Background(), "health")
+		assert.Equal(t, 3, count("/hook"), "changed findings are sent at once")
+		assert.Equal(t, 2, count("/slack"))
+	})
+}
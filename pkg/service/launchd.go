@@ -0,0 +1,88 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package service
+
+import (
+	"bytes"
+	"encoding/xml"
+	"path/filepath"
+	"sort"
+)
+
+// LaunchdLabel returns the label of the launchd agent of the named service
+func LaunchdLabel(name string) string {
+	return "com.hashicorp." + name
+}
+
+// LaunchdPlistPath returns the path of the property list of the launchd agent of the named service
+func LaunchdPlistPath(home string, name string) string {
+	return filepath.Join(home, "Library", "LaunchAgents", LaunchdLabel(name)+".plist")
+}
+
+// LaunchdPlist renders the property list of a launchd agent running the server at login and restarting
+// it when it exits
+func LaunchdPlist(cfg Config) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n")
+	b.WriteString(`<plist version="1.0">` + "\n<dict>\n")
+
+	writeKey := func(key string) {
+		b.WriteString("\t<key>")
+		_ = xml.EscapeText(&b, []byte(key))
+		b.WriteString("</key>\n")
+	}
+	writeString := func(indent string, value string) {
+		b.WriteString(indent + "<string>")
+		_ = xml.EscapeText(&b, []byte(value))
+		b.WriteString("</string>\n")
+	}
+
+	writeKey("Label")
+	writeString("\t", LaunchdLabel(cfg.Name))
+
+	writeKey("ProgramArguments")
+	b.WriteString("\t<array>\n")
+	writeString("\t\t", cfg.Executable)
+	for _, arg := range cfg.Args {
+		writeString("\t\t", arg)
+	}
+	b.WriteString("\t</array>\n")
+
+	if len(cfg.Env) > 0 {
+		names := make([]string, 0, len(cfg.Env))
+		for name := range cfg.Env {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		writeKey("EnvironmentVariables")
+		b.WriteString("\t<dict>\n")
+		for _, name := range names {
+			b.WriteString("\t\t<key>")
+			_ = xml.EscapeText(&b, []byte(name))
+			b.WriteString("</key>\n")
+			writeString("\t\t", cfg.Env[name])
+		}
+		b.WriteString("\t</dict>\n")
+	}
+
+	// Start at login and restart after a crash, but not after a clean exit such as 'service stop'
+	writeKey("RunAtLoad")
+	b.WriteString("\t<true/>\n")
+	writeKey("KeepAlive")
+	b.WriteString("\t<dict>\n\t\t<key>SuccessfulExit</key>\n\t\t<false/>\n\t</dict>\n")
+	writeKey("ProcessType")
+	writeString("\t", "Background")
+
+	if cfg.LogFile != "" {
+		writeKey("StandardOutPath")
+		writeString("\t", cfg.LogFile)
+		writeKey("StandardErrorPath")
+		writeString("\t", cfg.LogFile)
+	}
+
+	b.WriteString("</dict>\n</plist>\n")
+	return b.Bytes(), nil
+}
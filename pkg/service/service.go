@@ -0,0 +1,71 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Package service installs and runs the server as a Windows service or a macOS launchd agent, so that it
+// keeps running on a workstation without a third-party wrapper. Services run the StreamableHTTP transport,
+// since the stdio transport is started by the MCP client itself.
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// DefaultName is the name of the Windows service, and the last part of the label of the launchd agent
+const DefaultName = "vault-mcp-server"
+
+// ErrNotInstalled is returned when the service is not installed
+var ErrNotInstalled = errors.New("the service is not installed")
+
+// Config describes the server installed as a service
+type Config struct {
+	Name       string            // Name of the Windows service, or of the launchd agent
+	Executable string            // Absolute path of the server binary
+	Args       []string          // Arguments of the server
+	Env        map[string]string // Environment of the server, services do not inherit the environment of the user
+	LogFile    string            // File receiving the output of the launchd agent, Windows services log to the event log
+}
+
+// Status is the state of an installed service
+type Status string
+
+const (
+	StatusRunning Status = "running"
+	StatusStopped Status = "stopped"
+	StatusPending Status = "pending"
+)
+
+// ParseEnv parses the environment of a service from KEY=VALUE entries. An entry without a value copies the
+// variable from the current environment, so that secrets such as VAULT_TOKEN need not be typed on the
+// command line.
+func ParseEnv(entries []string) (map[string]string, error) {
+	env := map[string]string{}
+	for _, entry := range entries {
+		name, value, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("invalid environment variable '%s', expected KEY=VALUE or KEY", entry)
+		}
+		if !ok {
+			value, ok = os.LookupEnv(name)
+			if !ok {
+				return nil, fmt.Errorf("environment variable %s is not set", name)
+			}
+		}
+		env[name] = value
+	}
+	return env, nil
+}
+
+// sortedEnv returns the environment as sorted KEY=VALUE entries
+func sortedEnv(env map[string]string) []string {
+	entries := make([]string, 0, len(env))
+	for name, value := range env {
+		entries = append(entries, name+"="+value)
+	}
+	sort.Strings(entries)
+	return entries
+}
@@ -0,0 +1,120 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build darwin
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Install writes the property list of the launchd agent and loads it into the session of the user, which
+// starts the server
+func Install(cfg Config) error {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return err
+	}
+	if cfg.LogFile == "" {
+		cfg.LogFile = filepath.Join(home, "Library", "Logs", cfg.Name+".log")
+	}
+
+	plist, err := LaunchdPlist(cfg)
+	if err != nil {
+		return err
+	}
+	path := LaunchdPlistPath(home, cfg.Name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	// The environment may hold a Vault token
+	if err := os.WriteFile(path, plist, 0o600); err != nil {
+		return err
+	}
+
+	// A previous version of the agent is replaced
+	_ = launchctl("bootout", launchdTarget(cfg.Name))
+	return launchctl("bootstrap", launchdDomain(), path)
+}
+
+// Uninstall unloads the launchd agent, which stops the server, and removes its property list
+func Uninstall(name string) error {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return err
+	}
+	path := LaunchdPlistPath(home, name)
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return ErrNotInstalled
+	}
+
+	_ = launchctl("bootout", launchdTarget(name))
+	return os.Remove(path)
+}
+
+// Start starts the server of the launchd agent, or restarts it when it is running
+func Start(name string) error {
+	return launchctl("kickstart", "-k", launchdTarget(name))
+}
+
+// Stop stops the server of the launchd agent, which starts it again at the next login
+func Stop(name string) error {
+	return launchctl("kill", "SIGTERM", launchdTarget(name))
+}
+
+// QueryStatus returns the state of the server of the launchd agent
+func QueryStatus(name string) (Status, error) {
+	output, err := exec.Command("launchctl", "print", launchdTarget(name)).CombinedOutput()
+	if err != nil {
+		return "", ErrNotInstalled
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		if state, ok := strings.CutPrefix(strings.TrimSpace(line), "state = "); ok {
+			switch state {
+			case "running":
+				return StatusRunning, nil
+			case "spawn scheduled", "xpcproxy":
+				return StatusPending, nil
+			}
+			return StatusStopped, nil
+		}
+	}
+	return StatusStopped, nil
+}
+
+// IsService reports whether the process was started by the Windows service manager, never the case on macOS
+func IsService() bool {
+	return false
+}
+
+// Run is only needed on Windows, launchd agents run the server command and stop it with SIGTERM
+func Run(string, *log.Logger, func(context.Context) error) error {
+	return errors.New("'service run' is only used by Windows services, launchd agents run the server directly")
+}
+
+// launchdDomain returns the launchd domain of the GUI session of the user
+func launchdDomain() string {
+	return fmt.Sprintf("gui/%d", os.Getuid())
+}
+
+// launchdTarget returns the launchd service target of the agent
+func launchdTarget(name string) string {
+	return launchdDomain() + "/" + LaunchdLabel(name)
+}
+
+func launchctl(args ...string) error {
+	output, err := exec.Command("launchctl", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("launchctl %s failed: %w: %s", args[0], err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !darwin && !windows
+
+package service
+
+import (
+	"context"
+	"errors"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ErrUnsupported is returned on platforms without a supported service manager
+var ErrUnsupported = errors.New("services are only supported on Windows and macOS, run 'vault-mcp-server streamable-http' from a systemd unit on Linux")
+
+// Install is not supported on this platform
+func Install(Config) error {
+	return ErrUnsupported
+}
+
+// Uninstall is not supported on this platform
+func Uninstall(string) error {
+	return ErrUnsupported
+}
+
+// Start is not supported on this platform
+func Start(string) error {
+	return ErrUnsupported
+}
+
+// Stop is not supported on this platform
+func Stop(string) error {
+	return ErrUnsupported
+}
+
+// QueryStatus is not supported on this platform
+func QueryStatus(string) (Status, error) {
+	return "", ErrUnsupported
+}
+
+// IsService reports whether the process was started by the Windows service manager, never the case here
+func IsService() bool {
+	return false
+}
+
+// Run is not supported on this platform
+func Run(string, *log.Logger, func(context.Context) error) error {
+	return ErrUnsupported
+}
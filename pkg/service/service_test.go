@@ -0,0 +1,82 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package service
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEnv(t *testing.T) {
+	t.Setenv("VAULT_TOKEN", "hvs.test")
+
+	env, err := ParseEnv([]string{"VAULT_ADDR=https://vault:8200", "VAULT_TOKEN", "MCP_LOCALE=", "QUERY=a=b"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"VAULT_ADDR":  "https://vault:8200",
+		"VAULT_TOKEN": "hvs.test",
+		"MCP_LOCALE":  "",
+		"QUERY":       "a=b",
+	}, env)
+	assert.Equal(t, []string{"MCP_LOCALE=", "QUERY=a=b", "VAULT_ADDR=https://vault:8200", "VAULT_TOKEN=hvs.test"}, sortedEnv(env))
+
+	_, err = ParseEnv([]string{"=value"})
+	assert.Error(t, err)
+
+	_, err = ParseEnv([]string{"VAULT_MCP_SERVICE_TEST_UNSET"})
+	assert.ErrorContains(t, err, "is not set")
+}
+
+func TestLaunchdPlist(t *testing.T) {
+	assert.Equal(t, filepath.Join("/Users/dev", "Library", "LaunchAgents", "com.hashicorp.vault-mcp-server.plist"),
+		LaunchdPlistPath("/Users/dev", DefaultName))
+
+	plist, err := LaunchdPlist(Config{
+		Name:       DefaultName,
+		Executable: "/usr/local/bin/vault-mcp-server",
+		Args:       []string{"streamable-http", "--transport-port", "8080"},
+		Env:        map[string]string{"VAULT_TOKEN": "hvs.a&b", "VAULT_ADDR": "https://vault:8200"},
+		LogFile:    "/Users/dev/Library/Logs/vault-mcp-server.log",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>com.hashicorp.vault-mcp-server</string>
+	<key>ProgramArguments</key>
+	<array>
+		<string>/usr/local/bin/vault-mcp-server</string>
+		<string>streamable-http</string>
+		<string>--transport-port</string>
+		<string>8080</string>
+	</array>
+	<key>EnvironmentVariables</key>
+	<dict>
+		<key>VAULT_ADDR</key>
+		<string>https://vault:8200</string>
+		<key>VAULT_TOKEN</key>
+		<string>hvs.a&amp;b</string>
+	</dict>
+	<key>RunAtLoad</key>
+	<true/>
+	<key>KeepAlive</key>
+	<dict>
+		<key>SuccessfulExit</key>
+		<false/>
+	</dict>
+	<key>ProcessType</key>
+	<string>Background</string>
+	<key>StandardOutPath</key>
+	<string>/Users/dev/Library/Logs/vault-mcp-server.log</string>
+	<key>StandardErrorPath</key>
+	<string>/Users/dev/Library/Logs/vault-mcp-server.log</string>
+</dict>
+</plist>
+`, string(plist))
+}
@@ -0,0 +1,264 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build windows
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/eventlog"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+// stopTimeout is how long Stop and Uninstall wait for the server to stop
+const stopTimeout = 30 * time.Second
+
+// Install registers the server as an automatically started Windows service, together with its event log
+// source, and starts it
+func Install(cfg Config) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the service manager: %w", err)
+	}
+	defer m.Disconnect()
+
+	if s, err := m.OpenService(cfg.Name); err == nil {
+		s.Close()
+		return fmt.Errorf("the service %s is already installed", cfg.Name)
+	}
+
+	s, err := m.CreateService(cfg.Name, cfg.Executable, mgr.Config{
+		DisplayName: "Vault MCP Server",
+		Description: "Model Context Protocol server for HashiCorp Vault",
+		StartType:   mgr.StartAutomatic,
+	}, cfg.Args...)
+	if err != nil {
+		return fmt.Errorf("failed to create the service: %w", err)
+	}
+	defer s.Close()
+
+	// Restart the server after a crash
+	_ = s.SetRecoveryActions([]mgr.RecoveryAction{
+		{Type: mgr.ServiceRestart, Delay: 5 * time.Second},
+		{Type: mgr.ServiceRestart, Delay: 30 * time.Second},
+	}, uint32((24 * time.Hour).Seconds()))
+
+	if len(cfg.Env) > 0 {
+		if err := setServiceEnv(cfg.Name, cfg.Env); err != nil {
+			_ = s.Delete()
+			return err
+		}
+	}
+
+	if err := eventlog.InstallAsEventCreate(cfg.Name, eventlog.Error|eventlog.Warning|eventlog.Info); err != nil {
+		_ = s.Delete()
+		return fmt.Errorf("failed to install the event log source: %w", err)
+	}
+
+	return s.Start()
+}
+
+// Uninstall stops the Windows service and removes it together with its event log source
+func Uninstall(name string) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the service manager: %w", err)
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(name)
+	if err != nil {
+		return ErrNotInstalled
+	}
+	defer s.Close()
+
+	if err := stopService(s); err != nil {
+		return err
+	}
+	if err := s.Delete(); err != nil {
+		return fmt.Errorf("failed to delete the service: %w", err)
+	}
+	_ = eventlog.Remove(name)
+	return nil
+}
+
+// Start starts the Windows service
+func Start(name string) error {
+	return withService(name, func(s *mgr.Service) error {
+		return s.Start()
+	})
+}
+
+// Stop stops the Windows service and waits for the server to shut down
+func Stop(name string) error {
+	return withService(name, stopService)
+}
+
+// QueryStatus returns the state of the Windows service
+func QueryStatus(name string) (Status, error) {
+	var status Status
+	err := withService(name, func(s *mgr.Service) error {
+		state, err := s.Query()
+		if err != nil {
+			return err
+		}
+		switch state.State {
+		case svc.Running:
+			status = StatusRunning
+		case svc.Stopped:
+			status = StatusStopped
+		default:
+			status = StatusPending
+		}
+		return nil
+	})
+	return status, err
+}
+
+// IsService reports whether the process was started by the Windows service manager
+func IsService() bool {
+	isService, err := svc.IsWindowsService()
+	return err == nil && isService
+}
+
+// Run runs the server as the named Windows service, logging to the event log. The context passed to run is
+// cancelled when the service manager stops the service.
+func Run(name string, logger *log.Logger, run func(context.Context) error) error {
+	events, err := eventlog.Open(name)
+	if err != nil {
+		return fmt.Errorf("failed to open the event log: %w", err)
+	}
+	defer events.Close()
+	logger.AddHook(&eventLogHook{events: events})
+
+	return svc.Run(name, &handler{logger: logger, run: run})
+}
+
+// handler implements the lifecycle of the Windows service
+type handler struct {
+	logger *log.Logger
+	run    func(context.Context) error
+}
+
+func (h *handler) Execute(_ []string, requests <-chan svc.ChangeRequest, changes chan<- svc.Status) (bool, uint32) {
+	changes <- svc.Status{State: svc.StartPending}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- h.run(ctx)
+	}()
+
+	changes <- svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown}
+	for {
+		select {
+		case err := <-done:
+			// The server stopped on its own
+			if err != nil {
+				h.logger.Errorf("Server failed: %v", err)
+				return true, 1
+			}
+			return false, 0
+		case request := <-requests:
+			switch request.Cmd {
+			case svc.Interrogate:
+				changes <- request.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				h.logger.Info("Stopping service")
+				changes <- svc.Status{State: svc.StopPending}
+				cancel()
+				if err := <-done; err != nil {
+					h.logger.Errorf("Server failed to stop: %v", err)
+					return true, 1
+				}
+				return false, 0
+			}
+		}
+	}
+}
+
+// eventLogHook writes log entries of level info and above to the Windows event log
+type eventLogHook struct {
+	events *eventlog.Log
+}
+
+func (h *eventLogHook) Levels() []log.Level {
+	return []log.Level{log.PanicLevel, log.FatalLevel, log.ErrorLevel, log.WarnLevel, log.InfoLevel}
+}
+
+func (h *eventLogHook) Fire(entry *log.Entry) error {
+	message, err := entry.String()
+	if err != nil {
+		return err
+	}
+	switch entry.Level {
+	case log.PanicLevel, log.FatalLevel, log.ErrorLevel:
+		return h.events.Error(1, message)
+	case log.WarnLevel:
+		return h.events.Warning(1, message)
+	default:
+		return h.events.Info(1, message)
+	}
+}
+
+// setServiceEnv sets the environment of the service, which the service manager reads from the Environment
+// value of the service key
+func setServiceEnv(name string, env map[string]string) error {
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, `SYSTEM\CurrentControlSet\Services\`+name, registry.SET_VALUE)
+	if err != nil {
+		return fmt.Errorf("failed to open the registry key of the service: %w", err)
+	}
+	defer key.Close()
+
+	if err := key.SetStringsValue("Environment", sortedEnv(env)); err != nil {
+		return fmt.Errorf("failed to set the environment of the service: %w", err)
+	}
+	return nil
+}
+
+func withService(name string, f func(*mgr.Service) error) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the service manager: %w", err)
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(name)
+	if err != nil {
+		return ErrNotInstalled
+	}
+	defer s.Close()
+	return f(s)
+}
+
+func stopService(s *mgr.Service) error {
+	status, err := s.Control(svc.Stop)
+	if err != nil {
+		if errors.Is(err, windows.ERROR_SERVICE_NOT_ACTIVE) {
+			return nil
+		}
+		return fmt.Errorf("failed to stop the service: %w", err)
+	}
+
+	deadline := time.Now().Add(stopTimeout)
+	for status.State != svc.Stopped {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("the service did not stop within %s", stopTimeout)
+		}
+		time.Sleep(500 * time.Millisecond)
+		if status, err = s.Query(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,316 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Package telemetry aggregates anonymous counts of tool usage when telemetry is opted in, and reports them
+// periodically to a local file or an HTTP endpoint. Only tool names, call counts and error categories are
+// recorded: no arguments, paths, values, session or client identifiers ever leave the process.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// TelemetryEnv opts in to telemetry
+	TelemetryEnv = "MCP_TELEMETRY"
+	// FileEnv is the file the reports are appended to, one JSON object per line
+	FileEnv = "MCP_TELEMETRY_FILE"
+	// EndpointEnv is the URL the reports are posted to
+	EndpointEnv = "MCP_TELEMETRY_ENDPOINT"
+	// IntervalEnv is how often a report is written
+	IntervalEnv = "MCP_TELEMETRY_INTERVAL"
+
+	// DefaultInterval is the report interval when MCP_TELEMETRY_INTERVAL is not set
+	DefaultInterval = 24 * time.Hour
+	// minInterval keeps reports from being written more than once a minute
+	minInterval = time.Minute
+	// endpointTimeout bounds the time to post a report
+	endpointTimeout = 10 * time.Second
+)
+
+// Error categories of failed tool calls. They are derived from the error, whose message is never recorded.
+const (
+	CategoryRateLimited      = "rate_limited"
+	CategoryBudgetExceeded   = "budget_exceeded"
+	CategoryCircuitOpen      = "circuit_open"
+	CategoryTimeout          = "timeout"
+	CategoryRefused          = "refused"
+	CategoryPermissionDenied = "permission_denied"
+	CategoryNotFound         = "not_found"
+	CategoryInvalidArguments = "invalid_arguments"
+	CategoryToolError        = "tool_error"
+)
+
+// Config holds the telemetry configuration
+type Config struct {
+	Enabled  bool
+	File     string
+	Endpoint string
+	Interval time.Duration
+}
+
+// LoadConfigFromEnv loads the telemetry configuration from environment variables. Telemetry is disabled
+// unless MCP_TELEMETRY is true and a file or endpoint receives the reports.
+func LoadConfigFromEnv() Config {
+	config := Config{
+		File:     strings.TrimSpace(os.Getenv(FileEnv)),
+		Endpoint: strings.TrimSpace(os.Getenv(EndpointEnv)),
+		Interval: DefaultInterval,
+	}
+
+	if value := os.Getenv(TelemetryEnv); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			log.Warnf("Invalid %s value '%s', telemetry is disabled", TelemetryEnv, value)
+		}
+		config.Enabled = enabled
+	}
+	if config.Endpoint != "" {
+		if parsed, err := url.Parse(config.Endpoint); err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+			log.Warnf("Invalid %s value '%s', expected an http(s) URL", EndpointEnv, config.Endpoint)
+			config.Endpoint = ""
+		}
+	}
+	if value := os.Getenv(IntervalEnv); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed >= minInterval {
+			config.Interval = parsed
+		} else {
+			log.Warnf("Invalid %s value '%s', using default %s", IntervalEnv, value, DefaultInterval)
+		}
+	}
+	if config.Enabled && config.File == "" && config.Endpoint == "" {
+		log.Warnf("Telemetry is disabled, set %s or %s to receive the reports", FileEnv, EndpointEnv)
+		config.Enabled = false
+	}
+	return config
+}
+
+// ToolUsage holds the counts of one tool in a report
+type ToolUsage struct {
+	Calls  int            `json:"calls"`
+	Errors map[string]int `json:"errors,omitempty"` // Failed calls by error category
+}
+
+// Report holds the tool usage of one interval
+type Report struct {
+	ServerVersion string               `json:"server_version"`
+	PeriodStart   time.Time            `json:"period_start"`
+	PeriodEnd     time.Time            `json:"period_end"`
+	Tools         map[string]ToolUsage `json:"tools"`
+}
+
+// Collector counts tool calls and writes a report of them every interval
+type Collector struct {
+	config     Config
+	version    string
+	logger     *log.Logger
+	httpClient *http.Client
+
+	mu          sync.Mutex
+	periodStart time.Time
+	tools       map[string]ToolUsage
+}
+
+// NewCollector creates a collector for the configuration, or returns nil when telemetry is disabled
+func NewCollector(config Config, version string, logger *log.Logger) *Collector {
+	if !config.Enabled {
+		return nil
+	}
+	return &Collector{
+		config:      config,
+		version:     version,
+		logger:      logger,
+		httpClient:  &http.Client{Timeout: endpointTimeout},
+		periodStart: time.Now().UTC(),
+		tools:       map[string]ToolUsage{},
+	}
+}
+
+// collector counts the tool calls of the process, nil unless telemetry is opted in
+var collector atomic.Pointer[Collector]
+
+// SetCollector sets the collector that counts the tool calls of the process, nil to stop counting
+func SetCollector(c *Collector) {
+	collector.Store(c)
+}
+
+// Middleware counts the calls of every tool and the error categories of failed calls, while a collector
+// is set
+func Middleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			result, err := next(ctx, request)
+			if c := collector.Load(); c != nil {
+				c.Record(request.Params.Name, ErrorCategory(result, err))
+			}
+			return result, err
+		}
+	}
+}
+
+// Record counts a call of a tool, failed with the error category unless it is empty
+func (c *Collector) Record(tool string, category string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	usage := c.tools[tool]
+	usage.Calls++
+	if category != "" {
+		if usage.Errors == nil {
+			usage.Errors = map[string]int{}
+		}
+		usage.Errors[category]++
+	}
+	c.tools[tool] = usage
+}
+
+// ErrorCategory returns the category of the error of a tool call, empty when the call succeeded
+func ErrorCategory(result *mcp.CallToolResult, err error) string {
+	var rateLimitErr *client.RateLimitError
+	switch {
+	case err == nil && (result == nil || !result.IsError):
+		return ""
+	case errors.As(err, &rateLimitErr):
+		return CategoryRateLimited
+	case errors.Is(err, client.ErrCircuitOpen):
+		return CategoryCircuitOpen
+	case errors.Is(err, client.ErrToolTimeout), errors.Is(err, context.DeadlineExceeded):
+		return CategoryTimeout
+	}
+
+	var message string
+	if err != nil {
+		message = err.Error()
+	} else {
+		for _, content := range result.Content {
+			if text, ok := mcp.AsTextContent(content); ok {
+				message += text.Text
+			}
+		}
+	}
+	message = strings.ToLower(message)
+	switch {
+	case strings.Contains(message, "budget exceeded"):
+		return CategoryBudgetExceeded
+	case strings.Contains(message, "refused"), strings.Contains(message, "requires approval"):
+		return CategoryRefused
+	case strings.Contains(message, "permission denied"), strings.Contains(message, "code: 403"):
+		return CategoryPermissionDenied
+	case strings.Contains(message, "not found"), strings.Contains(message, "no secret found"), strings.Contains(message, "does not exist"), strings.Contains(message, "code: 404"):
+		return CategoryNotFound
+	case strings.Contains(message, "missing"), strings.Contains(message, "invalid"):
+		return CategoryInvalidArguments
+	}
+	return CategoryToolError
+}
+
+// Snapshot returns the report of the current interval and starts the next one
+func (c *Collector) Snapshot() Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now().UTC()
+	report := Report{ServerVersion: c.version, PeriodStart: c.periodStart, PeriodEnd: now, Tools: c.tools}
+	c.periodStart = now
+	c.tools = map[string]ToolUsage{}
+	return report
+}
+
+// Run writes a report every interval until the context is cancelled, and a last one when it is. Intervals
+// without tool calls are not reported.
+func (c *Collector) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.flush(context.WithoutCancel(ctx))
+			return
+		case <-ticker.C:
+			c.flush(ctx)
+		}
+	}
+}
+
+// flush writes the report of the current interval
+func (c *Collector) flush(ctx context.Context) {
+	report := c.Snapshot()
+	if len(report.Tools) == 0 {
+		return
+	}
+	if err := c.write(ctx, report); err != nil {
+		c.logger.WithError(err).Warn("Failed to write telemetry report")
+		return
+	}
+	c.logger.WithField("tools", len(report.Tools)).Debug("Wrote telemetry report")
+}
+
+// write appends the report to the file and posts it to the endpoint, whichever are configured
+func (c *Collector) write(ctx context.Context, report Report) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	if c.config.File != "" {
+		if err := appendLine(c.config.File, data); err != nil {
+			errs = append(errs, fmt.Errorf("file %s: %w", c.config.File, err))
+		}
+	}
+	if c.config.Endpoint != "" {
+		if err := c.post(ctx, data); err != nil {
+			errs = append(errs, fmt.Errorf("endpoint: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// appendLine appends a line to a file, creating it readable by its owner only
+func appendLine(file string, data []byte) error {
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// post sends a report to the endpoint
+func (c *Collector) post(ctx context.Context, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.Endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLogger() *log.Logger {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+	return logger
+}
+
+func TestLoadConfigFromEnv(t *testing.T) {
+	t.Setenv(TelemetryEnv, "")
+	t.Setenv(FileEnv, "")
+	t.Setenv(EndpointEnv, "")
+	t.Setenv(IntervalEnv, "")
+	assert.False(t, LoadConfigFromEnv().Enabled, "telemetry is opt-in")
+
+	t.Setenv(TelemetryEnv, "true")
+	assert.False(t, LoadConfigFromEnv().Enabled, "reports need a destination")
+
+	t.Setenv(FileEnv, "/var/lib/vault-mcp/telemetry.jsonl")
+	t.Setenv(IntervalEnv, "1h")
+	config := LoadConfigFromEnv()
+	assert.True(t, config.Enabled)
+	assert.Equal(t, time.Hour, config.Interval)
+
+	t.Setenv(EndpointEnv, "ftp://example.com")
+	t.Setenv(IntervalEnv, "1s")
+	config = LoadConfigFromEnv()
+	assert.Empty(t, config.Endpoint)
+	assert.Equal(t, DefaultInterval, config.Interval)
+}
+
+func TestErrorCategory(t *testing.T) {
+	for expected, call := range map[string]struct {
+		result *mcp.CallToolResult
+		err    error
+	}{
+		"":                       {result: mcp.NewToolResultText("ok")},
+		CategoryRateLimited:      {err: &client.RateLimitError{}},
+		CategoryCircuitOpen:      {err: fmt.Errorf("%w: Vault is failing", client.ErrCircuitOpen)},
+		CategoryTimeout:          {err: fmt.Errorf("%w: 'read_secret' did not complete", client.ErrToolTimeout)},
+		CategoryBudgetExceeded:   {err: fmt.Errorf("budget exceeded: this session has used its budget of 10 tool calls")},
+		CategoryPermissionDenied: {result: mcp.NewToolResultError("failed to read 'secret/app': Code: 403. Errors: * permission denied")},
+		CategoryNotFound:         {result: mcp.NewToolResultError("Secret not found")},
+		CategoryInvalidArguments: {result: mcp.NewToolResultError("Missing or invalid 'path' parameter")},
+		CategoryToolError:        {result: mcp.NewToolResultError("something broke")},
+	} {
+		assert.Equal(t, expected, ErrorCategory(call.result, call.err), expected)
+	}
+}
+
+func TestCollector(t *testing.T) {
+	assert.Nil(t, NewCollector(Config{}, "1.2.3", newLogger()))
+
+	var posted []Report
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var report Report
+		require.NoError(t, json.Unmarshal(body, &report))
+		posted = append(posted, report)
+	}))
+	defer endpoint.Close()
+
+	file := filepath.Join(t.TempDir(), "telemetry.jsonl")
+	c := NewCollector(Config{Enabled: true, File: file, Endpoint: endpoint.URL, Interval: time.Hour}, "1.2.3", newLogger())
+	require.NotNil(t, c)
+	SetCollector(c)
+	defer SetCollector(nil)
+
+	handler := Middleware()(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if request.GetString("path", "") == "secret/missing" {
+			return mcp.NewToolResultError("no secret found at 'secret/missing'"), nil
+		}
+		return mcp.NewToolResultText("s3cr3t"), nil
+	})
+	for _, path := range []string{"secret/app", "secret/app", "secret/missing"} {
+		_, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "read_secret", Arguments: map[string]interface{}{"path": path}}})
+		require.NoError(t, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c.Run(ctx)
+
+	expected := map[string]ToolUsage{"read_secret": {Calls: 3, Errors: map[string]int{CategoryNotFound: 1}}}
+	require.Len(t, posted, 1)
+	assert.Equal(t, "1.2.3", posted[0].ServerVersion)
+	assert.Equal(t, expected, posted[0].Tools)
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(data), "\n"))
+	assert.NotContains(t, string(data), "secret/", "paths are never reported")
+	assert.NotContains(t, string(data), "s3cr3t", "values are never reported")
+
+	// Intervals without tool calls are not reported
+	c.Run(ctx)
+	assert.Len(t, posted, 1)
+}
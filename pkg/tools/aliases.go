@@ -0,0 +1,63 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package tools
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// CanonicalToolName converts a legacy tool name, in kebab-case like write-secret or camelCase like
+// listSecrets, to the snake_case name tools are registered with
+func CanonicalToolName(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		switch {
+		case r == '-' || r == ' ' || r == '.':
+			b.WriteRune('_')
+		case unicode.IsUpper(r):
+			// Start a new word at the first upper case letter of a word and at the last letter of an acronym,
+			// e.g. listPKIIssuers becomes list_pki_issuers
+			if i > 0 && runes[i-1] != '_' && runes[i-1] != '-' &&
+				(!unicode.IsUpper(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteRune('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// ToolAliasHook returns a hook that forwards calls of deprecated tool names to the snake_case tool of the
+// same name and logs a deprecation warning, so that client configurations written for older releases keep
+// working. Calls of unknown names are left alone and fail as usual.
+func ToolAliasHook(logger *log.Logger) server.OnBeforeCallToolFunc {
+	return func(ctx context.Context, id any, request *mcp.CallToolRequest) {
+		hcServer := server.ServerFromContext(ctx)
+		name := request.Params.Name
+		if hcServer == nil || hcServer.GetTool(name) != nil {
+			return
+		}
+
+		canonical := CanonicalToolName(name)
+		if canonical == name || hcServer.GetTool(canonical) == nil {
+			return
+		}
+
+		fields := log.Fields{"tool": canonical, "deprecated_name": name}
+		if session := server.ClientSessionFromContext(ctx); session != nil {
+			fields["session_id"] = session.SessionID()
+		}
+		logger.WithFields(fields).Warnf("Tool name '%s' is deprecated and will be removed in a future release, use '%s' instead", name, canonical)
+		request.Params.Name = canonical
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// ApprovalStatus reports a tool call queued for approval and, once it ran, its result
+type ApprovalStatus struct {
+	client.Approval
+	Result string `json:"result,omitempty"` // Result of the tool call, once approved and run
+}
+
+// GetApprovalStatus creates a tool reporting the status of a tool call queued for approval by the session
+func GetApprovalStatus(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("get_approval_status",
+			mcp.WithDescription("Get the status of a tool call that was queued for approval by a human operator, and its result once it was approved and ran. Statuses are pending, approved (running), executed, failed, denied and expired. Only tool calls of the current session can be queried."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithString("approval_id",
+				mcp.Required(),
+				mcp.Description("The approval ID returned when the tool call was queued")),
+			schemas.Output("get_approval_status"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return getApprovalStatusHandler(ctx, req, logger)
+		},
+	}
+}
+
+func getApprovalStatusHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling get_approval_status request")
+
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return mcp.NewToolResultError("No active session"), nil
+	}
+
+	id, err := req.RequireString("approval_id")
+	if err != nil {
+		return mcp.NewToolResultError("Missing or invalid 'approval_id' parameter"), nil
+	}
+
+	// Results can hold data of the session, so other sessions are told the approval does not exist
+	approval, ok := client.GetApproval(id)
+	if !ok || approval.SessionID != session.SessionID() {
+		return mcp.NewToolResultError(fmt.Sprintf("Approval '%s' not found in this session", id)), nil
+	}
+
+	status := ApprovalStatus{Approval: approval, Result: approval.Result}
+	jsonData, err := json.Marshal(status)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal approval status to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	return mcp.NewToolResultStructured(status, string(jsonData)), nil
+}
@@ -0,0 +1,77 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// checkAuthMount verifies that an auth method of the given type is enabled at mount,
+// pointing to the tool that enables it otherwise
+func checkAuthMount(vault *api.Client, mount string, authType string, enableTool string) error {
+	auths, err := vault.Sys().ListAuth()
+	if err != nil {
+		return fmt.Errorf("failed to list auth methods: %v", err)
+	}
+
+	auth, ok := auths[mount+"/"]
+	if !ok {
+		return fmt.Errorf("auth method path '%s' does not exist, you should use '%s' if you want to enable the %s auth method on this path.", mount, enableTool, authType)
+	}
+	if auth.Type != authType {
+		return fmt.Errorf("auth method at path '%s' is of type '%s', not '%s'", mount, auth.Type, authType)
+	}
+
+	return nil
+}
+
+// enableAuthMount enables an auth method of the given type at mount, unless it is already enabled.
+// It returns true when the auth method was enabled.
+func enableAuthMount(vault *api.Client, mount string, authType string) (bool, error) {
+	auths, err := vault.Sys().ListAuth()
+	if err != nil {
+		return false, fmt.Errorf("failed to list auth methods: %v", err)
+	}
+
+	if auth, ok := auths[mount+"/"]; ok {
+		if auth.Type != authType {
+			return false, fmt.Errorf("auth method at path '%s' is of type '%s', not '%s'", mount, auth.Type, authType)
+		}
+		return false, nil
+	}
+
+	if err := vault.Sys().EnableAuthWithOptions(mount, &api.EnableAuthOptions{Type: authType}); err != nil {
+		return false, fmt.Errorf("failed to enable %s auth method at path '%s': %v", authType, mount, err)
+	}
+
+	return true, nil
+}
+
+// recordAuthEnabled records an auth method enabled by enableAuthMount in the change journal of the session
+func recordAuthEnabled(ctx context.Context, req mcp.CallToolRequest, mount string, authType string) {
+	client.RecordChange(ctx, client.ChangeRecord{
+		Tool:         req.Params.Name,
+		ResourceType: client.ResourceAuthMethod,
+		Mount:        mount,
+		Path:         "sys/auth/" + mount,
+		Operation:    client.OperationCreate,
+		New:          map[string]any{"type": authType},
+		Message:      fmt.Sprintf("Enabled the %s auth method at path '%s'.", authType, mount),
+	})
+}
+
+// readPrevious returns the data at path before a tool changes it, or nil when there is nothing to read.
+// The previous state is informational, so errors such as a missing read capability are ignored.
+func readPrevious(vault *api.Client, path string) map[string]any {
+	secret, err := vault.Logical().Read(path)
+	if err != nil || secret == nil || secret.Data == nil {
+		return nil
+	}
+	return secret.Data
+}
@@ -0,0 +1,257 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSession struct {
+	id string
+}
+
+func (s testSession) SessionID() string                                   { return s.id }
+func (s testSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s testSession) Initialize()                                         {}
+func (s testSession) Initialized() bool                                   { return true }
+
+// newTestContext returns a context whose session uses a mock Vault served by handler
+func newTestContext(t *testing.T, handler http.HandlerFunc) context.Context {
+	t.Helper()
+
+	mockVault := httptest.NewServer(handler)
+	t.Cleanup(mockVault.Close)
+
+	sessionID := "test-" + t.Name()
+	_, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	t.Cleanup(func() { client.DeleteVaultClient(sessionID) })
+
+	return server.NewMCPServer("test", "1.0").WithContext(context.Background(), testSession{id: sessionID})
+}
+
+func jsonResponse(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+}
+
+func newLogger() *log.Logger {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+	return logger
+}
+
+func TestListGitHubMappings(t *testing.T) {
+	ctx := newTestContext(t, func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimSuffix(r.URL.Path, "/") {
+		case "/v1/sys/auth":
+			jsonResponse(w, map[string]interface{}{"github/": map[string]interface{}{"type": "github"}})
+		case "/v1/auth/github/config":
+			jsonResponse(w, map[string]interface{}{"organization": "example", "token_policies": []string{"default"}})
+		case "/v1/auth/github/map/teams":
+			jsonResponse(w, map[string]interface{}{"keys": []string{"platform"}})
+		case "/v1/auth/github/map/teams/platform":
+			jsonResponse(w, map[string]interface{}{"key": "platform", "value": "deploy, read-secrets"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	result, err := ListGitHubMappings(newLogger()).Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{
+		Arguments: map[string]interface{}{},
+	}})
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var mappings GitHubMappings
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &mappings))
+	assert.Equal(t, "example", mappings.Organization)
+	assert.Equal(t, []string{"default"}, mappings.TokenPolicies)
+	assert.Equal(t, map[string][]string{"platform": {"deploy", "read-secrets"}}, mappings.Teams)
+	assert.Empty(t, mappings.Users)
+}
+
+func TestMapGitHubTeamWrongAuthType(t *testing.T) {
+	ctx := newTestContext(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/sys/auth" {
+			jsonResponse(w, map[string]interface{}{"github/": map[string]interface{}{"type": "userpass"}})
+			return
+		}
+		t.Errorf("Unexpected request to %s", r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	result, err := MapGitHubTeam(newLogger()).Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{
+		Arguments: map[string]interface{}{"name": "platform", "policies": "deploy"},
+	}})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "of type 'userpass'")
+}
+
+func TestListCertAuthRoles(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Example Root CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+
+	ctx := newTestContext(t, func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimSuffix(r.URL.Path, "/") {
+		case "/v1/sys/auth":
+			jsonResponse(w, map[string]interface{}{"cert/": map[string]interface{}{"type": "cert"}})
+		case "/v1/auth/cert/certs":
+			jsonResponse(w, map[string]interface{}{"keys": []string{"payments"}})
+		case "/v1/auth/cert/certs/payments":
+			jsonResponse(w, map[string]interface{}{
+				"certificate":          caPEM,
+				"allowed_common_names": []string{"*.payments.example.com"},
+				"token_policies":       []string{"payments"},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	result, err := ListCertAuthRoles(newLogger()).Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{
+		Arguments: map[string]interface{}{},
+	}})
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var roles []CertAuthRole
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &roles))
+	require.Len(t, roles, 1)
+	assert.Equal(t, "payments", roles[0].Name)
+	assert.Equal(t, "CN=Example Root CA", roles[0].CASubject)
+	assert.False(t, roles[0].CAExpired)
+	assert.Equal(t, []string{"*.payments.example.com"}, roles[0].AllowedCommonNames)
+	assert.Empty(t, roles[0].AllowedDNSSans)
+	assert.Equal(t, []string{"payments"}, roles[0].TokenPolicies)
+}
+
+func TestCheckMFACoverage(t *testing.T) {
+	auths := map[string]*api.AuthMount{
+		"token/":    {Type: "token", Accessor: "auth_token_1"},
+		"userpass/": {Type: "userpass", Accessor: "auth_userpass_1"},
+		"ldap/":     {Type: "ldap", Accessor: "auth_ldap_1"},
+		"github/":   {Type: "github", Accessor: "auth_github_1"},
+	}
+	enforcements := []MFALoginEnforcement{
+		{Name: "userpass-mfa", MFAMethodIDs: []string{"m1"}, AuthMethodTypes: []string{"userpass"}},
+		{Name: "ldap-mfa", MFAMethodIDs: []string{"m1"}, AuthMethodAccessors: []string{"auth_ldap_1"}},
+		{Name: "admins-mfa", MFAMethodIDs: []string{"m1"}, IdentityGroupIDs: []string{"g1"}},
+	}
+
+	report := CheckMFACoverage(enforcements, auths)
+
+	require.Len(t, report.AuthMethods, 3, "the token auth method is not reported")
+	assert.Equal(t, AuthMethodMFA{Path: "github", Type: "github", Accessor: "auth_github_1"}, report.AuthMethods[0])
+	assert.Equal(t, []string{"ldap-mfa"}, report.AuthMethods[1].EnforcedBy)
+	assert.True(t, report.AuthMethods[2].Enforced)
+	assert.Equal(t, []string{"userpass-mfa"}, report.AuthMethods[2].EnforcedBy)
+	assert.Equal(t, []string{"admins-mfa"}, report.IdentityEnforcements)
+}
+
+func TestTestAuthLogin(t *testing.T) {
+	var revokedToken string
+	ctx := newTestContext(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/sys/auth":
+			jsonResponse(w, map[string]interface{}{"userpass/": map[string]interface{}{"type": "userpass"}})
+		case "/v1/auth/userpass/login/alice":
+			if r.Header.Get("X-Vault-Token") != "" {
+				t.Error("Login should not send the session token")
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"auth": map[string]interface{}{
+				"client_token":   "login-token",
+				"accessor":       "login-accessor",
+				"policies":       []string{"default", "deploy"},
+				"token_policies": []string{"default", "deploy"},
+				"lease_duration": 3600,
+				"renewable":      true,
+			}})
+		case "/v1/auth/token/revoke-self":
+			revokedToken = r.Header.Get("X-Vault-Token")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	result, err := TestAuthLogin(newLogger()).Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{
+		Arguments: map[string]interface{}{"mount": "userpass", "username": "alice", "password": "hunter2"},
+	}})
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var login LoginTestResult
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &login))
+	assert.Equal(t, "userpass", login.Type)
+	assert.Equal(t, []string{"default", "deploy"}, login.Policies)
+	assert.Equal(t, 3600, login.TTL)
+	assert.True(t, login.Revoked)
+	assert.Empty(t, login.Accessor)
+	assert.Equal(t, "login-token", revokedToken)
+
+	vault, err := client.GetVaultClientFromContext(ctx, newLogger())
+	require.NoError(t, err)
+	assert.Equal(t, "test-token", vault.Token(), "session token should not be replaced")
+
+	// A token that is kept is returned by its accessor, never by the token itself
+	revokedToken = ""
+	result, err = TestAuthLogin(newLogger()).Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{
+		Arguments: map[string]interface{}{"mount": "userpass", "username": "alice", "password": "hunter2", "revoke": false},
+	}})
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &login))
+	assert.False(t, login.Revoked)
+	assert.Equal(t, "login-accessor", login.Accessor)
+	assert.Empty(t, revokedToken)
+	assert.NotContains(t, result.Content[0].(mcp.TextContent).Text, "login-token")
+}
+
+func TestBuildLoginRequest(t *testing.T) {
+	_, _, err := buildLoginRequest("approle", "approle", map[string]interface{}{})
+	assert.ErrorContains(t, err, "'role_id' parameter is required")
+
+	path, data, err := buildLoginRequest("jwt", "jwt", map[string]interface{}{"jwt": "eyJ", "role": "ci"})
+	require.NoError(t, err)
+	assert.Equal(t, "auth/jwt/login", path)
+	assert.Equal(t, map[string]interface{}{"jwt": "eyJ", "role": "ci"}, data)
+
+	_, _, err = buildLoginRequest("aws", "aws", map[string]interface{}{})
+	assert.ErrorContains(t, err, "not supported")
+}
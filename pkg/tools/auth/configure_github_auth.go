@@ -0,0 +1,147 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// ConfigureGitHubAuth creates a tool for enabling and configuring the GitHub auth method
+func ConfigureGitHubAuth(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("configure_github_auth",
+			mcp.WithDescription(`Enable the GitHub auth method if needed and configure the GitHub organization whose members can log in with a GitHub personal access token.
+## Setting up GitHub authentication
+  - Configure the organization with this tool. 'token_policies' are attached to every member of the organization.
+  - Map GitHub teams, or individual users, to Vault policies with the 'map_github_team' tool.
+  - Review the configuration and the mappings with the 'list_github_mappings' tool.
+`),
+			mcp.WithString("mount",
+				mcp.DefaultString("github"),
+				mcp.Description("The path of the GitHub auth method. Defaults to 'github'."),
+			),
+			mcp.WithString("organization",
+				mcp.Required(),
+				mcp.Description("The GitHub organization whose members can log in."),
+			),
+			mcp.WithString("base_url",
+				mcp.DefaultString(""),
+				mcp.Description("The API endpoint of a GitHub Enterprise Server, such as 'https://github.example.com/api/v3/'. Leave empty for github.com."),
+			),
+			mcp.WithString("token_policies",
+				mcp.DefaultString(""),
+				mcp.Description("A comma-separated list of policies attached to the tokens of every member of the organization."),
+			),
+			mcp.WithString("token_ttl",
+				mcp.DefaultString(""),
+				mcp.Description("The TTL of the tokens issued on login, such as '1h'. Defaults to the TTL of the mount."),
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return configureGitHubAuthHandler(ctx, req, logger)
+		},
+	}
+}
+
+func configureGitHubAuthHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling configure_github_auth request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, _ := args["mount"].(string)
+	mount = strings.Trim(mount, "/")
+	if mount == "" {
+		mount = "github"
+	}
+
+	organization, ok := args["organization"].(string)
+	if !ok || organization == "" {
+		return mcp.NewToolResultError("Missing or invalid 'organization' parameter"), nil
+	}
+
+	baseURL, _ := args["base_url"].(string)
+	tokenPoliciesStr, _ := args["token_policies"].(string)
+	tokenPolicies := utils.SplitList(tokenPoliciesStr)
+	tokenTTL, _ := args["token_ttl"].(string)
+
+	logger.WithFields(log.Fields{
+		"mount":          mount,
+		"organization":   organization,
+		"base_url":       baseURL,
+		"token_policies": tokenPolicies,
+	}).Debug("Configuring GitHub auth method with parameters")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	enabled, err := enableAuthMount(vault, mount, "github")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if enabled {
+		recordAuthEnabled(ctx, req, mount, "github")
+	}
+
+	configData := map[string]interface{}{
+		"organization":   organization,
+		"base_url":       baseURL,
+		"token_policies": tokenPolicies,
+	}
+	if tokenTTL != "" {
+		configData["token_ttl"] = tokenTTL
+	}
+
+	fullPath := fmt.Sprintf("auth/%s/config", mount)
+	previous := readPrevious(vault, fullPath)
+
+	written, err := vault.Logical().Write(fullPath, configData)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to write to path '%s': %v", fullPath, err)), nil
+	}
+
+	successMsg := fmt.Sprintf("Successfully configured GitHub auth method at path '%s' for organization '%s'.", mount, organization)
+	if enabled {
+		successMsg = fmt.Sprintf("Successfully enabled and configured GitHub auth method at path '%s' for organization '%s'.", mount, organization)
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":        mount,
+		"organization": organization,
+		"enabled":      enabled,
+	}).Info("Successfully configured GitHub auth method")
+
+	change := client.ChangeRecord{
+		ResourceType: client.ResourceGitHubConfig,
+		Mount:        mount,
+		Path:         fullPath,
+		Operation:    client.OperationCreate,
+		New:          configData,
+		Message:      successMsg,
+	}
+	if previous != nil {
+		change.Operation = client.OperationUpdate
+		change.Previous = previous
+	}
+	if written != nil {
+		change.VaultRequestID = written.RequestID
+	}
+
+	return client.NewChangeResult(ctx, req, change), nil
+}
@@ -0,0 +1,183 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// CreateCertAuthRole creates a tool for creating named certificate roles of the TLS certificate auth method
+func CreateCertAuthRole(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("create_cert_auth_role",
+			mcp.WithDescription(`Enable the TLS certificate auth method if needed and create or update a named certificate role. Clients log in with a client certificate that is signed by the trusted CA certificate of the role and matches its constraints.
+## Onboarding a service with mTLS
+  - Read the CA certificate of the issuer that signs the client certificates with the 'read_pki_issuer' tool.
+  - Create a role with this tool, passing the CA certificate, the common names the service may present and the policies of the service.
+  - Issue the client certificate of the service with the 'issue_pki_certificate' tool.
+  - Review the configured roles with the 'list_cert_auth_roles' tool.
+`),
+			mcp.WithString("mount",
+				mcp.DefaultString("cert"),
+				mcp.Description("The path of the TLS certificate auth method. Defaults to 'cert'."),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("The name of the certificate role, for example 'payments-service'."),
+			),
+			mcp.WithString("certificate",
+				mcp.Required(),
+				mcp.Description("The PEM encoded CA certificate trusted to sign client certificates, or the client certificate itself."),
+			),
+			mcp.WithString("allowed_common_names",
+				mcp.DefaultString(""),
+				mcp.Description("A comma-separated list of common names the client certificate must match. Glob patterns such as '*.payments.example.com' are supported."),
+			),
+			mcp.WithString("allowed_dns_sans",
+				mcp.DefaultString(""),
+				mcp.Description("A comma-separated list of DNS subject alternative names the client certificate must match."),
+			),
+			mcp.WithString("token_policies",
+				mcp.DefaultString(""),
+				mcp.Description("A comma-separated list of policies attached to the tokens issued on login."),
+			),
+			mcp.WithString("token_ttl",
+				mcp.DefaultString(""),
+				mcp.Description("The TTL of the tokens issued on login, such as '1h'. Defaults to the TTL of the mount."),
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return createCertAuthRoleHandler(ctx, req, logger)
+		},
+	}
+}
+
+func createCertAuthRoleHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling create_cert_auth_role request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, _ := args["mount"].(string)
+	mount = strings.Trim(mount, "/")
+	if mount == "" {
+		mount = "cert"
+	}
+
+	name, ok := args["name"].(string)
+	if !ok || name == "" {
+		return mcp.NewToolResultError("Missing or invalid 'name' parameter"), nil
+	}
+
+	certificate, ok := args["certificate"].(string)
+	if !ok || strings.TrimSpace(certificate) == "" {
+		return mcp.NewToolResultError("Missing or invalid 'certificate' parameter"), nil
+	}
+	if _, err := parseCertificate(certificate); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'certificate' parameter: %v", err)), nil
+	}
+
+	allowedCommonNamesStr, _ := args["allowed_common_names"].(string)
+	allowedCommonNames := utils.SplitList(allowedCommonNamesStr)
+	allowedDNSSansStr, _ := args["allowed_dns_sans"].(string)
+	allowedDNSSans := utils.SplitList(allowedDNSSansStr)
+	tokenPoliciesStr, _ := args["token_policies"].(string)
+	tokenPolicies := utils.SplitList(tokenPoliciesStr)
+	tokenTTL, _ := args["token_ttl"].(string)
+
+	logger.WithFields(log.Fields{
+		"mount":                mount,
+		"name":                 name,
+		"allowed_common_names": allowedCommonNames,
+		"allowed_dns_sans":     allowedDNSSans,
+		"token_policies":       tokenPolicies,
+	}).Debug("Creating cert auth role with parameters")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	enabled, err := enableAuthMount(vault, mount, "cert")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if enabled {
+		recordAuthEnabled(ctx, req, mount, "cert")
+	}
+
+	roleData := map[string]interface{}{
+		"certificate":          certificate,
+		"allowed_common_names": allowedCommonNames,
+		"allowed_dns_sans":     allowedDNSSans,
+		"token_policies":       tokenPolicies,
+	}
+	if tokenTTL != "" {
+		roleData["token_ttl"] = tokenTTL
+	}
+
+	fullPath := fmt.Sprintf("auth/%s/certs/%s", mount, name)
+	previous := readPrevious(vault, fullPath)
+
+	written, err := vault.Logical().Write(fullPath, roleData)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to write to path '%s': %v", fullPath, err)), nil
+	}
+
+	successMsg := fmt.Sprintf("Successfully created cert auth role with name '%s' on auth method '%s'.", name, mount)
+	if enabled {
+		successMsg = fmt.Sprintf("Successfully enabled the cert auth method at path '%s' and created cert auth role with name '%s'.", mount, name)
+	}
+	if len(allowedCommonNames) == 0 && len(allowedDNSSans) == 0 {
+		successMsg += " Any certificate signed by the CA can log in with this role, set 'allowed_common_names' or 'allowed_dns_sans' to restrict it."
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":   mount,
+		"name":    name,
+		"enabled": enabled,
+	}).Info("Successfully created cert auth role")
+
+	change := client.ChangeRecord{
+		ResourceType: client.ResourceCertAuthRole,
+		Mount:        mount,
+		Path:         fullPath,
+		Operation:    client.OperationCreate,
+		New:          roleData,
+		Message:      successMsg,
+	}
+	if previous != nil {
+		change.Operation = client.OperationUpdate
+		change.Previous = previous
+	}
+	if written != nil {
+		change.VaultRequestID = written.RequestID
+	}
+
+	return client.NewChangeResult(ctx, req, change), nil
+}
+
+// parseCertificate decodes the first certificate of a PEM bundle
+func parseCertificate(data string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(strings.TrimSpace(data)))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("no PEM encoded certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
@@ -0,0 +1,151 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// CreateMFALoginEnforcement creates a tool for creating login MFA enforcements
+func CreateMFALoginEnforcement(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("create_mfa_login_enforcement",
+			mcp.WithDescription("Create or update a login enforcement, which requires the given MFA methods for logins through auth methods, or for members of identity groups and entities. At least one target must be given."),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("The name of the login enforcement, for example 'userpass-mfa'."),
+			),
+			mcp.WithString("mfa_method_ids",
+				mcp.Required(),
+				mcp.Description("A comma-separated list of MFA method IDs, as returned by 'create_mfa_method' or 'list_mfa_methods'."),
+			),
+			mcp.WithString("auth_methods",
+				mcp.DefaultString(""),
+				mcp.Description("A comma-separated list of paths of auth methods that require MFA, for example 'userpass,ldap'."),
+			),
+			mcp.WithString("auth_method_types",
+				mcp.DefaultString(""),
+				mcp.Description("A comma-separated list of types of auth methods that require MFA, which applies to every auth method of these types, for example 'userpass'."),
+			),
+			mcp.WithString("identity_group_ids",
+				mcp.DefaultString(""),
+				mcp.Description("A comma-separated list of IDs of identity groups whose members require MFA."),
+			),
+			mcp.WithString("identity_entity_ids",
+				mcp.DefaultString(""),
+				mcp.Description("A comma-separated list of IDs of identity entities that require MFA."),
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return createMFALoginEnforcementHandler(ctx, req, logger)
+		},
+	}
+}
+
+func createMFALoginEnforcementHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling create_mfa_login_enforcement request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	name, ok := args["name"].(string)
+	if !ok || name == "" {
+		return mcp.NewToolResultError("Missing or invalid 'name' parameter"), nil
+	}
+
+	lists := map[string][]string{}
+	for _, param := range []string{"mfa_method_ids", "auth_methods", "auth_method_types", "identity_group_ids", "identity_entity_ids"} {
+		value, _ := args[param].(string)
+		lists[param] = utils.SplitList(value)
+	}
+
+	if len(lists["mfa_method_ids"]) == 0 {
+		return mcp.NewToolResultError("Missing or invalid 'mfa_method_ids' parameter"), nil
+	}
+	if len(lists["auth_methods"]) == 0 && len(lists["auth_method_types"]) == 0 && len(lists["identity_group_ids"]) == 0 && len(lists["identity_entity_ids"]) == 0 {
+		return mcp.NewToolResultError("At least one of 'auth_methods', 'auth_method_types', 'identity_group_ids' or 'identity_entity_ids' is required"), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"name":              name,
+		"mfa_method_ids":    lists["mfa_method_ids"],
+		"auth_methods":      lists["auth_methods"],
+		"auth_method_types": lists["auth_method_types"],
+	}).Debug("Creating MFA login enforcement with parameters")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	// Login enforcements refer to auth methods by accessor
+	var accessors []string
+	if len(lists["auth_methods"]) > 0 {
+		auths, err := vault.Sys().ListAuth()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to list auth methods: %v", err)), nil
+		}
+		for _, path := range lists["auth_methods"] {
+			auth, ok := auths[strings.Trim(path, "/")+"/"]
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("auth method path '%s' does not exist", path)), nil
+			}
+			accessors = append(accessors, auth.Accessor)
+		}
+	}
+
+	enforcementData := map[string]interface{}{
+		"mfa_method_ids": lists["mfa_method_ids"],
+	}
+	if len(accessors) > 0 {
+		enforcementData["auth_method_accessors"] = accessors
+	}
+	for _, param := range []string{"auth_method_types", "identity_group_ids", "identity_entity_ids"} {
+		if len(lists[param]) > 0 {
+			enforcementData[param] = lists[param]
+		}
+	}
+
+	fullPath := fmt.Sprintf("identity/mfa/login-enforcement/%s", name)
+	previous := readPrevious(vault, fullPath)
+
+	written, err := vault.Logical().Write(fullPath, enforcementData)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to write to path '%s': %v", fullPath, err)), nil
+	}
+
+	successMsg := fmt.Sprintf("Successfully created MFA login enforcement with name '%s'.", name)
+
+	logger.WithField("name", name).Info("Successfully created MFA login enforcement")
+
+	change := client.ChangeRecord{
+		ResourceType: client.ResourceMFALoginEnforcement,
+		Path:         fullPath,
+		Operation:    client.OperationCreate,
+		New:          enforcementData,
+		Message:      successMsg,
+	}
+	if previous != nil {
+		change.Operation = client.OperationUpdate
+		change.Previous = previous
+	}
+	if written != nil {
+		change.VaultRequestID = written.RequestID
+	}
+
+	return client.NewChangeResult(ctx, req, change), nil
+}
@@ -0,0 +1,165 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// mfaMethodParameters lists the parameters that each type of MFA method requires
+var mfaMethodParameters = map[string][]string{
+	"totp": {"issuer"},
+	"duo":  {"secret_key", "integration_key", "api_hostname"},
+	"okta": {"org_name", "api_token"},
+}
+
+// CreateMFAMethod creates a tool for creating login MFA methods
+func CreateMFAMethod(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("create_mfa_method",
+			mcp.WithDescription(`Create a login MFA method in Vault. The method is only used once a login enforcement refers to it.
+## Enforcing MFA for an auth method
+  - Create the MFA method with this tool and note the returned method ID.
+  - Create a login enforcement with the 'create_mfa_login_enforcement' tool that applies the method to the auth methods, groups or entities that must use MFA.
+  - Verify which auth methods are covered with the 'list_mfa_login_enforcements' tool.
+`),
+			mcp.WithString("type",
+				mcp.Required(),
+				mcp.Enum("totp", "duo", "okta"),
+				mcp.Description("The type of the MFA method."),
+			),
+			mcp.WithString("method_name",
+				mcp.DefaultString(""),
+				mcp.Description("A unique name for the MFA method, for example 'corp-totp'."),
+			),
+			mcp.WithString("issuer",
+				mcp.DefaultString(""),
+				mcp.Description("For 'totp', the name of the issuer shown in the authenticator app, for example 'Vault'."),
+			),
+			mcp.WithString("secret_key",
+				mcp.DefaultString(""),
+				mcp.Description("For 'duo', the secret key of the Duo application."),
+			),
+			mcp.WithString("integration_key",
+				mcp.DefaultString(""),
+				mcp.Description("For 'duo', the integration key of the Duo application."),
+			),
+			mcp.WithString("api_hostname",
+				mcp.DefaultString(""),
+				mcp.Description("For 'duo', the API hostname of the Duo application."),
+			),
+			mcp.WithString("org_name",
+				mcp.DefaultString(""),
+				mcp.Description("For 'okta', the name of the Okta organization."),
+			),
+			mcp.WithString("api_token",
+				mcp.DefaultString(""),
+				mcp.Description("For 'okta', an Okta API token."),
+			),
+			mcp.WithString("base_url",
+				mcp.DefaultString(""),
+				mcp.Description("For 'okta', the base domain of the organization, such as 'okta.com' or 'oktapreview.com'."),
+			),
+			mcp.WithString("username_format",
+				mcp.DefaultString(""),
+				mcp.Description("For 'duo' and 'okta', a template that maps the identity to the user name of the MFA provider, for example '{{identity.entity.metadata.email}}'."),
+			),
+			schemas.Output("create_mfa_method"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return createMFAMethodHandler(ctx, req, logger)
+		},
+	}
+}
+
+func createMFAMethodHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling create_mfa_method request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	methodType, _ := args["type"].(string)
+	required, ok := mfaMethodParameters[methodType]
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid 'type' parameter, must be one of 'totp', 'duo' or 'okta'"), nil
+	}
+
+	methodData := map[string]interface{}{}
+	for _, name := range required {
+		value, _ := args[name].(string)
+		if value == "" {
+			return mcp.NewToolResultError(fmt.Sprintf("The '%s' parameter is required for '%s' MFA methods", name, methodType)), nil
+		}
+		methodData[name] = value
+	}
+	for _, name := range []string{"method_name", "base_url", "username_format"} {
+		if value, _ := args[name].(string); value != "" {
+			methodData[name] = value
+		}
+	}
+
+	// Credentials of the MFA provider are never logged
+	logger.WithFields(log.Fields{
+		"type":        methodType,
+		"method_name": methodData["method_name"],
+	}).Debug("Creating MFA method with parameters")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	fullPath := fmt.Sprintf("identity/mfa/method/%s", methodType)
+
+	secret, err := vault.Logical().Write(fullPath, methodData)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to write to path '%s': %v", fullPath, err)), nil
+	}
+
+	method := MFAMethod{Type: methodType}
+	method.Name, _ = methodData["method_name"].(string)
+	// Credentials of the MFA provider are not kept in the change record
+	change := client.ChangeRecord{
+		Tool:         req.Params.Name,
+		ResourceType: client.ResourceMFAMethod,
+		Operation:    client.OperationCreate,
+		New:          map[string]any{"type": methodType, "method_name": method.Name},
+	}
+	if secret != nil {
+		change.VaultRequestID = secret.RequestID
+		if secret.Data != nil {
+			method.ID, _ = secret.Data["method_id"].(string)
+		}
+	}
+	change.Path = fmt.Sprintf("%s/%s", fullPath, method.ID)
+	change.New["method_id"] = method.ID
+	change.Message = fmt.Sprintf("Created %s MFA method '%s'.", methodType, method.ID)
+	client.RecordChange(ctx, change)
+
+	jsonData, err := json.Marshal(method)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal MFA method to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"type":      methodType,
+		"method_id": method.ID,
+	}).Info("Successfully created MFA method")
+
+	return mcp.NewToolResultStructured(method, string(jsonData)), nil
+}
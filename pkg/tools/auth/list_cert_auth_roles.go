@@ -0,0 +1,126 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// CertAuthRole describes a named certificate role of the TLS certificate auth method
+type CertAuthRole struct {
+	Name               string   `json:"name"`
+	CASubject          string   `json:"ca_subject,omitempty"`
+	CANotAfter         string   `json:"ca_not_after,omitempty"` // Expiry of the trusted certificate in RFC 3339 format
+	CAExpired          bool     `json:"ca_expired,omitempty"`
+	AllowedCommonNames []string `json:"allowed_common_names"`
+	AllowedDNSSans     []string `json:"allowed_dns_sans"`
+	TokenPolicies      []string `json:"token_policies"`
+}
+
+// ListCertAuthRoles creates a tool for listing the named certificate roles of the TLS certificate auth method
+func ListCertAuthRoles(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("list_cert_auth_roles",
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithDescription("Get the named certificate roles of a TLS certificate auth method, with the subject and expiry of their trusted certificate, their allowed names and their policies."),
+			mcp.WithString("mount",
+				mcp.DefaultString("cert"),
+				mcp.Description("The path of the TLS certificate auth method. Defaults to 'cert'."),
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return listCertAuthRolesHandler(ctx, req, logger)
+		},
+	}
+}
+
+func listCertAuthRolesHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling list_cert_auth_roles request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, _ := args["mount"].(string)
+	mount = strings.Trim(mount, "/")
+	if mount == "" {
+		mount = "cert"
+	}
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	if err := checkAuthMount(vault, mount, "cert", "create_cert_auth_role"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	listPath := fmt.Sprintf("auth/%s/certs", mount)
+	secret, err := vault.Logical().List(listPath)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list path '%s': %v", listPath, err)), nil
+	}
+
+	roles := []CertAuthRole{}
+	if secret != nil && secret.Data != nil {
+		for _, name := range toStrings(secret.Data["keys"]) {
+			rolePath := fmt.Sprintf("%s/%s", listPath, name)
+			roleSecret, err := vault.Logical().Read(rolePath)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to read path '%s': %v", rolePath, err)), nil
+			}
+			if roleSecret == nil || roleSecret.Data == nil {
+				continue
+			}
+
+			role := CertAuthRole{
+				Name:               name,
+				AllowedCommonNames: toStrings(roleSecret.Data["allowed_common_names"]),
+				AllowedDNSSans:     toStrings(roleSecret.Data["allowed_dns_sans"]),
+				TokenPolicies:      toStrings(roleSecret.Data["token_policies"]),
+			}
+
+			pemData, _ := roleSecret.Data["certificate"].(string)
+			if cert, err := parseCertificate(pemData); err == nil {
+				role.CASubject = cert.Subject.String()
+				role.CANotAfter = cert.NotAfter.UTC().Format(time.RFC3339)
+				role.CAExpired = time.Now().After(cert.NotAfter)
+			}
+
+			roles = append(roles, role)
+		}
+	}
+
+	jsonData, err := json.Marshal(roles)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal roles to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount": mount,
+		"roles": len(roles),
+	}).Debug("Successfully listed cert auth roles")
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
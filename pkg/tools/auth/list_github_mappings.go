@@ -0,0 +1,159 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// GitHubMappings describes the configuration of a GitHub auth method and its policy mappings
+type GitHubMappings struct {
+	Mount         string              `json:"mount"`
+	Organization  string              `json:"organization"`
+	BaseURL       string              `json:"base_url,omitempty"`
+	TokenPolicies []string            `json:"token_policies"` // Attached to every member of the organization
+	Teams         map[string][]string `json:"teams"`
+	Users         map[string][]string `json:"users"`
+}
+
+// ListGitHubMappings creates a tool for listing the configuration and mappings of the GitHub auth method
+func ListGitHubMappings(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("list_github_mappings",
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithDescription("Get the organization of a GitHub auth method and the policies mapped to each of its GitHub teams and users."),
+			mcp.WithString("mount",
+				mcp.DefaultString("github"),
+				mcp.Description("The path of the GitHub auth method. Defaults to 'github'."),
+			),
+			schemas.Output("list_github_mappings"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return listGitHubMappingsHandler(ctx, req, logger)
+		},
+	}
+}
+
+func listGitHubMappingsHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling list_github_mappings request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, _ := args["mount"].(string)
+	mount = strings.Trim(mount, "/")
+	if mount == "" {
+		mount = "github"
+	}
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	if err := checkAuthMount(vault, mount, "github", "configure_github_auth"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	mappings := GitHubMappings{
+		Mount:         mount,
+		TokenPolicies: []string{},
+	}
+
+	configPath := fmt.Sprintf("auth/%s/config", mount)
+	config, err := vault.Logical().Read(configPath)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to read path '%s': %v", configPath, err)), nil
+	}
+	if config != nil && config.Data != nil {
+		mappings.Organization, _ = config.Data["organization"].(string)
+		mappings.BaseURL, _ = config.Data["base_url"].(string)
+		mappings.TokenPolicies = append(mappings.TokenPolicies, toStrings(config.Data["token_policies"])...)
+	}
+
+	if mappings.Teams, err = readGitHubMap(vault, mount, "teams"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if mappings.Users, err = readGitHubMap(vault, mount, "users"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	jsonData, err := json.Marshal(mappings)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal mappings to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount": mount,
+		"teams": len(mappings.Teams),
+		"users": len(mappings.Users),
+	}).Debug("Successfully listed GitHub mappings")
+
+	return mcp.NewToolResultStructured(mappings, string(jsonData)), nil
+}
+
+// readGitHubMap reads the policies mapped to every team or user of a GitHub auth method
+func readGitHubMap(vault *api.Client, mount string, kind string) (map[string][]string, error) {
+	listPath := fmt.Sprintf("auth/%s/map/%s", mount, kind)
+
+	secret, err := vault.Logical().List(listPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list path '%s': %v", listPath, err)
+	}
+
+	mapping := map[string][]string{}
+	if secret == nil || secret.Data == nil {
+		return mapping, nil
+	}
+
+	for _, name := range toStrings(secret.Data["keys"]) {
+		entryPath := fmt.Sprintf("%s/%s", listPath, name)
+		entry, err := vault.Logical().Read(entryPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read path '%s': %v", entryPath, err)
+		}
+
+		policies := []string{}
+		if entry != nil && entry.Data != nil {
+			value, _ := entry.Data["value"].(string)
+			policies = append(policies, utils.SplitList(value)...)
+		}
+		mapping[name] = policies
+	}
+
+	return mapping, nil
+}
+
+// toStrings converts a list returned by Vault to a string slice
+func toStrings(v interface{}) []string {
+	items, _ := v.([]interface{})
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		if value, ok := item.(string); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
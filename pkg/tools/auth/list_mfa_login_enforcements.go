@@ -0,0 +1,197 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// MFALoginEnforcement describes a login enforcement, which requires MFA methods for logins
+type MFALoginEnforcement struct {
+	Name                string   `json:"name"`
+	MFAMethodIDs        []string `json:"mfa_method_ids"`
+	AuthMethodAccessors []string `json:"auth_method_accessors,omitempty"`
+	AuthMethodTypes     []string `json:"auth_method_types,omitempty"`
+	IdentityGroupIDs    []string `json:"identity_group_ids,omitempty"`
+	IdentityEntityIDs   []string `json:"identity_entity_ids,omitempty"`
+}
+
+// AuthMethodMFA describes whether logins through an auth method require MFA
+type AuthMethodMFA struct {
+	Path       string   `json:"path"`
+	Type       string   `json:"type"`
+	Accessor   string   `json:"accessor"`
+	Enforced   bool     `json:"enforced"`              // Every login through the auth method requires MFA
+	EnforcedBy []string `json:"enforced_by,omitempty"` // Names of the enforcements that apply to the auth method
+}
+
+// MFAEnforcementReport lists the login enforcements and the MFA coverage of the auth methods
+type MFAEnforcementReport struct {
+	Enforcements []MFALoginEnforcement `json:"enforcements"`
+	AuthMethods  []AuthMethodMFA       `json:"auth_methods"`
+	// Enforcements that only target identity groups or entities, which require MFA for some users of any auth method
+	IdentityEnforcements []string `json:"identity_enforcements,omitempty"`
+}
+
+// ListMFALoginEnforcements creates a tool for listing login MFA enforcements and the auth methods they cover
+func ListMFALoginEnforcements(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("list_mfa_login_enforcements",
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithDescription("Get the login MFA enforcements of Vault and report for every enabled auth method whether logins through it require MFA. Use this to answer questions such as 'is MFA enforced for userpass'."),
+			mcp.WithString("auth_method",
+				mcp.DefaultString(""),
+				mcp.Description("Optional path or type of an auth method, such as 'userpass', to only report the coverage of matching auth methods."),
+			),
+			schemas.Output("list_mfa_login_enforcements"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return listMFALoginEnforcementsHandler(ctx, req, logger)
+		},
+	}
+}
+
+func listMFALoginEnforcementsHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling list_mfa_login_enforcements request")
+
+	authMethod := strings.Trim(req.GetString("auth_method", ""), "/")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	enforcements, err := ReadMFALoginEnforcements(vault)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	auths, err := vault.Sys().ListAuth()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list auth methods: %v", err)), nil
+	}
+
+	report := CheckMFACoverage(enforcements, auths)
+
+	if authMethod != "" {
+		matching := []AuthMethodMFA{}
+		for _, coverage := range report.AuthMethods {
+			if coverage.Path == authMethod || coverage.Type == authMethod {
+				matching = append(matching, coverage)
+			}
+		}
+		if len(matching) == 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("no auth method with path or type '%s' is enabled", authMethod)), nil
+		}
+		report.AuthMethods = matching
+	}
+
+	jsonData, err := json.Marshal(report)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal MFA enforcements to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithField("enforcements", len(enforcements)).Debug("Successfully listed MFA login enforcements")
+
+	return mcp.NewToolResultStructured(report, string(jsonData)), nil
+}
+
+// ReadMFALoginEnforcements reads every login MFA enforcement
+func ReadMFALoginEnforcements(vault *api.Client) ([]MFALoginEnforcement, error) {
+	listPath := "identity/mfa/login-enforcement"
+
+	secret, err := vault.Logical().List(listPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list path '%s': %v", listPath, err)
+	}
+
+	enforcements := []MFALoginEnforcement{}
+	if secret == nil || secret.Data == nil {
+		return enforcements, nil
+	}
+
+	for _, name := range toStrings(secret.Data["keys"]) {
+		entryPath := fmt.Sprintf("%s/%s", listPath, name)
+		entry, err := vault.Logical().Read(entryPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read path '%s': %v", entryPath, err)
+		}
+		if entry == nil || entry.Data == nil {
+			continue
+		}
+
+		enforcements = append(enforcements, MFALoginEnforcement{
+			Name:                name,
+			MFAMethodIDs:        toStrings(entry.Data["mfa_method_ids"]),
+			AuthMethodAccessors: toStrings(entry.Data["auth_method_accessors"]),
+			AuthMethodTypes:     toStrings(entry.Data["auth_method_types"]),
+			IdentityGroupIDs:    toStrings(entry.Data["identity_group_ids"]),
+			IdentityEntityIDs:   toStrings(entry.Data["identity_entity_ids"]),
+		})
+	}
+
+	return enforcements, nil
+}
+
+// CheckMFACoverage reports which auth methods are covered by the login enforcements. An enforcement
+// covers an auth method when it targets its accessor or its type.
+func CheckMFACoverage(enforcements []MFALoginEnforcement, auths map[string]*api.AuthMount) MFAEnforcementReport {
+	report := MFAEnforcementReport{
+		Enforcements: enforcements,
+		AuthMethods:  []AuthMethodMFA{},
+	}
+
+	for _, enforcement := range enforcements {
+		if len(enforcement.AuthMethodAccessors) == 0 && len(enforcement.AuthMethodTypes) == 0 {
+			report.IdentityEnforcements = append(report.IdentityEnforcements, enforcement.Name)
+		}
+	}
+
+	for path, auth := range auths {
+		// The token auth method does not support login MFA
+		if auth.Type == "token" {
+			continue
+		}
+
+		coverage := AuthMethodMFA{
+			Path:     strings.TrimSuffix(path, "/"),
+			Type:     auth.Type,
+			Accessor: auth.Accessor,
+		}
+		for _, enforcement := range enforcements {
+			if slices.Contains(enforcement.AuthMethodAccessors, auth.Accessor) || slices.Contains(enforcement.AuthMethodTypes, auth.Type) {
+				coverage.EnforcedBy = append(coverage.EnforcedBy, enforcement.Name)
+			}
+		}
+		coverage.Enforced = len(coverage.EnforcedBy) > 0
+
+		report.AuthMethods = append(report.AuthMethods, coverage)
+	}
+
+	sort.Slice(report.AuthMethods, func(i, j int) bool {
+		return report.AuthMethods[i].Path < report.AuthMethods[j].Path
+	})
+
+	return report
+}
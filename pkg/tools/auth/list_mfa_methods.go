@@ -0,0 +1,89 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// MFAMethod describes a login MFA method
+type MFAMethod struct {
+	ID   string `json:"id"` // Used in 'mfa_method_ids' of login enforcements
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+}
+
+// ListMFAMethods creates a tool for listing login MFA methods
+func ListMFAMethods(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("list_mfa_methods",
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithDescription("Get the login MFA methods (TOTP, Duo, Okta, PingID) configured in Vault, with the IDs that login enforcements refer to."),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return listMFAMethodsHandler(ctx, req, logger)
+		},
+	}
+}
+
+func listMFAMethodsHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling list_mfa_methods request")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	fullPath := "identity/mfa/method"
+
+	secret, err := vault.Logical().List(fullPath)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list path '%s': %v", fullPath, err)), nil
+	}
+
+	methods := []MFAMethod{}
+	if secret != nil && secret.Data != nil {
+		keyInfo, _ := secret.Data["key_info"].(map[string]interface{})
+		for _, id := range toStrings(secret.Data["keys"]) {
+			method := MFAMethod{ID: id}
+			if info, ok := keyInfo[id].(map[string]interface{}); ok {
+				method.Type, _ = info["type"].(string)
+				method.Name, _ = info["name"].(string)
+			}
+			methods = append(methods, method)
+		}
+	}
+
+	sort.Slice(methods, func(i, j int) bool {
+		if methods[i].Type != methods[j].Type {
+			return methods[i].Type < methods[j].Type
+		}
+		return methods[i].Name < methods[j].Name
+	})
+
+	jsonData, err := json.Marshal(methods)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal MFA methods to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithField("methods", len(methods)).Debug("Successfully listed MFA methods")
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
@@ -0,0 +1,157 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// MapGitHubTeam creates a tool for mapping GitHub teams and users to policies
+func MapGitHubTeam(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("map_github_team",
+			mcp.WithDescription("Map a GitHub team, or an individual GitHub user, of the configured organization to Vault policies. The policies are attached to the token when a member logs in. An empty list of policies removes the mapping."),
+			mcp.WithString("mount",
+				mcp.DefaultString("github"),
+				mcp.Description("The path of the GitHub auth method. Defaults to 'github'."),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("The slug of the GitHub team, such as 'platform-team', or the GitHub user name."),
+			),
+			mcp.WithString("type",
+				mcp.DefaultString("team"),
+				mcp.Enum("team", "user"),
+				mcp.Description("Whether 'name' is a team or a user. Defaults to 'team'."),
+			),
+			mcp.WithString("policies",
+				mcp.DefaultString(""),
+				mcp.Description("A comma-separated list of policies for the team or user. Leave empty to remove the mapping."),
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mapGitHubTeamHandler(ctx, req, logger)
+		},
+	}
+}
+
+func mapGitHubTeamHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling map_github_team request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, _ := args["mount"].(string)
+	mount = strings.Trim(mount, "/")
+	if mount == "" {
+		mount = "github"
+	}
+
+	name, ok := args["name"].(string)
+	if !ok || name == "" {
+		return mcp.NewToolResultError("Missing or invalid 'name' parameter"), nil
+	}
+
+	mappingType, _ := args["type"].(string)
+	if mappingType == "" {
+		mappingType = "team"
+	}
+	if mappingType != "team" && mappingType != "user" {
+		return mcp.NewToolResultError("Invalid 'type' parameter, must be 'team' or 'user'"), nil
+	}
+
+	policiesStr, _ := args["policies"].(string)
+	policies := utils.SplitList(policiesStr)
+
+	logger.WithFields(log.Fields{
+		"mount":    mount,
+		"name":     name,
+		"type":     mappingType,
+		"policies": policies,
+	}).Debug("Mapping GitHub team with parameters")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	if err := checkAuthMount(vault, mount, "github", "configure_github_auth"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	fullPath := fmt.Sprintf("auth/%s/map/%ss/%s", mount, mappingType, name)
+	previous := readPrevious(vault, fullPath)
+
+	if len(policies) == 0 {
+		deleted, err := vault.Logical().Delete(fullPath)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to delete path '%s': %v", fullPath, err)), nil
+		}
+
+		logger.WithFields(log.Fields{
+			"mount": mount,
+			"name":  name,
+			"type":  mappingType,
+		}).Info("Successfully removed GitHub mapping")
+
+		change := client.ChangeRecord{
+			ResourceType: client.ResourceGitHubMapping,
+			Mount:        mount,
+			Path:         fullPath,
+			Operation:    client.OperationDelete,
+			Previous:     previous,
+			Message:      fmt.Sprintf("Successfully removed the policies of GitHub %s '%s' on auth method '%s'.", mappingType, name, mount),
+		}
+		if deleted != nil {
+			change.VaultRequestID = deleted.RequestID
+		}
+		return client.NewChangeResult(ctx, req, change), nil
+	}
+
+	written, err := vault.Logical().Write(fullPath, map[string]interface{}{
+		"value": strings.Join(policies, ","),
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to write to path '%s': %v", fullPath, err)), nil
+	}
+
+	successMsg := fmt.Sprintf("Successfully mapped GitHub %s '%s' to policies '%s' on auth method '%s'.", mappingType, name, strings.Join(policies, ", "), mount)
+
+	logger.WithFields(log.Fields{
+		"mount": mount,
+		"name":  name,
+		"type":  mappingType,
+	}).Info("Successfully mapped GitHub team")
+
+	change := client.ChangeRecord{
+		ResourceType: client.ResourceGitHubMapping,
+		Mount:        mount,
+		Path:         fullPath,
+		Operation:    client.OperationCreate,
+		New:          map[string]any{"value": strings.Join(policies, ",")},
+		Message:      successMsg,
+	}
+	if previous != nil {
+		change.Operation = client.OperationUpdate
+		change.Previous = previous
+	}
+	if written != nil {
+		change.VaultRequestID = written.RequestID
+	}
+
+	return client.NewChangeResult(ctx, req, change), nil
+}
@@ -0,0 +1,254 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// KubernetesServiceAccountTokenPath is where Kubernetes mounts the token of the pod's service account
+const KubernetesServiceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// LoginTestResult describes the token that a test login produced
+type LoginTestResult struct {
+	Mount            string            `json:"mount"`
+	Type             string            `json:"type"`
+	Policies         []string          `json:"policies"`
+	TokenPolicies    []string          `json:"token_policies"`
+	IdentityPolicies []string          `json:"identity_policies,omitempty"`
+	TTL              int               `json:"ttl"` // Lease duration of the token in seconds
+	Renewable        bool              `json:"renewable"`
+	EntityID         string            `json:"entity_id,omitempty"`
+	Metadata         map[string]string `json:"metadata,omitempty"`
+	MFARequired      bool              `json:"mfa_required,omitempty"` // The login requires MFA, no token was issued
+	Revoked          bool              `json:"revoked"`
+	Accessor         string            `json:"accessor,omitempty"` // Accessor of the token when it was not revoked, to look it up or revoke it later
+}
+
+// TestAuthLogin creates a tool for verifying that a login through an auth method works
+func TestAuthLogin(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("test_auth_login",
+			mcp.WithDescription("Log in through an auth method with the given credentials and report the policies and TTL of the resulting token, to verify that an auth method and its roles are set up correctly. The session keeps using its own token. The test token is revoked right away, unless 'revoke' is false or revoking it fails, in which case its accessor is returned so that it can be looked up or revoked later; the token itself is never returned. Supports userpass, ldap, okta, radius, approle, kubernetes, jwt, oidc (with a JWT) and github auth methods. For kubernetes, the service account token of the server's pod is used when no 'jwt' is given."),
+			mcp.WithString("mount",
+				mcp.Required(),
+				mcp.Description("The path of the auth method, for example 'userpass' or 'kubernetes'."),
+			),
+			mcp.WithString("type",
+				mcp.DefaultString(""),
+				mcp.Description("The type of the auth method. Only needed when the token of the session cannot read the auth methods."),
+			),
+			mcp.WithString("username",
+				mcp.DefaultString(""),
+				mcp.Description("The user name, for userpass, ldap, okta and radius."),
+			),
+			mcp.WithString("password",
+				mcp.DefaultString(""),
+				mcp.Description("The password, for userpass, ldap, okta and radius."),
+			),
+			mcp.WithString("role",
+				mcp.DefaultString(""),
+				mcp.Description("The role to log in with, for kubernetes, jwt and oidc."),
+			),
+			mcp.WithString("jwt",
+				mcp.DefaultString(""),
+				mcp.Description("The JWT to log in with, for kubernetes, jwt and oidc."),
+			),
+			mcp.WithString("role_id",
+				mcp.DefaultString(""),
+				mcp.Description("The role ID, for approle."),
+			),
+			mcp.WithString("secret_id",
+				mcp.DefaultString(""),
+				mcp.Description("The secret ID, for approle."),
+			),
+			mcp.WithString("token",
+				mcp.DefaultString(""),
+				mcp.Description("The GitHub personal access token, for github."),
+			),
+			mcp.WithBoolean("revoke",
+				mcp.DefaultBool(true),
+				mcp.Description("Revoke the token issued by the test login. Defaults to true. When false, the accessor of the token is returned instead."),
+			),
+			schemas.Output("test_auth_login"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return testAuthLoginHandler(ctx, req, logger)
+		},
+	}
+}
+
+func testAuthLoginHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling test_auth_login request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, _ := args["mount"].(string)
+	mount = strings.Trim(mount, "/")
+	if mount == "" {
+		return mcp.NewToolResultError("Missing or invalid 'mount' parameter"), nil
+	}
+
+	authType, _ := args["type"].(string)
+	revoke := req.GetBool("revoke", true)
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	if authType == "" {
+		auths, err := vault.Sys().ListAuth()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to list auth methods, pass the 'type' parameter instead: %v", err)), nil
+		}
+		auth, ok := auths[mount+"/"]
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("auth method path '%s' does not exist", mount)), nil
+		}
+		authType = auth.Type
+	}
+
+	loginPath, loginData, err := buildLoginRequest(mount, authType, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Credentials are never logged
+	logger.WithFields(log.Fields{
+		"mount": mount,
+		"type":  authType,
+	}).Debug("Testing login through auth method")
+
+	// The login uses a copy of the client without a token, so the session token is left untouched
+	loginClient := vault.WithNamespace(vault.Namespace())
+	loginClient.ClearToken()
+
+	secret, err := loginClient.Logical().WriteWithContext(ctx, loginPath, loginData)
+	if err != nil {
+		logger.WithError(err).WithField("mount", mount).Info("Test login failed")
+		return mcp.NewToolResultError(fmt.Sprintf("Login through auth method '%s' failed: %v", mount, err)), nil
+	}
+	if secret == nil || secret.Auth == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Login through auth method '%s' returned no token", mount)), nil
+	}
+
+	result := LoginTestResult{
+		Mount:            mount,
+		Type:             authType,
+		Policies:         secret.Auth.Policies,
+		TokenPolicies:    secret.Auth.TokenPolicies,
+		IdentityPolicies: secret.Auth.IdentityPolicies,
+		TTL:              secret.Auth.LeaseDuration,
+		Renewable:        secret.Auth.Renewable,
+		EntityID:         secret.Auth.EntityID,
+		Metadata:         secret.Auth.Metadata,
+		MFARequired:      secret.Auth.MFARequirement != nil,
+	}
+
+	if revoke && secret.Auth.ClientToken != "" {
+		loginClient.SetToken(secret.Auth.ClientToken)
+		if err := loginClient.Auth().Token().RevokeSelfWithContext(ctx, ""); err != nil {
+			logger.WithError(err).WithField("mount", mount).Warn("Failed to revoke the token of the test login")
+		} else {
+			result.Revoked = true
+		}
+	}
+	if !result.Revoked {
+		result.Accessor = secret.Auth.Accessor
+	}
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal login result to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":    mount,
+		"type":     authType,
+		"policies": result.Policies,
+		"revoked":  result.Revoked,
+	}).Info("Test login succeeded")
+
+	return mcp.NewToolResultStructured(result, string(jsonData)), nil
+}
+
+// buildLoginRequest returns the login path and the credentials for a login through an auth method
+func buildLoginRequest(mount string, authType string, args map[string]interface{}) (string, map[string]interface{}, error) {
+	arg := func(name string) string {
+		value, _ := args[name].(string)
+		return value
+	}
+	require := func(names ...string) error {
+		for _, name := range names {
+			if arg(name) == "" {
+				return fmt.Errorf("the '%s' parameter is required to log in through a '%s' auth method", name, authType)
+			}
+		}
+		return nil
+	}
+
+	switch authType {
+	case "userpass", "ldap", "okta", "radius":
+		if err := require("username", "password"); err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("auth/%s/login/%s", mount, arg("username")), map[string]interface{}{"password": arg("password")}, nil
+	case "approle":
+		if err := require("role_id"); err != nil {
+			return "", nil, err
+		}
+		data := map[string]interface{}{"role_id": arg("role_id")}
+		if secretID := arg("secret_id"); secretID != "" {
+			data["secret_id"] = secretID
+		}
+		return fmt.Sprintf("auth/%s/login", mount), data, nil
+	case "kubernetes":
+		if err := require("role"); err != nil {
+			return "", nil, err
+		}
+		jwt := arg("jwt")
+		if jwt == "" {
+			token, err := os.ReadFile(KubernetesServiceAccountTokenPath)
+			if err != nil {
+				return "", nil, fmt.Errorf("no 'jwt' given and the service account token of the server could not be read: %v", err)
+			}
+			jwt = strings.TrimSpace(string(token))
+		}
+		return fmt.Sprintf("auth/%s/login", mount), map[string]interface{}{"role": arg("role"), "jwt": jwt}, nil
+	case "jwt", "oidc":
+		if err := require("jwt"); err != nil {
+			return "", nil, err
+		}
+		data := map[string]interface{}{"jwt": arg("jwt")}
+		if role := arg("role"); role != "" {
+			data["role"] = role
+		}
+		return fmt.Sprintf("auth/%s/login", mount), data, nil
+	case "github":
+		if err := require("token"); err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("auth/%s/login", mount), map[string]interface{}{"token": arg("token")}, nil
+	default:
+		return "", nil, fmt.Errorf("test logins through '%s' auth methods are not supported", authType)
+	}
+}
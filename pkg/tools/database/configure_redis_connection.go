@@ -0,0 +1,210 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// ConfigureRedisConnection creates a tool for configuring the connection of a database mount to Redis or ElastiCache
+func ConfigureRedisConnection(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("configure_redis_connection",
+			mcp.WithDescription(`Create or update the connection of a database mount to a Redis server with the 'redis' plugin, or to an Amazon ElastiCache for Redis cluster with the 'redis-elasticache' plugin. Vault manages the users of the cache through this connection.
+  - 'redis' connects to 'host' and 'port' with the 'username' and 'password' of a user that can manage ACL users, optionally over TLS.
+  - 'redis-elasticache' connects to the primary endpoint in 'url' with an AWS access key in 'username' and 'password', or with the AWS credentials of the Vault server when they are omitted. ElastiCache only supports static roles.
+The password is sent to Vault only and is never returned.`),
+			mcp.WithString("mount",
+				mcp.DefaultString("database"),
+				mcp.Description("The mount of the database secrets engine. Defaults to 'database'."),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("The name of the connection, for example 'sessions-cache'."),
+			),
+			mcp.WithString("plugin",
+				mcp.DefaultString("redis"),
+				mcp.Enum("redis", "redis-elasticache"),
+				mcp.Description("The plugin of the connection, 'redis' for Redis servers or 'redis-elasticache' for ElastiCache clusters. Defaults to 'redis'."),
+			),
+			mcp.WithString("host",
+				mcp.Description("The host of the Redis server, required for the 'redis' plugin."),
+			),
+			mcp.WithNumber("port",
+				mcp.DefaultNumber(6379),
+				mcp.Description("The port of the Redis server. Defaults to 6379."),
+			),
+			mcp.WithString("url",
+				mcp.Description("The primary endpoint of the ElastiCache cluster with its port, for example 'master.my-cluster.xxxxxx.use1.cache.amazonaws.com:6379', required for the 'redis-elasticache' plugin."),
+			),
+			mcp.WithString("region",
+				mcp.Description("The AWS region of the ElastiCache cluster, for example 'us-east-1'."),
+			),
+			mcp.WithString("username",
+				mcp.Description("The user of the Redis server, or the AWS access key ID for ElastiCache."),
+			),
+			mcp.WithString("password",
+				mcp.Description("The password of the user of the Redis server, or the AWS secret access key for ElastiCache."),
+			),
+			mcp.WithBoolean("tls",
+				mcp.DefaultBool(false),
+				mcp.Description("Connect to the Redis server over TLS."),
+			),
+			mcp.WithBoolean("insecure_tls",
+				mcp.DefaultBool(false),
+				mcp.Description("Skip the verification of the certificate of the Redis server. Only use it for tests."),
+			),
+			mcp.WithString("ca_cert",
+				mcp.Description("The PEM encoded CA certificate that signed the certificate of the Redis server."),
+			),
+			mcp.WithString("allowed_roles",
+				mcp.DefaultString("*"),
+				mcp.Description("A comma-separated list of the roles that can use the connection. Defaults to '*'."),
+			),
+			mcp.WithBoolean("verify_connection",
+				mcp.DefaultBool(true),
+				mcp.Description("Verify that Vault can connect with the configuration before saving it. Defaults to true."),
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return configureRedisConnectionHandler(ctx, req, logger)
+		},
+	}
+}
+
+func configureRedisConnectionHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling configure_redis_connection request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	name, ok := args["name"].(string)
+	if !ok || name == "" {
+		return mcp.NewToolResultError("Missing or invalid 'name' parameter"), nil
+	}
+
+	pluginArg := req.GetString("plugin", "redis")
+	plugin, ok := redisPlugins[pluginArg]
+	if !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'plugin' parameter '%s', expected 'redis' or 'redis-elasticache'", pluginArg)), nil
+	}
+
+	allowedRoles := utils.SplitList(req.GetString("allowed_roles", "*"))
+	config := map[string]interface{}{
+		"plugin_name":       plugin,
+		"allowed_roles":     allowedRoles,
+		"verify_connection": req.GetBool("verify_connection", true),
+	}
+	if username := req.GetString("username", ""); username != "" {
+		config["username"] = username
+	}
+	if password := req.GetString("password", ""); password != "" {
+		config["password"] = password
+	}
+
+	// Summary of the connection for the change record, without the password
+	summary := map[string]any{"plugin_name": plugin, "allowed_roles": allowedRoles}
+
+	switch plugin {
+	case RedisPlugin:
+		host := req.GetString("host", "")
+		if host == "" {
+			return mcp.NewToolResultError("Missing 'host' parameter, the 'redis' plugin needs the host of the Redis server"), nil
+		}
+		if config["username"] == nil || config["password"] == nil {
+			return mcp.NewToolResultError("Missing 'username' or 'password' parameter, the 'redis' plugin needs a user that can manage ACL users"), nil
+		}
+		port := req.GetInt("port", 6379)
+		if port <= 0 || port > 65535 {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid 'port' parameter %d", port)), nil
+		}
+		config["host"] = host
+		config["port"] = port
+		config["tls"] = req.GetBool("tls", false)
+		config["insecure_tls"] = req.GetBool("insecure_tls", false)
+		if caCert := req.GetString("ca_cert", ""); caCert != "" {
+			config["ca_cert"] = caCert
+		}
+		summary["endpoint"] = host + ":" + strconv.Itoa(port)
+		summary["tls"] = config["tls"]
+	case RedisElastiCachePlugin:
+		url := req.GetString("url", "")
+		if url == "" {
+			return mcp.NewToolResultError("Missing 'url' parameter, the 'redis-elasticache' plugin needs the primary endpoint of the cluster"), nil
+		}
+		config["url"] = url
+		if region := req.GetString("region", ""); region != "" {
+			config["region"] = region
+		}
+		summary["endpoint"] = url
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":  mount,
+		"name":   name,
+		"plugin": plugin,
+	}).Debug("Configuring Redis connection with parameters")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	if err := checkDatabaseMount(vault, mount); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	fullPath := fmt.Sprintf("%s/config/%s", mount, name)
+
+	// Keep a summary of the connection that is replaced in the change record
+	previous, _ := vault.Logical().Read(fullPath)
+
+	written, err := vault.Logical().Write(fullPath, config)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to write to path '%s': %v", fullPath, err)), nil
+	}
+
+	successMsg := fmt.Sprintf("Successfully configured connection '%s' with plugin '%s' on mount '%s'.", name, plugin, mount)
+
+	logger.WithFields(log.Fields{
+		"mount":  mount,
+		"name":   name,
+		"plugin": plugin,
+	}).Info("Successfully configured Redis connection")
+
+	change := client.ChangeRecord{
+		ResourceType: client.ResourceDatabaseConnection,
+		Mount:        mount,
+		Path:         fullPath,
+		Operation:    client.OperationCreate,
+		New:          summary,
+		Message:      successMsg,
+	}
+	if previous != nil && previous.Data != nil {
+		change.Operation = client.OperationUpdate
+		change.Previous = map[string]any{"plugin_name": previous.Data["plugin_name"], "allowed_roles": previous.Data["allowed_roles"]}
+	}
+	if written != nil {
+		change.VaultRequestID = written.RequestID
+	}
+	return client.NewChangeResult(ctx, req, change), nil
+}
@@ -0,0 +1,183 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// CreateRedisRole creates a tool for creating dynamic and static roles of Redis connections
+func CreateRedisRole(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("create_redis_role",
+			mcp.WithDescription(`Create or update a role of a Redis or ElastiCache connection of a database mount.
+  - A dynamic role creates a new Redis ACL user with the 'acl_rules' for every credential request, which is deleted when its lease expires. Only the 'redis' plugin supports dynamic roles.
+  - A static role manages the password of an existing user named 'username', which Vault rotates every 'rotation_period'.`),
+			mcp.WithString("mount",
+				mcp.DefaultString("database"),
+				mcp.Description("The mount of the database secrets engine. Defaults to 'database'."),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("The name of the role, for example 'cache-readonly'."),
+			),
+			mcp.WithString("connection",
+				mcp.Required(),
+				mcp.Description("The name of the connection the role uses, configured with 'configure_redis_connection'."),
+			),
+			mcp.WithString("type",
+				mcp.DefaultString("dynamic"),
+				mcp.Enum("dynamic", "static"),
+				mcp.Description("'dynamic' to create a user per credential request, or 'static' to rotate the password of an existing user. Defaults to 'dynamic'."),
+			),
+			mcp.WithString("acl_rules",
+				mcp.DefaultString("+@read,~*"),
+				mcp.Description("A comma-separated list of the Redis ACL rules of the users of a dynamic role, for example '+@read,~cache:*'. Defaults to '+@read,~*'."),
+			),
+			mcp.WithString("default_ttl",
+				mcp.DefaultString("1h"),
+				mcp.Description("The default lease TTL of the credentials of a dynamic role. Defaults to '1h'."),
+			),
+			mcp.WithString("max_ttl",
+				mcp.DefaultString("24h"),
+				mcp.Description("The maximum lease TTL of the credentials of a dynamic role. Defaults to '24h'."),
+			),
+			mcp.WithString("username",
+				mcp.Description("The existing user whose password a static role rotates, required for static roles."),
+			),
+			mcp.WithString("rotation_period",
+				mcp.DefaultString("24h"),
+				mcp.Description("How often Vault rotates the password of a static role, at least '5s'. Defaults to '24h'."),
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return createRedisRoleHandler(ctx, req, logger)
+		},
+	}
+}
+
+func createRedisRoleHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling create_redis_role request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	name, ok := args["name"].(string)
+	if !ok || name == "" {
+		return mcp.NewToolResultError("Missing or invalid 'name' parameter"), nil
+	}
+
+	connection, ok := args["connection"].(string)
+	if !ok || connection == "" {
+		return mcp.NewToolResultError("Missing or invalid 'connection' parameter"), nil
+	}
+
+	roleType := req.GetString("type", "dynamic")
+	if roleType != "dynamic" && roleType != "static" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'type' parameter '%s', expected 'dynamic' or 'static'", roleType)), nil
+	}
+
+	var fullPath string
+	roleData := map[string]interface{}{"db_name": connection}
+	if roleType == "static" {
+		username := req.GetString("username", "")
+		if username == "" {
+			return mcp.NewToolResultError("Missing 'username' parameter, a static role needs the user whose password it rotates"), nil
+		}
+		fullPath = fmt.Sprintf("%s/static-roles/%s", mount, name)
+		roleData["username"] = username
+		roleData["rotation_period"] = req.GetString("rotation_period", "24h")
+	} else {
+		rules := utils.SplitList(req.GetString("acl_rules", "+@read,~*"))
+		if len(rules) == 0 {
+			return mcp.NewToolResultError("Missing or invalid 'acl_rules' parameter"), nil
+		}
+		// The Redis plugin expects the ACL rules as a JSON array in a single creation statement
+		statement, _ := json.Marshal(rules)
+		fullPath = fmt.Sprintf("%s/roles/%s", mount, name)
+		roleData["creation_statements"] = []string{string(statement)}
+		roleData["default_ttl"] = req.GetString("default_ttl", "1h")
+		roleData["max_ttl"] = req.GetString("max_ttl", "24h")
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":      mount,
+		"name":       name,
+		"connection": connection,
+		"type":       roleType,
+	}).Debug("Creating Redis role with parameters")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	if err := checkDatabaseMount(vault, mount); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	plugin, err := connectionPlugin(vault, mount, connection)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	switch {
+	case plugin == "":
+		return mcp.NewToolResultError(fmt.Sprintf("connection '%s' does not exist on mount '%s', you should use 'configure_redis_connection' to create it.", connection, mount)), nil
+	case plugin != RedisPlugin && plugin != RedisElastiCachePlugin:
+		return mcp.NewToolResultError(fmt.Sprintf("connection '%s' uses the plugin '%s', not a Redis plugin", connection, plugin)), nil
+	case plugin == RedisElastiCachePlugin && roleType == "dynamic":
+		return mcp.NewToolResultError(fmt.Sprintf("connection '%s' is an ElastiCache connection, which only supports static roles", connection)), nil
+	}
+
+	// Keep the configuration that is replaced in the change record
+	previous, _ := vault.Logical().Read(fullPath)
+
+	written, err := vault.Logical().Write(fullPath, roleData)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to write to path '%s': %v", fullPath, err)), nil
+	}
+
+	successMsg := fmt.Sprintf("Successfully created %s Redis role with name '%s' for connection '%s' on mount '%s'.", roleType, name, connection, mount)
+
+	logger.WithFields(log.Fields{
+		"mount": mount,
+		"name":  name,
+		"type":  roleType,
+	}).Info("Successfully created Redis role")
+
+	change := client.ChangeRecord{
+		ResourceType: client.ResourceDatabaseRole,
+		Mount:        mount,
+		Path:         fullPath,
+		Operation:    client.OperationCreate,
+		New:          roleData,
+		Message:      successMsg,
+	}
+	if previous != nil {
+		change.Operation = client.OperationUpdate
+		change.Previous = previous.Data
+	}
+	if written != nil {
+		change.VaultRequestID = written.RequestID
+	}
+	return client.NewChangeResult(ctx, req, change), nil
+}
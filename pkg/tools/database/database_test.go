@@ -0,0 +1,182 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSession struct {
+	id string
+}
+
+func (s testSession) SessionID() string                                   { return s.id }
+func (s testSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s testSession) Initialize()                                         {}
+func (s testSession) Initialized() bool                                   { return true }
+
+func TestRedisTools(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	written := map[string]map[string]interface{}{}
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut || r.Method == http.MethodPost {
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			written[r.URL.Path] = body
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/sys/mounts":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"database/": map[string]interface{}{"type": "database"},
+				"secret/":   map[string]interface{}{"type": "kv"},
+			}})
+		case "/v1/database/config/cache":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"plugin_name": RedisPlugin}})
+		case "/v1/database/config/elasticache":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"plugin_name": RedisElastiCachePlugin}})
+		case "/v1/database/creds/readonly":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"lease_id":       "database/creds/readonly/abc",
+				"lease_duration": 3600,
+				"renewable":      true,
+				"data":           map[string]interface{}{"username": "V_TOKEN_READONLY_1", "password": "s3cr3t"},
+			})
+		case "/v1/database/static-creds/app":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"username": "app", "password": "r0tated", "last_vault_rotation": "2025-06-01T06:00:00Z", "ttl": 86000,
+			}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockVault.Close()
+
+	sessionID := "test-database"
+	_, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	defer client.DeleteVaultClient(sessionID)
+
+	ctx := server.NewMCPServer("test", "1.0").WithContext(context.Background(), testSession{id: sessionID})
+	call := func(tool server.ServerTool, args map[string]interface{}) *mcp.CallToolResult {
+		result, err := tool.Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("configure redis connection", func(t *testing.T) {
+		result := call(ConfigureRedisConnection(logger), map[string]interface{}{
+			"mount": "database", "name": "cache", "host": "redis.internal", "port": float64(6380), "username": "vault", "password": "p@ss", "tls": true,
+		})
+		require.False(t, result.IsError, result.Content)
+		config := written["/v1/database/config/cache"]
+		assert.Equal(t, RedisPlugin, config["plugin_name"])
+		assert.Equal(t, "redis.internal", config["host"])
+		assert.Equal(t, float64(6380), config["port"])
+		assert.Equal(t, true, config["tls"])
+		assert.Equal(t, []interface{}{"*"}, config["allowed_roles"])
+		assert.NotContains(t, result.Content[0].(mcp.TextContent).Text, "p@ss", "the password is not part of the change record")
+	})
+
+	t.Run("configure elasticache connection", func(t *testing.T) {
+		result := call(ConfigureRedisConnection(logger), map[string]interface{}{
+			"mount": "database", "name": "elasticache", "plugin": "redis-elasticache", "url": "primary.cache.amazonaws.com:6379", "region": "us-east-1",
+		})
+		require.False(t, result.IsError, result.Content)
+		config := written["/v1/database/config/elasticache"]
+		assert.Equal(t, RedisElastiCachePlugin, config["plugin_name"])
+		assert.Equal(t, "primary.cache.amazonaws.com:6379", config["url"])
+		assert.NotContains(t, config, "host")
+	})
+
+	t.Run("invalid connections", func(t *testing.T) {
+		for name, args := range map[string]map[string]interface{}{
+			"missing host":        {"mount": "database", "name": "c", "username": "u", "password": "p"},
+			"missing credentials": {"mount": "database", "name": "c", "host": "redis.internal"},
+			"missing url":         {"mount": "database", "name": "c", "plugin": "redis-elasticache"},
+			"unknown plugin":      {"mount": "database", "name": "c", "plugin": "memcached"},
+			"not a database":      {"mount": "secret", "name": "c", "host": "redis.internal", "username": "u", "password": "p"},
+		} {
+			assert.True(t, call(ConfigureRedisConnection(logger), args).IsError, name)
+		}
+	})
+
+	t.Run("create dynamic role", func(t *testing.T) {
+		result := call(CreateRedisRole(logger), map[string]interface{}{
+			"mount": "database", "name": "readonly", "connection": "cache", "acl_rules": "+@read, ~cache:*",
+		})
+		require.False(t, result.IsError, result.Content)
+		role := written["/v1/database/roles/readonly"]
+		assert.Equal(t, "cache", role["db_name"])
+		assert.Equal(t, []interface{}{`["+@read","~cache:*"]`}, role["creation_statements"])
+		assert.Equal(t, "1h", role["default_ttl"])
+	})
+
+	t.Run("create static role", func(t *testing.T) {
+		result := call(CreateRedisRole(logger), map[string]interface{}{
+			"mount": "database", "name": "app", "connection": "elasticache", "type": "static", "username": "app", "rotation_period": "12h",
+		})
+		require.False(t, result.IsError, result.Content)
+		role := written["/v1/database/static-roles/app"]
+		assert.Equal(t, "app", role["username"])
+		assert.Equal(t, "12h", role["rotation_period"])
+	})
+
+	t.Run("invalid roles", func(t *testing.T) {
+		for name, args := range map[string]map[string]interface{}{
+			"dynamic elasticache role": {"mount": "database", "name": "r", "connection": "elasticache"},
+			"unknown connection":       {"mount": "database", "name": "r", "connection": "missing"},
+			"static without username":  {"mount": "database", "name": "r", "connection": "cache", "type": "static"},
+		} {
+			assert.True(t, call(CreateRedisRole(logger), args).IsError, name)
+		}
+	})
+
+	t.Run("generate credentials", func(t *testing.T) {
+		result := call(GenerateRedisCredentials(logger), map[string]interface{}{"mount": "database", "role": "readonly"})
+		require.False(t, result.IsError, result.Content)
+		var credentials RedisCredentials
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &credentials))
+		assert.Equal(t, "V_TOKEN_READONLY_1", credentials.Username)
+		assert.Equal(t, "database/creds/readonly/abc", credentials.LeaseID)
+		assert.Equal(t, 3600, credentials.LeaseDuration)
+
+		result = call(GenerateRedisCredentials(logger), map[string]interface{}{"mount": "database", "role": "app", "type": "static"})
+		require.False(t, result.IsError, result.Content)
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &credentials))
+		assert.Equal(t, "r0tated", credentials.Password)
+		assert.Equal(t, 86000, credentials.TTL)
+
+		assert.True(t, call(GenerateRedisCredentials(logger), map[string]interface{}{"mount": "database", "role": "missing"}).IsError)
+	})
+
+	t.Run("reference credentials", func(t *testing.T) {
+		result := call(GenerateRedisCredentials(logger), map[string]interface{}{"mount": "database", "role": "app", "type": "static", "output": "reference"})
+		require.False(t, result.IsError, result.Content)
+		text := result.Content[0].(mcp.TextContent).Text
+		assert.NotContains(t, text, "r0tated")
+		var credentials RedisCredentials
+		require.NoError(t, json.Unmarshal([]byte(text), &credentials))
+		assert.Equal(t, "vault://database/static-creds/app#password", credentials.PasswordReference)
+		assert.Empty(t, credentials.Password)
+
+		result = call(GenerateRedisCredentials(logger), map[string]interface{}{"mount": "database", "role": "readonly", "output": "reference"})
+		assert.True(t, result.IsError, "dynamic credentials cannot be referenced")
+	})
+}
@@ -0,0 +1,106 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// EnableDatabase creates a tool for creating Vault database mounts
+func EnableDatabase(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("enable_database",
+			mcp.WithDescription(`Enable the database secrets engine in Vault, which generates dynamic credentials and rotates the passwords of static users of databases and caches such as Redis and Amazon ElastiCache for Redis.
+## Setting up Redis credentials
+  - Create a database mount using this tool. Examples of names could be 'database' or 'redis'.
+  - Configure the connection to the Redis server or ElastiCache cluster with the 'configure_redis_connection' tool.
+  - Create a dynamic or static role with the 'create_redis_role' tool. ElastiCache only supports static roles.
+  - Get credentials with the 'generate_redis_credentials' tool.
+`),
+			mcp.WithString("path",
+				mcp.DefaultString("database"),
+				mcp.Description("The path where the database mount will be created. Defaults to 'database'."),
+			),
+			mcp.WithString("description",
+				mcp.DefaultString(""),
+				mcp.Description("A description for the database mount."),
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return enableDatabaseHandler(ctx, req, logger)
+		},
+	}
+}
+
+func enableDatabaseHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling enable_database request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	path, ok := args["path"].(string)
+	if !ok || path == "" {
+		return mcp.NewToolResultError("Missing or invalid 'path' parameter"), nil
+	}
+
+	description, _ := args["description"].(string)
+
+	logger.WithFields(log.Fields{
+		"path":        path,
+		"description": description,
+	}).Debug("Creating database mount with parameters")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list mounts: %v", err)), nil
+	}
+
+	// Check if the mount exists
+	if _, ok := mounts[path+"/"]; ok {
+		return mcp.NewToolResultError(fmt.Sprintf("mount path '%s' already exist, you should use 'delete_mount' if you want to re-create it.", path)), nil
+	}
+
+	// Create the mount
+	err = vault.Sys().Mount(path, &api.MountInput{
+		Type:        "database",
+		Description: description,
+	})
+	if err != nil {
+		logger.WithError(err).WithField("path", path).Error("Failed to create database mount")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create database mount: %v", err)), nil
+	}
+
+	successMsg := fmt.Sprintf("Successfully created database mount at path '%s'", path)
+	if description != "" {
+		successMsg += fmt.Sprintf(" with description: %s", description)
+	}
+
+	logger.WithField("path", path).Info("Successfully created database mount")
+
+	return client.NewChangeResult(ctx, req, client.ChangeRecord{
+		ResourceType: client.ResourceMount,
+		Mount:        path,
+		Path:         "sys/mounts/" + path,
+		Operation:    client.OperationCreate,
+		New:          map[string]any{"type": "database", "description": description},
+		Message:      successMsg,
+	}), nil
+}
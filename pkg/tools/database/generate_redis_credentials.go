@@ -0,0 +1,148 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// RedisCredentials are the credentials of a dynamic or static Redis role
+type RedisCredentials struct {
+	Username          string `json:"username"`
+	Password          string `json:"password,omitempty"`
+	PasswordReference string `json:"password_reference,omitempty"`  // Reference to the password of a static role, returned instead of the password
+	LeaseID           string `json:"lease_id,omitempty"`            // Lease of dynamic credentials, the user is deleted when it expires
+	LeaseDuration     int    `json:"lease_duration,omitempty"`      // Seconds until the lease of dynamic credentials expires
+	Renewable         bool   `json:"renewable,omitempty"`           // Whether the lease of dynamic credentials can be renewed
+	LastVaultRotation string `json:"last_vault_rotation,omitempty"` // When Vault last rotated the password of a static role
+	TTL               int    `json:"ttl,omitempty"`                 // Seconds until Vault rotates the password of a static role
+}
+
+// GenerateRedisCredentials creates a tool for getting the credentials of Redis roles
+func GenerateRedisCredentials(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("generate_redis_credentials",
+			mcp.WithDescription("Get credentials of a Redis or ElastiCache role of a database mount. A dynamic role creates a new user whose lease expires after the TTL of the role; a static role returns the current password of its user, which Vault rotates on schedule. The password is returned in the result, so only request credentials that are needed. For a static role, output 'reference' returns a vault:// reference to the password instead of the password."),
+			mcp.WithString("mount",
+				mcp.DefaultString("database"),
+				mcp.Description("The mount of the database secrets engine. Defaults to 'database'."),
+			),
+			mcp.WithString("role",
+				mcp.Required(),
+				mcp.Description("The name of the role, created with 'create_redis_role'."),
+			),
+			mcp.WithString("type",
+				mcp.DefaultString("dynamic"),
+				mcp.Enum("dynamic", "static"),
+				mcp.Description("The type of the role, 'dynamic' or 'static'. Defaults to 'dynamic'."),
+			),
+			mcp.WithString("output",
+				mcp.DefaultString(utils.OutputValue),
+				mcp.Enum(utils.OutputValue, utils.OutputReference),
+				mcp.Description("'value' returns the password, 'reference' returns a reference to the password of a static role instead. Dynamic credentials are created by every read, so they cannot be referenced. Defaults to 'value'."),
+			),
+			schemas.Output("generate_redis_credentials"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return generateRedisCredentialsHandler(ctx, req, logger)
+		},
+	}
+}
+
+func generateRedisCredentialsHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling generate_redis_credentials request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	role, ok := args["role"].(string)
+	if !ok || role == "" {
+		return mcp.NewToolResultError("Missing or invalid 'role' parameter"), nil
+	}
+
+	roleType := req.GetString("type", "dynamic")
+	fullPath := fmt.Sprintf("%s/creds/%s", mount, role)
+	switch roleType {
+	case "dynamic":
+	case "static":
+		fullPath = fmt.Sprintf("%s/static-creds/%s", mount, role)
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'type' parameter '%s', expected 'dynamic' or 'static'", roleType)), nil
+	}
+
+	output, err := utils.ExtractOutput(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if output == utils.OutputReference && roleType != "static" {
+		return mcp.NewToolResultError("Dynamic credentials are created by every read and cannot be returned as a reference, use output 'reference' with a static role"), nil
+	}
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	if err := checkDatabaseMount(vault, mount); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	secret, err := vault.Logical().Read(fullPath)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to read path '%s': %v", fullPath, err)), nil
+	}
+	if secret == nil || secret.Data == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("%s role '%s' does not exist on mount '%s', you should use 'create_redis_role' to create it.", roleType, role, mount)), nil
+	}
+
+	credentials := RedisCredentials{
+		LeaseID:       secret.LeaseID,
+		LeaseDuration: secret.LeaseDuration,
+		Renewable:     secret.Renewable,
+	}
+	credentials.Username, _ = secret.Data["username"].(string)
+	credentials.Password, _ = secret.Data["password"].(string)
+	credentials.LastVaultRotation, _ = secret.Data["last_vault_rotation"].(string)
+	if ttl, ok := secret.Data["ttl"].(json.Number); ok {
+		value, _ := ttl.Int64()
+		credentials.TTL = int(value)
+	}
+	if output == utils.OutputReference {
+		credentials.Password = ""
+		credentials.PasswordReference = utils.SecretReference{Path: fullPath, Key: "password"}.String()
+	}
+
+	jsonData, err := json.Marshal(credentials)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal credentials to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount": mount,
+		"role":  role,
+		"type":  roleType,
+	}).Info("Successfully generated Redis credentials")
+
+	return mcp.NewToolResultStructured(credentials, string(jsonData)), nil
+}
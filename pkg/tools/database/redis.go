@@ -0,0 +1,52 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package database
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/vault/api"
+)
+
+// Names of the Redis database plugins built into Vault
+const (
+	RedisPlugin            = "redis-database-plugin"
+	RedisElastiCachePlugin = "redis-elasticache-database-plugin"
+)
+
+// redisPlugins maps the plugin argument of the Redis tools to the name of the plugin
+var redisPlugins = map[string]string{
+	"redis":             RedisPlugin,
+	"redis-elasticache": RedisElastiCachePlugin,
+}
+
+// checkDatabaseMount returns an error when the mount does not exist or is not a database mount
+func checkDatabaseMount(vault *api.Client, mount string) error {
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		return fmt.Errorf("failed to list mounts: %v", err)
+	}
+
+	existing, ok := mounts[mount+"/"]
+	if !ok {
+		return fmt.Errorf("mount path '%s' does not exist, you should use 'enable_database' if you want enable the database secrets engine on this mount.", mount)
+	}
+	if existing.Type != "database" {
+		return fmt.Errorf("mount path '%s' is a '%s' mount, not a database mount", mount, existing.Type)
+	}
+	return nil
+}
+
+// connectionPlugin returns the plugin of a connection of a database mount, or "" when the connection does not exist
+func connectionPlugin(vault *api.Client, mount string, connection string) (string, error) {
+	config, err := vault.Logical().Read(fmt.Sprintf("%s/config/%s", mount, connection))
+	if err != nil {
+		return "", fmt.Errorf("failed to read connection '%s': %v", connection, err)
+	}
+	if config == nil || config.Data == nil {
+		return "", nil
+	}
+	plugin, _ := config.Data["plugin_name"].(string)
+	return plugin, nil
+}
@@ -0,0 +1,117 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// SessionPolicyReport holds the policies covering the Vault requests of the session
+type SessionPolicyReport struct {
+	Policies  []SessionPolicy `json:"policies"`            // One policy per Vault namespace the session used
+	Requests  int             `json:"requests"`            // Number of authorized Vault requests the policies cover
+	Changes   int             `json:"changes"`             // Number of changes in the change journal of the session, which the policies cover
+	Truncated bool            `json:"truncated,omitempty"` // Whether paths are missing because the session accessed too many
+	Notes     []string        `json:"notes,omitempty"`     // What to review before using the policies
+}
+
+// SessionPolicy is a least-privilege policy for the paths a session used in one namespace
+type SessionPolicy struct {
+	Namespace string                `json:"namespace,omitempty"` // Namespace to write the policy in, the root namespace if empty
+	Policy    string                `json:"policy"`              // The policy in HCL
+	Paths     []client.AccessRecord `json:"paths"`               // The paths of the policy and the capabilities the requests used
+}
+
+// GeneratePolicyFromSession creates a tool for turning the Vault requests of the session into a policy
+func GeneratePolicyFromSession(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("generate_policy_from_session",
+			mcp.WithDescription("Generate a least-privilege Vault policy in HCL from the Vault requests the tools of the current MCP session made, covering exactly the paths and capabilities they used, such as the writes listed by 'get_session_changes' and the reads and lists that led to them. Use it to turn an exploratory session into a locked-down token for automation that repeats the same steps. Requests refused by Vault are left out. The policy is only returned, write it with 'vault policy write'."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithString("name",
+				mcp.Description("Name of the policy, used in the comment at the top of the policy. Defaults to 'mcp-session'."),
+			),
+			schemas.Output("generate_policy_from_session"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return generatePolicyFromSessionHandler(ctx, req, logger)
+		},
+	}
+}
+
+func generatePolicyFromSessionHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling generate_policy_from_session request")
+
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return mcp.NewToolResultError("No active session"), nil
+	}
+
+	name := req.GetString("name", "mcp-session")
+	records, truncated := client.SessionAccess(session.SessionID())
+	if len(records) == 0 {
+		return mcp.NewToolResultError("The session has not made any authorized Vault request yet, run the steps to cover first"), nil
+	}
+
+	report := SessionPolicyReport{
+		Changes:   len(client.SessionChanges(session.SessionID())),
+		Truncated: truncated,
+	}
+	for _, record := range records {
+		report.Requests += record.Requests
+		if len(report.Policies) == 0 || report.Policies[len(report.Policies)-1].Namespace != record.Namespace {
+			report.Policies = append(report.Policies, SessionPolicy{Namespace: record.Namespace})
+		}
+		policy := &report.Policies[len(report.Policies)-1]
+		policy.Paths = append(policy.Paths, record)
+	}
+	for i := range report.Policies {
+		report.Policies[i].Policy = formatSessionPolicy(name, report.Policies[i].Paths)
+	}
+
+	report.Notes = append(report.Notes, "Writes are granted both 'create' and 'update', because Vault checks one or the other depending on whether the resource exists. Remove the one the automation does not need.")
+	if len(report.Policies) > 1 {
+		report.Notes = append(report.Notes, "The session used several namespaces, write each policy in its namespace.")
+	}
+	if truncated {
+		report.Notes = append(report.Notes, "The session accessed more paths than are recorded, the policies do not cover all of them.")
+	}
+
+	jsonData, err := json.Marshal(report)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal session policy to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"policies": len(report.Policies),
+		"requests": report.Requests,
+	}).Info("Generated policy from session")
+
+	return mcp.NewToolResultStructured(report, string(jsonData)), nil
+}
+
+// formatSessionPolicy formats the paths as an HCL policy, one block per path
+func formatSessionPolicy(name string, paths []client.AccessRecord) string {
+	var policy strings.Builder
+	fmt.Fprintf(&policy, "# %s: generated from the Vault requests of an MCP session\n", name)
+	for _, path := range paths {
+		fmt.Fprintf(&policy, "\npath %q {\n  capabilities = [\"%s\"]\n}\n", path.Path, strings.Join(path.Capabilities, `", "`))
+	}
+	return policy.String()
+}
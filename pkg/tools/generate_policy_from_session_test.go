@@ -0,0 +1,119 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratePolicyFromSession(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	ctx, _ := newUndoTestContext(t)
+	sessionID := "test-" + t.Name()
+	defer client.DeleteSessionAccess(sessionID)
+
+	tool := GeneratePolicyFromSession(logger)
+	call := func() *mcp.CallToolResult {
+		result, err := tool.Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"name": "app-sync"}}})
+		require.NoError(t, err)
+		return result
+	}
+	assert.True(t, call().IsError, "a session without Vault requests has no policy")
+
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	require.NoError(t, err)
+	_, err = vault.Logical().Read("secret/data/app/config")
+	require.NoError(t, err)
+	_, err = vault.Logical().Write("secret/data/app/config", map[string]interface{}{"data": map[string]interface{}{"password": "new"}})
+	require.NoError(t, err)
+
+	result := call()
+	require.False(t, result.IsError, result.Content)
+	var report SessionPolicyReport
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &report))
+	assert.Equal(t, 2, report.Requests)
+	require.Len(t, report.Policies, 1)
+	assert.Equal(t, `# app-sync: generated from the Vault requests of an MCP session
+
+path "secret/data/app/config" {
+  capabilities = ["create", "read", "update"]
+}
+`, report.Policies[0].Policy)
+	assert.NotContains(t, result.Content[0].(mcp.TextContent).Text, "password", "secret values are never part of the policy")
+}
+
+func TestGeneratePolicyFromSession_ReadReplica(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	replica := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"data":{"password":"old"}}}`))
+	}))
+	defer replica.Close()
+	t.Setenv(client.VaultReadAddress, replica.URL)
+
+	ctx, requests := newUndoTestContext(t)
+	sessionID := "test-" + t.Name()
+	defer client.DeleteSessionAccess(sessionID)
+	defer client.DeleteSessionUsage(sessionID)
+
+	// Read-only tools are only routed to the replica when the call goes through the server
+	srv := server.NewMCPServer("test", "1.0.0", server.WithToolHandlerMiddleware(client.ReadRoutingMiddleware()))
+	srv.AddTool(mcp.NewTool("read_config", mcp.WithReadOnlyHintAnnotation(true)), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		vault, err := client.GetVaultClientFromContext(ctx, logger)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := vault.Logical().Read("secret/data/app/config"); err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultText("success"), nil
+	})
+	srv.AddTools(GeneratePolicyFromSession(logger))
+	ctx = srv.WithContext(ctx, testSession{id: sessionID})
+
+	call := func(message string) *mcp.CallToolResult {
+		response, ok := srv.HandleMessage(ctx, []byte(message)).(mcp.JSONRPCResponse)
+		require.True(t, ok, "tool call failed")
+		result, ok := response.Result.(*mcp.CallToolResult)
+		require.True(t, ok)
+		require.False(t, result.IsError, result.Content)
+		return result
+	}
+
+	call(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"read_config"}}`)
+	assert.Empty(t, *requests, "the read went to the replica")
+
+	result := call(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"generate_policy_from_session","arguments":{"name":"app-sync"}}}`)
+	var report SessionPolicyReport
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &report))
+	assert.Equal(t, 1, report.Requests, "requests sent to the replica are part of the policy")
+	require.Len(t, report.Policies, 1)
+	assert.Contains(t, report.Policies[0].Policy, `path "secret/data/app/config" {
+  capabilities = ["read"]
+}`)
+}
+
+func TestGeneratePolicyFromSession_WithoutSession(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	result, err := GeneratePolicyFromSession(logger).Handler(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
@@ -0,0 +1,70 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package kv
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"filippo.io/age"
+)
+
+// ageVersionLine starts the header of every age file (https://age-encryption.org/v1)
+const ageVersionLine = "age-encryption.org/v1"
+
+// parseAgeRecipient parses an X25519 recipient such as 'age1...'
+func parseAgeRecipient(recipient string) (*age.X25519Recipient, error) {
+	parsed, err := age.ParseX25519Recipient(strings.TrimSpace(recipient))
+	if err != nil {
+		return nil, fmt.Errorf("invalid age recipient: %v", err)
+	}
+	return parsed, nil
+}
+
+// parseAgeIdentities parses the X25519 identities of an identity file, one 'AGE-SECRET-KEY-1...' per line.
+// Blank lines and lines starting with '#' are ignored.
+func parseAgeIdentities(file []byte) ([]age.Identity, error) {
+	return age.ParseIdentities(bytes.NewReader(file))
+}
+
+// ageEncrypt encrypts the plaintext to an X25519 recipient
+func ageEncrypt(plaintext []byte, recipient age.Recipient) ([]byte, error) {
+	var out bytes.Buffer
+	w, err := age.Encrypt(&out, recipient)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// isAgeFile reports whether data starts like an age file
+func isAgeFile(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(ageVersionLine+"\n"))
+}
+
+// ageDecrypt decrypts an age file with the first identity matching one of its recipients
+func ageDecrypt(data []byte, identities []age.Identity) ([]byte, error) {
+	r, err := age.Decrypt(bytes.NewReader(data), identities...)
+	var noMatch *age.NoIdentityMatchError
+	if errors.As(err, &noMatch) {
+		return nil, errors.New("no identity matches a recipient of the age file")
+	}
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("age payload is truncated or was modified: %v", err)
+	}
+	return plaintext, nil
+}
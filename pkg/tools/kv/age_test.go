@@ -0,0 +1,122 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package kv
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A file encrypted by the age command line tool (v1.2.1) with
+// 'age -r age1hs9wh545m73y7mnq7qy58djh2m6r442x9n4c627elrzqw2udge9qaj3ejt', and the identity file of its recipient
+const (
+	ageVectorIdentity = `# created: 2026-10-15T10:04:04Z
+# public key: age1hs9wh545m73y7mnq7qy58djh2m6r442x9n4c627elrzqw2udge9qaj3ejt
+AGE-SECRET-KEY-1LXYKL25YHHQJWFLRYGD386LG4QLV6RYNV6SKX3GAPESDRQGWPU2Q3CUMXK
+`
+	ageVectorRecipient = "age1hs9wh545m73y7mnq7qy58djh2m6r442x9n4c627elrzqw2udge9qaj3ejt"
+	ageVectorFile      = "YWdlLWVuY3J5cHRpb24ub3JnL3YxCi0+IFgyNTUxOSBNS2o5NlBWb2NGTS9acm9Ya0ZqQXIydGRk" +
+		"S0c1RHB3VlJveHR5L3d1R2pjClhJdXhpRmxzK3YrLzFKQzdVTjZ5OGc1d2pyM1JtbHd0M3Izb0I1" +
+		"OWFTSWMKLS0tIEc5UDhlcFQycVIzdGFXYVJ0Q0JvV2VKZENKTE1NTkZNYXNWVk1mQ3U1UjQKARIH" +
+		"Z4PgnYe2BCu/vKLEVDX9oLU3PS6NNQpUnPXbEuPu1+9P3CqIoJeE4UWF2+GITglSROH0gpr5Gcgi" +
+		"kEJNp57PmIpoOSXql/Q="
+	ageVectorPlaintext = `{"format":"vault-mcp-server/kv-backup/v1"}`
+)
+
+// newAgeIdentity returns an identity file holding a new X25519 identity, and its recipient
+func newAgeIdentity(t *testing.T) ([]byte, string) {
+	t.Helper()
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	return []byte("# created: 2025-06-02T09:30:00Z\n" + identity.String() + "\n"), identity.Recipient().String()
+}
+
+func TestAgeRoundTrip(t *testing.T) {
+	identityFile, recipient := newAgeIdentity(t)
+	assert.True(t, strings.HasPrefix(recipient, "age1"))
+	publicKey, err := parseAgeRecipient(recipient)
+	require.NoError(t, err)
+	identities, err := parseAgeIdentities(identityFile)
+	require.NoError(t, err)
+
+	for name, size := range map[string]int{"empty": 0, "small": 100, "one chunk": 64 * 1024, "several chunks": 2*64*1024 + 17} {
+		t.Run(name, func(t *testing.T) {
+			plaintext := make([]byte, size)
+			_, _ = rand.Read(plaintext)
+
+			sealed, err := ageEncrypt(plaintext, publicKey)
+			require.NoError(t, err)
+			assert.True(t, isAgeFile(sealed))
+
+			opened, err := ageDecrypt(sealed, identities)
+			require.NoError(t, err)
+			assert.True(t, bytes.Equal(plaintext, opened))
+		})
+	}
+}
+
+func TestAgeDecrypt_Vector(t *testing.T) {
+	sealed, err := base64.StdEncoding.DecodeString(ageVectorFile)
+	require.NoError(t, err)
+	assert.True(t, isAgeFile(sealed))
+
+	identities, err := parseAgeIdentities([]byte(ageVectorIdentity))
+	require.NoError(t, err)
+	require.Len(t, identities, 1)
+	assert.Equal(t, ageVectorRecipient, identities[0].(*age.X25519Identity).Recipient().String())
+
+	opened, err := ageDecrypt(sealed, identities)
+	require.NoError(t, err)
+	assert.Equal(t, ageVectorPlaintext, string(opened))
+}
+
+func TestAgeDecrypt_Rejects(t *testing.T) {
+	identityFile, recipient := newAgeIdentity(t)
+	otherFile, _ := newAgeIdentity(t)
+	publicKey, err := parseAgeRecipient(recipient)
+	require.NoError(t, err)
+	identities, err := parseAgeIdentities(identityFile)
+	require.NoError(t, err)
+	others, err := parseAgeIdentities(otherFile)
+	require.NoError(t, err)
+
+	sealed, err := ageEncrypt([]byte("secret archive"), publicKey)
+	require.NoError(t, err)
+
+	_, err = ageDecrypt(sealed, others)
+	assert.ErrorContains(t, err, "no identity matches")
+
+	tampered := bytes.Clone(sealed)
+	tampered[len(tampered)-1] ^= 1
+	_, err = ageDecrypt(tampered, identities)
+	assert.ErrorContains(t, err, "modified")
+
+	truncated := sealed[:len(sealed)-20]
+	_, err = ageDecrypt(truncated, identities)
+	assert.Error(t, err)
+}
+
+func TestParseAgeKeys(t *testing.T) {
+	_, err := parseAgeRecipient("age1invalid")
+	assert.Error(t, err)
+
+	identityFile, recipient := newAgeIdentity(t)
+	_, err = parseAgeRecipient(strings.ToUpper(recipient[:10]) + recipient[10:])
+	assert.Error(t, err, "mixed case is not valid bech32")
+
+	_, err = parseAgeIdentities([]byte(recipient))
+	assert.ErrorContains(t, err, "line 1")
+	_, err = parseAgeIdentities([]byte("# no keys\n"))
+	assert.Error(t, err)
+	identities, err := parseAgeIdentities(identityFile)
+	require.NoError(t, err)
+	assert.Len(t, identities, 1)
+}
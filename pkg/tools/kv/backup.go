@@ -0,0 +1,222 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package kv
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+	log "github.com/sirupsen/logrus"
+)
+
+// BackupDirEnv is the directory backup_kv_subtree writes its archives to and restore_kv_subtree reads them from.
+// The backup tools are only registered when it is set.
+const BackupDirEnv = "MCP_KV_BACKUP_DIR"
+
+// BackupAgeIdentityFileEnv is the age identity file restore_kv_subtree decrypts age archives with
+const BackupAgeIdentityFileEnv = "MCP_KV_BACKUP_AGE_IDENTITY_FILE"
+
+// MaxBackupSecrets is the default number of secrets written to an archive
+const MaxBackupSecrets = 10000
+
+// Encryptions of backup archives
+const (
+	BackupEncryptionTransit = "transit"
+	BackupEncryptionAge     = "age"
+)
+
+// Formats of the archive and of the envelope of transit encrypted archives
+const (
+	backupArchiveFormat  = "vault-mcp-server/kv-backup/v1"
+	backupTransitFormat  = "vault-mcp-server/kv-backup+transit/v1"
+	backupTransitKeyBits = 256
+)
+
+// BackupConfig configures where KV backups are kept and how age archives are decrypted
+type BackupConfig struct {
+	Dir             string
+	AgeIdentityFile string
+}
+
+// LoadBackupConfigFromEnv loads the backup directory from MCP_KV_BACKUP_DIR and the age identity file from
+// MCP_KV_BACKUP_AGE_IDENTITY_FILE. A backup directory that does not exist disables the backup tools with a warning.
+func LoadBackupConfigFromEnv() BackupConfig {
+	config := BackupConfig{
+		Dir:             strings.TrimSpace(os.Getenv(BackupDirEnv)),
+		AgeIdentityFile: strings.TrimSpace(os.Getenv(BackupAgeIdentityFileEnv)),
+	}
+	if config.Dir == "" {
+		return BackupConfig{}
+	}
+	if info, err := os.Stat(config.Dir); err != nil || !info.IsDir() {
+		log.Warnf("Invalid %s value '%s', expected an existing directory, the KV backup tools are disabled", BackupDirEnv, config.Dir)
+		return BackupConfig{}
+	}
+	log.Infof("KV backup tools are enabled with the backup directory '%s'", config.Dir)
+	return config
+}
+
+// Enabled reports whether a backup directory is configured
+func (c BackupConfig) Enabled() bool {
+	return c.Dir != ""
+}
+
+// archivePath returns the path of an archive in the backup directory. Archives are named relative to the backup
+// directory and cannot leave it.
+func (c BackupConfig) archivePath(file string) (string, error) {
+	if file == "" || !filepath.IsLocal(file) {
+		return "", fmt.Errorf("invalid 'file' parameter '%s', expected a path relative to the backup directory", file)
+	}
+	return filepath.Join(c.Dir, file), nil
+}
+
+// backupArchive is the plaintext of an archive: the latest version of every secret under a path
+type backupArchive struct {
+	Format    string         `json:"format"`
+	Mount     string         `json:"mount"`
+	Path      string         `json:"path"`
+	CreatedAt time.Time      `json:"created_at"`
+	Secrets   []backupSecret `json:"secrets"`
+}
+
+type backupSecret struct {
+	Path string                 `json:"path"` // Path of the secret relative to the path of the archive
+	Data map[string]interface{} `json:"data"`
+}
+
+// transitEnvelope holds an archive encrypted with AES-256-GCM under a data key that transit wrapped
+type transitEnvelope struct {
+	Format       string `json:"format"`
+	TransitMount string `json:"transit_mount"`
+	TransitKey   string `json:"transit_key"`
+	WrappedKey   string `json:"wrapped_key"`
+	Nonce        []byte `json:"nonce"`
+	Ciphertext   []byte `json:"ciphertext"`
+}
+
+// sealWithTransit encrypts an archive under a new data key of a transit key, only the data key is sent to Vault
+func sealWithTransit(vault *api.Client, transitMount, transitKey string, plaintext []byte) ([]byte, error) {
+	secret, err := vault.Logical().Write(fmt.Sprintf("%s/datakey/plaintext/%s", transitMount, transitKey), map[string]interface{}{
+		"bits": backupTransitKeyBits,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate a data key with transit key '%s/%s': %v", transitMount, transitKey, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("transit key '%s/%s' returned no data key", transitMount, transitKey)
+	}
+	encodedKey, _ := secret.Data["plaintext"].(string)
+	wrappedKey, _ := secret.Data["ciphertext"].(string)
+	dataKey, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil || wrappedKey == "" {
+		return nil, fmt.Errorf("transit key '%s/%s' returned an invalid data key", transitMount, transitKey)
+	}
+
+	aead, err := newBackupAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	envelope := transitEnvelope{
+		Format:       backupTransitFormat,
+		TransitMount: transitMount,
+		TransitKey:   transitKey,
+		WrappedKey:   wrappedKey,
+		Nonce:        make([]byte, aead.NonceSize()),
+	}
+	if _, err := rand.Read(envelope.Nonce); err != nil {
+		return nil, err
+	}
+	envelope.Ciphertext = aead.Seal(nil, envelope.Nonce, plaintext, []byte(backupTransitFormat))
+	return json.Marshal(envelope)
+}
+
+// openWithTransit decrypts an archive by unwrapping its data key with the transit key it names
+func openWithTransit(vault *api.Client, data []byte) ([]byte, error) {
+	var envelope transitEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil || envelope.Format != backupTransitFormat {
+		return nil, fmt.Errorf("the file is neither a transit encrypted archive nor an age file")
+	}
+
+	secret, err := vault.Logical().Write(fmt.Sprintf("%s/decrypt/%s", envelope.TransitMount, envelope.TransitKey), map[string]interface{}{
+		"ciphertext": envelope.WrappedKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the data key with transit key '%s/%s': %v", envelope.TransitMount, envelope.TransitKey, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("transit key '%s/%s' returned no data key", envelope.TransitMount, envelope.TransitKey)
+	}
+	encodedKey, _ := secret.Data["plaintext"].(string)
+	dataKey, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("transit key '%s/%s' returned an invalid data key", envelope.TransitMount, envelope.TransitKey)
+	}
+
+	aead, err := newBackupAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(envelope.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("the archive has an invalid nonce")
+	}
+	plaintext, err := aead.Open(nil, envelope.Nonce, envelope.Ciphertext, []byte(backupTransitFormat))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the archive, it was modified or encrypted with another key")
+	}
+	return plaintext, nil
+}
+
+func newBackupAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data key: %v", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// kvVersion2 reports whether a mount is a KV version 2 mount, or fails when it is not a KV mount
+func kvVersion2(vault *api.Client, mount string) (bool, error) {
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		return false, fmt.Errorf("failed to list mounts: %v", err)
+	}
+	m, ok := mounts[mount+"/"]
+	if !ok {
+		return false, fmt.Errorf("mount path '%s' does not exist", mount)
+	}
+	if m.Type != "kv" && m.Type != "generic" {
+		return false, fmt.Errorf("mount path '%s' is a %s mount, not a KV mount", mount, m.Type)
+	}
+	return m.Options["version"] == "2", nil
+}
+
+// secretData returns the data of a secret, or nil when it does not exist or its KV v2 version is deleted
+func secretData(secret *api.Secret, v2 bool) map[string]interface{} {
+	if secret == nil || secret.Data == nil {
+		return nil
+	}
+	if !v2 {
+		return secret.Data
+	}
+	data, _ := secret.Data["data"].(map[string]interface{})
+	return data
+}
+
+// joinSecretPath joins a folder and a path relative to it
+func joinSecretPath(folder, path string) string {
+	folder = strings.Trim(folder, "/")
+	if folder == "" {
+		return path
+	}
+	return folder + "/" + path
+}
@@ -0,0 +1,233 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+type BackupReport struct {
+	File       string   `json:"file"`              // Path of the archive relative to the backup directory
+	Mount      string   `json:"mount"`             // Mount the secrets were read from
+	Path       string   `json:"path"`              // Path of the backed up subtree, empty for the whole mount
+	Encryption string   `json:"encryption"`        // transit or age
+	Secrets    int      `json:"secrets"`           // Number of secrets in the archive
+	Skipped    []string `json:"skipped,omitempty"` // Secrets left out because their latest KV v2 version is deleted
+	Truncated  bool     `json:"truncated"`         // Whether the backup stopped at the limit, so the archive misses secrets
+	Bytes      int      `json:"bytes"`             // Size of the archive
+}
+
+// BackupKVSubtree creates a tool for backing up the secrets under a KV path into an encrypted archive
+func BackupKVSubtree(config BackupConfig, logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("backup_kv_subtree",
+			mcp.WithDescription("Back up the latest version of every secret under a path of a KV mount into an encrypted archive in the backup directory of the server, for targeted recovery with 'restore_kv_subtree' without restoring a full storage snapshot. The archive is encrypted under a data key of a transit key, or to an age recipient so that it can also be decrypted with the age command line tool. Secret values never leave the server in the result."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					DestructiveHint: utils.ToBoolPtr(false),
+					IdempotentHint:  utils.ToBoolPtr(false),
+				},
+			),
+			mcp.WithString("mount",
+				mcp.Required(),
+				mcp.Description("The mount path of the KV secrets engine, without the trailing slash."),
+			),
+			mcp.WithString("path",
+				mcp.DefaultString(""),
+				mcp.Description("The folder to back up without the mount prefix, such as 'team/app'. Defaults to the whole mount."),
+			),
+			mcp.WithString("file",
+				mcp.Required(),
+				mcp.Description("Name of the archive, relative to the backup directory, such as 'team-app-2025-06-02.bak'. Existing archives are never overwritten."),
+			),
+			mcp.WithString("encryption",
+				mcp.DefaultString(BackupEncryptionTransit),
+				mcp.Enum(BackupEncryptionTransit, BackupEncryptionAge),
+				mcp.Description("'transit' encrypts the archive under a data key of 'transit_key', 'age' encrypts it to 'age_recipient'. Defaults to 'transit'."),
+			),
+			mcp.WithString("transit_mount",
+				mcp.DefaultString("transit"),
+				mcp.Description("The mount path of the transit secrets engine. Defaults to 'transit'."),
+			),
+			mcp.WithString("transit_key",
+				mcp.Description("The name of the transit key, required for 'transit' encryption. Restoring needs 'decrypt' on the key."),
+			),
+			mcp.WithString("age_recipient",
+				mcp.Description("The X25519 age recipient, such as 'age1...', required for 'age' encryption. Restoring needs its identity in the identity file of the server."),
+			),
+			mcp.WithNumber("limit",
+				mcp.DefaultNumber(MaxBackupSecrets),
+				mcp.Description("The maximum number of secrets written to the archive. Defaults to 10000."),
+			),
+			schemas.Output("backup_kv_subtree"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return backupKVSubtreeHandler(ctx, req, config, logger)
+		},
+	}
+}
+
+func backupKVSubtreeHandler(ctx context.Context, req mcp.CallToolRequest, config BackupConfig, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling backup_kv_subtree request")
+
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	path := strings.Trim(req.GetString("path", ""), "/")
+
+	file, err := config.archivePath(req.GetString("file", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	encryption := req.GetString("encryption", BackupEncryptionTransit)
+	transitMount := strings.Trim(req.GetString("transit_mount", "transit"), "/")
+	transitKey := req.GetString("transit_key", "")
+	recipient := req.GetString("age_recipient", "")
+	var ageRecipient *age.X25519Recipient
+	switch encryption {
+	case BackupEncryptionTransit:
+		if transitMount == "" || transitKey == "" {
+			return mcp.NewToolResultError("'transit_mount' and 'transit_key' are required for 'transit' encryption"), nil
+		}
+	case BackupEncryptionAge:
+		if recipient == "" {
+			return mcp.NewToolResultError("'age_recipient' is required for 'age' encryption"), nil
+		}
+		if ageRecipient, err = parseAgeRecipient(recipient); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'encryption' parameter '%s', expected 'transit' or 'age'", encryption)), nil
+	}
+
+	limit := req.GetInt("limit", MaxBackupSecrets)
+	if limit < 1 {
+		return mcp.NewToolResultError("Invalid 'limit' parameter, must be at least 1"), nil
+	}
+
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	v2, err := kvVersion2(vault, mount)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	report := &BackupReport{
+		File:       req.GetString("file", ""),
+		Mount:      mount,
+		Path:       path,
+		Encryption: encryption,
+	}
+	archive := backupArchive{
+		Format:    backupArchiveFormat,
+		Mount:     mount,
+		Path:      path,
+		CreatedAt: time.Now().UTC(),
+		Secrets:   []backupSecret{},
+	}
+
+	report.Truncated, err = utils.WalkSecretPaths(vault, secretPath(mount, path, v2, sectionMetadata), func(relative string) (bool, error) {
+		if len(archive.Secrets) >= limit {
+			return false, nil
+		}
+		secret, err := vault.Logical().ReadWithContext(ctx, secretPath(mount, joinSecretPath(path, relative), v2, sectionData))
+		if err != nil {
+			return false, fmt.Errorf("failed to read secret '%s': %v", relative, err)
+		}
+		data := secretData(secret, v2)
+		if data == nil {
+			report.Skipped = append(report.Skipped, relative)
+			return true, nil
+		}
+		archive.Secrets = append(archive.Secrets, backupSecret{Path: relative, Data: data})
+		return true, nil
+	})
+	if err != nil {
+		logger.WithError(err).WithField("mount", mount).Error("Failed to read the secrets to back up")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to back up '%s': %v", mount+"/"+path, err)), nil
+	}
+	report.Secrets = len(archive.Secrets)
+
+	plaintext, err := json.Marshal(archive)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling archive: %v", err)), nil
+	}
+	var sealed []byte
+	if encryption == BackupEncryptionAge {
+		sealed, err = ageEncrypt(plaintext, ageRecipient)
+	} else {
+		sealed, err = sealWithTransit(vault, transitMount, transitKey, plaintext)
+	}
+	if err != nil {
+		logger.WithError(err).Error("Failed to encrypt the archive")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encrypt the archive: %v", err)), nil
+	}
+	report.Bytes = len(sealed)
+
+	if err := writeArchive(file, sealed); err != nil {
+		logger.WithError(err).WithField("file", file).Error("Failed to write the archive")
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	jsonData, err := json.Marshal(report)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal backup report to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":      mount,
+		"path":       path,
+		"file":       file,
+		"secrets":    report.Secrets,
+		"encryption": encryption,
+	}).Info("Backed up KV subtree")
+	return mcp.NewToolResultStructured(report, string(jsonData)), nil
+}
+
+// writeArchive writes an archive readable by the server user only, and never replaces an existing file
+func writeArchive(file string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0o700); err != nil {
+		return fmt.Errorf("failed to create the folder of the archive: %v", err)
+	}
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("archive '%s' already exists, choose another file name", filepath.Base(file))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create the archive: %v", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		_ = os.Remove(file)
+		return fmt.Errorf("failed to write the archive: %v", err)
+	}
+	return f.Close()
+}
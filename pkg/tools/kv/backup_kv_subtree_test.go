@@ -0,0 +1,231 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package kv
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// backupVault is a mock Vault with a KV v2 mount 'secret' and a transit mount whose data keys are 'wrapped' by
+// prefixing them
+type backupVault struct {
+	mu      sync.Mutex
+	secrets map[string]map[string]interface{}
+	writes  []string
+}
+
+func newBackupVault() *backupVault {
+	return &backupVault{secrets: map[string]map[string]interface{}{
+		"team/app/config":      {"mode": "prod"},
+		"team/app/db/password": {"password": "s3cr3t"},
+		"team/app/deleted":     nil,
+		"team/other":           {"x": "y"},
+	}}
+}
+
+func (v *backupVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+	switch {
+	case path == "sys/mounts":
+		jsonResponse(w, mountsV2Response("secret"))
+	case path == "transit/datakey/plaintext/backups":
+		key := bytes.Repeat([]byte{7}, 32)
+		jsonResponse(w, map[string]interface{}{"data": map[string]interface{}{
+			"plaintext":  base64.StdEncoding.EncodeToString(key),
+			"ciphertext": "vault:v1:" + base64.StdEncoding.EncodeToString(key),
+		}})
+	case path == "transit/decrypt/backups":
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		jsonResponse(w, map[string]interface{}{"data": map[string]interface{}{
+			"plaintext": strings.TrimPrefix(body["ciphertext"], "vault:v1:"),
+		}})
+	case strings.HasPrefix(path, "secret/metadata/"):
+		prefix := strings.Trim(strings.TrimPrefix(path, "secret/metadata/"), "/") + "/"
+		if prefix == "/" {
+			prefix = ""
+		}
+		folders := map[string]bool{}
+		var keys []string
+		for name := range v.secrets {
+			rest, ok := strings.CutPrefix(name, prefix)
+			if !ok {
+				continue
+			}
+			if folder, _, nested := strings.Cut(rest, "/"); nested {
+				if !folders[folder] {
+					folders[folder] = true
+					keys = append(keys, folder+"/")
+				}
+				continue
+			}
+			keys = append(keys, rest)
+		}
+		if len(keys) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		jsonResponse(w, map[string]interface{}{"data": map[string]interface{}{"keys": keys}})
+	case strings.HasPrefix(path, "secret/data/"):
+		name := strings.TrimPrefix(path, "secret/data/")
+		if r.Method == http.MethodGet {
+			data, ok := v.secrets[name]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			var value interface{}
+			if data != nil {
+				value = data
+			}
+			jsonResponse(w, map[string]interface{}{"data": map[string]interface{}{"data": value, "metadata": map[string]interface{}{"version": 1}}})
+			return
+		}
+		var body map[string]map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		v.secrets[name] = body["data"]
+		v.writes = append(v.writes, name)
+		jsonResponse(w, map[string]interface{}{"data": map[string]interface{}{"version": 2}})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestBackupAndRestoreKVSubtree_Transit(t *testing.T) {
+	vault := newBackupVault()
+	ctx, cleanup := newTestContext(t, vault)
+	defer cleanup()
+	config := BackupConfig{Dir: t.TempDir()}
+
+	backup := func(args map[string]interface{}) *mcp.CallToolResult {
+		result, err := backupKVSubtreeHandler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "backup_kv_subtree", Arguments: args}}, config, newLogger())
+		require.NoError(t, err)
+		return result
+	}
+	restore := func(args map[string]interface{}) *mcp.CallToolResult {
+		result, err := restoreKVSubtreeHandler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "restore_kv_subtree", Arguments: args}}, config, newLogger())
+		require.NoError(t, err)
+		return result
+	}
+
+	result := backup(map[string]interface{}{"mount": "secret", "path": "team/app", "file": "app.bak", "transit_key": "backups"})
+	require.False(t, result.IsError, getResultText(result))
+	var report BackupReport
+	require.NoError(t, json.Unmarshal([]byte(getResultText(result)), &report))
+	assert.Equal(t, 2, report.Secrets)
+	assert.Equal(t, []string{"deleted"}, report.Skipped)
+	assert.False(t, report.Truncated)
+
+	archive, err := os.ReadFile(filepath.Join(config.Dir, "app.bak"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(archive), "s3cr3t")
+	info, err := os.Stat(filepath.Join(config.Dir, "app.bak"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	result = backup(map[string]interface{}{"mount": "secret", "path": "team/app", "file": "app.bak", "transit_key": "backups"})
+	assert.True(t, result.IsError, "existing archives are not overwritten")
+	result = backup(map[string]interface{}{"mount": "secret", "file": "../app.bak", "transit_key": "backups"})
+	assert.True(t, result.IsError, "archives cannot leave the backup directory")
+
+	result = restore(map[string]interface{}{"file": "app.bak"})
+	require.False(t, result.IsError, getResultText(result))
+	var restored RestoreReport
+	require.NoError(t, json.Unmarshal([]byte(getResultText(result)), &restored))
+	assert.Equal(t, "team/app", restored.Path)
+	assert.Empty(t, restored.Restored)
+	assert.ElementsMatch(t, []string{"config", "db/password"}, restored.Skipped)
+	assert.Empty(t, vault.writes)
+
+	result = restore(map[string]interface{}{"file": "app.bak", "path": "restored", "dry_run": true})
+	require.False(t, result.IsError, getResultText(result))
+	assert.Empty(t, vault.writes)
+
+	result = restore(map[string]interface{}{"file": "app.bak", "path": "restored"})
+	require.False(t, result.IsError, getResultText(result))
+	require.NoError(t, json.Unmarshal([]byte(getResultText(result)), &restored))
+	assert.ElementsMatch(t, []string{"config", "db/password"}, restored.Restored)
+	assert.Equal(t, map[string]interface{}{"password": "s3cr3t"}, vault.secrets["restored/db/password"])
+
+	changes := client.SessionChanges("test-" + t.Name())
+	require.Len(t, changes, 2)
+	assert.Equal(t, client.OperationCreate, changes[0].Operation)
+	assert.Equal(t, "restore_kv_subtree", changes[0].Tool)
+	assert.Equal(t, json.Number("2"), changes[0].New["version"])
+}
+
+func TestBackupAndRestoreKVSubtree_Age(t *testing.T) {
+	vault := newBackupVault()
+	ctx, cleanup := newTestContext(t, vault)
+	defer cleanup()
+
+	identityFile, recipient := newAgeIdentity(t)
+	config := BackupConfig{Dir: t.TempDir(), AgeIdentityFile: filepath.Join(t.TempDir(), "identity.txt")}
+	require.NoError(t, os.WriteFile(config.AgeIdentityFile, identityFile, 0o600))
+
+	result, err := backupKVSubtreeHandler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{
+		"mount": "secret", "path": "team", "file": "nightly/team.age", "encryption": "age", "age_recipient": recipient,
+	}}}, config, newLogger())
+	require.NoError(t, err)
+	require.False(t, result.IsError, getResultText(result))
+
+	archive, err := os.ReadFile(filepath.Join(config.Dir, "nightly", "team.age"))
+	require.NoError(t, err)
+	assert.True(t, isAgeFile(archive))
+
+	result, err = restoreKVSubtreeHandler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{
+		"file": "nightly/team.age", "overwrite": true,
+	}}}, BackupConfig{Dir: config.Dir}, newLogger())
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, getResultText(result), BackupAgeIdentityFileEnv)
+
+	result, err = restoreKVSubtreeHandler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{
+		"file": "nightly/team.age", "overwrite": true,
+	}}}, config, newLogger())
+	require.NoError(t, err)
+	require.False(t, result.IsError, getResultText(result))
+	var restored RestoreReport
+	require.NoError(t, json.Unmarshal([]byte(getResultText(result)), &restored))
+	assert.Equal(t, BackupEncryptionAge, restored.Encryption)
+	assert.ElementsMatch(t, []string{"app/config", "app/db/password", "other"}, restored.Restored)
+	assert.ElementsMatch(t, []string{"team/app/config", "team/app/db/password", "team/other"}, vault.writes)
+}
+
+func TestLoadBackupConfigFromEnv(t *testing.T) {
+	t.Setenv(BackupDirEnv, "")
+	assert.False(t, LoadBackupConfigFromEnv().Enabled())
+
+	t.Setenv(BackupDirEnv, filepath.Join(t.TempDir(), "missing"))
+	assert.False(t, LoadBackupConfigFromEnv().Enabled())
+
+	dir := t.TempDir()
+	t.Setenv(BackupDirEnv, dir)
+	config := LoadBackupConfigFromEnv()
+	assert.True(t, config.Enabled())
+
+	path, err := config.archivePath("team/app.bak")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "team", "app.bak"), path)
+	for _, file := range []string{"", "/etc/passwd", "../escape.bak", "team/../../escape.bak"} {
+		_, err := config.archivePath(file)
+		assert.Error(t, err, file)
+	}
+}
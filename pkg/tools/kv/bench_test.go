@@ -0,0 +1,105 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package kv
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// newBenchVault serves a KV v2 mount 'secret' holding a secret with secretKeys keys at 'app/config' and
+// listKeys secrets under 'app/'. The responses are encoded once so that the handlers dominate the profile.
+func newBenchVault(secretKeys int, listKeys int) http.Handler {
+	mustMarshal := func(v interface{}) []byte {
+		data, err := json.Marshal(v)
+		if err != nil {
+			panic(err)
+		}
+		return data
+	}
+
+	data := make(map[string]interface{}, secretKeys)
+	for i := 0; i < secretKeys; i++ {
+		data[fmt.Sprintf("key-%d", i)] = strings.Repeat("v", 32)
+	}
+	keys := make([]string, 0, listKeys)
+	for i := 0; i < listKeys; i++ {
+		keys = append(keys, fmt.Sprintf("service-%d", i))
+	}
+
+	mounts := mustMarshal(mountsV2Response("secret"))
+	secret := mustMarshal(map[string]interface{}{"data": map[string]interface{}{"data": data, "metadata": map[string]interface{}{"version": 1}}})
+	listing := mustMarshal(map[string]interface{}{"data": map[string]interface{}{"keys": keys}})
+	written := mustMarshal(map[string]interface{}{"data": map[string]interface{}{"version": 2}})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/v1/sys/mounts":
+			_, _ = w.Write(mounts)
+		case strings.HasPrefix(r.URL.Path, "/v1/secret/metadata"):
+			_, _ = w.Write(listing)
+		case r.Method == http.MethodGet:
+			_, _ = w.Write(secret)
+		default:
+			_, _ = w.Write(written)
+		}
+	})
+}
+
+func benchmarkHandler(b *testing.B, handler kvHandler, vault http.Handler, args map[string]interface{}) {
+	ctx, cleanup := newTestContext(b, vault)
+	defer cleanup()
+
+	logger := newLogger()
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result, err := handler(ctx, req, logger)
+		if err != nil || result.IsError {
+			b.Fatalf("unexpected result: %v %s", err, getResultText(result))
+		}
+	}
+}
+
+func BenchmarkReadSecretHandler(b *testing.B) {
+	for _, keys := range []int{10, 1000} {
+		b.Run(fmt.Sprintf("keys=%d", keys), func(b *testing.B) {
+			benchmarkHandler(b, readSecretHandler, newBenchVault(keys, 0), map[string]interface{}{
+				"mount": "secret",
+				"path":  "app/config",
+			})
+		})
+	}
+}
+
+func BenchmarkWriteSecretHandler(b *testing.B) {
+	for _, keys := range []int{10, 1000} {
+		b.Run(fmt.Sprintf("keys=%d", keys), func(b *testing.B) {
+			benchmarkHandler(b, writeSecretHandler, newBenchVault(keys, 0), map[string]interface{}{
+				"mount": "secret",
+				"path":  "app/config",
+				"key":   "username",
+				"value": "app",
+			})
+		})
+	}
+}
+
+func BenchmarkListSecretsHandler(b *testing.B) {
+	for _, keys := range []int{100, 10000} {
+		b.Run(fmt.Sprintf("keys=%d", keys), func(b *testing.B) {
+			benchmarkHandler(b, listSecretsHandler, newBenchVault(0, keys), map[string]interface{}{
+				"mount": "secret",
+			})
+		})
+	}
+}
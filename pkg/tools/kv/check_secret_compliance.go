@@ -0,0 +1,342 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// SecretCompliance is the result of evaluating the values of a secret against password rules, without the values
+type SecretCompliance struct {
+	Mount          string          `json:"mount"`
+	Path           string          `json:"path"`
+	Version        int             `json:"version,omitempty"`         // Version of a KV v2 secret that was evaluated
+	PasswordPolicy string          `json:"password_policy,omitempty"` // Vault password policy the rules were taken from
+	Rules          []string        `json:"rules"`                     // Rules each value was evaluated against
+	Compliant      bool            `json:"compliant"`                 // Whether every evaluated key complies with every rule
+	Keys           []KeyCompliance `json:"keys"`
+}
+
+// KeyCompliance is the result of evaluating the value of one key of a secret
+type KeyCompliance struct {
+	Key        string   `json:"key"`
+	Compliant  bool     `json:"compliant"`
+	Violations []string `json:"violations,omitempty"` // Rules the value breaks
+}
+
+// complianceRule is a rule a value has to satisfy. Its description is reported as the violation, so it
+// must not depend on the value.
+type complianceRule struct {
+	description string
+	satisfied   func(value string) bool
+}
+
+// CheckSecretCompliance creates a tool for checking the values of a secret against password rules
+func CheckSecretCompliance(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("check_secret_compliance",
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithDescription("Check the values of a KV secret against a Vault password policy or against length and regex rules, and report which keys do not comply and which rules they break. The values are evaluated inside the server and never returned, so it suits security reviews of legacy secrets. Pass a password policy, rules, or both."),
+			mcp.WithString("mount",
+				mcp.Required(),
+				mcp.Description("The mount path of the KV secret engine, without the trailing slash."),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("The path of the secret without the mount prefix. For example, 'application/credentials'."),
+			),
+			mcp.WithArray("keys",
+				mcp.WithStringItems(),
+				mcp.Description("The keys of the secret to evaluate, for example ['password']. Defaults to every key of the secret holding a string."),
+			),
+			mcp.WithString("password_policy",
+				mcp.DefaultString(""),
+				mcp.Description("The name of a Vault password policy. Values must be at least as long as the policy length, only use characters of its character sets and hold the minimum number of characters of each set."),
+			),
+			mcp.WithNumber("min_length",
+				mcp.DefaultNumber(0),
+				mcp.Min(0),
+				mcp.Description("The minimum number of characters of a value, 0 for no minimum."),
+			),
+			mcp.WithNumber("max_length",
+				mcp.DefaultNumber(0),
+				mcp.Min(0),
+				mcp.Description("The maximum number of characters of a value, 0 for no maximum."),
+			),
+			mcp.WithString("pattern",
+				mcp.DefaultString(""),
+				mcp.Description("A regular expression the whole value must match, for example '[A-Za-z0-9!#%&*+-]+' to restrict the character set."),
+			),
+			mcp.WithArray("required_patterns",
+				mcp.WithStringItems(),
+				mcp.Description("Regular expressions that must each match somewhere in the value, for example ['[a-z]', '[A-Z]', '[0-9]'] to require lower case letters, upper case letters and digits."),
+			),
+			schemas.Output("check_secret_compliance"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return checkSecretComplianceHandler(ctx, req, logger)
+		},
+	}
+}
+
+func checkSecretComplianceHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling check_secret_compliance request")
+
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	path, ok := args["path"].(string)
+	if !ok || path == "" {
+		return mcp.NewToolResultError("Missing or invalid 'path' parameter"), nil
+	}
+
+	rules, err := complianceRules(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	passwordPolicy := req.GetString("password_policy", "")
+	if passwordPolicy == "" && len(rules) == 0 {
+		return mcp.NewToolResultError("Pass a 'password_policy' or at least one of 'min_length', 'max_length', 'pattern' and 'required_patterns'"), nil
+	}
+
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	if passwordPolicy != "" {
+		policyRules, err := passwordPolicyRules(vault, passwordPolicy)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		rules = append(policyRules, rules...)
+	}
+
+	v2, err := kvVersion2(vault, mount)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	fullPath := secretPath(mount, path, v2, sectionData)
+	secret, err := vault.Logical().ReadWithContext(ctx, fullPath)
+	if err != nil {
+		logger.WithError(err).WithFields(log.Fields{
+			"mount":     mount,
+			"path":      path,
+			"full_path": fullPath,
+		}).Error("Failed to read secret")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read secret: %v", err)), nil
+	}
+	data := secretData(secret, v2)
+	if data == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Secret not found at path '%s' in mount '%s', or its latest version is deleted.", path, mount)), nil
+	}
+
+	keys := req.GetStringSlice("keys", nil)
+	if len(keys) == 0 {
+		for key, value := range data {
+			if _, ok := value.(string); ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+	}
+
+	report := SecretCompliance{
+		Mount:          mount,
+		Path:           path,
+		PasswordPolicy: passwordPolicy,
+		Compliant:      true,
+		Keys:           make([]KeyCompliance, 0, len(keys)),
+	}
+	for _, rule := range rules {
+		report.Rules = append(report.Rules, rule.description)
+	}
+	if v2 {
+		if version, ok := secretSummary(secret, true)["version"].(json.Number); ok {
+			value, _ := version.Int64()
+			report.Version = int(value)
+		}
+	}
+
+	// The values are only evaluated here, neither they nor anything derived from them is logged or returned
+	for _, key := range keys {
+		result := KeyCompliance{Key: key}
+		value, exists := data[key]
+		switch s, isString := value.(string); {
+		case !exists:
+			result.Violations = []string{"the key does not exist"}
+		case !isString:
+			result.Violations = []string{"the value is not a string"}
+		default:
+			for _, rule := range rules {
+				if !rule.satisfied(s) {
+					result.Violations = append(result.Violations, rule.description)
+				}
+			}
+		}
+		result.Compliant = len(result.Violations) == 0
+		report.Compliant = report.Compliant && result.Compliant
+		report.Keys = append(report.Keys, result)
+	}
+
+	jsonData, err := utils.MarshalJSON(report)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal compliance report to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":     mount,
+		"path":      path,
+		"keys":      len(report.Keys),
+		"compliant": report.Compliant,
+	}).Debug("Checked secret compliance")
+
+	return mcp.NewToolResultStructured(report, jsonData), nil
+}
+
+// complianceRules returns the length and regex rules of the request
+func complianceRules(req mcp.CallToolRequest) ([]complianceRule, error) {
+	var rules []complianceRule
+
+	minLength := req.GetInt("min_length", 0)
+	maxLength := req.GetInt("max_length", 0)
+	if minLength < 0 || maxLength < 0 {
+		return nil, fmt.Errorf("'min_length' and 'max_length' cannot be negative")
+	}
+	if maxLength > 0 && minLength > maxLength {
+		return nil, fmt.Errorf("'min_length' cannot be greater than 'max_length'")
+	}
+	if minLength > 0 {
+		rules = append(rules, minLengthRule(minLength))
+	}
+	if maxLength > 0 {
+		rules = append(rules, complianceRule{
+			description: fmt.Sprintf("at most %d characters", maxLength),
+			satisfied:   func(value string) bool { return utf8.RuneCountInString(value) <= maxLength },
+		})
+	}
+
+	if pattern := req.GetString("pattern", ""); pattern != "" {
+		re, err := regexp.Compile(`^(?:` + pattern + `)$`)
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'pattern' parameter: %v", err)
+		}
+		rules = append(rules, complianceRule{
+			description: fmt.Sprintf("matches the pattern '%s'", pattern),
+			satisfied:   re.MatchString,
+		})
+	}
+
+	for _, pattern := range req.GetStringSlice("required_patterns", nil) {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid required pattern '%s': %v", pattern, err)
+		}
+		rules = append(rules, complianceRule{
+			description: fmt.Sprintf("contains a match of '%s'", pattern),
+			satisfied:   re.MatchString,
+		})
+	}
+	return rules, nil
+}
+
+// passwordPolicyRules returns the rules of a Vault password policy. Vault has no endpoint checking a value
+// against a policy, so the rules are derived from the length and character sets of the policy.
+func passwordPolicyRules(vault *api.Client, name string) ([]complianceRule, error) {
+	secret, err := vault.Logical().Read("sys/policies/password/" + name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read password policy '%s': %v", name, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("password policy '%s' does not exist", name)
+	}
+	policy, _ := secret.Data["policy"].(string)
+
+	var parsed struct {
+		Length int `hcl:"length"`
+		Rule   []map[string][]struct {
+			Charset  string `hcl:"charset"`
+			MinChars int    `hcl:"min-chars"`
+		} `hcl:"rule"`
+	}
+	if err := hcl.Decode(&parsed, policy); err != nil {
+		return nil, fmt.Errorf("failed to parse password policy '%s': %v", name, err)
+	}
+
+	var rules []complianceRule
+	if parsed.Length > 0 {
+		rules = append(rules, minLengthRule(parsed.Length))
+	}
+
+	var alphabet strings.Builder
+	for _, rule := range parsed.Rule {
+		for _, charset := range rule["charset"] {
+			alphabet.WriteString(charset.Charset)
+			if charset.MinChars <= 0 {
+				continue
+			}
+			rules = append(rules, complianceRule{
+				description: fmt.Sprintf("at least %d characters of '%s'", charset.MinChars, charset.Charset),
+				satisfied: func(value string) bool {
+					count := 0
+					for _, r := range value {
+						if strings.ContainsRune(charset.Charset, r) {
+							count++
+						}
+					}
+					return count >= charset.MinChars
+				},
+			})
+		}
+	}
+	if alphabet.Len() > 0 {
+		characters := alphabet.String()
+		rules = append(rules, complianceRule{
+			description: "only characters of the character sets of the password policy",
+			satisfied: func(value string) bool {
+				return strings.IndexFunc(value, func(r rune) bool { return !strings.ContainsRune(characters, r) }) < 0
+			},
+		})
+	}
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("password policy '%s' has no length or character set rules", name)
+	}
+	return rules, nil
+}
+
+func minLengthRule(length int) complianceRule {
+	return complianceRule{
+		description: fmt.Sprintf("at least %d characters", length),
+		satisfied:   func(value string) bool { return utf8.RuneCountInString(value) >= length },
+	}
+}
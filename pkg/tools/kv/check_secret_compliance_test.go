@@ -0,0 +1,142 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package kv
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPasswordPolicy = `
+length = 20
+rule "charset" {
+  charset = "abcdefghijklmnopqrstuvwxyz"
+  min-chars = 1
+}
+rule "charset" {
+  charset = "0123456789"
+  min-chars = 2
+}
+`
+
+func newComplianceVault() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/sys/mounts":
+			jsonResponse(w, mountsV2Response("secret"))
+		case "/v1/sys/policies/password/db":
+			jsonResponse(w, map[string]interface{}{"data": map[string]interface{}{"policy": testPasswordPolicy}})
+		case "/v1/secret/data/legacy/db":
+			jsonResponse(w, map[string]interface{}{"data": map[string]interface{}{
+				"data": map[string]interface{}{
+					"compliant": "k3y9abcdefghijklmnop",
+					"short":     "ab12",
+					"uppercase": "ABCDEFGHIJKLMNOPQR12",
+					"digits":    "zyxwvutsrqponmlkjihgf",
+					"port":      5432,
+				},
+				"metadata": map[string]interface{}{"version": 4},
+			}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func callCheckSecretCompliance(t *testing.T, args map[string]interface{}) (*mcp.CallToolResult, SecretCompliance) {
+	t.Helper()
+	ctx, cleanup := newTestContext(t, newComplianceVault())
+	defer cleanup()
+
+	result, err := checkSecretComplianceHandler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}, newLogger())
+	require.NoError(t, err)
+
+	var report SecretCompliance
+	if !result.IsError {
+		require.NoError(t, json.Unmarshal([]byte(getResultText(result)), &report))
+	}
+	return result, report
+}
+
+// violations returns the violations of each key of a report
+func violations(t *testing.T, report SecretCompliance) map[string][]string {
+	t.Helper()
+	byKey := map[string][]string{}
+	for _, key := range report.Keys {
+		assert.Equal(t, len(key.Violations) == 0, key.Compliant, key.Key)
+		byKey[key.Key] = key.Violations
+	}
+	return byKey
+}
+
+func TestCheckSecretCompliance_PasswordPolicy(t *testing.T) {
+	result, report := callCheckSecretCompliance(t, map[string]interface{}{"mount": "secret", "path": "legacy/db", "password_policy": "db"})
+	require.False(t, result.IsError, getResultText(result))
+
+	assert.Equal(t, 4, report.Version)
+	assert.False(t, report.Compliant)
+	assert.Equal(t, []string{
+		"at least 20 characters",
+		"at least 1 characters of 'abcdefghijklmnopqrstuvwxyz'",
+		"at least 2 characters of '0123456789'",
+		"only characters of the character sets of the password policy",
+	}, report.Rules)
+	assert.Equal(t, map[string][]string{
+		"compliant": nil,
+		"digits":    {"at least 2 characters of '0123456789'"},
+		"short":     {"at least 20 characters"},
+		"uppercase": {"at least 1 characters of 'abcdefghijklmnopqrstuvwxyz'", "only characters of the character sets of the password policy"},
+	}, violations(t, report), "only keys holding strings are evaluated by default")
+
+	for _, value := range []string{"k3y9abcdefghijklmnop", "ab12", "ABCDEFGHIJKLMNOPQR12", "zyxwvutsrqponmlkjihgf"} {
+		assert.NotContains(t, getResultText(result), value, "values are never returned")
+	}
+}
+
+func TestCheckSecretCompliance_Rules(t *testing.T) {
+	result, report := callCheckSecretCompliance(t, map[string]interface{}{
+		"mount":             "secret",
+		"path":              "legacy/db",
+		"keys":              []interface{}{"compliant", "uppercase", "port", "missing"},
+		"min_length":        float64(8),
+		"max_length":        float64(20),
+		"pattern":           "[a-z0-9]+",
+		"required_patterns": []interface{}{"[0-9]"},
+	})
+	require.False(t, result.IsError, getResultText(result))
+
+	assert.False(t, report.Compliant)
+	assert.Equal(t, map[string][]string{
+		"compliant": nil,
+		"uppercase": {"matches the pattern '[a-z0-9]+'"},
+		"port":      {"the value is not a string"},
+		"missing":   {"the key does not exist"},
+	}, violations(t, report))
+
+	result, report = callCheckSecretCompliance(t, map[string]interface{}{"mount": "secret", "path": "legacy/db", "keys": []interface{}{"compliant"}, "min_length": float64(12)})
+	require.False(t, result.IsError, getResultText(result))
+	assert.True(t, report.Compliant)
+}
+
+func TestCheckSecretCompliance_InvalidParameters(t *testing.T) {
+	for name, args := range map[string]map[string]interface{}{
+		"no rules":         {"mount": "secret", "path": "legacy/db"},
+		"invalid pattern":  {"mount": "secret", "path": "legacy/db", "pattern": "[a-z"},
+		"inverted lengths": {"mount": "secret", "path": "legacy/db", "min_length": float64(10), "max_length": float64(5)},
+		"missing policy":   {"mount": "secret", "path": "legacy/db", "password_policy": "missing"},
+		"missing secret":   {"mount": "secret", "path": "legacy/cache", "min_length": float64(8)},
+		"missing mount":    {"mount": "kv", "path": "legacy/db", "min_length": float64(8)},
+		"invalid required": {"mount": "secret", "path": "legacy/db", "required_patterns": []interface{}{"("}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			result, _ := callCheckSecretCompliance(t, args)
+			assert.True(t, result.IsError)
+		})
+	}
+}
@@ -8,7 +8,7 @@ import (
 	"fmt"
 	"github.com/hashicorp/vault-mcp-server/pkg/client"
 	"github.com/hashicorp/vault-mcp-server/pkg/utils"
-	"strings"
+	"github.com/hashicorp/vault/api"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -89,7 +89,7 @@ func deleteSecretHandler(ctx context.Context, req mcp.CallToolRequest, logger *l
 	}
 
 	// Default to a v1 KV path
-	fullPath := fmt.Sprintf("%s/%s", mount, strings.TrimPrefix(path, "/"))
+	fullPath := secretPath(mount, path, false, "")
 
 	isV2 := false
 
@@ -99,7 +99,7 @@ func deleteSecretHandler(ctx context.Context, req mcp.CallToolRequest, logger *l
 		if m.Options["version"] == "2" {
 			isV2 = true
 			// Construct the full path for reading (KV v2 format)
-			fullPath = fmt.Sprintf("%s/data/%s", mount, strings.TrimPrefix(path, "/"))
+			fullPath = secretPath(mount, path, true, sectionData)
 		}
 	} else {
 		return mcp.NewToolResultError(fmt.Sprintf("mount path '%s' does not exist. Use 'create_mount' with the type kv2 to create the mount.", mount)), nil
@@ -126,6 +126,9 @@ func deleteSecretHandler(ctx context.Context, req mcp.CallToolRequest, logger *l
 		}
 	}
 
+	// Summarize the secret before keys are removed from it in place
+	previous := secretSummary(currentSecret, isV2)
+
 	if key != "" {
 
 		if err != nil {
@@ -173,6 +176,22 @@ func deleteSecretHandler(ctx context.Context, req mcp.CallToolRequest, logger *l
 				successMsg = fmt.Sprintf("Successfully wrote version %v of the secret to path '%s' in mount '%s' with key '%s'", versionInfo.Data["version"], path, mount, key)
 			}
 
+			change := client.ChangeRecord{
+				ResourceType: client.ResourceKVSecret,
+				Mount:        mount,
+				Path:         fullPath,
+				Operation:    client.OperationUpdate,
+				Previous:     previous,
+				New:          secretSummary(&api.Secret{Data: secretData}, isV2),
+				Message:      successMsg,
+			}
+			if versionInfo != nil {
+				change.VaultRequestID = versionInfo.RequestID
+				if versionInfo.Data != nil {
+					change.New["version"] = versionInfo.Data["version"]
+				}
+			}
+
 			logger.WithFields(log.Fields{
 				"mount": mount,
 				"path":  path,
@@ -180,13 +199,13 @@ func deleteSecretHandler(ctx context.Context, req mcp.CallToolRequest, logger *l
 				"v2":    isV2,
 			}).Info("Successfully wrote secret")
 
-			return mcp.NewToolResultText(successMsg), nil
+			return client.NewChangeResult(ctx, req, change), nil
 		}
 
 	}
 
 	// Delete the secret
-	_, err = vault.Logical().Delete(fullPath)
+	deleted, err := vault.Logical().Delete(fullPath)
 	if err != nil {
 		logger.WithError(err).WithFields(log.Fields{
 			"mount":     mount,
@@ -206,5 +225,16 @@ func deleteSecretHandler(ctx context.Context, req mcp.CallToolRequest, logger *l
 		"v2":    isV2,
 	}).Info("Successfully deleted secret")
 
-	return mcp.NewToolResultText(successMsg), nil
+	change := client.ChangeRecord{
+		ResourceType: client.ResourceKVSecret,
+		Mount:        mount,
+		Path:         fullPath,
+		Operation:    client.OperationDelete,
+		Previous:     previous,
+		Message:      successMsg,
+	}
+	if deleted != nil {
+		change.VaultRequestID = deleted.RequestID
+	}
+	return client.NewChangeResult(ctx, req, change), nil
 }
@@ -0,0 +1,122 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func FuzzSecretPath(f *testing.F) {
+	f.Add("secret", "app/config", true, sectionData)
+	f.Add("secret", "/app/config", false, "")
+	f.Add("team/api-keys", "", true, sectionMetadata)
+	f.Add("%s%d", "%v/%!", true, sectionData)
+	f.Add("", "//", false, "")
+
+	f.Fuzz(func(t *testing.T, mount string, path string, v2 bool, section string) {
+		fullPath := secretPath(mount, path, v2, section)
+
+		prefix := mount + "/"
+		if v2 {
+			prefix += section + "/"
+		}
+		require.True(t, strings.HasPrefix(fullPath, prefix), "path %q does not start with %q", fullPath, prefix)
+		require.Equal(t, strings.TrimPrefix(path, "/"), strings.TrimPrefix(fullPath, prefix))
+	})
+}
+
+// newFuzzVault serves a KV v2 mount 'secret' and a KV v1 mount 'kv'. Reads of a secret return the given body.
+func newFuzzVault(secretBody []byte) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1/sys/mounts":
+			jsonResponse(w, map[string]interface{}{
+				"data": map[string]interface{}{
+					"secret/": map[string]interface{}{"type": "kv", "options": map[string]interface{}{"version": "2"}},
+					"kv/":     map[string]interface{}{"type": "kv", "options": map[string]interface{}{"version": "1"}},
+				},
+			})
+		case r.Method == http.MethodGet || r.Method == "LIST":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(secretBody)
+		default:
+			jsonResponse(w, map[string]interface{}{"data": map[string]interface{}{"version": 2}})
+		}
+	})
+}
+
+type kvHandler func(context.Context, mcp.CallToolRequest, *log.Logger) (*mcp.CallToolResult, error)
+
+var fuzzedHandlers = map[string]kvHandler{
+	"read_secret":  readSecretHandler,
+	"write_secret": writeSecretHandler,
+	"list_secrets": listSecretsHandler,
+}
+
+// callFuzzedHandlers calls every fuzzed tool with the given arguments, a malformed input must be reported
+// as a tool result instead of crashing the server
+func callFuzzedHandlers(t *testing.T, secretBody []byte, args any) {
+	ctx, cleanup := newTestContext(t, newFuzzVault(secretBody))
+	defer cleanup()
+
+	for name, handler := range fuzzedHandlers {
+		req := mcp.CallToolRequest{Params: mcp.CallToolParams{Name: name, Arguments: args}}
+		result, err := handler(ctx, req, newLogger())
+		require.NoError(t, err, name)
+		require.NotNil(t, result, name)
+	}
+}
+
+func FuzzToolArguments(f *testing.F) {
+	f.Add([]byte(`{"mount":"secret","path":"app/config","key":"username","value":"app"}`))
+	f.Add([]byte(`{"mount":"kv","path":"/app/config","key":"k","value":"v"}`))
+	f.Add([]byte(`{"mount":"secret/","path":"","recursive":true,"limit":-1}`))
+	f.Add([]byte(`{"mount":1,"path":["a"],"key":{"k":"v"},"value":null}`))
+	f.Add([]byte(`{"mount":"%s%!","path":"%v/../..","key":"\u0000","value":"x"}`))
+	f.Add([]byte(`["secret","app/config"]`))
+	f.Add([]byte(`null`))
+
+	// Every folder of the fuzzed Vault holds the folder 'app/' again
+	utils.MaxListedFolders = 10
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var args any
+		if err := json.Unmarshal(data, &args); err != nil {
+			t.Skip()
+		}
+		callFuzzedHandlers(t, []byte(`{"data":{"data":{"username":"app"},"metadata":{"version":1},"keys":["app/"]}}`), args)
+	})
+}
+
+// FuzzVaultResponses feeds arbitrary Vault responses to the KV tools, such as the soft-deleted secrets
+// whose null data used to crash write_secret
+func FuzzVaultResponses(f *testing.F) {
+	f.Add([]byte(`{"data":{"data":{"username":"app"},"metadata":{"version":1}}}`))
+	f.Add([]byte(`{"data":{"data":null,"metadata":{"deletion_time":"2024-01-18T03:00:00Z","version":1}}}`))
+	f.Add([]byte(`{"data":{"data":"string","metadata":null}}`))
+	f.Add([]byte(`{"data":{"keys":["app/",1,null]}}`))
+	f.Add([]byte(`{"data":null}`))
+	f.Add([]byte(`[]`))
+	f.Add([]byte(``))
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		for _, mount := range []string{"secret", "kv"} {
+			callFuzzedHandlers(t, body, map[string]any{
+				"mount": mount,
+				"path":  "app/config",
+				"key":   "username",
+				"value": "app",
+			})
+		}
+	})
+}
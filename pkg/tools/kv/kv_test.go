@@ -30,7 +30,7 @@ func (f fakeSession) SessionID() string                                  { retur
 
 // newTestContext creates a context wired to a mock Vault HTTP server.
 // The returned cleanup function must be deferred.
-func newTestContext(t *testing.T, handler http.Handler) (context.Context, func()) {
+func newTestContext(t testing.TB, handler http.Handler) (context.Context, func()) {
 	t.Helper()
 	mockVault := httptest.NewServer(handler)
 
@@ -47,6 +47,7 @@ func newTestContext(t *testing.T, handler http.Handler) (context.Context, func()
 	return ctx, func() {
 		mockVault.Close()
 		client.DeleteVaultClient(sessionID)
+		client.DeleteSessionChanges(sessionID)
 	}
 }
 
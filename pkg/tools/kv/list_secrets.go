@@ -5,17 +5,29 @@ package kv
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/hashicorp/vault-mcp-server/pkg/client"
 	"github.com/hashicorp/vault-mcp-server/pkg/utils"
-	"strings"
+
+	"github.com/hashicorp/vault/api"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	log "github.com/sirupsen/logrus"
 )
 
+// MaxRecursiveSecrets is the default number of secrets returned by a recursive listing
+const MaxRecursiveSecrets = 10000
+
+// SecretListing summarizes a listing that was returned in several content blocks
+type SecretListing struct {
+	Mount     string `json:"mount"`
+	Path      string `json:"path"`
+	Total     int    `json:"total"`     // Number of secrets in the preceding content blocks
+	Truncated bool   `json:"truncated"` // The listing stopped at the limit
+}
+
 // ListSecrets creates a tool for listing secrets in a Vault KV mount
 func ListSecrets(logger *log.Logger) server.ServerTool {
 	return server.ServerTool{
@@ -33,6 +45,14 @@ func ListSecrets(logger *log.Logger) server.ServerTool {
 			mcp.WithString("path",
 				mcp.DefaultString(""),
 				mcp.Description("The full path to list the secrets to without the mount prefix. For example, if you want to list from 'secrets/application/credentials', this should be 'application/credentials'.")),
+			mcp.WithBoolean("recursive",
+				mcp.DefaultBool(false),
+				mcp.Description("List the secrets of every folder under the path, returning their paths relative to it. Listings with more than 500 secrets are returned in several content blocks of 500 paths, followed by a summary block."),
+			),
+			mcp.WithNumber("limit",
+				mcp.DefaultNumber(MaxRecursiveSecrets),
+				mcp.Description("The maximum number of secrets returned by a recursive listing."),
+			),
 		),
 		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			return listSecretsHandler(ctx, req, logger)
@@ -72,7 +92,7 @@ func listSecretsHandler(ctx context.Context, req mcp.CallToolRequest, logger *lo
 	}
 
 	// Construct the full path for listing
-	fullPath := fmt.Sprintf(mount+"/%s", path)
+	fullPath := secretPath(mount, path, false, "")
 
 	mounts, err := vault.Sys().ListMounts()
 	if err != nil {
@@ -83,18 +103,19 @@ func listSecretsHandler(ctx context.Context, req mcp.CallToolRequest, logger *lo
 	if m, ok := mounts[mount+"/"]; ok {
 		// is it a KV v2 mount?
 		if m.Options["version"] == "2" {
-			if path == "" {
-				fullPath = fmt.Sprintf("%s/metadata/", mount)
-			} else {
-				fullPath = fmt.Sprintf("%s/metadata/%s", mount, strings.TrimPrefix(path, "/"))
-			}
+			fullPath = secretPath(mount, path, true, sectionMetadata)
 		}
 	} else {
 		return mcp.NewToolResultError(fmt.Sprintf("mount path '%s' does not exist. Use 'create_mount' with the type kv2 to create the mount.", mount)), nil
 	}
 
-	// List secrets
-	secret, err := vault.Logical().List(fullPath)
+	writer := utils.NewListWriter(ctx, req, 0)
+	truncated := false
+	if req.GetBool("recursive", false) {
+		truncated, err = walkSecrets(vault, fullPath, req.GetInt("limit", MaxRecursiveSecrets), writer)
+	} else {
+		err = listSecretNames(vault, fullPath, writer)
+	}
 	if err != nil {
 		logger.WithError(err).WithFields(log.Fields{
 			"mount":     mount,
@@ -104,44 +125,80 @@ func listSecretsHandler(ctx context.Context, req mcp.CallToolRequest, logger *lo
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to list secrets: %v", err)), nil
 	}
 
-	if secret == nil || secret.Data == nil {
-		logger.WithFields(log.Fields{
-			"mount": mount,
-			"path":  path,
-		}).Debug("No secrets found")
-		return mcp.NewToolResultText("[]"), nil
-	}
+	logger.WithFields(log.Fields{
+		"mount":        mount,
+		"path":         path,
+		"secret_count": writer.Count(),
+	}).Debug("Successfully listed secrets")
 
-	// Extract keys from the response
-	keys, ok := secret.Data["keys"].([]interface{})
-	if !ok {
-		logger.WithFields(log.Fields{
-			"mount": mount,
-			"path":  path,
-		}).Debug("No keys found in response")
-		return mcp.NewToolResultText("[]"), nil
+	result, err := writer.Result(&SecretListing{
+		Mount:     mount,
+		Path:      path,
+		Total:     writer.Count(),
+		Truncated: truncated || writer.Truncated(),
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal secrets to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
 	}
 
-	// Convert to string slice
-	var secretNames []string
+	return result, nil
+}
+
+// listSecretNames adds the keys directly under a path to the listing
+func listSecretNames(vault *api.Client, fullPath string, writer *utils.ListWriter) error {
+	keys, err := listKeys(vault, fullPath)
+	if err != nil {
+		return err
+	}
 	for _, key := range keys {
-		if keyStr, ok := key.(string); ok {
-			secretNames = append(secretNames, keyStr)
+		if err := writer.Add(key); err != nil {
+			if errors.Is(err, utils.ErrResultTooLarge) {
+				return nil
+			}
+			return err
 		}
 	}
+	return nil
+}
 
-	// Marshal to JSON
-	jsonData, err := json.Marshal(secretNames)
-	if err != nil {
-		logger.WithError(err).Error("Failed to marshal secrets to JSON")
-		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+// walkSecrets adds the paths of the secrets in every folder under a path to the listing, stopping at limit secrets
+func walkSecrets(vault *api.Client, fullPath string, limit int, writer *utils.ListWriter) (truncated bool, err error) {
+	if limit <= 0 {
+		limit = MaxRecursiveSecrets
 	}
 
-	logger.WithFields(log.Fields{
-		"mount":        mount,
-		"path":         path,
-		"secret_count": len(secretNames),
-	}).Debug("Successfully listed secrets")
+	return utils.WalkSecretPaths(vault, fullPath, func(path string) (bool, error) {
+		if writer.Count() >= limit {
+			return false, nil
+		}
+		if err := writer.Add(path); err != nil {
+			if errors.Is(err, utils.ErrResultTooLarge) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	})
+}
+
+// listKeys returns the keys of a LIST request, or nothing when the path does not exist. The keys are
+// the string values decoded from the response, which are passed on as they are to avoid boxing every key again.
+func listKeys(vault *api.Client, path string) ([]interface{}, error) {
+	secret, err := vault.Logical().List(path)
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, nil
+	}
 
-	return mcp.NewToolResultText(string(jsonData)), nil
+	rawKeys, _ := secret.Data["keys"].([]interface{})
+	keys := rawKeys[:0]
+	for _, key := range rawKeys {
+		if _, ok := key.(string); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
 }
@@ -0,0 +1,152 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package kv
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newListMux serves a KV v2 mount 'secrets' with the given keys per folder
+func newListMux(folders map[string][]string) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/sys/mounts", func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, mountsV2Response("secrets"))
+	})
+	mux.HandleFunc("/v1/secrets/metadata/", func(w http.ResponseWriter, r *http.Request) {
+		folder := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/secrets/metadata/"), "/")
+		keys, ok := folders[folder]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		jsonResponse(w, map[string]interface{}{"data": map[string]interface{}{"keys": keys}})
+	})
+	return mux
+}
+
+func listRequest(args map[string]interface{}) mcp.CallToolRequest {
+	return mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name:      "list_secrets",
+			Arguments: args,
+		},
+	}
+}
+
+func TestListSecretsHandler_Recursive(t *testing.T) {
+	ctx, cleanup := newTestContext(t, newListMux(map[string][]string{
+		"":        {"app/", "root-secret"},
+		"app":     {"db/", "api-key"},
+		"app/db":  {"password"},
+		"missing": nil,
+	}))
+	defer cleanup()
+
+	result, err := listSecretsHandler(ctx, listRequest(map[string]interface{}{
+		"mount":     "secrets",
+		"recursive": true,
+	}), newLogger())
+	require.NoError(t, err)
+	require.False(t, result.IsError, getResultText(result))
+	require.Len(t, result.Content, 1)
+
+	var secrets []string
+	require.NoError(t, json.Unmarshal([]byte(getResultText(result)), &secrets))
+	assert.Equal(t, []string{"root-secret", "app/api-key", "app/db/password"}, secrets)
+}
+
+func TestListSecretsHandler_StreamsLargeListings(t *testing.T) {
+	previous := utils.ListBlockSize
+	utils.ListBlockSize = 2
+	defer func() { utils.ListBlockSize = previous }()
+
+	keys := make([]string, 5)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("secret-%d", i)
+	}
+	ctx, cleanup := newTestContext(t, newListMux(map[string][]string{"": keys}))
+	defer cleanup()
+
+	result, err := listSecretsHandler(ctx, listRequest(map[string]interface{}{
+		"mount": "secrets",
+	}), newLogger())
+	require.NoError(t, err)
+	require.False(t, result.IsError, getResultText(result))
+
+	// Three blocks of at most two secrets, followed by the summary
+	require.Len(t, result.Content, 4)
+	var listed []string
+	for _, content := range result.Content[:3] {
+		text, ok := mcp.AsTextContent(content)
+		require.True(t, ok)
+		var block []string
+		require.NoError(t, json.Unmarshal([]byte(text.Text), &block))
+		assert.LessOrEqual(t, len(block), 2)
+		listed = append(listed, block...)
+	}
+	assert.Equal(t, keys, listed)
+
+	summaryText, ok := mcp.AsTextContent(result.Content[3])
+	require.True(t, ok)
+	var summary SecretListing
+	require.NoError(t, json.Unmarshal([]byte(summaryText.Text), &summary))
+	assert.Equal(t, SecretListing{Mount: "secrets", Path: "", Total: 5}, summary)
+}
+
+func TestListSecretsHandler_RecursiveLimit(t *testing.T) {
+	ctx, cleanup := newTestContext(t, newListMux(map[string][]string{
+		"":    {"a", "b", "app/"},
+		"app": {"c"},
+	}))
+	defer cleanup()
+
+	result, err := listSecretsHandler(ctx, listRequest(map[string]interface{}{
+		"mount":     "secrets",
+		"recursive": true,
+		"limit":     2,
+	}), newLogger())
+	require.NoError(t, err)
+	require.False(t, result.IsError, getResultText(result))
+
+	var secrets []string
+	require.NoError(t, json.Unmarshal([]byte(getResultText(result)), &secrets))
+	assert.Equal(t, []string{"a", "b"}, secrets)
+}
+
+func TestListSecretsHandler_MaxResultBytes(t *testing.T) {
+	previous := utils.MaxResultBytes
+	utils.MaxResultBytes = 12
+	defer func() { utils.MaxResultBytes = previous }()
+
+	ctx, cleanup := newTestContext(t, newListMux(map[string][]string{
+		"":    {"a", "b", "app/"},
+		"app": {"c"},
+	}))
+	defer cleanup()
+
+	result, err := listSecretsHandler(ctx, listRequest(map[string]interface{}{
+		"mount":     "secrets",
+		"recursive": true,
+	}), newLogger())
+	require.NoError(t, err)
+	require.False(t, result.IsError, getResultText(result))
+
+	// The listing stops at the cap and the summary reports it as truncated
+	require.Len(t, result.Content, 2)
+	assert.Equal(t, `["a","b"]`, getResultText(result))
+	summaryText, ok := mcp.AsTextContent(result.Content[1])
+	require.True(t, ok)
+	var summary SecretListing
+	require.NoError(t, json.Unmarshal([]byte(summaryText.Text), &summary))
+	assert.Equal(t, SecretListing{Mount: "secrets", Path: "", Total: 2, Truncated: true}, summary)
+}
@@ -0,0 +1,55 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package kv
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/vault/api"
+)
+
+// KV v2 sections of a secret path
+const (
+	sectionData     = "data"
+	sectionMetadata = "metadata"
+)
+
+// secretPath builds the API path of a secret in a KV mount. KV v2 mounts keep the data of a secret
+// under 'data/' and its metadata and listings under 'metadata/', KV v1 mounts use the path as is.
+func secretPath(mount string, path string, v2 bool, section string) string {
+	path = strings.TrimPrefix(path, "/")
+	if !v2 {
+		return mount + "/" + path
+	}
+	return mount + "/" + section + "/" + path
+}
+
+// secretSummary describes a secret for a change record by its version and key names, without its values.
+// It returns nil when there is no secret.
+func secretSummary(secret *api.Secret, v2 bool) map[string]any {
+	if secret == nil || secret.Data == nil {
+		return nil
+	}
+
+	data := secret.Data
+	summary := map[string]any{}
+	if v2 {
+		if metadata, ok := secret.Data["metadata"].(map[string]interface{}); ok && metadata["version"] != nil {
+			summary["version"] = metadata["version"]
+		}
+		data, _ = secret.Data["data"].(map[string]interface{})
+		if data == nil {
+			return nil
+		}
+	}
+
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	summary["keys"] = keys
+	return summary
+}
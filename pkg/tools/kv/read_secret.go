@@ -7,10 +7,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+
 	"github.com/hashicorp/vault-mcp-server/pkg/client"
 	"github.com/hashicorp/vault-mcp-server/pkg/utils"
-
-	"strings"
+	"github.com/hashicorp/vault/api"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -21,7 +22,12 @@ import (
 func ReadSecret(logger *log.Logger) server.ServerTool {
 	return server.ServerTool{
 		Tool: mcp.NewTool("read_secret",
-			mcp.WithDescription("Read a secret from a KV mount in at a specific path in Vault."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithDescription("Read a secret from a KV mount in at a specific path in Vault. With output 'reference', returns a vault://mount/path#key reference for each key instead of its value, for wiring secrets into configuration files without seeing them."),
 			mcp.WithString("mount",
 				mcp.Required(),
 				mcp.Description("The mount path of the secret engine. For example, if you want to read from 'secrets/application/credentials', this should be 'secrets' without the trailing slash."),
@@ -30,6 +36,15 @@ func ReadSecret(logger *log.Logger) server.ServerTool {
 				mcp.Required(),
 				mcp.Description("The full path to read the secret to without the mount prefix. For example, if you want to read from 'secrets/application/credentials', this should be 'application/credentials'."),
 			),
+			mcp.WithString("output",
+				mcp.DefaultString(utils.OutputValue),
+				mcp.Enum(utils.OutputValue, utils.OutputReference),
+				mcp.Description("'value' returns the values of the secret, 'reference' returns a reference to each value and the version of the secret instead. Defaults to 'value'."),
+			),
+			mcp.WithBoolean("include_metadata",
+				mcp.DefaultBool(false),
+				mcp.Description("Return the values under 'data' together with the 'metadata' of the version that was read: its version number, creation time, custom metadata and deletion state. Deleted versions are then returned with null data instead of an error. KV v1 secrets have no metadata. With output 'reference', the metadata is returned along with the references. Defaults to false."),
+			),
 		),
 		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			return readSecretHandler(ctx, req, logger)
@@ -56,6 +71,13 @@ func readSecretHandler(ctx context.Context, req mcp.CallToolRequest, logger *log
 		return mcp.NewToolResultError("Missing or invalid 'path' parameter"), nil
 	}
 
+	output, err := utils.ExtractOutput(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	includeMetadata := req.GetBool("include_metadata", false)
+
 	logger.WithFields(log.Fields{
 		"mount": mount,
 		"path":  path,
@@ -74,7 +96,7 @@ func readSecretHandler(ctx context.Context, req mcp.CallToolRequest, logger *log
 	}
 
 	// Default to a v1 KV path
-	fullPath := fmt.Sprintf("%s/%s", mount, strings.TrimPrefix(path, "/"))
+	fullPath := secretPath(mount, path, false, "")
 
 	isV2 := false
 
@@ -84,7 +106,7 @@ func readSecretHandler(ctx context.Context, req mcp.CallToolRequest, logger *log
 		if m.Options["version"] == "2" {
 			isV2 = true
 			// Construct the full path for reading (KV v2 format)
-			fullPath = fmt.Sprintf("%s/data/%s", mount, strings.TrimPrefix(path, "/"))
+			fullPath = secretPath(mount, path, true, sectionData)
 		}
 	} else {
 		return mcp.NewToolResultError(fmt.Sprintf("mount path '%s' does not exist. Use 'create_mount' with the type kv2 to create the mount.", mount)), nil
@@ -110,7 +132,8 @@ func readSecretHandler(ctx context.Context, req mcp.CallToolRequest, logger *log
 	}
 
 	// Handle the data structure differently for v1 and v2
-	var secretData interface{}
+	var secretData map[string]interface{}
+	var metadata *SecretMetadata
 
 	if isV2 {
 		if secret.Data["data"] == nil {
@@ -118,23 +141,34 @@ func readSecretHandler(ctx context.Context, req mcp.CallToolRequest, logger *log
 			if !ok {
 				return mcp.NewToolResultError("unexpected secret metadata format for v2 API"), nil
 			}
-			if metaData["deletion_time"] != nil {
+			if metaData["deletion_time"] != nil && !includeMetadata {
 				return mcp.NewToolResultError(fmt.Sprintf("Secret at path '%s' in mount '%s' is deleted and cannot be read.", path, mount)), nil
 			}
 		}
 		// V2 API structure: secret.Data["data"] contains the actual key-value pairs
 		data, ok := secret.Data["data"].(map[string]interface{})
-		if !ok {
+		if !ok && (secret.Data["data"] != nil || !includeMetadata) {
 			return mcp.NewToolResultError("unexpected secret data format for v2 API"), nil
 		}
 		secretData = data
+		if includeMetadata {
+			metadata = secretMetadata(secret)
+		}
 	} else {
 		// V1 API structure: secret.Data directly contains the key-value pairs
 		secretData = secret.Data
 	}
 
+	if output == utils.OutputReference {
+		return secretReferencesResult(mount, path, secret, secretData, metadata, isV2, logger)
+	}
+
 	// Marshal to JSON
-	jsonData, err := json.Marshal(secretData)
+	var result interface{} = secretData
+	if includeMetadata {
+		result = SecretEnvelope{Data: secretData, Metadata: metadata}
+	}
+	jsonData, err := utils.MarshalJSON(result)
 	if err != nil {
 		logger.WithError(err).Error("Failed to marshal secret to JSON")
 		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
@@ -145,5 +179,84 @@ func readSecretHandler(ctx context.Context, req mcp.CallToolRequest, logger *log
 		"path":  path,
 	}).Debug("Successfully read secret")
 
-	return mcp.NewToolResultText(string(jsonData)), nil
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// SecretEnvelope is a secret read with its metadata
+type SecretEnvelope struct {
+	Data     map[string]interface{} `json:"data"`               // Values of the secret, null when the version is deleted or destroyed
+	Metadata *SecretMetadata        `json:"metadata,omitempty"` // Metadata of the version that was read, absent for KV v1 secrets
+}
+
+// SecretMetadata is the metadata of a version of a KV v2 secret
+type SecretMetadata struct {
+	Version        int               `json:"version"`
+	CreatedTime    string            `json:"created_time"`
+	DeletionTime   string            `json:"deletion_time,omitempty"` // Set when the version is deleted
+	Destroyed      bool              `json:"destroyed"`
+	CustomMetadata map[string]string `json:"custom_metadata,omitempty"`
+}
+
+// secretMetadata returns the metadata that a KV v2 read returns along with the data
+func secretMetadata(secret *api.Secret) *SecretMetadata {
+	raw, _ := secret.Data["metadata"].(map[string]interface{})
+	metadata := &SecretMetadata{}
+	if version, ok := raw["version"].(json.Number); ok {
+		value, _ := version.Int64()
+		metadata.Version = int(value)
+	}
+	metadata.CreatedTime, _ = raw["created_time"].(string)
+	metadata.DeletionTime, _ = raw["deletion_time"].(string)
+	metadata.Destroyed, _ = raw["destroyed"].(bool)
+	if custom, ok := raw["custom_metadata"].(map[string]interface{}); ok {
+		metadata.CustomMetadata = make(map[string]string, len(custom))
+		for key, value := range custom {
+			metadata.CustomMetadata[key] = fmt.Sprint(value)
+		}
+	}
+	return metadata
+}
+
+// SecretReferences are the references to the values of a secret, returned instead of the values
+type SecretReferences struct {
+	Mount      string            `json:"mount"`
+	Path       string            `json:"path"`
+	Version    int               `json:"version,omitempty"`  // Version of a KV v2 secret that was read
+	References map[string]string `json:"references"`         // Reference to the value of each key
+	Metadata   *SecretMetadata   `json:"metadata,omitempty"` // Metadata of the version that was read, when requested
+}
+
+// secretReferencesResult returns references to the values of a secret. The references follow the current
+// version, appending ?version= pins them to the version that was read. The metadata tells a deleted version,
+// which has no references, apart from an empty secret.
+func secretReferencesResult(mount string, path string, secret *api.Secret, data map[string]interface{}, metadata *SecretMetadata, v2 bool, logger *log.Logger) (*mcp.CallToolResult, error) {
+	references := SecretReferences{
+		Mount:      mount,
+		Path:       path,
+		References: map[string]string{},
+		Metadata:   metadata,
+	}
+	if v2 {
+		if version, ok := secretSummary(secret, true)["version"].(json.Number); ok {
+			value, _ := version.Int64()
+			references.Version = int(value)
+		}
+	}
+	secretPath := mount + "/" + strings.Trim(path, "/")
+	for key := range data {
+		references.References[key] = utils.SecretReference{Path: secretPath, Key: key}.String()
+	}
+
+	jsonData, err := utils.MarshalJSON(references)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal secret references to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount": mount,
+		"path":  path,
+	}).Debug("Successfully read secret references")
+
+	return mcp.NewToolResultText(jsonData), nil
 }
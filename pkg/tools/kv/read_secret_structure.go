@@ -0,0 +1,157 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package kv
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// sectionSubkeys is the KV v2 section returning the key structure of a secret
+const sectionSubkeys = "subkeys"
+
+// SecretStructure is the key structure of a KV v2 secret, without any of its values
+type SecretStructure struct {
+	Mount   string         `json:"mount"`
+	Path    string         `json:"path"`
+	Version int            `json:"version,omitempty"` // Version of the secret the structure was read from
+	Subkeys map[string]any `json:"subkeys"`           // Nested key names, keys holding a value or cut off by the depth are null
+}
+
+// ReadSecretStructure creates a tool for reading the key structure of a secret without its values
+func ReadSecretStructure(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("read_secret_structure",
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithDescription("Read the key structure of a secret in a KV v2 mount, the nested key names without any values. Use it instead of 'read_secret' to find out which keys a secret holds."),
+			mcp.WithString("mount",
+				mcp.Required(),
+				mcp.Description("The mount path of the KV v2 secret engine, without the trailing slash."),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("The path of the secret without the mount prefix. For example, 'application/credentials'."),
+			),
+			mcp.WithNumber("depth",
+				mcp.DefaultNumber(0),
+				mcp.Min(0),
+				mcp.Description("How many levels of nested keys to return, 0 returns every level."),
+			),
+			mcp.WithNumber("version",
+				mcp.DefaultNumber(0),
+				mcp.Min(0),
+				mcp.Description("The version of the secret to read, 0 reads the latest version."),
+			),
+			schemas.Output("read_secret_structure"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return readSecretStructureHandler(ctx, req, logger)
+		},
+	}
+}
+
+func readSecretStructureHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling read_secret_structure request")
+
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	path, ok := args["path"].(string)
+	if !ok || path == "" {
+		return mcp.NewToolResultError("Missing or invalid 'path' parameter"), nil
+	}
+
+	depth := req.GetInt("depth", 0)
+	version := req.GetInt("version", 0)
+	if depth < 0 || version < 0 {
+		return mcp.NewToolResultError("'depth' and 'version' cannot be negative"), nil
+	}
+
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list mounts: %v", err)), nil
+	}
+	m, ok := mounts[mount+"/"]
+	if !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("mount path '%s' does not exist. Use 'create_mount' with the type kv2 to create the mount.", mount)), nil
+	}
+	// KV v1 has no subkeys endpoint, and reading the secret instead would return its values
+	if m.Options["version"] != "2" {
+		return mcp.NewToolResultError(fmt.Sprintf("mount '%s' is not a KV v2 mount, the key structure of a secret can only be read from KV v2 mounts", mount)), nil
+	}
+
+	query := map[string][]string{}
+	if depth > 0 {
+		query["depth"] = []string{strconv.Itoa(depth)}
+	}
+	if version > 0 {
+		query["version"] = []string{strconv.Itoa(version)}
+	}
+
+	fullPath := secretPath(mount, path, true, sectionSubkeys)
+	secret, err := vault.Logical().ReadWithDataWithContext(ctx, fullPath, query)
+	if err != nil {
+		logger.WithError(err).WithFields(log.Fields{
+			"mount":     mount,
+			"path":      path,
+			"full_path": fullPath,
+		}).Error("Failed to read secret structure")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read secret structure: %v", err)), nil
+	}
+	if secret == nil || secret.Data == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Secret not found at path '%s' in mount '%s'.", path, mount)), nil
+	}
+
+	subkeys, _ := secret.Data["subkeys"].(map[string]interface{})
+	metadata, _ := secret.Data["metadata"].(map[string]interface{})
+	if subkeys == nil {
+		if metadata["deletion_time"] != nil && metadata["deletion_time"] != "" {
+			return mcp.NewToolResultError(fmt.Sprintf("Secret at path '%s' in mount '%s' is deleted and cannot be read.", path, mount)), nil
+		}
+		return mcp.NewToolResultError("unexpected subkeys format for v2 API"), nil
+	}
+
+	structure := SecretStructure{Mount: mount, Path: path, Subkeys: subkeys}
+	if v, err := strconv.Atoi(fmt.Sprint(metadata["version"])); err == nil {
+		structure.Version = v
+	}
+
+	jsonData, err := utils.MarshalJSON(structure)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal secret structure to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount": mount,
+		"path":  path,
+	}).Debug("Successfully read secret structure")
+
+	return mcp.NewToolResultStructured(structure, jsonData), nil
+}
@@ -0,0 +1,112 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package kv
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func structureRequest(args map[string]interface{}) mcp.CallToolRequest {
+	return mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name:      "read_secret_structure",
+			Arguments: args,
+		},
+	}
+}
+
+func TestReadSecretStructureHandler(t *testing.T) {
+	logger := newLogger()
+	var query map[string][]string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/sys/mounts", func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, mountsV2Response("secret"))
+	})
+	mux.HandleFunc("/v1/secret/subkeys/app/database", func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		jsonResponse(w, map[string]interface{}{
+			"data": map[string]interface{}{
+				"subkeys": map[string]interface{}{
+					"username": nil,
+					"replicas": map[string]interface{}{"primary": nil},
+				},
+				"metadata": map[string]interface{}{"version": 3, "deletion_time": ""},
+			},
+		})
+	})
+	mux.HandleFunc("/v1/secret/data/app/database", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the values of the secret must not be read")
+	})
+
+	ctx, cleanup := newTestContext(t, mux)
+	defer cleanup()
+
+	result, err := readSecretStructureHandler(ctx, structureRequest(map[string]interface{}{
+		"mount": "secret",
+		"path":  "app/database",
+		"depth": float64(2),
+	}), logger)
+	require.NoError(t, err)
+	require.False(t, result.IsError, getResultText(result))
+
+	structure, ok := result.StructuredContent.(SecretStructure)
+	require.True(t, ok)
+	assert.Equal(t, 3, structure.Version)
+	assert.Equal(t, map[string]any{"username": nil, "replicas": map[string]any{"primary": nil}}, structure.Subkeys)
+	assert.Equal(t, []string{"2"}, query["depth"])
+	assert.NotContains(t, query, "version")
+}
+
+func TestReadSecretStructureHandler_KVv1(t *testing.T) {
+	logger := newLogger()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/sys/mounts", func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, map[string]interface{}{
+			"data": map[string]interface{}{
+				"legacy/": map[string]interface{}{"type": "kv", "options": map[string]interface{}{"version": "1"}},
+			},
+		})
+	})
+
+	ctx, cleanup := newTestContext(t, mux)
+	defer cleanup()
+
+	result, err := readSecretStructureHandler(ctx, structureRequest(map[string]interface{}{
+		"mount": "legacy",
+		"path":  "app",
+	}), logger)
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, getResultText(result), "not a KV v2 mount")
+}
+
+func TestReadSecretStructureHandler_NotFound(t *testing.T) {
+	logger := newLogger()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/sys/mounts", func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, mountsV2Response("secret"))
+	})
+	mux.HandleFunc("/v1/secret/subkeys/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	ctx, cleanup := newTestContext(t, mux)
+	defer cleanup()
+
+	result, err := readSecretStructureHandler(ctx, structureRequest(map[string]interface{}{
+		"mount": "secret",
+		"path":  "missing",
+	}), logger)
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, getResultText(result), "Secret not found")
+}
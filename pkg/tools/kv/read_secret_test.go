@@ -0,0 +1,168 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package kv
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadSecretHandler_References(t *testing.T) {
+	logger := newLogger()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/sys/mounts", func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, mountsV2Response("secret"))
+	})
+	mux.HandleFunc("/v1/secret/data/app/db", func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, map[string]interface{}{
+			"data": map[string]interface{}{
+				"data":     map[string]interface{}{"username": "app", "password": "hunter2"},
+				"metadata": map[string]interface{}{"version": 4, "deletion_time": ""},
+			},
+		})
+	})
+
+	ctx, cleanup := newTestContext(t, mux)
+	defer cleanup()
+
+	request := func(output string) mcp.CallToolRequest {
+		return mcp.CallToolRequest{Params: mcp.CallToolParams{
+			Name:      "read_secret",
+			Arguments: map[string]interface{}{"mount": "secret", "path": "app/db", "output": output},
+		}}
+	}
+
+	result, err := readSecretHandler(ctx, request("reference"), logger)
+	require.NoError(t, err)
+	require.False(t, result.IsError, getResultText(result))
+	assert.NotContains(t, getResultText(result), "hunter2")
+
+	var references SecretReferences
+	require.NoError(t, json.Unmarshal([]byte(getResultText(result)), &references))
+	assert.Equal(t, SecretReferences{
+		Mount:   "secret",
+		Path:    "app/db",
+		Version: 4,
+		References: map[string]string{
+			"username": "vault://secret/app/db#username",
+			"password": "vault://secret/app/db#password",
+		},
+	}, references)
+
+	result, err = readSecretHandler(ctx, request("value"), logger)
+	require.NoError(t, err)
+	assert.Contains(t, getResultText(result), "hunter2")
+
+	result, err = readSecretHandler(ctx, request("plaintext"), logger)
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
+
+func TestReadSecretHandler_IncludeMetadata(t *testing.T) {
+	logger := newLogger()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/sys/mounts", func(w http.ResponseWriter, r *http.Request) {
+		mounts := mountsV2Response("secret")
+		mounts["data"].(map[string]interface{})["legacy/"] = map[string]interface{}{"type": "kv", "options": map[string]interface{}{"version": "1"}}
+		jsonResponse(w, mounts)
+	})
+	mux.HandleFunc("/v1/secret/data/app/db", func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, map[string]interface{}{
+			"data": map[string]interface{}{
+				"data": map[string]interface{}{"password": "hunter2"},
+				"metadata": map[string]interface{}{
+					"version":         4,
+					"created_time":    "2025-06-02T09:30:00.000000Z",
+					"deletion_time":   "",
+					"destroyed":       false,
+					"custom_metadata": map[string]interface{}{"owner": "payments"},
+				},
+			},
+		})
+	})
+	mux.HandleFunc("/v1/secret/data/app/old", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		jsonResponse(w, map[string]interface{}{
+			"data": map[string]interface{}{
+				"data": nil,
+				"metadata": map[string]interface{}{
+					"version":       2,
+					"created_time":  "2025-05-01T08:00:00.000000Z",
+					"deletion_time": "2025-05-20T08:00:00.000000Z",
+					"destroyed":     false,
+				},
+			},
+		})
+	})
+	mux.HandleFunc("/v1/legacy/app/db", func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, map[string]interface{}{"data": map[string]interface{}{"password": "hunter2"}})
+	})
+
+	ctx, cleanup := newTestContext(t, mux)
+	defer cleanup()
+
+	readOutput := func(mount, path string, includeMetadata bool, output string) *mcp.CallToolResult {
+		result, err := readSecretHandler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{
+			Name:      "read_secret",
+			Arguments: map[string]interface{}{"mount": mount, "path": path, "include_metadata": includeMetadata, "output": output},
+		}}, logger)
+		require.NoError(t, err)
+		return result
+	}
+	read := func(mount, path string, includeMetadata bool) *mcp.CallToolResult {
+		return readOutput(mount, path, includeMetadata, "value")
+	}
+
+	result := read("secret", "app/db", true)
+	require.False(t, result.IsError, getResultText(result))
+	var envelope SecretEnvelope
+	require.NoError(t, json.Unmarshal([]byte(getResultText(result)), &envelope))
+	assert.Equal(t, map[string]interface{}{"password": "hunter2"}, envelope.Data)
+	assert.Equal(t, &SecretMetadata{
+		Version:        4,
+		CreatedTime:    "2025-06-02T09:30:00.000000Z",
+		CustomMetadata: map[string]string{"owner": "payments"},
+	}, envelope.Metadata)
+
+	result = read("secret", "app/db", false)
+	require.False(t, result.IsError, getResultText(result))
+	assert.JSONEq(t, `{"password":"hunter2"}`, getResultText(result), "the metadata is only returned on request")
+
+	result = read("secret", "app/old", false)
+	assert.True(t, result.IsError)
+	result = read("secret", "app/old", true)
+	require.False(t, result.IsError, getResultText(result))
+	envelope = SecretEnvelope{}
+	require.NoError(t, json.Unmarshal([]byte(getResultText(result)), &envelope))
+	assert.Nil(t, envelope.Data)
+	assert.Equal(t, 2, envelope.Metadata.Version)
+	assert.Equal(t, "2025-05-20T08:00:00.000000Z", envelope.Metadata.DeletionTime)
+
+	result = readOutput("secret", "app/old", true, "reference")
+	require.False(t, result.IsError, getResultText(result))
+	var references SecretReferences
+	require.NoError(t, json.Unmarshal([]byte(getResultText(result)), &references))
+	assert.Empty(t, references.References)
+	require.NotNil(t, references.Metadata, "a deleted version is told apart from an empty secret")
+	assert.Equal(t, "2025-05-20T08:00:00.000000Z", references.Metadata.DeletionTime)
+
+	result = readOutput("secret", "app/db", true, "reference")
+	require.False(t, result.IsError, getResultText(result))
+	references = SecretReferences{}
+	require.NoError(t, json.Unmarshal([]byte(getResultText(result)), &references))
+	assert.Equal(t, map[string]string{"password": "vault://secret/app/db#password"}, references.References)
+	assert.Equal(t, 4, references.Metadata.Version)
+	assert.NotContains(t, getResultText(result), "hunter2")
+
+	result = read("legacy", "app/db", true)
+	require.False(t, result.IsError, getResultText(result))
+	assert.JSONEq(t, `{"data":{"password":"hunter2"}}`, getResultText(result), "KV v1 secrets have no metadata")
+}
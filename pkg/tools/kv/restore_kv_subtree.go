@@ -0,0 +1,227 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+type RestoreReport struct {
+	File        string    `json:"file"`              // Path of the archive relative to the backup directory
+	Encryption  string    `json:"encryption"`        // transit or age
+	BackedUpAt  time.Time `json:"backed_up_at"`      // When the archive was written
+	SourceMount string    `json:"source_mount"`      // Mount the archive was backed up from
+	SourcePath  string    `json:"source_path"`       // Path the archive was backed up from
+	Mount       string    `json:"mount"`             // Mount the secrets are restored to
+	Path        string    `json:"path"`              // Path the secrets are restored under
+	DryRun      bool      `json:"dry_run"`           // Whether the secrets were only compared, not written
+	Restored    []string  `json:"restored"`          // Secrets written, or that would be written on a dry run
+	Skipped     []string  `json:"skipped,omitempty"` // Existing secrets left unchanged because overwrite is not set
+	Failed      []string  `json:"failed,omitempty"`  // Secrets that could not be written, with the error
+}
+
+// RestoreKVSubtree creates a tool for restoring the secrets of an archive written by backup_kv_subtree
+func RestoreKVSubtree(config BackupConfig, logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("restore_kv_subtree",
+			mcp.WithDescription("Restore the secrets of an archive written by 'backup_kv_subtree' from the backup directory of the server, to the mount and path they were backed up from or to another KV mount or path. Transit encrypted archives are decrypted with the transit key that encrypted them, age archives with the identity file of the server. Existing secrets are left unchanged unless 'overwrite' is set; on KV v2 mounts they get a new version. Restored secrets are recorded as changes of the session."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					DestructiveHint: utils.ToBoolPtr(true),
+					IdempotentHint:  utils.ToBoolPtr(false),
+				},
+			),
+			mcp.WithString("file",
+				mcp.Required(),
+				mcp.Description("Name of the archive, relative to the backup directory."),
+			),
+			mcp.WithString("mount",
+				mcp.DefaultString(""),
+				mcp.Description("The KV mount to restore to, without the trailing slash. Defaults to the mount the archive was backed up from."),
+			),
+			mcp.WithString("path",
+				mcp.Description("The folder to restore the secrets under, without the mount prefix. Defaults to the path the archive was backed up from, pass an empty string to restore to the root of the mount."),
+			),
+			mcp.WithBoolean("overwrite",
+				mcp.DefaultBool(false),
+				mcp.Description("Replace the secrets that exist. Defaults to false, which skips them."),
+			),
+			mcp.WithBoolean("dry_run",
+				mcp.DefaultBool(false),
+				mcp.Description("Only report which secrets would be restored or skipped, without writing them."),
+			),
+			schemas.Output("restore_kv_subtree"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return restoreKVSubtreeHandler(ctx, req, config, logger)
+		},
+	}
+}
+
+func restoreKVSubtreeHandler(ctx context.Context, req mcp.CallToolRequest, config BackupConfig, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling restore_kv_subtree request")
+
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	file, err := config.archivePath(req.GetString("file", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	overwrite := req.GetBool("overwrite", false)
+	dryRun := req.GetBool("dry_run", false)
+
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	sealed, err := os.ReadFile(file)
+	if err != nil {
+		logger.WithError(err).WithField("file", file).Error("Failed to read the archive")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read archive '%s': %v", req.GetString("file", ""), err)), nil
+	}
+	report := &RestoreReport{
+		File:       req.GetString("file", ""),
+		Encryption: BackupEncryptionTransit,
+		DryRun:     dryRun,
+		Restored:   []string{},
+	}
+	var plaintext []byte
+	if isAgeFile(sealed) {
+		report.Encryption = BackupEncryptionAge
+		plaintext, err = openWithAge(config, sealed)
+	} else {
+		plaintext, err = openWithTransit(vault, sealed)
+	}
+	if err != nil {
+		logger.WithError(err).WithField("file", file).Error("Failed to decrypt the archive")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to decrypt archive '%s': %v", report.File, err)), nil
+	}
+
+	var archive backupArchive
+	if err := json.Unmarshal(plaintext, &archive); err != nil || archive.Format != backupArchiveFormat {
+		return mcp.NewToolResultError(fmt.Sprintf("Archive '%s' is not a KV backup archive", report.File)), nil
+	}
+	report.BackedUpAt = archive.CreatedAt
+	report.SourceMount = archive.Mount
+	report.SourcePath = archive.Path
+
+	report.Mount = archive.Mount
+	if req.GetString("mount", "") != "" {
+		if report.Mount, err = utils.ExtractMountPath(args); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+	report.Path = archive.Path
+	if path, ok := args["path"].(string); ok {
+		report.Path = strings.Trim(path, "/")
+	}
+
+	v2, err := kvVersion2(vault, report.Mount)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	for i, secret := range archive.Secrets {
+		_ = utils.NotifyProgress(ctx, req, float64(i), float64(len(archive.Secrets)), "Restoring "+secret.Path)
+
+		fullPath := secretPath(report.Mount, joinSecretPath(report.Path, secret.Path), v2, sectionData)
+		current, err := vault.Logical().ReadWithContext(ctx, fullPath)
+		if err != nil {
+			report.Failed = append(report.Failed, fmt.Sprintf("%s: %v", secret.Path, err))
+			continue
+		}
+		exists := secretData(current, v2) != nil
+		if exists && !overwrite {
+			report.Skipped = append(report.Skipped, secret.Path)
+			continue
+		}
+		if dryRun {
+			report.Restored = append(report.Restored, secret.Path)
+			continue
+		}
+
+		body := secret.Data
+		if v2 {
+			body = map[string]interface{}{"data": secret.Data}
+		}
+		written, err := vault.Logical().WriteWithContext(ctx, fullPath, body)
+		if err != nil {
+			report.Failed = append(report.Failed, fmt.Sprintf("%s: %v", secret.Path, err))
+			continue
+		}
+		report.Restored = append(report.Restored, secret.Path)
+
+		change := client.ChangeRecord{
+			Tool:         req.Params.Name,
+			ResourceType: client.ResourceKVSecret,
+			Mount:        report.Mount,
+			Path:         fullPath,
+			Operation:    client.OperationCreate,
+			New:          secretSummary(&api.Secret{Data: body}, v2),
+			Message:      fmt.Sprintf("Restored secret '%s' from archive '%s'", secret.Path, report.File),
+		}
+		if exists {
+			change.Operation = client.OperationUpdate
+			change.Previous = secretSummary(current, v2)
+		}
+		if written != nil {
+			change.VaultRequestID = written.RequestID
+			if change.New != nil && written.Data != nil && written.Data["version"] != nil {
+				change.New["version"] = written.Data["version"]
+			}
+		}
+		client.RecordChange(ctx, change)
+	}
+
+	jsonData, err := json.Marshal(report)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal restore report to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"file":     file,
+		"mount":    report.Mount,
+		"path":     report.Path,
+		"restored": len(report.Restored),
+		"skipped":  len(report.Skipped),
+		"failed":   len(report.Failed),
+		"dry_run":  dryRun,
+	}).Info("Restored KV subtree")
+	return mcp.NewToolResultStructured(report, string(jsonData)), nil
+}
+
+// openWithAge decrypts an age archive with the identities of the identity file of the server
+func openWithAge(config BackupConfig, data []byte) ([]byte, error) {
+	if config.AgeIdentityFile == "" {
+		return nil, fmt.Errorf("the archive is encrypted with age, set %s to the identity file of its recipient to restore it", BackupAgeIdentityFileEnv)
+	}
+	file, err := os.ReadFile(config.AgeIdentityFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the age identity file: %v", err)
+	}
+	identities, err := parseAgeIdentities(file)
+	if err != nil {
+		return nil, fmt.Errorf("invalid age identity file: %v", err)
+	}
+	return ageDecrypt(data, identities)
+}
@@ -8,8 +8,7 @@ import (
 	"fmt"
 	"github.com/hashicorp/vault-mcp-server/pkg/client"
 	"github.com/hashicorp/vault-mcp-server/pkg/utils"
-
-	"strings"
+	"github.com/hashicorp/vault/api"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -98,7 +97,7 @@ func writeSecretHandler(ctx context.Context, req mcp.CallToolRequest, logger *lo
 	}
 
 	// Default to a v1 KV path
-	fullPath := fmt.Sprintf("%s/%s", mount, strings.TrimPrefix(path, "/"))
+	fullPath := secretPath(mount, path, false, "")
 
 	isV2 := false
 
@@ -108,7 +107,7 @@ func writeSecretHandler(ctx context.Context, req mcp.CallToolRequest, logger *lo
 		if m.Options["version"] == "2" {
 			isV2 = true
 			// Construct the full path for reading (KV v2 format)
-			fullPath = fmt.Sprintf("%s/data/%s", mount, strings.TrimPrefix(path, "/"))
+			fullPath = secretPath(mount, path, true, sectionData)
 		}
 	} else {
 		return mcp.NewToolResultError(fmt.Sprintf("mount path '%s' does not exist. Use 'create_mount' with the type kv2 to create the mount.", mount)), nil
@@ -122,6 +121,8 @@ func writeSecretHandler(ctx context.Context, req mcp.CallToolRequest, logger *lo
 
 	var secretData map[string]interface{}
 
+	// Summarize the secret before it is modified in place
+	previous := secretSummary(currentSecret, isV2)
 	if currentSecret != nil {
 		secretData = currentSecret.Data
 	}
@@ -160,10 +161,27 @@ func writeSecretHandler(ctx context.Context, req mcp.CallToolRequest, logger *lo
 
 	successMsg := fmt.Sprintf("Successfully updated the secret, adding or updating the key '%s' on path '%s' in mount '%s'", key, path, mount)
 
+	change := client.ChangeRecord{
+		ResourceType: client.ResourceKVSecret,
+		Mount:        mount,
+		Path:         fullPath,
+		Operation:    client.OperationUpdate,
+		Previous:     previous,
+		New:          secretSummary(&api.Secret{Data: secretData}, isV2),
+	}
+	if previous == nil {
+		change.Operation = client.OperationCreate
+	}
+
 	// Write out the version information if available as the AI may decide on a different approach if a version is provided
 	if versionInfo != nil && versionInfo.Data != nil {
 		successMsg = fmt.Sprintf("Successfully wrote version %v of the secret to path '%s' in mount '%s' with key '%s'", versionInfo.Data["version"], path, mount, key)
+		change.New["version"] = versionInfo.Data["version"]
+	}
+	if versionInfo != nil {
+		change.VaultRequestID = versionInfo.RequestID
 	}
+	change.Message = successMsg
 
 	logger.WithFields(log.Fields{
 		"mount": mount,
@@ -172,5 +190,5 @@ func writeSecretHandler(ctx context.Context, req mcp.CallToolRequest, logger *lo
 		"v2":    isV2,
 	}).Info("Successfully wrote secret")
 
-	return mcp.NewToolResultText(successMsg), nil
+	return client.NewChangeResult(ctx, req, change), nil
 }
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -130,4 +131,22 @@ func TestWriteSecretHandler_ExistingSecretV2(t *testing.T) {
 	require.True(t, ok, "written body should have 'data' wrapper for KV v2")
 	assert.Equal(t, "existing-value", dataField["existing-key"], "existing key should be preserved")
 	assert.Equal(t, "new-value", dataField["new-key"], "new key should be added")
+
+	// The result describes the change without the secret values
+	text := getResultText(result)
+	assert.NotContains(t, text, "existing-value")
+	assert.NotContains(t, text, "new-value")
+
+	var change client.ChangeRecord
+	require.NoError(t, json.Unmarshal([]byte(text), &change))
+	assert.Equal(t, client.OperationUpdate, change.Operation)
+	assert.Equal(t, client.ResourceKVSecret, change.ResourceType)
+	assert.Equal(t, "write_secret", change.Tool)
+	assert.Equal(t, []interface{}{"existing-key"}, change.Previous["keys"])
+	assert.Equal(t, float64(1), change.Previous["version"])
+	assert.Equal(t, []interface{}{"existing-key", "new-key"}, change.New["keys"])
+
+	changes := client.SessionChanges("test-" + t.Name())
+	require.Len(t, changes, 1)
+	assert.Equal(t, change.ID, changes[0].ID)
 }
@@ -0,0 +1,130 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package mongodbatlas
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// ConfigureMongoDBAtlas creates a tool for enabling and configuring the MongoDB Atlas secrets engine
+func ConfigureMongoDBAtlas(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("configure_mongodb_atlas",
+			mcp.WithDescription(`Enable the MongoDB Atlas secrets engine in Vault if needed and configure the programmatic API key that Vault uses to create API keys in Atlas. The key needs the Organization Owner role, or Project Owner for keys scoped to a project.
+## Generating Atlas API keys
+  - Configure the mount using this tool.
+  - Create a role with the 'create_mongodb_atlas_role' tool, which scopes the generated keys to an organization or a project.
+  - Generate API keys with the 'generate_mongodb_atlas_key' tool. Keys are deleted from Atlas when their lease expires.
+The private key is sent to Vault only and is never returned.`),
+			mcp.WithString("mount",
+				mcp.DefaultString("mongodbatlas"),
+				mcp.Description("The path of the MongoDB Atlas secrets engine, enabled when it does not exist. Defaults to 'mongodbatlas'."),
+			),
+			mcp.WithString("public_key",
+				mcp.Required(),
+				mcp.Description("The public key of the programmatic API key Vault uses."),
+			),
+			mcp.WithString("private_key",
+				mcp.Required(),
+				mcp.Description("The private key of the programmatic API key Vault uses."),
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return configureMongoDBAtlasHandler(ctx, req, logger)
+		},
+	}
+}
+
+func configureMongoDBAtlasHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling configure_mongodb_atlas request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, _ := args["mount"].(string)
+	mount = strings.Trim(mount, "/")
+	if mount == "" {
+		mount = mountType
+	}
+
+	publicKey, ok := args["public_key"].(string)
+	if !ok || publicKey == "" {
+		return mcp.NewToolResultError("Missing or invalid 'public_key' parameter"), nil
+	}
+
+	privateKey, ok := args["private_key"].(string)
+	if !ok || privateKey == "" {
+		return mcp.NewToolResultError("Missing or invalid 'private_key' parameter"), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":      mount,
+		"public_key": publicKey,
+	}).Debug("Configuring MongoDB Atlas secrets engine with parameters")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	enabled, err := enableAtlasMount(vault, mount)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if enabled {
+		recordMountEnabled(ctx, req, mount)
+	}
+
+	fullPath := fmt.Sprintf("%s/config", mount)
+
+	// Vault returns the public key of the configuration only
+	previous, _ := vault.Logical().Read(fullPath)
+
+	written, err := vault.Logical().Write(fullPath, map[string]interface{}{
+		"public_key":  publicKey,
+		"private_key": privateKey,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to write to path '%s': %v", fullPath, err)), nil
+	}
+
+	successMsg := fmt.Sprintf("Successfully configured the MongoDB Atlas secrets engine at path '%s' with public key '%s'.", mount, publicKey)
+	if enabled {
+		successMsg = fmt.Sprintf("Successfully enabled and configured the MongoDB Atlas secrets engine at path '%s' with public key '%s'.", mount, publicKey)
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":   mount,
+		"enabled": enabled,
+	}).Info("Successfully configured MongoDB Atlas secrets engine")
+
+	change := client.ChangeRecord{
+		ResourceType: client.ResourceMongoDBAtlasConfig,
+		Mount:        mount,
+		Path:         fullPath,
+		Operation:    client.OperationCreate,
+		New:          map[string]any{"public_key": publicKey},
+		Message:      successMsg,
+	}
+	if previous != nil && previous.Data != nil {
+		change.Operation = client.OperationUpdate
+		change.Previous = map[string]any{"public_key": previous.Data["public_key"]}
+	}
+	if written != nil {
+		change.VaultRequestID = written.RequestID
+	}
+	return client.NewChangeResult(ctx, req, change), nil
+}
@@ -0,0 +1,183 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package mongodbatlas
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// CreateMongoDBAtlasRole creates a tool for creating roles of the MongoDB Atlas secrets engine
+func CreateMongoDBAtlasRole(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("create_mongodb_atlas_role",
+			mcp.WithDescription(`Create or update a role of the MongoDB Atlas secrets engine, which defines the scope and Atlas roles of the programmatic API keys generated through it.
+  - Set 'organization_id' to generate organization keys, with organization roles such as 'ORG_READ_ONLY' or 'ORG_MEMBER'.
+  - Set 'project_id' to generate project keys, with project roles such as 'GROUP_READ_ONLY' or 'GROUP_CLUSTER_MANAGER'.
+Restrict the keys to the addresses of the clients with 'ip_addresses' or 'cidr_blocks' whenever possible.`),
+			mcp.WithString("mount",
+				mcp.DefaultString("mongodbatlas"),
+				mcp.Description("The mount of the MongoDB Atlas secrets engine. Defaults to 'mongodbatlas'."),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("The name of the role, for example 'analytics-readonly'."),
+			),
+			mcp.WithString("organization_id",
+				mcp.Description("The ID of the Atlas organization of organization keys."),
+			),
+			mcp.WithString("project_id",
+				mcp.Description("The ID of the Atlas project of project keys."),
+			),
+			mcp.WithString("roles",
+				mcp.Required(),
+				mcp.Description("A comma-separated list of the Atlas roles of the keys, for example 'GROUP_READ_ONLY' or 'ORG_MEMBER,ORG_BILLING_ADMIN'."),
+			),
+			mcp.WithString("project_roles",
+				mcp.Description("A comma-separated list of the roles of organization keys in the project set in 'project_id', when both are set."),
+			),
+			mcp.WithString("ip_addresses",
+				mcp.Description("A comma-separated list of the IP addresses that can use the keys."),
+			),
+			mcp.WithString("cidr_blocks",
+				mcp.Description("A comma-separated list of the CIDR blocks that can use the keys."),
+			),
+			mcp.WithString("ttl",
+				mcp.DefaultString("1h"),
+				mcp.Description("The lease TTL of the keys. Defaults to '1h'."),
+			),
+			mcp.WithString("max_ttl",
+				mcp.DefaultString("24h"),
+				mcp.Description("The maximum lease TTL of the keys. Defaults to '24h'."),
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return createMongoDBAtlasRoleHandler(ctx, req, logger)
+		},
+	}
+}
+
+func createMongoDBAtlasRoleHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling create_mongodb_atlas_role request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	name, ok := args["name"].(string)
+	if !ok || name == "" {
+		return mcp.NewToolResultError("Missing or invalid 'name' parameter"), nil
+	}
+
+	organizationID := req.GetString("organization_id", "")
+	projectID := req.GetString("project_id", "")
+	if organizationID == "" && projectID == "" {
+		return mcp.NewToolResultError("Missing 'organization_id' or 'project_id' parameter, the keys of a role are scoped to an organization or a project"), nil
+	}
+
+	roles := utils.SplitList(req.GetString("roles", ""))
+	if len(roles) == 0 {
+		return mcp.NewToolResultError("Missing or invalid 'roles' parameter"), nil
+	}
+	// Atlas rejects organization roles on project keys and project roles on organization keys
+	for _, role := range roles {
+		switch {
+		case strings.HasPrefix(role, "ORG_") && organizationID == "":
+			return mcp.NewToolResultError(fmt.Sprintf("Role '%s' is an organization role, which needs 'organization_id'", role)), nil
+		case strings.HasPrefix(role, "GROUP_") && organizationID != "":
+			return mcp.NewToolResultError(fmt.Sprintf("Role '%s' is a project role, set it in 'project_roles' for organization keys or create project keys without 'organization_id'", role)), nil
+		}
+	}
+
+	roleData := map[string]interface{}{
+		"roles":   roles,
+		"ttl":     req.GetString("ttl", "1h"),
+		"max_ttl": req.GetString("max_ttl", "24h"),
+	}
+	if organizationID != "" {
+		roleData["organization_id"] = organizationID
+	}
+	if projectID != "" {
+		roleData["project_id"] = projectID
+	}
+	if projectRoles := utils.SplitList(req.GetString("project_roles", "")); len(projectRoles) > 0 {
+		if organizationID == "" || projectID == "" {
+			return mcp.NewToolResultError("'project_roles' needs both 'organization_id' and 'project_id'"), nil
+		}
+		roleData["project_roles"] = projectRoles
+	}
+	if ipAddresses := utils.SplitList(req.GetString("ip_addresses", "")); len(ipAddresses) > 0 {
+		roleData["ip_addresses"] = ipAddresses
+	}
+	if cidrBlocks := utils.SplitList(req.GetString("cidr_blocks", "")); len(cidrBlocks) > 0 {
+		roleData["cidr_blocks"] = cidrBlocks
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":           mount,
+		"name":            name,
+		"organization_id": organizationID,
+		"project_id":      projectID,
+		"roles":           roles,
+	}).Debug("Creating MongoDB Atlas role with parameters")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	if err := checkAtlasMount(vault, mount); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	fullPath := fmt.Sprintf("%s/roles/%s", mount, name)
+
+	// Keep the configuration that is replaced in the change record
+	previous, _ := vault.Logical().Read(fullPath)
+
+	written, err := vault.Logical().Write(fullPath, roleData)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to write to path '%s': %v", fullPath, err)), nil
+	}
+
+	successMsg := fmt.Sprintf("Successfully created MongoDB Atlas role with name '%s' on mount '%s'.", name, mount)
+
+	logger.WithFields(log.Fields{
+		"mount": mount,
+		"name":  name,
+	}).Info("Successfully created MongoDB Atlas role")
+
+	change := client.ChangeRecord{
+		ResourceType: client.ResourceMongoDBAtlasRole,
+		Mount:        mount,
+		Path:         fullPath,
+		Operation:    client.OperationCreate,
+		New:          roleData,
+		Message:      successMsg,
+	}
+	if previous != nil {
+		change.Operation = client.OperationUpdate
+		change.Previous = previous.Data
+	}
+	if written != nil {
+		change.VaultRequestID = written.RequestID
+	}
+	return client.NewChangeResult(ctx, req, change), nil
+}
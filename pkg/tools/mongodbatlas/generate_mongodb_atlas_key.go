@@ -0,0 +1,111 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package mongodbatlas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// AtlasAPIKey is a programmatic API key generated by the MongoDB Atlas secrets engine
+type AtlasAPIKey struct {
+	PublicKey     string `json:"public_key"`
+	PrivateKey    string `json:"private_key"`
+	Description   string `json:"description,omitempty"`
+	LeaseID       string `json:"lease_id"`       // Lease of the key, the key is deleted from Atlas when it expires
+	LeaseDuration int    `json:"lease_duration"` // Seconds until the lease expires
+	Renewable     bool   `json:"renewable"`
+}
+
+// GenerateMongoDBAtlasKey creates a tool for generating programmatic API keys of MongoDB Atlas
+func GenerateMongoDBAtlasKey(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("generate_mongodb_atlas_key",
+			mcp.WithDescription("Generate a MongoDB Atlas programmatic API key with the scope and roles of a role of the MongoDB Atlas secrets engine. The key is deleted from Atlas when its lease expires. The private key is returned in the result, so only generate keys that are needed."),
+			mcp.WithString("mount",
+				mcp.DefaultString("mongodbatlas"),
+				mcp.Description("The mount of the MongoDB Atlas secrets engine. Defaults to 'mongodbatlas'."),
+			),
+			mcp.WithString("role",
+				mcp.Required(),
+				mcp.Description("The name of the role, created with 'create_mongodb_atlas_role'."),
+			),
+			schemas.Output("generate_mongodb_atlas_key"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return generateMongoDBAtlasKeyHandler(ctx, req, logger)
+		},
+	}
+}
+
+func generateMongoDBAtlasKeyHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling generate_mongodb_atlas_key request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	role, ok := args["role"].(string)
+	if !ok || role == "" {
+		return mcp.NewToolResultError("Missing or invalid 'role' parameter"), nil
+	}
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	if err := checkAtlasMount(vault, mount); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	fullPath := fmt.Sprintf("%s/creds/%s", mount, role)
+	secret, err := vault.Logical().Read(fullPath)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to read path '%s': %v", fullPath, err)), nil
+	}
+	if secret == nil || secret.Data == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("role '%s' does not exist on mount '%s', you should use 'create_mongodb_atlas_role' to create it.", role, mount)), nil
+	}
+
+	key := AtlasAPIKey{
+		LeaseID:       secret.LeaseID,
+		LeaseDuration: secret.LeaseDuration,
+		Renewable:     secret.Renewable,
+	}
+	key.PublicKey, _ = secret.Data["public_key"].(string)
+	key.PrivateKey, _ = secret.Data["private_key"].(string)
+	key.Description, _ = secret.Data["description"].(string)
+
+	jsonData, err := json.Marshal(key)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal API key to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":      mount,
+		"role":       role,
+		"public_key": key.PublicKey,
+	}).Info("Successfully generated MongoDB Atlas API key")
+
+	return mcp.NewToolResultStructured(key, string(jsonData)), nil
+}
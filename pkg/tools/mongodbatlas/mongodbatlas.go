@@ -0,0 +1,67 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package mongodbatlas
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// mountType is the type of the mounts of the MongoDB Atlas secrets engine
+const mountType = "mongodbatlas"
+
+// checkAtlasMount returns an error when the mount does not exist or is not a MongoDB Atlas mount
+func checkAtlasMount(vault *api.Client, mount string) error {
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		return fmt.Errorf("failed to list mounts: %v", err)
+	}
+
+	existing, ok := mounts[mount+"/"]
+	if !ok {
+		return fmt.Errorf("mount path '%s' does not exist, you should use 'configure_mongodb_atlas' if you want to enable the MongoDB Atlas secrets engine on this mount.", mount)
+	}
+	if existing.Type != mountType {
+		return fmt.Errorf("mount path '%s' is a '%s' mount, not a MongoDB Atlas mount", mount, existing.Type)
+	}
+	return nil
+}
+
+// enableAtlasMount enables the MongoDB Atlas secrets engine at mount, unless it is already enabled.
+// It returns true when the mount was created.
+func enableAtlasMount(vault *api.Client, mount string) (bool, error) {
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		return false, fmt.Errorf("failed to list mounts: %v", err)
+	}
+
+	if existing, ok := mounts[mount+"/"]; ok {
+		if existing.Type != mountType {
+			return false, fmt.Errorf("mount path '%s' is a '%s' mount, not a MongoDB Atlas mount", mount, existing.Type)
+		}
+		return false, nil
+	}
+
+	if err := vault.Sys().Mount(mount, &api.MountInput{Type: mountType}); err != nil {
+		return false, fmt.Errorf("failed to enable the MongoDB Atlas secrets engine at path '%s': %v", mount, err)
+	}
+	return true, nil
+}
+
+// recordMountEnabled records a mount created by enableAtlasMount in the change journal of the session
+func recordMountEnabled(ctx context.Context, req mcp.CallToolRequest, mount string) {
+	client.RecordChange(ctx, client.ChangeRecord{
+		Tool:         req.Params.Name,
+		ResourceType: client.ResourceMount,
+		Mount:        mount,
+		Path:         "sys/mounts/" + mount,
+		Operation:    client.OperationCreate,
+		New:          map[string]any{"type": mountType},
+		Message:      fmt.Sprintf("Enabled the MongoDB Atlas secrets engine at path '%s'.", mount),
+	})
+}
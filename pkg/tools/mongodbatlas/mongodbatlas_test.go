@@ -0,0 +1,129 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package mongodbatlas
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSession struct {
+	id string
+}
+
+func (s testSession) SessionID() string                                   { return s.id }
+func (s testSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s testSession) Initialize()                                         {}
+func (s testSession) Initialized() bool                                   { return true }
+
+func TestMongoDBAtlasTools(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	mounts := map[string]interface{}{"secret/": map[string]interface{}{"type": "kv"}}
+	written := map[string]map[string]interface{}{}
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/v1/sys/mounts":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": mounts})
+		case r.URL.Path == "/v1/sys/mounts/mongodbatlas" && r.Method == http.MethodPost:
+			mounts["mongodbatlas/"] = map[string]interface{}{"type": mountType}
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPut || r.Method == http.MethodPost:
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			written[r.URL.Path] = body
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/v1/mongodbatlas/creds/analytics":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"lease_id":       "mongodbatlas/creds/analytics/xyz",
+				"lease_duration": 3600,
+				"renewable":      true,
+				"data":           map[string]interface{}{"public_key": "abcdefgh", "private_key": "0000-1111", "description": "vault-analytics-123"},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockVault.Close()
+
+	sessionID := "test-mongodbatlas"
+	_, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	defer client.DeleteVaultClient(sessionID)
+
+	ctx := server.NewMCPServer("test", "1.0").WithContext(context.Background(), testSession{id: sessionID})
+	call := func(tool server.ServerTool, args map[string]interface{}) *mcp.CallToolResult {
+		result, err := tool.Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("roles need a configured mount", func(t *testing.T) {
+		result := call(CreateMongoDBAtlasRole(logger), map[string]interface{}{"mount": "mongodbatlas", "name": "analytics", "project_id": "p1", "roles": "GROUP_READ_ONLY"})
+		assert.True(t, result.IsError)
+	})
+
+	t.Run("configure", func(t *testing.T) {
+		result := call(ConfigureMongoDBAtlas(logger), map[string]interface{}{"public_key": "vaultpub", "private_key": "vault-private"})
+		require.False(t, result.IsError, result.Content)
+		assert.Contains(t, mounts, "mongodbatlas/")
+		assert.Equal(t, map[string]interface{}{"public_key": "vaultpub", "private_key": "vault-private"}, written["/v1/mongodbatlas/config"])
+		assert.NotContains(t, result.Content[0].(mcp.TextContent).Text, "vault-private", "the private key is not part of the change record")
+
+		changes := client.SessionChanges(sessionID)
+		require.Len(t, changes, 2)
+		assert.Equal(t, client.ResourceMount, changes[0].ResourceType)
+		assert.Equal(t, client.ResourceMongoDBAtlasConfig, changes[1].ResourceType)
+
+		assert.True(t, call(ConfigureMongoDBAtlas(logger), map[string]interface{}{"mount": "secret", "public_key": "a", "private_key": "b"}).IsError)
+	})
+
+	t.Run("create project role", func(t *testing.T) {
+		result := call(CreateMongoDBAtlasRole(logger), map[string]interface{}{
+			"mount": "mongodbatlas", "name": "analytics", "project_id": "p1", "roles": "GROUP_READ_ONLY", "cidr_blocks": "10.0.0.0/8",
+		})
+		require.False(t, result.IsError, result.Content)
+		role := written["/v1/mongodbatlas/roles/analytics"]
+		assert.Equal(t, "p1", role["project_id"])
+		assert.Equal(t, []interface{}{"GROUP_READ_ONLY"}, role["roles"])
+		assert.Equal(t, []interface{}{"10.0.0.0/8"}, role["cidr_blocks"])
+		assert.NotContains(t, role, "organization_id")
+	})
+
+	t.Run("invalid roles", func(t *testing.T) {
+		for name, args := range map[string]map[string]interface{}{
+			"no scope":                      {"mount": "mongodbatlas", "name": "r", "roles": "ORG_MEMBER"},
+			"no roles":                      {"mount": "mongodbatlas", "name": "r", "organization_id": "o1"},
+			"organization role on project":  {"mount": "mongodbatlas", "name": "r", "project_id": "p1", "roles": "ORG_MEMBER"},
+			"project role on organization":  {"mount": "mongodbatlas", "name": "r", "organization_id": "o1", "roles": "GROUP_OWNER"},
+			"project roles without project": {"mount": "mongodbatlas", "name": "r", "organization_id": "o1", "roles": "ORG_MEMBER", "project_roles": "GROUP_READ_ONLY"},
+		} {
+			assert.True(t, call(CreateMongoDBAtlasRole(logger), args).IsError, name)
+		}
+	})
+
+	t.Run("generate key", func(t *testing.T) {
+		result := call(GenerateMongoDBAtlasKey(logger), map[string]interface{}{"mount": "mongodbatlas", "role": "analytics"})
+		require.False(t, result.IsError, result.Content)
+		var key AtlasAPIKey
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &key))
+		assert.Equal(t, "abcdefgh", key.PublicKey)
+		assert.Equal(t, "0000-1111", key.PrivateKey)
+		assert.Equal(t, "mongodbatlas/creds/analytics/xyz", key.LeaseID)
+
+		assert.True(t, call(GenerateMongoDBAtlasKey(logger), map[string]interface{}{"mount": "mongodbatlas", "role": "missing"}).IsError)
+	})
+}
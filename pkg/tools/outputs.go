@@ -0,0 +1,253 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+)
+
+// ToolOutput is a tool with a structured result, whose output schema is generated from its result type
+type ToolOutput struct {
+	Tool     string
+	Type     reflect.Type
+	Examples []ToolExample
+}
+
+// ToolExample is an example call of a tool and its result
+type ToolExample struct {
+	Arguments map[string]any `json:"arguments"`
+	Result    any            `json:"result"`
+}
+
+// example is an example call of a tool returning T, so that examples stop compiling when the type changes
+type example[T any] struct {
+	Arguments map[string]any
+	Result    T
+}
+
+func output[T any](tool string, examples ...example[T]) ToolOutput {
+	out := ToolOutput{Tool: tool, Type: reflect.TypeFor[T]()}
+	for _, e := range examples {
+		out.Examples = append(out.Examples, ToolExample{Arguments: e.Arguments, Result: e.Result})
+	}
+	return out
+}
+
+// GenerateOutputSchema generates the definition file of a tool, holding the JSON schema of its result type
+// and its examples. Properties are described by the comments of the struct fields, and every example result
+// must validate against the schema.
+func GenerateOutputSchema(out ToolOutput) ([]byte, error) {
+	schema, err := jsonschema.ForType(out.Type, &jsonschema.ForOptions{IgnoreInvalidTypes: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the schema of %s: %w", out.Tool, err)
+	}
+	if schema.Type != "object" {
+		return nil, fmt.Errorf("the result of %s is not an object", out.Tool)
+	}
+
+	comments := fieldComments{}
+	if err := comments.describe(schema, out.Type); err != nil {
+		return nil, fmt.Errorf("failed to describe the schema of %s: %w", out.Tool, err)
+	}
+
+	resolved, err := schema.Resolve(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve the schema of %s: %w", out.Tool, err)
+	}
+	for i, e := range out.Examples {
+		// Results are validated in the form clients receive them
+		data, err := json.Marshal(e.Result)
+		if err != nil {
+			return nil, err
+		}
+		var instance any
+		if err := json.Unmarshal(data, &instance); err != nil {
+			return nil, err
+		}
+		if err := resolved.Validate(instance); err != nil {
+			return nil, fmt.Errorf("example %d of %s does not match its schema: %w", i+1, out.Tool, err)
+		}
+	}
+
+	definition := struct {
+		OutputSchema *jsonschema.Schema `json:"outputSchema"`
+		Examples     []ToolExample      `json:"examples"`
+	}{OutputSchema: schema, Examples: out.Examples}
+
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(definition); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// WriteOutputSchemas writes the definition files of ToolOutputs to dir and removes the files of tools that
+// are no longer listed
+func WriteOutputSchemas(dir string) error {
+	written := map[string]bool{}
+	for _, out := range ToolOutputs {
+		data, err := GenerateOutputSchema(out)
+		if err != nil {
+			return err
+		}
+		name := schemas.FileName(out.Tool)
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			return err
+		}
+		written[name] = true
+	}
+
+	stale, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, path := range stale {
+		if !written[filepath.Base(path)] {
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fieldComments holds the comments of the struct fields of the parsed packages, by package path, type and
+// field name
+type fieldComments map[string]map[string]map[string]string
+
+// describe sets the descriptions of the properties of the schema generated for t, and allows null for maps
+// as it is already allowed for slices, since both encode nil as null
+func (c fieldComments) describe(schema *jsonschema.Schema, t reflect.Type) error {
+	if schema == nil {
+		return nil
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		return c.describe(schema.Items, t.Elem())
+	case reflect.Map:
+		if schema.Type == "object" {
+			schema.Types = []string{"null", "object"}
+			schema.Type = ""
+		}
+		return c.describe(schema.AdditionalProperties, t.Elem())
+	case reflect.Struct:
+		if t == reflect.TypeFor[time.Time]() {
+			return nil
+		}
+		comments, err := c.lookup(t)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			// The fields of embedded structs are properties of the embedding struct
+			if field.Anonymous {
+				if err := c.describe(schema, field.Type); err != nil {
+					return err
+				}
+				continue
+			}
+			name := jsonName(field)
+			property := schema.Properties[name]
+			if property == nil {
+				continue
+			}
+			if property.Description == "" {
+				property.Description = comments[field.Name]
+			}
+			if err := c.describe(property, field.Type); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// lookup returns the comments of the fields of a struct type, parsing the sources of its package once
+func (c fieldComments) lookup(t reflect.Type) (map[string]string, error) {
+	pkgPath := t.PkgPath()
+	if pkgPath == "" {
+		return nil, nil
+	}
+	if types, ok := c[pkgPath]; ok {
+		return types[t.Name()], nil
+	}
+
+	pkg, err := build.Import(pkgPath, ".", build.FindOnly)
+	if err != nil {
+		return nil, err
+	}
+	names, err := filepath.Glob(filepath.Join(pkg.Dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	types := map[string]map[string]string{}
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, name, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		ast.Inspect(file, func(node ast.Node) bool {
+			spec, ok := node.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			structType, ok := spec.Type.(*ast.StructType)
+			if !ok {
+				return true
+			}
+			fields := map[string]string{}
+			for _, field := range structType.Fields.List {
+				text := field.Comment.Text()
+				if text == "" {
+					text = field.Doc.Text()
+				}
+				for _, ident := range field.Names {
+					fields[ident.Name] = strings.Join(strings.Fields(text), " ")
+				}
+			}
+			types[spec.Name.Name] = fields
+			return true
+		})
+	}
+	c[pkgPath] = types
+	return types[t.Name()], nil
+}
+
+// jsonName returns the name of the property encoding a struct field
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
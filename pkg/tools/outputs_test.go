@@ -0,0 +1,129 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package tools
+
+import (
+	"encoding/json"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/kv"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/sys"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputSchemasUpToDate(t *testing.T) {
+	listed := map[string]bool{}
+	for _, out := range ToolOutputs {
+		listed[schemas.FileName(out.Tool)] = true
+
+		generated, err := GenerateOutputSchema(out)
+		require.NoError(t, err, out.Tool)
+		file, err := schemas.File(out.Tool)
+		require.NoError(t, err, "no output schema for %s, run 'go generate ./pkg/tools/schemas'", out.Tool)
+		assert.Equal(t, string(generated), string(file), "the output schema of %s is stale, run 'go generate ./pkg/tools/schemas'", out.Tool)
+	}
+
+	files, err := filepath.Glob(filepath.Join("schemas", "*.json"))
+	require.NoError(t, err)
+	for _, file := range files {
+		assert.True(t, listed[filepath.Base(file)], "%s belongs to no tool, run 'go generate ./pkg/tools/schemas'", file)
+	}
+}
+
+func TestGenerateOutputSchema(t *testing.T) {
+	data, err := GenerateOutputSchema(output("generate_test", example[sys.RawResponse]{
+		Arguments: map[string]any{"path": "sys/health"},
+		Result:    sys.RawResponse{Method: "read", Path: "sys/health"},
+	}))
+	require.NoError(t, err)
+
+	var definition schemas.Definition
+	require.NoError(t, json.Unmarshal(data, &definition))
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(definition.OutputSchema, &schema))
+	assert.Equal(t, "object", schema["type"])
+	assert.Equal(t, []any{"method", "path"}, schema["required"])
+	properties := schema["properties"].(map[string]any)
+	assert.Equal(t, []any{"null", "object"}, properties["data"].(map[string]any)["type"], "nil maps encode as null")
+	require.Len(t, definition.Examples, 1)
+
+	t.Run("descriptions from field comments", func(t *testing.T) {
+		data, err := GenerateOutputSchema(output[sys.TidyResult]("tidy_test"))
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(data, &definition))
+		assert.Contains(t, string(definition.OutputSchema), `"description"`)
+	})
+
+	t.Run("examples must match the schema", func(t *testing.T) {
+		_, err := GenerateOutputSchema(ToolOutput{Tool: "invalid_test", Type: ToolOutputs[0].Type, Examples: []ToolExample{{Result: map[string]any{"unknown": 1}}}})
+		assert.ErrorContains(t, err, "does not match its schema")
+	})
+
+	t.Run("results must be objects", func(t *testing.T) {
+		_, err := GenerateOutputSchema(output[[]sys.Mount]("list_test"))
+		assert.Error(t, err)
+	})
+}
+
+func TestToolOutputSchemas(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	t.Setenv(EnableAdminToolsEnv, "true")
+	t.Setenv(sys.SecretEngineAllowlistEnv, "nomad=write")
+	t.Setenv(sys.SysEndpointAllowlistEnv, "write sys/leases/lookup")
+	t.Setenv(kv.BackupDirEnv, t.TempDir())
+	t.Setenv(client.ApprovalRequiredEnv, "delete_mount")
+
+	hcServer := server.NewMCPServer("test", "1.0.0")
+	InitTools(hcServer, logger)
+
+	for _, out := range ToolOutputs {
+		tool := hcServer.GetTool(out.Tool)
+		require.NotNil(t, tool, out.Tool)
+		assert.NotNil(t, tool.Tool.RawOutputSchema, "%s has no output schema", out.Tool)
+		assert.Contains(t, tool.Tool.Description, "Example arguments:", out.Tool)
+		require.NotNil(t, tool.Tool.Meta, out.Tool)
+		assert.NotEmpty(t, tool.Tool.Meta.AdditionalFields["examples"], out.Tool)
+
+		// The examples call the tool with its parameters
+		for _, e := range out.Examples {
+			for name := range e.Arguments {
+				assert.Contains(t, tool.Tool.InputSchema.Properties, name, "example of %s", out.Tool)
+			}
+			for _, name := range tool.Tool.InputSchema.Required {
+				assert.Contains(t, e.Arguments, name, "example of %s", out.Tool)
+			}
+		}
+	}
+
+	t.Run("tools queued for approval have no output schema", func(t *testing.T) {
+		t.Setenv(client.ApprovalRequiredEnv, "destructive")
+		hcServer := server.NewMCPServer("test", "1.0.0")
+		InitTools(hcServer, logger)
+
+		for _, out := range ToolOutputs {
+			tool := hcServer.GetTool(out.Tool)
+			require.NotNil(t, tool, out.Tool)
+			queued := client.LoadApprovalConfigFromEnv().Requires(tool.Tool)
+			assert.Equal(t, !queued, tool.Tool.RawOutputSchema != nil, out.Tool)
+		}
+		assert.Nil(t, hcServer.GetTool("call_secret_engine").Tool.RawOutputSchema)
+	})
+
+	t.Run("tools without a result type have none", func(t *testing.T) {
+		_, ok := schemas.Lookup("list_mounts")
+		assert.False(t, ok)
+		_, err := schemas.File("list_mounts")
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+		assert.Nil(t, hcServer.GetTool("list_mounts").Tool.RawOutputSchema)
+	})
+}
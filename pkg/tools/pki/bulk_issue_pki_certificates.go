@@ -0,0 +1,233 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package pki
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// MaxBulkCertificates is the maximum number of certificates issued by one bulk request
+	MaxBulkCertificates = 100
+	// DefaultBulkConcurrency is the default number of certificates issued at the same time
+	DefaultBulkConcurrency = 4
+)
+
+type BulkCertificateResult struct {
+	CommonName   string `json:"common_name"`             // Common name requested for the entry
+	SerialNumber string `json:"serial_number,omitempty"` // Serial number of the issued certificate
+	Expiration   int64  `json:"expiration,omitempty"`    // Expiry of the certificate as a Unix timestamp
+	Certificate  string `json:"certificate,omitempty"`   // Issued certificate
+	PrivateKey   string `json:"private_key,omitempty"`   // Private key of the issued certificate
+	IssuingCA    string `json:"issuing_ca,omitempty"`    // Certificate of the issuer
+	Error        string `json:"error,omitempty"`         // Error returned for this entry, if any
+}
+
+type BulkIssueReport struct {
+	Issued  int                      `json:"issued"`  // Number of certificates issued
+	Failed  int                      `json:"failed"`  // Number of entries that failed
+	Results []*BulkCertificateResult `json:"results"` // Results in the order of the requested entries
+}
+
+// BulkIssuePkiCertificates creates a tool for issuing several pki certificates in one call
+func BulkIssuePkiCertificates(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("bulk_issue_pki_certificates",
+			mcp.WithDescription("Issue several PKI certificates from the same role in one call. Entries are issued concurrently and a result is reported for each entry, so one failing entry does not stop the others."),
+			mcp.WithString("mount",
+				mcp.DefaultString("pki"),
+				mcp.Description("The mount where the certificates will be issued. Defaults to 'pki'."),
+			),
+			mcp.WithString("role_name",
+				mcp.Required(),
+				mcp.Description("The name of the role used to issue every certificate. This name must correspond to a role_name in the data returned from the list_pki_roles function."),
+			),
+			mcp.WithArray("certificates",
+				mcp.Required(),
+				mcp.MinItems(1),
+				mcp.MaxItems(MaxBulkCertificates),
+				mcp.Description("The certificates to issue."),
+				mcp.Items(map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"common_name": map[string]any{"type": "string", "description": "Common Name (CN) of the certificate."},
+						"alt_names":   map[string]any{"type": "string", "description": "Optional comma separated DNS or email subject alternative names."},
+						"ip_sans":     map[string]any{"type": "string", "description": "Optional comma separated IP subject alternative names."},
+						"uri_sans":    map[string]any{"type": "string", "description": "Optional comma separated URI subject alternative names."},
+						"ttl":         map[string]any{"type": "string", "description": "Optional TTL of the certificate, such as '720h'."},
+					},
+					"required": []string{"common_name"},
+				}),
+			),
+			mcp.WithNumber("concurrency",
+				mcp.DefaultNumber(DefaultBulkConcurrency),
+				mcp.Description("Optional number of certificates issued at the same time. Defaults to 4."),
+			),
+			schemas.Output("bulk_issue_pki_certificates"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return bulkIssuePkiCertificatesHandler(ctx, req, logger)
+		},
+	}
+}
+
+func bulkIssuePkiCertificatesHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling bulk_issue_pki_certificates request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	roleName, ok := args["role_name"].(string)
+	if !ok || roleName == "" {
+		return mcp.NewToolResultError("Missing or invalid 'role_name' parameter"), nil
+	}
+
+	entries, ok := args["certificates"].([]interface{})
+	if !ok || len(entries) == 0 {
+		return mcp.NewToolResultError("Missing or invalid 'certificates' parameter"), nil
+	}
+	if len(entries) > MaxBulkCertificates {
+		return mcp.NewToolResultError(fmt.Sprintf("Too many certificates requested, at most %d can be issued in one call", MaxBulkCertificates)), nil
+	}
+
+	requests := make([]map[string]interface{}, len(entries))
+	for i, e := range entries {
+		entry, ok := e.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid entry %d in 'certificates', it must be an object", i)), nil
+		}
+		commonName, ok := entry["common_name"].(string)
+		if !ok || commonName == "" {
+			return mcp.NewToolResultError(fmt.Sprintf("Missing or invalid 'common_name' in entry %d of 'certificates'", i)), nil
+		}
+		requestData := map[string]interface{}{
+			"common_name": commonName,
+		}
+		for _, field := range []string{"alt_names", "ip_sans", "uri_sans", "ttl"} {
+			if value, ok := entry[field].(string); ok && value != "" {
+				requestData[field] = value
+			}
+		}
+		requests[i] = requestData
+	}
+
+	concurrency := DefaultBulkConcurrency
+	if c, ok := args["concurrency"].(float64); ok && c >= 1 {
+		concurrency = int(c)
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":       mount,
+		"role_name":   roleName,
+		"count":       len(requests),
+		"concurrency": concurrency,
+	}).Debug("Issuing certificates with parameters")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list mounts: %v", err)), nil
+	}
+
+	// Check if the mount exists
+	if _, ok := mounts[mount+"/"]; !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("mount path '%s' does not exist, you should use 'enable_pki' if you want enable pki on this mount.", mount)), nil
+	}
+
+	fullPath := fmt.Sprintf("%s/issue/%s", mount, roleName)
+
+	report := &BulkIssueReport{
+		Results: make([]*BulkCertificateResult, len(requests)),
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+
+	for i, requestData := range requests {
+		wg.Add(1)
+		go func(i int, requestData map[string]interface{}) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			report.Results[i] = issueBulkCertificate(ctx, vault, fullPath, requestData)
+		}(i, requestData)
+	}
+	wg.Wait()
+
+	for _, result := range report.Results {
+		if result.Error != "" {
+			report.Failed++
+		} else {
+			report.Issued++
+		}
+	}
+
+	// Marshal to JSON
+	jsonData, err := json.Marshal(report)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal bulk issue report to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"role_name": roleName,
+		"issued":    report.Issued,
+		"failed":    report.Failed,
+	}).Info("Successfully processed bulk certificate issuance")
+
+	return mcp.NewToolResultStructured(report, string(jsonData)), nil
+}
+
+// issueBulkCertificate issues a single certificate and records the outcome
+func issueBulkCertificate(ctx context.Context, vault *api.Client, fullPath string, requestData map[string]interface{}) *BulkCertificateResult {
+	result := &BulkCertificateResult{
+		CommonName: requestData["common_name"].(string),
+	}
+
+	secret, err := vault.Logical().WriteWithContext(ctx, fullPath, requestData)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if secret == nil || secret.Data == nil {
+		result.Error = "issuing the certificate returned no data"
+		return result
+	}
+
+	result.SerialNumber, _ = secret.Data["serial_number"].(string)
+	result.Certificate, _ = secret.Data["certificate"].(string)
+	result.PrivateKey, _ = secret.Data["private_key"].(string)
+	result.IssuingCA, _ = secret.Data["issuing_ca"].(string)
+	if expiration, ok := secret.Data["expiration"].(json.Number); ok {
+		result.Expiration, _ = expiration.Int64()
+	}
+
+	return result
+}
@@ -146,7 +146,19 @@ func createPkiIssuerHandler(ctx context.Context, req mcp.CallToolRequest, logger
 
 	var successMsg string
 
+	newIssuer := map[string]any{
+		"common_name": commonName,
+		"issuer_name": issuerName,
+		"ttl":         ttl,
+		"root":        true,
+	}
+	if issuerID, ok := secret.Data["issuer_id"]; ok {
+		newIssuer["issuer_id"] = issuerID
+	}
+
 	if rootMount != "" && rootIssuer != "" {
+		newIssuer["root"] = false
+		newIssuer["root_mount"] = rootMount
 		csrData := secret.Data["csr"]
 
 		signData := map[string]interface{}{
@@ -191,9 +203,13 @@ func createPkiIssuerHandler(ctx context.Context, req mcp.CallToolRequest, logger
 		fullPath = fmt.Sprintf("%s/intermediate/set-signed", mount)
 
 		// Write the intermediate certificate
-		if _, err := vault.Logical().Write(fullPath, signedData); err != nil {
+		imported, err := vault.Logical().Write(fullPath, signedData)
+		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to set intermediate issuer '%s': %v", issuerName, err)), nil
 		}
+		if imported != nil && imported.Data["imported_issuers"] != nil {
+			newIssuer["imported_issuers"] = imported.Data["imported_issuers"]
+		}
 
 		successMsg = fmt.Sprintf("Successfully created pki intermediate issuer with name '%s' on mount '%s'. Certificate chain data: \n%s", issuerName, mount, certificateChainStr)
 
@@ -225,5 +241,12 @@ func createPkiIssuerHandler(ctx context.Context, req mcp.CallToolRequest, logger
 		"ttl":         ttl,
 	}).Info("Successfully created pki issuer")
 
-	return mcp.NewToolResultText(successMsg), nil
+	return client.NewChangeResult(ctx, req, client.ChangeRecord{
+		ResourceType: client.ResourcePKIIssuer,
+		Mount:        mount,
+		Path:         fmt.Sprintf("%s/issuer/%s", mount, issuerName),
+		Operation:    client.OperationCreate,
+		New:          newIssuer,
+		Message:      successMsg,
+	}), nil
 }
@@ -135,7 +135,10 @@ func createPkiRoleHandler(ctx context.Context, req mcp.CallToolRequest, logger *
 	}
 
 	// Write the role data to the specified path
-	_, err = vault.Logical().Write(fullPath, roleData)
+	// Keep the configuration of a role that is replaced in the change record, roles hold no secrets
+	previous, _ := vault.Logical().Read(fullPath)
+
+	written, err := vault.Logical().Write(fullPath, roleData)
 
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("failed to write to path '%s': %v", fullPath, err)), nil
@@ -148,5 +151,20 @@ func createPkiRoleHandler(ctx context.Context, req mcp.CallToolRequest, logger *
 		"max_ttl":   maxTTL,
 	}).Info("Successfully created pki role")
 
-	return mcp.NewToolResultText(successMsg), nil
+	change := client.ChangeRecord{
+		ResourceType: client.ResourcePKIRole,
+		Mount:        mount,
+		Path:         fullPath,
+		Operation:    client.OperationCreate,
+		New:          roleData,
+		Message:      successMsg,
+	}
+	if previous != nil {
+		change.Operation = client.OperationUpdate
+		change.Previous = previous.Data
+	}
+	if written != nil {
+		change.VaultRequestID = written.RequestID
+	}
+	return client.NewChangeResult(ctx, req, change), nil
 }
@@ -0,0 +1,298 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package pki
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+type CrossSignedIssuer struct {
+	IssuerID    string   `json:"issuer_id"`             // ID of the new cross-signed issuer
+	IssuerName  string   `json:"issuer_name,omitempty"` // Name of the new cross-signed issuer
+	KeyID       string   `json:"key_id"`                // ID of the key shared with the original issuer
+	SignedBy    string   `json:"signed_by"`             // Mount and issuer that signed the certificate
+	Certificate string   `json:"certificate"`           // Cross-signed certificate
+	CAChain     []string `json:"ca_chain,omitempty"`    // Chain built by Vault for the new issuer
+	Warnings    []string `json:"warnings,omitempty"`    // Warnings returned by Vault
+}
+
+// CrossSignPkiIssuer creates a tool for cross-signing a pki issuer with an issuer on another mount
+func CrossSignPkiIssuer(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("cross_sign_pki_issuer",
+			mcp.WithDescription("Cross-sign an existing PKI issuer with an issuer on another mount. Generates a CSR from the key of the existing issuer with the same subject, signs it with the other issuer, imports the signed certificate as a new issuer sharing the same key and returns the chain Vault builds for it. Use this when migrating to a new root without breaking certificates issued under the old one."),
+			mcp.WithString("mount",
+				mcp.DefaultString("pki"),
+				mcp.Description("The mount of the issuer to cross-sign. Defaults to 'pki'."),
+			),
+			mcp.WithString("issuer_ref",
+				mcp.Required(),
+				mcp.Description("Name or ID of the issuer to cross-sign, as returned by list_pki_issuers."),
+			),
+			mcp.WithString("signing_mount",
+				mcp.Required(),
+				mcp.Description("The mount of the issuer that will sign the certificate."),
+			),
+			mcp.WithString("signing_issuer_ref",
+				mcp.DefaultString("default"),
+				mcp.Description("Name or ID of the issuer on 'signing_mount' that will sign the certificate. Defaults to 'default'."),
+			),
+			mcp.WithString("new_issuer_name",
+				mcp.DefaultString(""),
+				mcp.Description("Optional name for the new cross-signed issuer."),
+			),
+			mcp.WithString("ttl",
+				mcp.DefaultString(""),
+				mcp.Description("Optional TTL of the cross-signed certificate, such as '8760h'. Defaults to the TTL configured on the signing mount."),
+			),
+			schemas.Output("cross_sign_pki_issuer"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return crossSignPkiIssuerHandler(ctx, req, logger)
+		},
+	}
+}
+
+func crossSignPkiIssuerHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling cross_sign_pki_issuer request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	issuerRef, ok := args["issuer_ref"].(string)
+	if !ok || issuerRef == "" {
+		return mcp.NewToolResultError("Missing or invalid 'issuer_ref' parameter"), nil
+	}
+
+	signingMount, ok := args["signing_mount"].(string)
+	signingMount = strings.Trim(signingMount, "/")
+	if !ok || signingMount == "" {
+		return mcp.NewToolResultError("Missing or invalid 'signing_mount' parameter"), nil
+	}
+	if signingMount == mount {
+		return mcp.NewToolResultError("'signing_mount' must be a different mount than 'mount'"), nil
+	}
+
+	signingIssuerRef, _ := args["signing_issuer_ref"].(string)
+	if signingIssuerRef == "" {
+		signingIssuerRef = "default"
+	}
+
+	newIssuerName, _ := args["new_issuer_name"].(string)
+	ttl, _ := args["ttl"].(string)
+
+	logger.WithFields(log.Fields{
+		"mount":              mount,
+		"issuer_ref":         issuerRef,
+		"signing_mount":      signingMount,
+		"signing_issuer_ref": signingIssuerRef,
+		"new_issuer_name":    newIssuerName,
+		"ttl":                ttl,
+	}).Debug("Cross-signing pki issuer with parameters")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list mounts: %v", err)), nil
+	}
+
+	// Check if both mounts exist
+	for _, m := range []string{mount, signingMount} {
+		if _, ok := mounts[m+"/"]; !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("mount path '%s' does not exist, you should use 'enable_pki' if you want enable pki on this mount.", m)), nil
+		}
+	}
+
+	// Step 1: read the existing issuer to find its key and subject
+	fullPath := fmt.Sprintf("%s/issuer/%s", mount, issuerRef)
+	issuer, err := vault.Logical().Read(fullPath)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to read path '%s': %v", fullPath, err)), nil
+	}
+	if issuer == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("No issuer found with reference '%s' in mount '%s'", issuerRef, mount)), nil
+	}
+
+	keyID, _ := issuer.Data["key_id"].(string)
+	if keyID == "" {
+		return mcp.NewToolResultError(fmt.Sprintf("issuer '%s' has no key in mount '%s' and cannot be cross-signed", issuerRef, mount)), nil
+	}
+
+	certificatePEM, _ := issuer.Data["certificate"].(string)
+	certificate, err := parseCertificatePEM(certificatePEM)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to parse the certificate of issuer '%s': %v", issuerRef, err)), nil
+	}
+
+	// Step 2: generate a CSR from the existing key with the same subject
+	csrData := subjectFields(certificate)
+	csrData["key_ref"] = keyID
+
+	fullPath = fmt.Sprintf("%s/intermediate/generate/existing", mount)
+	csr, err := vault.Logical().Write(fullPath, csrData)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to write to path '%s': %v", fullPath, err)), nil
+	}
+	if csr == nil || csr.Data["csr"] == nil {
+		return mcp.NewToolResultError("CSR generation returned no data"), nil
+	}
+
+	result := &CrossSignedIssuer{
+		KeyID:    keyID,
+		SignedBy: fmt.Sprintf("%s/issuer/%s", signingMount, signingIssuerRef),
+		Warnings: csr.Warnings,
+	}
+
+	// Step 3: sign the CSR with the other issuer, keeping the subject from the CSR
+	signData := map[string]interface{}{
+		"csr":            csr.Data["csr"],
+		"common_name":    certificate.Subject.CommonName,
+		"use_csr_values": true,
+		"format":         "pem",
+	}
+	if ttl != "" {
+		signData["ttl"] = ttl
+	}
+
+	fullPath = fmt.Sprintf("%s/issuer/%s/sign-intermediate", signingMount, signingIssuerRef)
+	signed, err := vault.Logical().Write(fullPath, signData)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to write to path '%s': %v", fullPath, err)), nil
+	}
+	if signed == nil || signed.Data["certificate"] == nil {
+		return mcp.NewToolResultError("Signing returned no certificate"), nil
+	}
+	result.Certificate, _ = signed.Data["certificate"].(string)
+	result.Warnings = append(result.Warnings, signed.Warnings...)
+
+	// Step 4: import the signed certificate and the signing CA so Vault can build the chain
+	bundle := result.Certificate
+	if issuingCA, ok := signed.Data["issuing_ca"].(string); ok && issuingCA != "" {
+		bundle = bundle + "\n" + issuingCA
+	}
+
+	fullPath = fmt.Sprintf("%s/issuers/import/cert", mount)
+	imported, err := vault.Logical().Write(fullPath, map[string]interface{}{
+		"pem_bundle": bundle,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to write to path '%s': %v", fullPath, err)), nil
+	}
+	if imported == nil {
+		return mcp.NewToolResultError("Import returned no data"), nil
+	}
+	result.Warnings = append(result.Warnings, imported.Warnings...)
+
+	// The import mapping links each imported issuer to its key, the cross-signed issuer uses the existing key
+	if mapping, ok := imported.Data["mapping"].(map[string]interface{}); ok {
+		for issuerID, mappedKey := range mapping {
+			if mappedKey == keyID {
+				result.IssuerID = issuerID
+			}
+		}
+	}
+	if result.IssuerID == "" {
+		return mcp.NewToolResultError(fmt.Sprintf("the cross-signed certificate was signed but no new issuer using key '%s' was imported, it may already exist in mount '%s'", keyID, mount)), nil
+	}
+
+	// Step 5: name the new issuer and read back the chain Vault built for it
+	fullPath = fmt.Sprintf("%s/issuer/%s", mount, result.IssuerID)
+	if newIssuerName != "" {
+		if _, err := vault.Logical().JSONMergePatch(ctx, fullPath, map[string]interface{}{"issuer_name": newIssuerName}); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("unable to name the new issuer '%s': %v", newIssuerName, err))
+		}
+	}
+	if newIssuer, err := vault.Logical().Read(fullPath); err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("unable to read the new issuer: %v", err))
+	} else if newIssuer != nil {
+		result.IssuerName, _ = newIssuer.Data["issuer_name"].(string)
+		result.CAChain = toStringSlice(newIssuer.Data["ca_chain"])
+	}
+
+	client.RecordChange(ctx, client.ChangeRecord{
+		Tool:           req.Params.Name,
+		ResourceType:   client.ResourcePKIIssuer,
+		Mount:          mount,
+		Path:           fullPath,
+		Operation:      client.OperationCreate,
+		New:            map[string]any{"issuer_id": result.IssuerID, "issuer_name": result.IssuerName, "key_id": result.KeyID, "signed_by": result.SignedBy},
+		VaultRequestID: imported.RequestID,
+		Message:        fmt.Sprintf("Cross-signed issuer '%s' on mount '%s' with %s", issuerRef, mount, result.SignedBy),
+	})
+
+	// Marshal to JSON
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal cross-signed issuer to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":     mount,
+		"issuer_id": result.IssuerID,
+		"signed_by": result.SignedBy,
+	}).Info("Successfully cross-signed pki issuer")
+
+	return mcp.NewToolResultStructured(result, string(jsonData)), nil
+}
+
+// parseCertificatePEM parses the first certificate in a PEM encoded string
+func parseCertificatePEM(data string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("no PEM encoded certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// subjectFields converts the subject of a certificate to the parameters accepted by the pki generate endpoints
+func subjectFields(certificate *x509.Certificate) map[string]interface{} {
+	fields := map[string]interface{}{
+		"common_name": certificate.Subject.CommonName,
+	}
+	subject := map[string][]string{
+		"organization":   certificate.Subject.Organization,
+		"ou":             certificate.Subject.OrganizationalUnit,
+		"country":        certificate.Subject.Country,
+		"locality":       certificate.Subject.Locality,
+		"province":       certificate.Subject.Province,
+		"street_address": certificate.Subject.StreetAddress,
+		"postal_code":    certificate.Subject.PostalCode,
+	}
+	for name, values := range subject {
+		if len(values) > 0 {
+			fields[name] = strings.Join(values, ",")
+		}
+	}
+	if certificate.Subject.SerialNumber != "" {
+		fields["serial_number"] = certificate.Subject.SerialNumber
+	}
+	return fields
+}
@@ -78,7 +78,10 @@ func deletePkiRoleHandler(ctx context.Context, req mcp.CallToolRequest, logger *
 	fullPath := fmt.Sprintf("%s/roles/%s", mount, roleName)
 
 	// Write the role data to the specified path
-	_, err = vault.Logical().Delete(fullPath)
+	// Keep the configuration of the role in the change record, roles hold no secrets
+	previous, _ := vault.Logical().Read(fullPath)
+
+	deleted, err := vault.Logical().Delete(fullPath)
 
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("failed to write to path '%s': %v", fullPath, err)), nil
@@ -90,5 +93,18 @@ func deletePkiRoleHandler(ctx context.Context, req mcp.CallToolRequest, logger *
 		"role_name": roleName,
 	}).Info("Successfully deleted pki role")
 
-	return mcp.NewToolResultText(successMsg), nil
+	change := client.ChangeRecord{
+		ResourceType: client.ResourcePKIRole,
+		Mount:        mount,
+		Path:         fullPath,
+		Operation:    client.OperationDelete,
+		Message:      successMsg,
+	}
+	if previous != nil {
+		change.Previous = previous.Data
+	}
+	if deleted != nil {
+		change.VaultRequestID = deleted.RequestID
+	}
+	return client.NewChangeResult(ctx, req, change), nil
 }
@@ -18,24 +18,7 @@ import (
 func EnablePki(logger *log.Logger) server.ServerTool {
 	return server.ServerTool{
 		Tool: mcp.NewTool("enable_pki",
-			mcp.WithDescription(`Enable the PKI (Public Key Infrastructure) secrets engine in Vault, allowing for the issuance and management of SSL/TLS certificates.
-## Setting up the Root CA
-  - Create a root PKI mount using this tool, giving it a suitable name that best describes its intended use. Examples could incorporate the domain name in to the name and include 'pki', 'pki_root', or 'pki_ca'.
-  - Create a PKI issuer using the 'create_pki_issuer' tool, which will define the CA (Certificate Authority) for the PKI root mount.
-  - Create a PKI role using the 'create_pki_role' tool, which will define the policies and constraints for issuing certificates against this root issuer.
-
-## Setting up an Intermediate CA
-  - Make sure you have set up the root CA as described above.
-  - Create a new intermediate PKI mount using this tool, giving it a suitable name that best describes its intended use. Examples could incorporate the domain name in to the name and include 'pki_int' or 'pki_int_ca'. You will need to specify the 'root_mount' and 'root_issuer' parameters to link it to the root CA.
-  - Create a PKI issuer using the 'create_pki_issuer' tool, which will define the CA (Certificate Authority) for the PKI intermediate mount.
-  - Create a PKI role using the 'create_pki_role' tool, which will define the policies and constraints for issuing certificates against this intermediate issuer.
-
-## Issuing SSL/TLS Certificates as an Intermediate CA or Root CA
-  - Set up the root CA according to the instructions above. If you want to issue certificates as an intermediate CA, make sure you have set up the intermediate CA as well.
-  - Create a PKI issuer using the 'create_pki_issuer' tool, which will define the CA (Certificate Authority) for the PKI mount.
-  - Create a PKI role using the 'create_pki_role' tool, which will define the policies and constraints for issuing certificates.
-  - Issue certificates using the 'issue_pki_certificate' tool, which will allow you to generate certificates based on the defined role.
-`),
+			mcp.WithDescription("Enable the PKI (Public Key Infrastructure) secrets engine in Vault, allowing for the issuance and management of SSL/TLS certificates. Read the 'vault-docs://pki/setup' resource for the steps to set up a root CA, an intermediate CA and issue certificates."),
 			mcp.WithString("path",
 				mcp.DefaultString("pki"),
 				mcp.Description("The path where the pki mount will be created. Defaults to 'pki'."),
@@ -140,5 +123,16 @@ func enablePkiHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.
 		"path": path,
 	}).Info("Successfully created pki mount")
 
-	return mcp.NewToolResultText(successMsg), nil
+	return client.NewChangeResult(ctx, req, client.ChangeRecord{
+		ResourceType: client.ResourceMount,
+		Mount:        path,
+		Path:         "sys/mounts/" + path,
+		Operation:    client.OperationCreate,
+		New: map[string]any{
+			"type":          mountInput.Type,
+			"description":   description,
+			"max_lease_ttl": maxTTL,
+		},
+		Message: successMsg,
+	}), nil
 }
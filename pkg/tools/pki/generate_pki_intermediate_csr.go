@@ -0,0 +1,183 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package pki
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+type IntermediateCSR struct {
+	CSR      string   `json:"csr"`                // PEM encoded certificate signing request
+	KeyID    string   `json:"key_id,omitempty"`   // ID of the key the CSR was generated with
+	KeyName  string   `json:"key_name,omitempty"` // Name of the key the CSR was generated with
+	Warnings []string `json:"warnings,omitempty"` // Warnings returned by Vault
+}
+
+// GeneratePkiIntermediateCSR creates a tool for generating an intermediate CSR to be signed outside of Vault
+func GeneratePkiIntermediateCSR(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("generate_pki_intermediate_csr",
+			mcp.WithDescription("Generate a certificate signing request (CSR) for an intermediate CA on a PKI mount in Vault. The private key stays inside Vault. Have the CSR signed by an external root CA, such as one kept in an HSM, and then import the signed certificate with set_pki_signed_intermediate."),
+			mcp.WithString("mount",
+				mcp.DefaultString("pki"),
+				mcp.Description("The mount where the intermediate will be created. Defaults to 'pki'."),
+			),
+			mcp.WithString("common_name",
+				mcp.Required(),
+				mcp.Description("Common Name (CN) of the intermediate CA, such as 'My Company Intermediate CA'."),
+			),
+			mcp.WithString("key_ref",
+				mcp.DefaultString(""),
+				mcp.Description("Optional name or ID of an existing key on the mount to use. A new key is generated when not set."),
+			),
+			mcp.WithString("key_name",
+				mcp.DefaultString(""),
+				mcp.Description("Optional name for the newly generated key. Ignored when 'key_ref' is set."),
+			),
+			mcp.WithString("key_type",
+				mcp.DefaultString("rsa"),
+				mcp.Enum("rsa", "ec", "ed25519"),
+				mcp.Description("Type of the newly generated key. Defaults to 'rsa'. Ignored when 'key_ref' is set."),
+			),
+			mcp.WithString("organization",
+				mcp.DefaultString(""),
+				mcp.Description("Optional organization (O) of the intermediate CA."),
+			),
+			mcp.WithString("country",
+				mcp.DefaultString(""),
+				mcp.Description("Optional country (C) of the intermediate CA."),
+			),
+			schemas.Output("generate_pki_intermediate_csr"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return generatePkiIntermediateCSRHandler(ctx, req, logger)
+		},
+	}
+}
+
+func generatePkiIntermediateCSRHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling generate_pki_intermediate_csr request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	commonName, ok := args["common_name"].(string)
+	if !ok || commonName == "" {
+		return mcp.NewToolResultError("Missing or invalid 'common_name' parameter"), nil
+	}
+
+	keyRef, _ := args["key_ref"].(string)
+	keyName, _ := args["key_name"].(string)
+	keyType, _ := args["key_type"].(string)
+	organization, _ := args["organization"].(string)
+	country, _ := args["country"].(string)
+
+	logger.WithFields(log.Fields{
+		"mount":       mount,
+		"common_name": commonName,
+		"key_ref":     keyRef,
+		"key_name":    keyName,
+		"key_type":    keyType,
+	}).Debug("Generating intermediate CSR with parameters")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list mounts: %v", err)), nil
+	}
+
+	// Check if the mount exists
+	if _, ok := mounts[mount+"/"]; !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("mount path '%s' does not exist, you should use 'enable_pki' if you want enable pki on this mount.", mount)), nil
+	}
+
+	csrData := map[string]interface{}{
+		"common_name": commonName,
+	}
+	if organization != "" {
+		csrData["organization"] = organization
+	}
+	if country != "" {
+		csrData["country"] = country
+	}
+
+	fullPath := fmt.Sprintf("%s/intermediate/generate/internal", mount)
+	if keyRef != "" {
+		fullPath = fmt.Sprintf("%s/intermediate/generate/existing", mount)
+		csrData["key_ref"] = keyRef
+	} else {
+		if keyName != "" {
+			csrData["key_name"] = keyName
+		}
+		if keyType != "" {
+			csrData["key_type"] = keyType
+		}
+	}
+
+	secret, err := vault.Logical().Write(fullPath, csrData)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to write to path '%s': %v", fullPath, err)), nil
+	}
+	if secret == nil || secret.Data["csr"] == nil {
+		return mcp.NewToolResultError("CSR generation returned no data"), nil
+	}
+
+	result := &IntermediateCSR{
+		Warnings: secret.Warnings,
+	}
+	result.CSR, _ = secret.Data["csr"].(string)
+	result.KeyID, _ = secret.Data["key_id"].(string)
+	result.KeyName, _ = secret.Data["key_name"].(string)
+
+	// A CSR generated with a new internal key adds the key to the mount
+	if keyRef == "" {
+		client.RecordChange(ctx, client.ChangeRecord{
+			Tool:           req.Params.Name,
+			ResourceType:   client.ResourcePKIKey,
+			Mount:          mount,
+			Path:           fmt.Sprintf("%s/key/%s", mount, result.KeyID),
+			Operation:      client.OperationCreate,
+			New:            map[string]any{"key_id": result.KeyID, "key_name": result.KeyName},
+			VaultRequestID: secret.RequestID,
+			Message:        fmt.Sprintf("Generated a key for an intermediate CSR on mount '%s'", mount),
+		})
+	}
+
+	// Marshal to JSON
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal CSR to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":  mount,
+		"key_id": result.KeyID,
+	}).Info("Successfully generated intermediate CSR")
+
+	return mcp.NewToolResultStructured(result, string(jsonData)), nil
+}
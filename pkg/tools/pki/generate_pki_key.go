@@ -0,0 +1,204 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package pki
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+type GeneratedKey struct {
+	KeyID      string `json:"key_id,omitempty"`      // ID of the generated key
+	KeyName    string `json:"key_name,omitempty"`    // Name of the generated key
+	KeyType    string `json:"key_type,omitempty"`    // Type of the generated key
+	PrivateKey string `json:"private_key,omitempty"` // Private key, only returned for exported keys that are not wrapped
+	WrapToken  string `json:"wrap_token,omitempty"`  // Response wrapping token holding the private key, when wrapped
+	WrapTTL    string `json:"wrap_ttl,omitempty"`    // TTL of the response wrapping token
+	WrapExpiry string `json:"wrap_expiry,omitempty"` // Expiry time of the response wrapping token
+}
+
+// GeneratePkiKey creates a tool for generating a pki key
+func GeneratePkiKey(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("generate_pki_key",
+			mcp.WithDescription("Generate a new key on a PKI mount in Vault. Internal keys never leave Vault. Exported keys return the private key once; set 'wrap_ttl' to receive it inside a single-use response wrapping token instead of in plain text."),
+			mcp.WithString("mount",
+				mcp.DefaultString("pki"),
+				mcp.Description("The mount where the key will be generated. Defaults to 'pki'."),
+			),
+			mcp.WithString("type",
+				mcp.DefaultString("internal"),
+				mcp.Enum("internal", "exported"),
+				mcp.Description("Whether the private key is kept inside Vault ('internal') or returned ('exported'). Defaults to 'internal'."),
+			),
+			mcp.WithString("key_name",
+				mcp.DefaultString(""),
+				mcp.Description("Optional name for the key, which can be used instead of its ID."),
+			),
+			mcp.WithString("key_type",
+				mcp.DefaultString("rsa"),
+				mcp.Enum("rsa", "ec", "ed25519"),
+				mcp.Description("Type of the key. Defaults to 'rsa'."),
+			),
+			mcp.WithNumber("key_bits",
+				mcp.DefaultNumber(0),
+				mcp.Description("Optional number of bits of the key. Defaults to 2048 for rsa and 256 for ec keys."),
+			),
+			mcp.WithString("wrap_ttl",
+				mcp.DefaultString(""),
+				mcp.Description("Optional TTL such as '5m'. When set with type 'exported', the response is wrapped and the private key can only be retrieved once by unwrapping the returned token."),
+			),
+			schemas.Output("generate_pki_key"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return generatePkiKeyHandler(ctx, req, logger)
+		},
+	}
+}
+
+func generatePkiKeyHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling generate_pki_key request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	keyGenType, _ := args["type"].(string)
+	if keyGenType == "" {
+		keyGenType = "internal"
+	}
+	if keyGenType != "internal" && keyGenType != "exported" {
+		return mcp.NewToolResultError("Missing or invalid 'type' parameter"), nil
+	}
+
+	keyName, _ := args["key_name"].(string)
+
+	keyType, _ := args["key_type"].(string)
+	if keyType == "" {
+		keyType = "rsa"
+	}
+
+	keyBits, _ := args["key_bits"].(float64)
+
+	wrapTTL, _ := args["wrap_ttl"].(string)
+	if wrapTTL != "" {
+		if keyGenType != "exported" {
+			return mcp.NewToolResultError("'wrap_ttl' can only be used with type 'exported'"), nil
+		}
+		if d, err := time.ParseDuration(wrapTTL); err != nil || d <= 0 {
+			return mcp.NewToolResultError("Missing or invalid 'wrap_ttl' parameter"), nil
+		}
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":    mount,
+		"type":     keyGenType,
+		"key_name": keyName,
+		"key_type": keyType,
+		"key_bits": keyBits,
+		"wrapped":  wrapTTL != "",
+	}).Debug("Generating pki key with parameters")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list mounts: %v", err)), nil
+	}
+
+	// Check if the mount exists
+	if _, ok := mounts[mount+"/"]; !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("mount path '%s' does not exist, you should use 'enable_pki' if you want enable pki on this mount.", mount)), nil
+	}
+
+	keyData := map[string]interface{}{
+		"key_type": keyType,
+	}
+	if keyName != "" {
+		keyData["key_name"] = keyName
+	}
+	if keyBits > 0 {
+		keyData["key_bits"] = int(keyBits)
+	}
+
+	if wrapTTL != "" {
+		// Use a copy of the session client so the wrapping only applies to this request
+		vault = vault.WithNamespace(vault.Namespace())
+		vault.SetWrappingLookupFunc(func(_, _ string) string {
+			return wrapTTL
+		})
+	}
+
+	fullPath := fmt.Sprintf("%s/keys/generate/%s", mount, keyGenType)
+
+	secret, err := vault.Logical().Write(fullPath, keyData)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to write to path '%s': %v", fullPath, err)), nil
+	}
+	if secret == nil {
+		return mcp.NewToolResultError("Key generation returned no data"), nil
+	}
+
+	result := &GeneratedKey{}
+	if secret.WrapInfo != nil {
+		result.KeyName = keyName
+		result.KeyType = keyType
+		result.WrapToken = secret.WrapInfo.Token
+		result.WrapTTL = (time.Duration(secret.WrapInfo.TTL) * time.Second).String()
+		result.WrapExpiry = secret.WrapInfo.CreationTime.Add(time.Duration(secret.WrapInfo.TTL) * time.Second).Format(time.RFC3339)
+	} else {
+		result.KeyID, _ = secret.Data["key_id"].(string)
+		result.KeyName, _ = secret.Data["key_name"].(string)
+		result.KeyType, _ = secret.Data["key_type"].(string)
+		result.PrivateKey, _ = secret.Data["private_key"].(string)
+	}
+
+	// The private key is only returned to the caller, the change record names the key
+	client.RecordChange(ctx, client.ChangeRecord{
+		Tool:           req.Params.Name,
+		ResourceType:   client.ResourcePKIKey,
+		Mount:          mount,
+		Path:           fmt.Sprintf("%s/key/%s", mount, result.KeyID),
+		Operation:      client.OperationCreate,
+		New:            map[string]any{"key_id": result.KeyID, "key_name": result.KeyName, "key_type": result.KeyType, "wrapped": result.WrapToken != ""},
+		VaultRequestID: secret.RequestID,
+		Message:        fmt.Sprintf("Generated %s key '%s' on mount '%s'", keyGenType, result.KeyName, mount),
+	})
+
+	// Marshal to JSON
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal key to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":    mount,
+		"key_id":   result.KeyID,
+		"key_name": result.KeyName,
+	}).Info("Successfully generated pki key")
+
+	return mcp.NewToolResultStructured(result, string(jsonData)), nil
+}
@@ -0,0 +1,172 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package pki
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+type ImportedIssuer struct {
+	ImportedIssuers []string          `json:"imported_issuers"`      // IDs of the issuers created by the import
+	ImportedKeys    []string          `json:"imported_keys"`         // IDs of the keys created by the import
+	Mapping         map[string]string `json:"mapping"`               // Issuer IDs mapped to the IDs of their keys
+	IssuerName      string            `json:"issuer_name,omitempty"` // Name given to the imported issuer, if any
+	Warnings        []string          `json:"warnings,omitempty"`    // Warnings returned by Vault
+}
+
+// ImportPkiIssuer creates a tool for importing an external certificate and key as a pki issuer
+func ImportPkiIssuer(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("import_pki_issuer",
+			mcp.WithDescription("Import an externally generated CA certificate, optionally with its private key, into a PKI mount in Vault as an issuer. Use this to bring your own root or intermediate CA into Vault."),
+			mcp.WithString("mount",
+				mcp.DefaultString("pki"),
+				mcp.Description("The mount where the issuer will be imported. Defaults to 'pki'."),
+			),
+			mcp.WithString("pem_bundle",
+				mcp.Required(),
+				mcp.Description("PEM encoded CA certificate, its private key (unencrypted) and optionally the rest of its chain. Certificates imported without a key can verify but not issue certificates."),
+			),
+			mcp.WithString("issuer_name",
+				mcp.DefaultString(""),
+				mcp.Description("Optional name to give the imported issuer. Only used when a single issuer is imported."),
+			),
+			schemas.Output("import_pki_issuer"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return importPkiIssuerHandler(ctx, req, logger)
+		},
+	}
+}
+
+func importPkiIssuerHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling import_pki_issuer request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	pemBundle, ok := args["pem_bundle"].(string)
+	if !ok || !strings.Contains(pemBundle, "-----BEGIN CERTIFICATE-----") {
+		return mcp.NewToolResultError("Missing or invalid 'pem_bundle' parameter, it must contain at least one PEM encoded certificate"), nil
+	}
+
+	issuerName, _ := args["issuer_name"].(string)
+
+	// The bundle may contain a private key, so only log the parameters that are safe to log
+	logger.WithFields(log.Fields{
+		"mount":       mount,
+		"issuer_name": issuerName,
+	}).Debug("Importing pki issuer with parameters")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list mounts: %v", err)), nil
+	}
+
+	// Check if the mount exists
+	if _, ok := mounts[mount+"/"]; !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("mount path '%s' does not exist, you should use 'enable_pki' if you want enable pki on this mount.", mount)), nil
+	}
+
+	fullPath := fmt.Sprintf("%s/issuers/import/bundle", mount)
+
+	secret, err := vault.Logical().Write(fullPath, map[string]interface{}{
+		"pem_bundle": pemBundle,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to write to path '%s': %v", fullPath, err)), nil
+	}
+	if secret == nil || secret.Data == nil {
+		return mcp.NewToolResultError("Import returned no data"), nil
+	}
+
+	result := &ImportedIssuer{
+		ImportedIssuers: toStringSlice(secret.Data["imported_issuers"]),
+		ImportedKeys:    toStringSlice(secret.Data["imported_keys"]),
+		Mapping:         make(map[string]string),
+		Warnings:        secret.Warnings,
+	}
+	if mapping, ok := secret.Data["mapping"].(map[string]interface{}); ok {
+		for issuerID, keyID := range mapping {
+			result.Mapping[issuerID], _ = keyID.(string)
+		}
+	}
+
+	// Name the issuer when the import created exactly one
+	if issuerName != "" {
+		if len(result.ImportedIssuers) == 1 {
+			namePath := fmt.Sprintf("%s/issuer/%s", mount, result.ImportedIssuers[0])
+			if _, err := vault.Logical().JSONMergePatch(ctx, namePath, map[string]interface{}{"issuer_name": issuerName}); err != nil {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("imported issuer could not be named '%s': %v", issuerName, err))
+			} else {
+				result.IssuerName = issuerName
+			}
+		} else {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%d issuers were imported, the issuer name '%s' was not applied", len(result.ImportedIssuers), issuerName))
+		}
+	}
+
+	client.RecordChange(ctx, client.ChangeRecord{
+		Tool:           req.Params.Name,
+		ResourceType:   client.ResourcePKIIssuer,
+		Mount:          mount,
+		Path:           fullPath,
+		Operation:      client.OperationCreate,
+		New:            map[string]any{"imported_issuers": result.ImportedIssuers, "imported_keys": result.ImportedKeys, "issuer_name": result.IssuerName},
+		VaultRequestID: secret.RequestID,
+		Message:        fmt.Sprintf("Imported %d issuers and %d keys on mount '%s'", len(result.ImportedIssuers), len(result.ImportedKeys), mount),
+	})
+
+	// Marshal to JSON
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal import result to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":            mount,
+		"imported_issuers": len(result.ImportedIssuers),
+		"imported_keys":    len(result.ImportedKeys),
+	}).Info("Successfully imported pki issuer")
+
+	return mcp.NewToolResultStructured(result, string(jsonData)), nil
+}
+
+// toStringSlice converts a list returned by Vault to a slice of strings
+func toStringSlice(v interface{}) []string {
+	result := []string{}
+	list, _ := v.([]interface{})
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
@@ -7,6 +7,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
+	"net/url"
+	"path"
+	"strings"
+
 	"github.com/hashicorp/vault-mcp-server/pkg/client"
 	"github.com/hashicorp/vault-mcp-server/pkg/utils"
 
@@ -36,6 +41,36 @@ func IssuePkiCertificate(logger *log.Logger) server.ServerTool {
 				mcp.DefaultString("30d"),
 				mcp.Description("Optional TTL for the certificate. This is the time that the certificate will be valid for. Defaults to '30d' (30 days). Other formats are also accepted, such as '87600h' for 10 years."),
 			),
+			mcp.WithString("alt_names",
+				mcp.DefaultString(""),
+				mcp.Description("Optional comma separated DNS names or email addresses to add as subject alternative names."),
+			),
+			mcp.WithString("ip_sans",
+				mcp.DefaultString(""),
+				mcp.Description("Optional comma separated IP addresses to add as subject alternative names. The role must allow IP SANs."),
+			),
+			mcp.WithString("uri_sans",
+				mcp.DefaultString(""),
+				mcp.Description("Optional comma separated URIs to add as subject alternative names. They must match the allowed_uri_sans of the role."),
+			),
+			mcp.WithBoolean("exclude_cn_from_sans",
+				mcp.DefaultBool(false),
+				mcp.Description("Optional flag to leave the common name out of the subject alternative names. Defaults to false."),
+			),
+			mcp.WithString("private_key_format",
+				mcp.DefaultString("der"),
+				mcp.Enum("der", "pem", "pkcs8"),
+				mcp.Description("Optional format of the returned private key. 'der' returns a PEM encoded key in the format of its type, 'pkcs8' returns a PEM encoded PKCS#8 key. Defaults to 'der'."),
+			),
+			mcp.WithString("key_type",
+				mcp.DefaultString(""),
+				mcp.Enum("", "rsa", "ec", "ed25519"),
+				mcp.Description("Optional key type. Only accepted when the role allows any key type ('any') or the value matches the key type of the role."),
+			),
+			mcp.WithNumber("key_bits",
+				mcp.DefaultNumber(0),
+				mcp.Description("Optional number of bits of the key. Only used together with 'key_type'."),
+			),
 		),
 		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			return issuePkiCertificateHandler(ctx, req, logger)
@@ -69,11 +104,34 @@ func issuePkiCertificateHandler(ctx context.Context, req mcp.CallToolRequest, lo
 
 	ttl, _ := args["ttl"].(string)
 
+	request := &IssueRequest{
+		CommonName: commonName,
+		AltNames:   utils.SplitList(req.GetString("alt_names", "")),
+		IPSans:     utils.SplitList(req.GetString("ip_sans", "")),
+		URISans:    utils.SplitList(req.GetString("uri_sans", "")),
+	}
+	request.ExcludeCNFromSans, _ = args["exclude_cn_from_sans"].(bool)
+	request.KeyType, _ = args["key_type"].(string)
+	if keyBits, ok := args["key_bits"].(float64); ok {
+		request.KeyBits = int(keyBits)
+	}
+
+	privateKeyFormat, _ := args["private_key_format"].(string)
+	switch privateKeyFormat {
+	case "", "der", "pem", "pkcs8":
+	default:
+		return mcp.NewToolResultError("Missing or invalid 'private_key_format' parameter"), nil
+	}
+
 	logger.WithFields(log.Fields{
 		"mount":       mount,
 		"role_name":   roleName,
 		"common_name": commonName,
 		"ttl":         ttl,
+		"alt_names":   request.AltNames,
+		"ip_sans":     request.IPSans,
+		"uri_sans":    request.URISans,
+		"key_type":    request.KeyType,
 	}).Debug("Creating certificate with parameters")
 
 	// Get Vault client from context
@@ -93,12 +151,45 @@ func issuePkiCertificateHandler(ctx context.Context, req mcp.CallToolRequest, lo
 		return mcp.NewToolResultError(fmt.Sprintf("mount path '%s' does not exist, you should use 'enable_pki' if you want enable pki on this mount.", mount)), nil
 	}
 
+	// Validate the request against the role so the caller learns which allowance is missing. Tokens
+	// that may issue but not read the role skip this check and rely on Vault to enforce the role.
+	rolePath := fmt.Sprintf("%s/roles/%s", mount, roleName)
+	role, err := vault.Logical().Read(rolePath)
+	if err != nil {
+		logger.WithError(err).WithField("role_name", roleName).Warn("Failed to read role, skipping validation")
+	} else if role == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("role '%s' does not exist in mount '%s'", roleName, mount)), nil
+	} else if err := ValidateIssueRequest(role.Data, request); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("the request is not allowed by role '%s': %v", roleName, err)), nil
+	}
+
 	fullPath := fmt.Sprintf("%s/issue/%s", mount, roleName)
 
 	requestData := map[string]interface{}{
 		"common_name": commonName,
 		"ttl":         ttl,
 	}
+	if len(request.AltNames) > 0 {
+		requestData["alt_names"] = strings.Join(request.AltNames, ",")
+	}
+	if len(request.IPSans) > 0 {
+		requestData["ip_sans"] = strings.Join(request.IPSans, ",")
+	}
+	if len(request.URISans) > 0 {
+		requestData["uri_sans"] = strings.Join(request.URISans, ",")
+	}
+	if request.ExcludeCNFromSans {
+		requestData["exclude_cn_from_sans"] = true
+	}
+	if privateKeyFormat != "" {
+		requestData["private_key_format"] = privateKeyFormat
+	}
+	if request.KeyType != "" {
+		requestData["key_type"] = request.KeyType
+		if request.KeyBits > 0 {
+			requestData["key_bits"] = request.KeyBits
+		}
+	}
 
 	// Write the issuer data to the specified path
 	secret, err := vault.Logical().Write(fullPath, requestData)
@@ -125,3 +216,133 @@ func issuePkiCertificateHandler(ctx context.Context, req mcp.CallToolRequest, lo
 
 	return mcp.NewToolResultText(string(jsonData)), nil
 }
+
+type IssueRequest struct {
+	CommonName        string
+	AltNames          []string
+	IPSans            []string
+	URISans           []string
+	ExcludeCNFromSans bool
+	KeyType           string
+	KeyBits           int
+}
+
+// ValidateIssueRequest checks the names, SANs and key settings of a request against the allowances of a pki role
+func ValidateIssueRequest(role map[string]interface{}, request *IssueRequest) error {
+	names := append([]string{request.CommonName}, request.AltNames...)
+	if !roleBool(role, "allow_any_name") {
+		for _, name := range names {
+			if !nameAllowedByRole(role, name) {
+				return fmt.Errorf("name '%s' is not allowed, check allowed_domains, allow_subdomains, allow_bare_domains and allow_glob_domains", name)
+			}
+		}
+	}
+
+	if len(request.IPSans) > 0 {
+		if !roleBool(role, "allow_ip_sans") {
+			return fmt.Errorf("IP SANs are not allowed, allow_ip_sans is false")
+		}
+		for _, ip := range request.IPSans {
+			if net.ParseIP(ip) == nil {
+				return fmt.Errorf("'%s' is not a valid IP address", ip)
+			}
+		}
+	}
+
+	if len(request.URISans) > 0 {
+		allowed := toStringSlice(role["allowed_uri_sans"])
+		if len(allowed) == 0 {
+			return fmt.Errorf("URI SANs are not allowed, allowed_uri_sans is empty")
+		}
+		for _, uri := range request.URISans {
+			if _, err := url.Parse(uri); err != nil {
+				return fmt.Errorf("'%s' is not a valid URI", uri)
+			}
+			if !matchesAny(allowed, uri) {
+				return fmt.Errorf("URI SAN '%s' does not match allowed_uri_sans %v", uri, allowed)
+			}
+		}
+	}
+
+	if request.KeyType != "" {
+		roleKeyType, _ := role["key_type"].(string)
+		if roleKeyType != "any" && roleKeyType != request.KeyType {
+			return fmt.Errorf("key type '%s' is not allowed, the role requires key type '%s'", request.KeyType, roleKeyType)
+		}
+	}
+
+	return nil
+}
+
+// nameAllowedByRole reports whether a DNS name or email address is allowed by the domain settings of a role
+func nameAllowedByRole(role map[string]interface{}, name string) bool {
+	// Email addresses are checked on their domain
+	if i := strings.LastIndex(name, "@"); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.ToLower(name)
+
+	if roleBool(role, "allow_localhost") && (name == "localhost" || name == "localdomain") {
+		return true
+	}
+
+	for _, domain := range toStringSlice(role["allowed_domains"]) {
+		domain = strings.ToLower(domain)
+		if strings.Contains(domain, "{{") {
+			// Templated domains depend on the identity of the caller, leave them to Vault
+			return true
+		}
+		if roleBool(role, "allow_bare_domains") && name == domain {
+			return true
+		}
+		if roleBool(role, "allow_subdomains") && strings.HasSuffix(name, "."+strings.TrimPrefix(domain, "*.")) {
+			return true
+		}
+		if roleBool(role, "allow_glob_domains") && strings.Contains(domain, "*") && globMatch(domain, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesAny reports whether the value matches any of the glob patterns
+func matchesAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if pattern == value || globMatch(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// globMatch matches a value against a pattern where '*' matches any sequence of characters
+func globMatch(pattern string, value string) bool {
+	// path.Match stops '*' at slashes, which Vault globs do not
+	if !strings.Contains(value, "/") {
+		matched, err := path.Match(pattern, value)
+		return err == nil && matched
+	}
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+	for i, part := range parts[1:] {
+		if i == len(parts)-2 {
+			return strings.HasSuffix(value, part)
+		}
+		idx := strings.Index(value, part)
+		if idx < 0 {
+			return false
+		}
+		value = value[idx+len(part):]
+	}
+	return value == ""
+}
+
+// roleBool reads a boolean setting of a pki role
+func roleBool(role map[string]interface{}, key string) bool {
+	b, _ := role[key].(bool)
+	return b
+}
@@ -0,0 +1,83 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package pki
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateIssueRequest(t *testing.T) {
+	role := map[string]interface{}{
+		"allowed_domains":    []interface{}{"internal.example.com", "*.svc.cluster.local"},
+		"allow_subdomains":   true,
+		"allow_bare_domains": false,
+		"allow_glob_domains": true,
+		"allow_localhost":    true,
+		"allow_ip_sans":      true,
+		"allowed_uri_sans":   []interface{}{"spiffe://cluster/*"},
+		"key_type":           "rsa",
+	}
+
+	tests := []struct {
+		name    string
+		request *IssueRequest
+		wantErr string
+	}{
+		{
+			name:    "subdomain with SANs",
+			request: &IssueRequest{CommonName: "app.internal.example.com", AltNames: []string{"localhost", "ops@mail.internal.example.com"}, IPSans: []string{"10.0.0.1"}},
+		},
+		{
+			name:    "glob domain",
+			request: &IssueRequest{CommonName: "api.svc.cluster.local"},
+		},
+		{
+			name:    "bare domain not allowed",
+			request: &IssueRequest{CommonName: "internal.example.com"},
+			wantErr: "name 'internal.example.com' is not allowed",
+		},
+		{
+			name:    "alt name outside allowed domains",
+			request: &IssueRequest{CommonName: "app.internal.example.com", AltNames: []string{"app.other.com"}},
+			wantErr: "name 'app.other.com' is not allowed",
+		},
+		{
+			name:    "invalid IP SAN",
+			request: &IssueRequest{CommonName: "app.internal.example.com", IPSans: []string{"not-an-ip"}},
+			wantErr: "not a valid IP address",
+		},
+		{
+			name:    "URI SAN matching glob",
+			request: &IssueRequest{CommonName: "app.internal.example.com", URISans: []string{"spiffe://cluster/ns/default/sa/app"}},
+		},
+		{
+			name:    "URI SAN not allowed",
+			request: &IssueRequest{CommonName: "app.internal.example.com", URISans: []string{"spiffe://other/app"}},
+			wantErr: "does not match allowed_uri_sans",
+		},
+		{
+			name:    "key type different from role",
+			request: &IssueRequest{CommonName: "app.internal.example.com", KeyType: "ec"},
+			wantErr: "the role requires key type 'rsa'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateIssueRequest(role, tt.request)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("IP SANs not allowed", func(t *testing.T) {
+		err := ValidateIssueRequest(map[string]interface{}{"allow_any_name": true}, &IssueRequest{CommonName: "anything", IPSans: []string{"10.0.0.1"}})
+		assert.ErrorContains(t, err, "allow_ip_sans is false")
+	})
+}
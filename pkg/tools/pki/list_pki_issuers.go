@@ -19,6 +19,11 @@ import (
 func ListPkiIssuers(logger *log.Logger) server.ServerTool {
 	return server.ServerTool{
 		Tool: mcp.NewTool("list_pki_issuers",
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
 			mcp.WithDescription("Get a list of PKI issuers on a specific pki mount in Vault, allowing you to see all the configured issuers for that mount."),
 			mcp.WithString("mount",
 				mcp.DefaultString("pki"),
@@ -19,11 +19,21 @@ import (
 func ListPkiRoles(logger *log.Logger) server.ServerTool {
 	return server.ServerTool{
 		Tool: mcp.NewTool("list_pki_roles",
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
 			mcp.WithDescription("Get a list of PKI roles which are able to issue certificates, allowing you to see all the configured roles for a specific PKI mount in Vault."),
 			mcp.WithString("mount",
 				mcp.DefaultString("pki"),
 				mcp.Description("The mount where the pki roles will be listed. Defaults to 'pki'."),
 			),
+			mcp.WithString("format",
+				mcp.DefaultString("json"),
+				mcp.Enum("json", "markdown", "table"),
+				mcp.Description("Optional output format. Use 'markdown' or 'table' for a compact table that is easier to display in chat. Defaults to 'json'."),
+			),
 		),
 		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			return listPkiRolesHandler(ctx, req, logger)
@@ -45,8 +55,14 @@ func listPkiRolesHandler(ctx context.Context, req mcp.CallToolRequest, logger *l
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
+	format, err := utils.ExtractFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
 	logger.WithFields(log.Fields{
-		"mount": mount,
+		"mount":  mount,
+		"format": format,
 	}).Debug("Listing pki roles with parameters")
 
 	// Get Vault client from context
@@ -78,6 +94,15 @@ func listPkiRolesHandler(ctx context.Context, req mcp.CallToolRequest, logger *l
 	// V1 API structure: secret.Data directly contains the key-value pairs
 	keyInfo := secret.Data["keys"]
 
+	if format != utils.FormatJSON {
+		keys, _ := keyInfo.([]interface{})
+		rows := make([][]string, 0, len(keys))
+		for _, key := range keys {
+			rows = append(rows, []string{fmt.Sprintf("%v", key)})
+		}
+		return mcp.NewToolResultText(utils.RenderTable(format, []string{"role_name"}, rows)), nil
+	}
+
 	// Marshal to JSON
 	jsonData, err := json.Marshal(keyInfo)
 	if err != nil {
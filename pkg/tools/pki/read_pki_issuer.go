@@ -19,6 +19,11 @@ import (
 func ReadPkiIssuer(logger *log.Logger) server.ServerTool {
 	return server.ServerTool{
 		Tool: mcp.NewTool("read_pki_issuer",
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
 			mcp.WithDescription("Read a PKI issuer details from a specific mount in Vault, allowing you to retrieve information about a specific PKI issuer."),
 			mcp.WithString("mount",
 				mcp.DefaultString("pki"),
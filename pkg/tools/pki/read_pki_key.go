@@ -0,0 +1,112 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package pki
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// ReadPkiKey creates a tool for reading pki key details
+func ReadPkiKey(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("read_pki_key",
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithDescription("Read the details of a PKI key on a specific mount in Vault, such as its type, name and whether it is managed. The private key itself is never returned."),
+			mcp.WithString("mount",
+				mcp.DefaultString("pki"),
+				mcp.Description("The mount where the pki key is stored. Defaults to 'pki'."),
+			),
+			mcp.WithString("key_ref",
+				mcp.Required(),
+				mcp.Description("The name or ID of the key, as returned by list_pki_keys. Use 'default' for the default key of the mount."),
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return readPkiKeyHandler(ctx, req, logger)
+		},
+	}
+}
+
+func readPkiKeyHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling read_pki_key request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	keyRef, ok := args["key_ref"].(string)
+	if !ok || keyRef == "" {
+		return mcp.NewToolResultError("Missing or invalid 'key_ref' parameter"), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":   mount,
+		"key_ref": keyRef,
+	}).Debug("Reading pki key details")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list mounts: %v", err)), nil
+	}
+
+	// Check if the mount exists
+	if _, ok := mounts[mount+"/"]; !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("mount path '%s' does not exist, you should use 'enable_pki' if you want enable pki on this mount.", mount)), nil
+	}
+
+	fullPath := fmt.Sprintf("%s/key/%s", mount, keyRef)
+
+	secret, err := vault.Logical().Read(fullPath)
+	if err != nil {
+		logger.WithError(err).WithFields(log.Fields{
+			"mount":     mount,
+			"full_path": fullPath,
+		}).Error("Failed to read key")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read key: %v", err)), nil
+	}
+
+	if secret == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("No key found with reference '%s' in mount '%s'", keyRef, mount)), nil
+	}
+
+	// Marshal to JSON
+	jsonData, err := json.Marshal(secret.Data)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal key to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":   mount,
+		"key_ref": keyRef,
+	}).Debug("Successfully read pki key")
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
@@ -19,6 +19,11 @@ import (
 func ReadPkiRole(logger *log.Logger) server.ServerTool {
 	return server.ServerTool{
 		Tool: mcp.NewTool("read_pki_role",
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
 			mcp.WithDescription("Read a PKI role details from a specific mount in Vault. This allows you to retrieve information about a specific PKI role."),
 			mcp.WithString("mount",
 				mcp.DefaultString("pki"),
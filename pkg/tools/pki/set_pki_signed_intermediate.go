@@ -0,0 +1,153 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package pki
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// SetPkiSignedIntermediate creates a tool for importing an externally signed intermediate certificate
+func SetPkiSignedIntermediate(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("set_pki_signed_intermediate",
+			mcp.WithDescription("Import an intermediate CA certificate that was signed outside of Vault for a CSR created with generate_pki_intermediate_csr. The certificate is matched with the key that generated the CSR and becomes a new issuer on the mount."),
+			mcp.WithString("mount",
+				mcp.DefaultString("pki"),
+				mcp.Description("The mount where the CSR was generated. Defaults to 'pki'."),
+			),
+			mcp.WithString("certificate",
+				mcp.Required(),
+				mcp.Description("PEM encoded signed intermediate certificate, optionally followed by the rest of its chain up to the root."),
+			),
+			mcp.WithString("issuer_name",
+				mcp.DefaultString(""),
+				mcp.Description("Optional name to give the new issuer."),
+			),
+			schemas.Output("set_pki_signed_intermediate"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return setPkiSignedIntermediateHandler(ctx, req, logger)
+		},
+	}
+}
+
+func setPkiSignedIntermediateHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling set_pki_signed_intermediate request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	certificate, ok := args["certificate"].(string)
+	if !ok || !strings.Contains(certificate, "-----BEGIN CERTIFICATE-----") {
+		return mcp.NewToolResultError("Missing or invalid 'certificate' parameter, it must contain a PEM encoded certificate"), nil
+	}
+
+	issuerName, _ := args["issuer_name"].(string)
+
+	logger.WithFields(log.Fields{
+		"mount":       mount,
+		"issuer_name": issuerName,
+	}).Debug("Setting signed intermediate with parameters")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list mounts: %v", err)), nil
+	}
+
+	// Check if the mount exists
+	if _, ok := mounts[mount+"/"]; !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("mount path '%s' does not exist, you should use 'enable_pki' if you want enable pki on this mount.", mount)), nil
+	}
+
+	fullPath := fmt.Sprintf("%s/intermediate/set-signed", mount)
+
+	secret, err := vault.Logical().Write(fullPath, map[string]interface{}{
+		"certificate": certificate,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to write to path '%s': %v", fullPath, err)), nil
+	}
+	if secret == nil || secret.Data == nil {
+		return mcp.NewToolResultError("Setting the signed intermediate returned no data"), nil
+	}
+
+	result := &ImportedIssuer{
+		ImportedIssuers: toStringSlice(secret.Data["imported_issuers"]),
+		ImportedKeys:    toStringSlice(secret.Data["imported_keys"]),
+		Mapping:         make(map[string]string),
+		Warnings:        secret.Warnings,
+	}
+	if mapping, ok := secret.Data["mapping"].(map[string]interface{}); ok {
+		for issuerID, keyID := range mapping {
+			result.Mapping[issuerID], _ = keyID.(string)
+		}
+	}
+
+	// Name the issuer that is backed by a key on the mount, the rest of the chain has no key
+	if issuerName != "" {
+		var issuerID string
+		for id, keyID := range result.Mapping {
+			if keyID != "" {
+				issuerID = id
+			}
+		}
+		if issuerID == "" {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("no imported issuer matched a key on the mount, the issuer name '%s' was not applied", issuerName))
+		} else if _, err := vault.Logical().JSONMergePatch(ctx, fmt.Sprintf("%s/issuer/%s", mount, issuerID), map[string]interface{}{"issuer_name": issuerName}); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("imported issuer could not be named '%s': %v", issuerName, err))
+		} else {
+			result.IssuerName = issuerName
+		}
+	}
+
+	client.RecordChange(ctx, client.ChangeRecord{
+		Tool:           req.Params.Name,
+		ResourceType:   client.ResourcePKIIssuer,
+		Mount:          mount,
+		Path:           fullPath,
+		Operation:      client.OperationCreate,
+		New:            map[string]any{"imported_issuers": result.ImportedIssuers, "imported_keys": result.ImportedKeys, "issuer_name": result.IssuerName},
+		VaultRequestID: secret.RequestID,
+		Message:        fmt.Sprintf("Set the signed intermediate certificate on mount '%s', importing %d issuers", mount, len(result.ImportedIssuers)),
+	})
+
+	// Marshal to JSON
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal import result to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":            mount,
+		"imported_issuers": len(result.ImportedIssuers),
+	}).Info("Successfully set signed intermediate")
+
+	return mcp.NewToolResultStructured(result, string(jsonData)), nil
+}
@@ -0,0 +1,317 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package pki
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+type CertificateVerification struct {
+	Valid         bool     `json:"valid"`                     // Whether the certificate chains to an issuer on the mount and is within its validity period
+	Error         string   `json:"error,omitempty"`           // Reason the verification failed, if it did
+	Subject       string   `json:"subject"`                   // Subject of the certificate
+	Issuer        string   `json:"issuer"`                    // Issuer of the certificate
+	SerialNumber  string   `json:"serial_number"`             // Serial number in the colon separated format used by Vault
+	NotBefore     string   `json:"not_before"`                // Start of the validity period
+	NotAfter      string   `json:"not_after"`                 // End of the validity period
+	Expired       bool     `json:"expired"`                   // Whether the certificate has expired
+	DaysRemaining int      `json:"days_remaining"`            // Days until the certificate expires, negative when expired
+	IsCA          bool     `json:"is_ca"`                     // Whether the certificate is a CA certificate
+	KeyUsage      []string `json:"key_usage,omitempty"`       // Key usages of the certificate
+	ExtKeyUsage   []string `json:"ext_key_usage,omitempty"`   // Extended key usages of the certificate
+	DNSNames      []string `json:"dns_names,omitempty"`       // DNS subject alternative names
+	IPAddresses   []string `json:"ip_addresses,omitempty"`    // IP subject alternative names
+	EmailAddress  []string `json:"email_addresses,omitempty"` // Email subject alternative names
+	URIs          []string `json:"uris,omitempty"`            // URI subject alternative names
+	Chain         []string `json:"chain,omitempty"`           // Subjects of the verified chain, from the certificate to the root
+	Revoked       bool     `json:"revoked"`                   // Whether Vault reports the certificate as revoked
+	RevocationAt  string   `json:"revocation_time,omitempty"` // Time the certificate was revoked, if it was
+}
+
+var keyUsageNames = []struct {
+	usage x509.KeyUsage
+	name  string
+}{
+	{x509.KeyUsageDigitalSignature, "DigitalSignature"},
+	{x509.KeyUsageContentCommitment, "ContentCommitment"},
+	{x509.KeyUsageKeyEncipherment, "KeyEncipherment"},
+	{x509.KeyUsageDataEncipherment, "DataEncipherment"},
+	{x509.KeyUsageKeyAgreement, "KeyAgreement"},
+	{x509.KeyUsageCertSign, "CertSign"},
+	{x509.KeyUsageCRLSign, "CRLSign"},
+	{x509.KeyUsageEncipherOnly, "EncipherOnly"},
+	{x509.KeyUsageDecipherOnly, "DecipherOnly"},
+}
+
+var extKeyUsageNames = map[x509.ExtKeyUsage]string{
+	x509.ExtKeyUsageAny:             "Any",
+	x509.ExtKeyUsageServerAuth:      "ServerAuth",
+	x509.ExtKeyUsageClientAuth:      "ClientAuth",
+	x509.ExtKeyUsageCodeSigning:     "CodeSigning",
+	x509.ExtKeyUsageEmailProtection: "EmailProtection",
+	x509.ExtKeyUsageTimeStamping:    "TimeStamping",
+	x509.ExtKeyUsageOCSPSigning:     "OCSPSigning",
+}
+
+// VerifyCertificate creates a tool for verifying a certificate against the issuers of a pki mount
+func VerifyCertificate(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("verify_certificate",
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithDescription("Verify a certificate against the issuers of a PKI mount in Vault. Reports whether the chain is valid, the expiry, key usages, subject alternative names and revocation status. Provide either a PEM certificate or the serial number of a certificate issued by the mount."),
+			mcp.WithString("mount",
+				mcp.DefaultString("pki"),
+				mcp.Description("The mount whose issuers are trusted. Defaults to 'pki'."),
+			),
+			mcp.WithString("certificate",
+				mcp.DefaultString(""),
+				mcp.Description("PEM encoded certificate to verify. Any additional certificates in the PEM are used as intermediates."),
+			),
+			mcp.WithString("serial",
+				mcp.DefaultString(""),
+				mcp.Description("Serial number of a certificate issued by the mount, such as '17:67:16:b0:b9:45:58:c0'. Used when 'certificate' is not given."),
+			),
+			schemas.Output("verify_certificate"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return verifyCertificateHandler(ctx, req, logger)
+		},
+	}
+}
+
+func verifyCertificateHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling verify_certificate request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	certificatePEM, _ := args["certificate"].(string)
+	serial, _ := args["serial"].(string)
+	if certificatePEM == "" && serial == "" {
+		return mcp.NewToolResultError("Either 'certificate' or 'serial' must be provided"), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":  mount,
+		"serial": serial,
+	}).Debug("Verifying certificate with parameters")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list mounts: %v", err)), nil
+	}
+
+	// Check if the mount exists
+	if _, ok := mounts[mount+"/"]; !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("mount path '%s' does not exist, you should use 'enable_pki' if you want enable pki on this mount.", mount)), nil
+	}
+
+	// Look the certificate up by serial when no PEM was given
+	if certificatePEM == "" {
+		fullPath := fmt.Sprintf("%s/cert/%s", mount, serial)
+		secret, err := vault.Logical().Read(fullPath)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to read path '%s': %v", fullPath, err)), nil
+		}
+		if secret == nil || secret.Data["certificate"] == nil {
+			return mcp.NewToolResultError(fmt.Sprintf("No certificate found with serial '%s' in mount '%s'", serial, mount)), nil
+		}
+		certificatePEM, _ = secret.Data["certificate"].(string)
+	}
+
+	certificates, err := parseCertificatesPEM(certificatePEM)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'certificate' parameter: %v", err)), nil
+	}
+
+	// Trust every issuer on the mount
+	fullPath := fmt.Sprintf("%s/issuers", mount)
+	issuerList, err := vault.Logical().List(fullPath)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to read path '%s': %v", fullPath, err)), nil
+	}
+
+	var issuers []*x509.Certificate
+	if issuerList != nil {
+		for _, issuerID := range toStringSlice(issuerList.Data["keys"]) {
+			issuer, err := vault.Logical().Read(fmt.Sprintf("%s/issuer/%s", mount, issuerID))
+			if err != nil || issuer == nil {
+				logger.WithError(err).WithField("issuer_id", issuerID).Warn("Failed to read issuer")
+				continue
+			}
+			issuerPEM, _ := issuer.Data["certificate"].(string)
+			issuerCert, err := parseCertificatePEM(issuerPEM)
+			if err != nil {
+				continue
+			}
+			issuers = append(issuers, issuerCert)
+		}
+	}
+
+	result := VerifyCertificateChain(certificates[0], certificates[1:], issuers, time.Now())
+
+	// Check the revocation status reported by Vault
+	fullPath = fmt.Sprintf("%s/cert/%s", mount, result.SerialNumber)
+	if stored, err := vault.Logical().Read(fullPath); err == nil && stored != nil {
+		if revocationTime, ok := stored.Data["revocation_time"].(json.Number); ok {
+			if seconds, err := revocationTime.Int64(); err == nil && seconds > 0 {
+				result.Revoked = true
+				result.Valid = false
+				result.RevocationAt = time.Unix(seconds, 0).UTC().Format(time.RFC3339)
+				if result.Error == "" {
+					result.Error = "certificate has been revoked"
+				}
+			}
+		}
+	}
+
+	// Marshal to JSON
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal verification result to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":  mount,
+		"serial": result.SerialNumber,
+		"valid":  result.Valid,
+	}).Debug("Successfully verified certificate")
+
+	return mcp.NewToolResultStructured(result, string(jsonData)), nil
+}
+
+// VerifyCertificateChain verifies a certificate against the given issuers. Self-signed issuers are
+// trusted as roots and the other issuers and intermediates are used to build the chain.
+func VerifyCertificateChain(certificate *x509.Certificate, intermediates []*x509.Certificate, issuers []*x509.Certificate, now time.Time) *CertificateVerification {
+	result := &CertificateVerification{
+		Subject:       certificate.Subject.String(),
+		Issuer:        certificate.Issuer.String(),
+		SerialNumber:  formatSerial(certificate.SerialNumber.Bytes()),
+		NotBefore:     certificate.NotBefore.UTC().Format(time.RFC3339),
+		NotAfter:      certificate.NotAfter.UTC().Format(time.RFC3339),
+		Expired:       now.After(certificate.NotAfter),
+		DaysRemaining: int(certificate.NotAfter.Sub(now).Hours() / 24),
+		IsCA:          certificate.IsCA,
+		DNSNames:      certificate.DNSNames,
+		EmailAddress:  certificate.EmailAddresses,
+	}
+
+	for _, ku := range keyUsageNames {
+		if certificate.KeyUsage&ku.usage != 0 {
+			result.KeyUsage = append(result.KeyUsage, ku.name)
+		}
+	}
+	for _, eku := range certificate.ExtKeyUsage {
+		if name, ok := extKeyUsageNames[eku]; ok {
+			result.ExtKeyUsage = append(result.ExtKeyUsage, name)
+		} else {
+			result.ExtKeyUsage = append(result.ExtKeyUsage, fmt.Sprintf("Unknown(%d)", eku))
+		}
+	}
+	for _, ip := range certificate.IPAddresses {
+		result.IPAddresses = append(result.IPAddresses, ip.String())
+	}
+	for _, uri := range certificate.URIs {
+		result.URIs = append(result.URIs, uri.String())
+	}
+
+	roots := x509.NewCertPool()
+	intermediatePool := x509.NewCertPool()
+	for _, issuer := range issuers {
+		if bytes.Equal(issuer.RawSubject, issuer.RawIssuer) {
+			roots.AddCert(issuer)
+		} else {
+			intermediatePool.AddCert(issuer)
+		}
+	}
+	for _, intermediate := range intermediates {
+		intermediatePool.AddCert(intermediate)
+	}
+
+	chains, err := certificate.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediatePool,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Valid = true
+	for _, c := range chains[0] {
+		result.Chain = append(result.Chain, c.Subject.String())
+	}
+
+	return result
+}
+
+// parseCertificatesPEM parses every certificate in a PEM encoded string
+func parseCertificatesPEM(data string) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+	if len(certificates) == 0 {
+		return nil, fmt.Errorf("no PEM encoded certificate found")
+	}
+	return certificates, nil
+}
+
+// formatSerial formats a serial number the way Vault does, as colon separated hex bytes
+func formatSerial(serial []byte) string {
+	parts := make([]string, len(serial))
+	for i, b := range serial {
+		parts[i] = fmt.Sprintf("%02x", b)
+	}
+	return strings.Join(parts, ":")
+}
@@ -0,0 +1,105 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package pki
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCertificate creates a certificate signed by parent, or a self-signed CA when parent is nil
+func newTestCertificate(t *testing.T, template *x509.Certificate, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	if parent == nil {
+		parent = template
+		parentKey = key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return certificate, key
+}
+
+func TestVerifyCertificateChain(t *testing.T) {
+	now := time.Now()
+
+	root, rootKey := newTestCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Root CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(365 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}, nil, nil)
+
+	leaf, _ := newTestCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(0x1767),
+		Subject:      pkix.Name{CommonName: "app.internal"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(30 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{"app.internal"},
+		IPAddresses:  []net.IP{net.ParseIP("10.0.0.1")},
+	}, root, rootKey)
+
+	t.Run("valid chain", func(t *testing.T) {
+		result := VerifyCertificateChain(leaf, nil, []*x509.Certificate{root}, now)
+
+		assert.True(t, result.Valid, result.Error)
+		assert.Equal(t, "17:67", result.SerialNumber)
+		assert.Equal(t, []string{"CN=app.internal", "CN=Root CA"}, result.Chain)
+		assert.Equal(t, []string{"DigitalSignature"}, result.KeyUsage)
+		assert.Equal(t, []string{"ServerAuth"}, result.ExtKeyUsage)
+		assert.Equal(t, []string{"app.internal"}, result.DNSNames)
+		assert.Equal(t, []string{"10.0.0.1"}, result.IPAddresses)
+		assert.False(t, result.Expired)
+		assert.Equal(t, 29, result.DaysRemaining)
+	})
+
+	t.Run("untrusted issuer", func(t *testing.T) {
+		otherRoot, _ := newTestCertificate(t, &x509.Certificate{
+			SerialNumber:          big.NewInt(2),
+			Subject:               pkix.Name{CommonName: "Other CA"},
+			NotBefore:             now.Add(-time.Hour),
+			NotAfter:              now.Add(365 * 24 * time.Hour),
+			IsCA:                  true,
+			BasicConstraintsValid: true,
+			KeyUsage:              x509.KeyUsageCertSign,
+		}, nil, nil)
+
+		result := VerifyCertificateChain(leaf, nil, []*x509.Certificate{otherRoot}, now)
+
+		assert.False(t, result.Valid)
+		assert.NotEmpty(t, result.Error)
+	})
+
+	t.Run("expired certificate", func(t *testing.T) {
+		later := now.Add(60 * 24 * time.Hour)
+		result := VerifyCertificateChain(leaf, nil, []*x509.Certificate{root}, later)
+
+		assert.False(t, result.Valid)
+		assert.True(t, result.Expired)
+		assert.Less(t, result.DaysRemaining, 0)
+	})
+}
@@ -0,0 +1,121 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/version"
+	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// ToolPluginsEnv lists the executables of out-of-process tool packs, separated by the OS path list separator
+const ToolPluginsEnv = "MCP_TOOL_PLUGINS"
+
+// pluginStartTimeout bounds how long a plugin may take to start and list its tools
+var pluginStartTimeout = 30 * time.Second
+
+// PluginProvider is a tool pack served by another process. The plugin is an MCP server that the Vault MCP
+// server talks to over RPC, tool calls are forwarded to it unchanged.
+type PluginProvider struct {
+	name   string
+	client *mcpclient.Client
+	tools  []mcp.Tool
+}
+
+// NewPluginProvider initializes an MCP client connected to a plugin and lists the tools of the plugin
+func NewPluginProvider(ctx context.Context, name string, c *mcpclient.Client) (*PluginProvider, error) {
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{
+		Name:    "vault-mcp-server",
+		Version: version.GetHumanVersion(),
+	}
+	if _, err := c.Initialize(ctx, initRequest); err != nil {
+		return nil, fmt.Errorf("failed to initialize plugin %s: %w", name, err)
+	}
+
+	result, err := c.ListTools(ctx, mcp.ListToolsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the tools of plugin %s: %w", name, err)
+	}
+
+	return &PluginProvider{name: name, client: c, tools: result.Tools}, nil
+}
+
+// StartToolPlugin starts a plugin executable that serves MCP on its standard input and output. The plugin
+// inherits the environment of the server, so it reaches Vault with the same VAULT_* settings.
+func StartToolPlugin(ctx context.Context, command string) (*PluginProvider, error) {
+	c, err := mcpclient.NewStdioMCPClient(command, os.Environ())
+	if err != nil {
+		return nil, fmt.Errorf("failed to start plugin %s: %w", command, err)
+	}
+
+	name := strings.TrimSuffix(filepath.Base(command), filepath.Ext(command))
+	provider, err := NewPluginProvider(ctx, name, c)
+	if err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+	return provider, nil
+}
+
+// LoadToolPluginsFromEnv starts the plugins listed in MCP_TOOL_PLUGINS. Plugins that fail to start are
+// logged and skipped so that a broken plugin does not take the server down.
+func LoadToolPluginsFromEnv(logger *log.Logger) []ToolProvider {
+	var plugins []ToolProvider
+	for _, command := range filepath.SplitList(os.Getenv(ToolPluginsEnv)) {
+		command = strings.TrimSpace(command)
+		if command == "" {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), pluginStartTimeout)
+		provider, err := StartToolPlugin(ctx, command)
+		cancel()
+		if err != nil {
+			logger.WithError(err).Errorf("Failed to load tool plugin %s", command)
+			continue
+		}
+		plugins = append(plugins, provider)
+	}
+	return plugins
+}
+
+func (p *PluginProvider) Name() string {
+	return p.name
+}
+
+func (p *PluginProvider) Tools(logger *log.Logger) []server.ServerTool {
+	tools := make([]server.ServerTool, 0, len(p.tools))
+	for _, tool := range p.tools {
+		tools = append(tools, server.ServerTool{
+			Tool: tool,
+			Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				logger.Debugf("Forwarding %s request to plugin %s", req.Params.Name, p.name)
+
+				result, err := p.client.CallTool(ctx, req)
+				if err != nil {
+					logger.WithError(err).Errorf("Plugin %s failed to handle %s", p.name, req.Params.Name)
+					return mcp.NewToolResultError(fmt.Sprintf("Plugin %s failed: %v", p.name, err)), nil
+				}
+				return result, nil
+			},
+		})
+	}
+	return tools
+}
+
+// Close stops the plugin
+func (p *PluginProvider) Close() error {
+	return p.client.Close()
+}
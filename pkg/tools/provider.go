@@ -0,0 +1,88 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// ToolProvider is a pack of tools that InitTools registers next to the built-in tools. Organizations can
+// ship private tool packs, such as tools for a custom secret engine, by calling RegisterToolProvider from
+// the init function of their package and importing that package in their build of the server.
+type ToolProvider interface {
+	// Name identifies the tool pack, it is also reported as a tool category by server_info
+	Name() string
+	// Tools returns the tools of the pack. Their handlers get Vault clients with client.GetVaultClientFromContext.
+	Tools(logger *log.Logger) []server.ServerTool
+}
+
+var (
+	providers   = map[string]ToolProvider{}
+	providersMu sync.RWMutex
+)
+
+// RegisterToolProvider adds a tool pack to the registry. It panics when a pack with the same name
+// is already registered, like the registries of database/sql drivers.
+func RegisterToolProvider(provider ToolProvider) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+
+	if provider == nil {
+		panic("tools: RegisterToolProvider provider is nil")
+	}
+	name := provider.Name()
+	if _, exists := providers[name]; exists {
+		panic(fmt.Sprintf("tools: RegisterToolProvider called twice for provider %s", name))
+	}
+	providers[name] = provider
+}
+
+// UnregisterToolProvider removes a tool pack from the registry
+func UnregisterToolProvider(name string) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	delete(providers, name)
+}
+
+// ToolProviders returns the registered tool packs sorted by name
+func ToolProviders() []ToolProvider {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+
+	registered := make([]ToolProvider, 0, len(providers))
+	for _, provider := range providers {
+		registered = append(registered, provider)
+	}
+	sort.Slice(registered, func(i, j int) bool {
+		return registered[i].Name() < registered[j].Name()
+	})
+	return registered
+}
+
+// addProviderTools registers the tools of the given packs, skipping tools whose name is already taken.
+// It returns the names of the packs that registered at least one tool.
+func addProviderTools(hcServer *server.MCPServer, packs []ToolProvider, logger *log.Logger) []string {
+	var names []string
+	for _, provider := range packs {
+		added := 0
+		for _, tool := range provider.Tools(logger) {
+			if hcServer.GetTool(tool.Tool.Name) != nil {
+				logger.Warnf("Tool %s of provider %s conflicts with a registered tool and was skipped", tool.Tool.Name, provider.Name())
+				continue
+			}
+			hcServer.AddTool(tool.Tool, tool.Handler)
+			added++
+		}
+		logger.Infof("Registered %d tools of provider %s", added, provider.Name())
+		if added > 0 {
+			names = append(names, provider.Name())
+		}
+	}
+	return names
+}
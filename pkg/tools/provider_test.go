@@ -0,0 +1,115 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testProvider struct {
+	name  string
+	tools []string
+}
+
+func (p testProvider) Name() string {
+	return p.name
+}
+
+func (p testProvider) Tools(_ *log.Logger) []server.ServerTool {
+	var tools []server.ServerTool
+	for _, name := range p.tools {
+		tools = append(tools, server.ServerTool{
+			Tool: mcp.NewTool(name, mcp.WithDescription("Test tool")),
+			Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return mcp.NewToolResultText("called " + req.Params.Name), nil
+			},
+		})
+	}
+	return tools
+}
+
+func TestToolProviderRegistry(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	RegisterToolProvider(testProvider{name: "custom-engine", tools: []string{"read_custom_secret", "list_secrets"}})
+	defer UnregisterToolProvider("custom-engine")
+
+	assert.Panics(t, func() {
+		RegisterToolProvider(testProvider{name: "custom-engine"})
+	})
+
+	hcServer := server.NewMCPServer("test", "1.0.0")
+	InitTools(hcServer, logger)
+
+	tool := hcServer.GetTool("read_custom_secret")
+	require.NotNil(t, tool)
+	assert.Contains(t, tool.Tool.InputSchema.Properties, client.NamespaceArgument)
+
+	// Built-in tools cannot be replaced by a provider
+	listSecrets := hcServer.GetTool("list_secrets")
+	require.NotNil(t, listSecrets)
+	assert.NotEqual(t, "Test tool", listSecrets.Tool.Description)
+
+	result, err := hcServer.GetTool("server_info").Handler(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	var info ServerInfoReport
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &info))
+	assert.Contains(t, info.ToolCategories, "custom-engine")
+}
+
+func TestPluginProvider(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	// The plugin is an MCP server, served in process instead of as a separate executable
+	plugin := server.NewMCPServer("plugin", "1.0.0")
+	plugin.AddTool(mcp.NewTool("greet", mcp.WithString("name", mcp.Required())),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := req.RequireString("name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			return mcp.NewToolResultText("hello " + name), nil
+		})
+
+	c, err := mcpclient.NewInProcessClient(plugin)
+	require.NoError(t, err)
+	require.NoError(t, c.Start(context.Background()))
+	defer c.Close()
+
+	provider, err := NewPluginProvider(context.Background(), "greeter", c)
+	require.NoError(t, err)
+	assert.Equal(t, "greeter", provider.Name())
+
+	tools := provider.Tools(logger)
+	require.Len(t, tools, 1)
+	assert.Equal(t, "greet", tools[0].Tool.Name)
+
+	req := mcp.CallToolRequest{}
+	req.Params.Name = "greet"
+	req.Params.Arguments = map[string]any{"name": "vault"}
+	result, err := tools[0].Handler(context.Background(), req)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, "hello vault", result.Content[0].(mcp.TextContent).Text)
+}
+
+func TestLoadToolPluginsFromEnvSkipsBrokenPlugins(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.FatalLevel)
+
+	t.Setenv(ToolPluginsEnv, "/nonexistent/vault-tools-plugin")
+	assert.Empty(t, LoadToolPluginsFromEnv(logger))
+}
@@ -0,0 +1,20 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Command gen writes the output schemas and examples of the tools with structured results to the current
+// directory, it is run by 'go generate' in pkg/tools/schemas
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/tools"
+)
+
+func main() {
+	if err := tools.WriteOutputSchemas("."); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to generate the output schemas: %v\n", err)
+		os.Exit(1)
+	}
+}
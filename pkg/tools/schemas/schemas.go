@@ -0,0 +1,85 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Package schemas holds the output schemas and example calls of the tools that return structured results.
+// The files are generated from the Go result types of the tools, run 'go generate ./pkg/tools/schemas' after
+// changing a result type or an example.
+package schemas
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+//go:generate go run ./gen
+
+//go:embed *.json
+var files embed.FS
+
+// Definition is the generated definition of a tool with a structured result
+type Definition struct {
+	OutputSchema json.RawMessage `json:"outputSchema"`
+	Examples     []Example       `json:"examples"`
+}
+
+// Example is an example call of a tool and its result
+type Example struct {
+	Arguments map[string]any  `json:"arguments"`
+	Result    json.RawMessage `json:"result"`
+}
+
+// FileName returns the name of the generated definition file of a tool
+func FileName(tool string) string {
+	return tool + ".json"
+}
+
+// File returns the generated definition file of a tool
+func File(tool string) ([]byte, error) {
+	return files.ReadFile(FileName(tool))
+}
+
+// Lookup returns the generated definition of a tool, or false when the tool has none
+func Lookup(tool string) (Definition, bool) {
+	var definition Definition
+	data, err := File(tool)
+	if err != nil {
+		return definition, false
+	}
+	if err := json.Unmarshal(data, &definition); err != nil {
+		return definition, false
+	}
+	return definition, true
+}
+
+// Output attaches the generated output schema and examples of the tool to its definition. The examples
+// are listed in the '_meta' of the tool for clients, and the arguments of the first example are appended
+// to the description for models, so the option must follow WithDescription.
+func Output(tool string) mcp.ToolOption {
+	return func(t *mcp.Tool) {
+		definition, ok := Lookup(tool)
+		if !ok {
+			return
+		}
+		t.RawOutputSchema = definition.OutputSchema
+		if len(definition.Examples) == 0 {
+			return
+		}
+
+		if t.Meta == nil {
+			t.Meta = &mcp.Meta{}
+		}
+		if t.Meta.AdditionalFields == nil {
+			t.Meta.AdditionalFields = map[string]any{}
+		}
+		t.Meta.AdditionalFields["examples"] = definition.Examples
+
+		arguments, err := json.Marshal(definition.Examples[0].Arguments)
+		if err == nil {
+			t.Description = fmt.Sprintf("%s\nExample arguments: %s", strings.TrimSpace(t.Description), arguments)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault-mcp-server/version"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// ServerInfoReport describes how the MCP server is built and configured
+type ServerInfoReport struct {
+	Version        string         `json:"version"`
+	GitCommit      string         `json:"git_commit,omitempty"`
+	BuildDate      string         `json:"build_date"`
+	GoVersion      string         `json:"go_version"`
+	ToolCategories []string       `json:"tool_categories"` // Categories of registered tools
+	ToolCount      int            `json:"tool_count"`
+	Limits         ServerLimits   `json:"limits"`
+	Security       ServerSecurity `json:"security"`
+	Vault          VaultSettings  `json:"vault"`
+}
+
+type ServerLimits struct {
+	GlobalRateLimit           float64            `json:"global_rate_limit"`            // Tool calls per second across all sessions
+	GlobalBurst               int                `json:"global_burst"`                 // Burst capacity across all sessions
+	SessionRateLimit          float64            `json:"session_rate_limit"`           // Tool calls per second per session
+	SessionBurst              int                `json:"session_burst"`                // Burst capacity per session
+	ClientRateLimit           float64            `json:"client_rate_limit"`            // Tool calls per second per client IP, 0 is disabled
+	ClientBurst               int                `json:"client_burst"`                 // Burst capacity per client IP
+	SessionMaxToolCalls       int64              `json:"session_max_tool_calls"`       // Tool call budget per session, 0 is unlimited
+	SessionMaxMutatingCalls   int64              `json:"session_max_mutating_calls"`   // Mutating tool call budget per session, 0 is unlimited
+	SessionMaxVaultRequests   int64              `json:"session_max_vault_requests"`   // Vault request budget per session, 0 is unlimited
+	CircuitBreakerThreshold   int                `json:"circuit_breaker_threshold"`    // Consecutive failures before calls fail fast, 0 is disabled
+	CircuitBreakerCooldownSec float64            `json:"circuit_breaker_cooldown_sec"` // Seconds calls fail fast before Vault is tried again
+	MaxResultBytes            int                `json:"max_result_bytes"`             // Size cap of large reports and listings
+	ToolTimeoutSec            float64            `json:"tool_timeout_sec"`             // Timeout of tool calls without an override, 0 is disabled
+	ToolTimeoutsSec           map[string]float64 `json:"tool_timeouts_sec,omitempty"`  // Timeouts of individual tools
+}
+
+type ServerSecurity struct {
+	TLSEnabled       bool   `json:"tls_enabled"` // Whether the HTTP transport serves TLS
+	CORSMode         string `json:"cors_mode"`
+	AllowedOrigins   int    `json:"allowed_origins"`             // Number of origins allowed by CORS
+	TrustedProxies   int    `json:"trusted_proxies"`             // Number of proxy addresses and ranges whose forwarding headers are trusted
+	AdminTools       bool   `json:"admin_tools"`                 // Whether the cluster administration tools are registered
+	ApprovalRequired string `json:"approval_required,omitempty"` // Tool calls waiting for a human approver, 'destructive' or tool names
+}
+
+type VaultSettings struct {
+	Address         string `json:"address"`
+	Namespace       string `json:"namespace,omitempty"`
+	TokenConfigured bool   `json:"token_configured"` // Whether the session authenticates to Vault with a token
+	ProxyAddress    string `json:"proxy_address,omitempty"`
+	ReadAddress     string `json:"read_address,omitempty"` // Read replica serving read-only tools
+}
+
+// ServerInfo creates a tool reporting the version and configuration of the MCP server
+func ServerInfo(hcServer *server.MCPServer, categories []string, logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("server_info",
+			mcp.WithDescription("Report the version and build of this MCP server, the enabled tool categories, the configured rate limits, session budgets and circuit breaker, whether TLS and CORS are active, and the Vault address and authentication used by the session. No secrets are returned."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			schemas.Output("server_info"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return serverInfoHandler(ctx, hcServer, categories, logger)
+		},
+	}
+}
+
+func serverInfoHandler(ctx context.Context, hcServer *server.MCPServer, categories []string, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling server_info request")
+
+	rateLimit := client.LoadRateLimitConfigFromEnv()
+	budget := client.LoadBudgetConfigFromEnv()
+	circuitBreaker := client.LoadCircuitBreakerConfigFromEnv()
+	cors := client.LoadCORSConfigFromEnv()
+	tlsConfig, _ := client.GetTLSConfigFromEnv()
+	trustedProxies, _ := client.LoadTrustedProxiesFromEnv()
+	toolTimeouts := client.LoadToolTimeoutConfigFromEnv()
+
+	info := ServerInfoReport{
+		Version:        version.GetHumanVersion(),
+		GitCommit:      version.GitCommit,
+		BuildDate:      version.BuildDate,
+		GoVersion:      runtime.Version(),
+		ToolCategories: categories,
+		ToolCount:      len(hcServer.ListTools()),
+		Limits: ServerLimits{
+			GlobalRateLimit:           float64(rateLimit.GlobalLimit),
+			GlobalBurst:               rateLimit.GlobalBurst,
+			SessionRateLimit:          float64(rateLimit.PerSessionLimit),
+			SessionBurst:              rateLimit.PerSessionBurst,
+			ClientRateLimit:           float64(rateLimit.PerClientLimit),
+			ClientBurst:               rateLimit.PerClientBurst,
+			SessionMaxToolCalls:       budget.MaxToolCalls,
+			SessionMaxMutatingCalls:   budget.MaxMutatingCalls,
+			SessionMaxVaultRequests:   budget.MaxVaultRequests,
+			CircuitBreakerThreshold:   circuitBreaker.FailureThreshold,
+			CircuitBreakerCooldownSec: circuitBreaker.Cooldown.Seconds(),
+			MaxResultBytes:            utils.MaxResultBytes,
+			ToolTimeoutSec:            toolTimeouts.Default.Seconds(),
+		},
+		Security: ServerSecurity{
+			TLSEnabled:       tlsConfig != nil,
+			CORSMode:         cors.Mode,
+			AllowedOrigins:   len(cors.AllowedOrigins),
+			TrustedProxies:   len(trustedProxies),
+			AdminTools:       adminToolsEnabled(logger),
+			ApprovalRequired: strings.TrimSpace(os.Getenv(client.ApprovalRequiredEnv)),
+		},
+		Vault: VaultSettings{
+			ProxyAddress: os.Getenv(client.VaultProxyAddress),
+			ReadAddress:  os.Getenv(client.VaultReadAddress),
+		},
+	}
+
+	if len(toolTimeouts.Tools) > 0 {
+		info.Limits.ToolTimeoutsSec = make(map[string]float64, len(toolTimeouts.Tools))
+		for name, timeout := range toolTimeouts.Tools {
+			info.Limits.ToolTimeoutsSec[name] = timeout.Seconds()
+		}
+	}
+
+	// The Vault settings are only known once the session has a client
+	if vault, err := client.GetVaultClientFromContext(ctx, logger); err == nil {
+		info.Vault.Address = vault.Address()
+		info.Vault.Namespace = vault.Namespace()
+		info.Vault.TokenConfigured = vault.Token() != ""
+	}
+
+	jsonData, err := json.Marshal(info)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal server info to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	return mcp.NewToolResultStructured(info, string(jsonData)), nil
+}
@@ -0,0 +1,70 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// SessionChangesReport lists the changes made in Vault by the tools of the session
+type SessionChangesReport struct {
+	Changes []client.ChangeRecord `json:"changes"` // Changes in the order they were made
+	Total   int                   `json:"total"`   // Number of changes kept for the session
+}
+
+// SessionChanges creates a tool listing the changes made in Vault during the current session
+func SessionChanges(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("get_session_changes",
+			mcp.WithDescription("List the changes that tools made in Vault during the current MCP session, such as created mounts, written secrets and updated roles, with the previous and new state of each resource. Secret values are never included. Use it to review what a session did or to find a change to revert."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithNumber("limit",
+				mcp.Min(1),
+				mcp.Description("Only return the most recent changes, up to this number. All changes of the session are returned by default.")),
+			schemas.Output("get_session_changes"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return sessionChangesHandler(ctx, req, logger)
+		},
+	}
+}
+
+func sessionChangesHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling get_session_changes request")
+
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return mcp.NewToolResultError("No active session"), nil
+	}
+
+	changes := client.SessionChanges(session.SessionID())
+	report := SessionChangesReport{Changes: changes, Total: len(changes)}
+	if limit := req.GetInt("limit", 0); limit > 0 && limit < len(changes) {
+		report.Changes = changes[len(changes)-limit:]
+	}
+	if report.Changes == nil {
+		report.Changes = []client.ChangeRecord{}
+	}
+
+	jsonData, err := json.Marshal(report)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal session changes to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	return mcp.NewToolResultStructured(report, string(jsonData)), nil
+}
@@ -0,0 +1,242 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// Deprecation statuses reported by Vault for builtin plugins
+const (
+	DeprecationSupported      = "supported"
+	DeprecationDeprecated     = "deprecated"
+	DeprecationPendingRemoval = "pending-removal"
+	DeprecationRemoved        = "removed"
+)
+
+// migrationGuidance is the known migration path away from a legacy plugin
+type migrationGuidance struct {
+	replacement string
+	guidance    string
+}
+
+// secretsEngineGuidance holds the migration paths of legacy secrets engines, by type
+var secretsEngineGuidance = map[string]migrationGuidance{
+	"ad":         {"ldap", "Migrate the roles and libraries of the Active Directory secrets engine to the LDAP secrets engine with schema 'ad'"},
+	"openldap":   {"ldap", "Mount the LDAP secrets engine, which replaces the OpenLDAP secrets engine, and move the roles to it"},
+	"generic":    {"kv", "Mount a KV version 2 secrets engine and move the secrets of the generic mount to it"},
+	"cassandra":  {"database", "Configure the Cassandra plugin of the database secrets engine and recreate the roles there"},
+	"mongodb":    {"database", "Configure the MongoDB plugin of the database secrets engine and recreate the roles there"},
+	"mssql":      {"database", "Configure the MSSQL plugin of the database secrets engine and recreate the roles there"},
+	"mysql":      {"database", "Configure the MySQL plugin of the database secrets engine and recreate the roles there"},
+	"postgresql": {"database", "Configure the PostgreSQL plugin of the database secrets engine and recreate the roles there"},
+}
+
+// authMethodGuidance holds the migration paths of legacy auth methods, by type
+var authMethodGuidance = map[string]migrationGuidance{
+	"app-id": {"approle", "Create an AppRole role for every app ID and move the clients to AppRole logins"},
+	"pcf":    {"cf", "Enable the cf auth method, which replaces the pcf auth method, and move the roles and clients to it"},
+}
+
+type PluginStatus struct {
+	Path              string `json:"path"`                         // Path of the mount, auth methods are prefixed with 'auth/'
+	Type              string `json:"type"`                         // Type of the secrets engine or auth method
+	DeprecationStatus string `json:"deprecation_status,omitempty"` // Deprecation status reported by Vault for builtin plugins
+	PluginVersion     string `json:"plugin_version,omitempty"`     // Version the mount is pinned to, if any
+	RunningVersion    string `json:"running_version,omitempty"`    // Version of the plugin that is running
+	External          bool   `json:"external"`                     // Whether the plugin is an external plugin from the plugin catalog
+	Replacement       string `json:"replacement,omitempty"`        // Type of the plugin to migrate to, if the plugin has a known replacement
+}
+
+type DeprecationReport struct {
+	VaultVersion string           `json:"vault_version,omitempty"`    // Version of the Vault server, if it could be read
+	Ready        bool             `json:"ready"`                      // Whether no mount uses a plugin that is removed or pending removal
+	Mounts       []*PluginStatus  `json:"mounts"`                     // Plugin of every secrets engine and auth method
+	Findings     []*Finding       `json:"findings"`                   // Migrations to plan before upgrading, sorted by severity
+	Plan         *RemediationPlan `json:"remediation_plan,omitempty"` // Drafted by the model of the client when generate_plan is set
+}
+
+// AnalyzeDeprecations creates a tool for reporting deprecated plugins and their migration paths before a Vault upgrade
+func AnalyzeDeprecations(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("analyze_deprecations",
+			mcp.WithDescription("Report the secrets engines and auth methods that use deprecated, pending removal or removed plugins, legacy plugins with a known replacement and external plugins, with the migration path of each, and whether the cluster is ready for a Vault upgrade. Vault refuses to mount plugins that are pending removal or removed, so migrate them before upgrading."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			withGeneratePlan(),
+			schemas.Output("analyze_deprecations"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return analyzeDeprecationsHandler(ctx, req, logger)
+		},
+	}
+}
+
+func analyzeDeprecationsHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling analyze_deprecations request")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		logger.WithError(err).Error("Failed to list mounts")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list mounts: %v", err)), nil
+	}
+
+	auths, err := vault.Sys().ListAuth()
+	if err != nil {
+		logger.WithError(err).Error("Failed to list auth methods")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list auth methods: %v", err)), nil
+	}
+
+	report := AnalyzePluginDeprecations(mounts, auths)
+	if status, err := vault.Sys().SealStatus(); err == nil && status != nil {
+		report.VaultVersion = status.Version
+	}
+	if req.GetBool("generate_plan", false) {
+		report.Plan = draftRemediationPlan(ctx, "deprecation analysis", report.Findings)
+	}
+
+	jsonData, err := json.Marshal(report)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal deprecation report to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"finding_count": len(report.Findings),
+		"ready":         report.Ready,
+	}).Debug("Successfully analyzed deprecations")
+	return mcp.NewToolResultStructured(report, string(jsonData)), nil
+}
+
+// AnalyzePluginDeprecations compares the plugins of the mounts against their deprecation status and the known
+// migration paths of legacy plugins
+func AnalyzePluginDeprecations(mounts map[string]*api.MountOutput, auths map[string]*api.AuthMount) *DeprecationReport {
+	report := &DeprecationReport{
+		Ready:    true,
+		Mounts:   []*PluginStatus{},
+		Findings: []*Finding{},
+	}
+
+	add := func(path string, mount *api.MountOutput, component string, known map[string]migrationGuidance) {
+		status := &PluginStatus{
+			Path:              path,
+			Type:              mount.Type,
+			DeprecationStatus: mount.DeprecationStatus,
+			PluginVersion:     mount.PluginVersion,
+			RunningVersion:    mount.RunningVersion,
+			External:          mount.RunningSha256 != "",
+		}
+		guidance, hasGuidance := known[mount.Type]
+		if hasGuidance {
+			status.Replacement = guidance.replacement
+		}
+		report.Mounts = append(report.Mounts, status)
+
+		if finding := deprecationFinding(status, component, guidance.guidance); finding != nil {
+			report.Findings = append(report.Findings, finding)
+			if finding.Severity == SeverityCritical || finding.Severity == SeverityHigh {
+				report.Ready = false
+			}
+		}
+		if status.PluginVersion != "" && status.RunningVersion != "" && status.PluginVersion != status.RunningVersion {
+			report.Findings = append(report.Findings, &Finding{
+				Severity:       SeverityLow,
+				Component:      component,
+				Message:        fmt.Sprintf("Mount '%s' is pinned to %s version %s but runs version %s", path, mount.Type, status.PluginVersion, status.RunningVersion),
+				Recommendation: "Reload the plugin of the mount so that it runs the pinned version before upgrading",
+			})
+		}
+	}
+
+	for path, mount := range mounts {
+		add(strings.TrimSuffix(path, "/"), mount, "secrets_engine", secretsEngineGuidance)
+	}
+	for path, auth := range auths {
+		add("auth/"+strings.TrimSuffix(path, "/"), auth, "auth_method", authMethodGuidance)
+	}
+
+	sort.Slice(report.Mounts, func(i, j int) bool {
+		return report.Mounts[i].Path < report.Mounts[j].Path
+	})
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		if severityOrder[report.Findings[i].Severity] != severityOrder[report.Findings[j].Severity] {
+			return severityOrder[report.Findings[i].Severity] < severityOrder[report.Findings[j].Severity]
+		}
+		return report.Findings[i].Message < report.Findings[j].Message
+	})
+
+	return report
+}
+
+// deprecationFinding reports a plugin that must or should be migrated before an upgrade, or nil when it needs no attention
+func deprecationFinding(status *PluginStatus, component, guidance string) *Finding {
+	recommendation := guidance
+	if recommendation == "" {
+		recommendation = fmt.Sprintf("Check the deprecation notices of the Vault release notes for a replacement of the %s plugin", status.Type)
+	}
+
+	switch status.DeprecationStatus {
+	case DeprecationRemoved:
+		return &Finding{
+			Severity:       SeverityCritical,
+			Component:      component,
+			Message:        fmt.Sprintf("Mount '%s' uses the %s plugin, which was removed from Vault", status.Path, status.Type),
+			Recommendation: recommendation,
+		}
+	case DeprecationPendingRemoval:
+		return &Finding{
+			Severity:       SeverityHigh,
+			Component:      component,
+			Message:        fmt.Sprintf("Mount '%s' uses the %s plugin, which is pending removal, Vault fails to unseal with it unless VAULT_ALLOW_PENDING_REMOVAL_MOUNTS is set", status.Path, status.Type),
+			Recommendation: recommendation,
+		}
+	case DeprecationDeprecated:
+		return &Finding{
+			Severity:       SeverityMedium,
+			Component:      component,
+			Message:        fmt.Sprintf("Mount '%s' uses the %s plugin, which is deprecated and will be removed in a future Vault release", status.Path, status.Type),
+			Recommendation: recommendation,
+		}
+	}
+
+	if guidance != "" {
+		return &Finding{
+			Severity:       SeverityLow,
+			Component:      component,
+			Message:        fmt.Sprintf("Mount '%s' uses the legacy %s plugin, which is replaced by the %s plugin", status.Path, status.Type, status.Replacement),
+			Recommendation: guidance,
+		}
+	}
+	if status.External {
+		return &Finding{
+			Severity:       SeverityInfo,
+			Component:      component,
+			Message:        fmt.Sprintf("Mount '%s' uses the external %s plugin version %s, which is not upgraded with Vault", status.Path, status.Type, status.RunningVersion),
+			Recommendation: "Check that the plugin supports the target Vault version, and register a newer version in the plugin catalog if needed",
+		}
+	}
+	return nil
+}
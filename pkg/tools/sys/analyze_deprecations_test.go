@@ -0,0 +1,62 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzePluginDeprecations(t *testing.T) {
+	mounts := map[string]*api.MountOutput{
+		"secret/": {Type: "kv", DeprecationStatus: DeprecationSupported, RunningVersion: "v0.21.0+builtin"},
+		"ad/":     {Type: "ad", DeprecationStatus: DeprecationPendingRemoval, RunningVersion: "v0.20.0+builtin"},
+		"legacy/": {Type: "generic", DeprecationStatus: DeprecationSupported},
+		"custom/": {Type: "custom-kv", RunningVersion: "v1.2.0", RunningSha256: "a1b2c3", PluginVersion: "v1.1.0"},
+	}
+	auths := map[string]*api.AuthMount{
+		"userpass/": {Type: "userpass", DeprecationStatus: DeprecationSupported},
+		"old/":      {Type: "app-id", DeprecationStatus: DeprecationRemoved},
+	}
+
+	report := AnalyzePluginDeprecations(mounts, auths)
+
+	assert.False(t, report.Ready)
+	require.Len(t, report.Mounts, 6)
+	assert.Equal(t, "ad", report.Mounts[0].Path)
+	assert.Equal(t, "ldap", report.Mounts[0].Replacement)
+	assert.Equal(t, "auth/old", report.Mounts[1].Path)
+	assert.Equal(t, "approle", report.Mounts[1].Replacement)
+	assert.True(t, report.Mounts[3].External, "plugins with a SHA256 come from the plugin catalog")
+
+	require.Len(t, report.Findings, 5)
+	assert.Equal(t, SeverityCritical, report.Findings[0].Severity)
+	assert.Contains(t, report.Findings[0].Message, "'auth/old'")
+	assert.Contains(t, report.Findings[0].Recommendation, "AppRole")
+	assert.Equal(t, SeverityHigh, report.Findings[1].Severity)
+	assert.Contains(t, report.Findings[1].Message, "'ad'")
+	assert.Equal(t, SeverityLow, report.Findings[2].Severity)
+	assert.Contains(t, report.Findings[2].Message, "'custom' is pinned to custom-kv version v1.1.0")
+	assert.Equal(t, SeverityLow, report.Findings[3].Severity)
+	assert.Contains(t, report.Findings[3].Message, "legacy generic plugin")
+	assert.Equal(t, SeverityInfo, report.Findings[4].Severity)
+	assert.Contains(t, report.Findings[4].Message, "external custom-kv plugin")
+}
+
+func TestAnalyzePluginDeprecations_Ready(t *testing.T) {
+	mounts := map[string]*api.MountOutput{
+		"secret/": {Type: "kv", DeprecationStatus: DeprecationSupported},
+		"ldap/":   {Type: "openldap", DeprecationStatus: DeprecationDeprecated},
+	}
+
+	report := AnalyzePluginDeprecations(mounts, nil)
+
+	assert.True(t, report.Ready, "deprecated plugins keep working after an upgrade")
+	require.Len(t, report.Findings, 1)
+	assert.Equal(t, SeverityMedium, report.Findings[0].Severity)
+	assert.Contains(t, report.Findings[0].Recommendation, "LDAP secrets engine")
+}
@@ -0,0 +1,365 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/auth"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// MaxRecommendedTokenTTL is the longest maximum token TTL of an auth method that is not reported
+	MaxRecommendedTokenTTL = 768 * time.Hour
+
+	// DefaultMaxAnalyzedNamespaces is the number of child namespaces analyzed by default
+	DefaultMaxAnalyzedNamespaces = 50
+)
+
+// passwordAuthTypes are the auth methods that authenticate users with a password, which should require MFA
+var passwordAuthTypes = map[string]bool{
+	"userpass": true,
+	"ldap":     true,
+	"okta":     true,
+	"radius":   true,
+}
+
+type SecurityHealthReport struct {
+	Findings      []*Finding                `json:"findings"`                    // Findings sorted by severity
+	Summary       map[string]int            `json:"summary"`                     // Number of findings per severity
+	Namespaces    map[string]map[string]int `json:"namespace_summary,omitempty"` // Number of findings per severity of each analyzed namespace, with include_child_namespaces
+	SkippedChecks []string                  `json:"skipped_checks,omitempty"`    // Checks that could not run, usually because of missing permissions
+	Plan          *RemediationPlan          `json:"remediation_plan,omitempty"`  // Drafted by the model of the client when generate_plan is set
+	Truncated     bool                      `json:"truncated,omitempty"`         // The least severe findings were left out to stay within the maximum result size
+	Omitted       int                       `json:"omitted_findings,omitempty"`  // Number of findings left out, they are still counted in the summary
+}
+
+// AnalyzeSecurityHealth creates a tool for assessing the overall security posture of a Vault cluster
+func AnalyzeSecurityHealth(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("analyze_security_health",
+			mcp.WithDescription("Analyze the security posture of the Vault cluster and report findings sorted by severity: audit devices, server configuration (listeners, storage, security flags), KV mounts without versioning, auth methods with long token TTLs and password based auth methods without login MFA. With include_child_namespaces, the child namespaces are analyzed too and every finding names its namespace. Checks that the token is not allowed to run are reported as skipped."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithBoolean("include_child_namespaces",
+				mcp.DefaultBool(false),
+				mcp.Description("Also analyze the secrets engines, auth methods and login MFA of the child namespaces of the namespace of the call, recursively, and count the findings per namespace. Vault Enterprise only. Defaults to false."),
+			),
+			mcp.WithNumber("max_namespaces",
+				mcp.DefaultNumber(DefaultMaxAnalyzedNamespaces),
+				mcp.Description("The maximum number of child namespaces analyzed with include_child_namespaces. Defaults to 50."),
+			),
+			withGeneratePlan(),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return analyzeSecurityHealthHandler(ctx, req, logger)
+		},
+	}
+}
+
+func analyzeSecurityHealthHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling analyze_security_health request")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	includeChildren := req.GetBool("include_child_namespaces", false)
+	maxNamespaces := req.GetInt("max_namespaces", DefaultMaxAnalyzedNamespaces)
+	if maxNamespaces <= 0 {
+		return mcp.NewToolResultError("'max_namespaces' must be greater than 0"), nil
+	}
+
+	report := newSecurityHealthReport()
+	skip := func(check string, err error) {
+		logger.WithError(err).WithField("check", check).Debug("Skipping security check")
+		report.SkippedChecks = append(report.SkippedChecks, fmt.Sprintf("%s: %v", check, err))
+	}
+
+	// Audit devices and the server configuration belong to the cluster, they are only read once
+	if audits, err := vault.Sys().ListAudit(); err != nil {
+		skip("audit devices", err)
+	} else {
+		report.add(analyzeAuditDevices(audits))
+	}
+
+	if secret, err := vault.Logical().Read("sys/config/state/sanitized"); err != nil {
+		skip("server configuration", err)
+	} else if secret != nil && secret.Data != nil {
+		report.add(AnalyzeSanitizedConfig(secret.Data).Findings)
+	}
+
+	if !includeChildren {
+		report.add(analyzeNamespace(vault, skip))
+	} else {
+		namespaces := listChildNamespaces(vault, maxNamespaces, func(namespace string, err error) {
+			skip("child namespaces of namespace "+namespaceLabel(vault.Namespace(), namespace), err)
+		})
+		for _, namespace := range append([]string{""}, namespaces...) {
+			label := namespaceLabel(vault.Namespace(), namespace)
+			nsClient := vault.WithNamespace(path.Join(vault.Namespace(), namespace))
+			findings := analyzeNamespace(nsClient, func(check string, err error) {
+				skip(fmt.Sprintf("%s in namespace %s", check, label), err)
+			})
+			report.Namespaces[label] = map[string]int{}
+			for _, finding := range findings {
+				finding.Namespace = label
+				report.Namespaces[label][finding.Severity]++
+			}
+			report.add(findings)
+		}
+	}
+
+	report.summarize()
+
+	if req.GetBool("generate_plan", false) {
+		report.Plan = draftRemediationPlan(ctx, "security health analysis", report.Findings)
+	}
+
+	jsonData, err := report.encode(utils.MaxResultBytes)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal security health report to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"finding_count": len(report.Findings),
+		"omitted":       report.Omitted,
+		"skipped":       len(report.SkippedChecks),
+	}).Debug("Successfully analyzed security health")
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+func newSecurityHealthReport() *SecurityHealthReport {
+	return &SecurityHealthReport{
+		Findings:   []*Finding{},
+		Summary:    map[string]int{},
+		Namespaces: map[string]map[string]int{},
+	}
+}
+
+// analyzeNamespace runs the checks of the configuration that every namespace has of its own: secrets
+// engines, auth methods and login MFA
+func analyzeNamespace(vault *api.Client, skip func(check string, err error)) []*Finding {
+	var findings []*Finding
+
+	if mounts, err := vault.Sys().ListMounts(); err != nil {
+		skip("secrets engines", err)
+	} else {
+		findings = append(findings, analyzeSecretsEngines(mounts)...)
+	}
+
+	auths, err := vault.Sys().ListAuth()
+	if err != nil {
+		skip("auth methods", err)
+		return findings
+	}
+	findings = append(findings, analyzeAuthMethods(auths)...)
+
+	if enforcements, err := auth.ReadMFALoginEnforcements(vault); err != nil {
+		skip("login MFA", err)
+	} else {
+		findings = append(findings, analyzeMFACoverage(auth.CheckMFACoverage(enforcements, auths))...)
+	}
+	return findings
+}
+
+// listChildNamespaces returns the paths of the child namespaces of the namespace of the client, relative to
+// it and breadth first, up to limit namespaces. A namespace whose children cannot be listed is passed to
+// skip and analyzed without its children.
+func listChildNamespaces(vault *api.Client, limit int, skip func(namespace string, err error)) []string {
+	var namespaces []string
+	queue := []string{""}
+	for len(queue) > 0 && len(namespaces) < limit {
+		parent := queue[0]
+		queue = queue[1:]
+
+		secret, err := vault.WithNamespace(path.Join(vault.Namespace(), parent)).Logical().List("sys/namespaces")
+		if err != nil {
+			skip(parent, err)
+			continue
+		}
+		if secret == nil || secret.Data == nil {
+			continue
+		}
+		keys, _ := secret.Data["keys"].([]interface{})
+		for _, key := range keys {
+			name, ok := key.(string)
+			if !ok || len(namespaces) >= limit {
+				continue
+			}
+			child := path.Join(parent, name)
+			namespaces = append(namespaces, child)
+			queue = append(queue, child)
+		}
+	}
+	return namespaces
+}
+
+// namespaceLabel names a namespace in a report by its full path, "root" for the root namespace
+func namespaceLabel(base string, namespace string) string {
+	if label := strings.Trim(path.Join(base, namespace), "/"); label != "" && label != "." {
+		return label
+	}
+	return "root"
+}
+
+// add appends the findings of a check to the report
+func (r *SecurityHealthReport) add(findings []*Finding) {
+	r.Findings = append(r.Findings, findings...)
+}
+
+// summarize sorts the findings by severity and counts them
+func (r *SecurityHealthReport) summarize() {
+	sort.SliceStable(r.Findings, func(i, j int) bool {
+		return severityOrder[r.Findings[i].Severity] < severityOrder[r.Findings[j].Severity]
+	})
+	for _, finding := range r.Findings {
+		r.Summary[finding.Severity]++
+	}
+}
+
+// encode streams the report as JSON, leaving out the findings that do not fit in limit bytes. Findings are
+// sorted by severity, so the least severe ones are left out first.
+func (r *SecurityHealthReport) encode(limit int) (string, error) {
+	stream := utils.NewJSONStream(limit)
+
+	stream.WriteRaw(`{"findings":`)
+	written, err := utils.WriteArray(stream, r.Findings)
+	if err != nil {
+		return "", err
+	}
+	if written < len(r.Findings) {
+		r.Truncated = true
+		r.Omitted = len(r.Findings) - written
+	}
+
+	stream.WriteRaw(`,"summary":`)
+	if err := stream.WriteValue(r.Summary); err != nil {
+		return "", err
+	}
+	if len(r.Namespaces) > 0 {
+		stream.WriteRaw(`,"namespace_summary":`)
+		if err := stream.WriteValue(r.Namespaces); err != nil {
+			return "", err
+		}
+	}
+	if len(r.SkippedChecks) > 0 {
+		stream.WriteRaw(`,"skipped_checks":`)
+		if err := stream.WriteValue(r.SkippedChecks); err != nil {
+			return "", err
+		}
+	}
+	if r.Plan != nil {
+		stream.WriteRaw(`,"remediation_plan":`)
+		if err := stream.WriteValue(r.Plan); err != nil {
+			return "", err
+		}
+	}
+	if r.Truncated {
+		stream.WriteRaw(fmt.Sprintf(`,"truncated":true,"omitted_findings":%d`, r.Omitted))
+	}
+	stream.WriteRaw("}")
+
+	return stream.Finish(), nil
+}
+
+// analyzeAuditDevices reports clusters without audit devices
+func analyzeAuditDevices(audits map[string]*api.Audit) []*Finding {
+	if len(audits) > 0 {
+		return nil
+	}
+	return []*Finding{{
+		Severity:       SeverityCritical,
+		Component:      "audit",
+		Message:        "No audit devices are enabled, requests to Vault are not recorded",
+		Recommendation: "Enable at least one audit device, and preferably two so that Vault keeps serving requests when one fails",
+	}}
+}
+
+// analyzeSecretsEngines reports KV mounts without versioning
+func analyzeSecretsEngines(mounts map[string]*api.MountOutput) []*Finding {
+	var findings []*Finding
+
+	paths := make([]string, 0, len(mounts))
+	for path := range mounts {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		mount := mounts[path]
+		if (mount.Type == "kv" || mount.Type == "generic") && mount.Options["version"] != "2" {
+			findings = append(findings, &Finding{
+				Severity:       SeverityLow,
+				Component:      "secrets_engine",
+				Message:        fmt.Sprintf("KV mount '%s' is not versioned, overwritten or deleted secrets cannot be recovered", strings.TrimSuffix(path, "/")),
+				Recommendation: "Upgrade the mount to KV version 2",
+			})
+		}
+	}
+
+	return findings
+}
+
+// analyzeAuthMethods reports auth methods whose tokens may live longer than MaxRecommendedTokenTTL
+func analyzeAuthMethods(auths map[string]*api.AuthMount) []*Finding {
+	var findings []*Finding
+	recommendation := fmt.Sprintf("Lower the max_lease_ttl of the auth method to %s or less", MaxRecommendedTokenTTL)
+
+	paths := make([]string, 0, len(auths))
+	for path := range auths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		maxTTL := time.Duration(auths[path].Config.MaxLeaseTTL) * time.Second
+		if maxTTL > MaxRecommendedTokenTTL {
+			findings = append(findings, &Finding{
+				Severity:       SeverityMedium,
+				Component:      "auth_method",
+				Message:        fmt.Sprintf("Auth method '%s' issues tokens with a maximum TTL of %s", strings.TrimSuffix(path, "/"), maxTTL),
+				Recommendation: recommendation,
+			})
+		}
+	}
+
+	return findings
+}
+
+// analyzeMFACoverage reports password based auth methods without login MFA
+func analyzeMFACoverage(report auth.MFAEnforcementReport) []*Finding {
+	var findings []*Finding
+
+	for _, coverage := range report.AuthMethods {
+		if passwordAuthTypes[coverage.Type] && !coverage.Enforced {
+			findings = append(findings, &Finding{
+				Severity:       SeverityMedium,
+				Component:      "auth_method",
+				Message:        fmt.Sprintf("Logins through the '%s' auth method '%s' do not require MFA", coverage.Type, coverage.Path),
+				Recommendation: "Create a login enforcement for the auth method with 'create_mfa_login_enforcement'",
+			})
+		}
+	}
+
+	return findings
+}
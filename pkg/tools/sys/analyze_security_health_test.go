@@ -0,0 +1,165 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/auth"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeSecurityChecks(t *testing.T) {
+	t.Run("audit devices", func(t *testing.T) {
+		findings := analyzeAuditDevices(map[string]*api.Audit{})
+		require.Len(t, findings, 1)
+		assert.Equal(t, SeverityCritical, findings[0].Severity)
+
+		assert.Empty(t, analyzeAuditDevices(map[string]*api.Audit{"file/": {Type: "file"}}))
+	})
+
+	t.Run("secrets engines", func(t *testing.T) {
+		findings := analyzeSecretsEngines(map[string]*api.MountOutput{
+			"secret/": {Type: "kv", Options: map[string]string{"version": "2"}},
+			"legacy/": {Type: "kv", Options: map[string]string{"version": "1"}},
+			"pki/":    {Type: "pki"},
+		})
+		require.Len(t, findings, 1)
+		assert.Contains(t, findings[0].Message, "'legacy'")
+	})
+
+	t.Run("auth methods", func(t *testing.T) {
+		findings := analyzeAuthMethods(map[string]*api.AuthMount{
+			"userpass/": {Type: "userpass", Config: api.AuthConfigOutput{MaxLeaseTTL: 8760 * 3600}},
+			"approle/":  {Type: "approle", Config: api.AuthConfigOutput{MaxLeaseTTL: 3600}},
+		})
+		require.Len(t, findings, 1)
+		assert.Contains(t, findings[0].Message, "'userpass'")
+	})
+
+	t.Run("login MFA", func(t *testing.T) {
+		findings := analyzeMFACoverage(auth.MFAEnforcementReport{AuthMethods: []auth.AuthMethodMFA{
+			{Path: "userpass", Type: "userpass", Enforced: false},
+			{Path: "ldap", Type: "ldap", Enforced: true},
+			{Path: "approle", Type: "approle", Enforced: false},
+		}})
+		require.Len(t, findings, 1)
+		assert.Contains(t, findings[0].Message, "'userpass'")
+	})
+}
+
+func TestSecurityHealthReportEncode(t *testing.T) {
+	report := newSecurityHealthReport()
+	report.add(analyzeSecretsEngines(benchMounts()))
+	report.add(analyzeAuditDevices(map[string]*api.Audit{}))
+	report.SkippedChecks = []string{"login MFA: permission denied"}
+	report.summarize()
+
+	// Within the cap the report matches json.Marshal
+	expected, err := json.Marshal(report)
+	require.NoError(t, err)
+	encoded, err := report.encode(0)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), encoded)
+
+	// Beyond the cap the least severe findings are left out, the summary still counts them
+	encoded, err = report.encode(2048)
+	require.NoError(t, err)
+	var truncated SecurityHealthReport
+	require.NoError(t, json.Unmarshal([]byte(encoded), &truncated))
+	assert.True(t, truncated.Truncated)
+	assert.Equal(t, len(report.Findings), len(truncated.Findings)+truncated.Omitted)
+	assert.Equal(t, SeverityCritical, truncated.Findings[0].Severity)
+	assert.Equal(t, 500, truncated.Summary[SeverityLow])
+	assert.Equal(t, report.SkippedChecks, truncated.SkippedChecks)
+}
+
+func TestAnalyzeSecurityHealth_ChildNamespaces(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	children := map[string][]string{"": {"team-a/", "team-b/"}, "team-a": {"dev/"}}
+	mounts := map[string]string{"": "secret/", "team-a": "legacy/", "team-a/dev": "old/", "team-b": "kv/"}
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		namespace := r.Header.Get("X-Vault-Namespace")
+		switch r.URL.Path {
+		case "/v1/sys/audit":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"file/": map[string]interface{}{"type": "file"}})
+		case "/v1/sys/namespaces":
+			if namespace == "team-b" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"keys": children[namespace]}})
+		case "/v1/sys/mounts":
+			version := "2"
+			if strings.HasPrefix(namespace, "team-a") {
+				version = "1"
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				mounts[namespace]: map[string]interface{}{"type": "kv", "options": map[string]interface{}{"version": version}},
+			}})
+		case "/v1/sys/auth":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockVault.Close()
+
+	sessionID := "test-" + t.Name()
+	_, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	defer client.DeleteVaultClient(sessionID)
+	ctx := server.NewMCPServer("test", "1.0").WithContext(context.Background(), testSession{id: sessionID})
+
+	call := func(args map[string]interface{}) SecurityHealthReport {
+		result, err := AnalyzeSecurityHealth(logger).Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		require.False(t, result.IsError, result.Content)
+		var report SecurityHealthReport
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &report))
+		return report
+	}
+
+	// By default only the namespace of the call is analyzed
+	report := call(map[string]interface{}{})
+	assert.Empty(t, report.Findings)
+	assert.Empty(t, report.Namespaces)
+
+	report = call(map[string]interface{}{"include_child_namespaces": true})
+	namespaces := map[string]string{}
+	for _, finding := range report.Findings {
+		namespaces[finding.Namespace] = finding.Message
+	}
+	assert.Equal(t, map[string]string{
+		"team-a":     "KV mount 'legacy' is not versioned, overwritten or deleted secrets cannot be recovered",
+		"team-a/dev": "KV mount 'old' is not versioned, overwritten or deleted secrets cannot be recovered",
+	}, namespaces)
+	assert.Equal(t, map[string]map[string]int{
+		"root":       {},
+		"team-a":     {SeverityLow: 1},
+		"team-a/dev": {SeverityLow: 1},
+		"team-b":     {},
+	}, report.Namespaces)
+	require.Len(t, report.SkippedChecks, 1)
+	assert.Contains(t, report.SkippedChecks[0], "child namespaces of namespace team-b")
+
+	// The number of child namespaces is bounded, breadth first
+	report = call(map[string]interface{}{"include_child_namespaces": true, "max_namespaces": float64(2)})
+	assert.Len(t, report.Namespaces, 3)
+	assert.NotContains(t, report.Namespaces, "team-a/dev")
+}
@@ -0,0 +1,339 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	SeverityCritical = "critical"
+	SeverityHigh     = "high"
+	SeverityMedium   = "medium"
+	SeverityLow      = "low"
+	SeverityInfo     = "info"
+)
+
+// severityOrder is used to sort findings with the most severe first
+var severityOrder = map[string]int{
+	SeverityCritical: 0,
+	SeverityHigh:     1,
+	SeverityMedium:   2,
+	SeverityLow:      3,
+	SeverityInfo:     4,
+}
+
+type Finding struct {
+	Severity       string `json:"severity"`                 // Severity of the finding (critical, high, medium, low, info)
+	Component      string `json:"component"`                // Component the finding applies to, such as 'listener' or 'storage'
+	Message        string `json:"message"`                  // Description of the finding
+	Recommendation string `json:"recommendation,omitempty"` // Suggested remediation, if any
+	Namespace      string `json:"namespace,omitempty"`      // Namespace the finding applies to, when child namespaces are analyzed
+}
+
+type ConfigAnalysis struct {
+	StorageType   string           `json:"storage_type"`               // Type of the configured storage backend
+	ListenerCount int              `json:"listener_count"`             // Number of configured listeners
+	Findings      []*Finding       `json:"findings"`                   // Findings sorted by severity
+	Plan          *RemediationPlan `json:"remediation_plan,omitempty"` // Drafted by the model of the client when generate_plan is set
+}
+
+// AnalyzeServerConfig creates a tool for assessing the security of the Vault server configuration
+func AnalyzeServerConfig(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("analyze_server_config",
+			mcp.WithDescription("Analyze the sanitized Vault server configuration and report security findings for each listener (TLS disabled, weak minimum TLS version, X-Forwarded-For trust), the storage backend and server-wide security flags. Requires read access to sys/config/state/sanitized."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			withGeneratePlan(),
+			schemas.Output("analyze_server_config"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return analyzeServerConfigHandler(ctx, req, logger)
+		},
+	}
+}
+
+func analyzeServerConfigHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling analyze_server_config request")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	secret, err := vault.Logical().Read("sys/config/state/sanitized")
+	if err != nil {
+		logger.WithError(err).Error("Failed to read sanitized configuration")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read sanitized configuration: %v", err)), nil
+	}
+	if secret == nil || secret.Data == nil {
+		return mcp.NewToolResultError("Sanitized configuration returned no data"), nil
+	}
+
+	analysis := AnalyzeSanitizedConfig(secret.Data)
+	if req.GetBool("generate_plan", false) {
+		analysis.Plan = draftRemediationPlan(ctx, "Vault server configuration analysis", analysis.Findings)
+	}
+
+	// Marshal the struct to JSON
+	jsonData, err := json.Marshal(analysis)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal configuration analysis to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithField("finding_count", len(analysis.Findings)).Debug("Successfully analyzed server configuration")
+	return mcp.NewToolResultStructured(analysis, string(jsonData)), nil
+}
+
+// AnalyzeSanitizedConfig evaluates the data returned by sys/config/state/sanitized
+func AnalyzeSanitizedConfig(config map[string]interface{}) *ConfigAnalysis {
+	analysis := &ConfigAnalysis{
+		Findings: []*Finding{},
+	}
+
+	listeners, _ := config["listeners"].([]interface{})
+	analysis.ListenerCount = len(listeners)
+	analysis.Findings = append(analysis.Findings, analyzeListeners(listeners)...)
+
+	storage, _ := config["storage"].(map[string]interface{})
+	analysis.StorageType, _ = storage["type"].(string)
+	analysis.Findings = append(analysis.Findings, analyzeStorage(storage)...)
+
+	analysis.Findings = append(analysis.Findings, analyzeSecurityFlags(config)...)
+
+	sort.SliceStable(analysis.Findings, func(i, j int) bool {
+		return severityOrder[analysis.Findings[i].Severity] < severityOrder[analysis.Findings[j].Severity]
+	})
+
+	return analysis
+}
+
+// analyzeListeners produces findings for the TLS and proxy settings of each listener
+func analyzeListeners(listeners []interface{}) []*Finding {
+	var findings []*Finding
+
+	for _, l := range listeners {
+		listener, ok := l.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		listenerType, _ := listener["type"].(string)
+		config, _ := listener["config"].(map[string]interface{})
+		if listenerType != "tcp" {
+			continue
+		}
+
+		address, _ := config["address"].(string)
+		if address == "" {
+			address = "127.0.0.1:8200"
+		}
+		name := fmt.Sprintf("TCP listener %s", address)
+		loopback := isLoopbackAddress(address)
+
+		if configBool(config["tls_disable"]) {
+			severity := SeverityCritical
+			if loopback {
+				severity = SeverityMedium
+			}
+			findings = append(findings, &Finding{
+				Severity:       severity,
+				Component:      "listener",
+				Message:        fmt.Sprintf("%s has tls_disable=true", name),
+				Recommendation: "Configure tls_cert_file and tls_key_file and remove tls_disable so that tokens and secrets are never sent in plaintext.",
+			})
+		} else {
+			switch minVersion, _ := config["tls_min_version"].(string); minVersion {
+			case "tls10", "tls11":
+				findings = append(findings, &Finding{
+					Severity:       SeverityHigh,
+					Component:      "listener",
+					Message:        fmt.Sprintf("%s allows deprecated TLS versions (tls_min_version=%s)", name, minVersion),
+					Recommendation: "Set tls_min_version to 'tls12' or 'tls13'.",
+				})
+			}
+
+			if !configBool(config["tls_require_and_verify_client_cert"]) && !loopback {
+				findings = append(findings, &Finding{
+					Severity:  SeverityInfo,
+					Component: "listener",
+					Message:   fmt.Sprintf("%s does not require client certificates", name),
+				})
+			}
+		}
+
+		if authorized := configString(config["x_forwarded_for_authorized_addrs"]); authorized != "" {
+			for _, cidr := range strings.Split(authorized, ",") {
+				cidr = strings.TrimSpace(cidr)
+				if cidr == "0.0.0.0/0" || cidr == "::/0" {
+					findings = append(findings, &Finding{
+						Severity:       SeverityHigh,
+						Component:      "listener",
+						Message:        fmt.Sprintf("%s trusts X-Forwarded-For headers from any address (%s)", name, cidr),
+						Recommendation: "Restrict x_forwarded_for_authorized_addrs to the addresses of your load balancers so clients cannot spoof their source address.",
+					})
+				}
+			}
+			if rejectNotAuthorized, ok := config["x_forwarded_for_reject_not_authorized"]; ok && !configBool(rejectNotAuthorized) {
+				findings = append(findings, &Finding{
+					Severity:       SeverityLow,
+					Component:      "listener",
+					Message:        fmt.Sprintf("%s accepts X-Forwarded-For headers from unauthorized addresses", name),
+					Recommendation: "Set x_forwarded_for_reject_not_authorized to true.",
+				})
+			}
+		}
+
+		if unauthenticated, ok := config["unauthenticated_metrics_access"]; ok && configBool(unauthenticated) {
+			findings = append(findings, &Finding{
+				Severity:       SeverityLow,
+				Component:      "listener",
+				Message:        fmt.Sprintf("%s exposes metrics without authentication", name),
+				Recommendation: "Disable unauthenticated_metrics_access unless the listener is only reachable from your monitoring network.",
+			})
+		}
+	}
+
+	return findings
+}
+
+// analyzeStorage produces findings for the configured storage backend
+func analyzeStorage(storage map[string]interface{}) []*Finding {
+	storageType, _ := storage["type"].(string)
+
+	switch storageType {
+	case "":
+		return nil
+	case "inmem":
+		return []*Finding{{
+			Severity:       SeverityCritical,
+			Component:      "storage",
+			Message:        "Storage backend is 'inmem', all data is lost when Vault restarts",
+			Recommendation: "Use integrated storage (raft) for anything other than development servers.",
+		}}
+	case "file":
+		return []*Finding{{
+			Severity:       SeverityMedium,
+			Component:      "storage",
+			Message:        "Storage backend is 'file', which does not support high availability",
+			Recommendation: "Migrate to integrated storage (raft) for high availability.",
+		}}
+	case "raft":
+		var findings []*Finding
+		if configBool(storage["disable_clustering"]) {
+			findings = append(findings, &Finding{
+				Severity:  SeverityMedium,
+				Component: "storage",
+				Message:   "Integrated storage has clustering disabled",
+			})
+		}
+		return findings
+	case "consul":
+		return nil
+	default:
+		return []*Finding{{
+			Severity:  SeverityInfo,
+			Component: "storage",
+			Message:   fmt.Sprintf("Storage backend is '%s', check that it is a supported backend for your Vault version", storageType),
+		}}
+	}
+}
+
+// analyzeSecurityFlags produces findings for server-wide settings that weaken security
+func analyzeSecurityFlags(config map[string]interface{}) []*Finding {
+	var findings []*Finding
+
+	if configBool(config["raw_storage_endpoint"]) {
+		findings = append(findings, &Finding{
+			Severity:       SeverityHigh,
+			Component:      "server",
+			Message:        "raw_storage_endpoint is enabled, allowing direct access to the storage layer through sys/raw",
+			Recommendation: "Disable raw_storage_endpoint unless it is needed for a recovery operation.",
+		})
+	}
+	if configBool(config["disable_mlock"]) {
+		findings = append(findings, &Finding{
+			Severity:       SeverityLow,
+			Component:      "server",
+			Message:        "disable_mlock is set, memory may be swapped to disk",
+			Recommendation: "Disable swap on the host, or remove disable_mlock when not using integrated storage.",
+		})
+	}
+	if configBool(config["enable_response_header_hostname"]) {
+		findings = append(findings, &Finding{
+			Severity:  SeverityInfo,
+			Component: "server",
+			Message:   "enable_response_header_hostname is set, node hostnames are disclosed in response headers",
+		})
+	}
+	if configBool(config["introspection_endpoint"]) {
+		findings = append(findings, &Finding{
+			Severity:  SeverityLow,
+			Component: "server",
+			Message:   "introspection_endpoint is enabled",
+		})
+	}
+
+	return findings
+}
+
+// configBool interprets the many ways a boolean can appear in the sanitized configuration
+func configBool(v interface{}) bool {
+	switch b := v.(type) {
+	case bool:
+		return b
+	case string:
+		parsed, err := strconv.ParseBool(b)
+		return err == nil && parsed
+	case json.Number:
+		return b.String() != "0"
+	case float64:
+		return b != 0
+	case int:
+		return b != 0
+	}
+	return false
+}
+
+// configString interprets a string or list of strings in the sanitized configuration as a comma separated string
+func configString(v interface{}) string {
+	switch s := v.(type) {
+	case string:
+		return s
+	case []interface{}:
+		var parts []string
+		for _, p := range s {
+			parts = append(parts, fmt.Sprintf("%v", p))
+		}
+		return strings.Join(parts, ",")
+	}
+	return ""
+}
+
+func isLoopbackAddress(address string) bool {
+	host := address
+	if i := strings.LastIndex(address, ":"); i > 0 {
+		host = address[:i]
+	}
+	host = strings.Trim(host, "[]")
+	return host == "127.0.0.1" || host == "localhost" || host == "::1"
+}
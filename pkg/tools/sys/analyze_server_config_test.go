@@ -0,0 +1,78 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeSanitizedConfig(t *testing.T) {
+	config := map[string]interface{}{
+		"listeners": []interface{}{
+			map[string]interface{}{
+				"type": "tcp",
+				"config": map[string]interface{}{
+					"address":                          "0.0.0.0:8200",
+					"tls_disable":                      "true",
+					"x_forwarded_for_authorized_addrs": "10.0.0.0/8, 0.0.0.0/0",
+				},
+			},
+			map[string]interface{}{
+				"type": "tcp",
+				"config": map[string]interface{}{
+					"address":                            "127.0.0.1:8201",
+					"tls_min_version":                    "tls11",
+					"tls_require_and_verify_client_cert": true,
+				},
+			},
+		},
+		"storage": map[string]interface{}{
+			"type": "inmem",
+		},
+		"raw_storage_endpoint": true,
+	}
+
+	analysis := AnalyzeSanitizedConfig(config)
+
+	assert.Equal(t, "inmem", analysis.StorageType)
+	assert.Equal(t, 2, analysis.ListenerCount)
+
+	var messages []string
+	for _, f := range analysis.Findings {
+		messages = append(messages, f.Message)
+	}
+	assert.Contains(t, messages, "TCP listener 0.0.0.0:8200 has tls_disable=true")
+	assert.Contains(t, messages, "TCP listener 127.0.0.1:8201 allows deprecated TLS versions (tls_min_version=tls11)")
+	assert.Contains(t, messages, "TCP listener 0.0.0.0:8200 trusts X-Forwarded-For headers from any address (0.0.0.0/0)")
+
+	// Findings are sorted with the most severe first
+	require.NotEmpty(t, analysis.Findings)
+	assert.Equal(t, SeverityCritical, analysis.Findings[0].Severity)
+	assert.Equal(t, SeverityHigh, analysis.Findings[len(analysis.Findings)-1].Severity)
+}
+
+func TestAnalyzeSanitizedConfig_LoopbackWithoutTLS(t *testing.T) {
+	config := map[string]interface{}{
+		"listeners": []interface{}{
+			map[string]interface{}{
+				"type": "tcp",
+				"config": map[string]interface{}{
+					"address":     "127.0.0.1:8200",
+					"tls_disable": 1.0,
+				},
+			},
+		},
+		"storage": map[string]interface{}{
+			"type": "raft",
+		},
+	}
+
+	analysis := AnalyzeSanitizedConfig(config)
+
+	require.Len(t, analysis.Findings, 1)
+	assert.Equal(t, SeverityMedium, analysis.Findings[0].Severity, "plaintext loopback listeners are less severe")
+}
@@ -0,0 +1,98 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// AttachManagedKey creates a tool for allowing a mount to use a managed key
+func AttachManagedKey(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("attach_managed_key",
+			mcp.WithDescription("Allow a secrets engine mount, typically a PKI mount, to use a managed key by adding it to the allowed managed keys of the mount. A PKI mount can then generate a root or intermediate CA whose private key stays in the HSM or KMS, by setting 'managed_key_name' when generating the issuer."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					IdempotentHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithString("mount",
+				mcp.Required(),
+				mcp.Description("The mount that may use the managed key, for example 'pki'."),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("The name or ID of the managed key."),
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return attachManagedKeyHandler(ctx, req, logger)
+		},
+	}
+}
+
+func attachManagedKeyHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling attach_managed_key request")
+
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	name, ok := args["name"].(string)
+	if !ok || name == "" {
+		return mcp.NewToolResultError("Missing or invalid 'name' parameter"), nil
+	}
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	config, err := vault.Sys().MountConfig(mount)
+	if err != nil {
+		logger.WithError(err).WithField("mount", mount).Error("Failed to read mount configuration")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read configuration of mount '%s': %v", mount, err)), nil
+	}
+
+	if slices.Contains(config.AllowedManagedKeys, name) {
+		return mcp.NewToolResultText(fmt.Sprintf("Managed key '%s' is already allowed on mount '%s'", name, mount)), nil
+	}
+
+	allowed := append(slices.Clone(config.AllowedManagedKeys), name)
+	if err := vault.Sys().TuneMountAllowNil(mount, api.TuneMountConfigInput{AllowedManagedKeys: &allowed}); err != nil {
+		logger.WithError(err).WithField("mount", mount).Error("Failed to attach managed key")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to allow managed key '%s' on mount '%s': %v", name, mount, err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount": mount,
+		"name":  name,
+	}).Info("Successfully attached managed key")
+	return client.NewChangeResult(ctx, req, client.ChangeRecord{
+		ResourceType: client.ResourceManagedKeyMount,
+		Mount:        mount,
+		Path:         "sys/mounts/" + mount + "/tune",
+		Operation:    client.OperationUpdate,
+		Previous:     map[string]any{"allowed_managed_keys": config.AllowedManagedKeys},
+		New:          map[string]any{"allowed_managed_keys": allowed},
+		Message:      fmt.Sprintf("Successfully allowed managed key '%s' on mount '%s'", name, mount),
+	}), nil
+}
@@ -0,0 +1,83 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/auth"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault/api"
+)
+
+// benchSize is the number of mounts and auth methods of the benchmarked cluster, a large multi-team cluster
+const benchSize = 1000
+
+func benchMounts() map[string]*api.MountOutput {
+	mounts := make(map[string]*api.MountOutput, benchSize)
+	for i := 0; i < benchSize; i++ {
+		version := "2"
+		if i%2 == 0 {
+			version = "1"
+		}
+		mounts[fmt.Sprintf("team-%d/", i)] = &api.MountOutput{Type: "kv", Options: map[string]string{"version": version}}
+	}
+	return mounts
+}
+
+func benchAuths() map[string]*api.AuthMount {
+	auths := make(map[string]*api.AuthMount, benchSize)
+	for i := 0; i < benchSize; i++ {
+		auths[fmt.Sprintf("userpass-%d/", i)] = &api.AuthMount{Type: "userpass", Config: api.AuthConfigOutput{MaxLeaseTTL: 8760 * 3600}}
+	}
+	return auths
+}
+
+func benchMFACoverage() auth.MFAEnforcementReport {
+	report := auth.MFAEnforcementReport{AuthMethods: make([]auth.AuthMethodMFA, 0, benchSize)}
+	for i := 0; i < benchSize; i++ {
+		report.AuthMethods = append(report.AuthMethods, auth.AuthMethodMFA{Path: fmt.Sprintf("userpass-%d", i), Type: "userpass", Enforced: i%2 == 0})
+	}
+	return report
+}
+
+func BenchmarkSecurityHealthCollectors(b *testing.B) {
+	mounts, auths, coverage := benchMounts(), benchAuths(), benchMFACoverage()
+
+	b.Run("secrets engines", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			analyzeSecretsEngines(mounts)
+		}
+	})
+
+	b.Run("auth methods", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			analyzeAuthMethods(auths)
+		}
+	})
+
+	b.Run("login MFA", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			analyzeMFACoverage(coverage)
+		}
+	})
+
+	b.Run("report", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			report := newSecurityHealthReport()
+			report.add(analyzeSecretsEngines(mounts))
+			report.add(analyzeAuthMethods(auths))
+			report.add(analyzeMFACoverage(coverage))
+			report.summarize()
+			if _, err := report.encode(utils.MaxResultBytes); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
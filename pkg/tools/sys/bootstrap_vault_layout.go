@@ -0,0 +1,655 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// Statuses of the steps of a layout
+const (
+	LayoutStepCreated = "created" // The step created the resource
+	LayoutStepExists  = "exists"  // The resource already existed and was left unchanged
+	LayoutStepPlanned = "planned" // The step would create the resource, reported by dry runs
+	LayoutStepFailed  = "failed"  // The step failed, the following steps did not run
+	LayoutStepSkipped = "skipped" // The step did not run because an earlier step failed
+)
+
+// LayoutTemplate describes a Vault layout applied by bootstrap_vault_layout. Audit devices are enabled
+// first, so that the rest of the layout is audited.
+type LayoutTemplate struct {
+	Description  string              `json:"description,omitempty"`   // What the layout is for
+	AuditDevices []LayoutAuditDevice `json:"audit_devices,omitempty"` // Audit devices to enable
+	Mounts       []LayoutMount       `json:"mounts,omitempty"`        // Secrets engines to mount
+	PKI          *LayoutPKI          `json:"pki,omitempty"`           // Root and intermediate CAs
+	Policies     []LayoutPolicy      `json:"policies,omitempty"`      // ACL policies to write
+	AuthMethods  []LayoutAuthMethod  `json:"auth_methods,omitempty"`  // Auth methods to enable
+	Users        []LayoutUser        `json:"users,omitempty"`         // Userpass users to create
+}
+
+type LayoutAuditDevice struct {
+	Path    string            `json:"path"`              // Path of the audit device
+	Type    string            `json:"type"`              // Type of the audit device, such as 'file'
+	Options map[string]string `json:"options,omitempty"` // Options of the audit device, such as file_path
+}
+
+type LayoutMount struct {
+	Path        string            `json:"path"`                  // Path of the mount
+	Type        string            `json:"type"`                  // Type of the secrets engine, such as 'kv'
+	Description string            `json:"description,omitempty"` // Description of the mount
+	Options     map[string]string `json:"options,omitempty"`     // Options of the mount, such as version
+}
+
+type LayoutPKI struct {
+	RootMount              string          `json:"root_mount"`                 // Mount of the root CA
+	RootCommonName         string          `json:"root_common_name"`           // Common name of the root CA
+	RootTTL                string          `json:"root_ttl,omitempty"`         // Lifetime of the root CA
+	IntermediateMount      string          `json:"intermediate_mount"`         // Mount of the intermediate CA issuing certificates
+	IntermediateCommonName string          `json:"intermediate_common_name"`   // Common name of the intermediate CA
+	IntermediateTTL        string          `json:"intermediate_ttl,omitempty"` // Lifetime of the intermediate CA
+	Roles                  []LayoutPKIRole `json:"roles,omitempty"`            // Roles of the intermediate CA
+}
+
+type LayoutPKIRole struct {
+	Name            string   `json:"name"`                       // Name of the role
+	AllowedDomains  []string `json:"allowed_domains"`            // Domains the role issues certificates for
+	AllowSubdomains bool     `json:"allow_subdomains,omitempty"` // Whether subdomains of the allowed domains are allowed
+	MaxTTL          string   `json:"max_ttl,omitempty"`          // Maximum lifetime of the certificates
+}
+
+type LayoutPolicy struct {
+	Name  string `json:"name"`  // Name of the policy
+	Rules string `json:"rules"` // HCL rules of the policy
+}
+
+type LayoutAuthMethod struct {
+	Path        string `json:"path"`                  // Path of the auth method
+	Type        string `json:"type"`                  // Type of the auth method, such as 'userpass'
+	Description string `json:"description,omitempty"` // Description of the auth method
+}
+
+type LayoutUser struct {
+	Mount    string   `json:"mount"`    // Path of the userpass auth method
+	Username string   `json:"username"` // Name of the user
+	Policies []string `json:"policies"` // Policies attached to the user
+}
+
+// LayoutStep is a step of an applied layout
+type LayoutStep struct {
+	Action    string `json:"action"`              // What the step does, such as 'create_mount'
+	Path      string `json:"path"`                // Vault path of the resource of the step
+	Namespace string `json:"namespace,omitempty"` // Namespace the step runs in, empty for the namespace of the tool call
+	Status    string `json:"status"`              // created, exists, planned, failed or skipped
+	Error     string `json:"error,omitempty"`     // Error of a failed step
+}
+
+// LayoutReport is the outcome of bootstrap_vault_layout
+type LayoutReport struct {
+	Template string       `json:"template"` // Name of the applied template, 'custom' for a supplied template
+	DryRun   bool         `json:"dry_run"`  // Whether the layout was only planned
+	Created  int          `json:"created"`  // Number of resources created, or that would be created by a dry run
+	Existing int          `json:"existing"` // Number of resources that already existed
+	Failed   bool         `json:"failed"`   // Whether a step failed
+	Steps    []LayoutStep `json:"steps"`    // Steps in the order they ran
+}
+
+// starterPolicies are the base policies of the built-in templates
+var starterPolicies = []LayoutPolicy{
+	{Name: "vault-admin", Rules: `path "*" {
+  capabilities = ["create", "read", "update", "delete", "list", "sudo"]
+}
+`},
+	{Name: "kv-reader", Rules: `path "kv/data/*" {
+  capabilities = ["read"]
+}
+
+path "kv/metadata/*" {
+  capabilities = ["read", "list"]
+}
+`},
+	{Name: "kv-writer", Rules: `path "kv/data/*" {
+  capabilities = ["create", "read", "update", "delete"]
+}
+
+path "kv/metadata/*" {
+  capabilities = ["read", "list", "delete"]
+}
+`},
+	{Name: "pki-issuer", Rules: `path "pki_int/issue/*" {
+  capabilities = ["update"]
+}
+`},
+}
+
+// LayoutTemplates are the built-in templates of bootstrap_vault_layout
+var LayoutTemplates = map[string]LayoutTemplate{
+	"starter": {
+		Description:  "Golden-path layout: file audit device, KV v2 mount, PKI root and intermediate CAs, base policies and a userpass admin",
+		AuditDevices: []LayoutAuditDevice{{Path: "file", Type: "file", Options: map[string]string{"file_path": "/var/log/vault/audit.log"}}},
+		Mounts:       []LayoutMount{{Path: "kv", Type: "kv", Description: "Application secrets", Options: map[string]string{"version": "2"}}},
+		PKI: &LayoutPKI{
+			RootMount:              "pki",
+			RootCommonName:         "Root CA",
+			RootTTL:                "87600h",
+			IntermediateMount:      "pki_int",
+			IntermediateCommonName: "Intermediate CA",
+			IntermediateTTL:        "43800h",
+			Roles:                  []LayoutPKIRole{{Name: "server", AllowedDomains: []string{"example.com"}, AllowSubdomains: true, MaxTTL: "720h"}},
+		},
+		Policies:    starterPolicies,
+		AuthMethods: []LayoutAuthMethod{{Path: "userpass", Type: "userpass", Description: "Human operators"}},
+		Users:       []LayoutUser{{Mount: "userpass", Username: "admin", Policies: []string{"vault-admin"}}},
+	},
+	"workshop": {
+		Description:  "Workshop layout for dev servers: the starter layout with the audit log on standard output and short-lived certificates",
+		AuditDevices: []LayoutAuditDevice{{Path: "stdout", Type: "file", Options: map[string]string{"file_path": "stdout"}}},
+		Mounts:       []LayoutMount{{Path: "kv", Type: "kv", Description: "Workshop secrets", Options: map[string]string{"version": "2"}}},
+		PKI: &LayoutPKI{
+			RootMount:              "pki",
+			RootCommonName:         "Workshop Root CA",
+			RootTTL:                "720h",
+			IntermediateMount:      "pki_int",
+			IntermediateCommonName: "Workshop Intermediate CA",
+			IntermediateTTL:        "168h",
+			Roles:                  []LayoutPKIRole{{Name: "server", AllowedDomains: []string{"workshop.local"}, AllowSubdomains: true, MaxTTL: "24h"}},
+		},
+		Policies:    starterPolicies,
+		AuthMethods: []LayoutAuthMethod{{Path: "userpass", Type: "userpass", Description: "Workshop participants"}},
+		Users:       []LayoutUser{{Mount: "userpass", Username: "admin", Policies: []string{"vault-admin"}}},
+	},
+}
+
+// BootstrapVaultLayout creates a tool applying a starter Vault layout from a template
+func BootstrapVaultLayout(logger *log.Logger) server.ServerTool {
+	templates := make([]string, 0, len(LayoutTemplates))
+	for name := range LayoutTemplates {
+		templates = append(templates, name)
+	}
+	sort.Strings(templates)
+
+	return server.ServerTool{
+		Tool: mcp.NewTool("bootstrap_vault_layout",
+			mcp.WithDescription("Apply an opinionated starter layout to Vault for onboarding and workshops: an audit device, a KV v2 mount, PKI root and intermediate CAs with a role, base policies and a userpass admin. "+
+				"Uses a built-in template or a supplied one. Resources that already exist are left unchanged, steps stop at the first failure and each step is reported. "+
+				"Created resources are recorded as changes of the session, so they can be reverted with undo_last_change. Use dry_run first to review the plan."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint:    utils.ToBoolPtr(false),
+					DestructiveHint: utils.ToBoolPtr(false),
+					IdempotentHint:  utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithString("template",
+				mcp.Enum(templates...),
+				mcp.DefaultString("starter"),
+				mcp.Description("Built-in template to apply. 'starter' audits to /var/log/vault/audit.log on the Vault server, 'workshop' suits dev servers. Ignored when custom_template is set."),
+			),
+			mcp.WithObject("custom_template",
+				mcp.Description("Optional template to apply instead of a built-in one, with the optional lists 'audit_devices' (path, type, options), 'mounts' (path, type, description, options), 'policies' (name, rules), "+
+					"'auth_methods' (path, type, description), 'users' (mount, username, policies) and the optional object 'pki' (root_mount, root_common_name, root_ttl, intermediate_mount, intermediate_common_name, intermediate_ttl, "+
+					"roles with name, allowed_domains, allow_subdomains and max_ttl)."),
+			),
+			mcp.WithString("password",
+				mcp.Description("Password of the userpass users of the template. Required when the template creates users."),
+			),
+			mcp.WithString("audit_file_path",
+				mcp.Description("Optional file path on the Vault server overriding the file_path of the file audit devices of the template."),
+			),
+			mcp.WithBoolean("dry_run",
+				mcp.DefaultBool(false),
+				mcp.Description("Only report which resources would be created, without changing Vault."),
+			),
+			schemas.Output("bootstrap_vault_layout"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return bootstrapVaultLayoutHandler(ctx, req, logger)
+		},
+	}
+}
+
+func bootstrapVaultLayoutHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling bootstrap_vault_layout request")
+
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		args = map[string]interface{}{}
+	}
+
+	name, _ := args["template"].(string)
+	if name == "" {
+		name = "starter"
+	}
+	var template LayoutTemplate
+	if custom, ok := args["custom_template"].(map[string]interface{}); ok && len(custom) > 0 {
+		data, err := json.Marshal(custom)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid 'custom_template' parameter: %v", err)), nil
+		}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&template); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid 'custom_template' parameter: %v", err)), nil
+		}
+		name = "custom"
+	} else if template, ok = LayoutTemplates[name]; !ok {
+		return mcp.NewToolResultError("Missing or invalid 'template' parameter"), nil
+	}
+	if err := validateLayoutTemplate(template); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid template: %v", err)), nil
+	}
+
+	password, _ := args["password"].(string)
+	if len(template.Users) > 0 && password == "" {
+		return mcp.NewToolResultError("Missing or invalid 'password' parameter"), nil
+	}
+	if auditFilePath, _ := args["audit_file_path"].(string); auditFilePath != "" {
+		template.AuditDevices = slices.Clone(template.AuditDevices)
+		for i, device := range template.AuditDevices {
+			if device.Type == "file" {
+				template.AuditDevices[i].Options = map[string]string{"file_path": auditFilePath}
+			}
+		}
+	}
+	dryRun, _ := args["dry_run"].(bool)
+
+	logger.WithFields(log.Fields{
+		"template": name,
+		"dry_run":  dryRun,
+	}).Debug("Applying Vault layout with parameters")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	mounts, err := vault.Sys().ListMountsWithContext(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list mounts: %v", err)), nil
+	}
+	auths, err := vault.Sys().ListAuthWithContext(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list auth methods: %v", err)), nil
+	}
+	audits, err := vault.Sys().ListAuditWithContext(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list audit devices: %v", err)), nil
+	}
+
+	steps := layoutSteps(template, password, existingLayout{mounts: mounts, auths: auths, audits: audits})
+	report := &LayoutReport{Template: name, DryRun: dryRun}
+	runLayoutSteps(ctx, req, vault, steps, report, logger)
+
+	jsonData, err := json.Marshal(report)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal layout report to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"template": name,
+		"dry_run":  dryRun,
+		"created":  report.Created,
+		"existing": report.Existing,
+		"failed":   report.Failed,
+	}).Info("Successfully processed Vault layout")
+
+	return mcp.NewToolResultStructured(report, string(jsonData)), nil
+}
+
+// runLayoutSteps runs the steps of a layout in order and adds them to the report. Steps whose resource exists
+// are left unchanged, dry runs only plan the others, and the steps after the first failure are skipped.
+func runLayoutSteps(ctx context.Context, req mcp.CallToolRequest, vault *api.Client, steps []layoutStep, report *LayoutReport, logger *log.Logger) {
+	report.Steps = make([]LayoutStep, 0, len(steps))
+	for i, step := range steps {
+		_ = utils.NotifyProgress(ctx, req, float64(i), float64(len(steps)), fmt.Sprintf("%s %s", step.Action, step.Path))
+
+		if report.Failed {
+			step.Status = LayoutStepSkipped
+			report.Steps = append(report.Steps, step.LayoutStep)
+			continue
+		}
+
+		stepVault := vault
+		if step.Namespace != "" {
+			stepVault = vault.WithNamespace(step.Namespace)
+		}
+		exists, err := step.exists(ctx, stepVault)
+		switch {
+		case err != nil:
+			step.Status = LayoutStepFailed
+			step.Error = err.Error()
+		case exists:
+			step.Status = LayoutStepExists
+			report.Existing++
+		case report.DryRun:
+			step.Status = LayoutStepPlanned
+			report.Created++
+		default:
+			change, err := step.apply(ctx, stepVault)
+			if err != nil {
+				step.Status = LayoutStepFailed
+				step.Error = err.Error()
+				break
+			}
+			step.Status = LayoutStepCreated
+			report.Created++
+			change.Tool = req.Params.Name
+			change.Namespace = step.Namespace
+			change.Operation = client.OperationCreate
+			change.Message = fmt.Sprintf("Step %s of %s created '%s'", step.Action, req.Params.Name, step.Path)
+			client.RecordChange(ctx, change)
+		}
+		if step.Status == LayoutStepFailed {
+			report.Failed = true
+			logger.WithFields(log.Fields{
+				"tool":   req.Params.Name,
+				"action": step.Action,
+				"path":   step.Path,
+				"error":  step.Error,
+			}).Error("Layout step failed")
+		}
+		report.Steps = append(report.Steps, step.LayoutStep)
+	}
+	_ = utils.NotifyProgress(ctx, req, float64(len(steps)), float64(len(steps)), "Layout applied")
+}
+
+// validateLayoutTemplate checks that every resource of a template is named
+func validateLayoutTemplate(template LayoutTemplate) error {
+	for _, device := range template.AuditDevices {
+		if device.Path == "" || device.Type == "" {
+			return fmt.Errorf("audit devices need a path and a type")
+		}
+	}
+	for _, mount := range template.Mounts {
+		if mount.Path == "" || mount.Type == "" {
+			return fmt.Errorf("mounts need a path and a type")
+		}
+	}
+	if pki := template.PKI; pki != nil {
+		if pki.RootMount == "" || pki.RootCommonName == "" || pki.IntermediateMount == "" || pki.IntermediateCommonName == "" {
+			return fmt.Errorf("pki needs root_mount, root_common_name, intermediate_mount and intermediate_common_name")
+		}
+		if pki.RootMount == pki.IntermediateMount {
+			return fmt.Errorf("the root and intermediate CAs need different mounts")
+		}
+		for _, role := range pki.Roles {
+			if role.Name == "" || len(role.AllowedDomains) == 0 {
+				return fmt.Errorf("pki roles need a name and allowed_domains")
+			}
+		}
+	}
+	for _, policy := range template.Policies {
+		if policy.Name == "" || policy.Rules == "" {
+			return fmt.Errorf("policies need a name and rules")
+		}
+	}
+	for _, method := range template.AuthMethods {
+		if method.Path == "" || method.Type == "" {
+			return fmt.Errorf("auth methods need a path and a type")
+		}
+	}
+	for _, user := range template.Users {
+		if user.Mount == "" || user.Username == "" {
+			return fmt.Errorf("users need a mount and a username")
+		}
+	}
+	return nil
+}
+
+// existingLayout holds the mounts, auth methods and audit devices of Vault before the layout is applied
+type existingLayout struct {
+	mounts map[string]*api.MountOutput
+	auths  map[string]*api.AuthMount
+	audits map[string]*api.Audit
+}
+
+// layoutStep is a step of a layout with the functions checking whether its resource exists and creating it
+type layoutStep struct {
+	LayoutStep
+	exists func(ctx context.Context, vault *api.Client) (bool, error)
+	apply  func(ctx context.Context, vault *api.Client) (client.ChangeRecord, error)
+}
+
+// layoutSteps plans the steps of a template. Steps on a mount that already existed report their resource
+// as existing, since the layout never changes what it did not create.
+func layoutSteps(template LayoutTemplate, password string, existing existingLayout) []layoutStep {
+	var steps []layoutStep
+
+	for _, device := range template.AuditDevices {
+		steps = append(steps, layoutStep{
+			LayoutStep: LayoutStep{Action: "enable_audit_device", Path: "sys/audit/" + device.Path},
+			exists:     staticExists(existing.audits[device.Path+"/"] != nil),
+			apply: func(ctx context.Context, vault *api.Client) (client.ChangeRecord, error) {
+				err := vault.Sys().EnableAuditWithOptionsWithContext(ctx, device.Path, &api.EnableAuditOptions{Type: device.Type, Options: device.Options})
+				return client.ChangeRecord{ResourceType: client.ResourceAuditDevice, Path: "sys/audit/" + device.Path, New: map[string]any{"type": device.Type}}, err
+			},
+		})
+	}
+
+	for _, mount := range template.Mounts {
+		steps = append(steps, mountStep(mount, existing))
+	}
+
+	if pki := template.PKI; pki != nil {
+		rootExisted := existing.mounts[pki.RootMount+"/"] != nil
+		intermediateExisted := existing.mounts[pki.IntermediateMount+"/"] != nil
+
+		steps = append(steps,
+			mountStep(LayoutMount{Path: pki.RootMount, Type: "pki", Description: "Root CA", Options: map[string]string{}}, existing),
+			layoutStep{
+				LayoutStep: LayoutStep{Action: "generate_root_ca", Path: pki.RootMount + "/root/generate/internal"},
+				exists:     staticExists(rootExisted),
+				apply: func(ctx context.Context, vault *api.Client) (client.ChangeRecord, error) {
+					ttl := defaultString(pki.RootTTL, "87600h")
+					if err := tuneMaxLeaseTTL(ctx, vault, pki.RootMount, ttl); err != nil {
+						return client.ChangeRecord{}, err
+					}
+					secret, err := vault.Logical().WriteWithContext(ctx, pki.RootMount+"/root/generate/internal", map[string]interface{}{
+						"common_name": pki.RootCommonName,
+						"issuer_name": "root",
+						"ttl":         ttl,
+					})
+					return client.ChangeRecord{ResourceType: client.ResourcePKIIssuer, Mount: pki.RootMount, Path: pki.RootMount + "/issuer/root", New: issuerSummary(secret)}, err
+				},
+			},
+			mountStep(LayoutMount{Path: pki.IntermediateMount, Type: "pki", Description: "Intermediate CA", Options: map[string]string{}}, existing),
+			layoutStep{
+				LayoutStep: LayoutStep{Action: "generate_intermediate_ca", Path: pki.IntermediateMount + "/intermediate/set-signed"},
+				exists:     staticExists(intermediateExisted),
+				apply: func(ctx context.Context, vault *api.Client) (client.ChangeRecord, error) {
+					return generateIntermediateCA(ctx, vault, vault, pki)
+				},
+			},
+		)
+
+		for _, role := range pki.Roles {
+			path := pki.IntermediateMount + "/roles/" + role.Name
+			steps = append(steps, layoutStep{
+				LayoutStep: LayoutStep{Action: "create_pki_role", Path: path},
+				exists:     readExists(path),
+				apply: func(ctx context.Context, vault *api.Client) (client.ChangeRecord, error) {
+					data := map[string]interface{}{
+						"allowed_domains":  role.AllowedDomains,
+						"allow_subdomains": role.AllowSubdomains,
+					}
+					if role.MaxTTL != "" {
+						data["max_ttl"] = role.MaxTTL
+					}
+					_, err := vault.Logical().WriteWithContext(ctx, path, data)
+					return client.ChangeRecord{ResourceType: client.ResourcePKIRole, Mount: pki.IntermediateMount, Path: path, New: map[string]any{"allowed_domains": role.AllowedDomains}}, err
+				},
+			})
+		}
+	}
+
+	for _, policy := range template.Policies {
+		path := "sys/policies/acl/" + policy.Name
+		steps = append(steps, layoutStep{
+			LayoutStep: LayoutStep{Action: "write_policy", Path: path},
+			exists:     readExists(path),
+			apply: func(ctx context.Context, vault *api.Client) (client.ChangeRecord, error) {
+				err := vault.Sys().PutPolicyWithContext(ctx, policy.Name, policy.Rules)
+				return client.ChangeRecord{ResourceType: client.ResourcePolicy, Path: path, New: map[string]any{"name": policy.Name}}, err
+			},
+		})
+	}
+
+	for _, method := range template.AuthMethods {
+		steps = append(steps, layoutStep{
+			LayoutStep: LayoutStep{Action: "enable_auth_method", Path: "sys/auth/" + method.Path},
+			exists:     staticExists(existing.auths[method.Path+"/"] != nil),
+			apply: func(ctx context.Context, vault *api.Client) (client.ChangeRecord, error) {
+				err := vault.Sys().EnableAuthWithOptionsWithContext(ctx, method.Path, &api.EnableAuthOptions{Type: method.Type, Description: method.Description})
+				return client.ChangeRecord{ResourceType: client.ResourceAuthMethod, Mount: method.Path, Path: "sys/auth/" + method.Path, New: map[string]any{"type": method.Type}}, err
+			},
+		})
+	}
+
+	for _, user := range template.Users {
+		path := "auth/" + user.Mount + "/users/" + user.Username
+		steps = append(steps, layoutStep{
+			LayoutStep: LayoutStep{Action: "create_user", Path: path},
+			exists:     readExists(path),
+			apply: func(ctx context.Context, vault *api.Client) (client.ChangeRecord, error) {
+				_, err := vault.Logical().WriteWithContext(ctx, path, map[string]interface{}{
+					"password":       password,
+					"token_policies": user.Policies,
+				})
+				return client.ChangeRecord{ResourceType: client.ResourceUserpassUser, Mount: user.Mount, Path: path, New: map[string]any{"policies": user.Policies}}, err
+			},
+		})
+	}
+
+	return steps
+}
+
+// mountStep plans the step mounting a secrets engine
+func mountStep(mount LayoutMount, existing existingLayout) layoutStep {
+	return layoutStep{
+		LayoutStep: LayoutStep{Action: "create_mount", Path: "sys/mounts/" + mount.Path},
+		exists:     staticExists(existing.mounts[mount.Path+"/"] != nil),
+		apply: func(ctx context.Context, vault *api.Client) (client.ChangeRecord, error) {
+			err := vault.Sys().MountWithContext(ctx, mount.Path, &api.MountInput{Type: mount.Type, Description: mount.Description, Options: mount.Options})
+			return client.ChangeRecord{
+				ResourceType: client.ResourceMount,
+				Mount:        mount.Path,
+				Path:         "sys/mounts/" + mount.Path,
+				New:          map[string]any{"type": mount.Type, "description": mount.Description, "options": mount.Options},
+			}, err
+		},
+	}
+}
+
+// generateIntermediateCA generates the key of the intermediate CA, signs it with the root CA and imports
+// the signed certificate. The signer is the client of the namespace of the root CA.
+func generateIntermediateCA(ctx context.Context, vault *api.Client, signer *api.Client, pki *LayoutPKI) (client.ChangeRecord, error) {
+	ttl := defaultString(pki.IntermediateTTL, "43800h")
+	if err := tuneMaxLeaseTTL(ctx, vault, pki.IntermediateMount, ttl); err != nil {
+		return client.ChangeRecord{}, err
+	}
+
+	csr, err := vault.Logical().WriteWithContext(ctx, pki.IntermediateMount+"/intermediate/generate/internal", map[string]interface{}{
+		"common_name": pki.IntermediateCommonName,
+		"key_name":    "intermediate",
+	})
+	if err != nil {
+		return client.ChangeRecord{}, err
+	}
+	if csr == nil || csr.Data["csr"] == nil {
+		return client.ChangeRecord{}, fmt.Errorf("generating the intermediate CA returned no CSR")
+	}
+
+	signed, err := signer.Logical().WriteWithContext(ctx, pki.RootMount+"/root/sign-intermediate", map[string]interface{}{
+		"csr":         csr.Data["csr"],
+		"common_name": pki.IntermediateCommonName,
+		"format":      "pem_bundle",
+		"ttl":         ttl,
+	})
+	if err != nil {
+		return client.ChangeRecord{}, err
+	}
+	if signed == nil || signed.Data["certificate"] == nil {
+		return client.ChangeRecord{}, fmt.Errorf("signing the intermediate CA returned no certificate")
+	}
+
+	imported, err := vault.Logical().WriteWithContext(ctx, pki.IntermediateMount+"/intermediate/set-signed", map[string]interface{}{
+		"certificate": signed.Data["certificate"],
+	})
+	if err != nil {
+		return client.ChangeRecord{}, err
+	}
+
+	// Name the imported issuer, so that roles can refer to it
+	if imported != nil {
+		if issuers, ok := imported.Data["imported_issuers"].([]interface{}); ok && len(issuers) > 0 {
+			if id, ok := issuers[0].(string); ok {
+				if _, err := vault.Logical().WriteWithContext(ctx, pki.IntermediateMount+"/issuer/"+id, map[string]interface{}{"issuer_name": "intermediate"}); err != nil {
+					return client.ChangeRecord{}, err
+				}
+			}
+		}
+	}
+
+	return client.ChangeRecord{
+		ResourceType: client.ResourcePKIIssuer,
+		Mount:        pki.IntermediateMount,
+		Path:         pki.IntermediateMount + "/issuer/intermediate",
+		New:          map[string]any{"common_name": pki.IntermediateCommonName, "signed_by": pki.RootMount},
+	}, nil
+}
+
+// tuneMaxLeaseTTL raises the maximum lease TTL of a PKI mount to the lifetime of its CA
+func tuneMaxLeaseTTL(ctx context.Context, vault *api.Client, mount string, ttl string) error {
+	return vault.Sys().TuneMountWithContext(ctx, mount, api.MountConfigInput{MaxLeaseTTL: ttl})
+}
+
+// issuerSummary summarizes a generated issuer for its change record
+func issuerSummary(secret *api.Secret) map[string]any {
+	if secret == nil {
+		return nil
+	}
+	return map[string]any{"issuer_id": secret.Data["issuer_id"], "serial_number": secret.Data["serial_number"]}
+}
+
+func staticExists(exists bool) func(context.Context, *api.Client) (bool, error) {
+	return func(context.Context, *api.Client) (bool, error) {
+		return exists, nil
+	}
+}
+
+// readExists checks whether a path can be read, paths on mounts created by a dry run do not exist yet
+func readExists(path string) func(context.Context, *api.Client) (bool, error) {
+	return func(ctx context.Context, vault *api.Client) (bool, error) {
+		secret, err := vault.Logical().ReadWithContext(ctx, path)
+		if err != nil {
+			if respErr, ok := err.(*api.ResponseError); ok && respErr.StatusCode == 404 {
+				return false, nil
+			}
+			return false, err
+		}
+		return secret != nil, nil
+	}
+}
+
+func defaultString(value string, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
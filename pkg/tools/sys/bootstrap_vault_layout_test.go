@@ -0,0 +1,240 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// layoutVault is a mock Vault keeping the mounts, auth methods, audit devices and configuration paths
+// written to it
+type layoutVault struct {
+	mu     sync.Mutex
+	mounts map[string]interface{}
+	auths  map[string]interface{}
+	audits map[string]interface{}
+	paths  map[string]bool
+	writes []string
+	deny   string // Path refused with permission denied
+}
+
+func newLayoutVault(t *testing.T, lv *layoutVault) (context.Context, func()) {
+	t.Helper()
+
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lv.mu.Lock()
+		defer lv.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		path := strings.TrimPrefix(r.URL.Path, "/v1/")
+		if path == lv.deny {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+
+		if r.Method == http.MethodGet {
+			switch {
+			case path == "sys/mounts":
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": lv.mounts})
+			case path == "sys/auth":
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": lv.auths})
+			case path == "sys/audit":
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": lv.audits})
+			case lv.paths[path]:
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"name": path}})
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"errors":[]}`))
+			}
+			return
+		}
+
+		lv.writes = append(lv.writes, path)
+		switch {
+		case strings.HasPrefix(path, "sys/mounts/") && !strings.HasSuffix(path, "/tune"):
+			lv.mounts[strings.TrimPrefix(path, "sys/mounts/")+"/"] = map[string]interface{}{"type": "pki"}
+		case strings.HasPrefix(path, "sys/auth/"):
+			lv.auths[strings.TrimPrefix(path, "sys/auth/")+"/"] = map[string]interface{}{"type": "userpass"}
+		case strings.HasPrefix(path, "sys/audit/"):
+			lv.audits[strings.TrimPrefix(path, "sys/audit/")+"/"] = map[string]interface{}{"type": "file"}
+		case strings.HasSuffix(path, "/intermediate/generate/internal"):
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"csr": "-----BEGIN CERTIFICATE REQUEST-----"}})
+			return
+		case strings.HasSuffix(path, "/root/sign-intermediate"):
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"certificate": "-----BEGIN CERTIFICATE-----"}})
+			return
+		case strings.HasSuffix(path, "/intermediate/set-signed"):
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"imported_issuers": []string{"b7c1"}}})
+			return
+		case strings.HasSuffix(path, "/root/generate/internal"):
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"issuer_id": "a1f3", "serial_number": "1a:2b"}})
+			return
+		default:
+			lv.paths[path] = true
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	sessionID := "test-" + t.Name()
+	_, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+
+	ctx := server.NewMCPServer("test", "1.0").WithContext(context.Background(), testSession{id: sessionID})
+
+	return ctx, func() {
+		mockVault.Close()
+		client.DeleteVaultClient(sessionID)
+	}
+}
+
+func emptyLayoutVault() *layoutVault {
+	return &layoutVault{
+		mounts: map[string]interface{}{"secret/": map[string]interface{}{"type": "kv"}},
+		auths:  map[string]interface{}{"token/": map[string]interface{}{"type": "token"}},
+		audits: map[string]interface{}{},
+		paths:  map[string]bool{},
+	}
+}
+
+func callBootstrapVaultLayout(t *testing.T, ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, LayoutReport) {
+	t.Helper()
+	logger := log.New()
+	logger.SetLevel(log.PanicLevel)
+
+	result, err := BootstrapVaultLayout(logger).Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{
+		Name:      "bootstrap_vault_layout",
+		Arguments: args,
+	}})
+	require.NoError(t, err)
+
+	var report LayoutReport
+	if !result.IsError {
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &report))
+	}
+	return result, report
+}
+
+func TestBootstrapVaultLayout(t *testing.T) {
+	t.Run("applies the template and is idempotent", func(t *testing.T) {
+		lv := emptyLayoutVault()
+		ctx, cleanup := newLayoutVault(t, lv)
+		defer cleanup()
+
+		result, report := callBootstrapVaultLayout(t, ctx, map[string]interface{}{"template": "workshop", "password": "workshop-pass"})
+		require.False(t, result.IsError)
+		assert.False(t, report.Failed)
+		assert.Equal(t, 0, report.Existing)
+		assert.Equal(t, len(report.Steps), report.Created)
+
+		var actions []string
+		for _, step := range report.Steps {
+			assert.Equal(t, LayoutStepCreated, step.Status, step.Path)
+			actions = append(actions, step.Action)
+		}
+		assert.Equal(t, []string{
+			"enable_audit_device", "create_mount", "create_mount", "generate_root_ca", "create_mount", "generate_intermediate_ca", "create_pki_role",
+			"write_policy", "write_policy", "write_policy", "write_policy", "enable_auth_method", "create_user",
+		}, actions)
+		assert.Contains(t, lv.writes, "pki_int/issuer/b7c1", "the imported intermediate is named")
+		assert.True(t, lv.paths["auth/userpass/users/admin"])
+
+		// Created resources are recorded, with the audit device and the user revertible by deleting their path
+		changes := client.SessionChanges("test-" + t.Name())
+		require.Len(t, changes, len(report.Steps))
+		assert.Equal(t, client.ResourceAuditDevice, changes[0].ResourceType)
+		assert.Equal(t, "sys/audit/stdout", changes[0].Path)
+		assert.Equal(t, "bootstrap_vault_layout", changes[0].Tool)
+		assert.Equal(t, client.ResourceUserpassUser, changes[len(changes)-1].ResourceType)
+		assert.NotContains(t, changes[len(changes)-1].New, "password")
+
+		writes := len(lv.writes)
+		_, report = callBootstrapVaultLayout(t, ctx, map[string]interface{}{"template": "workshop", "password": "workshop-pass"})
+		assert.Equal(t, 0, report.Created)
+		assert.Equal(t, len(report.Steps), report.Existing)
+		assert.Len(t, lv.writes, writes, "a second run changes nothing")
+	})
+
+	t.Run("dry run plans without writing", func(t *testing.T) {
+		lv := emptyLayoutVault()
+		lv.mounts["kv/"] = map[string]interface{}{"type": "kv"}
+		ctx, cleanup := newLayoutVault(t, lv)
+		defer cleanup()
+
+		result, report := callBootstrapVaultLayout(t, ctx, map[string]interface{}{"password": "p", "dry_run": true, "audit_file_path": "/tmp/audit.log"})
+		require.False(t, result.IsError)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, "starter", report.Template)
+		assert.Empty(t, lv.writes)
+		assert.Equal(t, 1, report.Existing)
+		assert.Equal(t, LayoutStepExists, report.Steps[1].Status)
+		assert.Equal(t, LayoutStepPlanned, report.Steps[0].Status)
+		assert.Equal(t, "/var/log/vault/audit.log", LayoutTemplates["starter"].AuditDevices[0].Options["file_path"], "the built-in template is not modified")
+	})
+
+	t.Run("stops at the first failure", func(t *testing.T) {
+		lv := emptyLayoutVault()
+		lv.deny = "sys/mounts/pki_int"
+		ctx, cleanup := newLayoutVault(t, lv)
+		defer cleanup()
+
+		result, report := callBootstrapVaultLayout(t, ctx, map[string]interface{}{"template": "workshop", "password": "p"})
+		require.False(t, result.IsError)
+		assert.True(t, report.Failed)
+		assert.Equal(t, LayoutStepFailed, report.Steps[4].Status)
+		assert.Contains(t, report.Steps[4].Error, "permission denied")
+		for _, step := range report.Steps[5:] {
+			assert.Equal(t, LayoutStepSkipped, step.Status)
+		}
+		assert.False(t, lv.paths["auth/userpass/users/admin"])
+	})
+
+	t.Run("custom template", func(t *testing.T) {
+		lv := emptyLayoutVault()
+		ctx, cleanup := newLayoutVault(t, lv)
+		defer cleanup()
+
+		result, report := callBootstrapVaultLayout(t, ctx, map[string]interface{}{"custom_template": map[string]interface{}{
+			"mounts":   []interface{}{map[string]interface{}{"path": "team-a", "type": "kv", "options": map[string]interface{}{"version": "2"}}},
+			"policies": []interface{}{map[string]interface{}{"name": "team-a", "rules": `path "team-a/*" { capabilities = ["read"] }`}},
+		}})
+		require.False(t, result.IsError)
+		assert.Equal(t, "custom", report.Template)
+		assert.Equal(t, 2, report.Created)
+		assert.Equal(t, []string{"sys/mounts/team-a", "sys/policies/acl/team-a"}, lv.writes)
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		lv := emptyLayoutVault()
+		ctx, cleanup := newLayoutVault(t, lv)
+		defer cleanup()
+
+		for name, args := range map[string]map[string]interface{}{
+			"unknown template": {"template": "enterprise", "password": "p"},
+			"missing password": {"template": "starter"},
+			"unknown field":    {"custom_template": map[string]interface{}{"secrets": []interface{}{}}},
+			"unnamed mount":    {"custom_template": map[string]interface{}{"mounts": []interface{}{map[string]interface{}{"type": "kv"}}}},
+			"same pki mounts": {"custom_template": map[string]interface{}{"pki": map[string]interface{}{
+				"root_mount": "pki", "root_common_name": "Root", "intermediate_mount": "pki", "intermediate_common_name": "Int",
+			}}},
+		} {
+			result, _ := callBootstrapVaultLayout(t, ctx, args)
+			assert.True(t, result.IsError, name)
+		}
+		assert.Empty(t, lv.writes)
+	})
+}
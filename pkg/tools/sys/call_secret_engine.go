@@ -0,0 +1,273 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// SecretEngineAllowlistEnv lists the mounts that call_secret_engine may call, as a comma-separated list of
+// mount=access pairs with access 'read' or 'write', e.g. "artifactory=read,nomad=write"
+const SecretEngineAllowlistEnv = "MCP_SECRET_ENGINE_ALLOWLIST"
+
+const (
+	EngineAccessRead  = "read"  // Only read and list operations
+	EngineAccessWrite = "write" // Read, list and write operations
+)
+
+// SecretEngineAllowlist holds the access granted to call_secret_engine, by mount
+type SecretEngineAllowlist map[string]string
+
+// LoadSecretEngineAllowlistFromEnv loads the mounts call_secret_engine may call from MCP_SECRET_ENGINE_ALLOWLIST.
+// A mount without an access is read-only. Invalid entries and the sys/ and auth/ paths, which have tools of
+// their own, are ignored with a warning.
+func LoadSecretEngineAllowlistFromEnv() SecretEngineAllowlist {
+	allowlist := SecretEngineAllowlist{}
+	for _, entry := range strings.Split(os.Getenv(SecretEngineAllowlistEnv), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		mount, access, hasAccess := strings.Cut(entry, "=")
+		mount = strings.Trim(strings.TrimSpace(mount), "/")
+		access = strings.ToLower(strings.TrimSpace(access))
+		if !hasAccess {
+			access = EngineAccessRead
+		}
+		if _, err := cleanRawPath(mount); err != nil || (access != EngineAccessRead && access != EngineAccessWrite) {
+			log.Warnf("Invalid %s entry '%s', expected mount=read or mount=write", SecretEngineAllowlistEnv, entry)
+			continue
+		}
+		if first, _, _ := strings.Cut(mount, "/"); first == "sys" || first == "auth" {
+			log.Warnf("Ignoring %s entry '%s', sys/ and auth/ paths cannot be called through call_secret_engine", SecretEngineAllowlistEnv, entry)
+			continue
+		}
+		allowlist[mount] = access
+	}
+	if len(allowlist) > 0 {
+		log.Infof("call_secret_engine is enabled for %d mounts", len(allowlist))
+	}
+	return allowlist
+}
+
+// Mounts returns the allowlisted mounts in order
+func (a SecretEngineAllowlist) Mounts() []string {
+	mounts := make([]string, 0, len(a))
+	for mount := range a {
+		mounts = append(mounts, mount)
+	}
+	sort.Strings(mounts)
+	return mounts
+}
+
+// RawResponse is the response of Vault to a call of an endpoint without a dedicated tool
+type RawResponse struct {
+	Method        string                 `json:"method"`
+	Path          string                 `json:"path"`
+	Data          map[string]interface{} `json:"data,omitempty"`
+	Warnings      []string               `json:"warnings,omitempty"`
+	LeaseID       string                 `json:"lease_id,omitempty"`
+	LeaseDuration int                    `json:"lease_duration,omitempty"`
+	Renewable     bool                   `json:"renewable,omitempty"`
+}
+
+// CallSecretEngine creates a tool for calling the endpoints of allowlisted secrets engines that have no
+// dedicated tools
+func CallSecretEngine(allowlist SecretEngineAllowlist, logger *log.Logger) server.ServerTool {
+	var mounts []string
+	for _, mount := range allowlist.Mounts() {
+		mounts = append(mounts, fmt.Sprintf("'%s' (%s)", mount, allowlist[mount]))
+	}
+
+	return server.ServerTool{
+		Tool: mcp.NewTool("call_secret_engine",
+			mcp.WithDescription(fmt.Sprintf(`Call an endpoint of a secrets engine that has no dedicated tool, such as a plugin for Artifactory or Nomad. Only use it when no other tool covers the operation, and check the API documentation of the engine for its paths and parameters.
+Allowed mounts: %s. Mounts allowed for 'read' only accept the 'read' and 'list' methods.`, strings.Join(mounts, ", "))),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					DestructiveHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithString("mount",
+				mcp.Required(),
+				mcp.Enum(allowlist.Mounts()...),
+				mcp.Description("The mount of the secrets engine, one of the allowed mounts."),
+			),
+			mcp.WithString("method",
+				mcp.DefaultString("read"),
+				mcp.Enum("read", "list", "write"),
+				mcp.Description("The operation: 'read' (GET), 'list' (LIST) or 'write' (POST). Defaults to 'read'."),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("The path of the endpoint relative to the mount, for example 'roles/ci' or 'token/ci'."),
+			),
+			mcp.WithObject("body",
+				mcp.Description("The JSON parameters of a write."),
+			),
+			schemas.Output("call_secret_engine"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return callSecretEngineHandler(ctx, req, allowlist, logger)
+		},
+	}
+}
+
+func callSecretEngineHandler(ctx context.Context, req mcp.CallToolRequest, allowlist SecretEngineAllowlist, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling call_secret_engine request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, _ := args["mount"].(string)
+	mount = strings.Trim(mount, "/")
+	access, allowed := allowlist[mount]
+	if !allowed {
+		return mcp.NewToolResultError(fmt.Sprintf("Mount '%s' is not allowed, allowed mounts: %s", mount, strings.Join(allowlist.Mounts(), ", "))), nil
+	}
+
+	method := req.GetString("method", "read")
+	if method != "read" && method != "list" && method != "write" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'method' parameter '%s', expected 'read', 'list' or 'write'", method)), nil
+	}
+	if method == "write" && access != EngineAccessWrite {
+		return mcp.NewToolResultError(fmt.Sprintf("Mount '%s' is allowed for reads only, writes are refused", mount)), nil
+	}
+
+	relative, _ := args["path"].(string)
+	relative, err := cleanRawPath(relative)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'path' parameter: %v", err)), nil
+	}
+	fullPath := mount + "/" + relative
+
+	body, _ := args["body"].(map[string]interface{})
+	if body != nil && method != "write" {
+		return mcp.NewToolResultError("The 'body' parameter is only used by writes"), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":  mount,
+		"method": method,
+		"path":   fullPath,
+	}).Debug("Calling secrets engine endpoint")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	secret, err := callRawEndpoint(vault, method, fullPath, body)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to %s path '%s': %v", method, fullPath, err)), nil
+	}
+
+	response := newRawResponse(method, fullPath, secret)
+	if method != "write" {
+		return rawResponseResult(response, logger)
+	}
+
+	logger.WithFields(log.Fields{
+		"mount": mount,
+		"path":  fullPath,
+	}).Info("Wrote to secrets engine endpoint")
+
+	// The parameters of a write may hold secrets, only their names are recorded
+	change := client.ChangeRecord{
+		Tool:         req.Params.Name,
+		ResourceType: client.ResourceRawEndpoint,
+		Mount:        mount,
+		Path:         fullPath,
+		Operation:    client.OperationUpdate,
+		New:          map[string]any{"parameters": sortedKeys(body)},
+		Message:      fmt.Sprintf("Wrote to '%s' through call_secret_engine.", fullPath),
+	}
+	if secret != nil {
+		change.VaultRequestID = secret.RequestID
+	}
+	client.RecordChange(ctx, change)
+	return rawResponseResult(response, logger)
+}
+
+// cleanRawPath validates a path given to the escape hatch tools. Paths must be relative and may not
+// contain empty, '.' or '..' segments, so that they cannot leave the allowed prefix.
+func cleanRawPath(path string) (string, error) {
+	path = strings.Trim(strings.TrimSpace(path), "/")
+	if path == "" {
+		return "", fmt.Errorf("the path is empty")
+	}
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return "", fmt.Errorf("the path '%s' may not contain empty, '.' or '..' segments", path)
+		}
+		if strings.ContainsAny(segment, "?#%\\") {
+			return "", fmt.Errorf("the path '%s' may not contain '?', '#', '%%' or '\\'", path)
+		}
+	}
+	return path, nil
+}
+
+// callRawEndpoint calls a Vault endpoint with the logical client
+func callRawEndpoint(vault *api.Client, method string, path string, body map[string]interface{}) (*api.Secret, error) {
+	switch method {
+	case "list":
+		return vault.Logical().List(path)
+	case "write":
+		if body == nil {
+			body = map[string]interface{}{}
+		}
+		return vault.Logical().Write(path, body)
+	case "delete":
+		return vault.Logical().Delete(path)
+	default:
+		return vault.Logical().Read(path)
+	}
+}
+
+func newRawResponse(method string, path string, secret *api.Secret) RawResponse {
+	response := RawResponse{Method: method, Path: path}
+	if secret != nil {
+		response.Data = secret.Data
+		response.Warnings = secret.Warnings
+		response.LeaseID = secret.LeaseID
+		response.LeaseDuration = secret.LeaseDuration
+		response.Renewable = secret.Renewable
+	}
+	return response
+}
+
+func rawResponseResult(response RawResponse, logger *log.Logger) (*mcp.CallToolResult, error) {
+	jsonData, err := utils.MarshalJSON(response)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal response to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+	return mcp.NewToolResultStructured(response, jsonData), nil
+}
+
+func sortedKeys(values map[string]interface{}) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
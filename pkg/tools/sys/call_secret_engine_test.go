@@ -0,0 +1,119 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSecretEngineAllowlistFromEnv(t *testing.T) {
+	t.Setenv(SecretEngineAllowlistEnv, "artifactory, nomad=write, team/consul=READ, sys=write, auth/token=read, bad=admin, ../kv=read")
+	assert.Equal(t, SecretEngineAllowlist{
+		"artifactory": EngineAccessRead,
+		"nomad":       EngineAccessWrite,
+		"team/consul": EngineAccessRead,
+	}, LoadSecretEngineAllowlistFromEnv())
+}
+
+func TestCleanRawPath(t *testing.T) {
+	path, err := cleanRawPath("/roles/ci/")
+	require.NoError(t, err)
+	assert.Equal(t, "roles/ci", path)
+
+	for _, invalid := range []string{"", "/", "../sys/seal", "roles/../../sys", "roles//ci", "./roles", "roles?list=true", "roles%2F..", `roles\ci`} {
+		_, err := cleanRawPath(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestCallSecretEngine(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	var requests []string
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == "LIST" || r.URL.Query().Get("list") == "true":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"keys": []string{"ci", "deploy"}}})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/nomad/creds/ci":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"lease_id": "nomad/creds/ci/abc", "lease_duration": 600,
+				"data": map[string]interface{}{"accessor_id": "a1", "secret_id": "s1"},
+			})
+		case r.Method == http.MethodPut || r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockVault.Close()
+
+	sessionID := "test-" + t.Name()
+	_, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	defer client.DeleteVaultClient(sessionID)
+	defer client.DeleteSessionChanges(sessionID)
+	ctx := server.NewMCPServer("test", "1.0").WithContext(context.Background(), testSession{id: sessionID})
+
+	tool := CallSecretEngine(SecretEngineAllowlist{"nomad": EngineAccessWrite, "artifactory": EngineAccessRead}, logger)
+	call := func(args map[string]interface{}) *mcp.CallToolResult {
+		result, err := tool.Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "call_secret_engine", Arguments: args}})
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("read", func(t *testing.T) {
+		result := call(map[string]interface{}{"mount": "nomad", "path": "creds/ci"})
+		require.False(t, result.IsError, result.Content)
+		var response RawResponse
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &response))
+		assert.Equal(t, "nomad/creds/ci", response.Path)
+		assert.Equal(t, "s1", response.Data["secret_id"])
+		assert.Equal(t, "nomad/creds/ci/abc", response.LeaseID)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		result := call(map[string]interface{}{"mount": "artifactory", "method": "list", "path": "roles"})
+		require.False(t, result.IsError, result.Content)
+		assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "deploy")
+	})
+
+	t.Run("write", func(t *testing.T) {
+		result := call(map[string]interface{}{"mount": "nomad", "method": "write", "path": "role/ci", "body": map[string]interface{}{"policies": "ci", "token": "secret"}})
+		require.False(t, result.IsError, result.Content)
+		assert.Contains(t, requests, "PUT /v1/nomad/role/ci")
+
+		changes := client.SessionChanges(sessionID)
+		require.Len(t, changes, 1)
+		assert.Equal(t, client.ResourceRawEndpoint, changes[0].ResourceType)
+		assert.Equal(t, []string{"policies", "token"}, changes[0].New["parameters"], "only the names of the parameters are recorded")
+	})
+
+	t.Run("refused calls", func(t *testing.T) {
+		before := len(requests)
+		for name, args := range map[string]map[string]interface{}{
+			"mount not allowed":   {"mount": "secret", "path": "data/app"},
+			"write to read mount": {"mount": "artifactory", "method": "write", "path": "roles/ci"},
+			"path escaping mount": {"mount": "nomad", "path": "../sys/seal"},
+			"unknown method":      {"mount": "nomad", "method": "delete", "path": "role/ci"},
+			"body on read":        {"mount": "nomad", "path": "role/ci", "body": map[string]interface{}{"a": "b"}},
+		} {
+			assert.True(t, call(args).IsError, name)
+		}
+		assert.Len(t, requests, before, "refused calls never reach Vault")
+	})
+}
@@ -0,0 +1,217 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// SysEndpointAllowlistEnv lists the sys/ endpoints that call_sys_endpoint may call, as a comma-separated list
+// of 'method path' entries, e.g. "read sys/leases/lookup/**,write sys/leases/renew"
+const SysEndpointAllowlistEnv = "MCP_SYS_ENDPOINT_ALLOWLIST"
+
+// sysMethods maps the methods of allowlist entries, including their HTTP names, to the methods of the tool
+var sysMethods = map[string]string{
+	"read":   "read",
+	"get":    "read",
+	"list":   "list",
+	"write":  "write",
+	"post":   "write",
+	"put":    "write",
+	"delete": "delete",
+}
+
+// SysEndpointRule allows a method on the sys/ paths matching a pattern, where '*' matches within one segment
+// and '**' matches any number of segments
+type SysEndpointRule struct {
+	Method  string `json:"method"`
+	Pattern string `json:"pattern"`
+}
+
+// SysEndpointAllowlist holds the sys/ endpoints call_sys_endpoint may call
+type SysEndpointAllowlist []SysEndpointRule
+
+// LoadSysEndpointAllowlistFromEnv loads the endpoints call_sys_endpoint may call from MCP_SYS_ENDPOINT_ALLOWLIST.
+// Patterns must start with 'sys/' and name the endpoint group, so that 'sys/**' cannot grant the whole API.
+// Invalid entries are ignored with a warning.
+func LoadSysEndpointAllowlistFromEnv() SysEndpointAllowlist {
+	var allowlist SysEndpointAllowlist
+	for _, entry := range strings.Split(os.Getenv(SysEndpointAllowlistEnv), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		fields := strings.Fields(entry)
+		if len(fields) != 2 {
+			log.Warnf("Invalid %s entry '%s', expected 'method sys/path'", SysEndpointAllowlistEnv, entry)
+			continue
+		}
+		method, ok := sysMethods[strings.ToLower(fields[0])]
+		pattern := strings.Trim(fields[1], "/")
+		segments := strings.Split(pattern, "/")
+		if !ok || len(segments) < 2 || segments[0] != "sys" || strings.ContainsAny(segments[1], "*?[") {
+			log.Warnf("Invalid %s entry '%s', expected a method (read, list, write or delete) and a path under sys/ naming the endpoint, such as 'sys/leases/lookup/**'", SysEndpointAllowlistEnv, entry)
+			continue
+		}
+		if _, err := cleanRawPath(pattern); err != nil {
+			log.Warnf("Invalid %s entry '%s': %v", SysEndpointAllowlistEnv, entry, err)
+			continue
+		}
+		allowlist = append(allowlist, SysEndpointRule{Method: method, Pattern: pattern})
+	}
+	if len(allowlist) > 0 {
+		log.Infof("call_sys_endpoint is enabled for %d endpoints", len(allowlist))
+	}
+	return allowlist
+}
+
+// Allows reports whether the method may be called on the path
+func (a SysEndpointAllowlist) Allows(method string, path string) bool {
+	segments := strings.Split(path, "/")
+	for _, rule := range a {
+		if rule.Method == method && matchSegments(strings.Split(rule.Pattern, "/"), segments) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadOnly reports whether the allowlist only allows reads and lists
+func (a SysEndpointAllowlist) ReadOnly() bool {
+	for _, rule := range a {
+		if rule.Method == "write" || rule.Method == "delete" {
+			return false
+		}
+	}
+	return true
+}
+
+func (a SysEndpointAllowlist) String() string {
+	rules := make([]string, 0, len(a))
+	for _, rule := range a {
+		rules = append(rules, rule.Method+" "+rule.Pattern)
+	}
+	return strings.Join(rules, ", ")
+}
+
+// CallSysEndpoint creates a tool for calling the allowlisted sys/ endpoints that have no dedicated tools
+func CallSysEndpoint(allowlist SysEndpointAllowlist, logger *log.Logger) server.ServerTool {
+	annotation := mcp.ToolAnnotation{DestructiveHint: utils.ToBoolPtr(true)}
+	if allowlist.ReadOnly() {
+		annotation = mcp.ToolAnnotation{ReadOnlyHint: utils.ToBoolPtr(true)}
+	}
+
+	return server.ServerTool{
+		Tool: mcp.NewTool("call_sys_endpoint",
+			mcp.WithDescription(fmt.Sprintf(`Call an endpoint of the sys/ API that has no dedicated tool. Only use it when no other tool covers the operation, and check the Vault API documentation for the parameters of the endpoint.
+Allowed endpoints, where '*' matches within one path segment and '**' any number of segments: %s.`, allowlist)),
+			mcp.WithToolAnnotation(annotation),
+			mcp.WithString("method",
+				mcp.DefaultString("read"),
+				mcp.Enum("read", "list", "write", "delete"),
+				mcp.Description("The operation: 'read' (GET), 'list' (LIST), 'write' (POST) or 'delete' (DELETE). Defaults to 'read'."),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("The path of the endpoint, for example 'sys/leases/lookup'."),
+			),
+			mcp.WithObject("body",
+				mcp.Description("The JSON parameters of a write."),
+			),
+			schemas.Output("call_sys_endpoint"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return callSysEndpointHandler(ctx, req, allowlist, logger)
+		},
+	}
+}
+
+func callSysEndpointHandler(ctx context.Context, req mcp.CallToolRequest, allowlist SysEndpointAllowlist, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling call_sys_endpoint request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	method := req.GetString("method", "read")
+	if sysMethods[method] != method {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'method' parameter '%s', expected 'read', 'list', 'write' or 'delete'", method)), nil
+	}
+
+	path, _ := args["path"].(string)
+	path, err := cleanRawPath(path)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'path' parameter: %v", err)), nil
+	}
+	if !strings.HasPrefix(path, "sys/") {
+		path = "sys/" + path
+	}
+	if !allowlist.Allows(method, path) {
+		return mcp.NewToolResultError(fmt.Sprintf("'%s %s' is not allowed, allowed endpoints: %s", method, path, allowlist)), nil
+	}
+
+	body, _ := args["body"].(map[string]interface{})
+	if body != nil && method != "write" {
+		return mcp.NewToolResultError("The 'body' parameter is only used by writes"), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"method": method,
+		"path":   path,
+	}).Debug("Calling sys endpoint")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	secret, err := callRawEndpoint(vault, method, path, body)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to %s path '%s': %v", method, path, err)), nil
+	}
+
+	response := newRawResponse(method, path, secret)
+	if method == "read" || method == "list" {
+		return rawResponseResult(response, logger)
+	}
+
+	logger.WithFields(log.Fields{
+		"method": method,
+		"path":   path,
+	}).Info("Changed sys endpoint")
+
+	// The parameters of a write may hold secrets, only their names are recorded
+	change := client.ChangeRecord{
+		Tool:         req.Params.Name,
+		ResourceType: client.ResourceRawEndpoint,
+		Path:         path,
+		Operation:    client.OperationUpdate,
+		New:          map[string]any{"parameters": sortedKeys(body)},
+		Message:      fmt.Sprintf("Wrote to '%s' through call_sys_endpoint.", path),
+	}
+	if method == "delete" {
+		change.Operation = client.OperationDelete
+		change.New = nil
+		change.Message = fmt.Sprintf("Deleted '%s' through call_sys_endpoint.", path)
+	}
+	if secret != nil {
+		change.VaultRequestID = secret.RequestID
+	}
+	client.RecordChange(ctx, change)
+	return rawResponseResult(response, logger)
+}
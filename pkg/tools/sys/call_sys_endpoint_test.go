@@ -0,0 +1,120 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSysEndpointAllowlistFromEnv(t *testing.T) {
+	t.Setenv(SysEndpointAllowlistEnv, "GET sys/leases/lookup/**, write sys/leases/renew, LIST sys/policies/acl, read sys/**, write sys/*/x, delete kv/data/x, read, admin sys/seal, read sys/../kv")
+	allowlist := LoadSysEndpointAllowlistFromEnv()
+	assert.Equal(t, SysEndpointAllowlist{
+		{Method: "read", Pattern: "sys/leases/lookup/**"},
+		{Method: "write", Pattern: "sys/leases/renew"},
+		{Method: "list", Pattern: "sys/policies/acl"},
+	}, allowlist)
+	assert.False(t, allowlist.ReadOnly())
+
+	assert.True(t, allowlist.Allows("read", "sys/leases/lookup/database/creds/ro/abc"))
+	assert.True(t, allowlist.Allows("write", "sys/leases/renew"))
+	assert.False(t, allowlist.Allows("write", "sys/leases/lookup/x"), "the method must match")
+	assert.False(t, allowlist.Allows("write", "sys/leases/renew/extra"))
+	assert.False(t, allowlist.Allows("read", "sys/seal-status"))
+}
+
+func TestCallSysEndpoint(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	var requests []string
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/sys/internal/counters/activity":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"total": map[string]interface{}{"clients": 42}}})
+		case r.Method == http.MethodPut || r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockVault.Close()
+
+	sessionID := "test-" + t.Name()
+	_, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	defer client.DeleteVaultClient(sessionID)
+	defer client.DeleteSessionChanges(sessionID)
+	ctx := server.NewMCPServer("test", "1.0").WithContext(context.Background(), testSession{id: sessionID})
+
+	allowlist := SysEndpointAllowlist{
+		{Method: "read", Pattern: "sys/internal/counters/*"},
+		{Method: "write", Pattern: "sys/quotas/lease-count/*"},
+		{Method: "delete", Pattern: "sys/quotas/lease-count/*"},
+	}
+	tool := CallSysEndpoint(allowlist, logger)
+	assert.True(t, *tool.Tool.Annotations.DestructiveHint)
+	call := func(args map[string]interface{}) *mcp.CallToolResult {
+		result, err := tool.Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "call_sys_endpoint", Arguments: args}})
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("read", func(t *testing.T) {
+		result := call(map[string]interface{}{"path": "internal/counters/activity"})
+		require.False(t, result.IsError, result.Content)
+		var response RawResponse
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &response))
+		assert.Equal(t, "sys/internal/counters/activity", response.Path)
+		assert.NotNil(t, response.Data["total"])
+		assert.IsType(t, RawResponse{}, result.StructuredContent)
+	})
+
+	t.Run("write and delete are recorded", func(t *testing.T) {
+		result := call(map[string]interface{}{"method": "write", "path": "sys/quotas/lease-count/ci", "body": map[string]interface{}{"max_leases": 100}})
+		require.False(t, result.IsError, result.Content)
+		result = call(map[string]interface{}{"method": "delete", "path": "sys/quotas/lease-count/ci"})
+		require.False(t, result.IsError, result.Content)
+		assert.Contains(t, requests, "PUT /v1/sys/quotas/lease-count/ci")
+		assert.Contains(t, requests, "DELETE /v1/sys/quotas/lease-count/ci")
+
+		changes := client.SessionChanges(sessionID)
+		require.Len(t, changes, 2)
+		assert.Equal(t, client.OperationUpdate, changes[0].Operation)
+		assert.Equal(t, []string{"max_leases"}, changes[0].New["parameters"])
+		assert.Equal(t, client.OperationDelete, changes[1].Operation)
+	})
+
+	t.Run("refused calls", func(t *testing.T) {
+		before := len(requests)
+		for name, args := range map[string]map[string]interface{}{
+			"path not allowed":      {"path": "sys/seal-status"},
+			"method not allowed":    {"method": "write", "path": "sys/internal/counters/activity"},
+			"path escaping pattern": {"method": "write", "path": "sys/quotas/lease-count/../../seal"},
+			"http method name":      {"method": "GET", "path": "sys/internal/counters/activity"},
+			"body on read":          {"path": "sys/internal/counters/activity", "body": map[string]interface{}{"a": "b"}},
+		} {
+			assert.True(t, call(args).IsError, name)
+		}
+		assert.Len(t, requests, before, "refused calls never reach Vault")
+	})
+
+	t.Run("read-only allowlist", func(t *testing.T) {
+		tool := CallSysEndpoint(SysEndpointAllowlist{{Method: "list", Pattern: "sys/policies/acl"}}, logger)
+		assert.True(t, *tool.Tool.Annotations.ReadOnlyHint)
+	})
+}
@@ -0,0 +1,182 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+type MountProtection struct {
+	Path                  string `json:"path"`                    // Path of the mount, auth methods are prefixed with 'auth/'
+	Type                  string `json:"type"`                    // Type of the secrets engine or auth method
+	SealWrap              bool   `json:"seal_wrap"`               // Whether the data of the mount is seal wrapped
+	ExternalEntropyAccess bool   `json:"external_entropy_access"` // Whether the mount uses Entropy Augmentation
+}
+
+type ProtectionReport struct {
+	Mounts   []*MountProtection `json:"mounts"`                     // Protection of every secrets engine and auth method
+	Findings []*Finding         `json:"findings"`                   // Required protections that are missing, sorted by severity
+	Plan     *RemediationPlan   `json:"remediation_plan,omitempty"` // Drafted by the model of the client when generate_plan is set
+}
+
+// CheckMountProtection creates a tool for checking seal wrap and Entropy Augmentation of mounts against a required list
+func CheckMountProtection(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("check_mount_protection",
+			mcp.WithDescription("Report which secrets engines and auth methods have seal wrap and external entropy access (Entropy Augmentation) enabled, and compare them against the mounts that require HSM backed protection. Both settings need Vault Enterprise with an HSM seal and can only be set when a mount is created."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithString("require_seal_wrap",
+				mcp.DefaultString(""),
+				mcp.Description("Comma separated list of mounts that must be seal wrapped. Entries match a mount path, such as 'pki' or 'auth/cert', or every mount of a type, such as 'type:transit'."),
+			),
+			mcp.WithString("require_external_entropy",
+				mcp.DefaultString(""),
+				mcp.Description("Comma separated list of mounts that must use external entropy, in the same format as 'require_seal_wrap'."),
+			),
+			withGeneratePlan(),
+			schemas.Output("check_mount_protection"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return checkMountProtectionHandler(ctx, req, logger)
+		},
+	}
+}
+
+func checkMountProtectionHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling check_mount_protection request")
+
+	requireSealWrap := splitRequirements(req.GetString("require_seal_wrap", ""))
+	requireEntropy := splitRequirements(req.GetString("require_external_entropy", ""))
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		logger.WithError(err).Error("Failed to list mounts")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list mounts: %v", err)), nil
+	}
+
+	auths, err := vault.Sys().ListAuth()
+	if err != nil {
+		logger.WithError(err).Error("Failed to list auth methods")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list auth methods: %v", err)), nil
+	}
+
+	report := CheckProtection(mounts, auths, requireSealWrap, requireEntropy)
+	if req.GetBool("generate_plan", false) {
+		report.Plan = draftRemediationPlan(ctx, "mount protection check", report.Findings)
+	}
+
+	jsonData, err := json.Marshal(report)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal protection report to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithField("finding_count", len(report.Findings)).Debug("Successfully checked mount protection")
+	return mcp.NewToolResultStructured(report, string(jsonData)), nil
+}
+
+// CheckProtection compares the seal wrap and external entropy settings of the mounts against the requirements
+func CheckProtection(mounts map[string]*api.MountOutput, auths map[string]*api.AuthMount, requireSealWrap, requireEntropy []string) *ProtectionReport {
+	report := &ProtectionReport{
+		Mounts:   []*MountProtection{},
+		Findings: []*Finding{},
+	}
+
+	for path, mount := range mounts {
+		report.Mounts = append(report.Mounts, &MountProtection{
+			Path:                  strings.TrimSuffix(path, "/"),
+			Type:                  mount.Type,
+			SealWrap:              mount.SealWrap,
+			ExternalEntropyAccess: mount.ExternalEntropyAccess,
+		})
+	}
+	for path, auth := range auths {
+		report.Mounts = append(report.Mounts, &MountProtection{
+			Path:                  "auth/" + strings.TrimSuffix(path, "/"),
+			Type:                  auth.Type,
+			SealWrap:              auth.SealWrap,
+			ExternalEntropyAccess: auth.ExternalEntropyAccess,
+		})
+	}
+	sort.Slice(report.Mounts, func(i, j int) bool {
+		return report.Mounts[i].Path < report.Mounts[j].Path
+	})
+
+	check := func(requirements []string, setting string, enabled func(*MountProtection) bool) {
+		for _, requirement := range requirements {
+			matched := false
+			for _, mount := range report.Mounts {
+				if !matchesRequirement(mount, requirement) {
+					continue
+				}
+				matched = true
+				if !enabled(mount) {
+					report.Findings = append(report.Findings, &Finding{
+						Severity:       SeverityHigh,
+						Component:      "mount",
+						Message:        fmt.Sprintf("Mount '%s' requires %s but it is not enabled", mount.Path, setting),
+						Recommendation: fmt.Sprintf("%s can only be enabled when a mount is created, create a new mount with %s enabled and migrate the data", setting, setting),
+					})
+				}
+			}
+			if !matched {
+				report.Findings = append(report.Findings, &Finding{
+					Severity:  SeverityInfo,
+					Component: "mount",
+					Message:   fmt.Sprintf("No mount matches the %s requirement '%s'", setting, requirement),
+				})
+			}
+		}
+	}
+	check(requireSealWrap, "seal wrap", func(m *MountProtection) bool { return m.SealWrap })
+	check(requireEntropy, "external entropy access", func(m *MountProtection) bool { return m.ExternalEntropyAccess })
+
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		return severityOrder[report.Findings[i].Severity] < severityOrder[report.Findings[j].Severity]
+	})
+
+	return report
+}
+
+// matchesRequirement reports whether a mount matches a path or 'type:<type>' requirement
+func matchesRequirement(mount *MountProtection, requirement string) bool {
+	if mountType, ok := strings.CutPrefix(requirement, "type:"); ok {
+		return mount.Type == mountType
+	}
+	return mount.Path == requirement
+}
+
+// splitRequirements splits a comma separated list of requirements
+func splitRequirements(value string) []string {
+	var requirements []string
+	for _, requirement := range strings.Split(value, ",") {
+		if requirement = strings.Trim(strings.TrimSpace(requirement), "/"); requirement != "" {
+			requirements = append(requirements, requirement)
+		}
+	}
+	return requirements
+}
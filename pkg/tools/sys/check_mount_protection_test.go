@@ -0,0 +1,38 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckProtection(t *testing.T) {
+	mounts := map[string]*api.MountOutput{
+		"pki/":     {Type: "pki", SealWrap: true},
+		"transit/": {Type: "transit", SealWrap: true, ExternalEntropyAccess: false},
+		"secret/":  {Type: "kv"},
+	}
+	auths := map[string]*api.AuthMount{
+		"cert/": {Type: "cert"},
+	}
+
+	report := CheckProtection(mounts, auths,
+		splitRequirements("pki, auth/cert/, type:transit"),
+		splitRequirements("type:transit,missing"),
+	)
+
+	require.Len(t, report.Mounts, 4)
+	assert.Equal(t, "auth/cert", report.Mounts[0].Path)
+
+	require.Len(t, report.Findings, 3)
+	assert.Equal(t, SeverityHigh, report.Findings[0].Severity)
+	assert.Contains(t, report.Findings[0].Message, "'auth/cert' requires seal wrap")
+	assert.Contains(t, report.Findings[1].Message, "'transit' requires external entropy access")
+	assert.Equal(t, SeverityInfo, report.Findings[2].Severity)
+	assert.Contains(t, report.Findings[2].Message, "'missing'")
+}
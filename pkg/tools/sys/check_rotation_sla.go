@@ -0,0 +1,323 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// RotationSLA is the maximum age of the secrets matching a path pattern
+type RotationSLA struct {
+	Pattern string        `json:"pattern"`
+	MaxAge  string        `json:"max_age"`
+	maxAge  time.Duration // Parsed MaxAge
+}
+
+// RotationViolation is a secret that was not rotated within its SLA
+type RotationViolation struct {
+	Path        string    `json:"path"`    // Path of the secret including its mount
+	Pattern     string    `json:"pattern"` // Pattern of the SLA that applies, the strictest when several match
+	MaxAge      string    `json:"max_age"`
+	LastRotated time.Time `json:"last_rotated"` // Creation time of the current version
+	AgeDays     int       `json:"age_days"`
+	OverdueDays int       `json:"overdue_days"`
+}
+
+type RotationReport struct {
+	CheckedAt      time.Time            `json:"checked_at"`
+	SecretsChecked int                  `json:"secrets_checked"` // Secrets matching an SLA
+	Compliant      int                  `json:"compliant"`
+	Violations     []*RotationViolation `json:"violations"`          // Most overdue first
+	Skipped        []string             `json:"skipped,omitempty"`   // Mounts that cannot be checked, such as KV v1 mounts
+	Truncated      []string             `json:"truncated,omitempty"` // Mounts where checking stopped at the limit
+	Errors         []string             `json:"errors,omitempty"`
+}
+
+// CheckRotationSLA creates a tool for reporting KV v2 secrets that were not rotated within their SLA
+func CheckRotationSLA(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("check_rotation_sla",
+			mcp.WithDescription("Check the age of KV v2 secrets against rotation SLAs declared per path pattern, such as {'prod/*/db-creds': '90d'}, and report the secrets whose current version is older than their SLA, most overdue first. Patterns match the full path of a secret including its mount: '*' matches a single path segment and '**' any number of segments. When several patterns match a secret, the strictest SLA applies. Suited to recurring compliance checks."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint:   utils.ToBoolPtr(true),
+					IdempotentHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithObject("slas",
+				mcp.Required(),
+				mcp.Description("Maximum age per path pattern, as a number of days like '90d', weeks like '2w' or a duration like '720h'. For example {'prod/*/db-creds': '90d', 'secret/**': '365d'}."),
+			),
+			mcp.WithString("mount",
+				mcp.DefaultString(""),
+				mcp.Description("Optional KV v2 mount to check. Every KV v2 mount that a pattern can match is checked by default."),
+			),
+			mcp.WithNumber("limit",
+				mcp.DefaultNumber(MaxSummarizedSecrets),
+				mcp.Description("The maximum number of secrets checked per mount."),
+			),
+			schemas.Output("check_rotation_sla"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return checkRotationSLAHandler(ctx, req, logger)
+		},
+	}
+}
+
+func checkRotationSLAHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling check_rotation_sla request")
+
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+	rawSLAs, ok := args["slas"].(map[string]interface{})
+	if !ok || len(rawSLAs) == 0 {
+		return mcp.NewToolResultError("Missing or invalid 'slas' parameter, expected an object of path patterns and maximum ages"), nil
+	}
+	slas, err := parseRotationSLAs(rawSLAs)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	mountFilter := strings.Trim(req.GetString("mount", ""), "/")
+	limit := req.GetInt("limit", MaxSummarizedSecrets)
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		logger.WithError(err).Error("Failed to list mounts")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list mounts: %v", err)), nil
+	}
+	if mountFilter != "" {
+		mount, ok := mounts[mountFilter+"/"]
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("Mount '%s' does not exist", mountFilter)), nil
+		}
+		mounts = map[string]*api.MountOutput{mountFilter + "/": mount}
+	}
+
+	now := time.Now().UTC()
+	report := &RotationReport{CheckedAt: now, Violations: []*RotationViolation{}}
+	for mountPath, mount := range mounts {
+		mountPath = strings.TrimSuffix(mountPath, "/")
+		if mount.Type != "kv" && mount.Type != "generic" {
+			if mountFilter != "" {
+				return mcp.NewToolResultError(fmt.Sprintf("Mount '%s' is a '%s' mount, rotation SLAs can only be checked on KV v2 mounts", mountPath, mount.Type)), nil
+			}
+			continue
+		}
+		if !slices.ContainsFunc(slas, func(sla RotationSLA) bool { return patternCoversMount(sla.Pattern, mountPath) }) {
+			continue
+		}
+		if mount.Options["version"] != "2" {
+			report.Skipped = append(report.Skipped, fmt.Sprintf("%s: KV v1 mounts do not record when secrets were written", mountPath))
+			continue
+		}
+
+		secrets, truncated, err := listKVSecrets(vault, mountPath, true, limit)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s: failed to list secrets: %v", mountPath, err))
+			continue
+		}
+		if truncated {
+			report.Truncated = append(report.Truncated, mountPath)
+		}
+
+		for _, secretPath := range secrets {
+			fullPath := mountPath + "/" + secretPath
+			sla, ok := strictestSLA(slas, fullPath)
+			if !ok {
+				continue
+			}
+
+			lastRotated, err := currentVersionCreated(vault, mountPath, secretPath)
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", fullPath, err))
+				continue
+			}
+			report.SecretsChecked++
+
+			age := now.Sub(lastRotated)
+			if age <= sla.maxAge {
+				report.Compliant++
+				continue
+			}
+			report.Violations = append(report.Violations, &RotationViolation{
+				Path:        fullPath,
+				Pattern:     sla.Pattern,
+				MaxAge:      sla.MaxAge,
+				LastRotated: lastRotated,
+				AgeDays:     int(age / (24 * time.Hour)),
+				OverdueDays: int((age - sla.maxAge) / (24 * time.Hour)),
+			})
+		}
+	}
+
+	sort.Slice(report.Violations, func(i, j int) bool {
+		if report.Violations[i].OverdueDays != report.Violations[j].OverdueDays {
+			return report.Violations[i].OverdueDays > report.Violations[j].OverdueDays
+		}
+		return report.Violations[i].Path < report.Violations[j].Path
+	})
+	sort.Strings(report.Skipped)
+	sort.Strings(report.Truncated)
+
+	jsonData, err := json.Marshal(report)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal rotation report to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"checked":    report.SecretsChecked,
+		"violations": len(report.Violations),
+	}).Debug("Successfully checked rotation SLAs")
+	return mcp.NewToolResultStructured(report, string(jsonData)), nil
+}
+
+// parseRotationSLAs parses the SLAs of the tool arguments, sorted by pattern
+func parseRotationSLAs(raw map[string]interface{}) ([]RotationSLA, error) {
+	slas := make([]RotationSLA, 0, len(raw))
+	for pattern, value := range raw {
+		maxAgeStr, _ := value.(string)
+		maxAge, err := parseMaxAge(maxAgeStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maximum age '%v' for pattern '%s': %v", value, pattern, err)
+		}
+		pattern = strings.Trim(pattern, "/")
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern '%s': %v", pattern, err)
+		}
+		slas = append(slas, RotationSLA{Pattern: pattern, MaxAge: maxAgeStr, maxAge: maxAge})
+	}
+	sort.Slice(slas, func(i, j int) bool { return slas[i].Pattern < slas[j].Pattern })
+	return slas, nil
+}
+
+// parseMaxAge parses a number of days like '90d', weeks like '2w' or a Go duration like '720h'
+func parseMaxAge(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	unit := time.Duration(0)
+	switch {
+	case strings.HasSuffix(value, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(value, "w"):
+		unit = 7 * 24 * time.Hour
+	}
+
+	var maxAge time.Duration
+	if unit > 0 {
+		count, err := strconv.Atoi(strings.TrimSpace(value[:len(value)-1]))
+		if err != nil {
+			return 0, fmt.Errorf("expected a number of days or weeks")
+		}
+		maxAge = time.Duration(count) * unit
+	} else {
+		var err error
+		if maxAge, err = time.ParseDuration(value); err != nil {
+			return 0, fmt.Errorf("expected a duration like '90d' or '720h'")
+		}
+	}
+	if maxAge <= 0 {
+		return 0, fmt.Errorf("the maximum age must be positive")
+	}
+	return maxAge, nil
+}
+
+// strictestSLA returns the SLA with the shortest maximum age among those whose pattern matches the path
+func strictestSLA(slas []RotationSLA, secretPath string) (RotationSLA, bool) {
+	var strictest RotationSLA
+	found := false
+	for _, sla := range slas {
+		if matchSegments(strings.Split(sla.Pattern, "/"), strings.Split(secretPath, "/")) &&
+			(!found || sla.maxAge < strictest.maxAge) {
+			strictest, found = sla, true
+		}
+	}
+	return strictest, found
+}
+
+// patternCoversMount reports whether a pattern can match secrets of the mount, so that other mounts are not walked
+func patternCoversMount(pattern string, mountPath string) bool {
+	patternSegments := strings.Split(pattern, "/")
+	for i, segment := range strings.Split(mountPath, "/") {
+		if i >= len(patternSegments) {
+			return false
+		}
+		if patternSegments[i] == "**" {
+			return true
+		}
+		if matched, _ := path.Match(patternSegments[i], segment); !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// matchSegments matches path segments against pattern segments, where '**' matches any number of segments
+func matchSegments(pattern []string, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	matched, _ := path.Match(pattern[0], segments[0])
+	return matched && matchSegments(pattern[1:], segments[1:])
+}
+
+// currentVersionCreated returns when the current version of a KV v2 secret was written
+func currentVersionCreated(vault *api.Client, mountPath string, secretPath string) (time.Time, error) {
+	metadata, err := vault.Logical().Read(fmt.Sprintf("%s/metadata/%s", mountPath, secretPath))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read metadata: %v", err)
+	}
+	if metadata == nil || metadata.Data == nil {
+		return time.Time{}, fmt.Errorf("no metadata")
+	}
+
+	current := fmt.Sprint(metadata.Data["current_version"])
+	versions, _ := metadata.Data["versions"].(map[string]interface{})
+	version, _ := versions[current].(map[string]interface{})
+	created, _ := version["created_time"].(string)
+	if created == "" {
+		// Fall back to the last update of the secret when the current version is not listed
+		created, _ = metadata.Data["updated_time"].(string)
+	}
+	createdTime, err := time.Parse(time.RFC3339Nano, created)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid creation time '%s' of version %s", created, current)
+	}
+	return createdTime, nil
+}
@@ -0,0 +1,140 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRotationSLA(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	list := func(w http.ResponseWriter, keys ...string) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"keys": keys}})
+	}
+	// metadata serves a secret whose current version was written the given number of days and an hour ago
+	metadata := func(w http.ResponseWriter, days int) {
+		created := time.Now().Add(-time.Duration(days)*24*time.Hour - time.Hour).UTC().Format(time.RFC3339Nano)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+			"current_version": 2,
+			"updated_time":    time.Now().UTC().Format(time.RFC3339Nano),
+			"versions": map[string]interface{}{
+				"1": map[string]interface{}{"created_time": "2020-01-01T00:00:00Z"},
+				"2": map[string]interface{}{"created_time": created},
+			},
+		}})
+	}
+
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch strings.TrimSuffix(r.URL.Path, "/") {
+		case "/v1/sys/mounts":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"prod/":    map[string]interface{}{"type": "kv", "options": map[string]interface{}{"version": "2"}},
+				"dev/":     map[string]interface{}{"type": "kv", "options": map[string]interface{}{"version": "2"}},
+				"legacy/":  map[string]interface{}{"type": "kv", "options": map[string]interface{}{"version": "1"}},
+				"transit/": map[string]interface{}{"type": "transit"},
+			}})
+		case "/v1/prod/metadata":
+			list(w, "billing/", "web/")
+		case "/v1/prod/metadata/billing":
+			list(w, "db-creds", "api-key")
+		case "/v1/prod/metadata/web":
+			list(w, "db-creds")
+		case "/v1/prod/metadata/billing/db-creds":
+			metadata(w, 120)
+		case "/v1/prod/metadata/billing/api-key":
+			metadata(w, 40)
+		case "/v1/prod/metadata/web/db-creds":
+			metadata(w, 10)
+		case "/v1/dev/metadata":
+			t.Error("mounts that no pattern matches are not walked")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockVault.Close()
+
+	sessionID := "test-" + t.Name()
+	_, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	defer client.DeleteVaultClient(sessionID)
+	ctx := server.NewMCPServer("test", "1.0").WithContext(context.Background(), testSession{id: sessionID})
+
+	check := func(t *testing.T, args map[string]interface{}) *mcp.CallToolResult {
+		result, err := CheckRotationSLA(logger).Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("secrets older than the strictest matching SLA are reported", func(t *testing.T) {
+		result := check(t, map[string]interface{}{"slas": map[string]interface{}{
+			"prod/*/db-creds": "90d",
+			"prod/**":         "5w",
+			"legacy/**":       "30d",
+		}})
+		require.False(t, result.IsError, result.Content[0].(mcp.TextContent).Text)
+
+		var report RotationReport
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &report))
+		assert.Equal(t, 3, report.SecretsChecked)
+		assert.Equal(t, 1, report.Compliant)
+		require.Len(t, report.Violations, 2)
+
+		assert.Equal(t, "prod/billing/db-creds", report.Violations[0].Path)
+		assert.Equal(t, "prod/**", report.Violations[0].Pattern)
+		assert.Equal(t, 120, report.Violations[0].AgeDays)
+		assert.Equal(t, 85, report.Violations[0].OverdueDays)
+		assert.Equal(t, "prod/billing/api-key", report.Violations[1].Path)
+		assert.Equal(t, 5, report.Violations[1].OverdueDays)
+
+		assert.Equal(t, []string{"legacy: KV v1 mounts do not record when secrets were written"}, report.Skipped)
+	})
+
+	t.Run("invalid SLAs are rejected", func(t *testing.T) {
+		result := check(t, map[string]interface{}{"slas": map[string]interface{}{"prod/**": "soon"}})
+		assert.True(t, result.IsError)
+
+		result = check(t, map[string]interface{}{"slas": map[string]interface{}{"prod/[": "90d"}})
+		assert.True(t, result.IsError)
+
+		result = check(t, map[string]interface{}{})
+		assert.True(t, result.IsError)
+	})
+
+	t.Run("only KV mounts can be checked", func(t *testing.T) {
+		result := check(t, map[string]interface{}{"slas": map[string]interface{}{"**": "90d"}, "mount": "transit"})
+		assert.True(t, result.IsError)
+	})
+}
+
+func TestParseMaxAge(t *testing.T) {
+	for value, expected := range map[string]time.Duration{
+		"90d":  90 * 24 * time.Hour,
+		"2w":   14 * 24 * time.Hour,
+		"720h": 720 * time.Hour,
+	} {
+		maxAge, err := parseMaxAge(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, expected, maxAge, value)
+	}
+	for _, value := range []string{"", "0d", "-1h", "d", "ninety days"} {
+		_, err := parseMaxAge(value)
+		assert.Error(t, err, value)
+	}
+}
@@ -0,0 +1,330 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// Statuses of the checks of an upgrade readiness report
+const (
+	UpgradeCheckPass    = "pass"
+	UpgradeCheckWarn    = "warn"
+	UpgradeCheckFail    = "fail"
+	UpgradeCheckSkipped = "skipped"
+)
+
+// UpgradeCheck is an item of the pre-flight checklist of an upgrade
+type UpgradeCheck struct {
+	Name    string `json:"name"`             // Area of the check: version, storage, plugins, replication or autopilot
+	Status  string `json:"status"`           // pass, warn, fail, or skipped when the token cannot read what the check needs
+	Message string `json:"message"`          // What the check found
+	Action  string `json:"action,omitempty"` // What to do before or during the upgrade
+}
+
+type UpgradeReadiness struct {
+	CurrentVersion string          `json:"current_version,omitempty"` // Version of the Vault server, if it could be read
+	TargetVersion  string          `json:"target_version"`
+	Ready          bool            `json:"ready"`              // Whether no check failed
+	Checks         []*UpgradeCheck `json:"checks"`             // The checklist, in the order to work through it
+	Findings       []*Finding      `json:"findings,omitempty"` // Plugin deprecations found by the plugins check, sorted by severity
+}
+
+// CheckUpgradeReadiness creates a tool for checking the cluster against the prerequisites of an upgrade to a target version
+func CheckUpgradeReadiness(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("check_upgrade_readiness",
+			mcp.WithDescription("Check whether the Vault cluster is ready to be upgraded to a target version and return a pre-flight checklist: the version step, the storage backend and how to back it up, deprecated and external plugins, the replication state and the order to upgrade clusters in, and the autopilot health and failure tolerance of integrated storage. Checks that the token cannot run are reported as skipped."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithString("target_version",
+				mcp.Required(),
+				mcp.Description("The Vault version to upgrade to, such as '1.20.0'."),
+			),
+			schemas.Output("check_upgrade_readiness"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return checkUpgradeReadinessHandler(ctx, req, logger)
+		},
+	}
+}
+
+func checkUpgradeReadinessHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling check_upgrade_readiness request")
+
+	targetVersion := strings.TrimSpace(req.GetString("target_version", ""))
+	target, err := parseVaultVersion(targetVersion)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing or invalid 'target_version' parameter: %v", err)), nil
+	}
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	report := &UpgradeReadiness{
+		TargetVersion: targetVersion,
+		Checks:        []*UpgradeCheck{},
+	}
+
+	if status, err := vault.Sys().SealStatus(); err != nil || status == nil || status.Version == "" {
+		report.add(skippedCheck("version", "the version of the server", err))
+	} else {
+		report.CurrentVersion = status.Version
+		report.add(checkVersionStep(status.Version, target))
+	}
+
+	storageType := ""
+	if secret, err := vault.Logical().Read("sys/config/state/sanitized"); err != nil || secret == nil || secret.Data == nil {
+		report.add(skippedCheck("storage", "sys/config/state/sanitized", err))
+	} else {
+		storageType = AnalyzeSanitizedConfig(secret.Data).StorageType
+		report.add(checkStorageBackup(storageType))
+	}
+
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		report.add(skippedCheck("plugins", "sys/mounts", err))
+	} else if auths, err := vault.Sys().ListAuth(); err != nil {
+		report.add(skippedCheck("plugins", "sys/auth", err))
+	} else {
+		deprecations := AnalyzePluginDeprecations(mounts, auths)
+		report.Findings = deprecations.Findings
+		report.add(checkPlugins(deprecations))
+	}
+
+	if replication, err := ReadReplicationStatus(vault); err != nil {
+		report.add(skippedCheck("replication", "sys/replication/status", err))
+	} else {
+		report.add(checkReplication(replication))
+	}
+
+	if storageType == "" || storageType == "raft" {
+		state, err := vault.Sys().RaftAutopilotState()
+		switch {
+		case err != nil:
+			report.add(skippedCheck("autopilot", "sys/storage/raft/autopilot/state", err))
+		case state != nil:
+			config, _ := vault.Sys().RaftAutopilotConfiguration()
+			report.add(checkAutopilot(state, config))
+		}
+	}
+
+	report.Ready = true
+	for _, check := range report.Checks {
+		if check.Status == UpgradeCheckFail {
+			report.Ready = false
+		}
+	}
+
+	jsonData, err := json.Marshal(report)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal upgrade readiness to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"target_version": targetVersion,
+		"ready":          report.Ready,
+	}).Debug("Successfully checked upgrade readiness")
+	return mcp.NewToolResultStructured(report, string(jsonData)), nil
+}
+
+// add appends a check to the checklist
+func (r *UpgradeReadiness) add(check *UpgradeCheck) {
+	r.Checks = append(r.Checks, check)
+}
+
+// skippedCheck reports a check that could not run because what it needs could not be read
+func skippedCheck(name, source string, err error) *UpgradeCheck {
+	message := fmt.Sprintf("Unable to read %s", source)
+	if err != nil {
+		message = fmt.Sprintf("%s: %v", message, err)
+	}
+	return &UpgradeCheck{Name: name, Status: UpgradeCheckSkipped, Message: message}
+}
+
+// checkVersionStep compares the running version with the target version
+func checkVersionStep(current string, target [3]int) *UpgradeCheck {
+	check := &UpgradeCheck{Name: "version"}
+	running, err := parseVaultVersion(current)
+	if err != nil {
+		check.Status = UpgradeCheckSkipped
+		check.Message = fmt.Sprintf("Unable to parse the running version '%s': %v", current, err)
+		return check
+	}
+
+	targetVersion := fmt.Sprintf("%d.%d.%d", target[0], target[1], target[2])
+	switch {
+	case slices.Compare(target[:], running[:]) == 0:
+		check.Status = UpgradeCheckFail
+		check.Message = fmt.Sprintf("The cluster already runs Vault %s", current)
+	case slices.Compare(target[:], running[:]) < 0:
+		check.Status = UpgradeCheckFail
+		check.Message = fmt.Sprintf("Vault %s is older than the running version %s, and Vault does not support downgrades", targetVersion, current)
+		check.Action = "Restore a snapshot taken before the upgrade to go back to an older version"
+	case target[0] != running[0] || target[1]-running[1] > 1:
+		check.Status = UpgradeCheckWarn
+		check.Message = fmt.Sprintf("Upgrading from %s to %s skips minor releases", current, targetVersion)
+		check.Action = "Read the upgrade guides of every release in between, their changes to defaults and removals apply to this upgrade"
+	default:
+		check.Status = UpgradeCheckPass
+		check.Message = fmt.Sprintf("Upgrading from %s to %s", current, targetVersion)
+		check.Action = fmt.Sprintf("Read the upgrade guide of Vault %d.%d", target[0], target[1])
+	}
+	return check
+}
+
+// checkStorageBackup tells how to back up the storage backend before upgrading
+func checkStorageBackup(storageType string) *UpgradeCheck {
+	check := &UpgradeCheck{Name: "storage", Status: UpgradeCheckPass}
+	switch storageType {
+	case "raft":
+		check.Message = "The cluster uses integrated storage"
+		check.Action = "Take a snapshot with 'vault operator raft snapshot save' right before upgrading"
+	case "consul":
+		check.Message = "The cluster uses Consul storage"
+		check.Action = "Take a snapshot with 'consul snapshot save' right before upgrading"
+	case "":
+		check.Status = UpgradeCheckSkipped
+		check.Message = "The sanitized configuration does not name the storage backend"
+	default:
+		check.Status = UpgradeCheckWarn
+		check.Message = fmt.Sprintf("The cluster uses the community supported %s storage backend, which may change or be removed in new releases", storageType)
+		check.Action = "Back up the storage with the tools of the backend, and check the release notes of the target version for the backend"
+	}
+	return check
+}
+
+// checkPlugins turns a deprecation report into a check, failing when a plugin is removed or pending removal
+func checkPlugins(deprecations *DeprecationReport) *UpgradeCheck {
+	check := &UpgradeCheck{Name: "plugins"}
+	var blocking, external []string
+	for _, finding := range deprecations.Findings {
+		if finding.Severity == SeverityCritical || finding.Severity == SeverityHigh {
+			blocking = append(blocking, finding.Message)
+		}
+	}
+	for _, mount := range deprecations.Mounts {
+		if mount.External {
+			external = append(external, mount.Path)
+		}
+	}
+
+	switch {
+	case !deprecations.Ready:
+		check.Status = UpgradeCheckFail
+		check.Message = strings.Join(blocking, "; ")
+		check.Action = "Migrate the mounts to the replacement plugins listed in the findings, Vault refuses to mount plugins that are pending removal or removed"
+	case len(deprecations.Findings) > 0:
+		check.Status = UpgradeCheckWarn
+		check.Message = fmt.Sprintf("The plugins of the %d mounts have %d findings about deprecated, legacy or external plugins", len(deprecations.Mounts), len(deprecations.Findings))
+		check.Action = "Review the findings, deprecated plugins keep working but will be removed in a future release"
+	default:
+		check.Status = UpgradeCheckPass
+		check.Message = fmt.Sprintf("None of the %d mounts uses a deprecated or external plugin", len(deprecations.Mounts))
+	}
+	if len(external) > 0 && check.Status != UpgradeCheckFail {
+		check.Action = fmt.Sprintf("Check that the external plugins of %s support the target version. %s", strings.Join(external, ", "), check.Action)
+	}
+	return check
+}
+
+// checkReplication checks that replication is healthy and tells the order to upgrade the clusters in
+func checkReplication(status *ReplicationStatus) *UpgradeCheck {
+	check := &UpgradeCheck{Name: "replication", Status: UpgradeCheckPass}
+	drMode, _ := status.DR["mode"].(string)
+	perfMode, _ := status.Performance["mode"].(string)
+	if (drMode == "" || drMode == "disabled") && (perfMode == "" || perfMode == "disabled") {
+		check.Message = "Replication is not in use"
+		return check
+	}
+
+	var roles, unhealthy []string
+	for _, replication := range []struct {
+		name   string
+		status map[string]interface{}
+	}{{"disaster recovery", status.DR}, {"performance", status.Performance}} {
+		mode, _ := replication.status["mode"].(string)
+		if mode == "" || mode == "disabled" {
+			continue
+		}
+		roles = append(roles, fmt.Sprintf("%s %s", replication.name, mode))
+		if state, _ := replication.status["state"].(string); state != "" && state != "running" && state != "stream-wals" {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s replication is in state '%s'", replication.name, state))
+		}
+	}
+	if len(unhealthy) > 0 {
+		check.Status = UpgradeCheckFail
+		check.Message = strings.Join(unhealthy, "; ")
+		check.Action = "Wait until replication is back to 'stream-wals' on the secondaries before upgrading"
+		return check
+	}
+
+	check.Message = fmt.Sprintf("This cluster is a %s", strings.Join(roles, " and a "))
+	check.Action = "Upgrade the disaster recovery secondaries first, then the performance secondaries, and the primary last"
+	return check
+}
+
+// checkAutopilot checks that integrated storage is healthy and can lose a server during a rolling upgrade
+func checkAutopilot(state *api.AutopilotState, config *api.AutopilotConfig) *UpgradeCheck {
+	check := &UpgradeCheck{Name: "autopilot", Status: UpgradeCheckPass}
+	check.Message = fmt.Sprintf("Autopilot reports %d voters and a failure tolerance of %d", len(state.Voters), state.FailureTolerance)
+	check.Action = "Upgrade the standby servers one at a time, waiting for autopilot to report them healthy, then step down the leader and upgrade it last"
+	if config != nil && !config.DisableUpgradeMigration && state.Upgrade != nil {
+		check.Action = "Autopilot upgrade migrations are enabled, join servers running the target version and autopilot promotes them and demotes the old servers"
+	}
+
+	switch {
+	case !state.Healthy:
+		check.Status = UpgradeCheckFail
+		check.Message = "Autopilot reports that not every server of the cluster is healthy"
+		check.Action = "Find the unhealthy servers with 'vault operator raft autopilot state' and repair them before upgrading"
+	case state.FailureTolerance < 1:
+		check.Status = UpgradeCheckWarn
+		check.Message = fmt.Sprintf("Autopilot reports a failure tolerance of %d, the cluster loses quorum when a server restarts", state.FailureTolerance)
+		check.Action = "Add voters so that the cluster keeps quorum while each server is restarted for the upgrade"
+	}
+	return check
+}
+
+// parseVaultVersion parses a version such as '1.19.3', 'v1.19.3' or '1.19.3+ent' into its major, minor and patch numbers
+func parseVaultVersion(version string) ([3]int, error) {
+	var parsed [3]int
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	version, _, _ = strings.Cut(version, "+")
+	version, _, _ = strings.Cut(version, "-")
+
+	parts := strings.Split(version, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return parsed, fmt.Errorf("expected a version such as '1.20.0', got '%s'", version)
+	}
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return parsed, fmt.Errorf("expected a version such as '1.20.0', got '%s'", version)
+		}
+		parsed[i] = number
+	}
+	return parsed, nil
+}
@@ -0,0 +1,135 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckUpgradeReadiness(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		data := func(data map[string]interface{}) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+		}
+		switch r.URL.Path {
+		case "/v1/sys/seal-status":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"type": "shamir", "version": "1.19.3"})
+		case "/v1/sys/config/state/sanitized":
+			data(map[string]interface{}{"storage": map[string]interface{}{"type": "raft"}})
+		case "/v1/sys/mounts":
+			data(map[string]interface{}{
+				"secret/": map[string]interface{}{"type": "kv", "deprecation_status": "supported"},
+				"ad/":     map[string]interface{}{"type": "ad", "deprecation_status": "pending-removal"},
+			})
+		case "/v1/sys/auth":
+			data(map[string]interface{}{"token/": map[string]interface{}{"type": "token"}})
+		case "/v1/sys/replication/status":
+			data(map[string]interface{}{
+				"dr":          map[string]interface{}{"mode": "primary", "state": "running"},
+				"performance": map[string]interface{}{"mode": "disabled"},
+			})
+		case "/v1/sys/storage/raft/autopilot/state":
+			data(map[string]interface{}{"healthy": true, "failure_tolerance": 0, "voters": []string{"node1"}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockVault.Close()
+
+	sessionID := "test-" + t.Name()
+	_, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	defer client.DeleteVaultClient(sessionID)
+	ctx := server.NewMCPServer("test", "1.0").WithContext(context.Background(), testSession{id: sessionID})
+
+	call := func(target string) UpgradeReadiness {
+		result, err := CheckUpgradeReadiness(logger).Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"target_version": target}}})
+		require.NoError(t, err)
+		require.False(t, result.IsError, result.Content)
+		var report UpgradeReadiness
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &report))
+		return report
+	}
+
+	report := call("1.20.0")
+	assert.Equal(t, "1.19.3", report.CurrentVersion)
+	assert.False(t, report.Ready, "a plugin pending removal blocks the upgrade")
+	statuses := map[string]string{}
+	for _, check := range report.Checks {
+		statuses[check.Name] = check.Status
+	}
+	assert.Equal(t, map[string]string{
+		"version":     UpgradeCheckPass,
+		"storage":     UpgradeCheckPass,
+		"plugins":     UpgradeCheckFail,
+		"replication": UpgradeCheckPass,
+		"autopilot":   UpgradeCheckWarn,
+	}, statuses)
+	assert.Contains(t, report.Checks[3].Message, "disaster recovery primary")
+	require.NotEmpty(t, report.Findings)
+	assert.Contains(t, report.Findings[0].Message, "'ad'")
+
+	report = call("1.18.0")
+	assert.Equal(t, UpgradeCheckFail, report.Checks[0].Status)
+	assert.Contains(t, report.Checks[0].Message, "does not support downgrades")
+
+	result, err := CheckUpgradeReadiness(logger).Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"target_version": "latest"}}})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
+
+func TestCheckVersionStep(t *testing.T) {
+	tests := []struct {
+		current string
+		target  string
+		status  string
+	}{
+		{"1.19.3", "1.19.4", UpgradeCheckPass},
+		{"1.19.3+ent", "1.20.0", UpgradeCheckPass},
+		{"1.17.0", "1.20.0", UpgradeCheckWarn},
+		{"1.19.3", "2.0.0", UpgradeCheckWarn},
+		{"1.19.3", "v1.19.3", UpgradeCheckFail},
+		{"1.19.3", "1.19.2", UpgradeCheckFail},
+		{"dev", "1.20.0", UpgradeCheckSkipped},
+	}
+	for _, tt := range tests {
+		t.Run(tt.current+"->"+tt.target, func(t *testing.T) {
+			target, err := parseVaultVersion(tt.target)
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, checkVersionStep(tt.current, target).Status)
+		})
+	}
+}
+
+func TestCheckReplicationAndAutopilot(t *testing.T) {
+	check := checkReplication(&ReplicationStatus{
+		DR:          map[string]interface{}{"mode": "secondary", "state": "merkle-sync"},
+		Performance: map[string]interface{}{"mode": "disabled"},
+	})
+	assert.Equal(t, UpgradeCheckFail, check.Status)
+	assert.Contains(t, check.Message, "'merkle-sync'")
+
+	check = checkAutopilot(&api.AutopilotState{Healthy: false}, nil)
+	assert.Equal(t, UpgradeCheckFail, check.Status)
+
+	check = checkAutopilot(&api.AutopilotState{Healthy: true, FailureTolerance: 1, Upgrade: &api.AutopilotUpgrade{}}, &api.AutopilotConfig{})
+	assert.Equal(t, UpgradeCheckPass, check.Status)
+	assert.Contains(t, check.Action, "upgrade migrations")
+}
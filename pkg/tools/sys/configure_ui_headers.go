@@ -0,0 +1,114 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// ConfigureUIHeaders creates a tool for configuring the custom headers returned by the Vault UI
+func ConfigureUIHeaders(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("configure_ui_headers",
+			mcp.WithDescription("Set or remove a custom HTTP header that Vault returns with the responses of its web UI, such as 'Content-Security-Policy' or 'Strict-Transport-Security'."),
+			mcp.WithString("header",
+				mcp.Required(),
+				mcp.Description("The name of the header, for example 'Content-Security-Policy'."),
+			),
+			mcp.WithString("values",
+				mcp.DefaultString(""),
+				mcp.Description("A comma-separated list of values of the header. Leave empty to remove the header."),
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return configureUIHeadersHandler(ctx, req, logger)
+		},
+	}
+}
+
+func configureUIHeadersHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling configure_ui_headers request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	header, ok := args["header"].(string)
+	header = strings.TrimSpace(header)
+	if !ok || header == "" {
+		return mcp.NewToolResultError("Missing or invalid 'header' parameter"), nil
+	}
+	if !client.ValidHeaderName(header) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'header' parameter '%s', only the characters allowed in HTTP header names can be used", header)), nil
+	}
+
+	var values []string
+	if valuesStr, ok := args["values"].(string); ok {
+		for _, value := range strings.Split(valuesStr, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+
+	logger.WithFields(log.Fields{
+		"header": header,
+		"values": values,
+	}).Debug("Configuring UI header")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	fullPath := "sys/config/ui/headers/" + header
+
+	// Summarize the current values for the change record, a header that cannot be read is treated as unset
+	var previous map[string]any
+	if current, err := vault.Logical().Read(fullPath); err == nil && current != nil && current.Data["values"] != nil {
+		previous = map[string]any{"values": current.Data["values"]}
+	}
+	change := client.ChangeRecord{
+		ResourceType: client.ResourceUIHeader,
+		Path:         fullPath,
+		Operation:    client.OperationUpdate,
+		Previous:     previous,
+	}
+
+	if len(values) == 0 {
+		if _, err := vault.Logical().Delete(fullPath); err != nil {
+			logger.WithError(err).WithField("header", header).Error("Failed to remove UI header")
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to remove UI header '%s': %v", header, err)), nil
+		}
+
+		logger.WithField("header", header).Info("Successfully removed UI header")
+		change.Operation = client.OperationDelete
+		change.Message = fmt.Sprintf("Successfully removed UI header '%s'", header)
+		return client.NewChangeResult(ctx, req, change), nil
+	}
+
+	if _, err := vault.Logical().Write(fullPath, map[string]interface{}{"values": values}); err != nil {
+		logger.WithError(err).WithField("header", header).Error("Failed to configure UI header")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to configure UI header '%s': %v", header, err)), nil
+	}
+
+	logger.WithField("header", header).Info("Successfully configured UI header")
+	if previous == nil {
+		change.Operation = client.OperationCreate
+	}
+	change.New = map[string]any{"values": values}
+	change.Message = fmt.Sprintf("Successfully set UI header '%s' to '%s'", header, strings.Join(values, ", "))
+	return client.NewChangeResult(ctx, req, change), nil
+}
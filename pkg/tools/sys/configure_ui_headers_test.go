@@ -0,0 +1,79 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigureUIHeaders(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	var written map[string]interface{}
+	var deleted []string
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/v1/sys/config/ui/headers/X-Existing" && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"values": []string{"old"}}})
+		case r.Method == http.MethodPut || r.Method == http.MethodPost:
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&written))
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockVault.Close()
+
+	sessionID := "test-" + t.Name()
+	_, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	defer client.DeleteVaultClient(sessionID)
+	ctx := server.NewMCPServer("test", "1.0").WithContext(context.Background(), testSession{id: sessionID})
+
+	call := func(args map[string]interface{}) *mcp.CallToolResult {
+		result, err := ConfigureUIHeaders(logger).Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		return result
+	}
+
+	result := call(map[string]interface{}{"header": "Content-Security-Policy", "values": "default-src 'self', frame-ancestors 'none'"})
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, []interface{}{"default-src 'self'", "frame-ancestors 'none'"}, written["values"])
+
+	result = call(map[string]interface{}{"header": "X-Existing", "values": ""})
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, []string{"/v1/sys/config/ui/headers/X-Existing"}, deleted)
+
+	for name, header := range map[string]string{
+		"empty":          " ",
+		"path traversal": "../../sys/policy/admin",
+		"query string":   "X-Header?list=true",
+		"space":          "X Header",
+		"newline":        "X-Header\nX-Injected",
+		"non ascii":      "X-Hëader",
+	} {
+		t.Run(name, func(t *testing.T) {
+			written = nil
+			result := call(map[string]interface{}{"header": header, "values": "value"})
+			assert.True(t, result.IsError)
+			assert.Nil(t, written, "an invalid header is never written")
+		})
+	}
+}
@@ -141,5 +141,16 @@ func createMountHandler(ctx context.Context, req mcp.CallToolRequest, logger *lo
 		"path": path,
 	}).Info("Successfully created mount")
 
-	return mcp.NewToolResultText(successMsg), nil
+	return client.NewChangeResult(ctx, req, client.ChangeRecord{
+		ResourceType: client.ResourceMount,
+		Mount:        path,
+		Path:         "sys/mounts/" + path,
+		Operation:    client.OperationCreate,
+		New: map[string]any{
+			"type":        mountInput.Type,
+			"description": description,
+			"options":     mountInput.Options,
+		},
+		Message: successMsg,
+	}), nil
 }
@@ -5,7 +5,12 @@ package sys
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
+
 	"github.com/hashicorp/vault-mcp-server/pkg/client"
 	"github.com/hashicorp/vault-mcp-server/pkg/utils"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -23,11 +28,19 @@ func DeleteMount(logger *log.Logger) server.ServerTool {
 					IdempotentHint:  utils.ToBoolPtr(true),
 				},
 			),
-			mcp.WithDescription("Delete a mounted secret engine in Vault. Use with extreme caution as this will remove all data under the mount path!"),
+			mcp.WithDescription("Delete a mounted secret engine in Vault. Use with extreme caution as this will remove all data under the mount path! Mounts that are not empty are only deleted with 'force' set to true, after the user confirmed the deletion when the client supports it. Without 'force' a summary of the contents of the mount is returned. Set 'backup' to copy the secrets of a KV mount into a timestamped backup mount before it is deleted."),
 			mcp.WithString("path",
 				mcp.Required(),
 				mcp.Description("The path where of mount to be deleted. Examples would be 'secrets' or 'kv'."),
 			),
+			mcp.WithBoolean("force",
+				mcp.DefaultBool(false),
+				mcp.Description("Delete the mount even if it still contains data. Only set this after reviewing the contents summary with the user."),
+			),
+			mcp.WithBoolean("backup",
+				mcp.DefaultBool(false),
+				mcp.Description("Copy the secrets of a KV mount into a new mount named '<path>-backup-<timestamp>' before deleting it, so that the data can be restored. Only supported for KV mounts."),
+			),
 		),
 		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			return deleteMountHandler(ctx, req, logger)
@@ -39,20 +52,20 @@ func deleteMountHandler(ctx context.Context, req mcp.CallToolRequest, logger *lo
 	logger.Debug("Handling delete_mount request")
 
 	// Extract parameters
-	var path string
-
-	if req.Params.Arguments != nil {
-		if args, ok := req.Params.Arguments.(map[string]interface{}); ok {
-			if path, ok = args["path"].(string); !ok || path == "" {
-				return mcp.NewToolResultError("Missing or invalid 'path' parameter"), nil
-			}
-		} else {
-			return mcp.NewToolResultError("Invalid arguments format"), nil
-		}
-	} else {
-		return mcp.NewToolResultError("Missing arguments"), nil
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
 	}
 
+	path, ok := args["path"].(string)
+	path = strings.Trim(path, "/")
+	if !ok || path == "" {
+		return mcp.NewToolResultError("Missing or invalid 'path' parameter"), nil
+	}
+
+	force, _ := args["force"].(bool)
+	backup, _ := args["backup"].(bool)
+
 	logger.WithField("path", path).Debug("Deleting mount")
 
 	// Get Vault client from context
@@ -62,6 +75,60 @@ func deleteMountHandler(ctx context.Context, req mcp.CallToolRequest, logger *lo
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
 	}
 
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list mounts: %v", err)), nil
+	}
+
+	mount, ok := mounts[path+"/"]
+	if !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("mount path '%s' does not exist", path)), nil
+	}
+
+	// Summarize what would be lost before anything is deleted
+	summary := summarizeMount(vault, path, mount)
+	if !summary.Empty {
+		jsonData, err := json.Marshal(summary)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+		}
+
+		if !force {
+			logger.WithField("path", path).Info("Refusing to delete mount that is not empty without force")
+			return mcp.NewToolResultError(fmt.Sprintf("mount path '%s' is not empty, nothing was deleted. Review the contents with the user and call 'delete_mount' again with 'force' set to true to delete it: %s", path, jsonData)), nil
+		}
+
+		consequence := "This cannot be undone."
+		if backup {
+			consequence = "The secrets are copied to a backup mount first."
+		}
+		confirmed, err := utils.RequestConfirmation(ctx, fmt.Sprintf("Delete the '%s' mount at '%s' and all of its data? %s Contents: %s", mount.Type, path, consequence, jsonData))
+		if errors.Is(err, utils.ErrConfirmationUnavailable) {
+			logger.WithField("path", path).Debug("Client does not support elicitation, relying on force")
+		} else if err != nil {
+			logger.WithError(err).WithField("path", path).Error("Failed to request confirmation")
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to request confirmation to delete mount at path '%s': %v", path, err)), nil
+		} else if !confirmed {
+			logger.WithField("path", path).Info("Deletion of mount was not confirmed")
+			return mcp.NewToolResultError(fmt.Sprintf("Deletion of mount at path '%s' was not confirmed by the user, nothing was deleted", path)), nil
+		}
+	}
+
+	// Copy the data of the mount so that the deletion can be undone
+	var snapshot *MountSnapshot
+	if backup {
+		snapshot, err = snapshotMountTo(vault, path, mount, backupMountPath(path, time.Now()))
+		if err != nil {
+			logger.WithError(err).WithField("path", path).Error("Failed to back up mount")
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to back up mount at path '%s', nothing was deleted: %v", path, err)), nil
+		}
+		logger.WithFields(log.Fields{
+			"path":    path,
+			"backup":  snapshot.Target,
+			"secrets": snapshot.Secrets,
+		}).Info("Backed up mount before deleting it")
+	}
+
 	// Delete the mount
 	err = vault.Sys().Unmount(path)
 	if err != nil {
@@ -70,7 +137,39 @@ func deleteMountHandler(ctx context.Context, req mcp.CallToolRequest, logger *lo
 	}
 
 	successMsg := fmt.Sprintf("Successfully deleted mount at path '%s'", path)
+	if snapshot != nil {
+		successMsg += fmt.Sprintf(". %d secrets were backed up to the mount at path '%s', move it back to '%s' (sys/remount) to restore them", snapshot.Secrets, snapshot.Target, path)
+		if snapshot.CurrentVersionOnly {
+			successMsg += ". Only the current version of each secret was backed up"
+		}
+		if len(snapshot.Skipped) > 0 {
+			successMsg += fmt.Sprintf(". %d secrets whose current version is deleted were not backed up: %s", len(snapshot.Skipped), strings.Join(snapshot.Skipped, ", "))
+		}
+	}
 	logger.WithField("path", path).Info("Successfully deleted mount")
 
-	return mcp.NewToolResultText(successMsg), nil
+	change := client.ChangeRecord{
+		ResourceType: client.ResourceMount,
+		Mount:        path,
+		Path:         "sys/mounts/" + path,
+		Operation:    client.OperationDelete,
+		Previous: map[string]any{
+			"type":        mount.Type,
+			"description": mount.Description,
+			"options":     mount.Options,
+			"empty":       summary.Empty,
+		},
+		Message: successMsg,
+	}
+	if snapshot != nil {
+		change.New = map[string]any{
+			"backup_mount":         snapshot.Target,
+			"backed_up_secrets":    snapshot.Secrets,
+			"current_version_only": snapshot.CurrentVersionOnly,
+		}
+		if len(snapshot.Skipped) > 0 {
+			change.New["skipped_secrets"] = snapshot.Skipped
+		}
+	}
+	return client.NewChangeResult(ctx, req, change), nil
 }
@@ -0,0 +1,182 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSession struct {
+	id string
+}
+
+func (s testSession) SessionID() string                                   { return s.id }
+func (s testSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s testSession) Initialize()                                         {}
+func (s testSession) Initialized() bool                                   { return true }
+
+// newMountsVault returns a mock Vault with a KV v2 mount at 'secret' holding the given secrets
+func newMountsVault(t *testing.T, secrets []string, unmounted *bool, backedUp *[]string) (context.Context, func()) {
+	t.Helper()
+
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/sys/mounts":
+			mounts := map[string]interface{}{
+				"secret/": map[string]interface{}{"type": "kv", "options": map[string]interface{}{"version": "2"}},
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": mounts})
+		case strings.TrimSuffix(r.URL.Path, "/") == "/v1/secret/metadata":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"keys": secrets}})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/secret/data/deleted":
+			// The current version of a deleted secret has no data
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"data":     nil,
+				"metadata": map[string]interface{}{"version": 3, "deletion_time": "2025-05-20T08:00:00Z"},
+			}})
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/secret/data/"):
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"data": map[string]interface{}{"password": "s3cr3t"},
+			}})
+		case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/v1/sys/mounts/secret-backup-"):
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/v1/sys/mounts/secret-backup-"):
+			*backedUp = append(*backedUp, "removed "+r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		case strings.HasPrefix(r.URL.Path, "/v1/secret-backup-") && strings.HasSuffix(r.URL.Path, "/data/readonly"):
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"errors": []string{"permission denied"}})
+		case strings.HasPrefix(r.URL.Path, "/v1/secret-backup-"):
+			*backedUp = append(*backedUp, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/sys/mounts/secret":
+			*unmounted = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	sessionID := "test-" + t.Name()
+	_, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+
+	ctx := server.NewMCPServer("test", "1.0").WithContext(context.Background(), testSession{id: sessionID})
+
+	return ctx, func() {
+		mockVault.Close()
+		client.DeleteVaultClient(sessionID)
+	}
+}
+
+func TestDeleteMount(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	tool := DeleteMount(logger)
+
+	t.Run("empty mount is deleted without force", func(t *testing.T) {
+		unmounted := false
+		ctx, cleanup := newMountsVault(t, []string{}, &unmounted, &[]string{})
+		defer cleanup()
+
+		result, err := tool.Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{"path": "secret"},
+		}})
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+		assert.True(t, unmounted)
+	})
+
+	t.Run("mount with secrets is not deleted without force", func(t *testing.T) {
+		unmounted := false
+		ctx, cleanup := newMountsVault(t, []string{"app", "db"}, &unmounted, &[]string{})
+		defer cleanup()
+
+		result, err := tool.Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{"path": "secret/"},
+		}})
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, result.Content[0].(mcp.TextContent).Text, `"secrets":2`)
+		assert.False(t, unmounted)
+	})
+
+	t.Run("mount with secrets is deleted with force", func(t *testing.T) {
+		unmounted := false
+		ctx, cleanup := newMountsVault(t, []string{"app", "db"}, &unmounted, &[]string{})
+		defer cleanup()
+
+		result, err := tool.Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{"path": "secret", "force": true},
+		}})
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+		assert.True(t, unmounted)
+	})
+
+	t.Run("mount is backed up before it is deleted", func(t *testing.T) {
+		unmounted := false
+		var backedUp []string
+		ctx, cleanup := newMountsVault(t, []string{"app", "db"}, &unmounted, &backedUp)
+		defer cleanup()
+
+		result, err := tool.Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{"path": "secret", "force": true, "backup": true},
+		}})
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+		assert.True(t, unmounted)
+		assert.Len(t, backedUp, 2)
+		assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "2 secrets were backed up")
+	})
+
+	t.Run("secrets whose current version is deleted are reported", func(t *testing.T) {
+		unmounted := false
+		var backedUp []string
+		ctx, cleanup := newMountsVault(t, []string{"app", "deleted"}, &unmounted, &backedUp)
+		defer cleanup()
+
+		result, err := tool.Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{"path": "secret", "force": true, "backup": true},
+		}})
+		require.NoError(t, err)
+		require.False(t, result.IsError, result.Content)
+		assert.Len(t, backedUp, 1)
+		text := result.Content[0].(mcp.TextContent).Text
+		assert.Contains(t, text, "Only the current version of each secret was backed up")
+		assert.Contains(t, text, "1 secrets whose current version is deleted were not backed up: deleted")
+	})
+
+	t.Run("partial backup mount is removed when the copy fails", func(t *testing.T) {
+		unmounted := false
+		var backedUp []string
+		ctx, cleanup := newMountsVault(t, []string{"app", "readonly"}, &unmounted, &backedUp)
+		defer cleanup()
+
+		result, err := tool.Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{"path": "secret", "force": true, "backup": true},
+		}})
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "nothing was deleted")
+		assert.False(t, unmounted)
+		require.Len(t, backedUp, 2)
+		assert.True(t, strings.HasPrefix(backedUp[1], "removed /v1/sys/mounts/secret-backup-"), backedUp)
+	})
+}
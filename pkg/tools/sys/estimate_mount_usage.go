@@ -0,0 +1,201 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// MountUsage is an estimate of the storage entries held by a mount
+type MountUsage struct {
+	Path         string `json:"path"`
+	Type         string `json:"type"`
+	Secrets      int    `json:"secrets,omitempty"`      // Number of KV secrets
+	Versions     int    `json:"versions,omitempty"`     // Number of KV v2 versions kept, including deleted versions
+	Certificates int    `json:"certificates,omitempty"` // Number of stored PKI certificates
+	Revoked      int    `json:"revoked,omitempty"`      // Number of revoked PKI certificates
+	Entries      int    `json:"entries"`                // Estimated number of storage entries
+	Truncated    bool   `json:"truncated,omitempty"`    // Counting stopped at the limit, the mount holds at least this many entries
+	Note         string `json:"note,omitempty"`
+}
+
+type UsageReport struct {
+	TotalEntries int           `json:"total_entries"` // Estimated storage entries of the inspected mounts
+	Mounts       []*MountUsage `json:"mounts"`        // Inspected mounts, biggest consumers first
+	Skipped      []string      `json:"skipped,omitempty"`
+}
+
+// EstimateMountUsage creates a tool for estimating the storage used by KV and PKI mounts
+func EstimateMountUsage(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("estimate_mount_usage",
+			mcp.WithDescription("Estimate how many storage entries each KV and PKI mount holds by walking KV metadata (secrets and their versions) and PKI certificate lists, and report the biggest consumers first. Use it to find out what is filling up Vault storage. Walking large mounts takes a while, the number of secrets counted per mount is limited."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithString("mount",
+				mcp.DefaultString(""),
+				mcp.Description("Optional mount to inspect. Every KV and PKI mount is inspected by default."),
+			),
+			mcp.WithBoolean("include_versions",
+				mcp.DefaultBool(true),
+				mcp.Description("Read the metadata of every KV v2 secret to count its versions. This needs one request per secret."),
+			),
+			mcp.WithNumber("limit",
+				mcp.DefaultNumber(MaxSummarizedSecrets),
+				mcp.Description("The maximum number of secrets or certificates counted per mount."),
+			),
+			mcp.WithNumber("top",
+				mcp.DefaultNumber(10),
+				mcp.Description("The number of mounts to report, biggest first. Use 0 to report every mount."),
+			),
+			schemas.Output("estimate_mount_usage"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return estimateMountUsageHandler(ctx, req, logger)
+		},
+	}
+}
+
+func estimateMountUsageHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling estimate_mount_usage request")
+
+	mountFilter := strings.TrimSuffix(req.GetString("mount", ""), "/")
+	includeVersions := req.GetBool("include_versions", true)
+	limit := req.GetInt("limit", MaxSummarizedSecrets)
+	top := req.GetInt("top", 10)
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		logger.WithError(err).Error("Failed to list mounts")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list mounts: %v", err)), nil
+	}
+
+	if mountFilter != "" {
+		mount, ok := mounts[mountFilter+"/"]
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("Mount '%s' does not exist", mountFilter)), nil
+		}
+		mounts = map[string]*api.MountOutput{mountFilter + "/": mount}
+	}
+
+	report := &UsageReport{Mounts: []*MountUsage{}}
+	for path, mount := range mounts {
+		path = strings.TrimSuffix(path, "/")
+		switch mount.Type {
+		case "kv", "generic", "pki":
+		default:
+			if mountFilter != "" {
+				return mcp.NewToolResultError(fmt.Sprintf("The usage of '%s' mounts cannot be estimated, only KV and PKI mounts are supported", mount.Type)), nil
+			}
+			report.Skipped = append(report.Skipped, path)
+			continue
+		}
+
+		usage := estimateUsage(vault, path, mount, includeVersions, limit)
+		report.TotalEntries += usage.Entries
+		report.Mounts = append(report.Mounts, usage)
+	}
+
+	sort.Slice(report.Mounts, func(i, j int) bool {
+		if report.Mounts[i].Entries != report.Mounts[j].Entries {
+			return report.Mounts[i].Entries > report.Mounts[j].Entries
+		}
+		return report.Mounts[i].Path < report.Mounts[j].Path
+	})
+	sort.Strings(report.Skipped)
+	if top > 0 && len(report.Mounts) > top {
+		report.Mounts = report.Mounts[:top]
+	}
+
+	jsonData, err := json.Marshal(report)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal usage report to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithField("total_entries", report.TotalEntries).Debug("Successfully estimated mount usage")
+	return mcp.NewToolResultStructured(report, string(jsonData)), nil
+}
+
+// estimateUsage counts the storage entries of a KV or PKI mount, stopping at limit secrets or certificates
+func estimateUsage(vault *api.Client, path string, mount *api.MountOutput, includeVersions bool, limit int) *MountUsage {
+	usage := &MountUsage{Path: path, Type: mount.Type}
+
+	if mount.Type == "pki" {
+		certs, err := listKeys(vault, path+"/certs")
+		if err != nil {
+			usage.Note = fmt.Sprintf("failed to list certificates: %v", err)
+			return usage
+		}
+		revoked, err := listKeys(vault, path+"/certs/revoked")
+		if err != nil {
+			usage.Note = fmt.Sprintf("failed to list revoked certificates: %v", err)
+			return usage
+		}
+		usage.Certificates, usage.Revoked = len(certs), len(revoked)
+		if limit > 0 && usage.Certificates > limit {
+			usage.Certificates, usage.Truncated = limit, true
+		}
+		// Every certificate is stored once, revoked certificates also have a revocation entry
+		usage.Entries = usage.Certificates + usage.Revoked
+		return usage
+	}
+
+	v2 := mount.Options["version"] == "2"
+	secrets, truncated, err := listKVSecrets(vault, path, v2, limit)
+	if err != nil {
+		usage.Note = fmt.Sprintf("failed to list secrets: %v", err)
+		return usage
+	}
+	usage.Secrets, usage.Truncated = len(secrets), truncated
+	usage.Entries = usage.Secrets
+	if !v2 {
+		return usage
+	}
+
+	if !includeVersions {
+		usage.Note = "versions were not counted, each secret is estimated as a single version"
+		usage.Entries = usage.Secrets * 2
+		return usage
+	}
+
+	// A KV v2 secret has a metadata entry and one entry per version
+	for _, secretPath := range secrets {
+		metadata, err := vault.Logical().Read(fmt.Sprintf("%s/metadata/%s", path, secretPath))
+		if err != nil {
+			usage.Note = fmt.Sprintf("failed to read metadata of '%s': %v", secretPath, err)
+			continue
+		}
+		if metadata == nil || metadata.Data == nil {
+			continue
+		}
+		versions, _ := metadata.Data["versions"].(map[string]interface{})
+		usage.Versions += len(versions)
+	}
+	usage.Entries = usage.Secrets + usage.Versions
+
+	return usage
+}
@@ -0,0 +1,84 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateMountUsage(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	list := func(w http.ResponseWriter, keys ...string) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"keys": keys}})
+	}
+
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch strings.TrimSuffix(r.URL.Path, "/") {
+		case "/v1/sys/mounts":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"secret/":  map[string]interface{}{"type": "kv", "options": map[string]interface{}{"version": "2"}},
+				"legacy/":  map[string]interface{}{"type": "kv", "options": map[string]interface{}{"version": "1"}},
+				"pki/":     map[string]interface{}{"type": "pki"},
+				"transit/": map[string]interface{}{"type": "transit"},
+			}})
+		case "/v1/secret/metadata":
+			list(w, "app", "team/")
+		case "/v1/secret/metadata/team":
+			list(w, "db")
+		case "/v1/secret/metadata/app", "/v1/secret/metadata/team/db":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"versions": map[string]interface{}{"1": map[string]interface{}{}, "2": map[string]interface{}{}, "3": map[string]interface{}{}},
+			}})
+		case "/v1/legacy":
+			list(w, "a")
+		case "/v1/pki/certs":
+			list(w, "01", "02", "03", "04")
+		case "/v1/pki/certs/revoked":
+			list(w, "01")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockVault.Close()
+
+	sessionID := "test-" + t.Name()
+	_, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	defer client.DeleteVaultClient(sessionID)
+	ctx := server.NewMCPServer("test", "1.0").WithContext(context.Background(), testSession{id: sessionID})
+
+	result, err := EstimateMountUsage(logger).Handler(ctx, mcp.CallToolRequest{})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var report UsageReport
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &report))
+	require.Len(t, report.Mounts, 3)
+
+	assert.Equal(t, "secret", report.Mounts[0].Path)
+	assert.Equal(t, 2, report.Mounts[0].Secrets)
+	assert.Equal(t, 6, report.Mounts[0].Versions)
+	assert.Equal(t, 8, report.Mounts[0].Entries)
+	assert.Equal(t, "pki", report.Mounts[1].Path)
+	assert.Equal(t, 5, report.Mounts[1].Entries)
+	assert.Equal(t, "legacy", report.Mounts[2].Path)
+	assert.Equal(t, 1, report.Mounts[2].Entries)
+	assert.Equal(t, 14, report.TotalEntries)
+	assert.Equal(t, []string{"transit"}, report.Skipped)
+}
@@ -0,0 +1,96 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// RateLimitQuota describes a rate limit quota of the Vault server
+type RateLimitQuota struct {
+	Name          string  `json:"name"`
+	Path          string  `json:"path"`           // Namespace or mount the quota applies to, empty for the whole server
+	Role          string  `json:"role,omitempty"` // Login role the quota applies to, if any
+	Rate          float64 `json:"rate"`           // Number of requests allowed per interval
+	Interval      int     `json:"interval"`       // Interval in seconds
+	BlockInterval int     `json:"block_interval"` // Seconds a client is blocked after exceeding the rate, 0 when not blocking
+}
+
+// GetRateLimitQuotas creates a tool for listing the rate limit quotas of the Vault server
+func GetRateLimitQuotas(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("get_rate_limit_quotas",
+			mcp.WithDescription("Get the rate limit quotas of the Vault server with the path, rate, interval and block interval of each."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return getRateLimitQuotasHandler(ctx, req, logger)
+		},
+	}
+}
+
+func getRateLimitQuotasHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling get_rate_limit_quotas request")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	names, err := listKeys(vault, "sys/quotas/rate-limit")
+	if err != nil {
+		logger.WithError(err).Error("Failed to list rate limit quotas")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list rate limit quotas: %v", err)), nil
+	}
+
+	quotas := []RateLimitQuota{}
+	for _, name := range names {
+		secret, err := vault.Logical().Read("sys/quotas/rate-limit/" + name)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to read rate limit quota '%s': %v", name, err)), nil
+		}
+		if secret == nil || secret.Data == nil {
+			continue
+		}
+
+		quota := RateLimitQuota{Name: name}
+		quota.Path, _ = secret.Data["path"].(string)
+		quota.Role, _ = secret.Data["role"].(string)
+		if rate, ok := secret.Data["rate"].(json.Number); ok {
+			quota.Rate, _ = rate.Float64()
+		}
+		if interval, ok := secret.Data["interval"].(json.Number); ok {
+			seconds, _ := interval.Int64()
+			quota.Interval = int(seconds)
+		}
+		if blockInterval, ok := secret.Data["block_interval"].(json.Number); ok {
+			seconds, _ := blockInterval.Int64()
+			quota.BlockInterval = int(seconds)
+		}
+		quotas = append(quotas, quota)
+	}
+
+	jsonData, err := json.Marshal(quotas)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal rate limit quotas to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithField("quota_count", len(quotas)).Debug("Successfully listed rate limit quotas")
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
@@ -0,0 +1,63 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRateLimitQuotas(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		data := func(data map[string]interface{}) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+		}
+		switch r.URL.Path {
+		case "/v1/sys/quotas/rate-limit":
+			if r.URL.Query().Get("list") != "true" && r.Method != "LIST" {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			data(map[string]interface{}{"keys": []string{"global", "approle-login"}})
+		case "/v1/sys/quotas/rate-limit/global":
+			data(map[string]interface{}{"name": "global", "path": "", "rate": 500.5, "interval": 1, "block_interval": 0})
+		case "/v1/sys/quotas/rate-limit/approle-login":
+			data(map[string]interface{}{"name": "approle-login", "path": "auth/approle/", "role": "ci", "rate": 10, "interval": 60, "block_interval": 300})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockVault.Close()
+
+	sessionID := "test-" + t.Name()
+	_, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	defer client.DeleteVaultClient(sessionID)
+	ctx := server.NewMCPServer("test", "1.0").WithContext(context.Background(), testSession{id: sessionID})
+
+	result, err := GetRateLimitQuotas(logger).Handler(ctx, mcp.CallToolRequest{})
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var quotas []RateLimitQuota
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &quotas))
+	assert.Equal(t, []RateLimitQuota{
+		{Name: "global", Rate: 500.5, Interval: 1},
+		{Name: "approle-login", Path: "auth/approle/", Role: "ci", Rate: 10, Interval: 60, BlockInterval: 300},
+	}, quotas)
+}
@@ -0,0 +1,84 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// ReplicationStatus describes the state of disaster recovery and performance replication
+type ReplicationStatus struct {
+	DR          map[string]interface{} `json:"dr"`          // Disaster recovery replication status, mode is 'disabled' when not set up
+	Performance map[string]interface{} `json:"performance"` // Performance replication status, mode is 'disabled' when not set up
+}
+
+// GetReplicationStatus creates a tool for reading the replication status of the cluster
+func GetReplicationStatus(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("get_replication_status",
+			mcp.WithDescription("Get the disaster recovery and performance replication status of the Vault cluster, including its mode (primary, secondary or disabled), state and known secondaries. Replication requires Vault Enterprise."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			schemas.Output("get_replication_status"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return getReplicationStatusHandler(ctx, req, logger)
+		},
+	}
+}
+
+func getReplicationStatusHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling get_replication_status request")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	status, err := ReadReplicationStatus(vault)
+	if err != nil {
+		logger.WithError(err).Error("Failed to read replication status")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read replication status, replication requires Vault Enterprise: %v", err)), nil
+	}
+
+	jsonData, err := json.Marshal(status)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal replication status to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.Debug("Successfully read replication status")
+	return mcp.NewToolResultStructured(status, string(jsonData)), nil
+}
+
+// ReadReplicationStatus reads the disaster recovery and performance replication status of the cluster
+func ReadReplicationStatus(vault *api.Client) (*ReplicationStatus, error) {
+	secret, err := vault.Logical().Read("sys/replication/status")
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("replication status returned no data")
+	}
+
+	status := &ReplicationStatus{}
+	status.DR, _ = secret.Data["dr"].(map[string]interface{})
+	status.Performance, _ = secret.Data["performance"].(map[string]interface{})
+	return status, nil
+}
@@ -0,0 +1,60 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetReplicationStatus(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	enterprise := true
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/v1/sys/replication/status" || !enterprise {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+			"dr":          map[string]interface{}{"mode": "primary", "state": "running", "known_secondaries": []string{"dr-east"}},
+			"performance": map[string]interface{}{"mode": "disabled"},
+		}})
+	}))
+	defer mockVault.Close()
+
+	sessionID := "test-" + t.Name()
+	_, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	defer client.DeleteVaultClient(sessionID)
+	ctx := server.NewMCPServer("test", "1.0").WithContext(context.Background(), testSession{id: sessionID})
+
+	result, err := GetReplicationStatus(logger).Handler(ctx, mcp.CallToolRequest{})
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var status ReplicationStatus
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &status))
+	assert.Equal(t, "primary", status.DR["mode"])
+	assert.Equal(t, []interface{}{"dr-east"}, status.DR["known_secondaries"])
+	assert.Equal(t, "disabled", status.Performance["mode"])
+	assert.Equal(t, &status, result.StructuredContent)
+
+	enterprise = false
+	result, err = GetReplicationStatus(logger).Handler(ctx, mcp.CallToolRequest{})
+	require.NoError(t, err)
+	assert.True(t, result.IsError, "replication is not available outside Vault Enterprise")
+}
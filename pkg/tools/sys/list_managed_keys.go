@@ -0,0 +1,96 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// ManagedKeyTypes are the backends that managed keys can be stored in
+var ManagedKeyTypes = []string{"pkcs11", "awskms", "azurekeyvault", "gcpckms"}
+
+type ManagedKey struct {
+	Type string `json:"type"` // Backend of the key, such as pkcs11 or awskms
+	Name string `json:"name"` // Name of the managed key
+}
+
+type ManagedKeyList struct {
+	Keys     []*ManagedKey `json:"keys"`
+	Warnings []string      `json:"warnings,omitempty"` // Key types that could not be listed
+}
+
+// ListManagedKeys creates a tool for listing the managed keys of Vault
+func ListManagedKeys(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("list_managed_keys",
+			mcp.WithDescription("List the managed keys, which are keys kept in an HSM (PKCS#11) or a cloud KMS and used by secrets engines such as PKI without ever leaving the device. Managed keys require Vault Enterprise."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithString("type",
+				mcp.DefaultString(""),
+				mcp.Enum("", "pkcs11", "awskms", "azurekeyvault", "gcpckms"),
+				mcp.Description("Optional type of managed keys to list. Lists every type by default."),
+			),
+			schemas.Output("list_managed_keys"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return listManagedKeysHandler(ctx, req, logger)
+		},
+	}
+}
+
+func listManagedKeysHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling list_managed_keys request")
+
+	keyTypes := ManagedKeyTypes
+	if keyType := req.GetString("type", ""); keyType != "" {
+		keyTypes = []string{keyType}
+	}
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	result := &ManagedKeyList{
+		Keys: []*ManagedKey{},
+	}
+	for _, keyType := range keyTypes {
+		names, err := listKeys(vault, "sys/managed-keys/"+keyType)
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("unable to list %s managed keys: %v", keyType, err))
+			continue
+		}
+		for _, name := range names {
+			result.Keys = append(result.Keys, &ManagedKey{Type: keyType, Name: name})
+		}
+	}
+
+	if len(result.Keys) == 0 && len(result.Warnings) == len(keyTypes) {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list managed keys, managed keys require Vault Enterprise: %v", result.Warnings)), nil
+	}
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal managed keys to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithField("key_count", len(result.Keys)).Debug("Successfully listed managed keys")
+	return mcp.NewToolResultStructured(result, string(jsonData)), nil
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	log "github.com/sirupsen/logrus"
+	"sort"
+	"strconv"
 )
 
 type Mount struct {
@@ -28,10 +30,16 @@ func ListMounts(logger *log.Logger) server.ServerTool {
 		Tool: mcp.NewTool("list_mounts",
 			mcp.WithToolAnnotation(
 				mcp.ToolAnnotation{
+					ReadOnlyHint:   utils.ToBoolPtr(true),
 					IdempotentHint: utils.ToBoolPtr(true),
 				},
 			),
 			mcp.WithDescription("List the available mounted secrets engines on a Vault Server."),
+			mcp.WithString("format",
+				mcp.DefaultString("json"),
+				mcp.Enum("json", "markdown", "table"),
+				mcp.Description("Optional output format. Use 'markdown' or 'table' for a compact table that is easier to display in chat. Defaults to 'json'."),
+			),
 		),
 		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			return listMountHandler(ctx, req, logger)
@@ -42,6 +50,13 @@ func ListMounts(logger *log.Logger) server.ServerTool {
 func listMountHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
 	logger.Debug("Handling list_mounts request")
 
+	// Extract parameters
+	args, _ := req.Params.Arguments.(map[string]interface{})
+	format, err := utils.ExtractFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
 	// Get Vault client from context
 	vault, err := client.GetVaultClientFromContext(ctx, logger)
 	if err != nil {
@@ -68,6 +83,19 @@ func listMountHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.
 		results = append(results, mount)
 	}
 
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+
+	if format != utils.FormatJSON {
+		rows := make([][]string, 0, len(results))
+		for _, m := range results {
+			rows = append(rows, []string{m.Name, m.Type, m.Description, strconv.Itoa(m.DefaultLeaseTTL), strconv.Itoa(m.MaxLeaseTTL)})
+		}
+		logger.WithField("mount_count", len(results)).Debug("Successfully listed mounts")
+		return mcp.NewToolResultText(utils.RenderTable(format, []string{"name", "type", "description", "default_lease_ttl", "max_lease_ttl"}, rows)), nil
+	}
+
 	// Marshal the struct to JSON
 	jsonData, err := json.Marshal(results)
 	if err != nil {
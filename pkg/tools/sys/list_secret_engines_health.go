@@ -0,0 +1,202 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// MaxConcurrentProbes is the number of mounts probed at the same time
+const MaxConcurrentProbes = 8
+
+type MountHealth struct {
+	Name          string  `json:"name"`                     // Name of the mount
+	Type          string  `json:"type"`                     // Type of the mount (e.g., kv, pki)
+	ProbePath     string  `json:"probe_path"`               // Path used to probe the mount
+	Reachable     bool    `json:"reachable"`                // Whether the mount answered the probe
+	LatencyMs     float64 `json:"latency_ms"`               // Latency of the probe in milliseconds
+	Error         string  `json:"error,omitempty"`          // Error returned by the probe, if any
+	PluginVersion string  `json:"plugin_version,omitempty"` // Running plugin version, if reported
+	Deprecation   string  `json:"deprecation,omitempty"`    // Deprecation warning for the engine, if any
+}
+
+// ListSecretEnginesHealth creates a tool for probing the health of every mounted secrets engine
+func ListSecretEnginesHealth(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("list_secret_engines_health",
+			mcp.WithDescription("Probe every mounted secrets engine in Vault concurrently with a cheap read or list against the engine itself and report per-mount reachability, latency, errors and deprecation warnings. Use this to find out which engine is slow or broken."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithString("timeout",
+				mcp.DefaultString("5s"),
+				mcp.Description("Optional timeout for each probe. Defaults to '5s'."),
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return listSecretEnginesHealthHandler(ctx, req, logger)
+		},
+	}
+}
+
+func listSecretEnginesHealthHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling list_secret_engines_health request")
+
+	timeout := 5 * time.Second
+	if args, ok := req.Params.Arguments.(map[string]interface{}); ok {
+		if timeoutStr, ok := args["timeout"].(string); ok && timeoutStr != "" {
+			parsed, err := time.ParseDuration(timeoutStr)
+			if err != nil || parsed <= 0 {
+				return mcp.NewToolResultError("Missing or invalid 'timeout' parameter"), nil
+			}
+			timeout = parsed
+		}
+	}
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		logger.WithError(err).Error("Failed to list mounts")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list mounts: %v", err)), nil
+	}
+
+	results := make([]*MountHealth, 0, len(mounts))
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	sem := make(chan struct{}, MaxConcurrentProbes)
+
+	for name, mount := range mounts {
+		// Acquire a slot before starting the goroutine, so that no more than MaxConcurrentProbes run at once
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(name string, mount *api.MountOutput) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			health := probeMount(ctx, vault, name, mount, timeout)
+			mu.Lock()
+			results = append(results, health)
+			mu.Unlock()
+		}(name, mount)
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+
+	// Marshal the struct to JSON
+	jsonData, err := json.Marshal(results)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal mount health to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithField("mount_count", len(results)).Debug("Successfully probed mounts")
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+// probeMount issues a cheap request against the mount and measures how long it takes
+func probeMount(ctx context.Context, vault *api.Client, name string, mount *api.MountOutput, timeout time.Duration) *MountHealth {
+	path := strings.TrimSuffix(name, "/")
+	method, probePath := probeRequestForMount(path, mount)
+
+	health := &MountHealth{
+		Name:          name,
+		Type:          mount.Type,
+		ProbePath:     probePath,
+		PluginVersion: mount.RunningVersion,
+	}
+
+	switch mount.DeprecationStatus {
+	case "", "supported":
+	default:
+		health.Deprecation = fmt.Sprintf("the %s engine is %s, plan a migration before upgrading Vault", mount.Type, mount.DeprecationStatus)
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	if method == http.MethodGet {
+		_, err := vault.Logical().ReadWithContext(probeCtx, probePath)
+		health.setProbeError(err)
+	} else {
+		_, err := vault.Logical().ListWithContext(probeCtx, probePath)
+		health.setProbeError(err)
+	}
+	health.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
+
+	return health
+}
+
+// probeRequestForMount returns the method and path of the cheapest request for the mount type. The request
+// always goes to the engine itself, so that a slow or broken plugin shows up in the probe.
+func probeRequestForMount(path string, mount *api.MountOutput) (string, string) {
+	switch mount.Type {
+	case "kv":
+		if mount.Options["version"] == "2" {
+			return http.MethodGet, fmt.Sprintf("%s/config", path)
+		}
+		return "LIST", fmt.Sprintf("%s/", path)
+	case "pki":
+		return http.MethodGet, fmt.Sprintf("%s/config/urls", path)
+	case "transit":
+		return http.MethodGet, fmt.Sprintf("%s/cache-config", path)
+	case "database":
+		return "LIST", fmt.Sprintf("%s/config", path)
+	case "aws":
+		return http.MethodGet, fmt.Sprintf("%s/config/lease", path)
+	case "ssh":
+		return "LIST", fmt.Sprintf("%s/roles", path)
+	case "totp":
+		return "LIST", fmt.Sprintf("%s/keys", path)
+	case "identity":
+		return "LIST", fmt.Sprintf("%s/entity/id", path)
+	case "system":
+		return http.MethodGet, "sys/health"
+	default:
+		// Listing the root of the mount reaches the engine, an engine without a list operation at its
+		// root answers with a client error and still counts as reachable
+		return "LIST", fmt.Sprintf("%s/", path)
+	}
+}
+
+// setProbeError records the probe error. The mount is considered reachable when Vault answered
+// the request without a server error, even if the token lacks permission on the probed path.
+func (h *MountHealth) setProbeError(err error) {
+	if err == nil {
+		h.Reachable = true
+		return
+	}
+
+	h.Error = err.Error()
+
+	var respErr *api.ResponseError
+	if errors.As(err, &respErr) && respErr.StatusCode < http.StatusInternalServerError {
+		h.Reachable = true
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListSecretEnginesHealth(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	mounts := map[string]interface{}{
+		"secret/":    map[string]interface{}{"type": "kv", "options": map[string]interface{}{"version": "2"}},
+		"pki/":       map[string]interface{}{"type": "pki"},
+		"database/":  map[string]interface{}{"type": "database"},
+		"custom/":    map[string]interface{}{"type": "vault-plugin-secrets-custom"},
+		"broken/":    map[string]interface{}{"type": "vault-plugin-secrets-broken"},
+		"legacy-ad/": map[string]interface{}{"type": "ad", "deprecation_status": "pending-removal"},
+	}
+	for i := 0; i < 3*MaxConcurrentProbes; i++ {
+		mounts[fmt.Sprintf("transit-%d/", i)] = map[string]interface{}{"type": "transit"}
+	}
+
+	var mu sync.Mutex
+	probes := map[string]string{}
+	var running, peak atomic.Int32
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/sys/mounts" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": mounts})
+			return
+		}
+
+		now := running.Add(1)
+		defer running.Add(-1)
+		for {
+			current := peak.Load()
+			if now <= current || peak.CompareAndSwap(current, now) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		method := r.Method
+		if r.URL.Query().Get("list") == "true" {
+			method = "LIST"
+		}
+		mu.Lock()
+		probes[r.URL.Path] = method
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/v1/broken":
+			w.WriteHeader(http.StatusNotImplemented)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"errors": []string{"plugin is not running"}})
+		case "/v1/custom":
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"errors": []string{"unsupported operation"}})
+		default:
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{}})
+		}
+	}))
+	defer mockVault.Close()
+
+	sessionID := "test-" + t.Name()
+	_, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	defer client.DeleteVaultClient(sessionID)
+	ctx := server.NewMCPServer("test", "1.0").WithContext(context.Background(), testSession{id: sessionID})
+
+	result, err := ListSecretEnginesHealth(logger).Handler(ctx, mcp.CallToolRequest{})
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var health []MountHealth
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &health))
+	require.Len(t, health, len(mounts))
+	byName := map[string]MountHealth{}
+	for _, mount := range health {
+		byName[mount.Name] = mount
+	}
+
+	assert.Equal(t, "secret/config", byName["secret/"].ProbePath)
+	assert.Equal(t, "pki/config/urls", byName["pki/"].ProbePath)
+	assert.Equal(t, "database/config", byName["database/"].ProbePath)
+	assert.Equal(t, "custom/", byName["custom/"].ProbePath)
+	assert.True(t, byName["custom/"].Reachable, "a client error still means the engine answered")
+	assert.False(t, byName["broken/"].Reachable)
+	assert.Contains(t, byName["broken/"].Error, "plugin is not running")
+	assert.Contains(t, byName["legacy-ad/"].Deprecation, "pending-removal")
+
+	assert.Equal(t, "LIST", probes["/v1/database/config"])
+	assert.Equal(t, "LIST", probes["/v1/custom"])
+	for path := range probes {
+		assert.NotContains(t, path, "/v1/sys/", "mounts are probed through their engine, not core endpoints")
+	}
+	assert.LessOrEqual(t, peak.Load(), int32(MaxConcurrentProbes))
+}
@@ -0,0 +1,97 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newManagedKeysVault returns a mock Vault with a pkcs11 managed key 'hsm-key' and a PKI mount at 'pki'
+func newManagedKeysVault(t *testing.T, allowed []string, tuned *map[string]interface{}) context.Context {
+	t.Helper()
+
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/sys/managed-keys/pkcs11/hsm-key":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"library": "softhsm", "slot": "0", "pin": "1234", "key_label": "ca",
+			}})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/sys/mounts/pki/tune":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"allowed_managed_keys": allowed,
+			}})
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/sys/mounts/pki/tune":
+			_ = json.NewDecoder(r.Body).Decode(tuned)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(mockVault.Close)
+
+	sessionID := "test-" + t.Name()
+	_, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	t.Cleanup(func() { client.DeleteVaultClient(sessionID) })
+
+	return server.NewMCPServer("test", "1.0").WithContext(context.Background(), testSession{id: sessionID})
+}
+
+func TestReadManagedKeyRedactsCredentials(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	ctx := newManagedKeysVault(t, nil, &map[string]interface{}{})
+
+	result, err := ReadManagedKey(logger).Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{
+		Arguments: map[string]interface{}{"type": "pkcs11", "name": "hsm-key"},
+	}})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	text := result.Content[0].(mcp.TextContent).Text
+	assert.Contains(t, text, `"library":"softhsm"`)
+	assert.NotContains(t, text, "1234")
+}
+
+func TestAttachManagedKey(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	t.Run("key is appended to the allowed keys", func(t *testing.T) {
+		tuned := map[string]interface{}{}
+		ctx := newManagedKeysVault(t, []string{"other-key"}, &tuned)
+
+		result, err := AttachManagedKey(logger).Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{"mount": "pki/", "name": "hsm-key"},
+		}})
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+		assert.Equal(t, []interface{}{"other-key", "hsm-key"}, tuned["allowed_managed_keys"])
+	})
+
+	t.Run("already allowed key is not tuned again", func(t *testing.T) {
+		tuned := map[string]interface{}{}
+		ctx := newManagedKeysVault(t, []string{"hsm-key"}, &tuned)
+
+		result, err := AttachManagedKey(logger).Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{"mount": "pki", "name": "hsm-key"},
+		}})
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+		assert.Empty(t, tuned)
+	})
+}
@@ -0,0 +1,615 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultDependencyLimit is the default number of entries read per listing by map_dependencies
+const DefaultDependencyLimit = 100
+
+// Types of the nodes of a dependency graph
+const (
+	NodePKIIssuer     = "pki_issuer"
+	NodePKIRole       = "pki_role"
+	NodeCertificate   = "certificate"
+	NodePolicy        = "policy"
+	NodeKVPath        = "kv_path"
+	NodeAuthRole      = "auth_role"
+	NodeSecretsEngine = "secrets_engine"
+	NodeAuthMethod    = "auth_method"
+	NodeCluster       = "cluster"
+)
+
+// Relations of the edges of a dependency graph, each edge points from the dependent node to the node it depends on
+const (
+	RelationSignsWith      = "signs_with"      // A PKI role issues certificates with an issuer
+	RelationIssuedBy       = "issued_by"       // A certificate was issued by an issuer
+	RelationGrants         = "grants"          // A policy grants access to a KV path or a PKI role
+	RelationAttaches       = "attaches"        // An auth role attaches a policy to the tokens it issues
+	RelationMountedOn      = "mounted_on"      // A node lives on a secrets engine or auth method
+	RelationReplicatesFrom = "replicates_from" // A secondary cluster replicates from its primary
+)
+
+// authRoleLists are the paths listing the roles of an auth method, and the fields of a role holding its policies
+var authRoleLists = map[string]struct {
+	path   string
+	fields []string
+}{
+	"approle":    {"role", []string{"token_policies", "policies"}},
+	"kubernetes": {"role", []string{"token_policies", "policies"}},
+	"jwt":        {"role", []string{"token_policies", "policies"}},
+	"oidc":       {"role", []string{"token_policies", "policies"}},
+	"aws":        {"role", []string{"token_policies", "policies"}},
+	"gcp":        {"role", []string{"token_policies", "policies"}},
+	"azure":      {"role", []string{"token_policies", "policies"}},
+	"cert":       {"certs", []string{"token_policies", "policies"}},
+	"userpass":   {"users", []string{"token_policies", "policies"}},
+	"ldap":       {"groups", []string{"policies"}},
+	"okta":       {"groups", []string{"policies"}},
+	"github":     {"map/teams", []string{"value"}},
+	"token":      {"roles", []string{"allowed_policies"}},
+}
+
+type DependencyNode struct {
+	ID    string `json:"id"`              // Unique ID of the node, '<type>:<path>'
+	Type  string `json:"type"`            // pki_issuer, pki_role, certificate, policy, kv_path, auth_role, secrets_engine, auth_method or cluster
+	Label string `json:"label"`           // Name of the node, such as the name of an issuer or the path of a policy rule
+	Mount string `json:"mount,omitempty"` // Mount of the node, auth methods are prefixed with 'auth/'
+}
+
+type DependencyEdge struct {
+	From     string `json:"from"`             // ID of the dependent node
+	To       string `json:"to"`               // ID of the node it depends on
+	Relation string `json:"relation"`         // signs_with, issued_by, grants, attaches, mounted_on or replicates_from
+	Detail   string `json:"detail,omitempty"` // Capabilities a policy grants on a path, or the kind of replication
+}
+
+type DependencyGraph struct {
+	Nodes     []*DependencyNode `json:"nodes"`
+	Edges     []*DependencyEdge `json:"edges"`
+	Focus     string            `json:"focus,omitempty"`     // Node whose dependents the graph is limited to
+	Truncated bool              `json:"truncated,omitempty"` // Whether listings stopped at the limit, so the graph misses nodes
+	Warnings  []string          `json:"warnings,omitempty"`  // Parts of Vault that could not be read, usually because of missing permissions
+	DOT       string            `json:"dot,omitempty"`       // The graph in Graphviz DOT format, when requested
+
+	nodes map[string]*DependencyNode
+	edges map[DependencyEdge]bool
+}
+
+// MapDependencies creates a tool for mapping the dependencies between PKI issuers, roles, certificates, policies, KV paths and auth roles
+func MapDependencies(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("map_dependencies",
+			mcp.WithDescription("Build a graph of the dependencies between PKI issuers, the PKI roles that sign with them and the certificates they issued, the policies granting access to KV paths and PKI roles, and the auth roles attaching those policies. Edges point from the dependent node to the node it depends on. Pass 'focus' to answer change-impact questions such as 'what breaks if I delete this issuer': the graph is then limited to the nodes that depend on the focus node, directly or transitively."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithString("focus",
+				mcp.DefaultString(""),
+				mcp.Description("Optional ID of a node, such as 'pki_issuer:pki/<issuer id>' or 'policy:app-read', to limit the graph to the nodes that depend on it."),
+			),
+			mcp.WithBoolean("include_certificates",
+				mcp.DefaultBool(true),
+				mcp.Description("Read the certificates of the PKI mounts to link them to their issuer. Defaults to true."),
+			),
+			mcp.WithString("format",
+				mcp.DefaultString("json"),
+				mcp.Enum("json", "dot"),
+				mcp.Description("'dot' adds the graph in Graphviz DOT format to the result. Defaults to 'json'."),
+			),
+			mcp.WithNumber("limit",
+				mcp.DefaultNumber(DefaultDependencyLimit),
+				mcp.Description("The maximum number of entries read per listing, such as the roles of a mount or the policies of the namespace. Defaults to 100."),
+			),
+			schemas.Output("map_dependencies"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mapDependenciesHandler(ctx, req, logger)
+		},
+	}
+}
+
+func mapDependenciesHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling map_dependencies request")
+
+	format := req.GetString("format", "json")
+	if format != "json" && format != "dot" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'format' parameter '%s', expected 'json' or 'dot'", format)), nil
+	}
+	limit := req.GetInt("limit", DefaultDependencyLimit)
+	if limit < 1 {
+		return mcp.NewToolResultError("Invalid 'limit' parameter, must be at least 1"), nil
+	}
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	graph := BuildDependencyGraph(vault, limit, req.GetBool("include_certificates", true))
+	if focus := req.GetString("focus", ""); focus != "" {
+		if err := graph.FocusOn(focus); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+	if format == "dot" {
+		graph.DOT = graph.RenderDOT()
+	}
+
+	jsonData, err := json.Marshal(graph)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal dependency graph to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"nodes": len(graph.Nodes),
+		"edges": len(graph.Edges),
+	}).Debug("Successfully mapped dependencies")
+	return mcp.NewToolResultStructured(graph, string(jsonData)), nil
+}
+
+// BuildDependencyGraph reads the PKI mounts, policies and auth roles of the namespace into a dependency graph.
+// Parts that cannot be read are reported as warnings, so that the graph covers what the token can see.
+func BuildDependencyGraph(vault *api.Client, limit int, includeCertificates bool) *DependencyGraph {
+	graph := &DependencyGraph{
+		Nodes: []*DependencyNode{},
+		Edges: []*DependencyEdge{},
+		nodes: map[string]*DependencyNode{},
+		edges: map[DependencyEdge]bool{},
+	}
+
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		graph.warn("secrets engines", err)
+		mounts = nil
+	}
+	var mountPaths []string
+	for path := range mounts {
+		mountPaths = append(mountPaths, path)
+	}
+	sort.Strings(mountPaths)
+
+	for _, path := range mountPaths {
+		if mounts[path].Type == "pki" {
+			graph.addPKIMount(vault, strings.TrimSuffix(path, "/"), limit, includeCertificates)
+		}
+	}
+	graph.addPolicies(vault, mounts, limit)
+
+	if auths, err := vault.Sys().ListAuth(); err != nil {
+		graph.warn("auth methods", err)
+	} else {
+		var authPaths []string
+		for path := range auths {
+			authPaths = append(authPaths, path)
+		}
+		sort.Strings(authPaths)
+		for _, path := range authPaths {
+			graph.addAuthRoles(vault, "auth/"+strings.TrimSuffix(path, "/"), auths[path].Type, limit)
+		}
+	}
+
+	graph.sort()
+	return graph
+}
+
+// addPKIMount adds the issuers, roles and certificates of a PKI mount
+func (g *DependencyGraph) addPKIMount(vault *api.Client, mount string, limit int, includeCertificates bool) {
+	issuers, err := vault.Logical().List(mount + "/issuers")
+	if err != nil {
+		g.warn(fmt.Sprintf("issuers of '%s'", mount), err)
+		return
+	}
+	names := map[string]string{}
+	if issuers != nil && issuers.Data != nil {
+		info, _ := issuers.Data["key_info"].(map[string]interface{})
+		for _, id := range g.limited(toStringList(issuers.Data["keys"]), limit) {
+			label := id
+			if details, ok := info[id].(map[string]interface{}); ok {
+				if name, _ := details["issuer_name"].(string); name != "" {
+					label = name
+					names[name] = id
+				}
+			}
+			g.addNode(NodePKIIssuer, mount+"/"+id, label, mount)
+		}
+	}
+	issuerID := func(ref string) string {
+		if id, ok := names[ref]; ok {
+			return id
+		}
+		return ref
+	}
+	if config, err := vault.Logical().Read(mount + "/config/issuers"); err == nil && config != nil && config.Data != nil {
+		if id, _ := config.Data["default"].(string); id != "" {
+			names["default"] = id
+		}
+	}
+
+	if roles, err := vault.Logical().List(mount + "/roles"); err != nil {
+		g.warn(fmt.Sprintf("roles of '%s'", mount), err)
+	} else if roles != nil && roles.Data != nil {
+		for _, name := range g.limited(toStringList(roles.Data["keys"]), limit) {
+			role := g.addNode(NodePKIRole, mount+"/roles/"+name, name, mount)
+			ref := "default"
+			if secret, err := vault.Logical().Read(mount + "/roles/" + name); err == nil && secret != nil && secret.Data != nil {
+				if value, _ := secret.Data["issuer_ref"].(string); value != "" {
+					ref = value
+				}
+			}
+			if issuer := g.nodes[nodeID(NodePKIIssuer, mount+"/"+issuerID(ref))]; issuer != nil {
+				g.addEdge(role.ID, issuer.ID, RelationSignsWith, "")
+			}
+		}
+	}
+
+	if !includeCertificates {
+		return
+	}
+	certs, err := vault.Logical().List(mount + "/certs")
+	if err != nil {
+		g.warn(fmt.Sprintf("certificates of '%s'", mount), err)
+		return
+	}
+	if certs == nil || certs.Data == nil {
+		return
+	}
+	for _, serial := range g.limited(toStringList(certs.Data["keys"]), limit) {
+		cert := g.addNode(NodeCertificate, mount+"/cert/"+serial, serial, mount)
+		secret, err := vault.Logical().Read(mount + "/cert/" + serial)
+		if err != nil || secret == nil || secret.Data == nil {
+			continue
+		}
+		if id, _ := secret.Data["issuer_id"].(string); id != "" {
+			if issuer := g.nodes[nodeID(NodePKIIssuer, mount+"/"+id)]; issuer != nil {
+				g.addEdge(cert.ID, issuer.ID, RelationIssuedBy, "")
+			}
+		}
+	}
+}
+
+// addPolicies adds the ACL policies and the KV paths and PKI roles their rules grant access to
+func (g *DependencyGraph) addPolicies(vault *api.Client, mounts map[string]*api.MountOutput, limit int) {
+	names, err := vault.Sys().ListPolicies()
+	if err != nil {
+		g.warn("policies", err)
+		return
+	}
+	sort.Strings(names)
+
+	for _, name := range g.limited(names, limit) {
+		if name == "root" {
+			continue
+		}
+		policy := g.addNode(NodePolicy, name, name, "")
+		rules, err := vault.Sys().GetPolicy(name)
+		if err != nil {
+			g.warn(fmt.Sprintf("policy '%s'", name), err)
+			continue
+		}
+		paths, err := parsePolicyPaths(rules)
+		if err != nil {
+			g.Warnings = append(g.Warnings, fmt.Sprintf("unable to parse policy '%s': %v", name, err))
+			continue
+		}
+
+		for _, rule := range paths {
+			mountPath, mount := findMount(mounts, rule.path)
+			if mount == nil {
+				continue
+			}
+			detail := strings.Join(rule.capabilities, ",")
+			switch mount.Type {
+			case "kv", "generic":
+				target := g.addNode(NodeKVPath, rule.path, rule.path, mountPath)
+				g.addEdge(policy.ID, target.ID, RelationGrants, detail)
+			case "pki":
+				for _, node := range g.Nodes {
+					if node.Type == NodePKIRole && node.Mount == mountPath && (policyPathMatches(rule.path, mountPath+"/issue/"+node.Label) || policyPathMatches(rule.path, mountPath+"/sign/"+node.Label)) {
+						g.addEdge(policy.ID, node.ID, RelationGrants, detail)
+					}
+				}
+			}
+		}
+	}
+}
+
+// addAuthRoles adds the roles of an auth method and the policies they attach
+func (g *DependencyGraph) addAuthRoles(vault *api.Client, mount, authType string, limit int) {
+	list, ok := authRoleLists[authType]
+	if !ok {
+		return
+	}
+	listPath := mount + "/" + list.path
+	secret, err := vault.Logical().List(listPath)
+	if err != nil {
+		g.warn(fmt.Sprintf("roles of '%s'", mount), err)
+		return
+	}
+	if secret == nil || secret.Data == nil {
+		return
+	}
+
+	for _, name := range g.limited(toStringList(secret.Data["keys"]), limit) {
+		role, err := vault.Logical().Read(listPath + "/" + name)
+		if err != nil || role == nil || role.Data == nil {
+			continue
+		}
+		var policies []string
+		for _, field := range list.fields {
+			policies = append(policies, toStringList(role.Data[field])...)
+		}
+		if len(policies) == 0 {
+			continue
+		}
+
+		node := g.addNode(NodeAuthRole, listPath+"/"+name, strings.TrimPrefix(listPath, "auth/")+"/"+name, mount)
+		for _, policyName := range policies {
+			if policyName == "default" {
+				continue
+			}
+			policy := g.addNode(NodePolicy, policyName, policyName, "")
+			g.addEdge(node.ID, policy.ID, RelationAttaches, "")
+		}
+	}
+}
+
+// FocusOn limits the graph to the focus node and the nodes that depend on it, directly or transitively
+func (g *DependencyGraph) FocusOn(focus string) error {
+	if _, ok := g.nodes[focus]; !ok {
+		return fmt.Errorf("node '%s' is not part of the dependency graph, node IDs look like 'pki_issuer:pki/<issuer id>' or 'policy:<name>'", focus)
+	}
+
+	dependents := map[string]bool{focus: true}
+	for changed := true; changed; {
+		changed = false
+		for _, edge := range g.Edges {
+			if dependents[edge.To] && !dependents[edge.From] {
+				dependents[edge.From] = true
+				changed = true
+			}
+		}
+	}
+
+	nodes := []*DependencyNode{}
+	for _, node := range g.Nodes {
+		if dependents[node.ID] {
+			nodes = append(nodes, node)
+		}
+	}
+	edges := []*DependencyEdge{}
+	for _, edge := range g.Edges {
+		if dependents[edge.From] && dependents[edge.To] {
+			edges = append(edges, edge)
+		}
+	}
+	g.Nodes, g.Edges, g.Focus = nodes, edges, focus
+	return nil
+}
+
+// RenderDOT renders the graph in Graphviz DOT format
+func (g *DependencyGraph) RenderDOT() string {
+	shapes := map[string]string{
+		NodePKIIssuer:     "hexagon",
+		NodePKIRole:       "box",
+		NodeCertificate:   "note",
+		NodePolicy:        "component",
+		NodeKVPath:        "folder",
+		NodeAuthRole:      "ellipse",
+		NodeSecretsEngine: "cylinder",
+		NodeAuthMethod:    "house",
+		NodeCluster:       "box3d",
+	}
+
+	var sb strings.Builder
+	sb.WriteString("digraph vault {\n  rankdir=LR;\n")
+	for _, node := range g.Nodes {
+		fmt.Fprintf(&sb, "  %q [label=%q, shape=%s];\n", node.ID, node.Label, shapes[node.Type])
+	}
+	for _, edge := range g.Edges {
+		label := edge.Relation
+		if edge.Detail != "" {
+			label += " (" + edge.Detail + ")"
+		}
+		fmt.Fprintf(&sb, "  %q -> %q [label=%q];\n", edge.From, edge.To, label)
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// RenderMermaid renders the graph as a Mermaid flowchart
+func (g *DependencyGraph) RenderMermaid() string {
+	shapes := map[string][2]string{
+		NodePKIIssuer:     {"{{", "}}"},
+		NodePKIRole:       {"[", "]"},
+		NodeCertificate:   {"[/", "/]"},
+		NodePolicy:        {"[[", "]]"},
+		NodeKVPath:        {">", "]"},
+		NodeAuthRole:      {"([", "])"},
+		NodeSecretsEngine: {"[(", ")]"},
+		NodeAuthMethod:    {"[\\", "/]"},
+		NodeCluster:       {"((", "))"},
+	}
+
+	// Mermaid IDs cannot hold the characters of Vault paths, so nodes are numbered in the order of the graph
+	ids := make(map[string]string, len(g.Nodes))
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+	for i, node := range g.Nodes {
+		ids[node.ID] = fmt.Sprintf("n%d", i)
+		shape, ok := shapes[node.Type]
+		if !ok {
+			shape = [2]string{"[", "]"}
+		}
+		fmt.Fprintf(&sb, "  %s%s\"%s\"%s\n", ids[node.ID], shape[0], mermaidEscape(node.Label), shape[1])
+	}
+	for _, edge := range g.Edges {
+		label := edge.Relation
+		if edge.Detail != "" {
+			label += " (" + edge.Detail + ")"
+		}
+		fmt.Fprintf(&sb, "  %s -->|\"%s\"| %s\n", ids[edge.From], mermaidEscape(label), ids[edge.To])
+	}
+	return sb.String()
+}
+
+// mermaidEscape escapes the quotes of a Mermaid label, which would otherwise end it
+func mermaidEscape(label string) string {
+	return strings.ReplaceAll(label, `"`, "#quot;")
+}
+
+// addNode adds a node unless the graph already has it, and returns the node of the graph
+func (g *DependencyGraph) addNode(nodeType, path, label, mount string) *DependencyNode {
+	id := nodeID(nodeType, path)
+	if node, ok := g.nodes[id]; ok {
+		return node
+	}
+	node := &DependencyNode{ID: id, Type: nodeType, Label: label, Mount: mount}
+	g.nodes[id] = node
+	g.Nodes = append(g.Nodes, node)
+	return node
+}
+
+// addEdge adds an edge unless the graph already has it
+func (g *DependencyGraph) addEdge(from, to, relation, detail string) {
+	edge := DependencyEdge{From: from, To: to, Relation: relation, Detail: detail}
+	if g.edges[edge] {
+		return
+	}
+	g.edges[edge] = true
+	g.Edges = append(g.Edges, &edge)
+}
+
+// limited cuts a listing to the limit, marking the graph as truncated when entries are left out
+func (g *DependencyGraph) limited(keys []string, limit int) []string {
+	if len(keys) > limit {
+		g.Truncated = true
+		return keys[:limit]
+	}
+	return keys
+}
+
+// warn records a part of Vault that could not be read
+func (g *DependencyGraph) warn(what string, err error) {
+	g.Warnings = append(g.Warnings, fmt.Sprintf("unable to read %s: %v", what, err))
+}
+
+// sort orders the nodes by ID and the edges by their ends, so that the graph is stable between calls
+func (g *DependencyGraph) sort() {
+	sort.Slice(g.Nodes, func(i, j int) bool {
+		return g.Nodes[i].ID < g.Nodes[j].ID
+	})
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		return g.Edges[i].To < g.Edges[j].To
+	})
+}
+
+// nodeID returns the ID of a node of the graph
+func nodeID(nodeType, path string) string {
+	return nodeType + ":" + path
+}
+
+// policyRule is a path rule of a policy
+type policyRule struct {
+	path         string
+	capabilities []string
+}
+
+// parsePolicyPaths returns the path rules of a policy in HCL or JSON
+func parsePolicyPaths(policy string) ([]policyRule, error) {
+	var parsed struct {
+		Path map[string]struct {
+			Capabilities []string `hcl:"capabilities"`
+		} `hcl:"path"`
+	}
+	if err := hcl.Decode(&parsed, policy); err != nil {
+		return nil, err
+	}
+
+	rules := make([]policyRule, 0, len(parsed.Path))
+	for path, rule := range parsed.Path {
+		rules = append(rules, policyRule{path: strings.TrimPrefix(path, "/"), capabilities: rule.Capabilities})
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].path < rules[j].path
+	})
+	return rules, nil
+}
+
+// findMount returns the mount a policy path falls under, by the longest matching mount path
+func findMount(mounts map[string]*api.MountOutput, path string) (string, *api.MountOutput) {
+	var bestPath string
+	var best *api.MountOutput
+	for mountPath, mount := range mounts {
+		if strings.HasPrefix(path, mountPath) && len(mountPath) > len(bestPath) {
+			bestPath, best = mountPath, mount
+		}
+	}
+	return strings.TrimSuffix(bestPath, "/"), best
+}
+
+// policyPathMatches reports whether a policy path matches a request path: '+' matches a single path segment
+// and a trailing '*' any suffix
+func policyPathMatches(pattern, path string) bool {
+	prefix, glob := strings.CutSuffix(pattern, "*")
+	patternSegments := strings.Split(prefix, "/")
+	pathSegments := strings.Split(path, "/")
+	for i, segment := range patternSegments {
+		last := i == len(patternSegments)-1
+		if i >= len(pathSegments) {
+			return false
+		}
+		switch {
+		case segment == "+":
+		case last && glob:
+			return strings.HasPrefix(pathSegments[i], segment)
+		case segment != pathSegments[i]:
+			return false
+		}
+	}
+	return glob || len(patternSegments) == len(pathSegments)
+}
+
+// toStringList converts a list returned by Vault, which may also be a comma separated string
+func toStringList(value interface{}) []string {
+	var strs []string
+	switch value := value.(type) {
+	case []interface{}:
+		for _, item := range value {
+			if s, ok := item.(string); ok && s != "" {
+				strs = append(strs, s)
+			}
+		}
+	case string:
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				strs = append(strs, item)
+			}
+		}
+	}
+	return strs
+}
@@ -0,0 +1,165 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newDependenciesVault serves a PKI mount with an issuer, a role and a certificate, a KV mount, two policies
+// and an AppRole role attaching one of them
+func newDependenciesVault(t *testing.T) context.Context {
+	t.Helper()
+
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		data := func(data map[string]interface{}) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+		}
+		keys := func(keys ...string) {
+			data(map[string]interface{}{"keys": keys})
+		}
+		switch r.URL.Path {
+		case "/v1/sys/mounts":
+			data(map[string]interface{}{
+				"pki/":    map[string]interface{}{"type": "pki"},
+				"secret/": map[string]interface{}{"type": "kv", "options": map[string]interface{}{"version": "2"}},
+			})
+		case "/v1/sys/auth":
+			data(map[string]interface{}{
+				"approle/": map[string]interface{}{"type": "approle"},
+				"token/":   map[string]interface{}{"type": "token"},
+			})
+		case "/v1/pki/issuers":
+			data(map[string]interface{}{
+				"keys":     []string{"issuer-1"},
+				"key_info": map[string]interface{}{"issuer-1": map[string]interface{}{"issuer_name": "root-2025"}},
+			})
+		case "/v1/pki/config/issuers":
+			data(map[string]interface{}{"default": "issuer-1"})
+		case "/v1/pki/roles":
+			keys("web")
+		case "/v1/pki/roles/web":
+			data(map[string]interface{}{"issuer_ref": "root-2025"})
+		case "/v1/pki/certs":
+			keys("1a:2b")
+		case "/v1/pki/cert/1a:2b":
+			data(map[string]interface{}{"issuer_id": "issuer-1"})
+		case "/v1/sys/policies/acl":
+			keys("default", "web-certs", "app-read", "root")
+		case "/v1/sys/policies/acl/web-certs":
+			data(map[string]interface{}{"name": "web-certs", "policy": `path "pki/issue/*" { capabilities = ["update"] }`})
+		case "/v1/sys/policies/acl/app-read":
+			data(map[string]interface{}{"name": "app-read", "policy": `{"path": {"secret/data/app/*": {"capabilities": ["read", "list"]}}}`})
+		case "/v1/sys/policies/acl/default":
+			data(map[string]interface{}{"name": "default", "policy": `path "auth/token/lookup-self" { capabilities = ["read"] }`})
+		case "/v1/auth/approle/role":
+			keys("web")
+		case "/v1/auth/approle/role/web":
+			data(map[string]interface{}{"token_policies": []string{"default", "web-certs"}})
+		case "/v1/auth/token/roles":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(mockVault.Close)
+
+	sessionID := "test-" + t.Name()
+	_, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	t.Cleanup(func() { client.DeleteVaultClient(sessionID) })
+	return server.NewMCPServer("test", "1.0").WithContext(context.Background(), testSession{id: sessionID})
+}
+
+func callMapDependencies(t *testing.T, ctx context.Context, args map[string]interface{}) *mcp.CallToolResult {
+	t.Helper()
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	result, err := MapDependencies(logger).Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+	require.NoError(t, err)
+	return result
+}
+
+func TestMapDependencies(t *testing.T) {
+	ctx := newDependenciesVault(t)
+
+	result := callMapDependencies(t, ctx, map[string]interface{}{})
+	require.False(t, result.IsError, result.Content)
+	var graph DependencyGraph
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &graph))
+
+	var ids []string
+	for _, node := range graph.Nodes {
+		ids = append(ids, node.ID)
+	}
+	assert.Equal(t, []string{
+		"auth_role:auth/approle/role/web",
+		"certificate:pki/cert/1a:2b",
+		"kv_path:secret/data/app/*",
+		"pki_issuer:pki/issuer-1",
+		"pki_role:pki/roles/web",
+		"policy:app-read",
+		"policy:default",
+		"policy:web-certs",
+	}, ids)
+	assert.Equal(t, []*DependencyEdge{
+		{From: "auth_role:auth/approle/role/web", To: "policy:web-certs", Relation: RelationAttaches},
+		{From: "certificate:pki/cert/1a:2b", To: "pki_issuer:pki/issuer-1", Relation: RelationIssuedBy},
+		{From: "pki_role:pki/roles/web", To: "pki_issuer:pki/issuer-1", Relation: RelationSignsWith},
+		{From: "policy:app-read", To: "kv_path:secret/data/app/*", Relation: RelationGrants, Detail: "read,list"},
+		{From: "policy:web-certs", To: "pki_role:pki/roles/web", Relation: RelationGrants, Detail: "update"},
+	}, graph.Edges)
+	require.Len(t, graph.Warnings, 1)
+	assert.Contains(t, graph.Warnings[0], "auth/token")
+}
+
+func TestMapDependencies_Focus(t *testing.T) {
+	ctx := newDependenciesVault(t)
+
+	result := callMapDependencies(t, ctx, map[string]interface{}{"focus": "pki_issuer:pki/issuer-1", "format": "dot", "include_certificates": false})
+	require.False(t, result.IsError, result.Content)
+	var graph DependencyGraph
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &graph))
+
+	assert.Len(t, graph.Nodes, 4, "the issuer, the PKI role, the policy granting it and the auth role attaching the policy")
+	assert.Len(t, graph.Edges, 3)
+	assert.Contains(t, graph.DOT, `"policy:web-certs" -> "pki_role:pki/roles/web" [label="grants (update)"];`)
+	assert.NotContains(t, graph.DOT, "app-read")
+
+	result = callMapDependencies(t, ctx, map[string]interface{}{"focus": "policy:missing"})
+	assert.True(t, result.IsError)
+}
+
+func TestPolicyPathMatches(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"pki/issue/web", "pki/issue/web", true},
+		{"pki/issue/*", "pki/issue/web", true},
+		{"pki/iss*", "pki/issue/web", true},
+		{"pki/+/web", "pki/sign/web", true},
+		{"pki/issue/*", "pki/issue", false},
+		{"pki/issue/api", "pki/issue/web", false},
+		{"pki/issue", "pki/issue/web", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, policyPathMatches(tt.pattern, tt.path), "%s matching %s", tt.pattern, tt.path)
+	}
+}
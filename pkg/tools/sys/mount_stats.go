@@ -0,0 +1,79 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+)
+
+// KVMountStats describes the shape of the secrets stored in a KV mount
+type KVMountStats struct {
+	Mount            string      `json:"mount"`
+	Version          int         `json:"version"`
+	Secrets          int         `json:"secrets"`
+	AverageVersions  float64     `json:"average_versions,omitempty"`  // Average number of versions kept per KV v2 secret
+	VersionHistogram map[int]int `json:"version_histogram,omitempty"` // Number of KV v2 secrets per number of versions kept
+	DeepestPath      string      `json:"deepest_path,omitempty"`
+	MaxDepth         int         `json:"max_depth"` // Number of path segments of the deepest secret
+	Truncated        bool        `json:"truncated,omitempty"`
+	ComputedAt       time.Time   `json:"computed_at"`
+	Note             string      `json:"note,omitempty"`
+}
+
+// ComputeKVMountStats walks a KV mount and computes its statistics, stopping at limit secrets. The metadata of
+// every KV v2 secret is read to count its versions.
+func ComputeKVMountStats(vault *api.Client, path string, mount *api.MountOutput, limit int) (*KVMountStats, error) {
+	if mount.Type != "kv" && mount.Type != "generic" {
+		return nil, fmt.Errorf("mount '%s' is a '%s' mount, statistics are only available for KV mounts", path, mount.Type)
+	}
+
+	v2 := mount.Options["version"] == "2"
+	stats := &KVMountStats{Mount: path, Version: 1, ComputedAt: time.Now().UTC()}
+	if v2 {
+		stats.Version = 2
+	}
+
+	secrets, truncated, err := listKVSecrets(vault, path, v2, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+	stats.Secrets, stats.Truncated = len(secrets), truncated
+
+	for _, secretPath := range secrets {
+		if depth := strings.Count(secretPath, "/") + 1; depth > stats.MaxDepth {
+			stats.DeepestPath, stats.MaxDepth = secretPath, depth
+		}
+	}
+
+	if !v2 || len(secrets) == 0 {
+		return stats, nil
+	}
+
+	stats.VersionHistogram = map[int]int{}
+	counted, total := 0, 0
+	for _, secretPath := range secrets {
+		metadata, err := vault.Logical().Read(fmt.Sprintf("%s/metadata/%s", path, secretPath))
+		if err != nil {
+			stats.Note = fmt.Sprintf("failed to read metadata of '%s': %v", secretPath, err)
+			continue
+		}
+		if metadata == nil || metadata.Data == nil {
+			continue
+		}
+		versions, _ := metadata.Data["versions"].(map[string]interface{})
+		stats.VersionHistogram[len(versions)]++
+		counted++
+		total += len(versions)
+	}
+	if counted > 0 {
+		stats.AverageVersions = math.Round(float64(total)/float64(counted)*100) / 100
+	}
+
+	return stats, nil
+}
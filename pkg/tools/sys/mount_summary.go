@@ -0,0 +1,112 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault/api"
+)
+
+// MaxSummarizedSecrets is the maximum number of KV secrets counted when summarizing a mount
+const MaxSummarizedSecrets = 1000
+
+// MountSummary describes what would be lost when a mount is deleted
+type MountSummary struct {
+	Path      string         `json:"path"`
+	Type      string         `json:"type"`
+	Empty     bool           `json:"empty"`
+	Contents  map[string]int `json:"contents,omitempty"`  // Number of objects per kind, e.g. secrets, roles or issuers
+	Truncated bool           `json:"truncated,omitempty"` // Counting stopped at MaxSummarizedSecrets, the mount holds at least that many
+	Note      string         `json:"note,omitempty"`
+}
+
+// summarizeMount counts the objects stored under a mount. Engines that cannot be
+// inspected are never reported as empty.
+func summarizeMount(vault *api.Client, path string, mount *api.MountOutput) MountSummary {
+	summary := MountSummary{
+		Path:     path,
+		Type:     mount.Type,
+		Contents: map[string]int{},
+	}
+
+	switch mount.Type {
+	case "kv", "generic":
+		secrets, truncated, err := listKVSecrets(vault, path, mount.Options["version"] == "2", MaxSummarizedSecrets)
+		if err != nil {
+			summary.Note = fmt.Sprintf("failed to list secrets: %v", err)
+			return summary
+		}
+		summary.Contents["secrets"] = len(secrets)
+		summary.Truncated = truncated
+	case "pki":
+		for _, kind := range []string{"roles", "issuers", "keys", "certs"} {
+			keys, err := listKeys(vault, fmt.Sprintf("%s/%s", path, kind))
+			if err != nil {
+				summary.Note = fmt.Sprintf("failed to list %s: %v", kind, err)
+				return summary
+			}
+			summary.Contents[kind] = len(keys)
+		}
+	default:
+		summary.Note = fmt.Sprintf("the contents of '%s' mounts cannot be summarized", mount.Type)
+		return summary
+	}
+
+	summary.Empty = true
+	for _, count := range summary.Contents {
+		if count > 0 {
+			summary.Empty = false
+		}
+	}
+
+	return summary
+}
+
+// listKVSecrets walks a KV mount and returns the paths of its secrets relative to the mount. The walk stops
+// after limit secrets, or MaxSummarizedSecrets when limit is not positive, and after utils.MaxListedFolders
+// folders, in which case truncated is true.
+func listKVSecrets(vault *api.Client, mount string, v2 bool, limit int) (secrets []string, truncated bool, err error) {
+	if limit <= 0 {
+		limit = MaxSummarizedSecrets
+	}
+	listPath := mount
+	if v2 {
+		listPath = mount + "/metadata"
+	}
+
+	truncated, err = utils.WalkSecretPaths(vault, listPath, func(path string) (bool, error) {
+		if len(secrets) >= limit {
+			return false, nil
+		}
+		secrets = append(secrets, path)
+		return true, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return secrets, truncated, nil
+}
+
+// listKeys returns the keys of a LIST request, or nothing when the path does not exist
+func listKeys(vault *api.Client, path string) ([]string, error) {
+	secret, err := vault.Logical().List(path)
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, nil
+	}
+
+	rawKeys, _ := secret.Data["keys"].([]interface{})
+	keys := make([]string, 0, len(rawKeys))
+	for _, key := range rawKeys {
+		if keyStr, ok := key.(string); ok {
+			keys = append(keys, keyStr)
+		}
+	}
+
+	return keys, nil
+}
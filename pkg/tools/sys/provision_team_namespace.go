@@ -0,0 +1,461 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// TeamPlaceholder is replaced with the name of the team namespace in the values of a team template
+const TeamPlaceholder = "{{team}}"
+
+// teamNamePattern matches the names of team namespaces, a single path segment
+var teamNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// reservedNamespaceNames are the path prefixes of Vault that cannot be used as namespace names
+var reservedNamespaceNames = []string{"sys", "auth", "cubbyhole", "identity", "root"}
+
+// TeamNamespaceTemplate describes what provision_team_namespace creates for a team. Every resource lives in
+// the team namespace, except the quotas, which are created in the parent namespace so that the team cannot
+// change them.
+type TeamNamespaceTemplate struct {
+	Description    string              `json:"description,omitempty"`     // What the template is for
+	Mounts         []LayoutMount       `json:"mounts,omitempty"`          // Secrets engines to mount in the namespace
+	IntermediateCA *TeamIntermediateCA `json:"intermediate_ca,omitempty"` // Intermediate CA signed by a PKI mount of the parent namespace
+	Policies       []LayoutPolicy      `json:"policies,omitempty"`        // ACL policies to write in the namespace
+	AuthMethods    []LayoutAuthMethod  `json:"auth_methods,omitempty"`    // Auth methods to enable in the namespace
+	AuthRoles      []TeamAuthRole      `json:"auth_roles,omitempty"`      // Roles of the auth methods bound to policies
+	Quotas         *TeamQuotas         `json:"quotas,omitempty"`          // Quotas on the namespace
+}
+
+type TeamIntermediateCA struct {
+	Mount      string          `json:"mount"`           // Mount of the intermediate CA in the namespace
+	CommonName string          `json:"common_name"`     // Common name of the intermediate CA
+	TTL        string          `json:"ttl,omitempty"`   // Lifetime of the intermediate CA
+	Roles      []LayoutPKIRole `json:"roles,omitempty"` // Roles of the intermediate CA
+}
+
+type TeamAuthRole struct {
+	Mount    string                 `json:"mount"`             // Path of the auth method in the namespace
+	Name     string                 `json:"name"`              // Name of the role
+	Policies []string               `json:"policies"`          // Policies attached to the tokens of the role
+	Options  map[string]interface{} `json:"options,omitempty"` // Other parameters of the role, such as bound_claims of a JWT role
+}
+
+type TeamQuotas struct {
+	Rate      float64 `json:"rate,omitempty"`       // Requests allowed per interval in the namespace, 0 for no rate limit quota
+	Interval  string  `json:"interval,omitempty"`   // Interval of the rate limit quota, defaults to 1s
+	MaxLeases int     `json:"max_leases,omitempty"` // Leases allowed in the namespace, 0 for no lease count quota
+}
+
+// TeamNamespaceReport is the outcome of provision_team_namespace
+type TeamNamespaceReport struct {
+	Namespace string `json:"namespace"` // Full path of the team namespace
+	LayoutReport
+}
+
+// teamPolicies are the baseline policies of the built-in templates, giving the team full control of its
+// namespace, developers read and write access to the KV mount and CI read access
+func teamPolicies(pki bool) []LayoutPolicy {
+	issue := ""
+	if pki {
+		issue = `
+path "pki_int/issue/*" {
+  capabilities = ["update"]
+}
+`
+	}
+	return []LayoutPolicy{
+		{Name: "team-admin", Rules: `path "*" {
+  capabilities = ["create", "read", "update", "delete", "list", "sudo"]
+}
+`},
+		{Name: "team-developer", Rules: `path "kv/data/*" {
+  capabilities = ["create", "read", "update", "delete"]
+}
+
+path "kv/metadata/*" {
+  capabilities = ["read", "list", "delete"]
+}
+` + issue},
+		{Name: "team-ci", Rules: `path "kv/data/*" {
+  capabilities = ["read"]
+}
+
+path "kv/metadata/*" {
+  capabilities = ["read", "list"]
+}
+` + issue},
+	}
+}
+
+// TeamNamespaceTemplates are the built-in templates of provision_team_namespace
+var TeamNamespaceTemplates = map[string]TeamNamespaceTemplate{
+	"standard": {
+		Description: "KV v2 mount, intermediate CA signed by the parent namespace, admin, developer and CI policies, an AppRole role for CI and quotas",
+		Mounts:      []LayoutMount{{Path: "kv", Type: "kv", Description: "Secrets of team " + TeamPlaceholder, Options: map[string]string{"version": "2"}}},
+		IntermediateCA: &TeamIntermediateCA{
+			Mount:      "pki_int",
+			CommonName: TeamPlaceholder + " Intermediate CA",
+			TTL:        "43800h",
+			Roles:      []LayoutPKIRole{{Name: "server", AllowedDomains: []string{TeamPlaceholder + ".internal"}, AllowSubdomains: true, MaxTTL: "720h"}},
+		},
+		Policies:    teamPolicies(true),
+		AuthMethods: []LayoutAuthMethod{{Path: "approle", Type: "approle", Description: "CI pipelines of team " + TeamPlaceholder}},
+		AuthRoles:   []TeamAuthRole{{Mount: "approle", Name: "ci", Policies: []string{"team-ci"}, Options: map[string]interface{}{"token_ttl": "1h", "token_max_ttl": "4h"}}},
+		Quotas:      &TeamQuotas{Rate: 100, Interval: "1s", MaxLeases: 1000},
+	},
+	"minimal": {
+		Description: "KV v2 mount with admin, developer and CI policies, without PKI, auth methods or quotas",
+		Mounts:      []LayoutMount{{Path: "kv", Type: "kv", Description: "Secrets of team " + TeamPlaceholder, Options: map[string]string{"version": "2"}}},
+		Policies:    teamPolicies(false),
+	},
+}
+
+// ProvisionTeamNamespace creates a tool provisioning a Vault Enterprise namespace for a new team from a template
+func ProvisionTeamNamespace(logger *log.Logger) server.ServerTool {
+	templates := slices.Sorted(maps.Keys(TeamNamespaceTemplates))
+
+	return server.ServerTool{
+		Tool: mcp.NewTool("provision_team_namespace",
+			mcp.WithDescription("Provision a Vault Enterprise namespace for a new team in one operation: the namespace under the namespace of the tool call, a KV v2 mount, an intermediate CA signed by a PKI mount of the parent namespace, "+
+				"baseline policies, an auth method with a role bound to the policies, and rate limit and lease count quotas on the namespace created in the parent namespace. "+
+				"Uses a built-in template or a supplied one, where {{team}} stands for the name of the namespace. Resources that already exist are left unchanged, steps stop at the first failure and each step is reported. "+
+				"Created resources are recorded as changes of the session, so they can be reverted with undo_last_change. Use dry_run first to preview the plan."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint:    utils.ToBoolPtr(false),
+					DestructiveHint: utils.ToBoolPtr(false),
+					IdempotentHint:  utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the team namespace, a single path segment such as 'payments'."),
+			),
+			mcp.WithString("template",
+				mcp.Enum(templates...),
+				mcp.DefaultString("standard"),
+				mcp.Description("Built-in template to apply. 'standard' creates every resource, 'minimal' only the KV mount and the policies. Ignored when custom_template is set."),
+			),
+			mcp.WithObject("custom_template",
+				mcp.Description("Optional template to apply instead of a built-in one, with the optional lists 'mounts' (path, type, description, options), 'policies' (name, rules), 'auth_methods' (path, type, description), "+
+					"'auth_roles' (mount, name, policies, options) and the optional objects 'intermediate_ca' (mount, common_name, ttl, roles with name, allowed_domains, allow_subdomains and max_ttl) and "+
+					"'quotas' (rate, interval, max_leases). {{team}} in any value is replaced with the name of the namespace."),
+			),
+			mcp.WithString("parent_pki_mount",
+				mcp.DefaultString("pki"),
+				mcp.Description("PKI mount of the parent namespace that signs the intermediate CA of the team. Defaults to 'pki'."),
+			),
+			mcp.WithBoolean("dry_run",
+				mcp.DefaultBool(false),
+				mcp.Description("Only report which resources would be created, without changing Vault."),
+			),
+			schemas.Output("provision_team_namespace"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return provisionTeamNamespaceHandler(ctx, req, logger)
+		},
+	}
+}
+
+func provisionTeamNamespaceHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling provision_team_namespace request")
+
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	name, _ := args["name"].(string)
+	name = strings.Trim(strings.TrimSpace(name), "/")
+	if !teamNamePattern.MatchString(name) || slices.Contains(reservedNamespaceNames, strings.ToLower(name)) {
+		return mcp.NewToolResultError("Missing or invalid 'name' parameter, expected a single path segment of letters, digits, '-' and '_'"), nil
+	}
+
+	templateName, _ := args["template"].(string)
+	if templateName == "" {
+		templateName = "standard"
+	}
+	var template TeamNamespaceTemplate
+	if custom, ok := args["custom_template"].(map[string]interface{}); ok && len(custom) > 0 {
+		data, err := json.Marshal(custom)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid 'custom_template' parameter: %v", err)), nil
+		}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&template); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid 'custom_template' parameter: %v", err)), nil
+		}
+		templateName = "custom"
+	} else if template, ok = TeamNamespaceTemplates[templateName]; !ok {
+		return mcp.NewToolResultError("Missing or invalid 'template' parameter"), nil
+	}
+	template, err := template.forTeam(name)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid template: %v", err)), nil
+	}
+	if err := validateTeamNamespaceTemplate(template); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid template: %v", err)), nil
+	}
+
+	parentPKIMount, _ := args["parent_pki_mount"].(string)
+	parentPKIMount = strings.Trim(parentPKIMount, "/")
+	if parentPKIMount == "" {
+		parentPKIMount = "pki"
+	}
+	dryRun, _ := args["dry_run"].(bool)
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	health, err := vault.Sys().HealthWithContext(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read the health of Vault: %v", err)), nil
+	}
+	if !health.Enterprise {
+		return mcp.NewToolResultError("Namespaces require Vault Enterprise"), nil
+	}
+
+	parent := strings.Trim(vault.Namespace(), "/")
+	namespace := name
+	if parent != "" {
+		namespace = parent + "/" + name
+	}
+
+	logger.WithFields(log.Fields{
+		"namespace": namespace,
+		"template":  templateName,
+		"dry_run":   dryRun,
+	}).Debug("Provisioning team namespace with parameters")
+
+	if template.IntermediateCA != nil {
+		mounts, err := vault.Sys().ListMountsWithContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to list mounts: %v", err)), nil
+		}
+		if mount, ok := mounts[parentPKIMount+"/"]; !ok || mount.Type != "pki" {
+			return mcp.NewToolResultError(fmt.Sprintf("The parent namespace has no PKI mount '%s' to sign the intermediate CA, set 'parent_pki_mount' or use a template without an intermediate CA", parentPKIMount)), nil
+		}
+	}
+
+	// Resources of a namespace that already existed are looked up, a new namespace has none yet
+	existed, err := readExists("sys/namespaces/"+name)(ctx, vault)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to read namespace '%s': %v", namespace, err)), nil
+	}
+	existing := existingLayout{mounts: map[string]*api.MountOutput{}, auths: map[string]*api.AuthMount{}}
+	if existed {
+		teamVault := vault.WithNamespace(namespace)
+		if existing.mounts, err = teamVault.Sys().ListMountsWithContext(ctx); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to list the mounts of namespace '%s': %v", namespace, err)), nil
+		}
+		if existing.auths, err = teamVault.Sys().ListAuthWithContext(ctx); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to list the auth methods of namespace '%s': %v", namespace, err)), nil
+		}
+	}
+
+	steps := teamNamespaceSteps(template, name, namespace, existed, existing, vault, parentPKIMount)
+	report := &TeamNamespaceReport{Namespace: namespace, LayoutReport: LayoutReport{Template: templateName, DryRun: dryRun}}
+	runLayoutSteps(ctx, req, vault, steps, &report.LayoutReport, logger)
+
+	jsonData, err := json.Marshal(report)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal team namespace report to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"namespace": namespace,
+		"template":  templateName,
+		"dry_run":   dryRun,
+		"created":   report.Created,
+		"existing":  report.Existing,
+		"failed":    report.Failed,
+	}).Info("Successfully processed team namespace")
+
+	return mcp.NewToolResultStructured(report, string(jsonData)), nil
+}
+
+// forTeam returns a copy of the template with the placeholder replaced with the name of the team
+func (t TeamNamespaceTemplate) forTeam(name string) (TeamNamespaceTemplate, error) {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return TeamNamespaceTemplate{}, err
+	}
+	var template TeamNamespaceTemplate
+	err = json.Unmarshal(bytes.ReplaceAll(data, []byte(TeamPlaceholder), []byte(name)), &template)
+	return template, err
+}
+
+// validateTeamNamespaceTemplate checks that every resource of a template is named
+func validateTeamNamespaceTemplate(template TeamNamespaceTemplate) error {
+	if err := validateLayoutTemplate(LayoutTemplate{Mounts: template.Mounts, Policies: template.Policies, AuthMethods: template.AuthMethods}); err != nil {
+		return err
+	}
+	if ca := template.IntermediateCA; ca != nil {
+		if ca.Mount == "" || ca.CommonName == "" {
+			return fmt.Errorf("intermediate_ca needs a mount and a common_name")
+		}
+		for _, role := range ca.Roles {
+			if role.Name == "" || len(role.AllowedDomains) == 0 {
+				return fmt.Errorf("pki roles need a name and allowed_domains")
+			}
+		}
+	}
+	for _, role := range template.AuthRoles {
+		if role.Mount == "" || role.Name == "" {
+			return fmt.Errorf("auth roles need a mount and a name")
+		}
+	}
+	if quotas := template.Quotas; quotas != nil && (quotas.Rate < 0 || quotas.MaxLeases < 0) {
+		return fmt.Errorf("quotas cannot be negative")
+	}
+	return nil
+}
+
+// teamNamespaceSteps plans the steps provisioning a team namespace. The namespace and its quotas are created
+// in the namespace of the tool call, every other step runs in the team namespace. Steps in a namespace that
+// does not exist yet plan their resource without looking it up.
+func teamNamespaceSteps(template TeamNamespaceTemplate, name, namespace string, existed bool, existing existingLayout, parent *api.Client, parentPKIMount string) []layoutStep {
+	inNamespace := func(step layoutStep) layoutStep {
+		step.Namespace = namespace
+		return step
+	}
+	pathExists := func(path string) func(context.Context, *api.Client) (bool, error) {
+		if !existed {
+			return staticExists(false)
+		}
+		return readExists(path)
+	}
+
+	steps := []layoutStep{{
+		LayoutStep: LayoutStep{Action: "create_namespace", Path: "sys/namespaces/" + name},
+		exists:     staticExists(existed),
+		apply: func(ctx context.Context, vault *api.Client) (client.ChangeRecord, error) {
+			_, err := vault.Logical().WriteWithContext(ctx, "sys/namespaces/"+name, nil)
+			return client.ChangeRecord{ResourceType: client.ResourceNamespace, Path: "sys/namespaces/" + name, New: map[string]any{"namespace": namespace}}, err
+		},
+	}}
+
+	for _, mount := range template.Mounts {
+		steps = append(steps, inNamespace(mountStep(mount, existing)))
+	}
+
+	if ca := template.IntermediateCA; ca != nil {
+		pki := &LayoutPKI{RootMount: parentPKIMount, IntermediateMount: ca.Mount, IntermediateCommonName: ca.CommonName, IntermediateTTL: ca.TTL}
+		steps = append(steps,
+			inNamespace(mountStep(LayoutMount{Path: ca.Mount, Type: "pki", Description: "Intermediate CA", Options: map[string]string{}}, existing)),
+			inNamespace(layoutStep{
+				LayoutStep: LayoutStep{Action: "generate_intermediate_ca", Path: ca.Mount + "/intermediate/set-signed"},
+				exists:     staticExists(existing.mounts[ca.Mount+"/"] != nil),
+				apply: func(ctx context.Context, vault *api.Client) (client.ChangeRecord, error) {
+					return generateIntermediateCA(ctx, vault, parent, pki)
+				},
+			}),
+		)
+		for _, role := range ca.Roles {
+			path := ca.Mount + "/roles/" + role.Name
+			steps = append(steps, inNamespace(layoutStep{
+				LayoutStep: LayoutStep{Action: "create_pki_role", Path: path},
+				exists:     pathExists(path),
+				apply: func(ctx context.Context, vault *api.Client) (client.ChangeRecord, error) {
+					data := map[string]interface{}{
+						"allowed_domains":  role.AllowedDomains,
+						"allow_subdomains": role.AllowSubdomains,
+					}
+					if role.MaxTTL != "" {
+						data["max_ttl"] = role.MaxTTL
+					}
+					_, err := vault.Logical().WriteWithContext(ctx, path, data)
+					return client.ChangeRecord{ResourceType: client.ResourcePKIRole, Mount: ca.Mount, Path: path, New: map[string]any{"allowed_domains": role.AllowedDomains}}, err
+				},
+			}))
+		}
+	}
+
+	for _, policy := range template.Policies {
+		path := "sys/policies/acl/" + policy.Name
+		steps = append(steps, inNamespace(layoutStep{
+			LayoutStep: LayoutStep{Action: "write_policy", Path: path},
+			exists:     pathExists(path),
+			apply: func(ctx context.Context, vault *api.Client) (client.ChangeRecord, error) {
+				err := vault.Sys().PutPolicyWithContext(ctx, policy.Name, policy.Rules)
+				return client.ChangeRecord{ResourceType: client.ResourcePolicy, Path: path, New: map[string]any{"name": policy.Name}}, err
+			},
+		}))
+	}
+
+	for _, method := range template.AuthMethods {
+		steps = append(steps, inNamespace(layoutStep{
+			LayoutStep: LayoutStep{Action: "enable_auth_method", Path: "sys/auth/" + method.Path},
+			exists:     staticExists(existing.auths[method.Path+"/"] != nil),
+			apply: func(ctx context.Context, vault *api.Client) (client.ChangeRecord, error) {
+				err := vault.Sys().EnableAuthWithOptionsWithContext(ctx, method.Path, &api.EnableAuthOptions{Type: method.Type, Description: method.Description})
+				return client.ChangeRecord{ResourceType: client.ResourceAuthMethod, Mount: method.Path, Path: "sys/auth/" + method.Path, New: map[string]any{"type": method.Type}}, err
+			},
+		}))
+	}
+
+	for _, role := range template.AuthRoles {
+		path := "auth/" + role.Mount + "/role/" + role.Name
+		steps = append(steps, inNamespace(layoutStep{
+			LayoutStep: LayoutStep{Action: "create_auth_role", Path: path},
+			exists:     pathExists(path),
+			apply: func(ctx context.Context, vault *api.Client) (client.ChangeRecord, error) {
+				data := make(map[string]interface{}, len(role.Options)+1)
+				maps.Copy(data, role.Options)
+				data["token_policies"] = role.Policies
+				_, err := vault.Logical().WriteWithContext(ctx, path, data)
+				return client.ChangeRecord{ResourceType: client.ResourceAuthRole, Mount: role.Mount, Path: path, New: map[string]any{"policies": role.Policies}}, err
+			},
+		}))
+	}
+
+	// Quotas are named after the team and apply to the whole namespace
+	if quotas := template.Quotas; quotas != nil {
+		if quotas.Rate > 0 {
+			data := map[string]interface{}{"path": name + "/", "rate": quotas.Rate, "interval": defaultString(quotas.Interval, "1s")}
+			steps = append(steps, quotaStep("rate-limit", name, data))
+		}
+		if quotas.MaxLeases > 0 {
+			steps = append(steps, quotaStep("lease-count", name, map[string]interface{}{"path": name + "/", "max_leases": quotas.MaxLeases}))
+		}
+	}
+
+	return steps
+}
+
+// quotaStep plans the step creating a quota
+func quotaStep(quotaType, name string, data map[string]interface{}) layoutStep {
+	path := "sys/quotas/" + quotaType + "/" + name
+	return layoutStep{
+		LayoutStep: LayoutStep{Action: "create_quota", Path: path},
+		exists:     readExists(path),
+		apply: func(ctx context.Context, vault *api.Client) (client.ChangeRecord, error) {
+			_, err := vault.Logical().WriteWithContext(ctx, path, data)
+			return client.ChangeRecord{ResourceType: client.ResourceQuota, Path: path, New: maps.Clone(data)}, err
+		},
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// namespaceVault is a mock Vault Enterprise keeping the namespaces, mounts, auth methods and configuration
+// paths written to it by namespace
+type namespaceVault struct {
+	mu         sync.Mutex
+	oss        bool
+	namespaces map[string]bool
+	mounts     map[string]map[string]interface{}
+	auths      map[string]map[string]interface{}
+	paths      map[string]map[string]interface{} // Bodies of the written paths, keyed by namespace and path
+	writes     []string                          // Namespace and path of each write
+}
+
+func newNamespaceVault() *namespaceVault {
+	return &namespaceVault{
+		namespaces: map[string]bool{"admin": true},
+		mounts:     map[string]map[string]interface{}{"admin": {"pki/": map[string]interface{}{"type": "pki"}}},
+		auths:      map[string]map[string]interface{}{},
+		paths:      map[string]map[string]interface{}{},
+	}
+}
+
+func newTeamNamespaceVault(t *testing.T, nv *namespaceVault) (context.Context, func()) {
+	t.Helper()
+
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nv.mu.Lock()
+		defer nv.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		namespace := strings.Trim(r.Header.Get("X-Vault-Namespace"), "/")
+		path := strings.TrimPrefix(r.URL.Path, "/v1/")
+		data := func(value interface{}) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": value})
+		}
+
+		if r.Method == http.MethodGet {
+			switch {
+			case path == "sys/health":
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"initialized": true, "enterprise": !nv.oss})
+			case path == "sys/mounts":
+				data(nv.mounts[namespace])
+			case path == "sys/auth":
+				data(nv.auths[namespace])
+			case strings.HasPrefix(path, "sys/namespaces/") && nv.namespaces[joinNamespace(namespace, strings.TrimPrefix(path, "sys/namespaces/"))]:
+				data(map[string]interface{}{"path": path})
+			case nv.paths[namespace+":"+path] != nil:
+				data(nv.paths[namespace+":"+path])
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"errors":[]}`))
+			}
+			return
+		}
+
+		nv.writes = append(nv.writes, namespace+":"+path)
+		body := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		switch {
+		case strings.HasPrefix(path, "sys/namespaces/"):
+			nv.namespaces[joinNamespace(namespace, strings.TrimPrefix(path, "sys/namespaces/"))] = true
+		case strings.HasPrefix(path, "sys/mounts/") && !strings.HasSuffix(path, "/tune"):
+			if nv.mounts[namespace] == nil {
+				nv.mounts[namespace] = map[string]interface{}{}
+			}
+			nv.mounts[namespace][strings.TrimPrefix(path, "sys/mounts/")+"/"] = map[string]interface{}{"type": body["type"]}
+		case strings.HasPrefix(path, "sys/auth/"):
+			if nv.auths[namespace] == nil {
+				nv.auths[namespace] = map[string]interface{}{}
+			}
+			nv.auths[namespace][strings.TrimPrefix(path, "sys/auth/")+"/"] = map[string]interface{}{"type": body["type"]}
+		case strings.HasSuffix(path, "/intermediate/generate/internal"):
+			data(map[string]interface{}{"csr": "-----BEGIN CERTIFICATE REQUEST-----"})
+			return
+		case strings.HasSuffix(path, "/root/sign-intermediate"):
+			data(map[string]interface{}{"certificate": "-----BEGIN CERTIFICATE-----"})
+			return
+		case strings.HasSuffix(path, "/intermediate/set-signed"):
+			data(map[string]interface{}{"imported_issuers": []string{"c2d4"}})
+			return
+		default:
+			nv.paths[namespace+":"+path] = body
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	sessionID := "test-" + t.Name()
+	_, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "admin")
+	require.NoError(t, err)
+
+	ctx := server.NewMCPServer("test", "1.0").WithContext(context.Background(), testSession{id: sessionID})
+
+	return ctx, func() {
+		mockVault.Close()
+		client.DeleteVaultClient(sessionID)
+	}
+}
+
+func joinNamespace(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "/" + name
+}
+
+func callProvisionTeamNamespace(t *testing.T, ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, TeamNamespaceReport) {
+	t.Helper()
+	logger := log.New()
+	logger.SetLevel(log.PanicLevel)
+
+	result, err := ProvisionTeamNamespace(logger).Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{
+		Name:      "provision_team_namespace",
+		Arguments: args,
+	}})
+	require.NoError(t, err)
+
+	var report TeamNamespaceReport
+	if !result.IsError {
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &report))
+	}
+	return result, report
+}
+
+func TestProvisionTeamNamespace(t *testing.T) {
+	t.Run("dry run previews a new namespace without writing", func(t *testing.T) {
+		nv := newNamespaceVault()
+		ctx, cleanup := newTeamNamespaceVault(t, nv)
+		defer cleanup()
+
+		result, report := callProvisionTeamNamespace(t, ctx, map[string]interface{}{"name": "payments", "dry_run": true})
+		require.False(t, result.IsError, result.Content)
+		assert.Equal(t, "admin/payments", report.Namespace)
+		assert.Equal(t, "standard", report.Template)
+		assert.True(t, report.DryRun)
+		assert.Empty(t, nv.writes)
+		assert.Equal(t, len(report.Steps), report.Created)
+		for _, step := range report.Steps {
+			assert.Equal(t, LayoutStepPlanned, step.Status, step.Path)
+		}
+	})
+
+	t.Run("provisions the namespace and is idempotent", func(t *testing.T) {
+		nv := newNamespaceVault()
+		ctx, cleanup := newTeamNamespaceVault(t, nv)
+		defer cleanup()
+
+		result, report := callProvisionTeamNamespace(t, ctx, map[string]interface{}{"name": "payments"})
+		require.False(t, result.IsError, result.Content)
+		assert.False(t, report.Failed)
+		assert.Equal(t, len(report.Steps), report.Created)
+
+		var actions []string
+		for _, step := range report.Steps {
+			assert.Equal(t, LayoutStepCreated, step.Status, step.Path)
+			actions = append(actions, step.Action)
+		}
+		assert.Equal(t, []string{
+			"create_namespace", "create_mount", "create_mount", "generate_intermediate_ca", "create_pki_role",
+			"write_policy", "write_policy", "write_policy", "enable_auth_method", "create_auth_role", "create_quota", "create_quota",
+		}, actions)
+		assert.Equal(t, "", report.Steps[0].Namespace, "the namespace is created in the parent namespace")
+		assert.Equal(t, "admin/payments", report.Steps[1].Namespace)
+		assert.Equal(t, "", report.Steps[len(report.Steps)-1].Namespace, "quotas are created in the parent namespace")
+
+		assert.True(t, nv.namespaces["admin/payments"])
+		assert.Contains(t, nv.writes, "admin:pki/root/sign-intermediate", "the parent namespace signs the intermediate CA")
+		assert.Contains(t, nv.writes, "admin/payments:pki_int/issuer/c2d4")
+		assert.Equal(t, []interface{}{"payments.internal"}, nv.paths["admin/payments:pki_int/roles/server"]["allowed_domains"])
+		assert.Equal(t, []interface{}{"team-ci"}, nv.paths["admin/payments:auth/approle/role/ci"]["token_policies"])
+		assert.Equal(t, "1h", nv.paths["admin/payments:auth/approle/role/ci"]["token_ttl"])
+		assert.Equal(t, "payments/", nv.paths["admin:sys/quotas/lease-count/payments"]["path"])
+
+		// Changes are recorded in the namespace they were made in, so that undo_last_change reverts them there
+		changes := client.SessionChanges("test-" + t.Name())
+		require.Len(t, changes, len(report.Steps))
+		assert.Equal(t, client.ResourceNamespace, changes[0].ResourceType)
+		assert.Equal(t, "admin", changes[0].Namespace)
+		assert.Equal(t, "admin/payments", changes[1].Namespace)
+		assert.Equal(t, client.ResourceAuthRole, changes[9].ResourceType)
+		assert.Equal(t, client.ResourceQuota, changes[len(changes)-1].ResourceType)
+		assert.Equal(t, "admin", changes[len(changes)-1].Namespace)
+
+		writes := len(nv.writes)
+		_, report = callProvisionTeamNamespace(t, ctx, map[string]interface{}{"name": "payments"})
+		assert.Equal(t, 0, report.Created)
+		assert.Equal(t, len(report.Steps), report.Existing)
+		assert.Len(t, nv.writes, writes, "a second run changes nothing")
+	})
+
+	t.Run("custom template with the team placeholder", func(t *testing.T) {
+		nv := newNamespaceVault()
+		ctx, cleanup := newTeamNamespaceVault(t, nv)
+		defer cleanup()
+
+		result, report := callProvisionTeamNamespace(t, ctx, map[string]interface{}{"name": "search", "custom_template": map[string]interface{}{
+			"policies": []interface{}{map[string]interface{}{"name": "{{team}}-reader", "rules": `path "kv/data/{{team}}/*" { capabilities = ["read"] }`}},
+			"quotas":   map[string]interface{}{"max_leases": 50},
+		}})
+		require.False(t, result.IsError, result.Content)
+		assert.Equal(t, "custom", report.Template)
+		assert.Equal(t, []string{"admin:sys/namespaces/search", "admin/search:sys/policies/acl/search-reader", "admin:sys/quotas/lease-count/search"}, nv.writes)
+		assert.Contains(t, nv.paths["admin/search:sys/policies/acl/search-reader"]["policy"], "kv/data/search/*")
+		assert.Equal(t, "{{team}} Intermediate CA", TeamNamespaceTemplates["standard"].IntermediateCA.CommonName, "the built-in template is not modified")
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		nv := newNamespaceVault()
+		ctx, cleanup := newTeamNamespaceVault(t, nv)
+		defer cleanup()
+
+		for name, args := range map[string]map[string]interface{}{
+			"missing name":       {},
+			"nested name":        {"name": "team/a"},
+			"reserved name":      {"name": "sys"},
+			"unknown template":   {"name": "a", "template": "large"},
+			"unknown field":      {"name": "a", "custom_template": map[string]interface{}{"secrets": []interface{}{}}},
+			"unnamed auth role":  {"name": "a", "custom_template": map[string]interface{}{"auth_roles": []interface{}{map[string]interface{}{"mount": "approle"}}}},
+			"missing parent pki": {"name": "a", "parent_pki_mount": "pki_root"},
+		} {
+			result, _ := callProvisionTeamNamespace(t, ctx, args)
+			assert.True(t, result.IsError, name)
+		}
+		assert.Empty(t, nv.writes)
+	})
+
+	t.Run("namespaces require Vault Enterprise", func(t *testing.T) {
+		nv := newNamespaceVault()
+		nv.oss = true
+		ctx, cleanup := newTeamNamespaceVault(t, nv)
+		defer cleanup()
+
+		result, _ := callProvisionTeamNamespace(t, ctx, map[string]interface{}{"name": "payments", "template": "minimal"})
+		require.True(t, result.IsError)
+		assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "Vault Enterprise")
+	})
+}
@@ -0,0 +1,94 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// managedKeySecretFields are configuration fields of managed keys holding credentials, which are never returned
+var managedKeySecretFields = []string{"pin", "access_key", "secret_key", "session_token", "client_secret", "credentials"}
+
+// ReadManagedKey creates a tool for reading the configuration of a managed key
+func ReadManagedKey(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("read_managed_key",
+			mcp.WithDescription("Read the configuration of a managed key, such as its HSM library and slot or its KMS key, algorithm and allowed usages. Credentials are not returned."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithString("type",
+				mcp.Required(),
+				mcp.Enum("pkcs11", "awskms", "azurekeyvault", "gcpckms"),
+				mcp.Description("The type of the managed key."),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("The name of the managed key."),
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return readManagedKeyHandler(ctx, req, logger)
+		},
+	}
+}
+
+func readManagedKeyHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling read_managed_key request")
+
+	keyType, err := req.RequireString("type")
+	if err != nil || !slices.Contains(ManagedKeyTypes, keyType) {
+		return mcp.NewToolResultError("Missing or invalid 'type' parameter"), nil
+	}
+
+	name, err := req.RequireString("name")
+	if err != nil || name == "" {
+		return mcp.NewToolResultError("Missing or invalid 'name' parameter"), nil
+	}
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	fullPath := fmt.Sprintf("sys/managed-keys/%s/%s", keyType, name)
+
+	secret, err := vault.Logical().Read(fullPath)
+	if err != nil {
+		logger.WithError(err).WithField("name", name).Error("Failed to read managed key")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read managed key '%s': %v", name, err)), nil
+	}
+	if secret == nil || secret.Data == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Managed key '%s' of type '%s' does not exist, use 'list_managed_keys' to find it", name, keyType)), nil
+	}
+
+	for _, field := range managedKeySecretFields {
+		delete(secret.Data, field)
+	}
+
+	jsonData, err := json.Marshal(secret.Data)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal managed key to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"type": keyType,
+		"name": name,
+	}).Debug("Successfully read managed key")
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
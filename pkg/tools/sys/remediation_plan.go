@@ -0,0 +1,63 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxPlanTokens is the maximum length of a remediation plan drafted by the model of the client
+const maxPlanTokens = 2000
+
+const remediationPlanPrompt = "You are a HashiCorp Vault operator. Draft a short, prioritized remediation plan for the findings below. " +
+	"Address critical and high severity findings first, group related findings, and give concrete steps or Vault commands. " +
+	"Do not invent findings."
+
+// RemediationPlan is a plan for the findings of an analysis, drafted by the model of the client through sampling
+type RemediationPlan struct {
+	Plan  string `json:"plan,omitempty"`
+	Model string `json:"model,omitempty"` // Model that drafted the plan, as reported by the client
+	Error string `json:"error,omitempty"` // Why no plan could be drafted
+}
+
+// withGeneratePlan adds the generate_plan parameter to analysis tools
+func withGeneratePlan() mcp.ToolOption {
+	return mcp.WithBoolean("generate_plan",
+		mcp.DefaultBool(false),
+		mcp.Description("Ask the model of the client, through MCP sampling, to draft a remediation plan for the findings and include it in the result. The client must support sampling."),
+	)
+}
+
+// draftRemediationPlan asks the model of the client to draft a plan for the findings of an analysis
+func draftRemediationPlan(ctx context.Context, analysis string, findings []*Finding) *RemediationPlan {
+	if len(findings) == 0 {
+		return &RemediationPlan{Plan: "No findings, nothing to remediate."}
+	}
+
+	var prompt strings.Builder
+	fmt.Fprintf(&prompt, "Findings of the %s:\n", analysis)
+	for _, finding := range findings {
+		fmt.Fprintf(&prompt, "- [%s] %s: %s", finding.Severity, finding.Component, finding.Message)
+		if finding.Recommendation != "" {
+			fmt.Fprintf(&prompt, " (recommendation: %s)", finding.Recommendation)
+		}
+		prompt.WriteString("\n")
+	}
+
+	plan, model, err := utils.RequestCompletion(ctx, remediationPlanPrompt, prompt.String(), maxPlanTokens)
+	if errors.Is(err, utils.ErrSamplingUnavailable) {
+		return &RemediationPlan{Error: "the client does not support sampling, no remediation plan was drafted"}
+	}
+	if err != nil {
+		return &RemediationPlan{Error: fmt.Sprintf("failed to draft a remediation plan: %v", err)}
+	}
+
+	return &RemediationPlan{Plan: plan, Model: model}
+}
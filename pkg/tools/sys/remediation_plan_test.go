@@ -0,0 +1,60 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// samplingSession is a session whose client answers sampling requests with a fixed plan
+type samplingSession struct {
+	testSession
+	prompt string
+}
+
+func (s *samplingSession) GetClientInfo() mcp.Implementation            { return mcp.Implementation{} }
+func (s *samplingSession) SetClientInfo(mcp.Implementation)             {}
+func (s *samplingSession) SetClientCapabilities(mcp.ClientCapabilities) {}
+func (s *samplingSession) GetClientCapabilities() mcp.ClientCapabilities {
+	return mcp.ClientCapabilities{Sampling: &struct{}{}}
+}
+
+func (s *samplingSession) RequestSampling(_ context.Context, request mcp.CreateMessageRequest) (*mcp.CreateMessageResult, error) {
+	s.prompt = mcp.GetTextFromContent(request.Messages[0].Content)
+	return &mcp.CreateMessageResult{
+		SamplingMessage: mcp.SamplingMessage{Role: mcp.RoleAssistant, Content: mcp.NewTextContent("1. Enable an audit device")},
+		Model:           "test-model",
+	}, nil
+}
+
+func TestDraftRemediationPlan(t *testing.T) {
+	findings := []*Finding{
+		{Severity: SeverityCritical, Component: "audit", Message: "No audit devices are enabled", Recommendation: "Enable an audit device"},
+	}
+
+	t.Run("plan is drafted by the client", func(t *testing.T) {
+		session := &samplingSession{testSession: testSession{id: "sampling"}}
+		ctx := server.NewMCPServer("test", "1.0").WithContext(context.Background(), session)
+
+		plan := draftRemediationPlan(ctx, "security health analysis", findings)
+		require.Empty(t, plan.Error)
+		assert.Equal(t, "1. Enable an audit device", plan.Plan)
+		assert.Equal(t, "test-model", plan.Model)
+		assert.Contains(t, session.prompt, "[critical] audit: No audit devices are enabled")
+	})
+
+	t.Run("clients without sampling get no plan", func(t *testing.T) {
+		ctx := server.NewMCPServer("test", "1.0").WithContext(context.Background(), testSession{id: "no-sampling"})
+
+		plan := draftRemediationPlan(ctx, "security health analysis", findings)
+		assert.Empty(t, plan.Plan)
+		assert.Contains(t, plan.Error, "does not support sampling")
+	})
+}
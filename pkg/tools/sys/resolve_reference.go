@@ -0,0 +1,196 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// ReferenceAllowlistEnv lists the secret paths whose references resolve_reference may resolve, as a
+// comma-separated list of patterns, e.g. "secret/app/**,database/static-creds/*"
+const ReferenceAllowlistEnv = "MCP_RESOLVE_REFERENCE_ALLOWLIST"
+
+// ReferenceAllowlist holds the patterns of the secret paths resolve_reference may resolve, where '*' matches
+// within one segment and '**' matches any number of segments
+type ReferenceAllowlist []string
+
+// LoadReferenceAllowlistFromEnv loads the paths resolve_reference may resolve from MCP_RESOLVE_REFERENCE_ALLOWLIST.
+// Patterns must name their mount, so that '**' cannot grant every secret. Invalid entries are ignored with a
+// warning.
+func LoadReferenceAllowlistFromEnv() ReferenceAllowlist {
+	var allowlist ReferenceAllowlist
+	for _, entry := range strings.Split(os.Getenv(ReferenceAllowlistEnv), ",") {
+		pattern := strings.Trim(strings.TrimSpace(entry), "/")
+		if pattern == "" {
+			continue
+		}
+		segments := strings.Split(pattern, "/")
+		if len(segments) < 2 || strings.ContainsAny(segments[0], "*?[") || segments[0] == "sys" || segments[0] == "auth" {
+			log.Warnf("Invalid %s entry '%s', expected a path pattern naming a secrets engine mount, such as 'secret/app/**'", ReferenceAllowlistEnv, entry)
+			continue
+		}
+		if _, err := cleanRawPath(pattern); err != nil {
+			log.Warnf("Invalid %s entry '%s': %v", ReferenceAllowlistEnv, entry, err)
+			continue
+		}
+		allowlist = append(allowlist, pattern)
+	}
+	if len(allowlist) > 0 {
+		log.Infof("resolve_reference is enabled for %d path patterns", len(allowlist))
+	}
+	return allowlist
+}
+
+// Allows reports whether references to the secret path may be resolved
+func (a ReferenceAllowlist) Allows(path string) bool {
+	segments := strings.Split(path, "/")
+	for _, pattern := range a {
+		if matchSegments(strings.Split(pattern, "/"), segments) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolvedReference is the value a secret reference points to
+type ResolvedReference struct {
+	Reference string `json:"reference"`
+	Value     any    `json:"value"`
+	Version   int    `json:"version,omitempty"` // Version of the KV v2 secret the value was read from
+}
+
+// ResolveReference creates a tool for resolving the vault:// references returned by read_secret and
+// generate_redis_credentials into their values, limited to the allowlisted paths
+func ResolveReference(allowlist ReferenceAllowlist, logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("resolve_reference",
+			mcp.WithDescription(fmt.Sprintf(`Resolve a vault://mount/path#key reference, as returned by read_secret or generate_redis_credentials with output 'reference', into the value it points to. Only resolve references when the value itself is needed, most workflows only need to pass the reference on.
+References to KV secrets and to the static credentials of database mounts can be resolved. Allowed paths, where '*' matches within one path segment and '**' any number of segments: %s.`, strings.Join(allowlist, ", "))),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithString("reference",
+				mcp.Required(),
+				mcp.Description("The reference, such as 'vault://secret/app/db#password'. Append '?version=3' before the '#' to read a version of a KV v2 secret other than the current one."),
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return resolveReferenceHandler(ctx, req, allowlist, logger)
+		},
+	}
+}
+
+func resolveReferenceHandler(ctx context.Context, req mcp.CallToolRequest, allowlist ReferenceAllowlist, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling resolve_reference request")
+
+	reference, err := utils.ParseSecretReference(req.GetString("reference", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if !allowlist.Allows(reference.Path) {
+		return mcp.NewToolResultError(fmt.Sprintf("references to '%s' cannot be resolved, allowed paths: %s", reference.Path, strings.Join(allowlist, ", "))), nil
+	}
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list mounts: %v", err)), nil
+	}
+	mountPath, mount := referenceMount(mounts, reference.Path)
+	if mount == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("no mount serves the path '%s'", reference.Path)), nil
+	}
+	relativePath := strings.TrimPrefix(reference.Path, mountPath+"/")
+
+	resolved := ResolvedReference{Reference: reference.String()}
+	var data map[string]interface{}
+	switch {
+	case mount.Type == "kv" && mount.Options["version"] == "2":
+		var query map[string][]string
+		if reference.Version > 0 {
+			query = map[string][]string{"version": {strconv.Itoa(reference.Version)}}
+		}
+		secret, err := vault.Logical().ReadWithData(mountPath+"/data/"+relativePath, query)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to read '%s': %v", reference.Path, err)), nil
+		}
+		if secret != nil {
+			data, _ = secret.Data["data"].(map[string]interface{})
+			if metadata, ok := secret.Data["metadata"].(map[string]interface{}); ok {
+				if version, ok := metadata["version"].(json.Number); ok {
+					value, _ := version.Int64()
+					resolved.Version = int(value)
+				}
+			}
+		}
+	case reference.Version > 0:
+		return mcp.NewToolResultError(fmt.Sprintf("'%s' is not a KV v2 secret, its reference cannot have a version", reference.Path)), nil
+	case mount.Type == "kv" || mount.Type == "generic" || (mount.Type == "database" && strings.HasPrefix(relativePath, "static-creds/")):
+		secret, err := vault.Logical().Read(reference.Path)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to read '%s': %v", reference.Path, err)), nil
+		}
+		if secret != nil {
+			data = secret.Data
+		}
+	default:
+		// Reading other engines can create credentials or leases, which a reference must not do
+		return mcp.NewToolResultError(fmt.Sprintf("references to '%s' cannot be resolved, only KV secrets and the static credentials of database mounts can", reference.Path)), nil
+	}
+
+	if data == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("no secret found at '%s'", reference.Path)), nil
+	}
+	value, ok := data[reference.Key]
+	if !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("the secret at '%s' has no key '%s'", reference.Path, reference.Key)), nil
+	}
+	resolved.Value = value
+
+	jsonData, err := utils.MarshalJSON(resolved)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal resolved reference to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"path": reference.Path,
+		"key":  reference.Key,
+	}).Info("Resolved secret reference")
+
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// referenceMount returns the mount serving a path, the most specific one when mounts are nested
+func referenceMount(mounts map[string]*api.MountOutput, path string) (string, *api.MountOutput) {
+	var mountPath string
+	var mount *api.MountOutput
+	for candidate, output := range mounts {
+		candidate = strings.TrimSuffix(candidate, "/")
+		if strings.HasPrefix(path, candidate+"/") && len(candidate) > len(mountPath) {
+			mountPath, mount = candidate, output
+		}
+	}
+	return mountPath, mount
+}
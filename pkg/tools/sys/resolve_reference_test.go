@@ -0,0 +1,136 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadReferenceAllowlistFromEnv(t *testing.T) {
+	t.Setenv(ReferenceAllowlistEnv, "secret/app/**, database/static-creds/*, **, */app, sys/config/*, secret/../sys, secret")
+	allowlist := LoadReferenceAllowlistFromEnv()
+	assert.Equal(t, ReferenceAllowlist{"secret/app/**", "database/static-creds/*"}, allowlist)
+
+	assert.True(t, allowlist.Allows("secret/app/db"))
+	assert.True(t, allowlist.Allows("database/static-creds/cache"))
+	assert.False(t, allowlist.Allows("secret/other/db"))
+	assert.False(t, allowlist.Allows("database/creds/cache"))
+}
+
+func TestResolveReference(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	var requests []string
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		path := strings.TrimSuffix(r.URL.Path, "/")
+		requests = append(requests, path+"?"+r.URL.RawQuery)
+		switch path {
+		case "/v1/sys/mounts":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"secret/":      map[string]interface{}{"type": "kv", "options": map[string]interface{}{"version": "2"}},
+				"secret/team/": map[string]interface{}{"type": "kv", "options": map[string]interface{}{"version": "1"}},
+				"database/":    map[string]interface{}{"type": "database"},
+				"transit/":     map[string]interface{}{"type": "transit"},
+				"sys/":         map[string]interface{}{"type": "system"},
+				"cubbyhole/":   map[string]interface{}{"type": "cubbyhole"},
+			}})
+		case "/v1/secret/data/app/db":
+			version := 4
+			password := "hunter2"
+			if r.URL.Query().Get("version") == "3" {
+				version, password = 3, "hunter1"
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"data":     map[string]interface{}{"password": password},
+				"metadata": map[string]interface{}{"version": version},
+			}})
+		case "/v1/secret/team/app/db":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"password": "legacy"}})
+		case "/v1/database/static-creds/cache":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"username": "cache", "password": "r0tated"}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockVault.Close()
+
+	sessionID := "test-" + t.Name()
+	_, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	defer client.DeleteVaultClient(sessionID)
+	ctx := server.NewMCPServer("test", "1.0").WithContext(context.Background(), testSession{id: sessionID})
+
+	tool := ResolveReference(ReferenceAllowlist{"secret/**", "database/static-creds/*", "database/creds/*", "transit/**"}, logger)
+	assert.True(t, *tool.Tool.Annotations.ReadOnlyHint)
+	resolve := func(reference string) (ResolvedReference, *mcp.CallToolResult) {
+		result, err := tool.Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "resolve_reference", Arguments: map[string]interface{}{"reference": reference}}})
+		require.NoError(t, err)
+		var resolved ResolvedReference
+		if !result.IsError {
+			require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resolved))
+		}
+		return resolved, result
+	}
+
+	t.Run("KV v2 secrets", func(t *testing.T) {
+		resolved, result := resolve("vault://secret/app/db#password")
+		require.False(t, result.IsError, result.Content)
+		assert.Equal(t, ResolvedReference{Reference: "vault://secret/app/db#password", Value: "hunter2", Version: 4}, resolved)
+
+		resolved, result = resolve("vault://secret/app/db?version=3#password")
+		require.False(t, result.IsError, result.Content)
+		assert.Equal(t, "hunter1", resolved.Value)
+		assert.Equal(t, 3, resolved.Version)
+	})
+
+	t.Run("nested KV v1 mounts and static credentials", func(t *testing.T) {
+		resolved, result := resolve("vault://secret/team/app/db#password")
+		require.False(t, result.IsError, result.Content)
+		assert.Equal(t, "legacy", resolved.Value)
+
+		resolved, result = resolve("vault://database/static-creds/cache#password")
+		require.False(t, result.IsError, result.Content)
+		assert.Equal(t, "r0tated", resolved.Value)
+	})
+
+	t.Run("refused references", func(t *testing.T) {
+		before := len(requests)
+		for name, reference := range map[string]string{
+			"not a reference":  "secret/app/db#password",
+			"path not allowed": "vault://cubbyhole/app#password",
+		} {
+			_, result := resolve(reference)
+			assert.True(t, result.IsError, name)
+		}
+		assert.Len(t, requests, before, "refused references never reach Vault")
+
+		for name, reference := range map[string]string{
+			"dynamic credentials":    "vault://database/creds/cache#password",
+			"other engines":          "vault://transit/keys/app#name",
+			"version of a KV v1 key": "vault://secret/team/app/db?version=2#password",
+			"missing key":            "vault://secret/app/db#username",
+			"missing secret":         "vault://secret/app/other#password",
+		} {
+			_, result := resolve(reference)
+			assert.True(t, result.IsError, name)
+		}
+		for _, request := range requests {
+			assert.NotContains(t, request, "/v1/database/creds/", "dynamic credentials are never created")
+		}
+	})
+}
@@ -0,0 +1,191 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// TidyTargets are the storage areas that run_tidy can clean up
+var TidyTargets = []string{"pki", "token", "leases"}
+
+// DefaultTidyTimeoutSeconds is how long run_tidy waits for a PKI tidy by default, shorter than the write timeout
+// of the HTTP transports so that the result is returned before the response is cut off
+const DefaultTidyTimeoutSeconds = 20
+
+// tidyPollInterval is how often the status of a running PKI tidy is read
+var tidyPollInterval = 2 * time.Second
+
+// TidyResult describes the outcome of a tidy operation
+type TidyResult struct {
+	Target string                 `json:"target"`
+	Path   string                 `json:"path"`
+	State  string                 `json:"state"`            // Finished, Running, Started or Error
+	Status map[string]interface{} `json:"status,omitempty"` // Last tidy status reported by Vault, only available for PKI
+	Error  string                 `json:"error,omitempty"`
+}
+
+// RunTidy creates a tool for triggering and monitoring tidy operations
+func RunTidy(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("run_tidy",
+			mcp.WithDescription("Trigger tidy operations that remove expired and revoked entries from Vault storage: PKI certificates and issuers, the token store of the token auth method, and leases. The PKI tidy is monitored until it finishes and progress is reported through progress notifications. Token and lease tidies run in the background in Vault, which reports no status for them."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					DestructiveHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithArray("targets",
+				mcp.Required(),
+				mcp.WithStringEnumItems(TidyTargets),
+				mcp.Description("The tidy operations to run: 'pki', 'token' and/or 'leases'."),
+			),
+			mcp.WithString("pki_mount",
+				mcp.DefaultString("pki"),
+				mcp.Description("The mount path of the PKI engine to tidy."),
+			),
+			mcp.WithString("safety_buffer",
+				mcp.DefaultString(""),
+				mcp.Description("Optional duration that expired PKI certificates are kept after their expiry, for example '72h'. Defaults to the setting of the mount."),
+			),
+			mcp.WithNumber("timeout_seconds",
+				mcp.DefaultNumber(DefaultTidyTimeoutSeconds),
+				mcp.Description("How long to wait for the PKI tidy to finish before reporting it as still running, its status can then be followed at '<pki_mount>/tidy-status'. Over HTTP, keep it below the write timeout of the server (30 seconds unless tool timeouts are configured)."),
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return runTidyHandler(ctx, req, logger)
+		},
+	}
+}
+
+func runTidyHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling run_tidy request")
+
+	targets, err := req.RequireStringSlice("targets")
+	if err != nil || len(targets) == 0 {
+		return mcp.NewToolResultError("Missing or invalid 'targets' parameter"), nil
+	}
+	for _, target := range targets {
+		if !slices.Contains(TidyTargets, target) {
+			return mcp.NewToolResultError(fmt.Sprintf("Unsupported tidy target '%s', supported targets are %v", target, TidyTargets)), nil
+		}
+	}
+
+	pkiMount := req.GetString("pki_mount", "pki")
+	safetyBuffer := req.GetString("safety_buffer", "")
+	timeout := time.Duration(req.GetInt("timeout_seconds", DefaultTidyTimeoutSeconds)) * time.Second
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	total := float64(len(targets))
+	results := make([]*TidyResult, 0, len(targets))
+	for i, target := range targets {
+		_ = utils.NotifyProgress(ctx, req, float64(i), total, fmt.Sprintf("Starting %s tidy", target))
+
+		var result *TidyResult
+		switch target {
+		case "pki":
+			result = runPKITidy(ctx, req, vault, pkiMount, safetyBuffer, timeout, float64(i), total)
+		case "token":
+			result = startTidy(vault, target, "auth/token/tidy", nil)
+		case "leases":
+			result = startTidy(vault, target, "sys/leases/tidy", nil)
+		}
+		results = append(results, result)
+
+		logger.WithFields(log.Fields{
+			"target": target,
+			"state":  result.State,
+		}).Info("Tidy operation completed")
+		_ = utils.NotifyProgress(ctx, req, float64(i+1), total, fmt.Sprintf("%s tidy: %s", target, result.State))
+	}
+
+	jsonData, err := json.Marshal(results)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal tidy results to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+// startTidy triggers a tidy operation that Vault runs in the background
+func startTidy(vault *api.Client, target, path string, data map[string]interface{}) *TidyResult {
+	result := &TidyResult{Target: target, Path: path, State: "Started"}
+	if _, err := vault.Logical().Write(path, data); err != nil {
+		result.State = "Error"
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// runPKITidy triggers a tidy of a PKI mount and polls its status until it is no longer running
+func runPKITidy(ctx context.Context, req mcp.CallToolRequest, vault *api.Client, mount, safetyBuffer string, timeout time.Duration, progress, total float64) *TidyResult {
+	data := map[string]interface{}{
+		"tidy_cert_store":      true,
+		"tidy_revoked_certs":   true,
+		"tidy_expired_issuers": true,
+	}
+	if safetyBuffer != "" {
+		data["safety_buffer"] = safetyBuffer
+	}
+
+	result := startTidy(vault, "pki", mount+"/tidy", data)
+	if result.Error != "" {
+		return result
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		secret, err := vault.Logical().Read(mount + "/tidy-status")
+		if err != nil {
+			result.State = "Error"
+			result.Error = fmt.Sprintf("failed to read tidy status: %v", err)
+			return result
+		}
+		if secret != nil && secret.Data != nil {
+			result.Status = secret.Data
+			if state, _ := secret.Data["state"].(string); state != "" {
+				result.State = state
+			}
+			if tidyErr, _ := secret.Data["error"].(string); tidyErr != "" {
+				result.Error = tidyErr
+			}
+		}
+
+		if result.State != "Running" && result.State != "Started" {
+			return result
+		}
+		if time.Now().After(deadline) {
+			result.Error = fmt.Sprintf("tidy is still running after %s, read '%s/tidy-status' to follow it", timeout, mount)
+			return result
+		}
+
+		_ = utils.NotifyProgress(ctx, req, progress, total, fmt.Sprintf("pki tidy of '%s' is running", mount))
+
+		select {
+		case <-ctx.Done():
+			result.Error = ctx.Err().Error()
+			return result
+		case <-time.After(tidyPollInterval):
+		}
+	}
+}
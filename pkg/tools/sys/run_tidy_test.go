@@ -0,0 +1,73 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunTidy(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	tidyPollInterval = time.Millisecond
+	t.Cleanup(func() { tidyPollInterval = 2 * time.Second })
+
+	var started []string
+	statusReads := 0
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost || r.Method == http.MethodPut:
+			started = append(started, r.URL.Path)
+			w.WriteHeader(http.StatusAccepted)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"warnings": []string{"Tidy operation successfully started"}})
+		case r.URL.Path == "/v1/pki/tidy-status":
+			statusReads++
+			state := "Running"
+			if statusReads > 2 {
+				state = "Finished"
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"state": state, "cert_store_deleted_count": 3,
+			}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockVault.Close()
+
+	sessionID := "test-" + t.Name()
+	_, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	defer client.DeleteVaultClient(sessionID)
+	ctx := server.NewMCPServer("test", "1.0").WithContext(context.Background(), testSession{id: sessionID})
+
+	result, err := RunTidy(logger).Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{
+		Arguments: map[string]interface{}{"targets": []interface{}{"pki", "token", "leases"}},
+	}})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var results []TidyResult
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &results))
+	require.Len(t, results, 3)
+	assert.Equal(t, "Finished", results[0].State)
+	assert.Equal(t, 3, statusReads)
+	assert.Equal(t, "Started", results[1].State)
+	assert.Equal(t, "Started", results[2].State)
+	assert.Equal(t, []string{"/v1/pki/tidy", "/v1/auth/token/tidy", "/v1/sys/leases/tidy"}, started)
+}
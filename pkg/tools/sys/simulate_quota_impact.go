@@ -0,0 +1,464 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// MetricsIntervalSeconds is the length of the intervals of the in-memory metrics served by sys/metrics
+	MetricsIntervalSeconds = 10
+	// MaxLeaseScan is the maximum number of leases counted under a path
+	MaxLeaseScan = 10000
+	// TightQuotaPercent is the default utilization of a quota from which it is reported as tight and alerted on
+	TightQuotaPercent = 80
+)
+
+// Utilization of rate limit and lease count quotas from which simulate_quota_impact raises an alert, as a
+// percentage of the quota
+const (
+	RateLimitAlertPercentEnv  = "MCP_QUOTA_ALERT_RATE_LIMIT_PERCENT"
+	LeaseCountAlertPercentEnv = "MCP_QUOTA_ALERT_LEASE_COUNT_PERCENT"
+)
+
+// Severities of a quota alert
+const (
+	QuotaAlertWarning  = "warning"  // Usage crossed the alert threshold of the quota
+	QuotaAlertCritical = "critical" // Usage reached the quota, requests or leases are refused
+)
+
+// QuotaThresholds holds the utilization percentages from which quotas are reported as tight and alerted on
+type QuotaThresholds struct {
+	RateLimit  float64
+	LeaseCount float64
+}
+
+// LoadQuotaThresholdsFromEnv loads the alert thresholds from MCP_QUOTA_ALERT_RATE_LIMIT_PERCENT and
+// MCP_QUOTA_ALERT_LEASE_COUNT_PERCENT. Unset or invalid values fall back to TightQuotaPercent, invalid ones
+// with a warning.
+func LoadQuotaThresholdsFromEnv() QuotaThresholds {
+	return QuotaThresholds{
+		RateLimit:  loadQuotaThreshold(RateLimitAlertPercentEnv),
+		LeaseCount: loadQuotaThreshold(LeaseCountAlertPercentEnv),
+	}
+}
+
+func loadQuotaThreshold(env string) float64 {
+	value := strings.TrimSpace(os.Getenv(env))
+	if value == "" {
+		return TightQuotaPercent
+	}
+	threshold, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+	if err != nil || threshold <= 0 || threshold > 100 {
+		log.Warnf("Invalid %s value '%s', expected a percentage between 0 and 100, using %d", env, value, TightQuotaPercent)
+		return TightQuotaPercent
+	}
+	return threshold
+}
+
+// ForType returns the threshold of a quota type
+func (t QuotaThresholds) ForType(quotaType string) float64 {
+	if quotaType == "lease-count" {
+		return t.LeaseCount
+	}
+	return t.RateLimit
+}
+
+// Verdicts of a simulated quota
+const (
+	QuotaVerdictSafe     = "safe"     // Current usage stays well below the quota
+	QuotaVerdictTight    = "tight"    // Current usage is close to the quota, bursts would be limited
+	QuotaVerdictBlocking = "blocking" // Current usage already exceeds the quota
+	QuotaVerdictUnknown  = "unknown"  // Usage could not be measured
+)
+
+// QuotaImpact is the estimated impact of a proposed quota
+type QuotaImpact struct {
+	Type               string       `json:"type"`                          // rate-limit or lease-count
+	Path               string       `json:"path"`                          // Namespace or mount the quota would apply to, empty for the whole server
+	ProposedRate       float64      `json:"proposed_rate,omitempty"`       // Requests per second allowed by a rate limit quota
+	ObservedRate       float64      `json:"observed_rate,omitempty"`       // Requests per second in the last metrics interval
+	RejectedPercent    float64      `json:"rejected_percent,omitempty"`    // Share of the current requests that would be rejected
+	ProposedMaxLeases  int          `json:"proposed_max_leases,omitempty"` // Leases allowed by a lease count quota
+	CurrentLeases      int          `json:"current_leases,omitempty"`      // Leases under the path
+	LeasesTruncated    bool         `json:"leases_truncated,omitempty"`    // Counting stopped at MaxLeaseScan leases
+	UtilizationPercent float64      `json:"utilization_percent"`           // Current usage as a percentage of the quota
+	Verdict            string       `json:"verdict"`                       // safe, tight, blocking or unknown
+	ExistingQuotas     []string     `json:"existing_quotas,omitempty"`     // Quotas of the same type on the same path
+	Findings           []string     `json:"findings"`                      // Explanation of the verdict
+	SkippedChecks      []string     `json:"skipped_checks,omitempty"`      // Measurements that could not run, usually because of missing permissions
+	Alerts             []QuotaAlert `json:"alerts"`                        // Quotas whose utilization crosses the alert threshold
+}
+
+// QuotaAlert reports a proposed or existing quota whose utilization crosses its alert threshold
+type QuotaAlert struct {
+	Quota              string  `json:"quota"`               // Name of the existing quota, empty for the proposed one
+	Type               string  `json:"type"`                // rate-limit or lease-count
+	Path               string  `json:"path"`                // Namespace or mount the quota applies to
+	UtilizationPercent float64 `json:"utilization_percent"` // Current usage as a percentage of the quota
+	ThresholdPercent   float64 `json:"threshold_percent"`   // Configured alert threshold of the quota type
+	Severity           string  `json:"severity"`            // warning or critical
+	Message            string  `json:"message"`
+}
+
+// vaultMetrics is the JSON format of sys/metrics
+type vaultMetrics struct {
+	Gauges []struct {
+		Name  string  `json:"Name"`
+		Value float64 `json:"Value"`
+	} `json:"Gauges"`
+	Samples []struct {
+		Name  string  `json:"Name"`
+		Count float64 `json:"Count"`
+	} `json:"Samples"`
+}
+
+// SimulateQuotaImpact creates a tool estimating the impact of a rate limit or lease count quota before it is applied
+func SimulateQuotaImpact(thresholds QuotaThresholds, logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("simulate_quota_impact",
+			mcp.WithDescription("Estimate the impact of a proposed rate limit or lease count quota before applying it. "+
+				"Compares the quota with the request rate of the last metrics interval from sys/metrics and the current number of leases, "+
+				"and reports whether current traffic would be rejected. The proposed quota and the existing quotas of the same type on the path "+
+				"are listed in 'alerts' when their utilization crosses the alert threshold of the server. Nothing is changed in Vault."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithString("type",
+				mcp.Required(),
+				mcp.Enum("rate-limit", "lease-count"),
+				mcp.Description("The type of the proposed quota."),
+			),
+			mcp.WithString("path",
+				mcp.DefaultString(""),
+				mcp.Description("The namespace or mount the quota would apply to, such as 'secret' or 'database'. Empty for the whole server."),
+			),
+			mcp.WithNumber("rate",
+				mcp.Description("Requests allowed per interval by a rate limit quota. Required for 'rate-limit'."),
+			),
+			mcp.WithNumber("interval",
+				mcp.DefaultNumber(1),
+				mcp.Description("The interval of a rate limit quota in seconds. Defaults to 1."),
+			),
+			mcp.WithNumber("max_leases",
+				mcp.Description("Leases allowed by a lease count quota. Required for 'lease-count'."),
+			),
+			schemas.Output("simulate_quota_impact"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return simulateQuotaImpactHandler(ctx, req, thresholds, logger)
+		},
+	}
+}
+
+func simulateQuotaImpactHandler(ctx context.Context, req mcp.CallToolRequest, thresholds QuotaThresholds, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling simulate_quota_impact request")
+
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	quotaType, _ := args["type"].(string)
+	if quotaType != "rate-limit" && quotaType != "lease-count" {
+		return mcp.NewToolResultError("Missing or invalid 'type' parameter"), nil
+	}
+	path, _ := args["path"].(string)
+	path = strings.Trim(path, "/")
+
+	impact := &QuotaImpact{Type: quotaType, Path: path, Findings: []string{}, Alerts: []QuotaAlert{}}
+	threshold := thresholds.ForType(quotaType)
+	switch quotaType {
+	case "rate-limit":
+		rate, ok := args["rate"].(float64)
+		if !ok || rate <= 0 {
+			return mcp.NewToolResultError("Missing or invalid 'rate' parameter"), nil
+		}
+		interval := 1.0
+		if i, ok := args["interval"].(float64); ok && i > 0 {
+			interval = i
+		}
+		impact.ProposedRate = rate / interval
+	case "lease-count":
+		maxLeases, ok := args["max_leases"].(float64)
+		if !ok || maxLeases < 1 {
+			return mcp.NewToolResultError("Missing or invalid 'max_leases' parameter"), nil
+		}
+		impact.ProposedMaxLeases = int(maxLeases)
+	}
+
+	logger.WithFields(log.Fields{
+		"type": quotaType,
+		"path": path,
+	}).Debug("Simulating quota with parameters")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	// Quotas on the same path are replaced or shadowed by the new one, their limit in requests per second or leases
+	existingLimits := map[string]float64{}
+	if names, err := listKeys(vault, "sys/quotas/"+quotaType); err != nil {
+		impact.SkippedChecks = append(impact.SkippedChecks, fmt.Sprintf("existing quotas: %v", err))
+	} else {
+		for _, name := range names {
+			secret, err := vault.Logical().ReadWithContext(ctx, "sys/quotas/"+quotaType+"/"+name)
+			if err != nil || secret == nil {
+				continue
+			}
+			if quotaPath, _ := secret.Data["path"].(string); strings.Trim(quotaPath, "/") == path {
+				impact.ExistingQuotas = append(impact.ExistingQuotas, name)
+				if limit := quotaLimit(quotaType, secret.Data); limit > 0 {
+					existingLimits[name] = limit
+				}
+			}
+		}
+		if len(impact.ExistingQuotas) > 0 {
+			impact.Findings = append(impact.Findings, fmt.Sprintf("Quotas already apply to this path: %s. Update them instead of adding another quota.", strings.Join(impact.ExistingQuotas, ", ")))
+		}
+	}
+
+	metrics, metricsErr := readVaultMetrics(ctx, vault)
+	if metricsErr != nil {
+		impact.SkippedChecks = append(impact.SkippedChecks, fmt.Sprintf("sys/metrics: %v", metricsErr))
+	}
+
+	switch quotaType {
+	case "rate-limit":
+		if metricsErr != nil {
+			impact.Verdict = QuotaVerdictUnknown
+			impact.Findings = append(impact.Findings, "The request rate could not be read from sys/metrics, which needs a token allowed to read it or unauthenticated metrics access on the listener.")
+			break
+		}
+		impact.ObservedRate = observedRequestRate(metrics, path)
+		impact.UtilizationPercent = percent(impact.ObservedRate, impact.ProposedRate)
+		impact.Verdict = quotaVerdict(impact.UtilizationPercent, threshold)
+		if impact.ObservedRate > impact.ProposedRate {
+			impact.RejectedPercent = percent(impact.ObservedRate-impact.ProposedRate, impact.ObservedRate)
+			impact.Findings = append(impact.Findings, fmt.Sprintf("The current rate of %.1f requests per second exceeds the quota of %.1f, about %.0f%% of the requests would be rejected with 429 errors.",
+				impact.ObservedRate, impact.ProposedRate, impact.RejectedPercent))
+		} else {
+			impact.Findings = append(impact.Findings, fmt.Sprintf("The current rate of %.1f requests per second uses %.0f%% of the quota of %.1f.",
+				impact.ObservedRate, impact.UtilizationPercent, impact.ProposedRate))
+		}
+		impact.Findings = append(impact.Findings, fmt.Sprintf("The rate was measured over the last %d second metrics interval, check it again at peak times before applying the quota.", MetricsIntervalSeconds))
+	case "lease-count":
+		if path == "" {
+			if metricsErr != nil {
+				impact.Verdict = QuotaVerdictUnknown
+				impact.Findings = append(impact.Findings, "The number of leases could not be read from sys/metrics.")
+				break
+			}
+			impact.CurrentLeases = int(gaugeValue(metrics, "expire.num_leases"))
+		} else {
+			impact.CurrentLeases, impact.LeasesTruncated, err = countLeases(vault, path, MaxLeaseScan)
+			if err != nil {
+				impact.SkippedChecks = append(impact.SkippedChecks, fmt.Sprintf("sys/leases/lookup/%s: %v", path, err))
+				impact.Verdict = QuotaVerdictUnknown
+				impact.Findings = append(impact.Findings, "The leases under the path could not be listed, which needs sudo on sys/leases/lookup.")
+				break
+			}
+		}
+		impact.UtilizationPercent = percent(float64(impact.CurrentLeases), float64(impact.ProposedMaxLeases))
+		impact.Verdict = quotaVerdict(impact.UtilizationPercent, threshold)
+		if impact.CurrentLeases >= impact.ProposedMaxLeases {
+			impact.Findings = append(impact.Findings, fmt.Sprintf("%d leases exist and the quota allows %d, new leases would be refused until %d leases expire or are revoked.",
+				impact.CurrentLeases, impact.ProposedMaxLeases, impact.CurrentLeases-impact.ProposedMaxLeases+1))
+		} else {
+			impact.Findings = append(impact.Findings, fmt.Sprintf("%d leases exist, %.0f%% of the quota of %d.", impact.CurrentLeases, impact.UtilizationPercent, impact.ProposedMaxLeases))
+		}
+		if impact.LeasesTruncated {
+			impact.Findings = append(impact.Findings, fmt.Sprintf("Counting stopped at %d leases, the actual number is higher.", MaxLeaseScan))
+		}
+	}
+	if impact.Verdict == QuotaVerdictTight {
+		impact.Findings = append(impact.Findings, fmt.Sprintf("Usage is above %g%% of the quota, bursts and growth would hit it.", threshold))
+	}
+
+	if impact.Verdict != QuotaVerdictUnknown {
+		usage := impact.ObservedRate
+		if quotaType == "lease-count" {
+			usage = float64(impact.CurrentLeases)
+		}
+		if alert, ok := quotaAlert("", quotaType, path, impact.UtilizationPercent, threshold); ok {
+			impact.Alerts = append(impact.Alerts, alert)
+		}
+		for _, name := range impact.ExistingQuotas {
+			if limit, ok := existingLimits[name]; ok {
+				if alert, ok := quotaAlert(name, quotaType, path, percent(usage, limit), threshold); ok {
+					impact.Alerts = append(impact.Alerts, alert)
+				}
+			}
+		}
+	}
+
+	jsonData, err := json.Marshal(impact)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal quota impact to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"type":    quotaType,
+		"path":    path,
+		"verdict": impact.Verdict,
+		"alerts":  len(impact.Alerts),
+	}).Debug("Successfully simulated quota")
+
+	return mcp.NewToolResultStructured(impact, string(jsonData)), nil
+}
+
+// readVaultMetrics reads the in-memory metrics of the Vault server
+func readVaultMetrics(ctx context.Context, vault *api.Client) (*vaultMetrics, error) {
+	resp, err := vault.Logical().ReadRawWithDataWithContext(ctx, "sys/metrics", map[string][]string{"format": {"json"}})
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var metrics vaultMetrics
+	if err := json.NewDecoder(resp.Body).Decode(&metrics); err != nil {
+		return nil, fmt.Errorf("invalid metrics: %w", err)
+	}
+	return &metrics, nil
+}
+
+// observedRequestRate returns the requests per second of the last metrics interval, for the whole server or
+// the requests routed to a mount. Route metrics are named vault.route.<operation>.<mount> with the slashes of
+// the mount replaced by dashes.
+func observedRequestRate(metrics *vaultMetrics, path string) float64 {
+	var count float64
+	for _, sample := range metrics.Samples {
+		if path == "" {
+			if strings.HasSuffix(sample.Name, "core.handle_request") {
+				count += sample.Count
+			}
+			continue
+		}
+		if _, route, ok := strings.Cut(sample.Name, ".route."); ok {
+			if _, mount, ok := strings.Cut(route, "."); ok && mount == strings.ReplaceAll(path+"/", "/", "-") {
+				count += sample.Count
+			}
+		}
+	}
+	return count / MetricsIntervalSeconds
+}
+
+// gaugeValue returns the value of a gauge, whose name may be prefixed with the host name
+func gaugeValue(metrics *vaultMetrics, name string) float64 {
+	for _, gauge := range metrics.Gauges {
+		if strings.HasSuffix(gauge.Name, "."+name) {
+			return gauge.Value
+		}
+	}
+	return 0
+}
+
+// countLeases counts the leases under a path, stopping after limit leases
+func countLeases(vault *api.Client, path string, limit int) (int, bool, error) {
+	count := 0
+	pending := []string{path + "/"}
+	for len(pending) > 0 {
+		prefix := pending[0]
+		pending = pending[1:]
+
+		keys, err := listKeys(vault, "sys/leases/lookup/"+prefix)
+		if err != nil {
+			return 0, false, err
+		}
+		for _, key := range keys {
+			if strings.HasSuffix(key, "/") {
+				pending = append(pending, prefix+key)
+				continue
+			}
+			if count >= limit {
+				return count, true, nil
+			}
+			count++
+		}
+	}
+	return count, false, nil
+}
+
+func quotaVerdict(utilization float64, threshold float64) string {
+	switch {
+	case utilization >= 100:
+		return QuotaVerdictBlocking
+	case utilization >= threshold:
+		return QuotaVerdictTight
+	}
+	return QuotaVerdictSafe
+}
+
+// quotaAlert returns the alert of a quota whose utilization crosses the threshold, critical once the quota is reached
+func quotaAlert(name, quotaType, path string, utilization, threshold float64) (QuotaAlert, bool) {
+	if utilization < threshold {
+		return QuotaAlert{}, false
+	}
+	alert := QuotaAlert{
+		Quota:              name,
+		Type:               quotaType,
+		Path:               path,
+		UtilizationPercent: utilization,
+		ThresholdPercent:   threshold,
+		Severity:           QuotaAlertWarning,
+	}
+	subject := "The proposed quota"
+	if name != "" {
+		subject = fmt.Sprintf("Quota '%s'", name)
+	}
+	if utilization >= 100 {
+		alert.Severity = QuotaAlertCritical
+		alert.Message = fmt.Sprintf("%s is used at %.0f%%, current usage already exceeds it.", subject, utilization)
+	} else {
+		alert.Message = fmt.Sprintf("%s is used at %.0f%%, above the alert threshold of %g%%.", subject, utilization, threshold)
+	}
+	return alert, true
+}
+
+// quotaLimit returns the limit of an existing quota, in requests per second for a rate limit or in leases for a
+// lease count quota, or 0 when it cannot be read
+func quotaLimit(quotaType string, data map[string]interface{}) float64 {
+	number := func(key string) float64 {
+		value, _ := data[key].(json.Number)
+		f, _ := value.Float64()
+		return f
+	}
+	if quotaType == "lease-count" {
+		return number("max_leases")
+	}
+	rate, interval := number("rate"), number("interval")
+	if interval <= 0 {
+		interval = 1
+	}
+	return rate / interval
+}
+
+func percent(value float64, total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(int(value/total*1000+0.5)) / 10
+}
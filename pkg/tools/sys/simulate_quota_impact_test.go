@@ -0,0 +1,211 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMetrics = `{
+	"Gauges": [{"Name": "vault.vault-0.expire.num_leases", "Value": 1200}],
+	"Samples": [
+		{"Name": "vault.core.handle_request", "Count": 800},
+		{"Name": "vault.route.read.secret-", "Count": 500},
+		{"Name": "vault.route.create.secret-", "Count": 125},
+		{"Name": "vault.route.read.secret-team-", "Count": 40}
+	]
+}`
+
+func newQuotaVault(t *testing.T, metrics bool) (context.Context, func()) {
+	t.Helper()
+
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch strings.TrimSuffix(r.URL.Path, "/") {
+		case "/v1/sys/metrics":
+			if !metrics {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+				return
+			}
+			assert.Equal(t, "json", r.URL.Query().Get("format"))
+			_, _ = w.Write([]byte(testMetrics))
+		case "/v1/sys/quotas/rate-limit":
+			_, _ = w.Write([]byte(`{"data":{"keys":["global","kv"]}}`))
+		case "/v1/sys/quotas/rate-limit/global":
+			_, _ = w.Write([]byte(`{"data":{"path":"","rate":100,"interval":1}}`))
+		case "/v1/sys/quotas/rate-limit/kv":
+			_, _ = w.Write([]byte(`{"data":{"path":"secret/","rate":300,"interval":2}}`))
+		case "/v1/sys/leases/lookup/database":
+			_, _ = w.Write([]byte(`{"data":{"keys":["creds/"]}}`))
+		case "/v1/sys/leases/lookup/database/creds":
+			_, _ = w.Write([]byte(`{"data":{"keys":["ro/","rw/"]}}`))
+		case "/v1/sys/leases/lookup/database/creds/ro":
+			_, _ = w.Write([]byte(`{"data":{"keys":["a1","a2","a3"]}}`))
+		case "/v1/sys/leases/lookup/database/creds/rw":
+			_, _ = w.Write([]byte(`{"data":{"keys":["b1"]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	sessionID := "test-" + t.Name()
+	_, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+
+	ctx := server.NewMCPServer("test", "1.0").WithContext(context.Background(), testSession{id: sessionID})
+
+	return ctx, func() {
+		mockVault.Close()
+		client.DeleteVaultClient(sessionID)
+	}
+}
+
+func simulateQuota(t *testing.T, ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, QuotaImpact) {
+	t.Helper()
+	return simulateQuotaWithThresholds(t, ctx, QuotaThresholds{RateLimit: TightQuotaPercent, LeaseCount: TightQuotaPercent}, args)
+}
+
+func simulateQuotaWithThresholds(t *testing.T, ctx context.Context, thresholds QuotaThresholds, args map[string]interface{}) (*mcp.CallToolResult, QuotaImpact) {
+	t.Helper()
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	result, err := SimulateQuotaImpact(thresholds, logger).Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+	require.NoError(t, err)
+
+	var impact QuotaImpact
+	if !result.IsError {
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &impact))
+	}
+	return result, impact
+}
+
+func TestSimulateQuotaImpact(t *testing.T) {
+	t.Run("rate limit on a mount exceeded by current traffic", func(t *testing.T) {
+		ctx, cleanup := newQuotaVault(t, true)
+		defer cleanup()
+
+		_, impact := simulateQuota(t, ctx, map[string]interface{}{"type": "rate-limit", "path": "secret/", "rate": float64(100), "interval": float64(2)})
+		assert.Equal(t, "secret", impact.Path)
+		assert.Equal(t, 50.0, impact.ProposedRate)
+		assert.Equal(t, 62.5, impact.ObservedRate, "only the requests routed to the mount count")
+		assert.Equal(t, 125.0, impact.UtilizationPercent)
+		assert.Equal(t, 20.0, impact.RejectedPercent)
+		assert.Equal(t, QuotaVerdictBlocking, impact.Verdict)
+		assert.Equal(t, []string{"kv"}, impact.ExistingQuotas)
+		require.Len(t, impact.Alerts, 1, "the existing quota allows 150 requests per second")
+		assert.Equal(t, "", impact.Alerts[0].Quota)
+		assert.Equal(t, QuotaAlertCritical, impact.Alerts[0].Severity)
+	})
+
+	t.Run("global rate limit", func(t *testing.T) {
+		ctx, cleanup := newQuotaVault(t, true)
+		defer cleanup()
+
+		_, impact := simulateQuota(t, ctx, map[string]interface{}{"type": "rate-limit", "rate": float64(95)})
+		assert.Equal(t, 80.0, impact.ObservedRate)
+		assert.Equal(t, QuotaVerdictTight, impact.Verdict)
+		assert.Equal(t, []string{"global"}, impact.ExistingQuotas)
+		require.Len(t, impact.Alerts, 2)
+		assert.Equal(t, QuotaAlertWarning, impact.Alerts[0].Severity)
+		assert.Equal(t, 84.2, impact.Alerts[0].UtilizationPercent)
+		assert.Equal(t, "global", impact.Alerts[1].Quota)
+		assert.Equal(t, 80.0, impact.Alerts[1].UtilizationPercent)
+		assert.Equal(t, 80.0, impact.Alerts[1].ThresholdPercent)
+	})
+
+	t.Run("configured thresholds", func(t *testing.T) {
+		ctx, cleanup := newQuotaVault(t, true)
+		defer cleanup()
+
+		_, impact := simulateQuotaWithThresholds(t, ctx, QuotaThresholds{RateLimit: 90, LeaseCount: 3}, map[string]interface{}{"type": "rate-limit", "rate": float64(95)})
+		assert.Equal(t, QuotaVerdictSafe, impact.Verdict)
+		assert.Empty(t, impact.Alerts)
+
+		_, impact = simulateQuotaWithThresholds(t, ctx, QuotaThresholds{RateLimit: 90, LeaseCount: 3}, map[string]interface{}{"type": "lease-count", "path": "database", "max_leases": float64(100)})
+		assert.Equal(t, QuotaVerdictTight, impact.Verdict)
+		require.Len(t, impact.Alerts, 1)
+		assert.Equal(t, 3.0, impact.Alerts[0].ThresholdPercent)
+	})
+
+	t.Run("lease count", func(t *testing.T) {
+		ctx, cleanup := newQuotaVault(t, true)
+		defer cleanup()
+
+		_, impact := simulateQuota(t, ctx, map[string]interface{}{"type": "lease-count", "path": "database", "max_leases": float64(100)})
+		assert.Equal(t, 4, impact.CurrentLeases)
+		assert.Equal(t, 4.0, impact.UtilizationPercent)
+		assert.Equal(t, QuotaVerdictSafe, impact.Verdict)
+
+		_, impact = simulateQuota(t, ctx, map[string]interface{}{"type": "lease-count", "max_leases": float64(1000)})
+		assert.Equal(t, 1200, impact.CurrentLeases, "the whole server uses the lease gauge")
+		assert.Equal(t, QuotaVerdictBlocking, impact.Verdict)
+		assert.Contains(t, impact.Findings[0], "until 201 leases expire")
+	})
+
+	t.Run("counting leases stops at the limit", func(t *testing.T) {
+		ctx, cleanup := newQuotaVault(t, true)
+		defer cleanup()
+		vault, err := client.GetVaultClientFromContext(ctx, log.New())
+		require.NoError(t, err)
+
+		count, truncated, err := countLeases(vault, "database", 2)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+		assert.True(t, truncated)
+	})
+
+	t.Run("metrics not readable", func(t *testing.T) {
+		ctx, cleanup := newQuotaVault(t, false)
+		defer cleanup()
+
+		_, impact := simulateQuota(t, ctx, map[string]interface{}{"type": "rate-limit", "rate": float64(10)})
+		assert.Equal(t, QuotaVerdictUnknown, impact.Verdict)
+		require.Len(t, impact.SkippedChecks, 1)
+		assert.Contains(t, impact.SkippedChecks[0], "sys/metrics")
+		assert.Empty(t, impact.Alerts)
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		ctx, cleanup := newQuotaVault(t, true)
+		defer cleanup()
+
+		for _, args := range []map[string]interface{}{
+			{"type": "role"},
+			{"type": "rate-limit"},
+			{"type": "lease-count", "max_leases": float64(0)},
+		} {
+			result, _ := simulateQuota(t, ctx, args)
+			assert.True(t, result.IsError, args)
+		}
+	})
+}
+
+func TestLoadQuotaThresholdsFromEnv(t *testing.T) {
+	t.Setenv(RateLimitAlertPercentEnv, "")
+	t.Setenv(LeaseCountAlertPercentEnv, "")
+	assert.Equal(t, QuotaThresholds{RateLimit: TightQuotaPercent, LeaseCount: TightQuotaPercent}, LoadQuotaThresholdsFromEnv())
+
+	t.Setenv(RateLimitAlertPercentEnv, "70%")
+	t.Setenv(LeaseCountAlertPercentEnv, "92.5")
+	assert.Equal(t, QuotaThresholds{RateLimit: 70, LeaseCount: 92.5}, LoadQuotaThresholdsFromEnv())
+
+	for _, value := range []string{"high", "0", "150"} {
+		t.Setenv(LeaseCountAlertPercentEnv, value)
+		assert.Equal(t, float64(TightQuotaPercent), LoadQuotaThresholdsFromEnv().LeaseCount, value)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+)
+
+// MaxSnapshotSecrets is the maximum number of secrets of a mount that can be backed up before it is deleted
+const MaxSnapshotSecrets = 10000
+
+// MountSnapshot describes a copy of a KV mount taken before it is deleted
+type MountSnapshot struct {
+	Source             string   `json:"source"`
+	Target             string   `json:"target"`
+	Secrets            int      `json:"secrets"`              // Number of secrets copied
+	CurrentVersionOnly bool     `json:"current_version_only"` // Only the current version of KV v2 secrets is copied, older versions are lost
+	Skipped            []string `json:"skipped,omitempty"`    // Secrets not copied because their current version is deleted or destroyed
+}
+
+// backupMountPath returns a timestamped mount path for the backup of a mount
+func backupMountPath(path string, now time.Time) string {
+	return fmt.Sprintf("%s-backup-%s", path, now.UTC().Format("20060102-150405"))
+}
+
+// snapshotMountTo copies the secrets of a KV mount into a new KV mount at target that uses the same engine
+// version. Only the current version of KV v2 secrets is copied, secrets whose current version is deleted are
+// skipped. When the copy fails, the partial backup mount is removed again.
+func snapshotMountTo(vault *api.Client, path string, mount *api.MountOutput, target string) (*MountSnapshot, error) {
+	if mount.Type != "kv" && mount.Type != "generic" {
+		return nil, fmt.Errorf("only KV mounts can be backed up, '%s' is a '%s' mount", path, mount.Type)
+	}
+	v2 := mount.Options["version"] == "2"
+
+	secrets, truncated, err := listKVSecrets(vault, path, v2, MaxSnapshotSecrets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %v", err)
+	}
+	if truncated {
+		return nil, fmt.Errorf("'%s' holds more than %d secrets or too many folders to be backed up completely", path, MaxSnapshotSecrets)
+	}
+
+	err = vault.Sys().Mount(target, &api.MountInput{
+		Type:        mount.Type,
+		Description: fmt.Sprintf("Backup of '%s' taken before it was deleted", path),
+		Options:     mount.Options,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backup mount '%s': %v", target, err)
+	}
+
+	snapshot, err := copySecrets(vault, path, target, v2, secrets)
+	if err != nil {
+		if unmountErr := vault.Sys().Unmount(target); unmountErr != nil {
+			return nil, fmt.Errorf("%v, the partial backup mount '%s' could not be removed and must be deleted: %v", err, target, unmountErr)
+		}
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// copySecrets copies the current version of the secrets of a KV mount to the same paths in the target mount
+func copySecrets(vault *api.Client, path, target string, v2 bool, secrets []string) (*MountSnapshot, error) {
+	snapshot := &MountSnapshot{Source: path, Target: target, CurrentVersionOnly: v2}
+	for _, secretPath := range secrets {
+		readPath, writePath := fmt.Sprintf("%s/%s", path, secretPath), fmt.Sprintf("%s/%s", target, secretPath)
+		if v2 {
+			readPath, writePath = fmt.Sprintf("%s/data/%s", path, secretPath), fmt.Sprintf("%s/data/%s", target, secretPath)
+		}
+
+		secret, err := vault.Logical().Read(readPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read secret '%s': %v", secretPath, err)
+		}
+		if secret == nil || secret.Data == nil {
+			snapshot.Skipped = append(snapshot.Skipped, secretPath)
+			continue
+		}
+
+		data := secret.Data
+		if v2 {
+			// The current version of a deleted KV v2 secret has no data
+			current, ok := secret.Data["data"].(map[string]interface{})
+			if !ok {
+				snapshot.Skipped = append(snapshot.Skipped, secretPath)
+				continue
+			}
+			data = map[string]interface{}{"data": current}
+		}
+
+		if _, err := vault.Logical().Write(writePath, data); err != nil {
+			return nil, fmt.Errorf("failed to write secret '%s' to backup mount: %v", secretPath, err)
+		}
+		snapshot.Secrets++
+	}
+
+	return snapshot, nil
+}
@@ -0,0 +1,84 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/vault/api"
+)
+
+// localCluster is the path of the node of the cluster the client is connected to
+const localCluster = "local"
+
+// BuildTopologyGraph reads the topology of the namespace: the dependency graph without certificates, linked to the
+// secrets engines and auth methods its nodes live on, and the clusters the cluster replicates with. Parts that
+// cannot be read, such as the replication status of Vault Community Edition, are reported as warnings.
+func BuildTopologyGraph(vault *api.Client, limit int) *DependencyGraph {
+	graph := BuildDependencyGraph(vault, limit, false)
+
+	if mounts, err := vault.Sys().ListMounts(); err == nil {
+		for path, mount := range mounts {
+			path = strings.TrimSuffix(path, "/")
+			graph.addNode(NodeSecretsEngine, path, fmt.Sprintf("%s (%s)", path, mount.Type), path)
+		}
+	}
+	if auths, err := vault.Sys().ListAuth(); err == nil {
+		for path, auth := range auths {
+			path = "auth/" + strings.TrimSuffix(path, "/")
+			graph.addNode(NodeAuthMethod, path, fmt.Sprintf("%s (%s)", path, auth.Type), path)
+		}
+	}
+	for _, node := range graph.Nodes {
+		if node.Mount == "" || node.Type == NodeSecretsEngine || node.Type == NodeAuthMethod {
+			continue
+		}
+		mountType := NodeSecretsEngine
+		if strings.HasPrefix(node.Mount, "auth/") {
+			mountType = NodeAuthMethod
+		}
+		if mount := graph.nodes[nodeID(mountType, node.Mount)]; mount != nil {
+			graph.addEdge(node.ID, mount.ID, RelationMountedOn, "")
+		}
+	}
+
+	if status, err := ReadReplicationStatus(vault); err != nil {
+		graph.warn("replication status", err)
+	} else {
+		graph.addReplication("dr", status.DR)
+		graph.addReplication("performance", status.Performance)
+	}
+
+	graph.sort()
+	return graph
+}
+
+// addReplication adds the clusters the local cluster replicates with in one kind of replication: the known
+// secondaries of a primary, or the primary of a secondary
+func (g *DependencyGraph) addReplication(kind string, status map[string]interface{}) {
+	mode, _ := status["mode"].(string)
+	if mode == "" || mode == "disabled" {
+		return
+	}
+	local := g.addNode(NodeCluster, localCluster, "this cluster", "")
+
+	switch {
+	case strings.Contains(mode, "primary"):
+		secondaries := toStringList(status["known_secondaries"])
+		sort.Strings(secondaries)
+		for _, id := range secondaries {
+			secondary := g.addNode(NodeCluster, id, id, "")
+			g.addEdge(secondary.ID, local.ID, RelationReplicatesFrom, kind)
+		}
+	case strings.Contains(mode, "secondary"):
+		address, _ := status["primary_cluster_addr"].(string)
+		if address == "" {
+			address = "primary"
+		}
+		primary := g.addNode(NodeCluster, address, address, "")
+		g.addEdge(local.ID, primary.ID, RelationReplicatesFrom, kind)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package sys
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildTopologyGraph(t *testing.T) {
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		data := func(data map[string]interface{}) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+		}
+		switch r.URL.Path {
+		case "/v1/sys/mounts":
+			data(map[string]interface{}{"secret/": map[string]interface{}{"type": "kv"}})
+		case "/v1/sys/auth":
+			data(map[string]interface{}{"userpass/": map[string]interface{}{"type": "userpass"}})
+		case "/v1/sys/policies/acl":
+			data(map[string]interface{}{"keys": []string{"app-read"}})
+		case "/v1/sys/policies/acl/app-read":
+			data(map[string]interface{}{"name": "app-read", "policy": `path "secret/app" { capabilities = ["read"] }`})
+		case "/v1/auth/userpass/users":
+			data(map[string]interface{}{"keys": []string{"alice"}})
+		case "/v1/auth/userpass/users/alice":
+			data(map[string]interface{}{"token_policies": []string{"app-read"}})
+		case "/v1/sys/replication/status":
+			data(map[string]interface{}{
+				"dr":          map[string]interface{}{"mode": "secondary", "primary_cluster_addr": "https://dr-primary:8201"},
+				"performance": map[string]interface{}{"mode": "primary", "known_secondaries": []string{"eu-west"}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockVault.Close()
+
+	sessionID := "test-" + t.Name()
+	vault, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	defer client.DeleteVaultClient(sessionID)
+
+	graph := BuildTopologyGraph(vault, DefaultDependencyLimit)
+	assert.Empty(t, graph.Warnings)
+
+	assert.Equal(t, []*DependencyEdge{
+		{From: "auth_role:auth/userpass/users/alice", To: "auth_method:auth/userpass", Relation: RelationMountedOn},
+		{From: "auth_role:auth/userpass/users/alice", To: "policy:app-read", Relation: RelationAttaches},
+		{From: "cluster:eu-west", To: "cluster:local", Relation: RelationReplicatesFrom, Detail: "performance"},
+		{From: "cluster:local", To: "cluster:https://dr-primary:8201", Relation: RelationReplicatesFrom, Detail: "dr"},
+		{From: "kv_path:secret/app", To: "secrets_engine:secret", Relation: RelationMountedOn},
+		{From: "policy:app-read", To: "kv_path:secret/app", Relation: RelationGrants, Detail: "read"},
+	}, graph.Edges)
+
+	mermaid := graph.RenderMermaid()
+	assert.Contains(t, mermaid, "flowchart LR\n")
+	assert.Contains(t, mermaid, `[("secret (kv)")]`)
+	assert.Contains(t, mermaid, `-->|"replicates_from (performance)"|`)
+	assert.NotContains(t, mermaid, "secret/app\" -->", "Vault paths are not used as Mermaid IDs")
+	assert.Contains(t, graph.RenderDOT(), `"kv_path:secret/app" -> "secrets_engine:secret" [label="mounted_on"];`)
+}
+
+func TestMermaidEscape(t *testing.T) {
+	assert.Equal(t, "say #quot;hi#quot;", mermaidEscape(`say "hi"`))
+}
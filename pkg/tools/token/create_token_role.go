@@ -0,0 +1,153 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package token
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// CreateTokenRole creates a tool for creating and updating roles of the token store
+func CreateTokenRole(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("create_token_role",
+			mcp.WithDescription("Create or update a role of the token store. Tokens created against the role with 'generate_token' get the policies, type and TTLs of the role, and may only be created for the entity aliases the role allows."),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("The name of the token role, for example 'ci-deploy'."),
+			),
+			mcp.WithString("allowed_policies",
+				mcp.DefaultString(""),
+				mcp.Description("A comma-separated list of policies tokens of the role may have. When empty, tokens may have any subset of the policies of the creating token."),
+			),
+			mcp.WithString("token_type",
+				mcp.DefaultString("default-service"),
+				mcp.Enum("service", "batch", "default-service", "default-batch"),
+				mcp.Description("The type of the tokens of the role: 'service' or 'batch' to force the type, 'default-service' or 'default-batch' to let the caller choose. Defaults to 'default-service'."),
+			),
+			mcp.WithBoolean("orphan",
+				mcp.DefaultBool(false),
+				mcp.Description("Create tokens without a parent, so they are not revoked with the token that created them."),
+			),
+			mcp.WithString("token_period",
+				mcp.DefaultString(""),
+				mcp.Description("Make the tokens of the role periodic with this period, such as '24h'."),
+			),
+			mcp.WithString("token_ttl",
+				mcp.DefaultString(""),
+				mcp.Description("The TTL of the tokens of the role, such as '1h'."),
+			),
+			mcp.WithString("token_explicit_max_ttl",
+				mcp.DefaultString(""),
+				mcp.Description("The TTL the tokens of the role can never be renewed beyond, such as '24h'."),
+			),
+			mcp.WithString("allowed_entity_aliases",
+				mcp.DefaultString(""),
+				mcp.Description("A comma-separated list of entity aliases tokens of the role may be created for. Glob patterns such as 'ci-*' are supported."),
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return createTokenRoleHandler(ctx, req, logger)
+		},
+	}
+}
+
+func createTokenRoleHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling create_token_role request")
+
+	name := strings.Trim(req.GetString("name", ""), "/")
+	if name == "" {
+		return mcp.NewToolResultError("Missing or invalid 'name' parameter"), nil
+	}
+
+	tokenType := req.GetString("token_type", "default-service")
+	switch tokenType {
+	case "service", "batch", "default-service", "default-batch":
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'token_type' parameter '%s', expected 'service', 'batch', 'default-service' or 'default-batch'", tokenType)), nil
+	}
+
+	tokenPeriod := req.GetString("token_period", "")
+	if tokenPeriod != "" && tokenType == "batch" {
+		return mcp.NewToolResultError("Batch tokens cannot be periodic, use another 'token_type' or remove 'token_period'"), nil
+	}
+
+	roleData := map[string]interface{}{
+		"allowed_policies":       utils.SplitList(req.GetString("allowed_policies", "")),
+		"token_type":             tokenType,
+		"orphan":                 req.GetBool("orphan", false),
+		"allowed_entity_aliases": utils.SplitList(req.GetString("allowed_entity_aliases", "")),
+	}
+	for _, field := range []string{"token_ttl", "token_explicit_max_ttl"} {
+		if value := req.GetString(field, ""); value != "" {
+			roleData[field] = value
+		}
+	}
+	if tokenPeriod != "" {
+		roleData["token_period"] = tokenPeriod
+	}
+
+	logger.WithFields(log.Fields{
+		"name":       name,
+		"token_type": tokenType,
+	}).Debug("Creating token role with parameters")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	fullPath := "auth/token/roles/" + name
+
+	// The previous state is informational, so errors such as a missing read capability are ignored
+	var previous map[string]any
+	if secret, err := vault.Logical().Read(fullPath); err == nil && secret != nil {
+		previous = secret.Data
+	}
+
+	written, err := vault.Logical().Write(fullPath, roleData)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to write to path '%s': %v", fullPath, err)), nil
+	}
+
+	successMsg := fmt.Sprintf("Successfully created token role '%s'. Create tokens against it with 'generate_token'.", name)
+	if previous != nil {
+		successMsg = fmt.Sprintf("Successfully updated token role '%s'.", name)
+	}
+	if len(roleData["allowed_policies"].([]string)) == 0 {
+		successMsg += " The role allows any policy of the creating token, set 'allowed_policies' to restrict it."
+	}
+
+	logger.WithFields(log.Fields{
+		"name":       name,
+		"token_type": tokenType,
+	}).Info("Successfully created token role")
+
+	change := client.ChangeRecord{
+		ResourceType: client.ResourceTokenRole,
+		Mount:        "token",
+		Path:         fullPath,
+		Operation:    client.OperationCreate,
+		New:          roleData,
+		Message:      successMsg,
+	}
+	if previous != nil {
+		change.Operation = client.OperationUpdate
+		change.Previous = previous
+	}
+	if written != nil {
+		change.VaultRequestID = written.RequestID
+	}
+
+	return client.NewChangeResult(ctx, req, change), nil
+}
@@ -0,0 +1,92 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package token
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTokenRoleHandler(t *testing.T) {
+	logger := newLogger()
+	var capturedBody map[string]interface{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/auth/token/roles/ci-deploy", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			jsonResponse(w, map[string]interface{}{"errors": []string{}})
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&capturedBody)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx, cleanup := newTestContext(t, mux)
+	defer cleanup()
+	sessionID := server.ClientSessionFromContext(ctx).SessionID()
+	defer client.DeleteSessionChanges(sessionID)
+
+	req := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name: "create_token_role",
+			Arguments: map[string]interface{}{
+				"name":                   "ci-deploy",
+				"allowed_policies":       "deploy",
+				"token_type":             "batch",
+				"orphan":                 true,
+				"token_ttl":              "20m",
+				"allowed_entity_aliases": "ci-*",
+			},
+		},
+	}
+
+	result, err := createTokenRoleHandler(ctx, req, logger)
+	require.NoError(t, err)
+	require.False(t, result.IsError, "expected success, got error: %s", getResultText(result))
+	assert.Contains(t, getResultText(result), "Successfully created token role 'ci-deploy'")
+
+	assert.Equal(t, []interface{}{"deploy"}, capturedBody["allowed_policies"])
+	assert.Equal(t, "batch", capturedBody["token_type"])
+	assert.Equal(t, true, capturedBody["orphan"])
+	assert.Equal(t, "20m", capturedBody["token_ttl"])
+	assert.Equal(t, []interface{}{"ci-*"}, capturedBody["allowed_entity_aliases"])
+	assert.NotContains(t, capturedBody, "token_period")
+
+	changes := client.SessionChanges(sessionID)
+	require.Len(t, changes, 1)
+	assert.Equal(t, client.ResourceTokenRole, changes[0].ResourceType)
+	assert.Equal(t, client.OperationCreate, changes[0].Operation)
+	assert.Equal(t, "auth/token/roles/ci-deploy", changes[0].Path)
+}
+
+func TestCreateTokenRoleHandler_InvalidArguments(t *testing.T) {
+	logger := newLogger()
+
+	tests := []struct {
+		name string
+		args map[string]interface{}
+		want string
+	}{
+		{"missing name", map[string]interface{}{}, "'name'"},
+		{"unknown token type", map[string]interface{}{"name": "r", "token_type": "default"}, "Invalid 'token_type'"},
+		{"periodic batch tokens", map[string]interface{}{"name": "r", "token_type": "batch", "token_period": "1h"}, "cannot be periodic"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "create_token_role", Arguments: tt.args}}
+			result, err := createTokenRoleHandler(t.Context(), req, logger)
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+			assert.Contains(t, getResultText(result), tt.want)
+		})
+	}
+}
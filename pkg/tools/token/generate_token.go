@@ -0,0 +1,199 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// Types of tokens created by generate_token
+const (
+	TokenTypeService = "service"
+	TokenTypeBatch   = "batch"
+)
+
+// GeneratedToken is a token created by generate_token
+type GeneratedToken struct {
+	Token         string   `json:"token"`
+	Accessor      string   `json:"accessor,omitempty"` // Accessor of a service token, batch tokens have none
+	Type          string   `json:"type"`
+	Role          string   `json:"role,omitempty"` // Token role the token was created against
+	Policies      []string `json:"policies"`
+	EntityID      string   `json:"entity_id,omitempty"` // Entity of the alias the token was created for
+	Orphan        bool     `json:"orphan"`
+	Renewable     bool     `json:"renewable"`
+	LeaseDuration int      `json:"lease_duration"`     // Seconds until the token expires, or until it must be renewed for periodic tokens
+	Period        string   `json:"period,omitempty"`   // Period of a periodic token, which never expires as long as it is renewed within the period
+	Warnings      []string `json:"warnings,omitempty"` // Warnings from Vault and about the way the token was created
+}
+
+// GenerateToken creates a tool for creating tokens, preferably against a token role
+func GenerateToken(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("generate_token",
+			mcp.WithDescription(`Create a Vault token. Prefer creating tokens against a token role, which fixes the policies, type and TTLs of the tokens and keeps them auditable, over passing policies directly. The token is returned in the result, so only create tokens that are needed.
+## Creating tokens for automation
+  - Review the existing roles with the 'list_token_roles' tool, or create one with the 'create_token_role' tool.
+  - Create the token with this tool, passing the role. Use type 'batch' for short-lived, high-volume workloads and 'period' for long-running services that renew their token.
+  - To tie the token to the identity of a workload, pass an 'entity_alias' allowed by the role.
+`),
+			mcp.WithString("role",
+				mcp.DefaultString(""),
+				mcp.Description("The token role to create the token against, created with 'create_token_role'. The role decides the policies, type and TTLs the token may have."),
+			),
+			mcp.WithString("type",
+				mcp.DefaultString(""),
+				mcp.Description("The type of the token, 'service' or 'batch'. Batch tokens are lightweight and are not stored, but cannot be renewed, revoked by accessor or periodic. Defaults to the type of the role, or 'service'."),
+			),
+			mcp.WithString("policies",
+				mcp.DefaultString(""),
+				mcp.Description("A comma-separated list of policies of the token. With a role, the policies must be allowed by the role."),
+			),
+			mcp.WithString("ttl",
+				mcp.DefaultString(""),
+				mcp.Description("The TTL of the token, such as '1h'. Defaults to the TTL of the role, or of the token store."),
+			),
+			mcp.WithString("period",
+				mcp.DefaultString(""),
+				mcp.Description("Make the token periodic with this period, such as '24h'. A periodic token never expires as long as it is renewed within the period. Not supported by batch tokens."),
+			),
+			mcp.WithString("display_name",
+				mcp.DefaultString(""),
+				mcp.Description("The display name of the token, shown in audit logs and by 'list_token_accessors'."),
+			),
+			mcp.WithString("entity_alias",
+				mcp.DefaultString(""),
+				mcp.Description("Create the token for the entity of this alias, which must be listed in the 'allowed_entity_aliases' of the role. Requires a role."),
+			),
+			mcp.WithNumber("num_uses",
+				mcp.DefaultNumber(0),
+				mcp.Description("The number of times the token can be used, 0 for unlimited uses. Defaults to 0."),
+			),
+			schemas.Output("generate_token"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return generateTokenHandler(ctx, req, logger)
+		},
+	}
+}
+
+func generateTokenHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling generate_token request")
+
+	role := strings.TrimSpace(req.GetString("role", ""))
+	tokenType := req.GetString("type", "")
+	switch tokenType {
+	case "", TokenTypeService, TokenTypeBatch:
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'type' parameter '%s', expected 'service' or 'batch'", tokenType)), nil
+	}
+
+	period := req.GetString("period", "")
+	if period != "" && tokenType == TokenTypeBatch {
+		return mcp.NewToolResultError("Batch tokens cannot be periodic, use type 'service' or remove 'period'"), nil
+	}
+
+	entityAlias := req.GetString("entity_alias", "")
+	if entityAlias != "" && role == "" {
+		return mcp.NewToolResultError("'entity_alias' requires a 'role' whose 'allowed_entity_aliases' include the alias"), nil
+	}
+
+	numUses := req.GetInt("num_uses", 0)
+	if numUses < 0 {
+		return mcp.NewToolResultError("Invalid 'num_uses' parameter, must be at least 0"), nil
+	}
+
+	request := &api.TokenCreateRequest{
+		Policies:    utils.SplitList(req.GetString("policies", "")),
+		TTL:         req.GetString("ttl", ""),
+		Period:      period,
+		DisplayName: req.GetString("display_name", ""),
+		NumUses:     numUses,
+		Type:        tokenType,
+		EntityAlias: entityAlias,
+	}
+
+	logger.WithFields(log.Fields{
+		"role":     role,
+		"type":     tokenType,
+		"policies": request.Policies,
+		"period":   period,
+	}).Debug("Creating token with parameters")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	var secret *api.Secret
+	if role != "" {
+		secret, err = vault.Auth().Token().CreateWithRole(request, role)
+	} else {
+		secret, err = vault.Auth().Token().Create(request)
+	}
+	if err != nil {
+		if role != "" {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to create token with role '%s': %v", role, err)), nil
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create token: %v", err)), nil
+	}
+	if secret == nil || secret.Auth == nil {
+		return mcp.NewToolResultError("Vault did not return a token"), nil
+	}
+
+	token := GeneratedToken{
+		Token:         secret.Auth.ClientToken,
+		Accessor:      secret.Auth.Accessor,
+		Type:          tokenTypeOf(secret.Auth.ClientToken),
+		Role:          role,
+		Policies:      secret.Auth.Policies,
+		EntityID:      secret.Auth.EntityID,
+		Orphan:        secret.Auth.Orphan,
+		Renewable:     secret.Auth.Renewable,
+		LeaseDuration: secret.Auth.LeaseDuration,
+		Period:        period,
+		Warnings:      secret.Warnings,
+	}
+	if token.Policies == nil {
+		token.Policies = []string{}
+	}
+	if role == "" {
+		token.Warnings = append(token.Warnings, "The token was created without a token role, so its policies and TTLs are not governed by a role. Prefer creating a role with 'create_token_role' and creating tokens against it.")
+	}
+
+	jsonData, err := json.Marshal(token)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal token to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"role":     role,
+		"type":     token.Type,
+		"accessor": token.Accessor,
+	}).Info("Successfully created token")
+
+	return mcp.NewToolResultStructured(token, string(jsonData)), nil
+}
+
+// tokenTypeOf returns the type of a token from its prefix, since Vault does not return the type on creation
+func tokenTypeOf(token string) string {
+	if strings.HasPrefix(token, "hvb.") || strings.HasPrefix(token, "b.") {
+		return TokenTypeBatch
+	}
+	return TokenTypeService
+}
@@ -0,0 +1,138 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package token
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateTokenHandler_WithRole(t *testing.T) {
+	logger := newLogger()
+	var capturedPath string
+	var capturedBody map[string]interface{}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&capturedBody)
+		jsonResponse(w, map[string]interface{}{
+			"auth": map[string]interface{}{
+				"client_token":   "hvb.AAAAAQJz0k3v",
+				"policies":       []interface{}{"default", "deploy"},
+				"orphan":         true,
+				"lease_duration": 1200,
+			},
+		})
+	})
+
+	ctx, cleanup := newTestContext(t, handler)
+	defer cleanup()
+
+	req := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name: "generate_token",
+			Arguments: map[string]interface{}{
+				"role": "ci-deploy",
+				"type": "batch",
+				"ttl":  "20m",
+			},
+		},
+	}
+
+	result, err := generateTokenHandler(ctx, req, logger)
+	require.NoError(t, err)
+	require.False(t, result.IsError, "expected success, got error: %s", getResultText(result))
+
+	assert.Equal(t, "/v1/auth/token/create/ci-deploy", capturedPath)
+	assert.Equal(t, "batch", capturedBody["type"])
+	assert.Equal(t, "20m", capturedBody["ttl"])
+
+	var token GeneratedToken
+	require.NoError(t, json.Unmarshal([]byte(getResultText(result)), &token))
+	assert.Equal(t, "hvb.AAAAAQJz0k3v", token.Token)
+	assert.Equal(t, TokenTypeBatch, token.Type)
+	assert.Equal(t, "ci-deploy", token.Role)
+	assert.Equal(t, []string{"default", "deploy"}, token.Policies)
+	assert.True(t, token.Orphan)
+	assert.Equal(t, 1200, token.LeaseDuration)
+	assert.Empty(t, token.Warnings)
+}
+
+func TestGenerateTokenHandler_WithoutRole(t *testing.T) {
+	logger := newLogger()
+	var capturedPath string
+	var capturedBody map[string]interface{}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&capturedBody)
+		jsonResponse(w, map[string]interface{}{
+			"auth": map[string]interface{}{
+				"client_token":   "hvs.CAESIJ7b",
+				"accessor":       "accessor-1",
+				"policies":       []interface{}{"default", "app"},
+				"renewable":      true,
+				"lease_duration": 86400,
+			},
+		})
+	})
+
+	ctx, cleanup := newTestContext(t, handler)
+	defer cleanup()
+
+	req := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name: "generate_token",
+			Arguments: map[string]interface{}{
+				"policies": "app, default",
+				"period":   "24h",
+			},
+		},
+	}
+
+	result, err := generateTokenHandler(ctx, req, logger)
+	require.NoError(t, err)
+	require.False(t, result.IsError, "expected success, got error: %s", getResultText(result))
+
+	assert.Equal(t, "/v1/auth/token/create", capturedPath)
+	assert.Equal(t, []interface{}{"app", "default"}, capturedBody["policies"])
+	assert.Equal(t, "24h", capturedBody["period"])
+
+	var token GeneratedToken
+	require.NoError(t, json.Unmarshal([]byte(getResultText(result)), &token))
+	assert.Equal(t, TokenTypeService, token.Type)
+	assert.Equal(t, "accessor-1", token.Accessor)
+	assert.Equal(t, "24h", token.Period)
+	require.Len(t, token.Warnings, 1, "tokens without a role are flagged")
+	assert.Contains(t, token.Warnings[0], "create_token_role")
+}
+
+func TestGenerateTokenHandler_InvalidArguments(t *testing.T) {
+	logger := newLogger()
+
+	tests := []struct {
+		name string
+		args map[string]interface{}
+		want string
+	}{
+		{"unknown type", map[string]interface{}{"type": "default"}, "Invalid 'type'"},
+		{"periodic batch token", map[string]interface{}{"type": "batch", "period": "1h"}, "cannot be periodic"},
+		{"entity alias without role", map[string]interface{}{"entity_alias": "worker"}, "requires a 'role'"},
+		{"negative uses", map[string]interface{}{"num_uses": -1}, "num_uses"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "generate_token", Arguments: tt.args}}
+			result, err := generateTokenHandler(t.Context(), req, logger)
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+			assert.Contains(t, getResultText(result), tt.want)
+		})
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultMaxAccessorLookups is the default number of accessors looked up by list_token_accessors
+	DefaultMaxAccessorLookups = 500
+
+	// DefaultUnusedDays is the default number of days without renewal after which a token is reported as unused
+	DefaultUnusedDays = 90
+)
+
+// Audit flags reported for tokens
+const (
+	FlagRootToken = "root_token"
+	FlagNoExpiry  = "no_expiry"
+	FlagUnused    = "unused"
+)
+
+type AccessorDetails struct {
+	Accessor        string   `json:"accessor"`
+	DisplayName     string   `json:"display_name"`
+	Path            string   `json:"path,omitempty"` // Login path the token was created through
+	Type            string   `json:"type"`
+	Policies        []string `json:"policies"`
+	Orphan          bool     `json:"orphan"`
+	TTLSeconds      int64    `json:"ttl_seconds"`                 // Remaining TTL, 0 for tokens that never expire
+	CreationTime    string   `json:"creation_time"`               // Creation time in RFC 3339 format
+	LastRenewalTime string   `json:"last_renewal_time,omitempty"` // Last renewal in RFC 3339 format, if the token was ever renewed
+	ExpireTime      string   `json:"expire_time,omitempty"`
+	Flags           []string `json:"flags,omitempty"` // Audit findings for the token
+}
+
+type AccessorReport struct {
+	Total     int               `json:"total"`     // Number of accessors in the token store
+	LookedUp  int               `json:"looked_up"` // Number of accessors that were looked up
+	Truncated bool              `json:"truncated"` // Not every accessor was looked up, raise 'limit' to look up more, or the report reached the maximum result size
+	Tokens    []AccessorDetails `json:"tokens"`    // In audit mode, only the flagged tokens. Empty when the tokens were returned in separate content blocks
+	Warnings  []string          `json:"warnings,omitempty"`
+}
+
+// ListTokenAccessors creates a tool for listing token accessors and auditing the tokens behind them
+func ListTokenAccessors(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("list_token_accessors",
+			mcp.WithDescription("List the accessors of the tokens in the token store with the policies and TTL of each token. In audit mode only tokens that need attention are returned: root tokens, tokens that never expire and tokens that were not renewed or created in the last 'unused_days' days. When more than 500 tokens are returned they are split into several content blocks of 500 tokens, followed by a block with the report. Requires sudo on auth/token/accessors."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithBoolean("audit",
+				mcp.DefaultBool(false),
+				mcp.Description("Only return root tokens, tokens without expiry and unused tokens, with the reasons they were flagged."),
+			),
+			mcp.WithNumber("unused_days",
+				mcp.DefaultNumber(DefaultUnusedDays),
+				mcp.Description("Number of days without renewal after which a token is flagged as unused. Vault does not record when a token was last used, so the last renewal, or the creation time, is used instead. Defaults to 90."),
+			),
+			mcp.WithNumber("limit",
+				mcp.DefaultNumber(DefaultMaxAccessorLookups),
+				mcp.Description("Maximum number of accessors to look up. Defaults to 500."),
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return listTokenAccessorsHandler(ctx, req, logger)
+		},
+	}
+}
+
+func listTokenAccessorsHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling list_token_accessors request")
+
+	audit := req.GetBool("audit", false)
+	unusedDays := req.GetInt("unused_days", DefaultUnusedDays)
+	if unusedDays < 1 {
+		return mcp.NewToolResultError("Invalid 'unused_days' parameter, must be at least 1"), nil
+	}
+	limit := req.GetInt("limit", DefaultMaxAccessorLookups)
+	if limit < 1 {
+		return mcp.NewToolResultError("Invalid 'limit' parameter, must be at least 1"), nil
+	}
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	secret, err := vault.Logical().List("auth/token/accessors")
+	if err != nil {
+		logger.WithError(err).Error("Failed to list token accessors")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list token accessors, this requires sudo on auth/token/accessors: %v", err)), nil
+	}
+
+	var accessors []string
+	if secret != nil && secret.Data != nil {
+		keys, _ := secret.Data["keys"].([]interface{})
+		for _, key := range keys {
+			if accessor, ok := key.(string); ok {
+				accessors = append(accessors, accessor)
+			}
+		}
+	}
+
+	report := &AccessorReport{
+		Total:  len(accessors),
+		Tokens: []AccessorDetails{},
+	}
+	if len(accessors) > limit {
+		accessors = accessors[:limit]
+		report.Truncated = true
+	}
+
+	writer := utils.NewListWriter(ctx, req, len(accessors))
+	now := time.Now()
+	for _, accessor := range accessors {
+		lookup, err := vault.Auth().Token().LookupAccessor(accessor)
+		if err != nil {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("unable to look up accessor '%s': %v", accessor, err))
+			continue
+		}
+		report.LookedUp++
+		if lookup == nil || lookup.Data == nil {
+			continue
+		}
+
+		details := accessorDetails(accessor, lookup)
+		details.Flags = AuditToken(lookup, now, unusedDays)
+		if audit && len(details.Flags) == 0 {
+			continue
+		}
+		if err := writer.Add(details); err != nil {
+			if errors.Is(err, utils.ErrResultTooLarge) {
+				report.Truncated = true
+				break
+			}
+			logger.WithError(err).Error("Failed to marshal token accessors to JSON")
+			return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+		}
+		report.Tokens = append(report.Tokens, details)
+	}
+
+	logger.WithFields(log.Fields{
+		"total":     report.Total,
+		"looked_up": report.LookedUp,
+		"returned":  len(report.Tokens),
+	}).Debug("Successfully listed token accessors")
+
+	// Large reports carry their tokens in separate content blocks
+	if writer.Streamed() {
+		report.Tokens = []AccessorDetails{}
+		result, err := writer.Result(report)
+		if err != nil {
+			logger.WithError(err).Error("Failed to marshal token accessors to JSON")
+			return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+		}
+		return result, nil
+	}
+
+	jsonData, err := json.Marshal(report)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal token accessors to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+// accessorDetails converts the lookup of a token accessor
+func accessorDetails(accessor string, lookup *api.Secret) AccessorDetails {
+	details := AccessorDetails{Accessor: accessor}
+	details.DisplayName, _ = lookup.Data["display_name"].(string)
+	details.Path, _ = lookup.Data["path"].(string)
+	details.Type, _ = lookup.Data["type"].(string)
+	details.Orphan, _ = lookup.Data["orphan"].(bool)
+	details.ExpireTime, _ = lookup.Data["expire_time"].(string)
+	details.Policies, _ = lookup.TokenPolicies()
+
+	ttl, _ := lookup.TokenTTL()
+	details.TTLSeconds = int64(ttl.Seconds())
+
+	if created, ok := unixTime(lookup.Data["creation_time"]); ok {
+		details.CreationTime = created.UTC().Format(time.RFC3339)
+	}
+	if renewed, ok := unixTime(lookup.Data["last_renewal_time"]); ok {
+		details.LastRenewalTime = renewed.UTC().Format(time.RFC3339)
+	}
+
+	return details
+}
+
+// AuditToken returns the audit flags of a looked up token
+func AuditToken(lookup *api.Secret, now time.Time, unusedDays int) []string {
+	var flags []string
+
+	policies, _ := lookup.TokenPolicies()
+	if slices.Contains(policies, "root") {
+		flags = append(flags, FlagRootToken)
+	}
+
+	expireTime, _ := lookup.Data["expire_time"].(string)
+	if ttl, _ := lookup.TokenTTL(); ttl == 0 && expireTime == "" {
+		flags = append(flags, FlagNoExpiry)
+	}
+
+	lastActivity, ok := unixTime(lookup.Data["last_renewal_time"])
+	if !ok {
+		lastActivity, ok = unixTime(lookup.Data["creation_time"])
+	}
+	if ok && now.Sub(lastActivity) > time.Duration(unusedDays)*24*time.Hour {
+		flags = append(flags, FlagUnused)
+	}
+
+	return flags
+}
+
+// unixTime parses a unix timestamp returned by a token lookup
+func unixTime(value interface{}) (time.Time, bool) {
+	number, ok := value.(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+	seconds, err := number.Int64()
+	if err != nil || seconds == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(seconds, 0), true
+}
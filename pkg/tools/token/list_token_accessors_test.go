@@ -0,0 +1,73 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package token
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListTokenAccessorsAudit(t *testing.T) {
+	now := time.Now()
+	tokens := map[string]map[string]interface{}{
+		"root-accessor": {
+			"display_name":  "root",
+			"policies":      []interface{}{"root"},
+			"ttl":           0,
+			"creation_time": now.Add(-time.Hour).Unix(),
+		},
+		"stale-accessor": {
+			"display_name":  "userpass-alice",
+			"policies":      []interface{}{"default"},
+			"ttl":           86400,
+			"expire_time":   now.Add(24 * time.Hour).Format(time.RFC3339),
+			"creation_time": now.Add(-200 * 24 * time.Hour).Unix(),
+		},
+		"healthy-accessor": {
+			"display_name":      "approle",
+			"policies":          []interface{}{"default", "app"},
+			"ttl":               3600,
+			"expire_time":       now.Add(time.Hour).Format(time.RFC3339),
+			"creation_time":     now.Add(-200 * 24 * time.Hour).Unix(),
+			"last_renewal_time": now.Add(-time.Hour).Unix(),
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/auth/token/accessors", func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, map[string]interface{}{
+			"data": map[string]interface{}{"keys": []string{"root-accessor", "stale-accessor", "healthy-accessor"}},
+		})
+	})
+	mux.HandleFunc("/v1/auth/token/lookup-accessor", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		jsonResponse(w, map[string]interface{}{"data": tokens[body["accessor"]]})
+	})
+
+	ctx, cleanup := newTestContext(t, mux)
+	defer cleanup()
+
+	result, err := ListTokenAccessors(newLogger()).Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{
+		Arguments: map[string]interface{}{"audit": true},
+	}})
+	require.NoError(t, err)
+	require.False(t, result.IsError, getResultText(result))
+
+	var report AccessorReport
+	require.NoError(t, json.Unmarshal([]byte(getResultText(result)), &report))
+	assert.Equal(t, 3, report.Total)
+	assert.Equal(t, 3, report.LookedUp)
+	require.Len(t, report.Tokens, 2)
+	assert.Equal(t, "root-accessor", report.Tokens[0].Accessor)
+	assert.Equal(t, []string{FlagRootToken, FlagNoExpiry}, report.Tokens[0].Flags)
+	assert.Equal(t, "stale-accessor", report.Tokens[1].Accessor)
+	assert.Equal(t, []string{FlagUnused}, report.Tokens[1].Flags)
+}
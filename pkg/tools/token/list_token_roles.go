@@ -0,0 +1,124 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// TokenRole describes a role of the token store
+type TokenRole struct {
+	Name                 string   `json:"name"`
+	AllowedPolicies      []string `json:"allowed_policies"`
+	TokenType            string   `json:"token_type"`
+	Orphan               bool     `json:"orphan"`
+	TokenPeriod          int64    `json:"token_period,omitempty"`           // Period of the tokens in seconds, 0 for tokens that are not periodic
+	TokenTTL             int64    `json:"token_ttl,omitempty"`              // TTL of the tokens in seconds, 0 for the TTL of the token store
+	TokenExplicitMaxTTL  int64    `json:"token_explicit_max_ttl,omitempty"` // Maximum TTL of the tokens in seconds, 0 for no maximum
+	AllowedEntityAliases []string `json:"allowed_entity_aliases,omitempty"`
+}
+
+// ListTokenRoles creates a tool for listing the roles of the token store
+func ListTokenRoles(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("list_token_roles",
+			mcp.WithDescription("Get the roles of the token store with the policies, type, TTLs and entity aliases of the tokens created against them with 'generate_token'."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return listTokenRolesHandler(ctx, req, logger)
+		},
+	}
+}
+
+func listTokenRolesHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling list_token_roles request")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	secret, err := vault.Logical().List("auth/token/roles")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list path 'auth/token/roles': %v", err)), nil
+	}
+
+	roles := []TokenRole{}
+	if secret != nil && secret.Data != nil {
+		keys, _ := secret.Data["keys"].([]interface{})
+		for _, key := range keys {
+			name, ok := key.(string)
+			if !ok {
+				continue
+			}
+			rolePath := "auth/token/roles/" + name
+			roleSecret, err := vault.Logical().Read(rolePath)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to read path '%s': %v", rolePath, err)), nil
+			}
+			if roleSecret == nil || roleSecret.Data == nil {
+				continue
+			}
+
+			role := TokenRole{
+				Name:                 name,
+				AllowedPolicies:      toStrings(roleSecret.Data["allowed_policies"]),
+				AllowedEntityAliases: toStrings(roleSecret.Data["allowed_entity_aliases"]),
+				TokenPeriod:          toSeconds(roleSecret.Data["token_period"]),
+				TokenTTL:             toSeconds(roleSecret.Data["token_ttl"]),
+				TokenExplicitMaxTTL:  toSeconds(roleSecret.Data["token_explicit_max_ttl"]),
+			}
+			role.TokenType, _ = roleSecret.Data["token_type"].(string)
+			role.Orphan, _ = roleSecret.Data["orphan"].(bool)
+			roles = append(roles, role)
+		}
+	}
+
+	jsonData, err := json.Marshal(roles)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal roles to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithField("roles", len(roles)).Debug("Successfully listed token roles")
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+// toStrings converts a list returned by Vault, which is empty rather than nil when Vault returns nothing
+func toStrings(value interface{}) []string {
+	strs := []string{}
+	items, _ := value.([]interface{})
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			strs = append(strs, s)
+		}
+	}
+	return strs
+}
+
+// toSeconds converts a duration in seconds returned by Vault
+func toSeconds(value interface{}) int64 {
+	number, ok := value.(json.Number)
+	if !ok {
+		return 0
+	}
+	seconds, _ := number.Int64()
+	return seconds
+}
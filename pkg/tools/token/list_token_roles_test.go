@@ -0,0 +1,56 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package token
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListTokenRolesHandler(t *testing.T) {
+	logger := newLogger()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/auth/token/roles", func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, map[string]interface{}{
+			"data": map[string]interface{}{"keys": []interface{}{"ci-deploy"}},
+		})
+	})
+	mux.HandleFunc("/v1/auth/token/roles/ci-deploy", func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, map[string]interface{}{
+			"data": map[string]interface{}{
+				"allowed_policies":       []interface{}{"deploy"},
+				"allowed_entity_aliases": []interface{}{"ci-*"},
+				"token_type":             "batch",
+				"orphan":                 true,
+				"token_period":           0,
+				"token_ttl":              1200,
+				"token_explicit_max_ttl": 0,
+			},
+		})
+	})
+
+	ctx, cleanup := newTestContext(t, mux)
+	defer cleanup()
+
+	result, err := listTokenRolesHandler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "list_token_roles"}}, logger)
+	require.NoError(t, err)
+	require.False(t, result.IsError, "expected success, got error: %s", getResultText(result))
+
+	var roles []TokenRole
+	require.NoError(t, json.Unmarshal([]byte(getResultText(result)), &roles))
+	assert.Equal(t, []TokenRole{{
+		Name:                 "ci-deploy",
+		AllowedPolicies:      []string{"deploy"},
+		TokenType:            "batch",
+		Orphan:               true,
+		TokenTTL:             1200,
+		AllowedEntityAliases: []string{"ci-*"},
+	}}, roles)
+}
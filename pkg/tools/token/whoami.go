@@ -0,0 +1,178 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultCapabilityPaths are the paths checked by whoami when no paths are given
+var DefaultCapabilityPaths = []string{
+	"sys/mounts",
+	"sys/auth",
+	"sys/policies/acl",
+	"auth/token/create",
+}
+
+type TokenDetails struct {
+	DisplayName  string              `json:"display_name"`          // Display name of the token
+	Accessor     string              `json:"accessor"`              // Accessor of the token
+	Type         string              `json:"type"`                  // Token type (service or batch)
+	Orphan       bool                `json:"orphan"`                // Whether the token is an orphan
+	Renewable    bool                `json:"renewable"`             // Whether the token can be renewed
+	TTL          string              `json:"ttl"`                   // Remaining TTL of the token, or 'never' for non-expiring tokens
+	TTLSeconds   int64               `json:"ttl_seconds"`           // Remaining TTL of the token in seconds
+	ExpireTime   string              `json:"expire_time,omitempty"` // Expiry time of the token, if any
+	Policies     map[string]string   `json:"policies"`              // Attached policies and their rules, if readable
+	EntityID     string              `json:"entity_id,omitempty"`   // Identity entity of the token, if any
+	Entity       map[string]any      `json:"entity,omitempty"`      // Identity entity details, if readable
+	Capabilities map[string][]string `json:"capabilities"`          // Effective capabilities on the checked paths
+	Warnings     []string            `json:"warnings,omitempty"`    // Lookups that could not be completed
+}
+
+// WhoAmI creates a tool for introspecting the token used by the session
+func WhoAmI(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("whoami",
+			mcp.WithDescription("Describe the Vault token used by this session: its policies and their rules, identity entity, remaining TTL and the effective capabilities on key paths. Use this before other tools to find out what you are allowed to do."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithString("paths",
+				mcp.DefaultString(""),
+				mcp.Description("Optional comma separated list of paths to check capabilities on, such as 'secrets/data/app,pki/issue/web'. Defaults to 'sys/mounts,sys/auth,sys/policies/acl,auth/token/create'."),
+			),
+			schemas.Output("whoami"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return whoAmIHandler(ctx, req, logger)
+		},
+	}
+}
+
+func whoAmIHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling whoami request")
+
+	paths := DefaultCapabilityPaths
+	if args, ok := req.Params.Arguments.(map[string]interface{}); ok {
+		if pathsStr, ok := args["paths"].(string); ok && pathsStr != "" {
+			paths = nil
+			for _, p := range strings.Split(pathsStr, ",") {
+				if p = strings.Trim(strings.TrimSpace(p), "/"); p != "" {
+					paths = append(paths, p)
+				}
+			}
+		}
+	}
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	secret, err := vault.Auth().Token().LookupSelf()
+	if err != nil {
+		logger.WithError(err).Error("Failed to look up token")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to look up token: %v", err)), nil
+	}
+	if secret == nil || secret.Data == nil {
+		return mcp.NewToolResultError("Token lookup returned no data"), nil
+	}
+
+	result := &TokenDetails{
+		Policies:     make(map[string]string),
+		Capabilities: make(map[string][]string),
+	}
+
+	result.DisplayName, _ = secret.Data["display_name"].(string)
+	result.Type, _ = secret.Data["type"].(string)
+	result.Orphan, _ = secret.Data["orphan"].(bool)
+	result.EntityID, _ = secret.Data["entity_id"].(string)
+	result.Accessor, _ = secret.TokenAccessor()
+	result.Renewable, _ = secret.TokenIsRenewable()
+
+	ttl, _ := secret.TokenTTL()
+	result.TTLSeconds = int64(ttl.Seconds())
+	if ttl == 0 {
+		result.TTL = "never"
+	} else {
+		result.TTL = ttl.String()
+	}
+	if expireTime, ok := secret.Data["expire_time"].(string); ok {
+		result.ExpireTime = expireTime
+	}
+
+	// Expand the attached policies (token and identity policies) into their rules
+	policies, _ := secret.TokenPolicies()
+	for _, policy := range policies {
+		if policy == "root" {
+			result.Policies[policy] = "root policy: all capabilities on all paths"
+			continue
+		}
+		rules, err := vault.Sys().GetPolicy(policy)
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("unable to read policy '%s': %v", policy, err))
+			result.Policies[policy] = ""
+			continue
+		}
+		result.Policies[policy] = rules
+	}
+
+	// Resolve the identity entity if the token has one
+	if result.EntityID != "" {
+		entity, err := vault.Logical().Read(fmt.Sprintf("identity/entity/id/%s", result.EntityID))
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("unable to read identity entity '%s': %v", result.EntityID, err))
+		} else if entity != nil {
+			result.Entity = map[string]any{
+				"name":      entity.Data["name"],
+				"aliases":   entity.Data["aliases"],
+				"group_ids": entity.Data["group_ids"],
+				"policies":  entity.Data["policies"],
+				"disabled":  entity.Data["disabled"],
+			}
+		}
+	}
+
+	// Check the effective capabilities on the requested paths, cached for the session
+	capabilities, err := client.SelfCapabilities(ctx, vault, paths)
+	if err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("unable to check capabilities: %v", err))
+	} else {
+		for p, caps := range capabilities {
+			if len(caps) > 0 {
+				result.Capabilities[p] = caps
+			}
+		}
+	}
+
+	// Marshal the struct to JSON
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal token details to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"policy_count": len(result.Policies),
+		"ttl":          time.Duration(result.TTLSeconds) * time.Second,
+	}).Debug("Successfully looked up token")
+
+	return mcp.NewToolResultStructured(result, string(jsonData)), nil
+}
@@ -0,0 +1,94 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package token
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWhoAmIHandler(t *testing.T) {
+	logger := newLogger()
+	var capturedPaths []interface{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/auth/token/lookup-self", func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, map[string]interface{}{
+			"data": map[string]interface{}{
+				"accessor":          "accessor-123",
+				"display_name":      "token-agent",
+				"entity_id":         "entity-1",
+				"policies":          []interface{}{"default", "app-read"},
+				"identity_policies": []interface{}{"team"},
+				"ttl":               3600,
+				"renewable":         true,
+				"type":              "service",
+			},
+		})
+	})
+	mux.HandleFunc("/v1/sys/policies/acl/app-read", func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, map[string]interface{}{
+			"data": map[string]interface{}{
+				"name":   "app-read",
+				"policy": `path "secrets/*" { capabilities = ["read"] }`,
+			},
+		})
+	})
+	mux.HandleFunc("/v1/sys/policies/acl/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		jsonResponse(w, map[string]interface{}{"errors": []string{"permission denied"}})
+	})
+	mux.HandleFunc("/v1/identity/entity/id/entity-1", func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, map[string]interface{}{
+			"data": map[string]interface{}{
+				"name": "agent",
+			},
+		})
+	})
+	mux.HandleFunc("/v1/sys/capabilities-self", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		capturedPaths, _ = body["paths"].([]interface{})
+		jsonResponse(w, map[string]interface{}{
+			"data": map[string]interface{}{
+				"secrets/data/app": []interface{}{"read"},
+			},
+		})
+	})
+
+	ctx, cleanup := newTestContext(t, mux)
+	defer cleanup()
+
+	req := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name: "whoami",
+			Arguments: map[string]interface{}{
+				"paths": "secrets/data/app/",
+			},
+		},
+	}
+
+	result, err := whoAmIHandler(ctx, req, logger)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.False(t, result.IsError, "expected success, got error: %s", getResultText(result))
+
+	var details TokenDetails
+	require.NoError(t, json.Unmarshal([]byte(getResultText(result)), &details))
+
+	assert.Equal(t, "accessor-123", details.Accessor)
+	assert.Equal(t, int64(3600), details.TTLSeconds)
+	assert.Equal(t, "1h0m0s", details.TTL)
+	assert.Contains(t, details.Policies["app-read"], "secrets/*")
+	assert.Contains(t, details.Policies, "team", "identity policies should be expanded")
+	assert.Equal(t, "agent", details.Entity["name"])
+	assert.Equal(t, []string{"read"}, details.Capabilities["secrets/data/app"])
+	assert.Equal(t, []interface{}{"secrets/data/app"}, capturedPaths, "paths should be trimmed of slashes")
+	assert.NotEmpty(t, details.Warnings, "unreadable policies should be reported as warnings")
+}
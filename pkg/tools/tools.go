@@ -4,13 +4,30 @@
 package tools
 
 import (
+	"os"
+	"slices"
+	"strconv"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/auth"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/database"
 	"github.com/hashicorp/vault-mcp-server/pkg/tools/kv"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/mongodbatlas"
 	"github.com/hashicorp/vault-mcp-server/pkg/tools/pki"
 	"github.com/hashicorp/vault-mcp-server/pkg/tools/sys"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/token"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/transform"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/transit"
 	"github.com/mark3labs/mcp-go/server"
 	log "github.com/sirupsen/logrus"
 )
 
+// EnableAdminToolsEnv is the environment variable that opts in to the tools that need broad sys/ access
+const EnableAdminToolsEnv = "MCP_ENABLE_ADMIN_TOOLS"
+
+// ToolCategories are the categories of tools that are always registered
+var ToolCategories = []string{"mounts", "kv", "pki", "managed_keys", "transform", "transit", "database", "mongodb_atlas", "auth", "mfa", "token"}
+
 func InitTools(hcServer *server.MCPServer, logger *log.Logger) {
 
 	// Tools for Vault mount management
@@ -23,6 +40,78 @@ func InitTools(hcServer *server.MCPServer, logger *log.Logger) {
 	deleteMountTool := sys.DeleteMount(logger)
 	hcServer.AddTool(deleteMountTool.Tool, deleteMountTool.Handler)
 
+	listSecretEnginesHealthTool := sys.ListSecretEnginesHealth(logger)
+	hcServer.AddTool(listSecretEnginesHealthTool.Tool, listSecretEnginesHealthTool.Handler)
+
+	analyzeServerConfigTool := sys.AnalyzeServerConfig(logger)
+	hcServer.AddTool(analyzeServerConfigTool.Tool, analyzeServerConfigTool.Handler)
+
+	checkMountProtectionTool := sys.CheckMountProtection(logger)
+	hcServer.AddTool(checkMountProtectionTool.Tool, checkMountProtectionTool.Handler)
+
+	estimateMountUsageTool := sys.EstimateMountUsage(logger)
+	hcServer.AddTool(estimateMountUsageTool.Tool, estimateMountUsageTool.Handler)
+
+	checkRotationSLATool := sys.CheckRotationSLA(logger)
+	hcServer.AddTool(checkRotationSLATool.Tool, checkRotationSLATool.Handler)
+
+	analyzeDeprecationsTool := sys.AnalyzeDeprecations(logger)
+	hcServer.AddTool(analyzeDeprecationsTool.Tool, analyzeDeprecationsTool.Handler)
+
+	// Tool for the secrets engines without dedicated tools, only registered for allowlisted mounts
+	secretEngineAllowlist := sys.LoadSecretEngineAllowlistFromEnv()
+	if len(secretEngineAllowlist) > 0 {
+		callSecretEngineTool := sys.CallSecretEngine(secretEngineAllowlist, logger)
+		hcServer.AddTool(callSecretEngineTool.Tool, callSecretEngineTool.Handler)
+	}
+
+	// Tool for the sys/ endpoints without dedicated tools, only registered for allowlisted endpoints
+	sysEndpointAllowlist := sys.LoadSysEndpointAllowlistFromEnv()
+	if len(sysEndpointAllowlist) > 0 {
+		callSysEndpointTool := sys.CallSysEndpoint(sysEndpointAllowlist, logger)
+		hcServer.AddTool(callSysEndpointTool.Tool, callSysEndpointTool.Handler)
+	}
+
+	// Tool resolving secret references into their values, only registered for allowlisted paths
+	referenceAllowlist := sys.LoadReferenceAllowlistFromEnv()
+	if len(referenceAllowlist) > 0 {
+		resolveReferenceTool := sys.ResolveReference(referenceAllowlist, logger)
+		hcServer.AddTool(resolveReferenceTool.Tool, resolveReferenceTool.Handler)
+	}
+
+	// Tools for cluster administration, only registered when opted in
+	if adminToolsEnabled(logger) {
+		analyzeSecurityHealthTool := sys.AnalyzeSecurityHealth(logger)
+		hcServer.AddTool(analyzeSecurityHealthTool.Tool, analyzeSecurityHealthTool.Handler)
+
+		getReplicationStatusTool := sys.GetReplicationStatus(logger)
+		hcServer.AddTool(getReplicationStatusTool.Tool, getReplicationStatusTool.Handler)
+
+		checkUpgradeReadinessTool := sys.CheckUpgradeReadiness(logger)
+		hcServer.AddTool(checkUpgradeReadinessTool.Tool, checkUpgradeReadinessTool.Handler)
+
+		mapDependenciesTool := sys.MapDependencies(logger)
+		hcServer.AddTool(mapDependenciesTool.Tool, mapDependenciesTool.Handler)
+
+		getRateLimitQuotasTool := sys.GetRateLimitQuotas(logger)
+		hcServer.AddTool(getRateLimitQuotasTool.Tool, getRateLimitQuotasTool.Handler)
+
+		simulateQuotaImpactTool := sys.SimulateQuotaImpact(sys.LoadQuotaThresholdsFromEnv(), logger)
+		hcServer.AddTool(simulateQuotaImpactTool.Tool, simulateQuotaImpactTool.Handler)
+
+		configureUIHeadersTool := sys.ConfigureUIHeaders(logger)
+		hcServer.AddTool(configureUIHeadersTool.Tool, configureUIHeadersTool.Handler)
+
+		runTidyTool := sys.RunTidy(logger)
+		hcServer.AddTool(runTidyTool.Tool, runTidyTool.Handler)
+
+		bootstrapVaultLayoutTool := sys.BootstrapVaultLayout(logger)
+		hcServer.AddTool(bootstrapVaultLayoutTool.Tool, bootstrapVaultLayoutTool.Handler)
+
+		provisionTeamNamespaceTool := sys.ProvisionTeamNamespace(logger)
+		hcServer.AddTool(provisionTeamNamespaceTool.Tool, provisionTeamNamespaceTool.Handler)
+	}
+
 	// Tools for KV secrets management
 	listSecretsTool := kv.ListSecrets(logger)
 	hcServer.AddTool(listSecretsTool.Tool, listSecretsTool.Handler)
@@ -30,12 +119,28 @@ func InitTools(hcServer *server.MCPServer, logger *log.Logger) {
 	readSecretTool := kv.ReadSecret(logger)
 	hcServer.AddTool(readSecretTool.Tool, readSecretTool.Handler)
 
+	readSecretStructureTool := kv.ReadSecretStructure(logger)
+	hcServer.AddTool(readSecretStructureTool.Tool, readSecretStructureTool.Handler)
+
+	checkSecretComplianceTool := kv.CheckSecretCompliance(logger)
+	hcServer.AddTool(checkSecretComplianceTool.Tool, checkSecretComplianceTool.Handler)
+
 	writeSecretTool := kv.WriteSecret(logger)
 	hcServer.AddTool(writeSecretTool.Tool, writeSecretTool.Handler)
 
 	deleteSecretTool := kv.DeleteSecret(logger)
 	hcServer.AddTool(deleteSecretTool.Tool, deleteSecretTool.Handler)
 
+	// Tools for backing up and restoring KV subtrees, only registered when a backup directory is configured
+	backupConfig := kv.LoadBackupConfigFromEnv()
+	if backupConfig.Enabled() {
+		backupKVSubtreeTool := kv.BackupKVSubtree(backupConfig, logger)
+		hcServer.AddTool(backupKVSubtreeTool.Tool, backupKVSubtreeTool.Handler)
+
+		restoreKVSubtreeTool := kv.RestoreKVSubtree(backupConfig, logger)
+		hcServer.AddTool(restoreKVSubtreeTool.Tool, restoreKVSubtreeTool.Handler)
+	}
+
 	// Tools for PKI management
 	enablePkiTool := pki.EnablePki(logger)
 	hcServer.AddTool(enablePkiTool.Tool, enablePkiTool.Handler)
@@ -49,6 +154,27 @@ func InitTools(hcServer *server.MCPServer, logger *log.Logger) {
 	readPkiIssuer := pki.ReadPkiIssuer(logger)
 	hcServer.AddTool(readPkiIssuer.Tool, readPkiIssuer.Handler)
 
+	importPkiIssuer := pki.ImportPkiIssuer(logger)
+	hcServer.AddTool(importPkiIssuer.Tool, importPkiIssuer.Handler)
+
+	generatePkiIntermediateCSR := pki.GeneratePkiIntermediateCSR(logger)
+	hcServer.AddTool(generatePkiIntermediateCSR.Tool, generatePkiIntermediateCSR.Handler)
+
+	setPkiSignedIntermediate := pki.SetPkiSignedIntermediate(logger)
+	hcServer.AddTool(setPkiSignedIntermediate.Tool, setPkiSignedIntermediate.Handler)
+
+	crossSignPkiIssuer := pki.CrossSignPkiIssuer(logger)
+	hcServer.AddTool(crossSignPkiIssuer.Tool, crossSignPkiIssuer.Handler)
+
+	listPkiKeys := pki.ListPkiKeys(logger)
+	hcServer.AddTool(listPkiKeys.Tool, listPkiKeys.Handler)
+
+	readPkiKey := pki.ReadPkiKey(logger)
+	hcServer.AddTool(readPkiKey.Tool, readPkiKey.Handler)
+
+	generatePkiKey := pki.GeneratePkiKey(logger)
+	hcServer.AddTool(generatePkiKey.Tool, generatePkiKey.Handler)
+
 	listPkiRoles := pki.ListPkiRoles(logger)
 	hcServer.AddTool(listPkiRoles.Tool, listPkiRoles.Handler)
 
@@ -63,4 +189,206 @@ func InitTools(hcServer *server.MCPServer, logger *log.Logger) {
 
 	issuePkiCertificate := pki.IssuePkiCertificate(logger)
 	hcServer.AddTool(issuePkiCertificate.Tool, issuePkiCertificate.Handler)
+
+	bulkIssuePkiCertificates := pki.BulkIssuePkiCertificates(logger)
+	hcServer.AddTool(bulkIssuePkiCertificates.Tool, bulkIssuePkiCertificates.Handler)
+
+	verifyCertificate := pki.VerifyCertificate(logger)
+	hcServer.AddTool(verifyCertificate.Tool, verifyCertificate.Handler)
+
+	// Tools for managed keys (Vault Enterprise)
+	listManagedKeys := sys.ListManagedKeys(logger)
+	hcServer.AddTool(listManagedKeys.Tool, listManagedKeys.Handler)
+
+	readManagedKey := sys.ReadManagedKey(logger)
+	hcServer.AddTool(readManagedKey.Tool, readManagedKey.Handler)
+
+	attachManagedKey := sys.AttachManagedKey(logger)
+	hcServer.AddTool(attachManagedKey.Tool, attachManagedKey.Handler)
+
+	// Tools for the Transform secrets engine (Vault Enterprise)
+	enableTransform := transform.EnableTransform(logger)
+	hcServer.AddTool(enableTransform.Tool, enableTransform.Handler)
+
+	createTransformTemplate := transform.CreateTransformTemplate(logger)
+	hcServer.AddTool(createTransformTemplate.Tool, createTransformTemplate.Handler)
+
+	createTransformTransformation := transform.CreateTransformTransformation(logger)
+	hcServer.AddTool(createTransformTransformation.Tool, createTransformTransformation.Handler)
+
+	createTransformRole := transform.CreateTransformRole(logger)
+	hcServer.AddTool(createTransformRole.Tool, createTransformRole.Handler)
+
+	listTransformRoles := transform.ListTransformRoles(logger)
+	hcServer.AddTool(listTransformRoles.Tool, listTransformRoles.Handler)
+
+	transformEncode := transform.TransformEncode(logger)
+	hcServer.AddTool(transformEncode.Tool, transformEncode.Handler)
+
+	transformDecode := transform.TransformDecode(logger)
+	hcServer.AddTool(transformDecode.Tool, transformDecode.Handler)
+
+	// Tools for the transit secrets engine
+	enableTransit := transit.EnableTransit(logger)
+	hcServer.AddTool(enableTransit.Tool, enableTransit.Handler)
+
+	createTransitKey := transit.CreateTransitKey(logger)
+	hcServer.AddTool(createTransitKey.Tool, createTransitKey.Handler)
+
+	encryptData := transit.EncryptData(logger)
+	hcServer.AddTool(encryptData.Tool, encryptData.Handler)
+
+	decryptData := transit.DecryptData(logger)
+	hcServer.AddTool(decryptData.Tool, decryptData.Handler)
+
+	rewrapData := transit.RewrapData(logger)
+	hcServer.AddTool(rewrapData.Tool, rewrapData.Handler)
+
+	signData := transit.SignData(logger)
+	hcServer.AddTool(signData.Tool, signData.Handler)
+
+	verifyData := transit.VerifyData(logger)
+	hcServer.AddTool(verifyData.Tool, verifyData.Handler)
+
+	// Tools for the database secrets engine with the Redis and ElastiCache plugins
+	enableDatabase := database.EnableDatabase(logger)
+	hcServer.AddTool(enableDatabase.Tool, enableDatabase.Handler)
+
+	configureRedisConnection := database.ConfigureRedisConnection(logger)
+	hcServer.AddTool(configureRedisConnection.Tool, configureRedisConnection.Handler)
+
+	createRedisRole := database.CreateRedisRole(logger)
+	hcServer.AddTool(createRedisRole.Tool, createRedisRole.Handler)
+
+	generateRedisCredentials := database.GenerateRedisCredentials(logger)
+	hcServer.AddTool(generateRedisCredentials.Tool, generateRedisCredentials.Handler)
+
+	// Tools for the MongoDB Atlas secrets engine
+	configureMongoDBAtlas := mongodbatlas.ConfigureMongoDBAtlas(logger)
+	hcServer.AddTool(configureMongoDBAtlas.Tool, configureMongoDBAtlas.Handler)
+
+	createMongoDBAtlasRole := mongodbatlas.CreateMongoDBAtlasRole(logger)
+	hcServer.AddTool(createMongoDBAtlasRole.Tool, createMongoDBAtlasRole.Handler)
+
+	generateMongoDBAtlasKey := mongodbatlas.GenerateMongoDBAtlasKey(logger)
+	hcServer.AddTool(generateMongoDBAtlasKey.Tool, generateMongoDBAtlasKey.Handler)
+
+	// Tools for auth method management
+	configureGitHubAuth := auth.ConfigureGitHubAuth(logger)
+	hcServer.AddTool(configureGitHubAuth.Tool, configureGitHubAuth.Handler)
+
+	mapGitHubTeam := auth.MapGitHubTeam(logger)
+	hcServer.AddTool(mapGitHubTeam.Tool, mapGitHubTeam.Handler)
+
+	listGitHubMappings := auth.ListGitHubMappings(logger)
+	hcServer.AddTool(listGitHubMappings.Tool, listGitHubMappings.Handler)
+
+	createCertAuthRole := auth.CreateCertAuthRole(logger)
+	hcServer.AddTool(createCertAuthRole.Tool, createCertAuthRole.Handler)
+
+	listCertAuthRoles := auth.ListCertAuthRoles(logger)
+	hcServer.AddTool(listCertAuthRoles.Tool, listCertAuthRoles.Handler)
+
+	testAuthLogin := auth.TestAuthLogin(logger)
+	hcServer.AddTool(testAuthLogin.Tool, testAuthLogin.Handler)
+
+	// Tools for login MFA
+	listMFAMethods := auth.ListMFAMethods(logger)
+	hcServer.AddTool(listMFAMethods.Tool, listMFAMethods.Handler)
+
+	createMFAMethod := auth.CreateMFAMethod(logger)
+	hcServer.AddTool(createMFAMethod.Tool, createMFAMethod.Handler)
+
+	listMFALoginEnforcements := auth.ListMFALoginEnforcements(logger)
+	hcServer.AddTool(listMFALoginEnforcements.Tool, listMFALoginEnforcements.Handler)
+
+	createMFALoginEnforcement := auth.CreateMFALoginEnforcement(logger)
+	hcServer.AddTool(createMFALoginEnforcement.Tool, createMFALoginEnforcement.Handler)
+
+	// Tools for token introspection
+	whoAmITool := token.WhoAmI(logger)
+	hcServer.AddTool(whoAmITool.Tool, whoAmITool.Handler)
+
+	listTokenAccessorsTool := token.ListTokenAccessors(logger)
+	hcServer.AddTool(listTokenAccessorsTool.Tool, listTokenAccessorsTool.Handler)
+
+	// Tools for creating tokens against token roles
+	generateTokenTool := token.GenerateToken(logger)
+	hcServer.AddTool(generateTokenTool.Tool, generateTokenTool.Handler)
+
+	createTokenRoleTool := token.CreateTokenRole(logger)
+	hcServer.AddTool(createTokenRoleTool.Tool, createTokenRoleTool.Handler)
+
+	listTokenRolesTool := token.ListTokenRoles(logger)
+	hcServer.AddTool(listTokenRolesTool.Tool, listTokenRolesTool.Handler)
+
+	// Tools of third-party tool packs, compiled in or loaded as plugins
+	providerCategories := addProviderTools(hcServer, append(ToolProviders(), LoadToolPluginsFromEnv(logger)...), logger)
+
+	// Every tool accepts an optional namespace that overrides the namespace of the session
+	for _, tool := range hcServer.ListTools() {
+		hcServer.AddTool(client.WithNamespaceArgument(tool.Tool), tool.Handler)
+	}
+
+	// Tools describing the MCP server and the session, which do not depend on a namespace
+	categories := ToolCategories
+	if adminToolsEnabled(logger) {
+		categories = append(slices.Clone(categories), "admin")
+	}
+	if len(secretEngineAllowlist) > 0 {
+		categories = append(slices.Clone(categories), "secret_engine")
+	}
+	if len(sysEndpointAllowlist) > 0 {
+		categories = append(slices.Clone(categories), "sys_endpoint")
+	}
+	if len(referenceAllowlist) > 0 {
+		categories = append(slices.Clone(categories), "resolve_reference")
+	}
+	if backupConfig.Enabled() {
+		categories = append(slices.Clone(categories), "kv_backup")
+	}
+	if len(providerCategories) > 0 {
+		categories = append(slices.Clone(categories), providerCategories...)
+	}
+	serverInfoTool := ServerInfo(hcServer, categories, logger)
+	hcServer.AddTool(serverInfoTool.Tool, serverInfoTool.Handler)
+
+	sessionChangesTool := SessionChanges(logger)
+	hcServer.AddTool(sessionChangesTool.Tool, sessionChangesTool.Handler)
+
+	undoLastChangeTool := UndoLastChange(logger)
+	hcServer.AddTool(undoLastChangeTool.Tool, undoLastChangeTool.Handler)
+
+	generatePolicyFromSessionTool := GeneratePolicyFromSession(logger)
+	hcServer.AddTool(generatePolicyFromSessionTool.Tool, generatePolicyFromSessionTool.Handler)
+
+	if approvalConfig := client.LoadApprovalConfigFromEnv(); approvalConfig.Enabled() {
+		approvalStatusTool := GetApprovalStatus(logger)
+		hcServer.AddTool(approvalStatusTool.Tool, approvalStatusTool.Handler)
+
+		// Calls queued for approval return the approval instead of the result of the tool, so the tools
+		// that can be queued declare no output schema
+		for _, tool := range hcServer.ListTools() {
+			if tool.Tool.RawOutputSchema != nil && approvalConfig.Requires(tool.Tool) {
+				tool.Tool.RawOutputSchema = nil
+				hcServer.AddTool(tool.Tool, tool.Handler)
+			}
+		}
+	}
+}
+
+// adminToolsEnabled reports whether MCP_ENABLE_ADMIN_TOOLS is set to a true value
+func adminToolsEnabled(logger *log.Logger) bool {
+	value := os.Getenv(EnableAdminToolsEnv)
+	if value == "" {
+		return false
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		logger.Warnf("Invalid %s value '%s', admin tools are disabled", EnableAdminToolsEnv, value)
+		return false
+	}
+
+	return enabled
 }
@@ -0,0 +1,104 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitToolsAdminTools(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	hcServer := server.NewMCPServer("test", "1.0.0")
+	InitTools(hcServer, logger)
+	assert.Nil(t, hcServer.GetTool("analyze_security_health"), "admin tools should not be registered by default")
+	assert.NotNil(t, hcServer.GetTool("analyze_server_config"))
+
+	t.Setenv(EnableAdminToolsEnv, "true")
+	hcServer = server.NewMCPServer("test", "1.0.0")
+	InitTools(hcServer, logger)
+	for _, name := range []string{"analyze_security_health", "get_replication_status", "get_rate_limit_quotas", "simulate_quota_impact", "configure_ui_headers", "run_tidy", "bootstrap_vault_layout", "provision_team_namespace"} {
+		assert.NotNil(t, hcServer.GetTool(name), "expected %s to be registered", name)
+	}
+}
+
+func TestServerInfo(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	t.Setenv("MCP_SESSION_MAX_TOOL_CALLS", "50")
+
+	hcServer := server.NewMCPServer("test", "1.0.0")
+	InitTools(hcServer, logger)
+
+	tool := hcServer.GetTool("server_info")
+	require.NotNil(t, tool)
+
+	result, err := tool.Handler(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var info ServerInfoReport
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &info))
+	assert.Equal(t, info, result.StructuredContent, "the structured content matches the text")
+	assert.Equal(t, ToolCategories, info.ToolCategories)
+	assert.Equal(t, len(hcServer.ListTools()), info.ToolCount)
+	assert.Equal(t, int64(50), info.Limits.SessionMaxToolCalls)
+	assert.False(t, info.Security.TLSEnabled)
+	assert.False(t, info.Security.AdminTools)
+}
+
+func TestCanonicalToolName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"write-secret":   "write_secret",
+		"listSecrets":    "list_secrets",
+		"ListSecrets":    "list_secrets",
+		"listPKIIssuers": "list_pki_issuers",
+		"issue-pki-cert": "issue_pki_cert",
+		"list_secrets":   "list_secrets",
+	} {
+		assert.Equal(t, expected, CanonicalToolName(name), name)
+	}
+}
+
+func TestToolAliasHook(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	hooks := &server.Hooks{}
+	hooks.AddBeforeCallTool(ToolAliasHook(logger))
+	hcServer := server.NewMCPServer("test", "1.0.0", server.WithHooks(hooks))
+	hcServer.AddTool(mcp.NewTool("list_secrets"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(request.Params.Name), nil
+	})
+
+	call := func(name string) mcp.JSONRPCMessage {
+		message, err := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"method":  "tools/call",
+			"params":  map[string]any{"name": name},
+		})
+		require.NoError(t, err)
+		return hcServer.HandleMessage(context.Background(), message)
+	}
+
+	for _, name := range []string{"list_secrets", "list-secrets", "listSecrets"} {
+		response, ok := call(name).(mcp.JSONRPCResponse)
+		require.True(t, ok, "expected %s to be forwarded", name)
+		assert.Equal(t, "list_secrets", response.Result.(*mcp.CallToolResult).Content[0].(mcp.TextContent).Text)
+	}
+
+	_, ok := call("readSecret").(mcp.JSONRPCError)
+	assert.True(t, ok, "aliases of unknown tools fail")
+}
@@ -0,0 +1,132 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package transform
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// CreateTransformRole creates a tool for creating transform roles
+func CreateTransformRole(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("create_transform_role",
+			mcp.WithDescription("Create or update a role of the Transform secrets engine. A role groups the transformations that can be used to encode and decode data through it."),
+			mcp.WithString("mount",
+				mcp.DefaultString("transform"),
+				mcp.Description("The mount of the Transform secrets engine. Defaults to 'transform'."),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("The name of the role, for example 'payments'."),
+			),
+			mcp.WithString("transformations",
+				mcp.Required(),
+				mcp.Description("A comma-separated list of the transformations of the role, for example 'card-number,ssn'."),
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return createTransformRoleHandler(ctx, req, logger)
+		},
+	}
+}
+
+func createTransformRoleHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling create_transform_role request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	name, ok := args["name"].(string)
+	if !ok || name == "" {
+		return mcp.NewToolResultError("Missing or invalid 'name' parameter"), nil
+	}
+
+	var transformations []string
+	if transformationsStr, ok := args["transformations"].(string); ok {
+		for _, transformation := range strings.Split(transformationsStr, ",") {
+			if transformation = strings.TrimSpace(transformation); transformation != "" {
+				transformations = append(transformations, transformation)
+			}
+		}
+	}
+	if len(transformations) == 0 {
+		return mcp.NewToolResultError("Missing or invalid 'transformations' parameter"), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":           mount,
+		"name":            name,
+		"transformations": transformations,
+	}).Debug("Creating transform role with parameters")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list mounts: %v", err)), nil
+	}
+
+	// Check if the mount exists
+	if _, ok := mounts[mount+"/"]; !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("mount path '%s' does not exist, you should use 'enable_transform' if you want enable transform on this mount.", mount)), nil
+	}
+
+	fullPath := fmt.Sprintf("%s/role/%s", mount, name)
+
+	// Keep the configuration that is replaced in the change record
+	previous, _ := vault.Logical().Read(fullPath)
+
+	roleData := map[string]interface{}{
+		"transformations": transformations,
+	}
+	written, err := vault.Logical().Write(fullPath, roleData)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to write to path '%s': %v", fullPath, err)), nil
+	}
+
+	successMsg := fmt.Sprintf("Successfully created transform role with name '%s' on mount '%s'.", name, mount)
+
+	logger.WithFields(log.Fields{
+		"mount": mount,
+		"name":  name,
+	}).Info("Successfully created transform role")
+
+	change := client.ChangeRecord{
+		ResourceType: client.ResourceTransformRole,
+		Mount:        mount,
+		Path:         fullPath,
+		Operation:    client.OperationCreate,
+		New:          roleData,
+		Message:      successMsg,
+	}
+	if previous != nil {
+		change.Operation = client.OperationUpdate
+		change.Previous = previous.Data
+	}
+	if written != nil {
+		change.VaultRequestID = written.RequestID
+	}
+	return client.NewChangeResult(ctx, req, change), nil
+}
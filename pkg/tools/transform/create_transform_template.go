@@ -0,0 +1,145 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package transform
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// CreateTransformTemplate creates a tool for creating transform templates
+func CreateTransformTemplate(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("create_transform_template",
+			mcp.WithDescription("Create or update a template of the Transform secrets engine. A template describes the format of the data to transform with a regular expression, whose capture groups select the characters that are encoded."),
+			mcp.WithString("mount",
+				mcp.DefaultString("transform"),
+				mcp.Description("The mount of the Transform secrets engine. Defaults to 'transform'."),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("The name of the template, for example 'ssn'."),
+			),
+			mcp.WithString("pattern",
+				mcp.Required(),
+				mcp.Description(`The regular expression that matches the data. Only the characters in capture groups are transformed, for example '(\d{3})-(\d{2})-(\d{4})' for a SSN.`),
+			),
+			mcp.WithString("alphabet",
+				mcp.DefaultString("builtin/numeric"),
+				mcp.Description("The alphabet of the transformed characters, such as 'builtin/numeric', 'builtin/alphanumericlower' or the name of a custom alphabet. Defaults to 'builtin/numeric'."),
+			),
+			mcp.WithString("encode_format",
+				mcp.DefaultString(""),
+				mcp.Description("Optional format of the encoded value, using the capture groups of the pattern, for example '$1-$2-$3'."),
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return createTransformTemplateHandler(ctx, req, logger)
+		},
+	}
+}
+
+func createTransformTemplateHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling create_transform_template request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	name, ok := args["name"].(string)
+	if !ok || name == "" {
+		return mcp.NewToolResultError("Missing or invalid 'name' parameter"), nil
+	}
+
+	pattern, ok := args["pattern"].(string)
+	if !ok || pattern == "" {
+		return mcp.NewToolResultError("Missing or invalid 'pattern' parameter"), nil
+	}
+
+	alphabet, _ := args["alphabet"].(string)
+	if alphabet == "" {
+		alphabet = "builtin/numeric"
+	}
+
+	encodeFormat, _ := args["encode_format"].(string)
+
+	logger.WithFields(log.Fields{
+		"mount":    mount,
+		"name":     name,
+		"alphabet": alphabet,
+	}).Debug("Creating transform template with parameters")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list mounts: %v", err)), nil
+	}
+
+	// Check if the mount exists
+	if _, ok := mounts[mount+"/"]; !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("mount path '%s' does not exist, you should use 'enable_transform' if you want enable transform on this mount.", mount)), nil
+	}
+
+	fullPath := fmt.Sprintf("%s/template/%s", mount, name)
+
+	templateData := map[string]interface{}{
+		"type":     "regex",
+		"pattern":  pattern,
+		"alphabet": alphabet,
+	}
+	if encodeFormat != "" {
+		templateData["encode_format"] = encodeFormat
+	}
+
+	// Keep the configuration that is replaced in the change record
+	previous, _ := vault.Logical().Read(fullPath)
+
+	written, err := vault.Logical().Write(fullPath, templateData)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to write to path '%s': %v", fullPath, err)), nil
+	}
+
+	successMsg := fmt.Sprintf("Successfully created transform template with name '%s' on mount '%s'.", name, mount)
+
+	logger.WithFields(log.Fields{
+		"mount": mount,
+		"name":  name,
+	}).Info("Successfully created transform template")
+
+	change := client.ChangeRecord{
+		ResourceType: client.ResourceTransformTemplate,
+		Mount:        mount,
+		Path:         fullPath,
+		Operation:    client.OperationCreate,
+		New:          templateData,
+		Message:      successMsg,
+	}
+	if previous != nil {
+		change.Operation = client.OperationUpdate
+		change.Previous = previous.Data
+	}
+	if written != nil {
+		change.VaultRequestID = written.RequestID
+	}
+	return client.NewChangeResult(ctx, req, change), nil
+}
@@ -0,0 +1,186 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package transform
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// CreateTransformTransformation creates a tool for creating transform transformations
+func CreateTransformTransformation(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("create_transform_transformation",
+			mcp.WithDescription("Create or update a transformation of the Transform secrets engine. A transformation defines how data is transformed: 'fpe' encrypts it while preserving its format, 'masking' replaces characters irreversibly and 'tokenization' replaces the value with a random token."),
+			mcp.WithString("mount",
+				mcp.DefaultString("transform"),
+				mcp.Description("The mount of the Transform secrets engine. Defaults to 'transform'."),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("The name of the transformation, for example 'card-number'."),
+			),
+			mcp.WithString("type",
+				mcp.Required(),
+				mcp.Enum("fpe", "masking", "tokenization"),
+				mcp.Description("The type of the transformation."),
+			),
+			mcp.WithString("template",
+				mcp.DefaultString(""),
+				mcp.Description("The template of the data, required for 'fpe' and 'masking'. Use 'builtin/creditcardnumber' or 'builtin/socialsecuritynumber', or a template created with 'create_transform_template'."),
+			),
+			mcp.WithString("tweak_source",
+				mcp.DefaultString("internal"),
+				mcp.Enum("supplied", "generated", "internal"),
+				mcp.Description("The source of the tweak for 'fpe'. With 'supplied' or 'generated' the tweak must be passed when decoding. Defaults to 'internal'."),
+			),
+			mcp.WithString("masking_character",
+				mcp.DefaultString("*"),
+				mcp.Description("The character that replaces the data for 'masking'. Defaults to '*'."),
+			),
+			mcp.WithBoolean("convergent",
+				mcp.DefaultBool(false),
+				mcp.Description("For 'tokenization', encode the same value to the same token."),
+			),
+			mcp.WithString("allowed_roles",
+				mcp.DefaultString(""),
+				mcp.Description("A comma-separated list of roles that are allowed to use the transformation, for example 'payments'. Roles may contain glob patterns."),
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return createTransformTransformationHandler(ctx, req, logger)
+		},
+	}
+}
+
+func createTransformTransformationHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling create_transform_transformation request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	name, ok := args["name"].(string)
+	if !ok || name == "" {
+		return mcp.NewToolResultError("Missing or invalid 'name' parameter"), nil
+	}
+
+	transformationType, _ := args["type"].(string)
+	if transformationType != "fpe" && transformationType != "masking" && transformationType != "tokenization" {
+		return mcp.NewToolResultError("Missing or invalid 'type' parameter, must be one of 'fpe', 'masking' or 'tokenization'"), nil
+	}
+
+	template, _ := args["template"].(string)
+	if template == "" && transformationType != "tokenization" {
+		return mcp.NewToolResultError(fmt.Sprintf("The 'template' parameter is required for '%s' transformations", transformationType)), nil
+	}
+
+	var allowedRoles []string
+	if allowedRolesStr, ok := args["allowed_roles"].(string); ok && allowedRolesStr != "" {
+		allowedRoles = strings.Split(allowedRolesStr, ",")
+		for i := range allowedRoles {
+			allowedRoles[i] = strings.TrimSpace(allowedRoles[i])
+		}
+	}
+
+	transformationData := map[string]interface{}{
+		"allowed_roles": allowedRoles,
+	}
+
+	switch transformationType {
+	case "fpe":
+		tweakSource, _ := args["tweak_source"].(string)
+		if tweakSource == "" {
+			tweakSource = "internal"
+		}
+		transformationData["template"] = template
+		transformationData["tweak_source"] = tweakSource
+	case "masking":
+		maskingCharacter, _ := args["masking_character"].(string)
+		if maskingCharacter == "" {
+			maskingCharacter = "*"
+		}
+		transformationData["template"] = template
+		transformationData["masking_character"] = maskingCharacter
+	case "tokenization":
+		convergent, _ := args["convergent"].(bool)
+		transformationData["convergent"] = convergent
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":         mount,
+		"name":          name,
+		"type":          transformationType,
+		"allowed_roles": allowedRoles,
+	}).Debug("Creating transform transformation with parameters")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list mounts: %v", err)), nil
+	}
+
+	// Check if the mount exists
+	if _, ok := mounts[mount+"/"]; !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("mount path '%s' does not exist, you should use 'enable_transform' if you want enable transform on this mount.", mount)), nil
+	}
+
+	fullPath := fmt.Sprintf("%s/transformations/%s/%s", mount, transformationType, name)
+
+	// Keep the configuration that is replaced in the change record
+	previous, _ := vault.Logical().Read(fullPath)
+
+	written, err := vault.Logical().Write(fullPath, transformationData)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to write to path '%s': %v", fullPath, err)), nil
+	}
+
+	successMsg := fmt.Sprintf("Successfully created %s transformation with name '%s' on mount '%s'.", transformationType, name, mount)
+	if len(allowedRoles) == 0 {
+		successMsg += " No roles are allowed to use it yet, set 'allowed_roles' to the roles that should use it."
+	}
+
+	logger.WithFields(log.Fields{
+		"mount": mount,
+		"name":  name,
+		"type":  transformationType,
+	}).Info("Successfully created transform transformation")
+
+	change := client.ChangeRecord{
+		ResourceType: client.ResourceTransformation,
+		Mount:        mount,
+		Path:         fullPath,
+		Operation:    client.OperationCreate,
+		New:          transformationData,
+		Message:      successMsg,
+	}
+	if previous != nil {
+		change.Operation = client.OperationUpdate
+		change.Previous = previous.Data
+	}
+	if written != nil {
+		change.VaultRequestID = written.RequestID
+	}
+	return client.NewChangeResult(ctx, req, change), nil
+}
@@ -0,0 +1,107 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package transform
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// EnableTransform creates a tool for creating Vault transform mounts
+func EnableTransform(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("enable_transform",
+			mcp.WithDescription(`Enable the Transform secrets engine in Vault (Vault Enterprise with the Advanced Data Protection module), which performs format preserving encryption (FPE), masking and tokenization of sensitive data such as credit card numbers (PANs) or social security numbers (SSNs).
+## Setting up a data transformation
+  - Create a transform mount using this tool. Examples of names could be 'transform' or 'data_protection'.
+  - For format preserving encryption or masking of data that is not covered by the builtin templates, create a template with the 'create_transform_template' tool that describes the format of the data with a regular expression.
+  - Create a transformation with the 'create_transform_transformation' tool, which defines how the data is transformed (fpe, masking or tokenization) and which roles may use it.
+  - Create a role with the 'create_transform_role' tool, which groups the transformations that a client may use.
+  - Encode and decode data with the 'transform_encode' and 'transform_decode' tools.
+`),
+			mcp.WithString("path",
+				mcp.DefaultString("transform"),
+				mcp.Description("The path where the transform mount will be created. Defaults to 'transform'."),
+			),
+			mcp.WithString("description",
+				mcp.DefaultString(""),
+				mcp.Description("A description for the transform mount."),
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return enableTransformHandler(ctx, req, logger)
+		},
+	}
+}
+
+func enableTransformHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling enable_transform request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	path, ok := args["path"].(string)
+	if !ok || path == "" {
+		return mcp.NewToolResultError("Missing or invalid 'path' parameter"), nil
+	}
+
+	description, _ := args["description"].(string)
+
+	logger.WithFields(log.Fields{
+		"path":        path,
+		"description": description,
+	}).Debug("Creating transform mount with parameters")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list mounts: %v", err)), nil
+	}
+
+	// Check if the mount exists
+	if _, ok := mounts[path+"/"]; ok {
+		return mcp.NewToolResultError(fmt.Sprintf("mount path '%s' already exist, you should use 'delete_mount' if you want to re-create it.", path)), nil
+	}
+
+	// Create the mount
+	err = vault.Sys().Mount(path, &api.MountInput{
+		Type:        "transform",
+		Description: description,
+	})
+	if err != nil {
+		logger.WithError(err).WithField("path", path).Error("Failed to create transform mount")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create transform mount, the Transform secrets engine requires Vault Enterprise with the Advanced Data Protection module: %v", err)), nil
+	}
+
+	successMsg := fmt.Sprintf("Successfully created transform mount at path '%s'", path)
+	if description != "" {
+		successMsg += fmt.Sprintf(" with description: %s", description)
+	}
+
+	logger.WithField("path", path).Info("Successfully created transform mount")
+
+	return client.NewChangeResult(ctx, req, client.ChangeRecord{
+		ResourceType: client.ResourceMount,
+		Mount:        path,
+		Path:         "sys/mounts/" + path,
+		Operation:    client.OperationCreate,
+		New:          map[string]any{"type": "transform", "description": description},
+		Message:      successMsg,
+	}), nil
+}
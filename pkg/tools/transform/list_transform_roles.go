@@ -0,0 +1,111 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// ListTransformRoles creates a tool for listing transform roles
+func ListTransformRoles(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("list_transform_roles",
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithDescription("Get a list of the roles of a Transform secrets engine mount, which can be used to encode and decode data."),
+			mcp.WithString("mount",
+				mcp.DefaultString("transform"),
+				mcp.Description("The mount of the Transform secrets engine. Defaults to 'transform'."),
+			),
+			mcp.WithString("format",
+				mcp.DefaultString("json"),
+				mcp.Enum("json", "markdown", "table"),
+				mcp.Description("Optional output format. Use 'markdown' or 'table' for a compact table that is easier to display in chat. Defaults to 'json'."),
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return listTransformRolesHandler(ctx, req, logger)
+		},
+	}
+}
+
+func listTransformRolesHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling list_transform_roles request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	format, err := utils.ExtractFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list mounts: %v", err)), nil
+	}
+
+	// Check if the mount exists
+	if _, ok := mounts[mount+"/"]; !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("mount path '%s' does not exist, you should use 'enable_transform' if you want enable transform on this mount.", mount)), nil
+	}
+
+	fullPath := fmt.Sprintf("%s/role", mount)
+
+	secret, err := vault.Logical().List(fullPath)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to read path '%s': %v", fullPath, err)), nil
+	}
+
+	keys := []interface{}{}
+	if secret != nil && secret.Data != nil {
+		if roles, ok := secret.Data["keys"].([]interface{}); ok {
+			keys = roles
+		}
+	}
+
+	if format != utils.FormatJSON {
+		rows := make([][]string, 0, len(keys))
+		for _, key := range keys {
+			rows = append(rows, []string{fmt.Sprintf("%v", key)})
+		}
+		return mcp.NewToolResultText(utils.RenderTable(format, []string{"role_name"}, rows)), nil
+	}
+
+	jsonData, err := json.Marshal(keys)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal roles to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithField("mount", mount).Debug("Successfully listed transform roles")
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
@@ -0,0 +1,52 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package transform
+
+import (
+	"context"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// TransformDecode creates a tool for decoding data with the Transform secrets engine
+func TransformDecode(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("transform_decode",
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithDescription("Decode a value that was encoded with a 'fpe' or 'tokenization' transformation of a Transform secrets engine role, returning the original value. Masked values cannot be decoded."),
+			mcp.WithString("mount",
+				mcp.DefaultString("transform"),
+				mcp.Description("The mount of the Transform secrets engine. Defaults to 'transform'."),
+			),
+			mcp.WithString("role",
+				mcp.Required(),
+				mcp.Description("The role to decode the value with."),
+			),
+			mcp.WithString("value",
+				mcp.Required(),
+				mcp.Description("The encoded value to decode."),
+			),
+			mcp.WithString("transformation",
+				mcp.DefaultString(""),
+				mcp.Description("The transformation to use. Required when the role has more than one transformation."),
+			),
+			mcp.WithString("tweak",
+				mcp.DefaultString(""),
+				mcp.Description("The base64 encoded tweak that was supplied or generated when the value was encoded, for 'fpe' transformations with a 'supplied' or 'generated' tweak source."),
+			),
+			schemas.Output("transform_decode"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return transformHandler(ctx, req, logger, "decode")
+		},
+	}
+}
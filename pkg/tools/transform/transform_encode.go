@@ -0,0 +1,155 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// TransformedValue is the result of encoding or decoding a value with the Transform secrets engine
+type TransformedValue struct {
+	Role           string `json:"role"`
+	Transformation string `json:"transformation,omitempty"`
+	Value          string `json:"value"`
+	Tweak          string `json:"tweak,omitempty"` // Generated tweak, which is required to decode the value
+}
+
+// TransformEncode creates a tool for encoding data with the Transform secrets engine
+func TransformEncode(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("transform_encode",
+			mcp.WithDescription("Encode a value, such as a credit card or social security number, with a transformation of a Transform secrets engine role. Depending on the transformation the value is encrypted while preserving its format, masked or replaced by a token."),
+			mcp.WithString("mount",
+				mcp.DefaultString("transform"),
+				mcp.Description("The mount of the Transform secrets engine. Defaults to 'transform'."),
+			),
+			mcp.WithString("role",
+				mcp.Required(),
+				mcp.Description("The role to encode the value with."),
+			),
+			mcp.WithString("value",
+				mcp.Required(),
+				mcp.Description("The value to encode."),
+			),
+			mcp.WithString("transformation",
+				mcp.DefaultString(""),
+				mcp.Description("The transformation to use. Required when the role has more than one transformation."),
+			),
+			mcp.WithString("tweak",
+				mcp.DefaultString(""),
+				mcp.Description("The base64 encoded tweak, required for 'fpe' transformations with a 'supplied' tweak source."),
+			),
+			schemas.Output("transform_encode"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return transformHandler(ctx, req, logger, "encode")
+		},
+	}
+}
+
+// transformHandler encodes or decodes a value, depending on the operation
+func transformHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger, operation string) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling transform_" + operation + " request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	role, ok := args["role"].(string)
+	if !ok || role == "" {
+		return mcp.NewToolResultError("Missing or invalid 'role' parameter"), nil
+	}
+
+	value, ok := args["value"].(string)
+	if !ok || value == "" {
+		return mcp.NewToolResultError("Missing or invalid 'value' parameter"), nil
+	}
+
+	transformation, _ := args["transformation"].(string)
+	tweak, _ := args["tweak"].(string)
+
+	// The value itself is sensitive and never logged
+	logger.WithFields(log.Fields{
+		"mount":          mount,
+		"role":           role,
+		"transformation": transformation,
+		"operation":      operation,
+	}).Debug("Transforming value")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list mounts: %v", err)), nil
+	}
+
+	// Check if the mount exists
+	if _, ok := mounts[mount+"/"]; !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("mount path '%s' does not exist, you should use 'enable_transform' if you want enable transform on this mount.", mount)), nil
+	}
+
+	fullPath := fmt.Sprintf("%s/%s/%s", mount, operation, role)
+
+	data := map[string]interface{}{
+		"value": value,
+	}
+	if transformation != "" {
+		data["transformation"] = transformation
+	}
+	if tweak != "" {
+		data["tweak"] = tweak
+	}
+
+	secret, err := vault.Logical().Write(fullPath, data)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to %s value with role '%s': %v", operation, role, err)), nil
+	}
+	if secret == nil || secret.Data == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("no data returned from path '%s'", fullPath)), nil
+	}
+
+	result := TransformedValue{
+		Role:           role,
+		Transformation: transformation,
+	}
+	result.Value, _ = secret.Data[operation+"d_value"].(string)
+	if operation == "encode" {
+		result.Tweak, _ = secret.Data["tweak"].(string)
+	}
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal result to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":     mount,
+		"role":      role,
+		"operation": operation,
+	}).Debug("Successfully transformed value")
+
+	return mcp.NewToolResultStructured(result, string(jsonData)), nil
+}
@@ -0,0 +1,93 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSession struct {
+	id string
+}
+
+func (s testSession) SessionID() string                                   { return s.id }
+func (s testSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s testSession) Initialize()                                         {}
+func (s testSession) Initialized() bool                                   { return true }
+
+func TestTransformEncodeDecode(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	var received map[string]interface{}
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/sys/mounts":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"transform/": map[string]interface{}{"type": "transform"},
+			}})
+		case "/v1/transform/encode/payments":
+			_ = json.NewDecoder(r.Body).Decode(&received)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"encoded_value": "4111-7290-1638-2210",
+				"tweak":         "dHdlYWs=",
+			}})
+		case "/v1/transform/decode/payments":
+			_ = json.NewDecoder(r.Body).Decode(&received)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"decoded_value": "4111-1111-1111-1111",
+			}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockVault.Close()
+
+	sessionID := "test-transform"
+	_, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	defer client.DeleteVaultClient(sessionID)
+
+	ctx := server.NewMCPServer("test", "1.0").WithContext(context.Background(), testSession{id: sessionID})
+
+	t.Run("encode", func(t *testing.T) {
+		result, err := TransformEncode(logger).Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{"mount": "transform", "role": "payments", "value": "4111-1111-1111-1111", "transformation": "card-number"},
+		}})
+		require.NoError(t, err)
+		require.False(t, result.IsError, result.Content)
+
+		var value TransformedValue
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &value))
+		assert.Equal(t, "4111-7290-1638-2210", value.Value)
+		assert.Equal(t, "dHdlYWs=", value.Tweak)
+		assert.Equal(t, "card-number", received["transformation"])
+	})
+
+	t.Run("decode", func(t *testing.T) {
+		result, err := TransformDecode(logger).Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{"mount": "transform", "role": "payments", "value": "4111-7290-1638-2210", "tweak": "dHdlYWs="},
+		}})
+		require.NoError(t, err)
+		require.False(t, result.IsError, result.Content)
+
+		var value TransformedValue
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &value))
+		assert.Equal(t, "4111-1111-1111-1111", value.Value)
+		assert.Empty(t, value.Tweak)
+		assert.Equal(t, "dHdlYWs=", received["tweak"])
+	})
+}
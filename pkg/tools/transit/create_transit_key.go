@@ -0,0 +1,149 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package transit
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// TransitKeyTypes are the key types transit keys can be created with
+var TransitKeyTypes = []string{
+	"aes256-gcm96", "aes128-gcm96", "chacha20-poly1305",
+	"ed25519", "ecdsa-p256", "ecdsa-p384", "ecdsa-p521",
+	"rsa-2048", "rsa-3072", "rsa-4096",
+}
+
+// CreateTransitKey creates a tool for creating named transit keys
+func CreateTransitKey(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("create_transit_key",
+			mcp.WithDescription("Create a named encryption or signing key on a transit mount. AES and ChaCha20 keys encrypt and decrypt data, Ed25519, ECDSA and RSA keys sign and verify data; RSA keys can also encrypt. Existing keys are never replaced."),
+			mcp.WithString("mount",
+				mcp.DefaultString("transit"),
+				mcp.Description("The mount of the transit secrets engine. Defaults to 'transit'."),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("The name of the key, for example 'orders'."),
+			),
+			mcp.WithString("type",
+				mcp.DefaultString("aes256-gcm96"),
+				mcp.Enum(TransitKeyTypes...),
+				mcp.Description("The type of the key. Defaults to 'aes256-gcm96'."),
+			),
+			mcp.WithBoolean("exportable",
+				mcp.DefaultBool(false),
+				mcp.Description("Whether the key can be exported. This cannot be disabled once the key is created."),
+			),
+			mcp.WithBoolean("derived",
+				mcp.DefaultBool(false),
+				mcp.Description("Whether the key derives a key for each context, which then has to be passed to encrypt and decrypt data."),
+			),
+			mcp.WithString("auto_rotate_period",
+				mcp.DefaultString(""),
+				mcp.Description("How often the key is rotated automatically, for example '720h'. At least one hour, defaults to no automatic rotation."),
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return createTransitKeyHandler(ctx, req, logger)
+		},
+	}
+}
+
+func createTransitKeyHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling create_transit_key request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	name, ok := args["name"].(string)
+	if !ok || name == "" {
+		return mcp.NewToolResultError("Missing or invalid 'name' parameter"), nil
+	}
+
+	keyType := req.GetString("type", "aes256-gcm96")
+	if !slices.Contains(TransitKeyTypes, keyType) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'type' parameter '%s', expected one of %s", keyType, strings.Join(TransitKeyTypes, ", "))), nil
+	}
+
+	keyData := map[string]interface{}{
+		"type":       keyType,
+		"exportable": req.GetBool("exportable", false),
+		"derived":    req.GetBool("derived", false),
+	}
+	if period := req.GetString("auto_rotate_period", ""); period != "" {
+		keyData["auto_rotate_period"] = period
+	}
+
+	logger.WithFields(log.Fields{
+		"mount": mount,
+		"name":  name,
+		"type":  keyType,
+	}).Debug("Creating transit key with parameters")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	if err := checkTransitMount(vault, mount); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	fullPath := fmt.Sprintf("%s/keys/%s", mount, name)
+
+	// Writing to an existing key only updates its configuration, which would be surprising for a create tool
+	existing, err := vault.Logical().Read(fullPath)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to read path '%s': %v", fullPath, err)), nil
+	}
+	if existing != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("transit key '%s' already exists on mount '%s'", name, mount)), nil
+	}
+
+	written, err := vault.Logical().Write(fullPath, keyData)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to write to path '%s': %v", fullPath, err)), nil
+	}
+
+	successMsg := fmt.Sprintf("Successfully created transit key '%s' of type '%s' on mount '%s'.", name, keyType, mount)
+
+	logger.WithFields(log.Fields{
+		"mount": mount,
+		"name":  name,
+		"type":  keyType,
+	}).Info("Successfully created transit key")
+
+	change := client.ChangeRecord{
+		ResourceType: client.ResourceTransitKey,
+		Mount:        mount,
+		Path:         fullPath,
+		Operation:    client.OperationCreate,
+		New:          keyData,
+		Message:      successMsg,
+	}
+	if written != nil {
+		change.VaultRequestID = written.RequestID
+	}
+	return client.NewChangeResult(ctx, req, change), nil
+}
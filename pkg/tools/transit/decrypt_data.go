@@ -0,0 +1,127 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package transit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// DecryptData creates a tool for decrypting data with a transit key
+func DecryptData(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("decrypt_data",
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithDescription("Decrypt a ciphertext returned by 'encrypt_data' with the named transit key that encrypted it. Text is returned as is, binary data base64 encoded."),
+			mcp.WithString("mount",
+				mcp.DefaultString("transit"),
+				mcp.Description("The mount of the transit secrets engine. Defaults to 'transit'."),
+			),
+			mcp.WithString("key",
+				mcp.Required(),
+				mcp.Description("The name of the key the data was encrypted with."),
+			),
+			mcp.WithString("ciphertext",
+				mcp.Required(),
+				mcp.Description("The ciphertext to decrypt, such as 'vault:v1:...'."),
+			),
+			mcp.WithString("context",
+				mcp.DefaultString(""),
+				mcp.Description("The base64 encoded context the data was encrypted with, required for derived keys."),
+			),
+			schemas.Output("decrypt_data"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return decryptDataHandler(ctx, req, logger)
+		},
+	}
+}
+
+func decryptDataHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling decrypt_data request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	key, ok := args["key"].(string)
+	if !ok || key == "" {
+		return mcp.NewToolResultError("Missing or invalid 'key' parameter"), nil
+	}
+
+	ciphertext, ok := args["ciphertext"].(string)
+	if !ok || ciphertext == "" {
+		return mcp.NewToolResultError("Missing or invalid 'ciphertext' parameter"), nil
+	}
+
+	data := map[string]interface{}{
+		"ciphertext": ciphertext,
+	}
+	if transitContext := req.GetString("context", ""); transitContext != "" {
+		data["context"] = transitContext
+	}
+
+	logger.WithFields(log.Fields{
+		"mount": mount,
+		"key":   key,
+	}).Debug("Decrypting data")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	if err := checkTransitMount(vault, mount); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	fullPath := fmt.Sprintf("%s/decrypt/%s", mount, key)
+
+	secret, err := vault.Logical().Write(fullPath, data)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to decrypt data with key '%s': %v", key, err)), nil
+	}
+	if secret == nil || secret.Data == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("no data returned from path '%s'", fullPath)), nil
+	}
+
+	// The plaintext itself is sensitive and never logged
+	encoded, _ := secret.Data["plaintext"].(string)
+	result := TransitPlaintext{Key: key}
+	result.Plaintext, result.Base64 = decodePlaintext(encoded)
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal result to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount": mount,
+		"key":   key,
+	}).Debug("Successfully decrypted data")
+
+	return mcp.NewToolResultStructured(result, string(jsonData)), nil
+}
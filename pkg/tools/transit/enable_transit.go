@@ -0,0 +1,106 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package transit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// EnableTransit creates a tool for creating Vault transit mounts
+func EnableTransit(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("enable_transit",
+			mcp.WithDescription(`Enable the Transit secrets engine in Vault, which encrypts, decrypts and signs data for applications without storing it ("encryption as a service"). The keys never leave Vault.
+## Encrypting data with transit
+  - Create a transit mount using this tool. Examples of names could be 'transit' or 'app-encryption'.
+  - Create a named key with the 'create_transit_key' tool, an AES or ChaCha20 key for encryption or an Ed25519, ECDSA or RSA key for signatures.
+  - Encrypt and decrypt data with the 'encrypt_data' and 'decrypt_data' tools, and re-encrypt ciphertexts with the latest version of a rotated key with 'rewrap_data'.
+  - Sign data and verify signatures with the 'sign_data' and 'verify_data' tools.
+`),
+			mcp.WithString("path",
+				mcp.DefaultString("transit"),
+				mcp.Description("The path where the transit mount will be created. Defaults to 'transit'."),
+			),
+			mcp.WithString("description",
+				mcp.DefaultString(""),
+				mcp.Description("A description for the transit mount."),
+			),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return enableTransitHandler(ctx, req, logger)
+		},
+	}
+}
+
+func enableTransitHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling enable_transit request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	path, ok := args["path"].(string)
+	if !ok || path == "" {
+		return mcp.NewToolResultError("Missing or invalid 'path' parameter"), nil
+	}
+
+	description, _ := args["description"].(string)
+
+	logger.WithFields(log.Fields{
+		"path":        path,
+		"description": description,
+	}).Debug("Creating transit mount with parameters")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list mounts: %v", err)), nil
+	}
+
+	// Check if the mount exists
+	if _, ok := mounts[path+"/"]; ok {
+		return mcp.NewToolResultError(fmt.Sprintf("mount path '%s' already exist, you should use 'delete_mount' if you want to re-create it.", path)), nil
+	}
+
+	// Create the mount
+	err = vault.Sys().Mount(path, &api.MountInput{
+		Type:        "transit",
+		Description: description,
+	})
+	if err != nil {
+		logger.WithError(err).WithField("path", path).Error("Failed to create transit mount")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create transit mount: %v", err)), nil
+	}
+
+	successMsg := fmt.Sprintf("Successfully created transit mount at path '%s'", path)
+	if description != "" {
+		successMsg += fmt.Sprintf(" with description: %s", description)
+	}
+
+	logger.WithField("path", path).Info("Successfully created transit mount")
+
+	return client.NewChangeResult(ctx, req, client.ChangeRecord{
+		ResourceType: client.ResourceMount,
+		Mount:        path,
+		Path:         "sys/mounts/" + path,
+		Operation:    client.OperationCreate,
+		New:          map[string]any{"type": "transit", "description": description},
+		Message:      successMsg,
+	}), nil
+}
@@ -0,0 +1,146 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package transit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// EncryptData creates a tool for encrypting data with a transit key
+func EncryptData(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("encrypt_data",
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithDescription("Encrypt data with a named key of the transit secrets engine. The returned ciphertext is prefixed with the key version, such as 'vault:v1:', and can only be decrypted by Vault with the 'decrypt_data' tool."),
+			mcp.WithString("mount",
+				mcp.DefaultString("transit"),
+				mcp.Description("The mount of the transit secrets engine. Defaults to 'transit'."),
+			),
+			mcp.WithString("key",
+				mcp.Required(),
+				mcp.Description("The name of the key to encrypt the data with."),
+			),
+			mcp.WithString("plaintext",
+				mcp.Required(),
+				mcp.Description("The data to encrypt."),
+			),
+			mcp.WithBoolean("base64",
+				mcp.DefaultBool(false),
+				mcp.Description("Whether the plaintext is base64 encoded already, for binary data. Defaults to false, which encodes the text for transit."),
+			),
+			mcp.WithString("context",
+				mcp.DefaultString(""),
+				mcp.Description("The base64 encoded context, required for derived keys. The same context has to be passed to decrypt the data."),
+			),
+			mcp.WithNumber("key_version",
+				mcp.DefaultNumber(0),
+				mcp.Description("The version of the key to encrypt with. Defaults to 0, the latest version."),
+			),
+			schemas.Output("encrypt_data"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return encryptDataHandler(ctx, req, logger)
+		},
+	}
+}
+
+func encryptDataHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling encrypt_data request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	key, ok := args["key"].(string)
+	if !ok || key == "" {
+		return mcp.NewToolResultError("Missing or invalid 'key' parameter"), nil
+	}
+
+	plaintext, ok := args["plaintext"].(string)
+	if !ok || plaintext == "" {
+		return mcp.NewToolResultError("Missing or invalid 'plaintext' parameter"), nil
+	}
+
+	input, err := encodeInput(plaintext, req.GetBool("base64", false))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'plaintext' parameter: %v", err)), nil
+	}
+
+	data := map[string]interface{}{
+		"plaintext": input,
+	}
+	if transitContext := req.GetString("context", ""); transitContext != "" {
+		data["context"] = transitContext
+	}
+	if version := req.GetInt("key_version", 0); version > 0 {
+		data["key_version"] = version
+	}
+
+	// The plaintext itself is sensitive and never logged
+	logger.WithFields(log.Fields{
+		"mount": mount,
+		"key":   key,
+	}).Debug("Encrypting data")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	if err := checkTransitMount(vault, mount); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	fullPath := fmt.Sprintf("%s/encrypt/%s", mount, key)
+
+	secret, err := vault.Logical().Write(fullPath, data)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to encrypt data with key '%s': %v", key, err)), nil
+	}
+	if secret == nil || secret.Data == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("no data returned from path '%s'", fullPath)), nil
+	}
+
+	result := TransitCiphertext{
+		Key:        key,
+		KeyVersion: keyVersion(secret.Data),
+	}
+	result.Ciphertext, _ = secret.Data["ciphertext"].(string)
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal result to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":       mount,
+		"key":         key,
+		"key_version": result.KeyVersion,
+	}).Debug("Successfully encrypted data")
+
+	return mcp.NewToolResultStructured(result, string(jsonData)), nil
+}
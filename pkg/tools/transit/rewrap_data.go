@@ -0,0 +1,136 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package transit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// RewrapData creates a tool for re-encrypting ciphertexts with the latest version of a transit key
+func RewrapData(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("rewrap_data",
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					ReadOnlyHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithDescription("Re-encrypt a ciphertext with the latest version of the transit key that encrypted it, or another version, without revealing the plaintext. Use this after a key is rotated so that older key versions can be retired."),
+			mcp.WithString("mount",
+				mcp.DefaultString("transit"),
+				mcp.Description("The mount of the transit secrets engine. Defaults to 'transit'."),
+			),
+			mcp.WithString("key",
+				mcp.Required(),
+				mcp.Description("The name of the key the data was encrypted with."),
+			),
+			mcp.WithString("ciphertext",
+				mcp.Required(),
+				mcp.Description("The ciphertext to re-encrypt, such as 'vault:v1:...'."),
+			),
+			mcp.WithString("context",
+				mcp.DefaultString(""),
+				mcp.Description("The base64 encoded context the data was encrypted with, required for derived keys."),
+			),
+			mcp.WithNumber("key_version",
+				mcp.DefaultNumber(0),
+				mcp.Description("The version of the key to re-encrypt with. Defaults to 0, the latest version."),
+			),
+			schemas.Output("rewrap_data"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return rewrapDataHandler(ctx, req, logger)
+		},
+	}
+}
+
+func rewrapDataHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling rewrap_data request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	key, ok := args["key"].(string)
+	if !ok || key == "" {
+		return mcp.NewToolResultError("Missing or invalid 'key' parameter"), nil
+	}
+
+	ciphertext, ok := args["ciphertext"].(string)
+	if !ok || ciphertext == "" {
+		return mcp.NewToolResultError("Missing or invalid 'ciphertext' parameter"), nil
+	}
+
+	data := map[string]interface{}{
+		"ciphertext": ciphertext,
+	}
+	if transitContext := req.GetString("context", ""); transitContext != "" {
+		data["context"] = transitContext
+	}
+	if version := req.GetInt("key_version", 0); version > 0 {
+		data["key_version"] = version
+	}
+
+	logger.WithFields(log.Fields{
+		"mount": mount,
+		"key":   key,
+	}).Debug("Rewrapping data")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	if err := checkTransitMount(vault, mount); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	fullPath := fmt.Sprintf("%s/rewrap/%s", mount, key)
+
+	secret, err := vault.Logical().Write(fullPath, data)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to rewrap data with key '%s': %v", key, err)), nil
+	}
+	if secret == nil || secret.Data == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("no data returned from path '%s'", fullPath)), nil
+	}
+
+	result := TransitCiphertext{
+		Key:        key,
+		KeyVersion: keyVersion(secret.Data),
+	}
+	result.Ciphertext, _ = secret.Data["ciphertext"].(string)
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal result to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":       mount,
+		"key":         key,
+		"key_version": result.KeyVersion,
+	}).Debug("Successfully rewrapped data")
+
+	return mcp.NewToolResultStructured(result, string(jsonData)), nil
+}
@@ -0,0 +1,179 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package transit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// TransitHashAlgorithms are the hash algorithms transit signs data with
+var TransitHashAlgorithms = []string{"sha2-256", "sha2-224", "sha2-384", "sha2-512", "sha3-224", "sha3-256", "sha3-384", "sha3-512"}
+
+// withSignatureParameters adds the parameters shared by sign_data and verify_data
+func withSignatureParameters() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("mount",
+			mcp.DefaultString("transit"),
+			mcp.Description("The mount of the transit secrets engine. Defaults to 'transit'."),
+		),
+		mcp.WithString("key",
+			mcp.Required(),
+			mcp.Description("The name of the Ed25519, ECDSA or RSA key."),
+		),
+		mcp.WithString("input",
+			mcp.Required(),
+			mcp.Description("The data that is signed."),
+		),
+		mcp.WithBoolean("base64",
+			mcp.DefaultBool(false),
+			mcp.Description("Whether the input is base64 encoded already, for binary data. Defaults to false, which encodes the text for transit."),
+		),
+		mcp.WithString("hash_algorithm",
+			mcp.DefaultString("sha2-256"),
+			mcp.Enum(TransitHashAlgorithms...),
+			mcp.Description("The hash algorithm of ECDSA and RSA signatures, ignored for Ed25519 keys. Defaults to 'sha2-256'."),
+		),
+		mcp.WithString("signature_algorithm",
+			mcp.DefaultString("pss"),
+			mcp.Enum("pss", "pkcs1v15"),
+			mcp.Description("The signature algorithm of RSA keys, ignored for other keys. Defaults to 'pss'."),
+		),
+		mcp.WithString("context",
+			mcp.DefaultString(""),
+			mcp.Description("The base64 encoded context, required for derived Ed25519 keys."),
+		),
+	}
+}
+
+// signatureData returns the request body of a sign or verify request, or an error result when a parameter is invalid
+func signatureData(req mcp.CallToolRequest) (map[string]interface{}, *mcp.CallToolResult) {
+	input, ok := req.GetArguments()["input"].(string)
+	if !ok || input == "" {
+		return nil, mcp.NewToolResultError("Missing or invalid 'input' parameter")
+	}
+	encoded, err := encodeInput(input, req.GetBool("base64", false))
+	if err != nil {
+		return nil, mcp.NewToolResultError(fmt.Sprintf("Invalid 'input' parameter: %v", err))
+	}
+
+	data := map[string]interface{}{
+		"input":               encoded,
+		"hash_algorithm":      req.GetString("hash_algorithm", "sha2-256"),
+		"signature_algorithm": req.GetString("signature_algorithm", "pss"),
+	}
+	if transitContext := req.GetString("context", ""); transitContext != "" {
+		data["context"] = transitContext
+	}
+	return data, nil
+}
+
+// SignData creates a tool for signing data with a transit key
+func SignData(logger *log.Logger) server.ServerTool {
+	options := append([]mcp.ToolOption{
+		mcp.WithToolAnnotation(
+			mcp.ToolAnnotation{
+				ReadOnlyHint: utils.ToBoolPtr(true),
+			},
+		),
+		mcp.WithDescription("Sign data with a named Ed25519, ECDSA or RSA key of the transit secrets engine. The returned signature is prefixed with the key version, such as 'vault:v1:', and can be checked with the 'verify_data' tool."),
+	}, withSignatureParameters()...)
+	options = append(options,
+		mcp.WithNumber("key_version",
+			mcp.DefaultNumber(0),
+			mcp.Description("The version of the key to sign with. Defaults to 0, the latest version."),
+		),
+		schemas.Output("sign_data"),
+	)
+
+	return server.ServerTool{
+		Tool: mcp.NewTool("sign_data", options...),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return signDataHandler(ctx, req, logger)
+		},
+	}
+}
+
+func signDataHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling sign_data request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	key, ok := args["key"].(string)
+	if !ok || key == "" {
+		return mcp.NewToolResultError("Missing or invalid 'key' parameter"), nil
+	}
+
+	data, errResult := signatureData(req)
+	if errResult != nil {
+		return errResult, nil
+	}
+	if version := req.GetInt("key_version", 0); version > 0 {
+		data["key_version"] = version
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":          mount,
+		"key":            key,
+		"hash_algorithm": data["hash_algorithm"],
+	}).Debug("Signing data")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	if err := checkTransitMount(vault, mount); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	fullPath := fmt.Sprintf("%s/sign/%s", mount, key)
+
+	secret, err := vault.Logical().Write(fullPath, data)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to sign data with key '%s': %v", key, err)), nil
+	}
+	if secret == nil || secret.Data == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("no data returned from path '%s'", fullPath)), nil
+	}
+
+	result := TransitSignature{
+		Key:        key,
+		KeyVersion: keyVersion(secret.Data),
+	}
+	result.Signature, _ = secret.Data["signature"].(string)
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal result to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount":       mount,
+		"key":         key,
+		"key_version": result.KeyVersion,
+	}).Debug("Successfully signed data")
+
+	return mcp.NewToolResultStructured(result, string(jsonData)), nil
+}
@@ -0,0 +1,84 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package transit
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/hashicorp/vault/api"
+)
+
+// TransitCiphertext is the result of encrypting or rewrapping data with a transit key
+type TransitCiphertext struct {
+	Key        string `json:"key"`
+	Ciphertext string `json:"ciphertext"`  // Ciphertext prefixed with the key version, such as 'vault:v1:...'
+	KeyVersion int    `json:"key_version"` // Version of the key that encrypted the data
+}
+
+// TransitPlaintext is the result of decrypting data with a transit key
+type TransitPlaintext struct {
+	Key       string `json:"key"`
+	Plaintext string `json:"plaintext"`
+	Base64    bool   `json:"base64,omitempty"` // Whether the plaintext is base64 encoded because it is not UTF-8 text
+}
+
+// TransitSignature is the result of signing data with a transit key
+type TransitSignature struct {
+	Key        string `json:"key"`
+	Signature  string `json:"signature"`   // Signature prefixed with the key version, such as 'vault:v1:...'
+	KeyVersion int    `json:"key_version"` // Version of the key that signed the data
+}
+
+// TransitVerification is the result of verifying a signature with a transit key
+type TransitVerification struct {
+	Key   string `json:"key"`
+	Valid bool   `json:"valid"` // Whether the signature matches the data
+}
+
+// checkTransitMount fails when the mount does not exist or is not a transit mount
+func checkTransitMount(vault *api.Client, mount string) error {
+	mounts, err := vault.Sys().ListMounts()
+	if err != nil {
+		return fmt.Errorf("failed to list mounts: %v", err)
+	}
+	m, ok := mounts[mount+"/"]
+	if !ok {
+		return fmt.Errorf("mount path '%s' does not exist, you should use 'enable_transit' if you want enable transit on this mount.", mount)
+	}
+	if m.Type != "transit" {
+		return fmt.Errorf("mount path '%s' is a %s mount, not a transit mount", mount, m.Type)
+	}
+	return nil
+}
+
+// encodeInput returns the base64 encoding transit expects of a plaintext or input to sign, which is passed as is
+// when it is base64 encoded already
+func encodeInput(input string, isBase64 bool) (string, error) {
+	if !isBase64 {
+		return base64.StdEncoding.EncodeToString([]byte(input)), nil
+	}
+	if _, err := base64.StdEncoding.DecodeString(input); err != nil {
+		return "", fmt.Errorf("the input is not valid base64: %v", err)
+	}
+	return input, nil
+}
+
+// decodePlaintext decodes a plaintext returned by transit, keeping it base64 encoded when it is binary data
+func decodePlaintext(encoded string) (string, bool) {
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || !utf8.Valid(decoded) {
+		return encoded, true
+	}
+	return string(decoded), false
+}
+
+// keyVersion returns the key version of a transit response
+func keyVersion(data map[string]interface{}) int {
+	version, _ := data["key_version"].(json.Number)
+	v, _ := version.Int64()
+	return int(v)
+}
@@ -0,0 +1,233 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package transit
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSession struct {
+	id string
+}
+
+func (s testSession) SessionID() string                                   { return s.id }
+func (s testSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s testSession) Initialize()                                         {}
+func (s testSession) Initialized() bool                                   { return true }
+
+// newTransitVault starts a mock Vault with a transit mount whose 'ciphertexts' and 'signatures' are the base64
+// input prefixed with the key version, and returns the bodies of the requests to the mount
+func newTransitVault(t *testing.T) (context.Context, map[string]map[string]interface{}) {
+	t.Helper()
+
+	received := map[string]map[string]interface{}{}
+	keys := map[string]bool{"orders": true}
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		path := strings.TrimPrefix(r.URL.Path, "/v1/")
+		if path == "sys/mounts" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"transit/": map[string]interface{}{"type": "transit"},
+				"secret/":  map[string]interface{}{"type": "kv"},
+			}})
+			return
+		}
+
+		body := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		operation, key, _ := strings.Cut(strings.TrimPrefix(path, "transit/"), "/")
+		received[operation] = body
+		data := func(value map[string]interface{}) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": value})
+		}
+
+		switch operation {
+		case "keys":
+			if r.Method == http.MethodGet {
+				if !keys[key] {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				data(map[string]interface{}{"name": key})
+				return
+			}
+			keys[key] = true
+			w.WriteHeader(http.StatusNoContent)
+		case "encrypt":
+			data(map[string]interface{}{"ciphertext": "vault:v1:" + body["plaintext"].(string), "key_version": 1})
+		case "decrypt":
+			data(map[string]interface{}{"plaintext": strings.TrimPrefix(body["ciphertext"].(string), "vault:v1:")})
+		case "rewrap":
+			data(map[string]interface{}{"ciphertext": strings.Replace(body["ciphertext"].(string), "vault:v1:", "vault:v2:", 1), "key_version": 2})
+		case "sign":
+			data(map[string]interface{}{"signature": "vault:v1:" + body["input"].(string), "key_version": 1})
+		case "verify":
+			data(map[string]interface{}{"valid": body["signature"] == "vault:v1:"+body["input"].(string)})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(mockVault.Close)
+
+	sessionID := "test-" + t.Name()
+	_, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	t.Cleanup(func() { client.DeleteVaultClient(sessionID) })
+
+	return server.NewMCPServer("test", "1.0").WithContext(context.Background(), testSession{id: sessionID}), received
+}
+
+func callTool(t *testing.T, ctx context.Context, tool server.ServerTool, args map[string]interface{}) *mcp.CallToolResult {
+	t.Helper()
+	result, err := tool.Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: tool.Tool.Name, Arguments: args}})
+	require.NoError(t, err)
+	return result
+}
+
+func resultText(result *mcp.CallToolResult) string {
+	return result.Content[0].(mcp.TextContent).Text
+}
+
+func TestCreateTransitKey(t *testing.T) {
+	ctx, received := newTransitVault(t)
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	result := callTool(t, ctx, CreateTransitKey(logger), map[string]interface{}{"mount": "transit", "name": "releases", "type": "ed25519", "auto_rotate_period": "720h"})
+	require.False(t, result.IsError, resultText(result))
+	assert.Equal(t, "ed25519", received["keys"]["type"])
+	assert.Equal(t, "720h", received["keys"]["auto_rotate_period"])
+	assert.Equal(t, false, received["keys"]["exportable"])
+
+	changes := client.SessionChanges("test-" + t.Name())
+	require.Len(t, changes, 1)
+	assert.Equal(t, client.ResourceTransitKey, changes[0].ResourceType)
+	assert.Equal(t, "transit/keys/releases", changes[0].Path)
+
+	result = callTool(t, ctx, CreateTransitKey(logger), map[string]interface{}{"mount": "transit", "name": "orders"})
+	assert.True(t, result.IsError, "existing keys are not replaced")
+	result = callTool(t, ctx, CreateTransitKey(logger), map[string]interface{}{"mount": "transit", "name": "a", "type": "des"})
+	assert.True(t, result.IsError)
+	result = callTool(t, ctx, CreateTransitKey(logger), map[string]interface{}{"mount": "secret", "name": "a"})
+	assert.True(t, result.IsError, "the mount must be a transit mount")
+	assert.Contains(t, resultText(result), "not a transit mount")
+}
+
+func TestEncryptDecryptRewrap(t *testing.T) {
+	ctx, received := newTransitVault(t)
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	result := callTool(t, ctx, EncryptData(logger), map[string]interface{}{"mount": "transit", "key": "orders", "plaintext": "4111-1111-1111-1111", "context": "b3JkZXJz"})
+	require.False(t, result.IsError, resultText(result))
+	var ciphertext TransitCiphertext
+	require.NoError(t, json.Unmarshal([]byte(resultText(result)), &ciphertext))
+	assert.Equal(t, "vault:v1:"+base64.StdEncoding.EncodeToString([]byte("4111-1111-1111-1111")), ciphertext.Ciphertext)
+	assert.Equal(t, 1, ciphertext.KeyVersion)
+	assert.Equal(t, "b3JkZXJz", received["encrypt"]["context"])
+	assert.NotContains(t, received["encrypt"], "key_version")
+
+	result = callTool(t, ctx, RewrapData(logger), map[string]interface{}{"mount": "transit", "key": "orders", "ciphertext": ciphertext.Ciphertext})
+	require.False(t, result.IsError, resultText(result))
+	var rewrapped TransitCiphertext
+	require.NoError(t, json.Unmarshal([]byte(resultText(result)), &rewrapped))
+	assert.Equal(t, 2, rewrapped.KeyVersion)
+	assert.True(t, strings.HasPrefix(rewrapped.Ciphertext, "vault:v2:"))
+
+	result = callTool(t, ctx, DecryptData(logger), map[string]interface{}{"mount": "transit", "key": "orders", "ciphertext": ciphertext.Ciphertext})
+	require.False(t, result.IsError, resultText(result))
+	var plaintext TransitPlaintext
+	require.NoError(t, json.Unmarshal([]byte(resultText(result)), &plaintext))
+	assert.Equal(t, "4111-1111-1111-1111", plaintext.Plaintext)
+	assert.False(t, plaintext.Base64)
+
+	t.Run("binary data stays base64 encoded", func(t *testing.T) {
+		binary := base64.StdEncoding.EncodeToString([]byte{0xff, 0xfe, 0x00})
+		result := callTool(t, ctx, EncryptData(logger), map[string]interface{}{"mount": "transit", "key": "orders", "plaintext": binary, "base64": true})
+		require.False(t, result.IsError, resultText(result))
+		assert.Equal(t, binary, received["encrypt"]["plaintext"])
+
+		result = callTool(t, ctx, DecryptData(logger), map[string]interface{}{"mount": "transit", "key": "orders", "ciphertext": "vault:v1:" + binary})
+		require.False(t, result.IsError, resultText(result))
+		require.NoError(t, json.Unmarshal([]byte(resultText(result)), &plaintext))
+		assert.Equal(t, binary, plaintext.Plaintext)
+		assert.True(t, plaintext.Base64)
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		for name, args := range map[string]map[string]interface{}{
+			"missing key":     {"mount": "transit", "plaintext": "a"},
+			"invalid base64":  {"mount": "transit", "key": "orders", "plaintext": "not base64!", "base64": true},
+			"missing mount":   {"mount": "missing", "key": "orders", "plaintext": "a"},
+			"empty plaintext": {"mount": "transit", "key": "orders", "plaintext": ""},
+		} {
+			result := callTool(t, ctx, EncryptData(logger), args)
+			assert.True(t, result.IsError, name)
+		}
+	})
+}
+
+func TestSignVerify(t *testing.T) {
+	ctx, received := newTransitVault(t)
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	result := callTool(t, ctx, SignData(logger), map[string]interface{}{"mount": "transit", "key": "releases", "input": "release-1.4.0", "hash_algorithm": "sha2-512"})
+	require.False(t, result.IsError, resultText(result))
+	var signature TransitSignature
+	require.NoError(t, json.Unmarshal([]byte(resultText(result)), &signature))
+	assert.Equal(t, 1, signature.KeyVersion)
+	assert.Equal(t, "sha2-512", received["sign"]["hash_algorithm"])
+	assert.Equal(t, "pss", received["sign"]["signature_algorithm"])
+
+	var verification TransitVerification
+	result = callTool(t, ctx, VerifyData(logger), map[string]interface{}{"mount": "transit", "key": "releases", "input": "release-1.4.0", "signature": signature.Signature})
+	require.False(t, result.IsError, resultText(result))
+	require.NoError(t, json.Unmarshal([]byte(resultText(result)), &verification))
+	assert.True(t, verification.Valid)
+
+	result = callTool(t, ctx, VerifyData(logger), map[string]interface{}{"mount": "transit", "key": "releases", "input": "release-1.4.1", "signature": signature.Signature})
+	require.False(t, result.IsError, resultText(result))
+	require.NoError(t, json.Unmarshal([]byte(resultText(result)), &verification))
+	assert.False(t, verification.Valid)
+
+	result = callTool(t, ctx, VerifyData(logger), map[string]interface{}{"mount": "transit", "key": "releases", "input": "release-1.4.0"})
+	assert.True(t, result.IsError, "the signature is required")
+}
+
+func TestToolAnnotations(t *testing.T) {
+	logger := log.New()
+	readOnly := map[string]bool{}
+	for _, tool := range []server.ServerTool{
+		EnableTransit(logger), CreateTransitKey(logger), EncryptData(logger), DecryptData(logger),
+		RewrapData(logger), SignData(logger), VerifyData(logger),
+	} {
+		hint := tool.Tool.Annotations.ReadOnlyHint
+		readOnly[tool.Tool.Name] = hint != nil && *hint
+	}
+
+	// Cryptographic operations change nothing in Vault, only the mount and its keys do
+	assert.Equal(t, map[string]bool{
+		"enable_transit":     false,
+		"create_transit_key": false,
+		"encrypt_data":       true,
+		"decrypt_data":       true,
+		"rewrap_data":        true,
+		"sign_data":          true,
+		"verify_data":        true,
+	}, readOnly)
+}
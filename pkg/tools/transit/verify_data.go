@@ -0,0 +1,118 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package transit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// VerifyData creates a tool for verifying signatures with a transit key
+func VerifyData(logger *log.Logger) server.ServerTool {
+	options := append([]mcp.ToolOption{
+		mcp.WithToolAnnotation(
+			mcp.ToolAnnotation{
+				ReadOnlyHint: utils.ToBoolPtr(true),
+			},
+		),
+		mcp.WithDescription("Verify a signature returned by 'sign_data' against the signed data, with the named transit key that signed it. Pass the same hash and signature algorithms that the data was signed with."),
+	}, withSignatureParameters()...)
+	options = append(options,
+		mcp.WithString("signature",
+			mcp.Required(),
+			mcp.Description("The signature to verify, such as 'vault:v1:...'."),
+		),
+		schemas.Output("verify_data"),
+	)
+
+	return server.ServerTool{
+		Tool: mcp.NewTool("verify_data", options...),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return verifyDataHandler(ctx, req, logger)
+		},
+	}
+}
+
+func verifyDataHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling verify_data request")
+
+	// Extract parameters
+	args, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("Missing or invalid arguments format"), nil
+	}
+
+	mount, err := utils.ExtractMountPath(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	key, ok := args["key"].(string)
+	if !ok || key == "" {
+		return mcp.NewToolResultError("Missing or invalid 'key' parameter"), nil
+	}
+
+	signature, ok := args["signature"].(string)
+	if !ok || signature == "" {
+		return mcp.NewToolResultError("Missing or invalid 'signature' parameter"), nil
+	}
+
+	data, errResult := signatureData(req)
+	if errResult != nil {
+		return errResult, nil
+	}
+	data["signature"] = signature
+
+	logger.WithFields(log.Fields{
+		"mount":          mount,
+		"key":            key,
+		"hash_algorithm": data["hash_algorithm"],
+	}).Debug("Verifying signature")
+
+	// Get Vault client from context
+	vault, err := client.GetVaultClientFromContext(ctx, logger)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get Vault client")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+	}
+
+	if err := checkTransitMount(vault, mount); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	fullPath := fmt.Sprintf("%s/verify/%s", mount, key)
+
+	secret, err := vault.Logical().Write(fullPath, data)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to verify signature with key '%s': %v", key, err)), nil
+	}
+	if secret == nil || secret.Data == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("no data returned from path '%s'", fullPath)), nil
+	}
+
+	result := TransitVerification{Key: key}
+	result.Valid, _ = secret.Data["valid"].(bool)
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal result to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	logger.WithFields(log.Fields{
+		"mount": mount,
+		"key":   key,
+		"valid": result.Valid,
+	}).Debug("Successfully verified signature")
+
+	return mcp.NewToolResultStructured(result, string(jsonData)), nil
+}
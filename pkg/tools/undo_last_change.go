@@ -0,0 +1,359 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/hashicorp/vault-mcp-server/pkg/tools/schemas"
+	"github.com/hashicorp/vault-mcp-server/pkg/utils"
+	"github.com/hashicorp/vault/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+)
+
+// UndoReport describes the undo of a change of the session
+type UndoReport struct {
+	Change client.ChangeRecord  `json:"change"`           // Change that is undone
+	Undo   string               `json:"undo"`             // Inverse operation run to undo the change
+	DryRun bool                 `json:"dry_run"`          // Whether the inverse operation was only planned
+	Result *client.ChangeRecord `json:"result,omitempty"` // Change record of the inverse operation, once it ran
+}
+
+// undoOperation is the inverse of a change
+type undoOperation struct {
+	description string
+	record      client.ChangeRecord
+	run         func(vault *api.Client) error
+}
+
+// UndoLastChange creates a tool reverting the last change made in Vault during the current session
+func UndoLastChange(logger *log.Logger) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("undo_last_change",
+			mcp.WithDescription("Undo the most recent change that tools made in Vault during the current MCP session by running its inverse operation: a created mount, auth method, role or template is deleted, a previous KV v2 version or configuration is restored, and a deleted KV v2 secret is undeleted. Changes that cannot be reverted, such as KV v1 values, generated keys and issuers, or mounts deleted without a backup, are reported and left as is. Use 'dry_run' to review the inverse operation first and get_session_changes to list the changes."),
+			mcp.WithToolAnnotation(
+				mcp.ToolAnnotation{
+					DestructiveHint: utils.ToBoolPtr(true),
+				},
+			),
+			mcp.WithNumber("change_id",
+				mcp.Description("The ID of the change to undo instead of the most recent one, as listed by get_session_changes. Later changes of the same resource must be undone first."),
+			),
+			mcp.WithBoolean("dry_run",
+				mcp.DefaultBool(false),
+				mcp.Description("Only describe the inverse operation without running it."),
+			),
+			schemas.Output("undo_last_change"),
+		),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return undoLastChangeHandler(ctx, req, logger)
+		},
+	}
+}
+
+func undoLastChangeHandler(ctx context.Context, req mcp.CallToolRequest, logger *log.Logger) (*mcp.CallToolResult, error) {
+	logger.Debug("Handling undo_last_change request")
+
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return mcp.NewToolResultError("No active session"), nil
+	}
+
+	change, err := findChangeToUndo(client.SessionChanges(session.SessionID()), req.GetInt("change_id", 0))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	undo, err := inverseChange(change)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Change %d (%s by '%s') cannot be undone: %v", change.ID, change.Operation, change.Tool, err)), nil
+	}
+
+	report := UndoReport{Change: change, Undo: undo.description, DryRun: req.GetBool("dry_run", false)}
+	if !report.DryRun {
+		vault, err := client.GetVaultClientFromContext(ctx, logger)
+		if err != nil {
+			logger.WithError(err).Error("Failed to get Vault client")
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get Vault client: %v", err)), nil
+		}
+		// The change is undone in the namespace it was made in
+		if change.Namespace != vault.Namespace() {
+			vault = vault.WithNamespace(change.Namespace)
+		}
+
+		if err := undo.run(vault); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to undo change %d: %v", change.ID, err)), nil
+		}
+
+		undo.record.Tool = req.Params.Name
+		undo.record.Namespace = change.Namespace
+		undo.record.UndoOf = change.ID
+		undo.record.Message = fmt.Sprintf("Undid change %d: %s", change.ID, undo.description)
+		result := client.RecordChange(ctx, undo.record)
+		client.MarkChangeUndone(session.SessionID(), change.ID, result.ID)
+		report.Result = &result
+
+		logger.WithFields(log.Fields{
+			"change_id": change.ID,
+			"tool":      change.Tool,
+			"path":      change.Path,
+		}).Info("Successfully undid change")
+	}
+
+	jsonData, err := json.Marshal(report)
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal undo report to JSON")
+		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling JSON: %v", err)), nil
+	}
+
+	return mcp.NewToolResultStructured(report, string(jsonData)), nil
+}
+
+// findChangeToUndo returns the change with the ID, or the most recent change when id is 0. Changes that were
+// undone and undo operations themselves are skipped, and a change is only undone when no later change of
+// the same resource, or of a resource inside the same mount for mounts and auth methods, is still in effect.
+func findChangeToUndo(changes []client.ChangeRecord, id int) (client.ChangeRecord, error) {
+	var candidates []client.ChangeRecord
+	for _, change := range changes {
+		if change.UndoOf == 0 && change.UndoneBy == 0 {
+			candidates = append(candidates, change)
+		}
+	}
+	if len(candidates) == 0 {
+		return client.ChangeRecord{}, fmt.Errorf("there are no changes to undo in this session")
+	}
+	if id == 0 {
+		return candidates[len(candidates)-1], nil
+	}
+
+	for i, change := range candidates {
+		if change.ID != id {
+			continue
+		}
+		for _, later := range candidates[i+1:] {
+			if later.Namespace != change.Namespace {
+				continue
+			}
+			if later.Path == change.Path || (coversMount(change) && later.Mount == change.Mount) {
+				return client.ChangeRecord{}, fmt.Errorf("change %d was followed by change %d on '%s', undo it first", id, later.ID, later.Path)
+			}
+		}
+		return change, nil
+	}
+
+	for _, change := range changes {
+		if change.ID == id {
+			return client.ChangeRecord{}, fmt.Errorf("change %d was already undone or is an undo itself", id)
+		}
+	}
+	return client.ChangeRecord{}, fmt.Errorf("change %d is not a change of this session", id)
+}
+
+// coversMount reports whether undoing the change affects everything inside its mount
+func coversMount(change client.ChangeRecord) bool {
+	return change.Mount != "" && (change.ResourceType == client.ResourceMount || change.ResourceType == client.ResourceAuthMethod)
+}
+
+// inverseChange plans the operation reverting a change, or explains why the change cannot be reverted
+func inverseChange(change client.ChangeRecord) (undoOperation, error) {
+	switch change.ResourceType {
+	case client.ResourceMount:
+		return inverseMountChange(change)
+	case client.ResourceAuthMethod:
+		if change.Operation != client.OperationCreate {
+			return undoOperation{}, fmt.Errorf("only enabled auth methods can be disabled again")
+		}
+		return undoOperation{
+			description: fmt.Sprintf("disable the auth method at '%s'", change.Mount),
+			record:      client.ChangeRecord{ResourceType: change.ResourceType, Mount: change.Mount, Path: change.Path, Operation: client.OperationDelete, Previous: change.New},
+			run: func(vault *api.Client) error {
+				return vault.Sys().DisableAuth(change.Mount)
+			},
+		}, nil
+	case client.ResourceKVSecret:
+		return inverseSecretChange(change)
+	case client.ResourceManagedKeyMount:
+		return undoOperation{
+			description: fmt.Sprintf("restore the allowed managed keys of mount '%s'", change.Mount),
+			record:      client.ChangeRecord{ResourceType: change.ResourceType, Mount: change.Mount, Path: change.Path, Operation: client.OperationUpdate, Previous: change.New, New: change.Previous},
+			run: func(vault *api.Client) error {
+				_, err := vault.Logical().Write(change.Path, map[string]interface{}{
+					"allowed_managed_keys": change.Previous["allowed_managed_keys"],
+				})
+				return err
+			},
+		}, nil
+	case client.ResourcePKIIssuer, client.ResourcePKIKey:
+		return undoOperation{}, fmt.Errorf("keys and issuers cannot be generated again once deleted and may already have signed certificates, delete them explicitly if they are no longer needed")
+	case client.ResourceTransitKey:
+		return undoOperation{}, fmt.Errorf("data encrypted with a transit key can no longer be decrypted once the key is deleted, delete it explicitly if it is no longer needed")
+	case client.ResourceGitHubConfig:
+		if change.Operation == client.OperationCreate {
+			return undoOperation{}, fmt.Errorf("the configuration of an auth method cannot be deleted, undo the change that enabled the auth method instead")
+		}
+		return inverseConfigChange(change)
+	case client.ResourceDatabaseConnection:
+		if change.Operation != client.OperationCreate {
+			return undoOperation{}, fmt.Errorf("Vault does not return the password of a connection, configure the connection again instead")
+		}
+		return inverseConfigChange(change)
+	case client.ResourceRawEndpoint:
+		return undoOperation{}, fmt.Errorf("writes to endpoints without a dedicated tool have no known inverse, revert them explicitly")
+	case client.ResourceMongoDBAtlasConfig:
+		if change.Operation == client.OperationCreate {
+			return undoOperation{}, fmt.Errorf("the configuration of a MongoDB Atlas mount cannot be deleted, undo the change that enabled the mount instead")
+		}
+		return undoOperation{}, fmt.Errorf("Vault does not return the private key of the configuration, configure the mount again instead")
+	default:
+		return inverseConfigChange(change)
+	}
+}
+
+// inverseMountChange disables a created mount, or moves the backup of a deleted mount back to its path
+func inverseMountChange(change client.ChangeRecord) (undoOperation, error) {
+	if change.Operation == client.OperationCreate {
+		return undoOperation{
+			description: fmt.Sprintf("disable the mount at '%s', deleting any data written to it", change.Mount),
+			record:      client.ChangeRecord{ResourceType: change.ResourceType, Mount: change.Mount, Path: change.Path, Operation: client.OperationDelete, Previous: change.New},
+			run: func(vault *api.Client) error {
+				return vault.Sys().Unmount(change.Mount)
+			},
+		}, nil
+	}
+
+	backup, _ := change.New["backup_mount"].(string)
+	if change.Operation != client.OperationDelete || backup == "" {
+		return undoOperation{}, fmt.Errorf("the data of a mount deleted without a backup is gone")
+	}
+	return undoOperation{
+		description: fmt.Sprintf("move the backup mount '%s' back to '%s', only the current version of KV v2 secrets was backed up", backup, change.Mount),
+		record: client.ChangeRecord{
+			ResourceType: change.ResourceType,
+			Mount:        change.Mount,
+			Path:         change.Path,
+			Operation:    client.OperationCreate,
+			New:          map[string]any{"type": change.Previous["type"], "restored_from": backup},
+		},
+		run: func(vault *api.Client) error {
+			return vault.Sys().Remount(backup, change.Mount)
+		},
+	}, nil
+}
+
+// inverseSecretChange reverts a KV v2 change using the versions of the secret. KV v1 mounts keep no
+// previous values, so only the creation of a KV v1 secret can be reverted.
+func inverseSecretChange(change client.ChangeRecord) (undoOperation, error) {
+	previousVersion := change.Previous["version"]
+	newVersion := change.New["version"]
+	relative, v2 := strings.CutPrefix(change.Path, change.Mount+"/data/")
+	v2 = v2 && (previousVersion != nil || newVersion != nil)
+	record := client.ChangeRecord{ResourceType: change.ResourceType, Mount: change.Mount, Path: change.Path, Previous: change.New, New: change.Previous}
+
+	switch {
+	case change.Operation == client.OperationCreate && v2:
+		record.Operation = client.OperationDelete
+		return undoOperation{
+			description: fmt.Sprintf("delete version %v of secret '%s'", newVersion, change.Path),
+			record:      record,
+			run: func(vault *api.Client) error {
+				_, err := vault.Logical().Write(change.Mount+"/delete/"+relative, map[string]interface{}{
+					"versions": []interface{}{newVersion},
+				})
+				return err
+			},
+		}, nil
+	case change.Operation == client.OperationCreate:
+		record.Operation = client.OperationDelete
+		return undoOperation{
+			description: fmt.Sprintf("delete secret '%s'", change.Path),
+			record:      record,
+			run: func(vault *api.Client) error {
+				_, err := vault.Logical().Delete(change.Path)
+				return err
+			},
+		}, nil
+	case !v2:
+		return undoOperation{}, fmt.Errorf("KV v1 mounts keep no previous values")
+	case previousVersion == nil:
+		return undoOperation{}, fmt.Errorf("the previous version of the secret was not recorded")
+	case change.Operation == client.OperationDelete:
+		record.Operation = client.OperationCreate
+		return undoOperation{
+			description: fmt.Sprintf("undelete version %v of secret '%s'", previousVersion, change.Path),
+			record:      record,
+			run: func(vault *api.Client) error {
+				_, err := vault.Logical().Write(change.Mount+"/undelete/"+relative, map[string]interface{}{
+					"versions": []interface{}{previousVersion},
+				})
+				return err
+			},
+		}, nil
+	default:
+		record.Operation = client.OperationUpdate
+		return undoOperation{
+			description: fmt.Sprintf("write version %v of secret '%s' as its current version", previousVersion, change.Path),
+			record:      record,
+			run: func(vault *api.Client) error {
+				previous, err := vault.Logical().ReadWithData(change.Path, map[string][]string{
+					"version": {fmt.Sprint(previousVersion)},
+				})
+				if err != nil {
+					return err
+				}
+				var data map[string]interface{}
+				if previous != nil {
+					data, _ = previous.Data["data"].(map[string]interface{})
+				}
+				if data == nil {
+					return fmt.Errorf("version %v of secret '%s' was deleted or destroyed", previousVersion, change.Path)
+				}
+				_, err = vault.Logical().Write(change.Path, map[string]interface{}{"data": data})
+				return err
+			},
+		}, nil
+	}
+}
+
+// inverseConfigChange reverts a change of a configuration path, such as a role or template, by deleting
+// what was created and writing back the previous configuration of what was updated or deleted
+func inverseConfigChange(change client.ChangeRecord) (undoOperation, error) {
+	record := client.ChangeRecord{ResourceType: change.ResourceType, Mount: change.Mount, Path: change.Path, Previous: change.New, New: change.Previous}
+
+	if change.Operation == client.OperationCreate {
+		if change.Path == "" || strings.HasSuffix(change.Path, "/") {
+			return undoOperation{}, fmt.Errorf("the path of the created resource was not recorded")
+		}
+		record.Operation = client.OperationDelete
+		return undoOperation{
+			description: fmt.Sprintf("delete '%s'", change.Path),
+			record:      record,
+			run: func(vault *api.Client) error {
+				_, err := vault.Logical().Delete(change.Path)
+				return err
+			},
+		}, nil
+	}
+
+	if change.Previous == nil {
+		return undoOperation{}, fmt.Errorf("the previous configuration was not recorded")
+	}
+	record.Operation = client.OperationUpdate
+	if change.Operation == client.OperationDelete {
+		record.Operation = client.OperationCreate
+	}
+	return undoOperation{
+		description: fmt.Sprintf("write the previous configuration back to '%s'", change.Path),
+		record:      record,
+		run: func(vault *api.Client) error {
+			_, err := vault.Logical().Write(change.Path, change.Previous)
+			return err
+		},
+	}, nil
+}
@@ -0,0 +1,185 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/vault-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSession implements server.ClientSession for testing
+type testSession struct {
+	id string
+}
+
+func (s testSession) Initialize()                                         {}
+func (s testSession) Initialized() bool                                   { return true }
+func (s testSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s testSession) SessionID() string                                   { return s.id }
+
+// vaultRequest is a request received by the mock Vault server
+type vaultRequest struct {
+	method string
+	path   string
+	query  string
+	body   map[string]interface{}
+}
+
+// newUndoTestContext creates a session context wired to a mock Vault server recording the requests it receives
+func newUndoTestContext(t *testing.T) (context.Context, *[]vaultRequest) {
+	t.Helper()
+
+	var requests []vaultRequest
+	mockVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		requests = append(requests, vaultRequest{method: r.Method, path: r.URL.Path, query: r.URL.RawQuery, body: body})
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{"data": map[string]interface{}{"password": "old"}},
+			})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(mockVault.Close)
+
+	sessionID := "test-" + t.Name()
+	_, err := client.NewVaultClient(sessionID, mockVault.URL, false, "test-token", "")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		client.DeleteVaultClient(sessionID)
+		client.DeleteSessionChanges(sessionID)
+	})
+
+	return server.NewMCPServer("test", "1.0.0").WithContext(context.Background(), testSession{id: sessionID}), &requests
+}
+
+func callUndo(t *testing.T, ctx context.Context, args map[string]interface{}) *mcp.CallToolResult {
+	t.Helper()
+	logger := log.New()
+	logger.SetLevel(log.ErrorLevel)
+
+	result, err := undoLastChangeHandler(ctx, mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Name: "undo_last_change", Arguments: args},
+	}, logger)
+	require.NoError(t, err)
+	return result
+}
+
+func TestUndoLastChange_RestoresPreviousSecretVersion(t *testing.T) {
+	ctx, requests := newUndoTestContext(t)
+
+	client.RecordChange(ctx, client.ChangeRecord{
+		Tool:         "write_secret",
+		ResourceType: client.ResourceKVSecret,
+		Mount:        "secret",
+		Path:         "secret/data/app",
+		Operation:    client.OperationUpdate,
+		Previous:     map[string]any{"version": json.Number("3"), "keys": []string{"password"}},
+		New:          map[string]any{"version": json.Number("4"), "keys": []string{"password"}},
+	})
+
+	result := callUndo(t, ctx, map[string]interface{}{})
+	require.False(t, result.IsError, result.Content)
+
+	require.Len(t, *requests, 2)
+	assert.Equal(t, "version=3", (*requests)[0].query)
+	assert.Equal(t, http.MethodPut, (*requests)[1].method)
+	assert.Equal(t, "/v1/secret/data/app", (*requests)[1].path)
+	assert.Equal(t, map[string]interface{}{"password": "old"}, (*requests)[1].body["data"])
+
+	var report UndoReport
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &report))
+	require.NotNil(t, report.Result)
+	assert.Equal(t, 1, report.Result.UndoOf)
+	assert.NotContains(t, result.Content[0].(mcp.TextContent).Text, "old")
+
+	changes := client.SessionChanges("test-" + t.Name())
+	require.Len(t, changes, 2)
+	assert.Equal(t, report.Result.ID, changes[0].UndoneBy)
+
+	// The undo itself is not undone, and nothing else is left
+	result = callUndo(t, ctx, map[string]interface{}{})
+	assert.True(t, result.IsError)
+}
+
+func TestUndoLastChange_DisablesCreatedMount(t *testing.T) {
+	ctx, requests := newUndoTestContext(t)
+
+	client.RecordChange(ctx, client.ChangeRecord{ResourceType: client.ResourceMount, Mount: "pki-int", Path: "sys/mounts/pki-int", Operation: client.OperationCreate})
+	client.RecordChange(ctx, client.ChangeRecord{ResourceType: client.ResourcePKIRole, Mount: "pki-int", Path: "pki-int/roles/web", Operation: client.OperationCreate})
+
+	t.Run("later changes inside the mount are undone first", func(t *testing.T) {
+		result := callUndo(t, ctx, map[string]interface{}{"change_id": float64(1)})
+		assert.True(t, result.IsError)
+		assert.Empty(t, *requests)
+	})
+
+	t.Run("dry run does not call Vault", func(t *testing.T) {
+		result := callUndo(t, ctx, map[string]interface{}{"dry_run": true})
+		require.False(t, result.IsError)
+		assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "delete 'pki-int/roles/web'")
+		assert.Empty(t, *requests)
+	})
+
+	t.Run("changes are undone last first", func(t *testing.T) {
+		require.False(t, callUndo(t, ctx, map[string]interface{}{}).IsError)
+		require.False(t, callUndo(t, ctx, map[string]interface{}{}).IsError)
+
+		require.Len(t, *requests, 2)
+		assert.Equal(t, http.MethodDelete, (*requests)[0].method)
+		assert.Equal(t, "/v1/pki-int/roles/web", (*requests)[0].path)
+		assert.Equal(t, http.MethodDelete, (*requests)[1].method)
+		assert.Equal(t, "/v1/sys/mounts/pki-int", (*requests)[1].path)
+	})
+}
+
+func TestUndoLastChange_IrreversibleChanges(t *testing.T) {
+	tests := []struct {
+		name   string
+		change client.ChangeRecord
+	}{
+		{
+			name:   "KV v1 update",
+			change: client.ChangeRecord{ResourceType: client.ResourceKVSecret, Mount: "kv", Path: "kv/app", Operation: client.OperationUpdate, Previous: map[string]any{"keys": []string{"a"}}},
+		},
+		{
+			name:   "generated key",
+			change: client.ChangeRecord{ResourceType: client.ResourcePKIKey, Mount: "pki", Path: "pki/key/abc", Operation: client.OperationCreate},
+		},
+		{
+			name:   "created transit key",
+			change: client.ChangeRecord{ResourceType: client.ResourceTransitKey, Mount: "transit", Path: "transit/keys/orders", Operation: client.OperationCreate},
+		},
+		{
+			name:   "mount deleted without backup",
+			change: client.ChangeRecord{ResourceType: client.ResourceMount, Mount: "kv", Path: "sys/mounts/kv", Operation: client.OperationDelete},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, requests := newUndoTestContext(t)
+			client.RecordChange(ctx, tt.change)
+
+			result := callUndo(t, ctx, map[string]interface{}{})
+			assert.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "cannot be undone")
+			assert.Empty(t, *requests)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ErrConfirmationUnavailable is returned when the client of the session cannot be asked for a confirmation
+var ErrConfirmationUnavailable = errors.New("client does not support elicitation")
+
+// RequestConfirmation asks the user of the current session to confirm an action through elicitation.
+// It returns ErrConfirmationUnavailable when the client did not declare the elicitation capability.
+func RequestConfirmation(ctx context.Context, message string) (bool, error) {
+	srv := server.ServerFromContext(ctx)
+	if srv == nil {
+		return false, ErrConfirmationUnavailable
+	}
+
+	session, ok := server.ClientSessionFromContext(ctx).(server.SessionWithClientInfo)
+	if !ok || session.GetClientCapabilities().Elicitation == nil {
+		return false, ErrConfirmationUnavailable
+	}
+
+	result, err := srv.RequestElicitation(ctx, mcp.ElicitationRequest{
+		Request: mcp.Request{Method: string(mcp.MethodElicitationCreate)},
+		Params: mcp.ElicitationParams{
+			Message: message,
+			RequestedSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"confirm": map[string]any{
+						"type":        "boolean",
+						"description": "Confirm that you want to proceed",
+					},
+				},
+				"required": []string{"confirm"},
+			},
+		},
+	})
+	if errors.Is(err, server.ErrElicitationNotSupported) {
+		return false, ErrConfirmationUnavailable
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if result.Action != mcp.ElicitationResponseActionAccept {
+		return false, nil
+	}
+
+	content, _ := result.Content.(map[string]any)
+	confirmed, _ := content["confirm"].(bool)
+
+	return confirmed, nil
+}
@@ -0,0 +1,74 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	FormatJSON     = "json"
+	FormatMarkdown = "markdown"
+	FormatTable    = "table"
+)
+
+// ExtractFormat returns the requested output format, defaulting to json
+func ExtractFormat(args map[string]any) (string, error) {
+	format, ok := args["format"].(string)
+	if !ok || format == "" {
+		return FormatJSON, nil
+	}
+
+	switch format {
+	case FormatJSON, FormatMarkdown, FormatTable:
+		return format, nil
+	default:
+		return "", fmt.Errorf("invalid 'format' parameter '%s', must be one of 'json', 'markdown' or 'table'", format)
+	}
+}
+
+// RenderTable renders the rows as a markdown table or an aligned plain text table
+func RenderTable(format string, headers []string, rows [][]string) string {
+	var sb strings.Builder
+
+	if format == FormatMarkdown {
+		writeMarkdownRow(&sb, headers)
+		separators := make([]string, len(headers))
+		for i := range separators {
+			separators[i] = "---"
+		}
+		writeMarkdownRow(&sb, separators)
+		for _, row := range rows {
+			writeMarkdownRow(&sb, row)
+		}
+		return sb.String()
+	}
+
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = strings.ReplaceAll(cell, "\n", " ")
+		}
+		_, _ = fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	_ = tw.Flush()
+
+	return sb.String()
+}
+
+func writeMarkdownRow(sb *strings.Builder, cells []string) {
+	sb.WriteString("|")
+	for _, cell := range cells {
+		cell = strings.ReplaceAll(cell, "|", "\\|")
+		cell = strings.ReplaceAll(cell, "\n", " ")
+		sb.WriteString(" ")
+		sb.WriteString(cell)
+		sb.WriteString(" |")
+	}
+	sb.WriteString("\n")
+}
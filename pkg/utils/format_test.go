@@ -0,0 +1,38 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractFormat(t *testing.T) {
+	format, err := ExtractFormat(nil)
+	require.NoError(t, err)
+	assert.Equal(t, FormatJSON, format)
+
+	format, err = ExtractFormat(map[string]any{"format": "markdown"})
+	require.NoError(t, err)
+	assert.Equal(t, FormatMarkdown, format)
+
+	_, err = ExtractFormat(map[string]any{"format": "yaml"})
+	assert.Error(t, err)
+}
+
+func TestRenderTable(t *testing.T) {
+	headers := []string{"name", "type"}
+	rows := [][]string{
+		{"secrets/", "kv"},
+		{"a|b/", "pki"},
+	}
+
+	markdown := RenderTable(FormatMarkdown, headers, rows)
+	assert.Equal(t, "| name | type |\n| --- | --- |\n| secrets/ | kv |\n| a\\|b/ | pki |\n", markdown)
+
+	table := RenderTable(FormatTable, headers, rows)
+	assert.Equal(t, "name      type\nsecrets/  kv\na|b/      pki\n", table)
+}
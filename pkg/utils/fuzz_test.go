@@ -0,0 +1,39 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func FuzzExtractMountPath(f *testing.F) {
+	f.Add([]byte(`{"mount":"secret"}`))
+	f.Add([]byte(`{"mount":"secret/"}`))
+	f.Add([]byte(`{"mount":"/"}`))
+	f.Add([]byte(`{"mount":"//"}`))
+	f.Add([]byte(`{"mount":42}`))
+	f.Add([]byte(`{"mount":null,"format":["json"]}`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var args map[string]any
+		if err := json.Unmarshal(data, &args); err != nil {
+			t.Skip()
+		}
+
+		mount, err := ExtractMountPath(args)
+		if err == nil {
+			require.NotEmpty(t, mount)
+			require.Equal(t, strings.TrimSuffix(args["mount"].(string), "/"), mount)
+		}
+
+		format, err := ExtractFormat(args)
+		if err == nil {
+			require.Contains(t, []string{FormatJSON, FormatMarkdown, FormatTable}, format)
+		}
+	})
+}
@@ -0,0 +1,157 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// MaxResultBytesEnv overrides the size cap of large JSON results
+const MaxResultBytesEnv = "MCP_MAX_RESULT_BYTES"
+
+// MaxResultBytes caps the size of large JSON results, such as security reports and recursive listings.
+// Items that do not fit are left out and the result summarizes what was omitted.
+var MaxResultBytes = 4 << 20
+
+// ErrResultTooLarge is returned when an item does not fit within the size cap of a result
+var ErrResultTooLarge = errors.New("result exceeds the maximum result size")
+
+// maxPooledBuffer is the capacity above which encoding buffers are not kept for reuse, so that a single
+// result larger than the default size cap does not stay in memory for the lifetime of the server
+const maxPooledBuffer = 4 << 20
+
+var jsonBuffers = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
+}
+
+// LoadMaxResultBytesFromEnv returns the size cap set by MCP_MAX_RESULT_BYTES, or false when it is not set or invalid
+func LoadMaxResultBytesFromEnv() (int, bool) {
+	limit, err := strconv.Atoi(os.Getenv(MaxResultBytesEnv))
+	if err != nil || limit <= 0 {
+		return 0, false
+	}
+	return limit, true
+}
+
+// encodeJSON encodes v with a pooled buffer and passes the encoding, which is only valid during the call, to write
+func encodeJSON(v any, write func([]byte) error) error {
+	buf := jsonBuffers.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer func() {
+		if buf.Cap() <= maxPooledBuffer {
+			jsonBuffers.Put(buf)
+		}
+	}()
+
+	if err := json.NewEncoder(buf).Encode(v); err != nil {
+		return err
+	}
+	// Encode terminates the value with a newline that json.Marshal does not write
+	return write(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+}
+
+// MarshalJSON returns the JSON encoding of v as produced by json.Marshal. The encoding buffers are reused
+// across calls and the result is copied only once, where string(json.Marshal(v)) copies it twice.
+func MarshalJSON(v any) (string, error) {
+	var result string
+	err := encodeJSON(v, func(data []byte) error {
+		result = string(data)
+		return nil
+	})
+	return result, err
+}
+
+// JSONStream writes large JSON results one piece at a time. Arrays are encoded element by element into a
+// pooled buffer that the text of the result is copied from once, instead of encoding the whole value into a
+// buffer, copying it into a byte slice and copying that again into the text. Elements that do not fit within
+// the size cap are not written, so results stop growing once they reach it.
+type JSONStream struct {
+	buf   *bytes.Buffer
+	enc   *json.Encoder
+	limit int // Maximum length of the text, 0 for no limit
+}
+
+// NewJSONStream creates a stream whose elements must fit in limit bytes, or any number of bytes when limit is 0
+func NewJSONStream(limit int) *JSONStream {
+	buf := jsonBuffers.Get().(*bytes.Buffer)
+	buf.Reset()
+	return &JSONStream{buf: buf, enc: json.NewEncoder(buf), limit: limit}
+}
+
+// WriteRaw appends JSON syntax, such as an object key, without checking the size cap
+func (s *JSONStream) WriteRaw(raw string) {
+	s.buf.WriteString(raw)
+}
+
+// WriteValue appends the encoding of v without checking the size cap, for the summaries that follow a capped array
+func (s *JSONStream) WriteValue(v any) error {
+	return s.write(v, 0)
+}
+
+// WriteElement appends the element at index of an array, preceded by a comma unless it is the first one.
+// It returns ErrResultTooLarge, leaving the stream unchanged, when the element does not fit within the cap.
+func (s *JSONStream) WriteElement(index int, v any) error {
+	start := s.buf.Len()
+	if index > 0 {
+		s.buf.WriteByte(',')
+	}
+	if err := s.write(v, s.limit); err != nil {
+		s.buf.Truncate(start)
+		return err
+	}
+	return nil
+}
+
+// write encodes v straight into the buffer, failing when the buffer then exceeds limit
+func (s *JSONStream) write(v any, limit int) error {
+	if err := s.enc.Encode(v); err != nil {
+		return err
+	}
+	// Encode terminates the value with a newline that json.Marshal does not write
+	s.buf.Truncate(s.buf.Len() - 1)
+
+	if limit > 0 && s.buf.Len() > limit {
+		return ErrResultTooLarge
+	}
+	return nil
+}
+
+// Len returns the number of bytes written so far
+func (s *JSONStream) Len() int {
+	return s.buf.Len()
+}
+
+// Finish returns the text of the stream and releases its buffer, the stream cannot be used afterwards
+func (s *JSONStream) Finish() string {
+	text := s.buf.String()
+	if s.buf.Cap() <= maxPooledBuffer {
+		jsonBuffers.Put(s.buf)
+	}
+	s.buf, s.enc = nil, nil
+	return text
+}
+
+// WriteArray appends the items as a JSON array, stopping at the first item that does not fit within the
+// size cap. It returns the number of items written.
+func WriteArray[T any](s *JSONStream, items []T) (int, error) {
+	s.WriteRaw("[")
+	defer s.WriteRaw("]")
+
+	for i, item := range items {
+		if err := s.WriteElement(i, item); err != nil {
+			if errors.Is(err, ErrResultTooLarge) {
+				return i, nil
+			}
+			return i, err
+		}
+	}
+	return len(items), nil
+}
@@ -0,0 +1,71 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	for _, v := range []any{
+		nil,
+		[]any{},
+		map[string]any{"b": "<tag> &  ", "a": []int{1, 2}},
+		strings.Repeat("x", 2*maxPooledBuffer),
+	} {
+		expected, err := json.Marshal(v)
+		require.NoError(t, err)
+
+		// Twice, to encode with a reused buffer
+		for i := 0; i < 2; i++ {
+			actual, err := MarshalJSON(v)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), actual)
+		}
+	}
+
+	_, err := MarshalJSON(math.Inf(1))
+	assert.Error(t, err)
+}
+
+func TestJSONStream(t *testing.T) {
+	stream := NewJSONStream(20)
+	stream.WriteRaw(`{"items":`)
+	written, err := WriteArray(stream, []string{"abc", "def", "ghi"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, written)
+
+	// Summaries are written past the cap
+	stream.WriteRaw(`,"total":`)
+	require.NoError(t, stream.WriteValue(3))
+	stream.WriteRaw("}")
+	assert.JSONEq(t, `{"items":["abc"],"total":3}`, stream.Finish())
+
+	// Without a cap, the array matches json.Marshal
+	stream = NewJSONStream(0)
+	items := []map[string]any{{"a": "<b>"}, {}}
+	written, err = WriteArray(stream, items)
+	require.NoError(t, err)
+	assert.Equal(t, 2, written)
+	expected, err := json.Marshal(items)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), stream.Finish())
+}
+
+func TestLoadMaxResultBytesFromEnv(t *testing.T) {
+	t.Setenv(MaxResultBytesEnv, "1024")
+	limit, ok := LoadMaxResultBytesFromEnv()
+	assert.True(t, ok)
+	assert.Equal(t, 1024, limit)
+
+	t.Setenv(MaxResultBytesEnv, "-1")
+	_, ok = LoadMaxResultBytesFromEnv()
+	assert.False(t, ok)
+}
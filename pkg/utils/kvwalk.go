@@ -0,0 +1,61 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault/api"
+)
+
+// MaxListedFolders bounds the folders listed by a walk of a KV tree, so that deeply nested or cyclic
+// folders cannot keep the walk going without ever reaching the secret limit of the caller
+var MaxListedFolders = 10000
+
+// WalkSecretPaths visits the path of every secret in the folders under a listing path, relative to it, until
+// visit returns false or MaxListedFolders folders were listed. It reports whether the walk stopped before
+// visiting every secret. The listing path is the mount of a KV v1 secrets engine or the metadata path of a
+// KV v2 one, optionally followed by a folder.
+func WalkSecretPaths(vault *api.Client, listPath string, visit func(path string) (bool, error)) (truncated bool, err error) {
+	listPath = strings.TrimSuffix(listPath, "/")
+
+	pending := []string{""}
+	for listed := 0; len(pending) > 0; listed++ {
+		if listed >= MaxListedFolders {
+			return true, nil
+		}
+		prefix := pending[0]
+		pending = pending[1:]
+
+		secret, err := vault.Logical().List(fmt.Sprintf("%s/%s", listPath, prefix))
+		if err != nil {
+			return false, err
+		}
+		if secret == nil || secret.Data == nil {
+			continue
+		}
+
+		keys, _ := secret.Data["keys"].([]interface{})
+		for _, rawKey := range keys {
+			key, ok := rawKey.(string)
+			if !ok {
+				continue
+			}
+			if strings.HasSuffix(key, "/") {
+				pending = append(pending, prefix+key)
+				continue
+			}
+			more, err := visit(prefix + key)
+			if err != nil {
+				return false, err
+			}
+			if !more {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
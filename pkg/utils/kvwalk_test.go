@@ -0,0 +1,80 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newWalkVault(t *testing.T, folders map[string][]string) *api.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/v1/")
+		keys, ok := folders[strings.TrimSuffix(path, "/")]
+		if !ok {
+			// Every folder not listed holds the folder 'loop/' again
+			keys = []string{"loop/"}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"keys": keys}})
+	}))
+	t.Cleanup(server.Close)
+
+	config := api.DefaultConfig()
+	config.Address = server.URL
+	config.MaxRetries = 0
+	vault, err := api.NewClient(config)
+	require.NoError(t, err)
+	return vault
+}
+
+func TestWalkSecretPaths(t *testing.T) {
+	vault := newWalkVault(t, map[string][]string{
+		"secret/metadata":           {"app/", "root"},
+		"secret/metadata/app":       {"db", "cache", "empty/"},
+		"secret/metadata/app/empty": {},
+	})
+
+	var paths []string
+	collect := func(path string) (bool, error) {
+		paths = append(paths, path)
+		return true, nil
+	}
+	truncated, err := WalkSecretPaths(vault, "secret/metadata/", collect)
+	require.NoError(t, err)
+	assert.False(t, truncated)
+	assert.Equal(t, []string{"root", "app/db", "app/cache"}, paths)
+
+	paths = nil
+	truncated, err = WalkSecretPaths(vault, "secret/metadata", func(path string) (bool, error) {
+		paths = append(paths, path)
+		return len(paths) < 2, nil
+	})
+	require.NoError(t, err)
+	assert.True(t, truncated, "the walk stops when visit returns false")
+	assert.Equal(t, []string{"root", "app/db"}, paths)
+}
+
+func TestWalkSecretPaths_BoundsFolders(t *testing.T) {
+	defer func(max int) { MaxListedFolders = max }(MaxListedFolders)
+	MaxListedFolders = 5
+
+	listed := 0
+	vault := newWalkVault(t, nil)
+	truncated, err := WalkSecretPaths(vault, "cyclic", func(string) (bool, error) {
+		listed++
+		return true, nil
+	})
+	require.NoError(t, err)
+	assert.True(t, truncated, "a cyclic tree without secrets still ends")
+	assert.Zero(t, listed)
+}
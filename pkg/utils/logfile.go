@@ -0,0 +1,168 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat is the timestamp in the names of rotated files, without colons so that it is valid on Windows
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// LogRotation configures the rotation and retention of a log file
+type LogRotation struct {
+	MaxSize    int64         // Rotate once the file would grow beyond this many bytes, 0 disables size based rotation
+	Interval   time.Duration // Rotate once the file has been written to for this long, 0 disables time based rotation
+	MaxBackups int           // Number of rotated files kept, 0 keeps every rotated file
+	MaxAge     time.Duration // Rotated files older than this are deleted, 0 keeps them regardless of their age
+}
+
+// RotatingFile is a log file that is renamed to <name>-<timestamp><ext> and replaced by a new file once it
+// grows too large or too old. Rotated files beyond the retention limits are deleted after every rotation.
+type RotatingFile struct {
+	path     string
+	rotation LogRotation
+	now      func() time.Time
+
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	opened time.Time
+}
+
+// OpenRotatingFile opens the log file at path for appending, creating it if needed
+func OpenRotatingFile(path string, rotation LogRotation) (*RotatingFile, error) {
+	f := &RotatingFile{path: path, rotation: rotation, now: time.Now}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.opened = f.now()
+	return nil
+}
+
+// Write appends p to the log file, rotating the file first when p would exceed the rotation limits
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.shouldRotate(len(p)) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close closes the log file
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
+
+// shouldRotate reports whether writing size bytes requires a new file. An empty file is never rotated,
+// so that a single entry larger than MaxSize is still written.
+func (f *RotatingFile) shouldRotate(size int) bool {
+	if f.size == 0 {
+		return false
+	}
+	if f.rotation.MaxSize > 0 && f.size+int64(size) > f.rotation.MaxSize {
+		return true
+	}
+	return f.rotation.Interval > 0 && f.now().Sub(f.opened) >= f.rotation.Interval
+}
+
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+
+	prefix, ext := f.backupName()
+	backup := prefix + f.now().UTC().Format(backupTimeFormat) + ext
+	if err := os.Rename(f.path, backup); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	// A file that cannot be deleted is retried after the next rotation
+	_ = f.prune()
+	return nil
+}
+
+// backupName returns the parts of the rotated file names before and after the timestamp
+func (f *RotatingFile) backupName() (string, string) {
+	ext := filepath.Ext(f.path)
+	return strings.TrimSuffix(f.path, ext) + "-", ext
+}
+
+// prune deletes the rotated files beyond MaxBackups or older than MaxAge
+func (f *RotatingFile) prune() error {
+	if f.rotation.MaxBackups <= 0 && f.rotation.MaxAge <= 0 {
+		return nil
+	}
+
+	prefix, ext := f.backupName()
+	matches, err := filepath.Glob(prefix + "*" + ext)
+	if err != nil {
+		return err
+	}
+
+	type backup struct {
+		path    string
+		rotated time.Time
+	}
+	var backups []backup
+	for _, match := range matches {
+		rotated, err := time.Parse(backupTimeFormat, strings.TrimSuffix(strings.TrimPrefix(match, prefix), ext))
+		if err != nil {
+			// Not a file rotated by us
+			continue
+		}
+		backups = append(backups, backup{path: match, rotated: rotated})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].rotated.After(backups[j].rotated)
+	})
+
+	var errs []string
+	for i, b := range backups {
+		expired := f.rotation.MaxAge > 0 && f.now().Sub(b.rotated) > f.rotation.MaxAge
+		if (f.rotation.MaxBackups > 0 && i >= f.rotation.MaxBackups) || expired {
+			if err := os.Remove(b.path); err != nil {
+				errs = append(errs, err.Error())
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to delete rotated log files: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "vault-mcp.log")
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	file, err := OpenRotatingFile(path, LogRotation{MaxSize: 10, Interval: time.Hour, MaxBackups: 2, MaxAge: 24 * time.Hour})
+	require.NoError(t, err)
+	defer file.Close()
+	file.now = func() time.Time { return now }
+	file.opened = now
+
+	backups := func() []string {
+		matches, err := filepath.Glob(filepath.Join(dir, "vault-mcp-*.log"))
+		require.NoError(t, err)
+		sort.Strings(matches)
+		return matches
+	}
+
+	// An entry larger than MaxSize is written to an empty file
+	_, err = file.Write([]byte("0123456789ab"))
+	require.NoError(t, err)
+	assert.Empty(t, backups())
+
+	// The next entry rotates by size
+	now = now.Add(time.Second)
+	_, err = file.Write([]byte("second"))
+	require.NoError(t, err)
+	require.Len(t, backups(), 1)
+	data, err := os.ReadFile(backups()[0])
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789ab", string(data))
+
+	// Then by time
+	now = now.Add(time.Hour)
+	_, err = file.Write([]byte("third"))
+	require.NoError(t, err)
+	assert.Len(t, backups(), 2)
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "third", string(data))
+
+	// Only MaxBackups rotated files are kept
+	now = now.Add(time.Hour)
+	_, err = file.Write([]byte("fourth"))
+	require.NoError(t, err)
+	require.Len(t, backups(), 2)
+	data, err = os.ReadFile(backups()[1])
+	require.NoError(t, err)
+	assert.Equal(t, "third", string(data))
+
+	// Rotated files older than MaxAge are deleted, files that were not rotated by us are left alone
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "vault-mcp-other.log"), nil, 0600))
+	now = now.Add(25 * time.Hour)
+	_, err = file.Write([]byte("fifth"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "vault-mcp-"+now.Format(backupTimeFormat)+".log"),
+		filepath.Join(dir, "vault-mcp-other.log"),
+	}, backups())
+}
+
+func TestRotatingFileAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vault-mcp.log")
+	require.NoError(t, os.WriteFile(path, []byte("existing\n"), 0600))
+
+	file, err := OpenRotatingFile(path, LogRotation{})
+	require.NoError(t, err)
+	_, err = file.Write([]byte("appended\n"))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "existing\nappended\n", string(data))
+}
@@ -0,0 +1,31 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// NotifyProgress sends a progress notification for a tool call. Nothing is sent when the
+// client did not ask for progress by setting a progress token on the request.
+func NotifyProgress(ctx context.Context, req mcp.CallToolRequest, progress, total float64, message string) error {
+	if req.Params.Meta == nil || req.Params.Meta.ProgressToken == nil {
+		return nil
+	}
+
+	srv := server.ServerFromContext(ctx)
+	if srv == nil {
+		return nil
+	}
+
+	return srv.SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+		"progressToken": req.Params.Meta.ProgressToken,
+		"progress":      progress,
+		"total":         total,
+		"message":       message,
+	})
+}
@@ -0,0 +1,103 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// SecretReferenceScheme is the scheme of references to secret values
+const SecretReferenceScheme = "vault://"
+
+// Outputs of the tools that return secret values
+const (
+	OutputValue     = "value"     // The secret values themselves
+	OutputReference = "reference" // References to the values, resolved with resolve_reference
+)
+
+// SecretReference locates a value in Vault: the key of the data at a path, and for KV v2 secrets the version
+type SecretReference struct {
+	Path    string // Path of the secret including its mount, without the data/ section of KV v2 mounts
+	Key     string
+	Version int // Version of a KV v2 secret, 0 for the current version
+}
+
+// String returns the canonical form of the reference, vault://mount/path#key, with ?version=n when the
+// reference is pinned to a version
+func (r SecretReference) String() string {
+	var sb strings.Builder
+	sb.WriteString(SecretReferenceScheme)
+	sb.WriteString(r.Path)
+	if r.Version > 0 {
+		sb.WriteString("?version=")
+		sb.WriteString(strconv.Itoa(r.Version))
+	}
+	sb.WriteString("#")
+	sb.WriteString(url.PathEscape(r.Key))
+	return sb.String()
+}
+
+// ExtractOutput returns the requested output of a tool returning secret values, defaulting to the values
+func ExtractOutput(args map[string]any) (string, error) {
+	output, ok := args["output"].(string)
+	if !ok || output == "" {
+		return OutputValue, nil
+	}
+
+	switch output {
+	case OutputValue, OutputReference:
+		return output, nil
+	default:
+		return "", fmt.Errorf("invalid 'output' parameter '%s', must be 'value' or 'reference'", output)
+	}
+}
+
+// ParseSecretReference parses a reference in the canonical form vault://mount/path#key, optionally pinned to
+// a KV v2 version with ?version=n
+func ParseSecretReference(reference string) (SecretReference, error) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(reference), SecretReferenceScheme)
+	if !ok {
+		return SecretReference{}, fmt.Errorf("invalid reference '%s', expected vault://mount/path#key", reference)
+	}
+	rest, key, ok := strings.Cut(rest, "#")
+	if !ok || key == "" {
+		return SecretReference{}, fmt.Errorf("invalid reference '%s', the key is missing after '#'", reference)
+	}
+	key, err := url.PathUnescape(key)
+	if err != nil {
+		return SecretReference{}, fmt.Errorf("invalid reference '%s': %v", reference, err)
+	}
+	path, query, _ := strings.Cut(rest, "?")
+	path = strings.Trim(path, "/")
+
+	parsed := SecretReference{Path: path, Key: key}
+	if !strings.Contains(path, "/") {
+		return SecretReference{}, fmt.Errorf("invalid reference '%s', expected a mount and a path", reference)
+	}
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return SecretReference{}, fmt.Errorf("invalid reference '%s', the path has an empty or relative segment", reference)
+		}
+	}
+	if query != "" {
+		values, err := url.ParseQuery(query)
+		if err != nil {
+			return SecretReference{}, fmt.Errorf("invalid reference '%s': %v", reference, err)
+		}
+		for name := range values {
+			if name != "version" {
+				return SecretReference{}, fmt.Errorf("invalid reference '%s', unknown parameter '%s'", reference, name)
+			}
+		}
+		version, err := strconv.Atoi(values.Get("version"))
+		if err != nil || version < 1 {
+			return SecretReference{}, fmt.Errorf("invalid reference '%s', the version must be a positive number", reference)
+		}
+		parsed.Version = version
+	}
+	return parsed, nil
+}
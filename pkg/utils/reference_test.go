@@ -0,0 +1,51 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretReference(t *testing.T) {
+	reference := SecretReference{Path: "secret/app/db", Key: "password"}
+	assert.Equal(t, "vault://secret/app/db#password", reference.String())
+
+	pinned := SecretReference{Path: "secret/app/db", Key: "api key#2", Version: 3}
+	assert.Equal(t, "vault://secret/app/db?version=3#api%20key%232", pinned.String())
+
+	for _, r := range []SecretReference{reference, pinned} {
+		parsed, err := ParseSecretReference(r.String())
+		require.NoError(t, err)
+		assert.Equal(t, r, parsed)
+	}
+
+	for _, invalid := range []string{
+		"secret/app#password",
+		"vault://secret/app",
+		"vault://secret/app#",
+		"vault://secret#password",
+		"vault://secret/../sys/config#password",
+		"vault://secret/app?version=0#password",
+		"vault://secret/app?ttl=1h#password",
+	} {
+		_, err := ParseSecretReference(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestExtractOutput(t *testing.T) {
+	output, err := ExtractOutput(nil)
+	require.NoError(t, err)
+	assert.Equal(t, OutputValue, output)
+
+	output, err = ExtractOutput(map[string]any{"output": "reference"})
+	require.NoError(t, err)
+	assert.Equal(t, OutputReference, output)
+
+	_, err = ExtractOutput(map[string]any{"output": "plaintext"})
+	assert.Error(t, err)
+}
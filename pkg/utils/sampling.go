@@ -0,0 +1,46 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ErrSamplingUnavailable is returned when the client of the session cannot be asked to sample a message
+var ErrSamplingUnavailable = errors.New("client does not support sampling")
+
+// RequestCompletion asks the model of the client of the current session to answer a prompt through sampling.
+// It returns the text of the answer and the name of the model, or ErrSamplingUnavailable when the client
+// did not declare the sampling capability.
+func RequestCompletion(ctx context.Context, systemPrompt, prompt string, maxTokens int) (string, string, error) {
+	session := server.ClientSessionFromContext(ctx)
+	if info, ok := session.(server.SessionWithClientInfo); !ok || info.GetClientCapabilities().Sampling == nil {
+		return "", "", ErrSamplingUnavailable
+	}
+
+	sampler, ok := session.(server.SessionWithSampling)
+	if !ok {
+		return "", "", ErrSamplingUnavailable
+	}
+
+	result, err := sampler.RequestSampling(ctx, mcp.CreateMessageRequest{
+		Request: mcp.Request{Method: string(mcp.MethodSamplingCreateMessage)},
+		CreateMessageParams: mcp.CreateMessageParams{
+			Messages: []mcp.SamplingMessage{
+				{Role: mcp.RoleUser, Content: mcp.NewTextContent(prompt)},
+			},
+			SystemPrompt: systemPrompt,
+			MaxTokens:    maxTokens,
+		},
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	return mcp.GetTextFromContent(result.Content), result.Model, nil
+}
@@ -0,0 +1,114 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ListBlockSize is the number of items in every content block of a streamed listing
+var ListBlockSize = 500
+
+// ListWriter splits a large listing into several content blocks, each holding a JSON array of at most
+// ListBlockSize items, instead of one giant text blob. Every completed block is announced with a progress
+// notification, which the StreamableHTTP transport streams to the client while the listing continues.
+// Items are encoded as they are added, and the listing stops once its blocks reach MaxResultBytes.
+type ListWriter struct {
+	ctx       context.Context
+	req       mcp.CallToolRequest
+	total     int // Expected number of items, 0 when unknown
+	blocks    []mcp.Content
+	block     *JSONStream // Block being written, nil until the first item
+	inBlock   int         // Number of items in the block being written
+	count     int
+	size      int // Size of the completed blocks
+	truncated bool
+}
+
+// NewListWriter creates a writer for the listing of a tool call, total is the expected number of items or 0
+func NewListWriter(ctx context.Context, req mcp.CallToolRequest, total int) *ListWriter {
+	return &ListWriter{ctx: ctx, req: req, total: total}
+}
+
+// Add appends an item to the listing, emitting a content block when it is full. It returns ErrResultTooLarge
+// once the listing reached MaxResultBytes, the listing is then marked as truncated.
+func (w *ListWriter) Add(item any) error {
+	// A block is only emitted once the next item arrives, so listings of up to a single block are never split
+	if w.inBlock == ListBlockSize {
+		w.flush()
+	}
+	if w.block == nil {
+		w.block = NewJSONStream(max(MaxResultBytes-w.size, 1))
+		w.block.WriteRaw("[")
+	}
+
+	if err := w.block.WriteElement(w.inBlock, item); err != nil {
+		if errors.Is(err, ErrResultTooLarge) {
+			w.truncated = true
+		}
+		return err
+	}
+	w.inBlock++
+	w.count++
+	return nil
+}
+
+// Count returns the number of items added so far
+func (w *ListWriter) Count() int {
+	return w.count
+}
+
+// Truncated reports whether items were left out because the listing reached MaxResultBytes
+func (w *ListWriter) Truncated() bool {
+	return w.truncated
+}
+
+// Streamed reports whether the listing did not fit in a single block. Tools return their usual
+// single result for listings that were not streamed. Truncated listings are always streamed, so
+// that their summary reaches the client.
+func (w *ListWriter) Streamed() bool {
+	return w.count > ListBlockSize || w.truncated
+}
+
+// Result returns the content blocks of the listing followed by a final summary block. A listing that
+// was not streamed is returned as a single JSON array without a summary.
+func (w *ListWriter) Result(summary any) (*mcp.CallToolResult, error) {
+	if !w.Streamed() {
+		if w.block == nil {
+			return mcp.NewToolResultText("[]"), nil
+		}
+		w.block.WriteRaw("]")
+		return mcp.NewToolResultText(w.block.Finish()), nil
+	}
+
+	if w.inBlock > 0 {
+		w.flush()
+	}
+
+	jsonData, err := MarshalJSON(summary)
+	if err != nil {
+		return nil, err
+	}
+
+	content := make([]mcp.Content, 0, len(w.blocks)+1)
+	content = append(content, w.blocks...)
+	content = append(content, mcp.NewTextContent(jsonData))
+	return &mcp.CallToolResult{Content: content}, nil
+}
+
+// flush emits the block being written
+func (w *ListWriter) flush() {
+	w.block.WriteRaw("]")
+	w.size += w.block.Len()
+	w.blocks = append(w.blocks, mcp.NewTextContent(w.block.Finish()))
+	w.block = nil
+	w.inBlock = 0
+
+	// A client that cannot be notified still receives every block in the result
+	_ = NotifyProgress(w.ctx, w.req, float64(w.count), float64(w.total), fmt.Sprintf("Listed %d items", w.count))
+}
@@ -0,0 +1,77 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListWriter(t *testing.T) {
+	previous := ListBlockSize
+	ListBlockSize = 3
+	defer func() { ListBlockSize = previous }()
+
+	texts := func(result *mcp.CallToolResult) []string {
+		var texts []string
+		for _, content := range result.Content {
+			text, ok := mcp.AsTextContent(content)
+			require.True(t, ok)
+			texts = append(texts, text.Text)
+		}
+		return texts
+	}
+
+	// An empty listing is an empty array
+	writer := NewListWriter(context.Background(), mcp.CallToolRequest{}, 0)
+	result, err := writer.Result(map[string]int{"total": 0})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"[]"}, texts(result))
+
+	// A full block is not split
+	writer = NewListWriter(context.Background(), mcp.CallToolRequest{}, 0)
+	for _, item := range []string{"a", "b", "c"} {
+		require.NoError(t, writer.Add(item))
+	}
+	assert.False(t, writer.Streamed())
+	result, err = writer.Result(map[string]int{"total": 3})
+	require.NoError(t, err)
+	assert.Equal(t, []string{`["a","b","c"]`}, texts(result))
+
+	// Larger listings are split into blocks followed by the summary
+	writer = NewListWriter(context.Background(), mcp.CallToolRequest{}, 0)
+	for _, item := range []string{"a", "b", "c", "d"} {
+		require.NoError(t, writer.Add(item))
+	}
+	assert.True(t, writer.Streamed())
+	assert.Equal(t, 4, writer.Count())
+	result, err = writer.Result(map[string]int{"total": 4})
+	require.NoError(t, err)
+	assert.Equal(t, []string{`["a","b","c"]`, `["d"]`, `{"total":4}`}, texts(result))
+}
+
+func TestListWriterMaxResultBytes(t *testing.T) {
+	previous := MaxResultBytes
+	MaxResultBytes = 16
+	defer func() { MaxResultBytes = previous }()
+
+	writer := NewListWriter(context.Background(), mcp.CallToolRequest{}, 0)
+	require.NoError(t, writer.Add("aaaa"))
+	require.NoError(t, writer.Add("bbbb"))
+	assert.ErrorIs(t, writer.Add("cccc"), ErrResultTooLarge)
+	assert.True(t, writer.Truncated())
+	assert.Equal(t, 2, writer.Count())
+
+	// A truncated listing always carries its summary
+	assert.True(t, writer.Streamed())
+	result, err := writer.Result(map[string]bool{"truncated": true})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 2)
+	assert.Equal(t, `["aaaa","bbbb"]`, result.Content[0].(mcp.TextContent).Text)
+	assert.Equal(t, `{"truncated":true}`, result.Content[1].(mcp.TextContent).Text)
+}
@@ -20,3 +20,14 @@ func ExtractMountPath(args map[string]any) (string, error) {
 func ToBoolPtr(b bool) *bool {
 	return &b
 }
+
+// SplitList splits a comma-separated list into its trimmed, non-empty items
+func SplitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
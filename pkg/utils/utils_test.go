@@ -0,0 +1,17 @@
+// Copyright IBM Corp. 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitList(t *testing.T) {
+	assert.Equal(t, []string{"default", "dev-team", "ops"}, SplitList(" default,dev-team ,, ops,"))
+	assert.Equal(t, []string{"*"}, SplitList("*"))
+	assert.Nil(t, SplitList(""))
+	assert.Nil(t, SplitList(" , "))
+}